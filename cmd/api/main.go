@@ -8,16 +8,28 @@ import (
 	"github.com/labstack/echo/v4"
 	"github.com/labstack/echo/v4/middleware"
 
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/adapters/blobstore"
 	httpAdapter "github.com/LucasSiedschlag/HausHaltsMeister/internal/adapters/http"
 	"github.com/LucasSiedschlag/HausHaltsMeister/internal/adapters/postgres"
 	"github.com/LucasSiedschlag/HausHaltsMeister/internal/config"
 	"github.com/LucasSiedschlag/HausHaltsMeister/internal/db"
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/domain/account"
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/domain/attachment"
 	"github.com/LucasSiedschlag/HausHaltsMeister/internal/domain/budget"
 	"github.com/LucasSiedschlag/HausHaltsMeister/internal/domain/cashflow"
 	"github.com/LucasSiedschlag/HausHaltsMeister/internal/domain/category"
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/domain/exchange"
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/domain/importer"
 	"github.com/LucasSiedschlag/HausHaltsMeister/internal/domain/installment"
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/domain/payee"
 	"github.com/LucasSiedschlag/HausHaltsMeister/internal/domain/payment"
 	"github.com/LucasSiedschlag/HausHaltsMeister/internal/domain/picuinha"
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/domain/reconciliation"
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/domain/recurrence"
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/domain/report"
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/domain/rule"
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/domain/tag"
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/domain/transfer"
 )
 
 // @title           HausHaltsMeister API
@@ -42,6 +54,13 @@ import (
 func main() {
 	// 1. Load config
 	cfg := config.Load()
+	baseCurrency, err := exchange.NormalizeCurrency(cfg.BaseCurrency)
+	if err != nil {
+		log.Fatalf("Invalid BASE_CURRENCY %q: %v", cfg.BaseCurrency, err)
+	}
+	if cfg.DuplicateDays < 0 || cfg.DuplicateDays > cashflow.MaxDuplicateDays {
+		log.Fatalf("Invalid DUPLICATE_DAYS %d: %v", cfg.DuplicateDays, cashflow.ErrInvalidDays)
+	}
 
 	// 2. Setup DB
 	ctx := context.Background()
@@ -58,14 +77,49 @@ func main() {
 	picRepo := postgres.NewPicuinhaRepository(pool)
 	payRepo := postgres.NewPaymentRepository(pool)
 	instRepo := postgres.NewInstallmentRepository(pool)
+	impRepo := postgres.NewImportRepository(pool)
+	recRepo := postgres.NewRecurrenceRepository(pool)
+	tagRepo := postgres.NewTagRepository(pool)
+	attRepo := postgres.NewAttachmentRepository(pool)
+	accRepo := postgres.NewAccountRepository(pool)
+	trRepo := postgres.NewTransferRepository(pool)
+	reconRepo := postgres.NewReconciliationRepository(pool)
+	exRepo := postgres.NewExchangeRepository(pool)
+	payeeRepo := postgres.NewPayeeRepository(pool)
+	ruleRepo := postgres.NewRuleRepository(pool)
+	reportRepo := postgres.NewReportRepository(pool)
+	blobs, err := blobstore.NewLocalStore(cfg.AttachmentsDir)
+	if err != nil {
+		log.Fatalf("Unable to open attachments store: %v", err)
+	}
 
 	// 4. Setup services
 	catService := category.NewService(catRepo)
 	cfService := cashflow.NewService(cfRepo, catRepo)
+	cfService.SetCurrency(baseCurrency, cfg.IOFRate)
+	cfService.SetDuplicateDays(cfg.DuplicateDays)
 	bgService := budget.NewService(bgRepo, catRepo, cfRepo)
 	picService := picuinha.NewService(picRepo)
 	payService := payment.NewService(payRepo)
 	instService := installment.NewService(instRepo, cfService, payRepo)
+	impService := importer.NewService(impRepo, cfService, payRepo)
+	recService := recurrence.NewService(recRepo, cfService, catRepo, payRepo)
+	tagService := tag.NewService(tagRepo, cfRepo)
+	attService := attachment.NewService(attRepo, blobs, cfRepo, cfg.AttachmentMaxBytes)
+	if removed, err := attService.Prune(ctx); err != nil {
+		log.Printf("Unable to prune attachments: %v", err)
+	} else if removed > 0 {
+		log.Printf("Pruned %d orphaned attachment files", removed)
+	}
+	accService := account.NewService(accRepo, payRepo)
+	trService := transfer.NewService(trRepo, cfRepo, accRepo)
+	reconService := reconciliation.NewService(reconRepo, accRepo, payRepo)
+	exService := exchange.NewService(exRepo, baseCurrency)
+	payeeService := payee.NewService(payeeRepo, catRepo, payRepo)
+	ruleService := rule.NewService(ruleRepo, cfService, catRepo, payRepo, tagRepo)
+	cfService.SetRules(ruleService)
+	reportService := report.NewService(reportRepo, catRepo, recRepo)
+	statementService := report.NewStatementService(cfService, bgService, payService, picService)
 
 	// 5. Setup handlers
 	catHandler := httpAdapter.NewCategoryHandler(catService)
@@ -74,6 +128,18 @@ func main() {
 	picHandler := httpAdapter.NewPicuinhaHandler(picService)
 	payHandler := httpAdapter.NewPaymentHandler(payService)
 	instHandler := httpAdapter.NewInstallmentHandler(instService)
+	impHandler := httpAdapter.NewImportHandler(impService)
+	recHandler := httpAdapter.NewRecurrenceHandler(recService)
+	tagHandler := httpAdapter.NewTagHandler(tagService)
+	attHandler := httpAdapter.NewAttachmentHandler(attService)
+	accHandler := httpAdapter.NewAccountHandler(accService)
+	trHandler := httpAdapter.NewTransferHandler(trService)
+	reconHandler := httpAdapter.NewReconciliationHandler(reconService)
+	exHandler := httpAdapter.NewExchangeHandler(exService)
+	payeeHandler := httpAdapter.NewPayeeHandler(payeeService)
+	ruleHandler := httpAdapter.NewRuleHandler(ruleService)
+	reportHandler := httpAdapter.NewReportHandler(reportService)
+	statementHandler := httpAdapter.NewStatementHandler(statementService)
 
 	// 6. Setup Echo
 	e := echo.New()
@@ -87,6 +153,18 @@ func main() {
 	httpAdapter.RegisterPicuinhaRoutes(e, picHandler)
 	httpAdapter.RegisterPaymentRoutes(e, payHandler)
 	httpAdapter.RegisterInstallmentRoutes(e, instHandler)
+	httpAdapter.RegisterImportRoutes(e, impHandler)
+	httpAdapter.RegisterRecurrenceRoutes(e, recHandler)
+	httpAdapter.RegisterTagRoutes(e, tagHandler)
+	httpAdapter.RegisterAttachmentRoutes(e, attHandler)
+	httpAdapter.RegisterAccountRoutes(e, accHandler)
+	httpAdapter.RegisterTransferRoutes(e, trHandler)
+	httpAdapter.RegisterReconciliationRoutes(e, reconHandler)
+	httpAdapter.RegisterExchangeRoutes(e, exHandler)
+	httpAdapter.RegisterPayeeRoutes(e, payeeHandler)
+	httpAdapter.RegisterRuleRoutes(e, ruleHandler)
+	httpAdapter.RegisterReportRoutes(e, reportHandler)
+	httpAdapter.RegisterStatementRoutes(e, statementHandler)
 	httpAdapter.RegisterSwaggerRoutes(e)
 
 	// 8. Start server
@@ -0,0 +1,82 @@
+-- name: CreateAccount :one
+INSERT INTO accounts (name, kind, institution, opening_balance, opening_date, is_active)
+VALUES ($1, $2, $3, $4, $5, $6)
+RETURNING account_id, name, kind, institution, opening_balance, opening_date, is_active, created_at;
+
+-- name: ListAccounts :many
+SELECT account_id, name, kind, institution, opening_balance, opening_date, is_active, created_at
+FROM accounts
+WHERE (sqlc.narg('is_active')::boolean IS NULL OR is_active = sqlc.narg('is_active'))
+ORDER BY name;
+
+-- name: GetAccount :one
+SELECT account_id, name, kind, institution, opening_balance, opening_date, is_active, created_at
+FROM accounts
+WHERE account_id = $1;
+
+-- name: GetAccountByName :one
+SELECT account_id, name, kind, institution, opening_balance, opening_date, is_active, created_at
+FROM accounts
+WHERE lower(name) = lower(sqlc.arg(name)::text);
+
+-- name: UpdateAccount :one
+UPDATE accounts
+SET name = $2,
+    kind = $3,
+    institution = $4,
+    opening_balance = $5,
+    opening_date = $6,
+    is_active = $7
+WHERE account_id = $1
+RETURNING account_id, name, kind, institution, opening_balance, opening_date, is_active, created_at;
+
+-- name: DeleteAccount :exec
+DELETE FROM accounts
+WHERE account_id = $1;
+
+-- name: CountAccountLinks :one
+SELECT
+  (SELECT COUNT(*) FROM cash_flows cf WHERE cf.account_id = sqlc.arg(account_id)::int)
+  + (SELECT COUNT(*) FROM payment_methods pm WHERE pm.account_id = sqlc.arg(account_id)::int) AS link_count;
+
+-- name: AccountExists :one
+SELECT EXISTS (
+  SELECT 1 FROM accounts WHERE account_id = $1
+);
+
+-- name: SetPaymentMethodAccount :execrows
+UPDATE payment_methods
+SET account_id = $2
+WHERE payment_method_id = $1;
+
+-- name: GetAccountBalances :many
+SELECT
+  a.account_id,
+  a.name,
+  a.kind,
+  a.is_active,
+  (CASE
+    WHEN a.opening_date > sqlc.arg(as_of)::date THEN 0
+    ELSE a.opening_balance + COALESCE(SUM(CASE WHEN cf.direction = 'IN' THEN cf.amount ELSE -cf.amount END), 0)
+  END)::numeric AS balance
+FROM accounts a
+LEFT JOIN cash_flows cf ON cf.account_id = a.account_id
+  AND cf.date >= a.opening_date
+  AND cf.date <= sqlc.arg(as_of)::date
+  AND cf.status = 'REALIZED'
+GROUP BY a.account_id
+ORDER BY a.name;
+
+-- name: ListAccountDailyTotals :many
+SELECT
+  cf.date,
+  COALESCE(SUM(CASE WHEN cf.direction = 'IN' THEN cf.amount ELSE 0 END), 0)::numeric AS total_income,
+  COALESCE(SUM(CASE WHEN cf.direction = 'OUT' THEN cf.amount ELSE 0 END), 0)::numeric AS total_expense
+FROM cash_flows cf
+JOIN accounts a ON a.account_id = cf.account_id
+WHERE a.account_id = sqlc.arg(account_id)
+  AND cf.date >= a.opening_date
+  AND cf.date <= sqlc.arg(to_date)::date
+  AND cf.status = 'REALIZED'
+GROUP BY cf.date
+ORDER BY cf.date;
@@ -0,0 +1,45 @@
+-- name: CreateAttachment :one
+INSERT INTO attachments (cash_flow_id, installment_plan_id, file_name, content_type, size_bytes, sha256)
+VALUES ($1, $2, $3, $4, $5, $6)
+RETURNING attachment_id, cash_flow_id, installment_plan_id, file_name, content_type, size_bytes, sha256, created_at;
+
+-- name: GetAttachment :one
+SELECT attachment_id, cash_flow_id, installment_plan_id, file_name, content_type, size_bytes, sha256, created_at
+FROM attachments
+WHERE attachment_id = $1;
+
+-- name: FindAttachment :one
+SELECT attachment_id, cash_flow_id, installment_plan_id, file_name, content_type, size_bytes, sha256, created_at
+FROM attachments
+WHERE sha256 = sqlc.arg(sha256)
+  AND cash_flow_id IS NOT DISTINCT FROM sqlc.narg(cash_flow_id)::int
+  AND installment_plan_id IS NOT DISTINCT FROM sqlc.narg(installment_plan_id)::int;
+
+-- name: ListAttachmentsByCashFlow :many
+SELECT attachment_id, cash_flow_id, installment_plan_id, file_name, content_type, size_bytes, sha256, created_at
+FROM attachments
+WHERE cash_flow_id = $1
+ORDER BY created_at, attachment_id;
+
+-- name: ListAttachmentsByInstallmentPlan :many
+SELECT attachment_id, cash_flow_id, installment_plan_id, file_name, content_type, size_bytes, sha256, created_at
+FROM attachments
+WHERE installment_plan_id = $1
+ORDER BY created_at, attachment_id;
+
+-- name: DeleteAttachment :exec
+DELETE FROM attachments
+WHERE attachment_id = $1;
+
+-- name: CountAttachmentsBySha256 :one
+SELECT COUNT(*)
+FROM attachments
+WHERE sha256 = $1;
+
+-- name: InstallmentPlanExists :one
+SELECT EXISTS (
+  SELECT 1 FROM installment_plans WHERE installment_plan_id = $1
+);
+
+-- name: LockAttachmentHash :exec
+SELECT pg_advisory_xact_lock(hashtextextended(sqlc.arg(sha256)::text, 0));
@@ -5,11 +5,21 @@ INSERT INTO cash_flows (
   direction,
   title,
   amount,
-  is_fixed
+  is_fixed,
+  fitid,
+  external_account,
+  source_cash_flow_id,
+  account_id,
+  status,
+  currency,
+  original_amount,
+  exchange_rate,
+  iof_amount,
+  payee_id
 ) VALUES (
-  $1, $2, $3, $4, $5, $6
+  $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16
 )
-RETURNING cash_flow_id, date, category_id, direction, title, amount, is_fixed;
+RETURNING cash_flow_id, date, category_id, direction, title, amount, is_fixed, fitid, external_account, source_cash_flow_id, account_id, cleared_at, reconciliation_id, status, currency, original_amount, exchange_rate, iof_amount, payee_id;
 
 -- name: ListCashFlowsByMonth :many
 SELECT
@@ -20,6 +30,15 @@ SELECT
   cf.title,
   cf.amount,
   cf.is_fixed,
+  cf.account_id,
+  cf.cleared_at,
+  cf.reconciliation_id,
+  cf.status,
+  cf.currency,
+  cf.original_amount,
+  cf.exchange_rate,
+  cf.iof_amount,
+  cf.payee_id,
   fc.name AS category_name
 FROM cash_flows cf
 JOIN flow_categories fc ON fc.category_id = cf.category_id
@@ -28,18 +47,359 @@ ORDER BY cf.date, cf.cash_flow_id;
 
 -- name: GetMonthlySummary :one
 SELECT
-  SUM(CASE WHEN direction = 'IN' THEN amount ELSE 0 END)::float AS total_income,
-  SUM(CASE WHEN direction = 'OUT' THEN amount ELSE 0 END)::float AS total_expense
-FROM cash_flows
-WHERE date_trunc('month', date) = date_trunc('month', $1::date);
+  COALESCE(SUM(CASE WHEN direction = 'IN' THEN amount ELSE 0 END), 0)::numeric AS total_income,
+  COALESCE(SUM(CASE WHEN direction = 'OUT' THEN amount ELSE 0 END), 0)::numeric AS total_expense
+FROM cash_flow_lines
+WHERE date_trunc('month', date) = date_trunc('month', sqlc.arg(month)::date)
+  AND (status = 'REALIZED' OR sqlc.arg(include_planned)::boolean);
 
 -- name: GetCategorySummary :many
 SELECT
   fc.name,
   fc.direction,
-  SUM(cf.amount)::float AS total_amount
+  SUM(cl.amount)::numeric AS total_amount
+FROM cash_flow_lines cl
+JOIN flow_categories fc ON fc.category_id = cl.category_id
+WHERE date_trunc('month', cl.date) = date_trunc('month', sqlc.arg(month)::date)
+  AND (cl.status = 'REALIZED' OR sqlc.arg(include_planned)::boolean)
+GROUP BY fc.name, fc.direction
+ORDER BY total_amount DESC;
+
+-- name: GetCategoryTotals :many
+SELECT
+  fc.category_id,
+  fc.name,
+  fc.direction,
+  SUM(cl.amount)::numeric AS total_amount
+FROM cash_flow_lines cl
+JOIN flow_categories fc ON fc.category_id = cl.category_id
+WHERE cl.date >= date_trunc('month', sqlc.arg('date_from')::date)
+  AND cl.date < date_trunc('month', sqlc.arg('date_to')::date) + interval '1 month'
+  AND (cl.status = 'REALIZED' OR sqlc.arg('include_planned')::boolean)
+GROUP BY fc.category_id, fc.name, fc.direction
+ORDER BY total_amount DESC;
+
+-- name: GetCashFlowByID :one
+SELECT
+  cf.cash_flow_id,
+  cf.date,
+  cf.category_id,
+  cf.direction,
+  cf.title,
+  cf.amount,
+  cf.is_fixed,
+  cf.account_id,
+  cf.cleared_at,
+  cf.reconciliation_id,
+  cf.status,
+  cf.currency,
+  cf.original_amount,
+  cf.exchange_rate,
+  cf.iof_amount,
+  cf.payee_id,
+  fc.name AS category_name
+FROM cash_flows cf
+JOIN flow_categories fc ON fc.category_id = cf.category_id
+WHERE cf.cash_flow_id = $1;
+
+-- name: UpdateCashFlow :one
+UPDATE cash_flows
+SET date = $2,
+    category_id = $3,
+    direction = $4,
+    title = $5,
+    amount = $6,
+    is_fixed = $7,
+    currency = $8,
+    original_amount = $9,
+    exchange_rate = $10,
+    iof_amount = $11
+WHERE cash_flow_id = $1
+RETURNING cash_flow_id, date, category_id, direction, title, amount, is_fixed, fitid, external_account, source_cash_flow_id, account_id, cleared_at, reconciliation_id, status, currency, original_amount, exchange_rate, iof_amount, payee_id;
+
+-- name: DeleteCashFlow :exec
+DELETE FROM cash_flows
+WHERE cash_flow_id = $1;
+
+-- name: DeleteExpenseDetailsByCashFlow :exec
+DELETE FROM expense_details
+WHERE cash_flow_id = $1;
+
+-- name: CountCashFlowInstallmentLinks :one
+SELECT (
+  (SELECT COUNT(*) FROM installment_plan_items ipi WHERE ipi.cash_flow_id = sqlc.arg(cash_flow_id)::int)
+  + (SELECT COUNT(*) FROM expense_details ed WHERE ed.cash_flow_id = sqlc.arg(cash_flow_id)::int AND ed.installment_plan_id IS NOT NULL)
+)::bigint AS link_count;
+
+-- name: CreateCashFlowRevision :exec
+INSERT INTO cash_flow_revisions (
+  cash_flow_id,
+  action,
+  date,
+  category_id,
+  direction,
+  title,
+  amount,
+  is_fixed,
+  currency,
+  original_amount,
+  exchange_rate,
+  iof_amount,
+  status
+) VALUES (
+  $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13
+);
+
+-- name: ListCashFlowRevisions :many
+SELECT cash_flow_revision_id, cash_flow_id, action, date, category_id, direction, title, amount, is_fixed, revised_at, currency, original_amount, exchange_rate, iof_amount, status
+FROM cash_flow_revisions
+WHERE cash_flow_id = $1
+ORDER BY revised_at DESC, cash_flow_revision_id DESC;
+
+-- name: SearchCashFlows :many
+SELECT
+  cf.cash_flow_id,
+  cf.date,
+  cf.category_id,
+  cf.direction,
+  cf.title,
+  cf.amount,
+  cf.is_fixed,
+  cf.account_id,
+  cf.cleared_at,
+  cf.reconciliation_id,
+  cf.status,
+  cf.currency,
+  cf.original_amount,
+  cf.exchange_rate,
+  cf.iof_amount,
+  cf.payee_id,
+  fc.name AS category_name
 FROM cash_flows cf
 JOIN flow_categories fc ON fc.category_id = cf.category_id
+WHERE (sqlc.narg('date_from')::date IS NULL OR cf.date >= sqlc.narg('date_from')::date)
+  AND (sqlc.narg('date_to')::date IS NULL OR cf.date <= sqlc.narg('date_to')::date)
+  AND (sqlc.narg('category_ids')::int[] IS NULL OR cf.category_id = ANY(sqlc.narg('category_ids')::int[]))
+  AND (sqlc.narg('direction')::text IS NULL OR cf.direction = sqlc.narg('direction')::text)
+  AND (sqlc.narg('title')::text IS NULL OR cf.title ILIKE '%' || sqlc.narg('title')::text || '%')
+  AND (sqlc.narg('min_amount')::numeric IS NULL OR cf.amount >= sqlc.narg('min_amount')::numeric)
+  AND (sqlc.narg('max_amount')::numeric IS NULL OR cf.amount <= sqlc.narg('max_amount')::numeric)
+  AND (sqlc.narg('is_fixed')::boolean IS NULL OR cf.is_fixed = sqlc.narg('is_fixed')::boolean)
+  AND (sqlc.narg('status')::text IS NULL OR cf.status = sqlc.narg('status')::text)
+  AND (sqlc.narg('payment_method_id')::int IS NULL OR EXISTS (
+    SELECT 1 FROM expense_details ed
+    WHERE ed.cash_flow_id = cf.cash_flow_id
+      AND ed.payment_method_id = sqlc.narg('payment_method_id')::int
+  ))
+  AND (sqlc.narg('tags')::text[] IS NULL OR EXISTS (
+    SELECT 1 FROM cash_flow_tags cft
+    JOIN tags t ON t.tag_id = cft.tag_id
+    WHERE cft.cash_flow_id = cf.cash_flow_id
+      AND t.name = ANY(sqlc.narg('tags')::text[])
+  ))
+  AND (sqlc.narg('payee_id')::int IS NULL OR cf.payee_id = sqlc.narg('payee_id')::int)
+ORDER BY
+  CASE WHEN sqlc.arg('sort_by')::text = 'date' AND NOT sqlc.arg('sort_desc')::boolean THEN cf.date END ASC,
+  CASE WHEN sqlc.arg('sort_by')::text = 'date' AND sqlc.arg('sort_desc')::boolean THEN cf.date END DESC,
+  CASE WHEN sqlc.arg('sort_by')::text = 'amount' AND NOT sqlc.arg('sort_desc')::boolean THEN cf.amount END ASC,
+  CASE WHEN sqlc.arg('sort_by')::text = 'amount' AND sqlc.arg('sort_desc')::boolean THEN cf.amount END DESC,
+  CASE WHEN sqlc.arg('sort_by')::text = 'title' AND NOT sqlc.arg('sort_desc')::boolean THEN cf.title END ASC,
+  CASE WHEN sqlc.arg('sort_by')::text = 'title' AND sqlc.arg('sort_desc')::boolean THEN cf.title END DESC,
+  cf.cash_flow_id
+LIMIT sqlc.arg('page_limit')::int OFFSET sqlc.arg('page_offset')::int;
+
+-- name: CountCashFlows :one
+SELECT COUNT(*)
+FROM cash_flows cf
+WHERE (sqlc.narg('date_from')::date IS NULL OR cf.date >= sqlc.narg('date_from')::date)
+  AND (sqlc.narg('date_to')::date IS NULL OR cf.date <= sqlc.narg('date_to')::date)
+  AND (sqlc.narg('category_ids')::int[] IS NULL OR cf.category_id = ANY(sqlc.narg('category_ids')::int[]))
+  AND (sqlc.narg('direction')::text IS NULL OR cf.direction = sqlc.narg('direction')::text)
+  AND (sqlc.narg('title')::text IS NULL OR cf.title ILIKE '%' || sqlc.narg('title')::text || '%')
+  AND (sqlc.narg('min_amount')::numeric IS NULL OR cf.amount >= sqlc.narg('min_amount')::numeric)
+  AND (sqlc.narg('max_amount')::numeric IS NULL OR cf.amount <= sqlc.narg('max_amount')::numeric)
+  AND (sqlc.narg('is_fixed')::boolean IS NULL OR cf.is_fixed = sqlc.narg('is_fixed')::boolean)
+  AND (sqlc.narg('status')::text IS NULL OR cf.status = sqlc.narg('status')::text)
+  AND (sqlc.narg('payment_method_id')::int IS NULL OR EXISTS (
+    SELECT 1 FROM expense_details ed
+    WHERE ed.cash_flow_id = cf.cash_flow_id
+      AND ed.payment_method_id = sqlc.narg('payment_method_id')::int
+  ))
+  AND (sqlc.narg('tags')::text[] IS NULL OR EXISTS (
+    SELECT 1 FROM cash_flow_tags cft
+    JOIN tags t ON t.tag_id = cft.tag_id
+    WHERE cft.cash_flow_id = cf.cash_flow_id
+      AND t.name = ANY(sqlc.narg('tags')::text[])
+  ))
+  AND (sqlc.narg('payee_id')::int IS NULL OR cf.payee_id = sqlc.narg('payee_id')::int);
+
+-- name: CreateCashFlowExpenseDetail :exec
+INSERT INTO expense_details (cash_flow_id, payment_method_id, is_fixed, is_future, affects_card_invoice)
+VALUES ($1, $2, $3, (SELECT status = 'PLANNED' FROM cash_flows WHERE cash_flow_id = $1), $4);
+
+-- name: GetCashFlowByFITID :one
+SELECT cash_flow_id
+FROM cash_flows
+WHERE COALESCE(external_account, '') = sqlc.arg(external_account)::text
+  AND fitid = sqlc.arg(fitid)::text;
+
+-- name: ListFixedCashFlowsToCopy :many
+SELECT cf.cash_flow_id, cf.date, cf.category_id, cf.direction, cf.title, cf.amount, cf.is_fixed, cf.fitid, cf.external_account, cf.source_cash_flow_id, cf.account_id, cf.cleared_at, cf.reconciliation_id, cf.status, cf.currency, cf.original_amount, cf.exchange_rate, cf.iof_amount, cf.payee_id
+FROM cash_flows cf
 WHERE date_trunc('month', cf.date) = date_trunc('month', $1::date)
-GROUP BY fc.name, fc.direction
-ORDER BY total_amount DESC;
+  AND cf.is_fixed = true
+  AND cf.status <> 'CANCELLED'
+  AND NOT EXISTS (
+    SELECT 1 FROM recurrence_occurrences ro
+    WHERE ro.cash_flow_id = cf.cash_flow_id
+  )
+ORDER BY cf.date, cf.cash_flow_id;
+
+-- name: ListCashFlowCopyTargets :many
+SELECT cash_flow_id, date, category_id, direction, title, amount, is_fixed, fitid, external_account, source_cash_flow_id, account_id, cleared_at, reconciliation_id, status, currency, original_amount, exchange_rate, iof_amount, payee_id
+FROM cash_flows
+WHERE date_trunc('month', date) = date_trunc('month', $1::date)
+ORDER BY date, cash_flow_id;
+
+-- name: CreateCashFlowSplit :one
+INSERT INTO cash_flow_splits (cash_flow_id, category_id, amount)
+VALUES ($1, $2, $3)
+RETURNING cash_flow_split_id, cash_flow_id, category_id, amount;
+
+-- name: ListCashFlowSplits :many
+SELECT s.cash_flow_split_id, s.cash_flow_id, s.category_id, s.amount, fc.name AS category_name
+FROM cash_flow_splits s
+JOIN flow_categories fc ON fc.category_id = s.category_id
+WHERE s.cash_flow_id = $1
+ORDER BY s.cash_flow_split_id;
+
+-- name: DeleteCashFlowSplits :exec
+DELETE FROM cash_flow_splits
+WHERE cash_flow_id = $1;
+
+-- name: ListCashFlowLinesByMonth :many
+SELECT cash_flow_id, cash_flow_split_id, date, direction, category_id, amount, status
+FROM cash_flow_lines
+WHERE date_trunc('month', date) = date_trunc('month', $1::date)
+  AND status = 'REALIZED'
+ORDER BY date, cash_flow_id, cash_flow_split_id;
+
+-- name: SetCashFlowAccount :execrows
+UPDATE cash_flows
+SET account_id = $2
+WHERE cash_flow_id = $1;
+
+-- name: SetCashFlowPayee :execrows
+UPDATE cash_flows
+SET payee_id = $2
+WHERE cash_flow_id = $1;
+
+-- name: GetPaymentMethodAccount :one
+SELECT account_id
+FROM payment_methods
+WHERE payment_method_id = $1
+  AND kind <> 'CREDIT_CARD';
+
+-- name: IsTransferLeg :one
+SELECT EXISTS (
+  SELECT 1
+  FROM transfers t
+  WHERE t.out_cash_flow_id = $1 OR t.in_cash_flow_id = $1
+) AS is_leg;
+
+-- name: ConfirmCashFlow :one
+WITH details AS (
+  UPDATE expense_details
+  SET is_future = false
+  WHERE cash_flow_id = $1
+)
+UPDATE cash_flows
+SET status = 'REALIZED',
+    date = $2,
+    amount = $3,
+    currency = $4,
+    original_amount = $5,
+    exchange_rate = $6,
+    iof_amount = $7
+WHERE cash_flow_id = $1
+  AND status = 'PLANNED'
+RETURNING cash_flow_id, date, category_id, direction, title, amount, is_fixed, fitid, external_account, source_cash_flow_id, account_id, cleared_at, reconciliation_id, status, currency, original_amount, exchange_rate, iof_amount, payee_id;
+
+-- name: CancelCashFlow :execrows
+WITH details AS (
+  UPDATE expense_details
+  SET is_future = false
+  WHERE cash_flow_id = $1
+)
+UPDATE cash_flows
+SET status = 'CANCELLED'
+WHERE cash_flow_id = $1
+  AND status = 'PLANNED';
+
+-- name: ListDuplicateCandidates :many
+SELECT
+  cf.cash_flow_id,
+  cf.date,
+  cf.category_id,
+  fc.name AS category_name,
+  cf.direction,
+  cf.title,
+  cf.amount,
+  cf.payee_id,
+  cf.status,
+  ed.payment_method_id
+FROM cash_flows cf
+JOIN flow_categories fc ON fc.category_id = cf.category_id
+LEFT JOIN LATERAL (
+  SELECT d.payment_method_id
+  FROM expense_details d
+  WHERE d.cash_flow_id = cf.cash_flow_id
+    AND d.payment_method_id IS NOT NULL
+  ORDER BY d.expense_detail_id
+  LIMIT 1
+) ed ON true
+WHERE cf.direction = sqlc.arg('direction')
+  AND cf.amount = sqlc.arg('amount')
+  AND cf.date BETWEEN sqlc.arg('date_from') AND sqlc.arg('date_to')
+  AND cf.status <> 'CANCELLED'
+  AND fc.role <> 'TRANSFER'
+ORDER BY cf.date, cf.cash_flow_id;
+
+-- name: ListDuplicateSuspects :many
+SELECT
+  cf.cash_flow_id,
+  cf.date,
+  cf.category_id,
+  fc.name AS category_name,
+  cf.direction,
+  cf.title,
+  cf.amount,
+  cf.payee_id,
+  cf.status,
+  ed.payment_method_id
+FROM cash_flows cf
+JOIN flow_categories fc ON fc.category_id = cf.category_id
+LEFT JOIN LATERAL (
+  SELECT d.payment_method_id
+  FROM expense_details d
+  WHERE d.cash_flow_id = cf.cash_flow_id
+    AND d.payment_method_id IS NOT NULL
+  ORDER BY d.expense_detail_id
+  LIMIT 1
+) ed ON true
+WHERE cf.status <> 'CANCELLED'
+  AND fc.role <> 'TRANSFER'
+  AND (sqlc.narg('date_from')::date IS NULL OR cf.date >= sqlc.narg('date_from')::date)
+  AND (sqlc.narg('date_to')::date IS NULL OR cf.date <= sqlc.narg('date_to')::date)
+  AND EXISTS (
+    SELECT 1
+    FROM cash_flows o
+    JOIN flow_categories ofc ON ofc.category_id = o.category_id
+    WHERE o.cash_flow_id <> cf.cash_flow_id
+      AND o.direction = cf.direction
+      AND o.amount = cf.amount
+      AND o.status <> 'CANCELLED'
+      AND ofc.role <> 'TRANSFER'
+      AND o.date BETWEEN cf.date - sqlc.arg('days')::int AND cf.date + sqlc.arg('days')::int
+  )
+ORDER BY cf.direction, cf.amount, cf.date, cf.cash_flow_id;
@@ -3,20 +3,21 @@ INSERT INTO flow_categories (
   name,
   direction,
   is_budget_relevant,
-  is_active
+  is_active,
+  role
 ) VALUES (
-  $1, $2, $3, $4
+  $1, $2, $3, $4, $5
 )
-RETURNING category_id, name, direction, is_budget_relevant, is_active, inactive_from_month;
+RETURNING category_id, name, direction, is_budget_relevant, is_active, inactive_from_month, role;
 
 -- name: ListCategories :many
-SELECT category_id, name, direction, is_budget_relevant, is_active, inactive_from_month
+SELECT category_id, name, direction, is_budget_relevant, is_active, inactive_from_month, role
 FROM flow_categories
 WHERE ($1::boolean = false OR is_active = true)
 ORDER BY name;
 
 -- name: ListCategoriesByMonth :many
-SELECT category_id, name, direction, is_budget_relevant, is_active, inactive_from_month
+SELECT category_id, name, direction, is_budget_relevant, is_active, inactive_from_month, role
 FROM flow_categories fc
 WHERE (
   $1::boolean = false
@@ -43,7 +44,7 @@ AND (
 ORDER BY name;
 
 -- name: GetCategoryByID :one
-SELECT category_id, name, direction, is_budget_relevant, is_active, inactive_from_month
+SELECT category_id, name, direction, is_budget_relevant, is_active, inactive_from_month, role
 FROM flow_categories
 WHERE category_id = $1;
 
@@ -52,13 +53,14 @@ UPDATE flow_categories
 SET name = $2,
     direction = $3,
     is_budget_relevant = $4,
-    is_active = $5
+    is_active = $5,
+    role = $6
 WHERE category_id = $1
-RETURNING category_id, name, direction, is_budget_relevant, is_active, inactive_from_month;
+RETURNING category_id, name, direction, is_budget_relevant, is_active, inactive_from_month, role;
 
 -- name: DeactivateCategory :one
 UPDATE flow_categories
 SET is_active = false,
     inactive_from_month = $2
 WHERE category_id = $1
-RETURNING category_id, name, direction, is_budget_relevant, is_active, inactive_from_month;
+RETURNING category_id, name, direction, is_budget_relevant, is_active, inactive_from_month, role;
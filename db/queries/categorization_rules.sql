@@ -0,0 +1,133 @@
+-- name: CreateCategorizationRule :one
+INSERT INTO categorization_rules (
+  name,
+  priority,
+  is_active,
+  title_pattern,
+  direction,
+  min_amount,
+  max_amount,
+  payment_method_id,
+  payee_id,
+  set_category_id,
+  set_is_fixed,
+  set_payee_id
+) VALUES (
+  $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12
+)
+RETURNING categorization_rule_id;
+
+-- name: ListCategorizationRules :many
+SELECT
+  r.categorization_rule_id,
+  r.name,
+  r.priority,
+  r.is_active,
+  r.title_pattern,
+  r.direction,
+  r.min_amount,
+  r.max_amount,
+  r.payment_method_id,
+  r.payee_id,
+  r.set_category_id,
+  r.set_is_fixed,
+  r.set_payee_id,
+  r.created_at,
+  fc.name AS set_category_name
+FROM categorization_rules r
+LEFT JOIN flow_categories fc ON fc.category_id = r.set_category_id
+WHERE (sqlc.narg('is_active')::boolean IS NULL OR r.is_active = sqlc.narg('is_active'))
+ORDER BY r.priority, r.categorization_rule_id;
+
+-- name: GetCategorizationRule :one
+SELECT
+  r.categorization_rule_id,
+  r.name,
+  r.priority,
+  r.is_active,
+  r.title_pattern,
+  r.direction,
+  r.min_amount,
+  r.max_amount,
+  r.payment_method_id,
+  r.payee_id,
+  r.set_category_id,
+  r.set_is_fixed,
+  r.set_payee_id,
+  r.created_at,
+  fc.name AS set_category_name
+FROM categorization_rules r
+LEFT JOIN flow_categories fc ON fc.category_id = r.set_category_id
+WHERE r.categorization_rule_id = $1;
+
+-- name: UpdateCategorizationRule :one
+UPDATE categorization_rules
+SET name = $2,
+    priority = $3,
+    is_active = $4,
+    title_pattern = $5,
+    direction = $6,
+    min_amount = $7,
+    max_amount = $8,
+    payment_method_id = $9,
+    payee_id = $10,
+    set_category_id = $11,
+    set_is_fixed = $12,
+    set_payee_id = $13
+WHERE categorization_rule_id = $1
+RETURNING categorization_rule_id;
+
+-- name: DeleteCategorizationRule :exec
+DELETE FROM categorization_rules
+WHERE categorization_rule_id = $1;
+
+-- name: ListCategorizationRuleTags :many
+SELECT rt.categorization_rule_id, t.tag_id, t.name
+FROM categorization_rule_tags rt
+JOIN tags t ON t.tag_id = rt.tag_id
+WHERE sqlc.narg('categorization_rule_id')::int IS NULL OR rt.categorization_rule_id = sqlc.narg('categorization_rule_id')::int
+ORDER BY rt.categorization_rule_id, t.name;
+
+-- name: AddCategorizationRuleTag :exec
+INSERT INTO categorization_rule_tags (categorization_rule_id, tag_id)
+VALUES ($1, $2)
+ON CONFLICT DO NOTHING;
+
+-- name: DeleteCategorizationRuleTags :exec
+DELETE FROM categorization_rule_tags
+WHERE categorization_rule_id = $1;
+
+-- name: ListCategorizationCandidates :many
+SELECT
+  cf.cash_flow_id,
+  cf.date,
+  cf.title,
+  cf.direction,
+  cf.amount,
+  cf.category_id,
+  cf.is_fixed,
+  cf.payee_id,
+  ed.payment_method_id,
+  ARRAY(
+    SELECT cft.tag_id
+    FROM cash_flow_tags cft
+    WHERE cft.cash_flow_id = cf.cash_flow_id
+    ORDER BY cft.tag_id
+  )::int[] AS tag_ids
+FROM cash_flows cf
+JOIN flow_categories fc ON fc.category_id = cf.category_id
+LEFT JOIN LATERAL (
+  SELECT d.payment_method_id
+  FROM expense_details d
+  WHERE d.cash_flow_id = cf.cash_flow_id
+    AND d.payment_method_id IS NOT NULL
+  ORDER BY d.expense_detail_id
+  LIMIT 1
+) ed ON true
+WHERE cf.status <> 'CANCELLED'
+  AND cf.reconciliation_id IS NULL
+  AND fc.role <> 'TRANSFER'
+  AND (sqlc.narg('direction')::text IS NULL OR cf.direction = sqlc.narg('direction')::text)
+  AND (sqlc.narg('date_from')::date IS NULL OR cf.date >= sqlc.narg('date_from')::date)
+  AND (sqlc.narg('date_to')::date IS NULL OR cf.date <= sqlc.narg('date_to')::date)
+ORDER BY cf.date, cf.cash_flow_id;
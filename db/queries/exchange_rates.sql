@@ -0,0 +1,23 @@
+-- name: UpsertExchangeRate :one
+INSERT INTO exchange_rates (currency, rate_date, rate)
+VALUES ($1, $2, $3)
+ON CONFLICT (currency, rate_date) DO UPDATE SET rate = EXCLUDED.rate
+RETURNING exchange_rate_id, currency, rate_date, rate, created_at;
+
+-- name: ListExchangeRates :many
+SELECT exchange_rate_id, currency, rate_date, rate, created_at
+FROM exchange_rates
+WHERE (sqlc.narg('currency')::text IS NULL OR currency = sqlc.narg('currency')::text)
+ORDER BY currency, rate_date DESC;
+
+-- name: GetEffectiveExchangeRate :one
+SELECT exchange_rate_id, currency, rate_date, rate, created_at
+FROM exchange_rates
+WHERE currency = $1
+  AND rate_date <= $2
+ORDER BY rate_date DESC
+LIMIT 1;
+
+-- name: DeleteExchangeRate :execrows
+DELETE FROM exchange_rates
+WHERE exchange_rate_id = $1;
@@ -0,0 +1,63 @@
+-- name: CreateImportProfile :one
+INSERT INTO import_profiles (
+  name,
+  delimiter,
+  has_header,
+  date_column,
+  date_format,
+  title_column,
+  amount_column,
+  decimal_comma,
+  sign_convention
+) VALUES (
+  $1, $2, $3, $4, $5, $6, $7, $8, $9
+)
+RETURNING import_profile_id, name, delimiter, has_header, date_column, date_format, title_column, amount_column, decimal_comma, sign_convention, created_at;
+
+-- name: ListImportProfiles :many
+SELECT import_profile_id, name, delimiter, has_header, date_column, date_format, title_column, amount_column, decimal_comma, sign_convention, created_at
+FROM import_profiles
+ORDER BY name;
+
+-- name: GetImportProfile :one
+SELECT import_profile_id, name, delimiter, has_header, date_column, date_format, title_column, amount_column, decimal_comma, sign_convention, created_at
+FROM import_profiles
+WHERE import_profile_id = $1;
+
+-- name: UpdateImportProfile :one
+UPDATE import_profiles
+SET name = $2,
+    delimiter = $3,
+    has_header = $4,
+    date_column = $5,
+    date_format = $6,
+    title_column = $7,
+    amount_column = $8,
+    decimal_comma = $9,
+    sign_convention = $10
+WHERE import_profile_id = $1
+RETURNING import_profile_id, name, delimiter, has_header, date_column, date_format, title_column, amount_column, decimal_comma, sign_convention, created_at;
+
+-- name: DeleteImportProfile :exec
+DELETE FROM import_profiles
+WHERE import_profile_id = $1;
+
+-- name: SuggestCategoryForTitle :one
+SELECT cf.category_id, fc.name
+FROM cash_flows cf
+JOIN flow_categories fc ON fc.category_id = cf.category_id
+WHERE lower(cf.title) = lower(sqlc.arg(title)::text)
+  AND cf.direction = sqlc.arg(direction)::text
+  AND fc.is_active = true
+GROUP BY cf.category_id, fc.name
+ORDER BY COUNT(*) DESC, MAX(cf.date) DESC
+LIMIT 1;
+
+-- name: FindDuplicateCashFlow :one
+SELECT cash_flow_id
+FROM cash_flows
+WHERE date = $1
+  AND direction = $2
+  AND amount = $3
+ORDER BY cash_flow_id
+LIMIT 1;
@@ -1,8 +1,8 @@
 -- name: CreateInstallmentPlan :one
-INSERT INTO installment_plans (description, total_amount, installment_count, installment_amount, start_date, payment_method_id, starts_on_current_invoice)
-VALUES ($1, $2, $3, $4, $5, $6, $7)
-RETURNING installment_plan_id, description, total_amount, installment_count, installment_amount, start_date, payment_method_id, starts_on_current_invoice, plan_type, person_id, category_id, interest_rate, interest_rate_unit, recurrence_interval_months, created_at;
+INSERT INTO installment_plans (description, total_amount, installment_count, installment_amount, start_date, payment_method_id, starts_on_current_invoice, currency, original_amount, exchange_rate, iof_amount)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+RETURNING installment_plan_id, description, total_amount, installment_count, installment_amount, start_date, payment_method_id, starts_on_current_invoice, plan_type, person_id, category_id, interest_rate, interest_rate_unit, recurrence_interval_months, created_at, currency, original_amount, exchange_rate, iof_amount;
 
 -- name: CreateExpenseDetail :exec
 INSERT INTO expense_details (cash_flow_id, payment_method_id, is_fixed, is_future, installment_plan_id, affects_card_invoice)
-VALUES ($1, $2, false, true, $3, $4);
+VALUES ($1, $2, false, (SELECT status = 'PLANNED' FROM cash_flows WHERE cash_flow_id = $1), $3, $4);
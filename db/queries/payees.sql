@@ -0,0 +1,105 @@
+-- name: CreatePayee :one
+INSERT INTO payees (name, normalized_name, default_category_id, default_payment_method_id)
+VALUES ($1, $2, $3, $4)
+RETURNING payee_id, name, normalized_name, default_category_id, default_payment_method_id, created_at;
+
+-- name: ListPayees :many
+SELECT
+  p.payee_id,
+  p.name,
+  p.normalized_name,
+  p.default_category_id,
+  p.default_payment_method_id,
+  p.created_at,
+  (SELECT COUNT(*) FROM cash_flows cf WHERE cf.payee_id = p.payee_id)::bigint AS cash_flow_count
+FROM payees p
+ORDER BY p.name, p.payee_id;
+
+-- name: GetPayee :one
+SELECT payee_id, name, normalized_name, default_category_id, default_payment_method_id, created_at
+FROM payees
+WHERE payee_id = $1;
+
+-- name: UpdatePayee :one
+UPDATE payees
+SET name = $2,
+    normalized_name = $3,
+    default_category_id = $4,
+    default_payment_method_id = $5
+WHERE payee_id = $1
+RETURNING payee_id, name, normalized_name, default_category_id, default_payment_method_id, created_at;
+
+-- name: DeletePayee :exec
+DELETE FROM payees
+WHERE payee_id = $1;
+
+-- name: ListPayeeAliases :many
+SELECT payee_alias_id, payee_id, alias, normalized_alias
+FROM payee_aliases
+WHERE sqlc.narg('payee_id')::int IS NULL OR payee_id = sqlc.narg('payee_id')::int
+ORDER BY payee_id, alias;
+
+-- name: AddPayeeAlias :exec
+INSERT INTO payee_aliases (payee_id, alias, normalized_alias)
+VALUES ($1, $2, $3);
+
+-- name: DeletePayeeAliases :exec
+DELETE FROM payee_aliases
+WHERE payee_id = $1;
+
+-- name: FindPayeeByKey :one
+SELECT payee_id FROM payees WHERE normalized_name = sqlc.arg(key)::text
+UNION ALL
+SELECT payee_id FROM payee_aliases WHERE normalized_alias = sqlc.arg(key)::text
+LIMIT 1;
+
+-- name: MatchPayee :one
+SELECT k.payee_id
+FROM (
+  SELECT payee_id, normalized_name AS key FROM payees
+  UNION ALL
+  SELECT payee_id, normalized_alias AS key FROM payee_aliases
+) k
+WHERE sqlc.arg(title)::text = k.key
+   OR sqlc.arg(title)::text LIKE k.key || ' %'
+ORDER BY length(k.key) DESC, k.payee_id
+LIMIT 1;
+
+-- name: ReassignPayeeCashFlows :execrows
+UPDATE cash_flows
+SET payee_id = sqlc.arg(to_payee_id)::int
+WHERE payee_id = sqlc.arg(from_payee_id)::int;
+
+-- name: GetPayeeReport :many
+SELECT
+  date_trunc('month', cl.date)::date AS month,
+  cl.category_id,
+  fc.name AS category_name,
+  cl.direction,
+  COUNT(DISTINCT cl.cash_flow_id)::bigint AS cash_flow_count,
+  SUM(cl.amount)::numeric AS total_amount
+FROM cash_flow_lines cl
+JOIN cash_flows cf ON cf.cash_flow_id = cl.cash_flow_id
+JOIN flow_categories fc ON fc.category_id = cl.category_id
+WHERE cf.payee_id = sqlc.arg(payee_id)::int
+  AND cl.status = 'REALIZED'
+  AND (sqlc.narg('date_from')::date IS NULL OR cl.date >= sqlc.narg('date_from')::date)
+  AND (sqlc.narg('date_to')::date IS NULL OR cl.date <= sqlc.narg('date_to')::date)
+GROUP BY date_trunc('month', cl.date), cl.category_id, fc.name, cl.direction
+ORDER BY month, total_amount DESC;
+
+-- name: ListTopPayees :many
+SELECT
+  p.payee_id,
+  p.name,
+  COUNT(*)::bigint AS cash_flow_count,
+  SUM(cf.amount)::numeric AS total_amount
+FROM cash_flows cf
+JOIN payees p ON p.payee_id = cf.payee_id
+WHERE cf.status = 'REALIZED'
+  AND cf.direction = sqlc.arg(direction)::text
+  AND (sqlc.narg('date_from')::date IS NULL OR cf.date >= sqlc.narg('date_from')::date)
+  AND (sqlc.narg('date_to')::date IS NULL OR cf.date <= sqlc.narg('date_to')::date)
+GROUP BY p.payee_id, p.name
+ORDER BY total_amount DESC, p.name
+LIMIT sqlc.arg(page_limit)::int;
@@ -1,16 +1,16 @@
 -- name: CreatePaymentMethod :one
 INSERT INTO payment_methods (name, kind, bank_name, credit_limit, closing_day, due_day, is_active)
 VALUES ($1, $2, $3, $4, $5, $6, $7)
-RETURNING payment_method_id, name, kind, bank_name, credit_limit, closing_day, due_day, is_active;
+RETURNING payment_method_id, name, kind, bank_name, credit_limit, closing_day, due_day, is_active, account_id;
 
 -- name: ListPaymentMethods :many
-SELECT payment_method_id, name, kind, bank_name, credit_limit, closing_day, due_day, is_active
+SELECT payment_method_id, name, kind, bank_name, credit_limit, closing_day, due_day, is_active, account_id
 FROM payment_methods
 WHERE (sqlc.narg('is_active')::boolean IS NULL OR is_active = sqlc.narg('is_active'))
 ORDER BY name;
 
 -- name: GetPaymentMethod :one
-SELECT payment_method_id, name, kind, bank_name, credit_limit, closing_day, due_day, is_active
+SELECT payment_method_id, name, kind, bank_name, credit_limit, closing_day, due_day, is_active, account_id
 FROM payment_methods
 WHERE payment_method_id = $1;
 
@@ -24,7 +24,7 @@ SET name = $2,
     due_day = $7,
     is_active = $8
 WHERE payment_method_id = $1
-RETURNING payment_method_id, name, kind, bank_name, credit_limit, closing_day, due_day, is_active;
+RETURNING payment_method_id, name, kind, bank_name, credit_limit, closing_day, due_day, is_active, account_id;
 
 -- name: GetInvoiceEntries :many
 SELECT 
@@ -32,18 +32,23 @@ SELECT
     cf.date, 
     cf.title, 
     cf.amount, 
+    cf.currency,
+    cf.original_amount,
+    cf.iof_amount,
     cat.name as category_name
 FROM cash_flows cf
 JOIN flow_categories cat ON cf.category_id = cat.category_id
 JOIN expense_details ed ON cf.cash_flow_id = ed.cash_flow_id
 WHERE ed.payment_method_id = $1 
   AND DATE_TRUNC('month', cf.date) = DATE_TRUNC('month', $2::date)
+  AND cf.status <> 'CANCELLED'
 ORDER BY cf.date ASC;
 
 -- name: GetOutstandingAmount :one
-SELECT COALESCE(SUM(cf.amount), 0)::float
+SELECT COALESCE(SUM(cf.amount), 0)::numeric
 FROM cash_flows cf
 JOIN expense_details ed ON cf.cash_flow_id = ed.cash_flow_id
 WHERE ed.payment_method_id = $1
   AND ed.affects_card_invoice = true
+  AND cf.status <> 'CANCELLED'
   AND DATE_TRUNC('month', cf.date) >= DATE_TRUNC('month', $2::date);
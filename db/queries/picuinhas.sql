@@ -65,7 +65,8 @@ INSERT INTO installment_plans (
 VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
 RETURNING installment_plan_id, description, total_amount, installment_count, installment_amount,
   start_date, payment_method_id, starts_on_current_invoice, plan_type, person_id,
-  category_id, interest_rate, interest_rate_unit, recurrence_interval_months, created_at;
+  category_id, interest_rate, interest_rate_unit, recurrence_interval_months, created_at,
+  currency, original_amount, exchange_rate, iof_amount;
 
 -- name: UpdatePicuinhaCase :one
 UPDATE installment_plans
@@ -84,7 +85,8 @@ SET person_id = $2,
 WHERE installment_plan_id = $1
 RETURNING installment_plan_id, description, total_amount, installment_count, installment_amount,
   start_date, payment_method_id, starts_on_current_invoice, plan_type, person_id,
-  category_id, interest_rate, interest_rate_unit, recurrence_interval_months, created_at;
+  category_id, interest_rate, interest_rate_unit, recurrence_interval_months, created_at,
+  currency, original_amount, exchange_rate, iof_amount;
 
 -- name: DeletePicuinhaCase :exec
 DELETE FROM installment_plans
@@ -93,7 +95,8 @@ WHERE installment_plan_id = $1;
 -- name: GetPicuinhaCase :one
 SELECT installment_plan_id, description, total_amount, installment_count, installment_amount,
   start_date, payment_method_id, starts_on_current_invoice, plan_type, person_id,
-  category_id, interest_rate, interest_rate_unit, recurrence_interval_months, created_at
+  category_id, interest_rate, interest_rate_unit, recurrence_interval_months, created_at,
+  currency, original_amount, exchange_rate, iof_amount
 FROM installment_plans
 WHERE installment_plan_id = $1
   AND person_id IS NOT NULL;
@@ -0,0 +1,91 @@
+-- name: CreateReconciliation :one
+INSERT INTO reconciliations (account_id, payment_method_id, statement_date, statement_balance)
+VALUES ($1, $2, $3, $4)
+RETURNING reconciliation_id, account_id, payment_method_id, statement_date, statement_balance, status, cleared_balance, created_at, completed_at;
+
+-- name: GetReconciliation :one
+SELECT reconciliation_id, account_id, payment_method_id, statement_date, statement_balance, status, cleared_balance, created_at, completed_at
+FROM reconciliations
+WHERE reconciliation_id = $1;
+
+-- name: GetOpenReconciliation :one
+SELECT reconciliation_id, account_id, payment_method_id, statement_date, statement_balance, status, cleared_balance, created_at, completed_at
+FROM reconciliations
+WHERE status = 'OPEN'
+  AND account_id IS NOT DISTINCT FROM sqlc.narg(account_id)::int
+  AND payment_method_id IS NOT DISTINCT FROM sqlc.narg(payment_method_id)::int;
+
+-- name: ListReconciliations :many
+SELECT reconciliation_id, account_id, payment_method_id, statement_date, statement_balance, status, cleared_balance, created_at, completed_at
+FROM reconciliations
+WHERE (sqlc.narg(account_id)::int IS NULL OR account_id = sqlc.narg(account_id)::int)
+  AND (sqlc.narg(payment_method_id)::int IS NULL OR payment_method_id = sqlc.narg(payment_method_id)::int)
+ORDER BY statement_date DESC, reconciliation_id DESC;
+
+-- name: CompleteReconciliation :one
+UPDATE reconciliations
+SET status = 'COMPLETED',
+    cleared_balance = $2,
+    completed_at = now()
+WHERE reconciliation_id = $1
+RETURNING reconciliation_id, account_id, payment_method_id, statement_date, statement_balance, status, cleared_balance, created_at, completed_at;
+
+-- name: DeleteReconciliation :exec
+DELETE FROM reconciliations
+WHERE reconciliation_id = $1;
+
+-- name: ListReconciliationCandidates :many
+SELECT cf.cash_flow_id, cf.date, cf.direction, cf.title, cf.amount, cf.cleared_at, fc.name AS category_name
+FROM cash_flows cf
+JOIN flow_categories fc ON fc.category_id = cf.category_id
+WHERE cf.reconciliation_id IS NULL
+  AND cf.status = 'REALIZED'
+  AND cf.date <= sqlc.arg(statement_date)::date
+  AND (sqlc.narg(account_id)::int IS NULL OR cf.account_id = sqlc.narg(account_id)::int)
+  AND (sqlc.narg(payment_method_id)::int IS NULL OR EXISTS (
+    SELECT 1 FROM expense_details ed
+    WHERE ed.cash_flow_id = cf.cash_flow_id
+      AND ed.payment_method_id = sqlc.narg(payment_method_id)::int
+  ))
+ORDER BY cf.date, cf.cash_flow_id;
+
+-- name: ListReconciledCashFlows :many
+SELECT cf.cash_flow_id, cf.date, cf.direction, cf.title, cf.amount, cf.cleared_at, fc.name AS category_name
+FROM cash_flows cf
+JOIN flow_categories fc ON fc.category_id = cf.category_id
+WHERE cf.reconciliation_id = $1
+ORDER BY cf.date, cf.cash_flow_id;
+
+-- name: GetClearedTotal :one
+SELECT COALESCE(SUM(CASE WHEN cf.direction = 'IN' THEN cf.amount ELSE -cf.amount END), 0)::numeric AS total
+FROM cash_flows cf
+WHERE cf.cleared_at IS NOT NULL
+  AND cf.status = 'REALIZED'
+  AND (sqlc.arg(include_reconciled)::boolean OR cf.reconciliation_id IS NULL)
+  AND (sqlc.narg(from_date)::date IS NULL OR cf.date >= sqlc.narg(from_date)::date)
+  AND (sqlc.narg(account_id)::int IS NULL OR cf.account_id = sqlc.narg(account_id)::int)
+  AND (sqlc.narg(payment_method_id)::int IS NULL OR EXISTS (
+    SELECT 1 FROM expense_details ed
+    WHERE ed.cash_flow_id = cf.cash_flow_id
+      AND ed.payment_method_id = sqlc.narg(payment_method_id)::int
+  ))
+  AND cf.date <= sqlc.arg(statement_date)::date;
+
+-- name: SetCashFlowsCleared :execrows
+UPDATE cash_flows
+SET cleared_at = CASE WHEN sqlc.arg(cleared)::boolean THEN COALESCE(cleared_at, now()) ELSE NULL END
+WHERE cash_flow_id = ANY(sqlc.arg(cash_flow_ids)::int[])
+  AND reconciliation_id IS NULL;
+
+-- name: LockClearedCashFlows :execrows
+UPDATE cash_flows cf
+SET reconciliation_id = sqlc.arg(reconciliation_id)::int
+WHERE cf.cleared_at IS NOT NULL
+  AND cf.reconciliation_id IS NULL
+  AND cf.date <= sqlc.arg(statement_date)::date
+  AND (sqlc.narg(account_id)::int IS NULL OR cf.account_id = sqlc.narg(account_id)::int)
+  AND (sqlc.narg(payment_method_id)::int IS NULL OR EXISTS (
+    SELECT 1 FROM expense_details ed
+    WHERE ed.cash_flow_id = cf.cash_flow_id
+      AND ed.payment_method_id = sqlc.narg(payment_method_id)::int
+  ));
@@ -0,0 +1,69 @@
+-- name: CreateRecurrenceRule :one
+INSERT INTO recurrence_rules (
+  title,
+  category_id,
+  direction,
+  amount,
+  is_fixed,
+  payment_method_id,
+  frequency,
+  interval_count,
+  by_month_day,
+  business_day_adjust,
+  start_date,
+  end_date,
+  is_active
+) VALUES (
+  $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13
+)
+RETURNING recurrence_rule_id, title, category_id, direction, amount, is_fixed, payment_method_id, frequency, interval_count, by_month_day, business_day_adjust, start_date, end_date, is_active, created_at;
+
+-- name: ListRecurrenceRules :many
+SELECT recurrence_rule_id, title, category_id, direction, amount, is_fixed, payment_method_id, frequency, interval_count, by_month_day, business_day_adjust, start_date, end_date, is_active, created_at
+FROM recurrence_rules
+WHERE (sqlc.narg('is_active')::boolean IS NULL OR is_active = sqlc.narg('is_active'))
+ORDER BY title, recurrence_rule_id;
+
+-- name: GetRecurrenceRule :one
+SELECT recurrence_rule_id, title, category_id, direction, amount, is_fixed, payment_method_id, frequency, interval_count, by_month_day, business_day_adjust, start_date, end_date, is_active, created_at
+FROM recurrence_rules
+WHERE recurrence_rule_id = $1;
+
+-- name: UpdateRecurrenceRule :one
+UPDATE recurrence_rules
+SET title = $2,
+    category_id = $3,
+    direction = $4,
+    amount = $5,
+    is_fixed = $6,
+    payment_method_id = $7,
+    frequency = $8,
+    interval_count = $9,
+    by_month_day = $10,
+    business_day_adjust = $11,
+    start_date = $12,
+    end_date = $13,
+    is_active = $14
+WHERE recurrence_rule_id = $1
+RETURNING recurrence_rule_id, title, category_id, direction, amount, is_fixed, payment_method_id, frequency, interval_count, by_month_day, business_day_adjust, start_date, end_date, is_active, created_at;
+
+-- name: DeleteRecurrenceRule :exec
+DELETE FROM recurrence_rules
+WHERE recurrence_rule_id = $1;
+
+-- name: ClaimRecurrenceOccurrence :execrows
+INSERT INTO recurrence_occurrences (recurrence_rule_id, occurrence_date)
+VALUES ($1, $2)
+ON CONFLICT (recurrence_rule_id, occurrence_date) DO NOTHING;
+
+-- name: SetRecurrenceOccurrenceCashFlow :exec
+UPDATE recurrence_occurrences
+SET cash_flow_id = $3
+WHERE recurrence_rule_id = $1
+  AND occurrence_date = $2;
+
+-- name: ListRecurrenceOccurrences :many
+SELECT recurrence_rule_id, occurrence_date, cash_flow_id, created_at
+FROM recurrence_occurrences
+WHERE recurrence_rule_id = $1
+ORDER BY occurrence_date;
@@ -0,0 +1,475 @@
+-- name: GetCumulativeCashFlow :many
+WITH flows AS (
+  SELECT
+    date_trunc('month', cf.date)::date AS month,
+    cf.direction,
+    cf.amount,
+    cf.status = 'PLANNED' AS is_planned,
+    (
+      EXISTS (
+        SELECT 1
+        FROM expense_details d
+        JOIN installment_plans p ON p.installment_plan_id = d.installment_plan_id
+        WHERE d.cash_flow_id = cf.cash_flow_id
+          AND p.person_id IS NOT NULL
+      )
+      OR EXISTS (
+        SELECT 1
+        FROM installment_plan_items i
+        JOIN installment_plans p ON p.installment_plan_id = i.installment_plan_id
+        WHERE i.cash_flow_id = cf.cash_flow_id
+          AND p.person_id IS NOT NULL
+      )
+    ) AS is_picuinha
+  FROM cash_flows cf
+  JOIN flow_categories fc ON fc.category_id = cf.category_id
+  WHERE fc.role <> 'TRANSFER'
+    AND cf.date < date_trunc('month', sqlc.arg('date_to')::date) + interval '1 month'
+    AND (
+      cf.status = 'REALIZED'
+      OR (
+        sqlc.arg('include_installments')::boolean
+        AND cf.status = 'PLANNED'
+        AND EXISTS (
+          SELECT 1
+          FROM expense_details d
+          WHERE d.cash_flow_id = cf.cash_flow_id
+            AND d.installment_plan_id IS NOT NULL
+        )
+      )
+    )
+),
+months AS (
+  -- Months before the range only feed the opening balance.
+  SELECT generate_series(
+    LEAST(
+      date_trunc('month', sqlc.arg('date_from')::date),
+      COALESCE((SELECT MIN(f.month) FROM flows f), date_trunc('month', sqlc.arg('date_from')::date))
+    ),
+    date_trunc('month', sqlc.arg('date_to')::date),
+    interval '1 month'
+  )::date AS month
+),
+monthly AS (
+  SELECT
+    m.month,
+    COALESCE(SUM(f.amount) FILTER (WHERE f.direction = 'IN' AND NOT f.is_picuinha), 0) AS own_income,
+    COALESCE(SUM(f.amount) FILTER (WHERE f.direction = 'OUT' AND NOT f.is_picuinha), 0) AS own_expense,
+    COALESCE(SUM(f.amount) FILTER (WHERE f.direction = 'IN' AND f.is_picuinha), 0) AS picuinha_income,
+    COALESCE(SUM(f.amount) FILTER (WHERE f.direction = 'OUT' AND f.is_picuinha), 0) AS picuinha_expense,
+    COALESCE(SUM(f.amount) FILTER (WHERE f.direction = 'OUT' AND f.is_planned), 0) AS planned_installments
+  FROM months m
+  LEFT JOIN flows f ON f.month = m.month
+  GROUP BY m.month
+),
+running AS (
+  SELECT
+    monthly.*,
+    SUM(own_income - own_expense) OVER (ORDER BY month) AS own_balance,
+    SUM(picuinha_income - picuinha_expense) OVER (ORDER BY month) AS picuinha_balance
+  FROM monthly
+)
+SELECT
+  month,
+  own_income::numeric AS own_income,
+  own_expense::numeric AS own_expense,
+  picuinha_income::numeric AS picuinha_income,
+  picuinha_expense::numeric AS picuinha_expense,
+  planned_installments::numeric AS planned_installments,
+  own_balance::numeric AS own_balance,
+  picuinha_balance::numeric AS picuinha_balance
+FROM running
+WHERE month >= date_trunc('month', sqlc.arg('date_from')::date)
+ORDER BY month;
+
+-- name: GetCategoryHistory :many
+WITH months AS (
+  -- The five months before the range fill the first moving averages.
+  SELECT generate_series(
+    date_trunc('month', sqlc.arg('date_from')::date) - interval '5 months',
+    date_trunc('month', sqlc.arg('date_to')::date),
+    interval '1 month'
+  )::date AS month
+),
+actuals AS (
+  SELECT date_trunc('month', cl.date)::date AS month, SUM(cl.amount) AS total
+  FROM cash_flow_lines cl
+  WHERE cl.category_id = sqlc.arg('category_id')
+    AND cl.status = 'REALIZED'
+  GROUP BY 1
+),
+income AS (
+  SELECT date_trunc('month', cl.date)::date AS month, SUM(cl.amount) AS total
+  FROM cash_flow_lines cl
+  JOIN flow_categories fc ON fc.category_id = cl.category_id
+  WHERE cl.direction = 'IN'
+    AND fc.direction = 'IN'
+    AND fc.is_budget_relevant
+    AND cl.status = 'REALIZED'
+  GROUP BY 1
+),
+monthly AS (
+  SELECT
+    m.month,
+    COALESCE(a.total, 0) AS total,
+    CASE
+      WHEN bi.mode = 'PERCENT_OF_INCOME' THEN round(COALESCE(i.total, 0) * bi.target_percent / 100, 2)
+      ELSE bi.planned_amount
+    END AS planned,
+    row_number() OVER (ORDER BY m.month) AS idx
+  FROM months m
+  LEFT JOIN actuals a ON a.month = m.month
+  LEFT JOIN income i ON i.month = m.month
+  -- A month without budget items follows the latest one before it that has some.
+  LEFT JOIN LATERAL (
+    SELECT bp.budget_period_id
+    FROM budget_periods bp
+    WHERE bp.month <= m.month
+      AND EXISTS (SELECT 1 FROM budget_items x WHERE x.budget_period_id = bp.budget_period_id)
+    ORDER BY bp.month DESC
+    LIMIT 1
+  ) p ON true
+  LEFT JOIN budget_items bi ON bi.budget_period_id = p.budget_period_id
+    AND bi.category_id = sqlc.arg('category_id')
+),
+windowed AS (
+  SELECT
+    monthly.*,
+    AVG(total) OVER (ORDER BY month ROWS BETWEEN 2 PRECEDING AND CURRENT ROW) AS avg_3m,
+    AVG(total) OVER (ORDER BY month ROWS BETWEEN 5 PRECEDING AND CURRENT ROW) AS avg_6m,
+    LAG(total) OVER (ORDER BY month) AS previous_total
+  FROM monthly
+)
+SELECT
+  month,
+  total::numeric AS total,
+  (planned IS NOT NULL)::boolean AS has_budget,
+  COALESCE(planned, 0)::numeric AS planned,
+  round(avg_3m, 2)::numeric AS avg_3m,
+  round(avg_6m, 2)::numeric AS avg_6m,
+  COALESCE(previous_total, 0)::numeric AS previous_total,
+  -- Window functions run after WHERE, so the trend covers the range only.
+  COALESCE(regr_slope(total, idx) OVER (), 0)::float8 AS trend_slope
+FROM windowed
+WHERE month >= date_trunc('month', sqlc.arg('date_from')::date)
+ORDER BY month;
+
+-- name: ListFastestGrowingCategories :many
+WITH months AS (
+  SELECT generate_series(
+    date_trunc('month', sqlc.arg('date_from')::date),
+    date_trunc('month', sqlc.arg('date_to')::date),
+    interval '1 month'
+  )::date AS month
+),
+actuals AS (
+  SELECT cl.category_id, date_trunc('month', cl.date)::date AS month, SUM(cl.amount) AS total
+  FROM cash_flow_lines cl
+  WHERE cl.direction = 'OUT'
+    AND cl.status = 'REALIZED'
+    AND cl.date >= date_trunc('month', sqlc.arg('date_from')::date)
+    AND cl.date < date_trunc('month', sqlc.arg('date_to')::date) + interval '1 month'
+  GROUP BY 1, 2
+),
+income AS (
+  SELECT date_trunc('month', cl.date)::date AS month, SUM(cl.amount) AS total
+  FROM cash_flow_lines cl
+  JOIN flow_categories fc ON fc.category_id = cl.category_id
+  WHERE cl.direction = 'IN'
+    AND fc.direction = 'IN'
+    AND fc.is_budget_relevant
+    AND cl.status = 'REALIZED'
+  GROUP BY 1
+),
+budgets AS (
+  -- A month without budget items follows the latest one before it that has some.
+  SELECT
+    m.month,
+    bi.category_id,
+    CASE
+      WHEN bi.mode = 'PERCENT_OF_INCOME' THEN round(COALESCE(i.total, 0) * bi.target_percent / 100, 2)
+      ELSE bi.planned_amount
+    END AS planned
+  FROM months m
+  JOIN LATERAL (
+    SELECT bp.budget_period_id
+    FROM budget_periods bp
+    WHERE bp.month <= m.month
+      AND EXISTS (SELECT 1 FROM budget_items x WHERE x.budget_period_id = bp.budget_period_id)
+    ORDER BY bp.month DESC
+    LIMIT 1
+  ) p ON true
+  JOIN budget_items bi ON bi.budget_period_id = p.budget_period_id
+  LEFT JOIN income i ON i.month = m.month
+),
+series AS (
+  SELECT
+    fc.category_id,
+    fc.name,
+    COALESCE(a.total, 0) AS total,
+    b.planned,
+    row_number() OVER (PARTITION BY fc.category_id ORDER BY m.month) AS idx
+  FROM flow_categories fc
+  CROSS JOIN months m
+  LEFT JOIN actuals a ON a.category_id = fc.category_id AND a.month = m.month
+  LEFT JOIN budgets b ON b.category_id = fc.category_id AND b.month = m.month
+  WHERE fc.direction = 'OUT'
+    AND fc.role <> 'TRANSFER'
+    AND EXISTS (SELECT 1 FROM actuals x WHERE x.category_id = fc.category_id)
+),
+trends AS (
+  SELECT
+    category_id,
+    name,
+    SUM(total) AS total,
+    AVG(total) AS average,
+    regr_slope(total, idx) AS slope,
+    SUM(planned) AS planned
+  FROM series
+  GROUP BY category_id, name
+)
+SELECT
+  category_id,
+  name,
+  total::numeric AS total,
+  round(average, 2)::numeric AS monthly_average,
+  slope::float8 AS trend_slope,
+  (planned IS NOT NULL)::boolean AS has_budget,
+  COALESCE(planned, 0)::numeric AS planned
+FROM trends
+WHERE slope > 0
+ORDER BY slope DESC, name
+LIMIT sqlc.arg('max_rows')::int;
+
+-- name: GetForecastOpeningBalance :one
+SELECT
+  COALESCE(SUM(CASE WHEN cf.direction = 'IN' THEN cf.amount ELSE -cf.amount END), 0)::numeric AS balance
+FROM cash_flows cf
+JOIN flow_categories fc ON fc.category_id = cf.category_id
+WHERE fc.role <> 'TRANSFER'
+  AND (
+    cf.status = 'REALIZED'
+    OR (
+      cf.status = 'PLANNED'
+      AND cf.date >= date_trunc('month', sqlc.arg('month')::date)
+      AND cf.date < date_trunc('month', sqlc.arg('month')::date) + interval '1 month'
+    )
+  );
+
+-- name: ListForecastPlanned :many
+SELECT
+  date_trunc('month', cf.date)::date AS month,
+  cf.direction,
+  cf.category_id,
+  cf.title,
+  cf.amount,
+  EXISTS (
+    SELECT 1
+    FROM expense_details d
+    WHERE d.cash_flow_id = cf.cash_flow_id
+      AND d.installment_plan_id IS NOT NULL
+  )::boolean AS is_installment
+FROM cash_flows cf
+JOIN flow_categories fc ON fc.category_id = cf.category_id
+WHERE fc.role <> 'TRANSFER'
+  AND cf.status = 'PLANNED'
+  AND cf.date >= date_trunc('month', sqlc.arg('date_from')::date)
+  AND cf.date < date_trunc('month', sqlc.arg('date_to')::date) + interval '1 month'
+ORDER BY cf.date, cf.cash_flow_id;
+
+-- name: ListForecastFixed :many
+WITH fixed AS (
+  -- Fixed flows typed by hand: recurrence rules and installments are
+  -- projected on their own.
+  SELECT cf.cash_flow_id, date_trunc('month', cf.date)::date AS month, cf.date, cf.direction, cf.category_id, cf.title, cf.amount
+  FROM cash_flows cf
+  JOIN flow_categories fc ON fc.category_id = cf.category_id
+  WHERE cf.is_fixed
+    AND cf.status <> 'CANCELLED'
+    AND fc.role <> 'TRANSFER'
+    AND cf.date < date_trunc('month', sqlc.arg('month')::date) + interval '1 month'
+    AND NOT EXISTS (
+      SELECT 1 FROM recurrence_occurrences o WHERE o.cash_flow_id = cf.cash_flow_id
+    )
+    AND NOT EXISTS (
+      SELECT 1
+      FROM expense_details d
+      WHERE d.cash_flow_id = cf.cash_flow_id
+        AND d.installment_plan_id IS NOT NULL
+    )
+)
+SELECT f.direction, f.category_id, f.title, f.amount
+FROM fixed f
+WHERE f.month = (SELECT MAX(month) FROM fixed)
+ORDER BY f.date, f.cash_flow_id;
+
+-- name: ListForecastReceivables :many
+SELECT
+  GREATEST(date_trunc('month', i.due_date), date_trunc('month', sqlc.arg('date_from')::date))::date AS month,
+  SUM(i.amount + i.extra_amount)::numeric AS amount
+FROM installment_plan_items i
+JOIN installment_plans p ON p.installment_plan_id = i.installment_plan_id
+WHERE p.person_id IS NOT NULL
+  AND NOT i.is_paid
+  AND i.due_date < date_trunc('month', sqlc.arg('date_to')::date) + interval '1 month'
+GROUP BY 1
+ORDER BY 1;
+
+-- name: ListVariableSpending :many
+WITH months AS (
+  SELECT generate_series(
+    date_trunc('month', sqlc.arg('date_from')::date),
+    date_trunc('month', sqlc.arg('date_to')::date),
+    interval '1 month'
+  )::date AS month
+),
+variable AS (
+  SELECT cl.category_id, date_trunc('month', cl.date)::date AS month, SUM(cl.amount) AS total
+  FROM cash_flow_lines cl
+  JOIN cash_flows cf ON cf.cash_flow_id = cl.cash_flow_id
+  WHERE cl.direction = 'OUT'
+    AND cl.status = 'REALIZED'
+    AND NOT cf.is_fixed
+    AND cl.date >= date_trunc('month', sqlc.arg('date_from')::date)
+    AND cl.date < date_trunc('month', sqlc.arg('date_to')::date) + interval '1 month'
+    AND NOT EXISTS (
+      SELECT 1 FROM recurrence_occurrences o WHERE o.cash_flow_id = cf.cash_flow_id
+    )
+    AND NOT EXISTS (
+      SELECT 1
+      FROM expense_details d
+      WHERE d.cash_flow_id = cf.cash_flow_id
+        AND d.installment_plan_id IS NOT NULL
+    )
+    AND NOT EXISTS (
+      SELECT 1
+      FROM installment_plan_items i
+      JOIN installment_plans p ON p.installment_plan_id = i.installment_plan_id
+      WHERE i.cash_flow_id = cf.cash_flow_id
+        AND p.person_id IS NOT NULL
+    )
+  GROUP BY 1, 2
+),
+series AS (
+  SELECT c.category_id, COALESCE(v.total, 0) AS total
+  FROM (SELECT DISTINCT category_id FROM variable) c
+  CROSS JOIN months m
+  LEFT JOIN variable v ON v.category_id = c.category_id AND v.month = m.month
+)
+SELECT
+  s.category_id,
+  fc.name,
+  round(AVG(s.total), 2)::numeric AS average,
+  COALESCE(stddev_samp(s.total), 0)::float8 AS stddev
+FROM series s
+JOIN flow_categories fc ON fc.category_id = s.category_id
+GROUP BY s.category_id, fc.name
+ORDER BY average DESC, fc.name;
+
+-- name: GetAnnualMatrix :many
+WITH months AS (
+  SELECT generate_series(
+    date_trunc('year', sqlc.arg('year_start')::date),
+    date_trunc('year', sqlc.arg('year_start')::date) + interval '11 months',
+    interval '1 month'
+  )::date AS month
+),
+actuals AS (
+  SELECT cl.category_id, date_trunc('month', cl.date)::date AS month, SUM(cl.amount) AS total
+  FROM cash_flow_lines cl
+  WHERE cl.status = 'REALIZED'
+    AND cl.date >= date_trunc('year', sqlc.arg('year_start')::date)
+    AND cl.date < date_trunc('year', sqlc.arg('year_start')::date) + interval '1 year'
+  GROUP BY 1, 2
+),
+income AS (
+  SELECT date_trunc('month', cl.date)::date AS month, SUM(cl.amount) AS total
+  FROM cash_flow_lines cl
+  JOIN flow_categories fc ON fc.category_id = cl.category_id
+  WHERE cl.direction = 'IN'
+    AND fc.direction = 'IN'
+    AND fc.is_budget_relevant
+    AND cl.status = 'REALIZED'
+  GROUP BY 1
+),
+budgets AS (
+  SELECT
+    bi.category_id,
+    m.month,
+    CASE
+      WHEN bi.mode = 'PERCENT_OF_INCOME' THEN round(COALESCE(i.total, 0) * bi.target_percent / 100, 2)
+      ELSE bi.planned_amount
+    END AS planned
+  FROM months m
+  -- A month without budget items follows the latest one before it that has some.
+  JOIN LATERAL (
+    SELECT bp.budget_period_id
+    FROM budget_periods bp
+    WHERE bp.month <= m.month
+      AND EXISTS (SELECT 1 FROM budget_items x WHERE x.budget_period_id = bp.budget_period_id)
+    ORDER BY bp.month DESC
+    LIMIT 1
+  ) p ON true
+  JOIN budget_items bi ON bi.budget_period_id = p.budget_period_id
+  LEFT JOIN income i ON i.month = m.month
+)
+SELECT
+  fc.category_id,
+  fc.name,
+  fc.direction,
+  COALESCE(a.month, b.month)::date AS month,
+  COALESCE(a.total, 0)::numeric AS total,
+  (b.planned IS NOT NULL)::boolean AS has_budget,
+  COALESCE(b.planned, 0)::numeric AS planned
+FROM actuals a
+FULL JOIN budgets b ON b.category_id = a.category_id AND b.month = a.month
+JOIN flow_categories fc ON fc.category_id = COALESCE(a.category_id, b.category_id)
+WHERE fc.role <> 'TRANSFER'
+ORDER BY fc.direction, fc.name, fc.category_id, month;
+
+-- name: GetReinvestment :many
+WITH flows AS (
+  SELECT
+    date_trunc('month', cl.date)::date AS month,
+    cl.direction,
+    cl.amount
+  FROM cash_flow_lines cl
+  JOIN flow_categories fc ON fc.category_id = cl.category_id
+  WHERE fc.role = 'INVESTMENT'
+    AND cl.status = 'REALIZED'
+    AND cl.date < date_trunc('month', sqlc.arg('date_to')::date) + interval '1 month'
+),
+months AS (
+  -- Months before the range only feed the opening gap.
+  SELECT generate_series(
+    LEAST(
+      date_trunc('month', sqlc.arg('date_from')::date),
+      COALESCE((SELECT MIN(f.month) FROM flows f), date_trunc('month', sqlc.arg('date_from')::date))
+    ),
+    date_trunc('month', sqlc.arg('date_to')::date),
+    interval '1 month'
+  )::date AS month
+),
+monthly AS (
+  SELECT
+    m.month,
+    COALESCE(SUM(f.amount) FILTER (WHERE f.direction = 'IN'), 0) AS inflow,
+    COALESCE(SUM(f.amount) FILTER (WHERE f.direction = 'OUT'), 0) AS contributions
+  FROM months m
+  LEFT JOIN flows f ON f.month = m.month
+  GROUP BY m.month
+),
+running AS (
+  SELECT
+    monthly.*,
+    SUM(inflow - contributions) OVER (ORDER BY month) AS cumulative_gap
+  FROM monthly
+)
+SELECT
+  month,
+  inflow::numeric AS inflow,
+  contributions::numeric AS contributions,
+  cumulative_gap::numeric AS cumulative_gap
+FROM running
+WHERE month >= date_trunc('month', sqlc.arg('date_from')::date)
+ORDER BY month;
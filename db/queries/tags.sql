@@ -0,0 +1,62 @@
+-- name: CreateTag :one
+INSERT INTO tags (name)
+VALUES ($1)
+RETURNING tag_id, name, created_at;
+
+-- name: ListTags :many
+SELECT t.tag_id, t.name, t.created_at, COUNT(cft.cash_flow_id)::bigint AS cash_flow_count
+FROM tags t
+LEFT JOIN cash_flow_tags cft ON cft.tag_id = t.tag_id
+GROUP BY t.tag_id, t.name, t.created_at
+ORDER BY t.name;
+
+-- name: GetTag :one
+SELECT tag_id, name, created_at
+FROM tags
+WHERE tag_id = $1;
+
+-- name: GetTagByName :one
+SELECT tag_id, name, created_at
+FROM tags
+WHERE name = $1;
+
+-- name: UpdateTag :one
+UPDATE tags
+SET name = $2
+WHERE tag_id = $1
+RETURNING tag_id, name, created_at;
+
+-- name: DeleteTag :exec
+DELETE FROM tags
+WHERE tag_id = $1;
+
+-- name: AddCashFlowTag :exec
+INSERT INTO cash_flow_tags (cash_flow_id, tag_id)
+VALUES ($1, $2)
+ON CONFLICT DO NOTHING;
+
+-- name: DeleteCashFlowTags :exec
+DELETE FROM cash_flow_tags
+WHERE cash_flow_id = $1;
+
+-- name: ListCashFlowTags :many
+SELECT t.tag_id, t.name, t.created_at
+FROM tags t
+JOIN cash_flow_tags cft ON cft.tag_id = t.tag_id
+WHERE cft.cash_flow_id = $1
+ORDER BY t.name;
+
+-- name: GetTagReport :many
+SELECT
+  date_trunc('month', cl.date)::date AS month,
+  cl.category_id,
+  fc.name AS category_name,
+  cl.direction,
+  SUM(cl.amount)::numeric AS total_amount
+FROM cash_flow_lines cl
+JOIN cash_flow_tags cft ON cft.cash_flow_id = cl.cash_flow_id
+JOIN flow_categories fc ON fc.category_id = cl.category_id
+WHERE cft.tag_id = $1
+  AND cl.status = 'REALIZED'
+GROUP BY date_trunc('month', cl.date), cl.category_id, fc.name, cl.direction
+ORDER BY month, total_amount DESC;
@@ -0,0 +1,57 @@
+-- name: CreateTransfer :one
+INSERT INTO transfers (from_account_id, to_account_id, out_cash_flow_id, in_cash_flow_id)
+VALUES ($1, $2, $3, $4)
+RETURNING transfer_id, from_account_id, to_account_id, out_cash_flow_id, in_cash_flow_id, created_at;
+
+-- name: GetTransfer :one
+SELECT
+  t.transfer_id,
+  cf.date,
+  cf.amount,
+  cf.title,
+  t.from_account_id,
+  fa.name AS from_account_name,
+  t.to_account_id,
+  ta.name AS to_account_name,
+  t.out_cash_flow_id,
+  t.in_cash_flow_id,
+  t.created_at
+FROM transfers t
+JOIN cash_flows cf ON cf.cash_flow_id = t.out_cash_flow_id
+JOIN accounts fa ON fa.account_id = t.from_account_id
+JOIN accounts ta ON ta.account_id = t.to_account_id
+WHERE t.transfer_id = $1;
+
+-- name: ListTransfers :many
+SELECT
+  t.transfer_id,
+  cf.date,
+  cf.amount,
+  cf.title,
+  t.from_account_id,
+  fa.name AS from_account_name,
+  t.to_account_id,
+  ta.name AS to_account_name,
+  t.out_cash_flow_id,
+  t.in_cash_flow_id,
+  t.created_at
+FROM transfers t
+JOIN cash_flows cf ON cf.cash_flow_id = t.out_cash_flow_id
+JOIN accounts fa ON fa.account_id = t.from_account_id
+JOIN accounts ta ON ta.account_id = t.to_account_id
+WHERE (sqlc.narg('date_from')::date IS NULL OR cf.date >= sqlc.narg('date_from')::date)
+  AND (sqlc.narg('date_to')::date IS NULL OR cf.date <= sqlc.narg('date_to')::date)
+ORDER BY cf.date, t.transfer_id;
+
+-- name: DeleteTransfer :exec
+DELETE FROM transfers
+WHERE transfer_id = $1;
+
+-- name: GetTransferCategory :one
+SELECT category_id
+FROM flow_categories
+WHERE role = 'TRANSFER'
+  AND direction = $1
+  AND is_active = true
+ORDER BY category_id
+LIMIT 1;
@@ -0,0 +1,116 @@
+package blobstore
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/domain/attachment"
+)
+
+var errInvalidKey = errors.New("invalid blob key")
+
+// LocalStore keeps blobs on the local filesystem under dir, fanned out by
+// the first two characters of the key (dir/ab/abcdef...).
+type LocalStore struct {
+	dir string
+}
+
+func NewLocalStore(dir string) (*LocalStore, error) {
+	if err := os.MkdirAll(dir, 0o750); err != nil {
+		return nil, fmt.Errorf("failed to create attachments dir: %w", err)
+	}
+	return &LocalStore{dir: dir}, nil
+}
+
+// Put writes the blob through a temporary file and a rename, so readers
+// never see a partial file. Existing keys are left untouched.
+func (s *LocalStore) Put(ctx context.Context, key string, r io.Reader) error {
+	path, err := s.path(key)
+	if err != nil {
+		return err
+	}
+	if _, err := os.Stat(path); err == nil {
+		return nil
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), key+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := io.Copy(tmp, r); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+func (s *LocalStore) Open(ctx context.Context, key string) (io.ReadCloser, error) {
+	path, err := s.path(key)
+	if err != nil {
+		return nil, err
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, attachment.ErrBlobNotFound
+		}
+		return nil, err
+	}
+	return f, nil
+}
+
+func (s *LocalStore) Delete(ctx context.Context, key string) error {
+	path, err := s.path(key)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	return nil
+}
+
+// Keys walks dir for blobs, skipping temporary files of writes in progress.
+func (s *LocalStore) Keys(ctx context.Context) ([]string, error) {
+	var keys []string
+	err := filepath.WalkDir(s.dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() || strings.Contains(d.Name(), ".") {
+			return nil
+		}
+		if _, err := s.path(d.Name()); err == nil {
+			keys = append(keys, d.Name())
+		}
+		return nil
+	})
+	return keys, err
+}
+
+// path only accepts lower-case hex keys, which keeps callers from escaping dir.
+func (s *LocalStore) path(key string) (string, error) {
+	if len(key) < 3 {
+		return "", errInvalidKey
+	}
+	for _, r := range key {
+		if (r < '0' || r > '9') && (r < 'a' || r > 'f') {
+			return "", errInvalidKey
+		}
+	}
+	return filepath.Join(s.dir, key[:2], key), nil
+}
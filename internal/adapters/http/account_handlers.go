@@ -0,0 +1,342 @@
+package http
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/adapters/http/dto"
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/domain/account"
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/domain/payment"
+	"github.com/labstack/echo/v4"
+)
+
+type AccountHandler struct {
+	service account.Service
+}
+
+func NewAccountHandler(service account.Service) *AccountHandler {
+	return &AccountHandler{service: service}
+}
+
+// Create registers an account.
+// @Summary Criar Conta
+// @Description Creates an account with its opening balance. The opening balance is the balance at the start of the opening date.
+// @Tags Accounts
+// @Accept json
+// @Produce json
+// @Param payload body dto.AccountRequest true "Account Payload"
+// @Success 201 {object} dto.AccountResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 409 {object} dto.ErrorResponse
+// @Router /accounts [post]
+func (h *AccountHandler) Create(c echo.Context) error {
+	var req dto.AccountRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: "invalid payload"})
+	}
+
+	acc, err := toAccount(req)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: "invalid opening_date format, expected YYYY-MM-DD"})
+	}
+
+	created, err := h.service.CreateAccount(c.Request().Context(), acc)
+	if err != nil {
+		return accountError(c, err, "failed to create account")
+	}
+
+	return c.JSON(http.StatusCreated, toAccountResponse(*created))
+}
+
+// List returns all accounts.
+// @Summary Listar Contas
+// @Description Returns all accounts, active ones first.
+// @Tags Accounts
+// @Accept json
+// @Produce json
+// @Success 200 {array} dto.AccountResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /accounts [get]
+func (h *AccountHandler) List(c echo.Context) error {
+	list, err := h.service.ListAccounts(c.Request().Context())
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, dto.ErrorResponse{Error: "failed to list accounts"})
+	}
+
+	resp := make([]dto.AccountResponse, len(list))
+	for i, a := range list {
+		resp[i] = toAccountResponse(a)
+	}
+	return c.JSON(http.StatusOK, resp)
+}
+
+// Update changes an account.
+// @Summary Atualizar Conta
+// @Description Updates an account by ID. Send is_active=false to deactivate an account that has history.
+// @Tags Accounts
+// @Accept json
+// @Produce json
+// @Param id path int true "Account ID"
+// @Param payload body dto.AccountRequest true "Account Payload"
+// @Success 200 {object} dto.AccountResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 409 {object} dto.ErrorResponse
+// @Router /accounts/{id} [put]
+func (h *AccountHandler) Update(c echo.Context) error {
+	idStr := c.Param("id")
+	var id int32
+	if _, err := fmt.Sscanf(idStr, "%d", &id); err != nil {
+		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: "invalid id format"})
+	}
+
+	var req dto.AccountRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: "invalid payload"})
+	}
+
+	acc, err := toAccount(req)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: "invalid opening_date format, expected YYYY-MM-DD"})
+	}
+	acc.ID = id
+
+	updated, err := h.service.UpdateAccount(c.Request().Context(), acc)
+	if err != nil {
+		return accountError(c, err, "failed to update account")
+	}
+
+	return c.JSON(http.StatusOK, toAccountResponse(*updated))
+}
+
+// Delete removes an account.
+// @Summary Excluir Conta
+// @Description Deletes an account by ID. Accounts with cash flows or payment methods cannot be deleted; deactivate them instead.
+// @Tags Accounts
+// @Accept json
+// @Produce json
+// @Param id path int true "Account ID"
+// @Success 200 {object} map[string]string
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 409 {object} dto.ErrorResponse
+// @Router /accounts/{id} [delete]
+func (h *AccountHandler) Delete(c echo.Context) error {
+	idStr := c.Param("id")
+	var id int32
+	if _, err := fmt.Sscanf(idStr, "%d", &id); err != nil {
+		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: "invalid id format"})
+	}
+
+	if err := h.service.DeleteAccount(c.Request().Context(), id); err != nil {
+		return accountError(c, err, "failed to delete account")
+	}
+
+	return c.JSON(http.StatusOK, map[string]string{"status": "deleted"})
+}
+
+// Balances returns the balance of every account on a date.
+// @Summary Saldos das Contas
+// @Description Returns the balance of every account at the end of a date: opening balance plus the cash flows from the opening date up to the date. Defaults to today.
+// @Tags Accounts
+// @Accept json
+// @Produce json
+// @Param date query string false "Date (YYYY-MM-DD)"
+// @Success 200 {object} dto.AccountBalancesResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /accounts/balances [get]
+func (h *AccountHandler) Balances(c echo.Context) error {
+	asOf := today()
+	if v := c.QueryParam("date"); v != "" {
+		d, err := time.Parse("2006-01-02", v)
+		if err != nil {
+			return c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: "invalid date format, expected YYYY-MM-DD"})
+		}
+		asOf = d
+	}
+
+	balances, err := h.service.GetBalances(c.Request().Context(), asOf)
+	if err != nil {
+		return accountError(c, err, "failed to get balances")
+	}
+
+	resp := dto.AccountBalancesResponse{
+		Date:     asOf.Format("2006-01-02"),
+		Accounts: make([]dto.AccountBalanceResponse, len(balances)),
+	}
+	for i, b := range balances {
+		resp.Total = resp.Total.Add(b.Balance)
+		resp.Accounts[i] = dto.AccountBalanceResponse{
+			AccountID: b.AccountID,
+			Name:      b.Name,
+			Kind:      b.Kind,
+			IsActive:  b.IsActive,
+			Balance:   b.Balance,
+		}
+	}
+	return c.JSON(http.StatusOK, resp)
+}
+
+// RunningBalance returns the daily running balance of an account.
+// @Summary Extrato da Conta
+// @Description Returns the balance at the end of every day with movements in the range. to defaults to today and from to the first day of to's month.
+// @Tags Accounts
+// @Accept json
+// @Produce json
+// @Param id path int true "Account ID"
+// @Param from query string false "Start date (YYYY-MM-DD)"
+// @Param to query string false "End date (YYYY-MM-DD)"
+// @Success 200 {object} dto.RunningBalanceResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Router /accounts/{id}/running-balance [get]
+func (h *AccountHandler) RunningBalance(c echo.Context) error {
+	idStr := c.Param("id")
+	var id int32
+	if _, err := fmt.Sscanf(idStr, "%d", &id); err != nil {
+		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: "invalid id format"})
+	}
+
+	to := today()
+	if v := c.QueryParam("to"); v != "" {
+		d, err := time.Parse("2006-01-02", v)
+		if err != nil {
+			return c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: "invalid to format, expected YYYY-MM-DD"})
+		}
+		to = d
+	}
+	from := time.Date(to.Year(), to.Month(), 1, 0, 0, 0, 0, time.UTC)
+	if v := c.QueryParam("from"); v != "" {
+		d, err := time.Parse("2006-01-02", v)
+		if err != nil {
+			return c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: "invalid from format, expected YYYY-MM-DD"})
+		}
+		from = d
+	}
+
+	rb, err := h.service.GetRunningBalance(c.Request().Context(), id, from, to)
+	if err != nil {
+		return accountError(c, err, "failed to get running balance")
+	}
+
+	resp := dto.RunningBalanceResponse{
+		Account:      toAccountResponse(rb.Account),
+		From:         rb.From.Format("2006-01-02"),
+		To:           rb.To.Format("2006-01-02"),
+		StartBalance: rb.StartBalance,
+		EndBalance:   rb.EndBalance,
+		Days:         make([]dto.AccountDailyBalanceResponse, len(rb.Days)),
+	}
+	for i, d := range rb.Days {
+		resp.Days[i] = dto.AccountDailyBalanceResponse{
+			Date:         d.Date.Format("2006-01-02"),
+			TotalIncome:  d.TotalIncome,
+			TotalExpense: d.TotalExpense,
+			Balance:      d.Balance,
+		}
+	}
+	return c.JSON(http.StatusOK, resp)
+}
+
+// LinkPaymentMethod sets the account a payment method moves money from.
+// @Summary Vincular Meio de Pagamento à Conta
+// @Description Links a payment method to an account; new cash flows paid with it are assigned to that account. Credit cards cannot be linked. A null account_id removes the link.
+// @Tags Accounts
+// @Accept json
+// @Produce json
+// @Param id path int true "Payment Method ID"
+// @Param payload body dto.SetPaymentMethodAccountRequest true "Account"
+// @Success 200 {object} dto.PaymentMethodResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Router /payment-methods/{id}/account [put]
+func (h *AccountHandler) LinkPaymentMethod(c echo.Context) error {
+	idStr := c.Param("id")
+	var id int32
+	if _, err := fmt.Sscanf(idStr, "%d", &id); err != nil {
+		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: "invalid id format"})
+	}
+
+	var req dto.SetPaymentMethodAccountRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: "invalid payload"})
+	}
+
+	method, err := h.service.LinkPaymentMethod(c.Request().Context(), id, req.AccountID)
+	if err != nil {
+		return accountError(c, err, "failed to link payment method")
+	}
+
+	return c.JSON(http.StatusOK, toPaymentMethodResponse(method))
+}
+
+func RegisterAccountRoutes(e *echo.Echo, h *AccountHandler) {
+	g := e.Group("/accounts")
+	g.POST("", h.Create)
+	g.GET("", h.List)
+	g.GET("/balances", h.Balances)
+	g.PUT("/:id", h.Update)
+	g.DELETE("/:id", h.Delete)
+	g.GET("/:id/running-balance", h.RunningBalance)
+
+	e.PUT("/payment-methods/:id/account", h.LinkPaymentMethod)
+}
+
+func accountError(c echo.Context, err error, fallback string) error {
+	switch {
+	case errors.Is(err, account.ErrAccountNotFound),
+		errors.Is(err, payment.ErrPaymentMethodNotFound):
+		return c.JSON(http.StatusNotFound, dto.ErrorResponse{Error: err.Error()})
+	case errors.Is(err, account.ErrDuplicateName),
+		errors.Is(err, account.ErrAccountInUse):
+		return c.JSON(http.StatusConflict, dto.ErrorResponse{Error: err.Error()})
+	case errors.Is(err, account.ErrNameRequired),
+		errors.Is(err, account.ErrInvalidKind),
+		errors.Is(err, account.ErrOpeningDateRequired),
+		errors.Is(err, account.ErrInvalidRange),
+		errors.Is(err, account.ErrCreditCardAccount):
+		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: err.Error()})
+	}
+	return c.JSON(http.StatusInternalServerError, dto.ErrorResponse{Error: fmt.Sprintf("%s: %v", fallback, err)})
+}
+
+func today() time.Time {
+	now := time.Now().UTC()
+	return time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+func toAccount(req dto.AccountRequest) (account.Account, error) {
+	acc := account.Account{
+		Name:           req.Name,
+		Kind:           req.Kind,
+		Institution:    req.Institution,
+		OpeningBalance: req.OpeningBalance,
+		IsActive:       true,
+	}
+	if req.IsActive != nil {
+		acc.IsActive = *req.IsActive
+	}
+	if req.OpeningDate != "" {
+		d, err := time.Parse("2006-01-02", req.OpeningDate)
+		if err != nil {
+			return acc, err
+		}
+		acc.OpeningDate = d
+	}
+	return acc, nil
+}
+
+func toAccountResponse(a account.Account) dto.AccountResponse {
+	return dto.AccountResponse{
+		ID:             a.ID,
+		Name:           a.Name,
+		Kind:           a.Kind,
+		Institution:    a.Institution,
+		OpeningBalance: a.OpeningBalance,
+		OpeningDate:    a.OpeningDate.Format("2006-01-02"),
+		IsActive:       a.IsActive,
+	}
+}
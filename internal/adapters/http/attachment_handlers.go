@@ -0,0 +1,287 @@
+package http
+
+import (
+	"errors"
+	"fmt"
+	"mime"
+	"net/http"
+	"time"
+
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/adapters/http/dto"
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/domain/attachment"
+	"github.com/labstack/echo/v4"
+)
+
+type AttachmentHandler struct {
+	service attachment.Service
+}
+
+func NewAttachmentHandler(service attachment.Service) *AttachmentHandler {
+	return &AttachmentHandler{service: service}
+}
+
+// UploadCashFlow attaches a file to a cash flow.
+// @Summary Anexar Arquivo ao Lançamento
+// @Description Uploads a receipt or document for a cash flow. The type is detected from the content; uploading the same content again returns the existing attachment.
+// @Tags Attachments
+// @Accept multipart/form-data
+// @Produce json
+// @Param id path int true "Cash Flow ID"
+// @Param file formData file true "File"
+// @Success 201 {object} dto.AttachmentResponse
+// @Success 200 {object} dto.AttachmentResponse "Same content already attached"
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 413 {object} dto.ErrorResponse
+// @Failure 415 {object} dto.ErrorResponse
+// @Router /cashflows/{id}/attachments [post]
+func (h *AttachmentHandler) UploadCashFlow(c echo.Context) error {
+	return h.upload(c, attachment.OwnerCashFlow)
+}
+
+// ListCashFlow returns the attachments of a cash flow.
+// @Summary Listar Anexos do Lançamento
+// @Description Returns the attachments of a cash flow.
+// @Tags Attachments
+// @Accept json
+// @Produce json
+// @Param id path int true "Cash Flow ID"
+// @Success 200 {array} dto.AttachmentResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Router /cashflows/{id}/attachments [get]
+func (h *AttachmentHandler) ListCashFlow(c echo.Context) error {
+	return h.list(c, attachment.OwnerCashFlow)
+}
+
+// UploadInstallmentPlan attaches a file to an installment plan.
+// @Summary Anexar Arquivo ao Parcelamento
+// @Description Uploads a document for an installment plan. The type is detected from the content; uploading the same content again returns the existing attachment.
+// @Tags Attachments
+// @Accept multipart/form-data
+// @Produce json
+// @Param id path int true "Installment Plan ID"
+// @Param file formData file true "File"
+// @Success 201 {object} dto.AttachmentResponse
+// @Success 200 {object} dto.AttachmentResponse "Same content already attached"
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 413 {object} dto.ErrorResponse
+// @Failure 415 {object} dto.ErrorResponse
+// @Router /installments/{id}/attachments [post]
+func (h *AttachmentHandler) UploadInstallmentPlan(c echo.Context) error {
+	return h.upload(c, attachment.OwnerInstallmentPlan)
+}
+
+// ListInstallmentPlan returns the attachments of an installment plan.
+// @Summary Listar Anexos do Parcelamento
+// @Description Returns the attachments of an installment plan.
+// @Tags Attachments
+// @Accept json
+// @Produce json
+// @Param id path int true "Installment Plan ID"
+// @Success 200 {array} dto.AttachmentResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Router /installments/{id}/attachments [get]
+func (h *AttachmentHandler) ListInstallmentPlan(c echo.Context) error {
+	return h.list(c, attachment.OwnerInstallmentPlan)
+}
+
+// UploadPicuinhaCase attaches a file to a picuinha case.
+// @Summary Anexar Arquivo à Picuinha
+// @Description Uploads a proof (e.g. a transfer receipt) for a picuinha case. Cases are installment plans, so the attachment is also listed under /installments/{id}/attachments.
+// @Tags Attachments
+// @Accept multipart/form-data
+// @Produce json
+// @Param id path int true "Case ID"
+// @Param file formData file true "File"
+// @Success 201 {object} dto.AttachmentResponse
+// @Success 200 {object} dto.AttachmentResponse "Same content already attached"
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 413 {object} dto.ErrorResponse
+// @Failure 415 {object} dto.ErrorResponse
+// @Router /picuinhas/cases/{id}/attachments [post]
+func (h *AttachmentHandler) UploadPicuinhaCase(c echo.Context) error {
+	return h.upload(c, attachment.OwnerInstallmentPlan)
+}
+
+// ListPicuinhaCase returns the attachments of a picuinha case.
+// @Summary Listar Anexos da Picuinha
+// @Description Returns the attachments of a picuinha case.
+// @Tags Attachments
+// @Accept json
+// @Produce json
+// @Param id path int true "Case ID"
+// @Success 200 {array} dto.AttachmentResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Router /picuinhas/cases/{id}/attachments [get]
+func (h *AttachmentHandler) ListPicuinhaCase(c echo.Context) error {
+	return h.list(c, attachment.OwnerInstallmentPlan)
+}
+
+// Download returns the content of an attachment.
+// @Summary Baixar Anexo
+// @Description Streams the attachment content with its detected content type.
+// @Tags Attachments
+// @Produce octet-stream
+// @Param id path int true "Attachment ID"
+// @Success 200 {file} file
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Router /attachments/{id} [get]
+func (h *AttachmentHandler) Download(c echo.Context) error {
+	idStr := c.Param("id")
+	var id int32
+	if _, err := fmt.Sscanf(idStr, "%d", &id); err != nil {
+		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: "invalid id format"})
+	}
+
+	a, content, err := h.service.Open(c.Request().Context(), id)
+	if err != nil {
+		return attachmentError(c, err, "failed to open attachment")
+	}
+	defer content.Close()
+
+	header := c.Response().Header()
+	header.Set(echo.HeaderContentDisposition, mime.FormatMediaType("attachment", map[string]string{"filename": a.FileName}))
+	header.Set(echo.HeaderXContentTypeOptions, "nosniff")
+	return c.Stream(http.StatusOK, a.ContentType, content)
+}
+
+// Delete removes an attachment.
+// @Summary Excluir Anexo
+// @Description Deletes an attachment by ID. The stored file is removed when no other attachment has the same content.
+// @Tags Attachments
+// @Accept json
+// @Produce json
+// @Param id path int true "Attachment ID"
+// @Success 200 {object} map[string]string
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Router /attachments/{id} [delete]
+func (h *AttachmentHandler) Delete(c echo.Context) error {
+	idStr := c.Param("id")
+	var id int32
+	if _, err := fmt.Sscanf(idStr, "%d", &id); err != nil {
+		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: "invalid id format"})
+	}
+
+	if err := h.service.Delete(c.Request().Context(), id); err != nil {
+		return attachmentError(c, err, "failed to delete attachment")
+	}
+
+	return c.JSON(http.StatusOK, map[string]string{"status": "deleted"})
+}
+
+// Prune removes stored files no attachment uses anymore.
+// @Summary Limpar Arquivos Órfãos
+// @Description Removes stored files left behind by attachments deleted along with their cash flow, installment plan or picuinha case.
+// @Tags Attachments
+// @Accept json
+// @Produce json
+// @Success 200 {object} dto.PruneAttachmentsResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /attachments/prune [post]
+func (h *AttachmentHandler) Prune(c echo.Context) error {
+	removed, err := h.service.Prune(c.Request().Context())
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, dto.ErrorResponse{Error: "failed to prune attachments"})
+	}
+	return c.JSON(http.StatusOK, dto.PruneAttachmentsResponse{Removed: removed})
+}
+
+func (h *AttachmentHandler) upload(c echo.Context, ownerType string) error {
+	idStr := c.Param("id")
+	var id int32
+	if _, err := fmt.Sscanf(idStr, "%d", &id); err != nil {
+		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: "invalid id format"})
+	}
+
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: "file is required"})
+	}
+	file, err := fileHeader.Open()
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: "unable to read file"})
+	}
+	defer file.Close()
+
+	owner := attachment.Owner{Type: ownerType, ID: id}
+	a, created, err := h.service.Upload(c.Request().Context(), owner, fileHeader.Filename, file)
+	if err != nil {
+		return attachmentError(c, err, "failed to upload attachment")
+	}
+
+	status := http.StatusCreated
+	if !created {
+		status = http.StatusOK
+	}
+	return c.JSON(status, toAttachmentResponse(*a))
+}
+
+func (h *AttachmentHandler) list(c echo.Context, ownerType string) error {
+	idStr := c.Param("id")
+	var id int32
+	if _, err := fmt.Sscanf(idStr, "%d", &id); err != nil {
+		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: "invalid id format"})
+	}
+
+	list, err := h.service.List(c.Request().Context(), attachment.Owner{Type: ownerType, ID: id})
+	if err != nil {
+		return attachmentError(c, err, "failed to list attachments")
+	}
+
+	resp := make([]dto.AttachmentResponse, len(list))
+	for i, a := range list {
+		resp[i] = toAttachmentResponse(a)
+	}
+	return c.JSON(http.StatusOK, resp)
+}
+
+func RegisterAttachmentRoutes(e *echo.Echo, h *AttachmentHandler) {
+	g := e.Group("/attachments")
+	g.POST("/prune", h.Prune)
+	g.GET("/:id", h.Download)
+	g.DELETE("/:id", h.Delete)
+
+	e.POST("/cashflows/:id/attachments", h.UploadCashFlow)
+	e.GET("/cashflows/:id/attachments", h.ListCashFlow)
+	e.POST("/installments/:id/attachments", h.UploadInstallmentPlan)
+	e.GET("/installments/:id/attachments", h.ListInstallmentPlan)
+	e.POST("/picuinhas/cases/:id/attachments", h.UploadPicuinhaCase)
+	e.GET("/picuinhas/cases/:id/attachments", h.ListPicuinhaCase)
+}
+
+func attachmentError(c echo.Context, err error, fallback string) error {
+	switch {
+	case errors.Is(err, attachment.ErrAttachmentNotFound),
+		errors.Is(err, attachment.ErrInstallmentPlanNotFound),
+		errors.Is(err, attachment.ErrBlobNotFound):
+		return c.JSON(http.StatusNotFound, dto.ErrorResponse{Error: err.Error()})
+	case errors.Is(err, attachment.ErrFileTooLarge):
+		return c.JSON(http.StatusRequestEntityTooLarge, dto.ErrorResponse{Error: err.Error()})
+	case errors.Is(err, attachment.ErrUnsupportedType):
+		return c.JSON(http.StatusUnsupportedMediaType, dto.ErrorResponse{Error: err.Error()})
+	case errors.Is(err, attachment.ErrEmptyFile),
+		errors.Is(err, attachment.ErrInvalidOwnerType):
+		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: err.Error()})
+	}
+	return cashFlowError(c, err, fallback)
+}
+
+func toAttachmentResponse(a attachment.Attachment) dto.AttachmentResponse {
+	return dto.AttachmentResponse{
+		ID:          a.ID,
+		OwnerType:   a.Owner.Type,
+		OwnerID:     a.Owner.ID,
+		FileName:    a.FileName,
+		ContentType: a.ContentType,
+		SizeBytes:   a.SizeBytes,
+		SHA256:      a.SHA256,
+		CreatedAt:   a.CreatedAt.Format(time.RFC3339),
+	}
+}
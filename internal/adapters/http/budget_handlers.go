@@ -9,6 +9,7 @@ import (
 
 	"github.com/LucasSiedschlag/HausHaltsMeister/internal/adapters/http/dto"
 	"github.com/LucasSiedschlag/HausHaltsMeister/internal/domain/budget"
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/money"
 	"github.com/labstack/echo/v4"
 )
 
@@ -180,7 +181,7 @@ func (h *BudgetHandler) BulkUpdateItems(c echo.Context) error {
 	}
 
 	for _, item := range req.Items {
-		_, err := h.service.SetBudgetItem(c.Request().Context(), parsedMonth, item.CategoryID, budget.ModePercentOfIncome, 0, item.TargetPercent)
+		_, err := h.service.SetBudgetItem(c.Request().Context(), parsedMonth, item.CategoryID, budget.ModePercentOfIncome, money.Amount{}, item.TargetPercent)
 		if err != nil {
 			if err == budget.ErrInvalidCategory || err == budget.ErrInvalidPercent || err == budget.ErrInvalidMode {
 				return c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: err.Error()})
@@ -250,7 +251,7 @@ func toBudgetItemResponse(it *budget.BudgetItem) dto.BudgetItemResponse {
 	}
 }
 
-func normalizeBudgetInput(mode string, plannedAmount *float64, targetPercent *float64) (string, float64, float64, error) {
+func normalizeBudgetInput(mode string, plannedAmount *money.Amount, targetPercent *float64) (string, money.Amount, float64, error) {
 	normalizedMode := strings.ToUpper(strings.TrimSpace(mode))
 	if targetPercent != nil {
 		normalizedMode = budget.ModePercentOfIncome
@@ -269,16 +270,16 @@ func normalizeBudgetInput(mode string, plannedAmount *float64, targetPercent *fl
 	switch normalizedMode {
 	case budget.ModePercentOfIncome:
 		if targetPercent == nil {
-			return "", 0, 0, fmt.Errorf("target_percent is required for percent mode")
+			return "", money.Amount{}, 0, fmt.Errorf("target_percent is required for percent mode")
 		}
-		return normalizedMode, 0, *targetPercent, nil
+		return normalizedMode, money.Amount{}, *targetPercent, nil
 	case budget.ModeAbsolute:
 		if plannedAmount == nil {
-			return "", 0, 0, fmt.Errorf("planned_amount is required for absolute mode")
+			return "", money.Amount{}, 0, fmt.Errorf("planned_amount is required for absolute mode")
 		}
 		return normalizedMode, *plannedAmount, 0, nil
 	default:
-		return "", 0, 0, budget.ErrInvalidMode
+		return "", money.Amount{}, 0, budget.ErrInvalidMode
 	}
 }
 
@@ -1,12 +1,19 @@
 package http
 
 import (
+	"errors"
 	"fmt"
+	"math"
 	"net/http"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/LucasSiedschlag/HausHaltsMeister/internal/adapters/http/dto"
 	"github.com/LucasSiedschlag/HausHaltsMeister/internal/domain/cashflow"
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/domain/exchange"
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/domain/payee"
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/money"
 	"github.com/labstack/echo/v4"
 )
 
@@ -20,7 +27,7 @@ func NewCashFlowHandler(service cashflow.Service) *CashFlowHandler {
 
 // Create creates a new cash flow entry.
 // @Summary Criar Lançamento
-// @Description Creates a new cash flow (income or expense).
+// @Description Creates a new cash flow (income or expense), optionally split across several categories. With status PLANNED the flow is expected and stays out of realized totals until confirmed. A flow that looks like an existing one (same direction and amount, close dates, similar title, same payment method) is refused with 409 and the candidates, unless allow_duplicate is set.
 // @Tags CashFlows
 // @Accept json
 // @Produce json
@@ -28,6 +35,7 @@ func NewCashFlowHandler(service cashflow.Service) *CashFlowHandler {
 // @Success 201 {object} dto.CashFlowResponse
 // @Failure 400 {object} dto.ErrorResponse
 // @Failure 401 {object} dto.ErrorResponse
+// @Failure 409 {object} dto.DuplicateCashFlowErrorResponse
 // @Router /cashflows [post]
 func (h *CashFlowHandler) Create(c echo.Context) error {
 	var req dto.CreateCashFlowRequest
@@ -40,23 +48,227 @@ func (h *CashFlowHandler) Create(c echo.Context) error {
 		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: "invalid date format, use YYYY-MM-DD"})
 	}
 
-	created, err := h.service.CreateCashFlow(
+	created, err := h.service.Create(c.Request().Context(), cashflow.CreateCashFlowRequest{
+		Date:       parsedDate,
+		CategoryID: req.CategoryID,
+		Direction:  req.Direction,
+		Title:      req.Title,
+		Amount:     req.Amount,
+		IsFixed:    req.IsFixed,
+		AccountID:  req.AccountID,
+		PayeeID:    req.PayeeID,
+		Status:     req.Status,
+		Splits:     toSplits(req.Splits),
+		Currency:   req.Currency,
+		IOFAmount:  req.IOFAmount,
+
+		CheckDuplicates: !req.AllowDuplicate,
+	})
+	var dup *cashflow.DuplicateError
+	if errors.As(err, &dup) {
+		resp := dto.DuplicateCashFlowErrorResponse{
+			Error:      cashflow.ErrPossibleDuplicate.Error(),
+			Candidates: make([]dto.CashFlowDuplicateResponse, len(dup.Candidates)),
+		}
+		for i, cand := range dup.Candidates {
+			resp.Candidates[i] = toCashFlowDuplicateResponse(cand)
+		}
+		return c.JSON(http.StatusConflict, resp)
+	}
+	if err != nil {
+		return cashFlowError(c, err, "failed to create cash flow")
+	}
+
+	return c.JSON(http.StatusCreated, toCashFlowResponse(created))
+}
+
+// Update corrects an existing cash flow entry.
+// @Summary Atualizar Lançamento
+// @Description Updates a cash flow. The previous values are kept as a revision.
+// @Tags CashFlows
+// @Accept json
+// @Produce json
+// @Param id path int true "CashFlow ID"
+// @Param payload body dto.UpdateCashFlowRequest true "CashFlow Payload"
+// @Success 200 {object} dto.CashFlowResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Router /cashflows/{id} [put]
+func (h *CashFlowHandler) Update(c echo.Context) error {
+	idStr := c.Param("id")
+	var id int32
+	if _, err := fmt.Sscanf(idStr, "%d", &id); err != nil {
+		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: "invalid id format"})
+	}
+
+	var req dto.UpdateCashFlowRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: "invalid payload"})
+	}
+
+	parsedDate, err := time.Parse("2006-01-02", req.Date)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: "invalid date format, use YYYY-MM-DD"})
+	}
+
+	updated, err := h.service.UpdateCashFlow(
 		c.Request().Context(),
+		id,
 		parsedDate,
 		req.CategoryID,
 		req.Direction,
 		req.Title,
 		req.Amount,
 		req.IsFixed,
+		req.Currency,
 	)
 	if err != nil {
-		if err == cashflow.ErrDirectionMismatch || err == cashflow.ErrCategoryNotFound {
-			return c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: err.Error()})
+		return cashFlowError(c, err, "failed to update cash flow")
+	}
+
+	return c.JSON(http.StatusOK, toCashFlowResponse(updated))
+}
+
+// Delete removes a cash flow entry.
+// @Summary Excluir Lançamento
+// @Description Deletes a cash flow. Flows linked to installment plans cannot be deleted.
+// @Tags CashFlows
+// @Accept json
+// @Produce json
+// @Param id path int true "CashFlow ID"
+// @Success 200 {object} map[string]string
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 409 {object} dto.ErrorResponse
+// @Router /cashflows/{id} [delete]
+func (h *CashFlowHandler) Delete(c echo.Context) error {
+	idStr := c.Param("id")
+	var id int32
+	if _, err := fmt.Sscanf(idStr, "%d", &id); err != nil {
+		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: "invalid id format"})
+	}
+
+	if err := h.service.DeleteCashFlow(c.Request().Context(), id); err != nil {
+		return cashFlowError(c, err, "failed to delete cash flow")
+	}
+
+	return c.JSON(http.StatusOK, map[string]string{"status": "deleted"})
+}
+
+// Confirm marks a planned cash flow as realized.
+// @Summary Confirmar Lançamento Previsto
+// @Description Confirms a planned cash flow with its actual date and amount, which default to the planned ones. From then on it counts in realized totals and account balances.
+// @Tags CashFlows
+// @Accept json
+// @Produce json
+// @Param id path int true "CashFlow ID"
+// @Param payload body dto.ConfirmCashFlowRequest false "Actual values"
+// @Success 200 {object} dto.CashFlowResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 409 {object} dto.ErrorResponse
+// @Router /cashflows/{id}/confirm [post]
+func (h *CashFlowHandler) Confirm(c echo.Context) error {
+	idStr := c.Param("id")
+	var id int32
+	if _, err := fmt.Sscanf(idStr, "%d", &id); err != nil {
+		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: "invalid id format"})
+	}
+
+	var req dto.ConfirmCashFlowRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: "invalid payload"})
+	}
+	var date *time.Time
+	if req.Date != "" {
+		parsedDate, err := time.Parse("2006-01-02", req.Date)
+		if err != nil {
+			return c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: "invalid date format, use YYYY-MM-DD"})
 		}
-		return c.JSON(http.StatusInternalServerError, dto.ErrorResponse{Error: fmt.Sprintf("failed to create cash flow: %v", err)})
+		date = &parsedDate
 	}
 
-	return c.JSON(http.StatusCreated, toCashFlowResponse(created))
+	confirmed, err := h.service.ConfirmCashFlow(c.Request().Context(), id, date, req.Amount)
+	if err != nil {
+		return cashFlowError(c, err, "failed to confirm cash flow")
+	}
+
+	return c.JSON(http.StatusOK, toCashFlowResponse(confirmed))
+}
+
+// Cancel marks a planned cash flow as cancelled.
+// @Summary Cancelar Lançamento Previsto
+// @Description Records that a planned cash flow will not happen. It stays listed with status CANCELLED but counts in no total, balance or invoice.
+// @Tags CashFlows
+// @Accept json
+// @Produce json
+// @Param id path int true "CashFlow ID"
+// @Success 200 {object} dto.CashFlowResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 409 {object} dto.ErrorResponse
+// @Router /cashflows/{id}/cancel [post]
+func (h *CashFlowHandler) Cancel(c echo.Context) error {
+	idStr := c.Param("id")
+	var id int32
+	if _, err := fmt.Sscanf(idStr, "%d", &id); err != nil {
+		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: "invalid id format"})
+	}
+
+	cancelled, err := h.service.CancelCashFlow(c.Request().Context(), id)
+	if err != nil {
+		return cashFlowError(c, err, "failed to cancel cash flow")
+	}
+
+	return c.JSON(http.StatusOK, toCashFlowResponse(cancelled))
+}
+
+// ListRevisions returns the history of corrections of a cash flow.
+// @Summary Histórico do Lançamento
+// @Description Returns the original values recorded before each update or delete.
+// @Tags CashFlows
+// @Accept json
+// @Produce json
+// @Param id path int true "CashFlow ID"
+// @Success 200 {array} dto.CashFlowRevisionResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Router /cashflows/{id}/revisions [get]
+func (h *CashFlowHandler) ListRevisions(c echo.Context) error {
+	idStr := c.Param("id")
+	var id int32
+	if _, err := fmt.Sscanf(idStr, "%d", &id); err != nil {
+		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: "invalid id format"})
+	}
+
+	revisions, err := h.service.ListRevisions(c.Request().Context(), id)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, dto.ErrorResponse{Error: "failed to list revisions"})
+	}
+
+	resp := make([]dto.CashFlowRevisionResponse, len(revisions))
+	for i, r := range revisions {
+		resp[i] = dto.CashFlowRevisionResponse{
+			ID:         r.ID,
+			CashFlowID: r.CashFlowID,
+			Action:     r.Action,
+			Date:       r.Date.Format("2006-01-02"),
+			CategoryID: r.CategoryID,
+			Direction:  r.Direction,
+			Title:      r.Title,
+			Amount:     r.Amount,
+			IsFixed:    r.IsFixed,
+			Status:     r.Status,
+			RevisedAt:  r.RevisedAt.Format(time.RFC3339),
+		}
+		if conv := r.Conversion; conv != nil {
+			resp[i].Currency = conv.Currency
+			resp[i].OriginalAmount = &conv.OriginalAmount
+			resp[i].ExchangeRate = &conv.Rate
+			resp[i].IOFAmount = &conv.IOFAmount
+		}
+	}
+
+	return c.JSON(http.StatusOK, resp)
 }
 
 // ListByMonth returns a list of cash flows for a given month.
@@ -94,13 +306,79 @@ func (h *CashFlowHandler) ListByMonth(c echo.Context) error {
 	return c.JSON(http.StatusOK, resp)
 }
 
+// Search returns cash flows matching several optional criteria, paginated.
+// @Summary Pesquisar Lançamentos
+// @Description Filters cash flows by date range, categories, direction, title, amount range, fixed flag, payment method, tags and payee.
+// @Tags CashFlows
+// @Accept json
+// @Produce json
+// @Param date_from query string false "Start date (YYYY-MM-DD)" format(date)
+// @Param date_to query string false "End date (YYYY-MM-DD)" format(date)
+// @Param category_id query []int false "Category IDs (repeat or comma-separated)"
+// @Param direction query string false "IN or OUT"
+// @Param title query string false "Case-insensitive title substring"
+// @Param min_amount query number false "Minimum amount"
+// @Param max_amount query number false "Maximum amount"
+// @Param is_fixed query bool false "Fixed flag"
+// @Param payment_method_id query int false "Payment method ID"
+// @Param status query string false "PLANNED, REALIZED or CANCELLED"
+// @Param tag query []string false "Tag names (repeat or comma-separated); matches flows with any of them"
+// @Param payee_id query int false "Payee ID"
+// @Param sort query string false "date, amount or title" default(date)
+// @Param order query string false "asc or desc" default(desc)
+// @Param limit query int false "Page size (max 200)" default(50)
+// @Param offset query int false "Items to skip" default(0)
+// @Success 200 {object} dto.CashFlowPageResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Router /cashflows/search [get]
+func (h *CashFlowHandler) Search(c echo.Context) error {
+	filter, err := parseCashFlowFilter(c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: err.Error()})
+	}
+
+	page, err := h.service.SearchCashFlows(c.Request().Context(), filter)
+	if err != nil {
+		return cashFlowError(c, err, "failed to search cash flows")
+	}
+
+	items := make([]dto.CashFlowSearchItem, len(page.Items))
+	for i, cf := range page.Items {
+		items[i] = dto.CashFlowSearchItem{
+			ID:           cf.ID,
+			Date:         cf.Date.Format("2006-01-02"),
+			CategoryID:   cf.CategoryID,
+			CategoryName: cf.CategoryName,
+			Direction:    cf.Direction,
+			Title:        cf.Title,
+			Amount:       cf.Amount,
+			IsFixed:      cf.IsFixed,
+			AccountID:    cf.AccountID,
+			PayeeID:      cf.PayeeID,
+			Status:       cf.Status,
+			Cleared:      cf.ClearedAt != nil,
+			Reconciled:   cf.ReconciliationID != nil,
+		}
+	}
+
+	return c.JSON(http.StatusOK, dto.CashFlowPageResponse{
+		Items:  items,
+		Total:  page.Total,
+		Limit:  page.Limit,
+		Offset: page.Offset,
+	})
+}
+
 // MonthlySummary returns the financial summary for a given month.
 // @Summary Resumo Mensal
-// @Description Returns the total income, expense, and balance for the specified month.
+// @Description Returns the total income, expense, and balance for the specified month. Only realized flows count unless include_planned is set. With compare, also returns the change against the baseline (the previous month, the same month last year, or the monthly average of the 3 or 12 months before) and the categories with the biggest swings.
 // @Tags CashFlows
 // @Accept json
 // @Produce json
 // @Param month query string true "Reference Month (YYYY-MM-DD)" format(date) example(2024-03-01)
+// @Param include_planned query bool false "Also count planned flows" default(false)
+// @Param compare query string false "Compare with previous_month, previous_year, avg_3m or avg_12m"
 // @Success 200 {object} dto.MonthlySummaryResponse
 // @Failure 400 {object} dto.ErrorResponse
 // @Failure 401 {object} dto.ErrorResponse
@@ -114,8 +392,20 @@ func (h *CashFlowHandler) MonthlySummary(c echo.Context) error {
 	if err != nil {
 		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: "invalid month format"})
 	}
+	includePlanned, err := parseIncludePlanned(c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: err.Error()})
+	}
+
+	if mode := c.QueryParam("compare"); mode != "" {
+		cmp, err := h.service.CompareMonthlySummary(c.Request().Context(), parsedMonth, includePlanned, mode)
+		if err != nil {
+			return cashFlowError(c, err, "failed to compare summary")
+		}
+		return c.JSON(http.StatusOK, toComparedSummaryResponse(cmp))
+	}
 
-	summary, err := h.service.GetMonthlySummary(c.Request().Context(), parsedMonth)
+	summary, err := h.service.GetMonthlySummary(c.Request().Context(), parsedMonth, includePlanned)
 	if err != nil {
 		return c.JSON(http.StatusInternalServerError, dto.ErrorResponse{Error: "failed to get summary"})
 	}
@@ -129,11 +419,13 @@ func (h *CashFlowHandler) MonthlySummary(c echo.Context) error {
 
 // CategorySummary returns the financial summary grouped by category for a given month.
 // @Summary Resumo por Categoria
-// @Description Returns a list of expenses/incomes grouped by category for the specified month.
+// @Description Returns a list of expenses/incomes grouped by category for the specified month. Only realized flows count unless include_planned is set. With compare, each category also carries its baseline amount, delta and percentage change, and the ones that moved the most are flagged.
 // @Tags CashFlows
 // @Accept json
 // @Produce json
 // @Param month query string true "Reference Month (YYYY-MM-DD)" format(date) example(2024-03-01)
+// @Param include_planned query bool false "Also count planned flows" default(false)
+// @Param compare query string false "Compare with previous_month, previous_year, avg_3m or avg_12m"
 // @Success 200 {array} dto.CategorySummaryResponse
 // @Failure 400 {object} dto.ErrorResponse
 // @Failure 401 {object} dto.ErrorResponse
@@ -147,8 +439,24 @@ func (h *CashFlowHandler) CategorySummary(c echo.Context) error {
 	if err != nil {
 		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: "invalid month format"})
 	}
+	includePlanned, err := parseIncludePlanned(c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: err.Error()})
+	}
+
+	if mode := c.QueryParam("compare"); mode != "" {
+		cmp, err := h.service.CompareCategorySummary(c.Request().Context(), parsedMonth, includePlanned, mode)
+		if err != nil {
+			return cashFlowError(c, err, "failed to compare category summary")
+		}
+		resp := make([]dto.CategorySummaryResponse, len(cmp))
+		for i, cc := range cmp {
+			resp[i] = toCategoryComparisonResponse(cc)
+		}
+		return c.JSON(http.StatusOK, resp)
+	}
 
-	summary, err := h.service.GetCategorySummary(c.Request().Context(), parsedMonth)
+	summary, err := h.service.GetCategorySummary(c.Request().Context(), parsedMonth, includePlanned)
 	if err != nil {
 		return c.JSON(http.StatusInternalServerError, dto.ErrorResponse{Error: "failed to get category summary"})
 	}
@@ -167,12 +475,12 @@ func (h *CashFlowHandler) CategorySummary(c echo.Context) error {
 
 // CopyFixed copies fixed expenses from one month to another.
 // @Summary Copiar Gastos Fixos
-// @Description Copies fixed expenses from a source month to a target month.
+// @Description Copies fixed expenses from a source month to a target month in a single transaction. Flows already copied are skipped; flows the target month already has with the same title are reported as conflicts. With dry_run nothing is saved.
 // @Tags CashFlows
 // @Accept json
 // @Produce json
 // @Param payload body dto.CopyFixedRequest true "Copy Fixed Param"
-// @Success 200 {object} map[string]int
+// @Success 200 {object} dto.CopyFixedResponse
 // @Failure 400 {object} dto.ErrorResponse
 // @Failure 401 {object} dto.ErrorResponse
 // @Router /cashflows/copy-fixed [post]
@@ -191,25 +499,374 @@ func (h *CashFlowHandler) CopyFixed(c echo.Context) error {
 		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: "invalid to_month format"})
 	}
 
-	count, err := h.service.CopyFixedExpenses(c.Request().Context(), from, to)
+	report, err := h.service.CopyFixedExpenses(c.Request().Context(), from, to, req.DryRun)
 	if err != nil {
-		return c.JSON(http.StatusInternalServerError, dto.ErrorResponse{Error: fmt.Sprintf("failed to copy expenses: %v", err)})
+		return cashFlowError(c, err, "failed to copy expenses")
 	}
 
-	return c.JSON(http.StatusOK, map[string]interface{}{"copied_count": count})
+	return c.JSON(http.StatusOK, dto.CopyFixedResponse{
+		DryRun:        report.DryRun,
+		CopiedCount:   len(report.Created),
+		SkippedCount:  len(report.Skipped),
+		ConflictCount: len(report.Conflicts),
+		Created:       toCopyFixedItems(report.Created),
+		Skipped:       toCopyFixedItems(report.Skipped),
+		Conflicts:     toCopyFixedItems(report.Conflicts),
+	})
+}
+
+// ListSplits returns the category splits of a cash flow.
+// @Summary Listar Divisões do Lançamento
+// @Description Returns the category splits of a cash flow. Empty when the flow is not split.
+// @Tags CashFlows
+// @Accept json
+// @Produce json
+// @Param id path int true "CashFlow ID"
+// @Success 200 {array} dto.CashFlowSplitResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Router /cashflows/{id}/splits [get]
+func (h *CashFlowHandler) ListSplits(c echo.Context) error {
+	idStr := c.Param("id")
+	var id int32
+	if _, err := fmt.Sscanf(idStr, "%d", &id); err != nil {
+		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: "invalid id format"})
+	}
+
+	splits, err := h.service.ListSplits(c.Request().Context(), id)
+	if err != nil {
+		return cashFlowError(c, err, "failed to list splits")
+	}
+
+	return c.JSON(http.StatusOK, toSplitResponses(splits))
+}
+
+// SetSplits replaces the category splits of a cash flow.
+// @Summary Definir Divisões do Lançamento
+// @Description Replaces the category splits of a cash flow. The amounts must add up to the flow amount; an empty list removes the splits.
+// @Tags CashFlows
+// @Accept json
+// @Produce json
+// @Param id path int true "CashFlow ID"
+// @Param payload body dto.SetCashFlowSplitsRequest true "Splits"
+// @Success 200 {array} dto.CashFlowSplitResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 409 {object} dto.ErrorResponse
+// @Router /cashflows/{id}/splits [put]
+func (h *CashFlowHandler) SetSplits(c echo.Context) error {
+	idStr := c.Param("id")
+	var id int32
+	if _, err := fmt.Sscanf(idStr, "%d", &id); err != nil {
+		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: "invalid id format"})
+	}
+
+	var req dto.SetCashFlowSplitsRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: "invalid payload"})
+	}
+
+	splits, err := h.service.SetSplits(c.Request().Context(), id, toSplits(req.Splits))
+	if err != nil {
+		return cashFlowError(c, err, "failed to set splits")
+	}
+
+	return c.JSON(http.StatusOK, toSplitResponses(splits))
+}
+
+// SetAccount moves a cash flow to another account.
+// @Summary Definir Conta do Lançamento
+// @Description Sets the account a cash flow moved money in or out of. A null account_id detaches it.
+// @Tags CashFlows
+// @Accept json
+// @Produce json
+// @Param id path int true "CashFlow ID"
+// @Param payload body dto.SetCashFlowAccountRequest true "Account"
+// @Success 200 {object} dto.CashFlowResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Router /cashflows/{id}/account [put]
+func (h *CashFlowHandler) SetAccount(c echo.Context) error {
+	idStr := c.Param("id")
+	var id int32
+	if _, err := fmt.Sscanf(idStr, "%d", &id); err != nil {
+		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: "invalid id format"})
+	}
+
+	var req dto.SetCashFlowAccountRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: "invalid payload"})
+	}
+
+	updated, err := h.service.SetAccount(c.Request().Context(), id, req.AccountID)
+	if err != nil {
+		return cashFlowError(c, err, "failed to set account")
+	}
+
+	return c.JSON(http.StatusOK, toCashFlowResponse(updated))
+}
+
+// SetPayee links a cash flow to a payee.
+// @Summary Definir Favorecido do Lançamento
+// @Description Sets the payee of a cash flow. A null payee_id unlinks it.
+// @Tags CashFlows
+// @Accept json
+// @Produce json
+// @Param id path int true "CashFlow ID"
+// @Param payload body dto.SetCashFlowPayeeRequest true "Payee"
+// @Success 200 {object} dto.CashFlowResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Router /cashflows/{id}/payee [put]
+func (h *CashFlowHandler) SetPayee(c echo.Context) error {
+	idStr := c.Param("id")
+	var id int32
+	if _, err := fmt.Sscanf(idStr, "%d", &id); err != nil {
+		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: "invalid id format"})
+	}
+
+	var req dto.SetCashFlowPayeeRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: "invalid payload"})
+	}
+
+	updated, err := h.service.SetPayee(c.Request().Context(), id, req.PayeeID)
+	if err != nil {
+		return cashFlowError(c, err, "failed to set payee")
+	}
+
+	return c.JSON(http.StatusOK, toCashFlowResponse(updated))
+}
+
+// ListDuplicates reports existing cash flows that look like the same entry.
+// @Summary Lançamentos Duplicados
+// @Description Pairs up existing cash flows with the same direction and amount, at most days apart, with similar titles (or the same payee) and the same payment method when both have one. Cancelled flows and transfers are left out.
+// @Tags CashFlows
+// @Accept json
+// @Produce json
+// @Param from query string false "Start date (YYYY-MM-DD)"
+// @Param to query string false "End date (YYYY-MM-DD)"
+// @Param days query int false "Maximum days apart (default 3, up to 31)"
+// @Success 200 {array} dto.CashFlowDuplicatePairResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Router /cashflows/duplicates [get]
+func (h *CashFlowHandler) ListDuplicates(c echo.Context) error {
+	from, to, err := parseDateRange(c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: err.Error()})
+	}
+	filter := cashflow.DuplicateFilter{From: from, To: to}
+	if v := c.QueryParam("days"); v != "" {
+		days, err := strconv.Atoi(v)
+		if err != nil {
+			return c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: "invalid days"})
+		}
+		filter.Days = &days
+	}
+
+	pairs, err := h.service.ListDuplicates(c.Request().Context(), filter)
+	if err != nil {
+		return cashFlowError(c, err, "failed to list duplicates")
+	}
+
+	resp := make([]dto.CashFlowDuplicatePairResponse, len(pairs))
+	for i, p := range pairs {
+		resp[i] = dto.CashFlowDuplicatePairResponse{
+			First:      toCashFlowDuplicateResponse(p.First),
+			Second:     toCashFlowDuplicateResponse(p.Second),
+			DaysApart:  p.DaysApart,
+			Similarity: math.Round(p.Similarity*100) / 100,
+		}
+	}
+	return c.JSON(http.StatusOK, resp)
 }
 
 func RegisterCashFlowRoutes(e *echo.Echo, h *CashFlowHandler) {
 	g := e.Group("/cashflows")
 	g.POST("", h.Create)
 	g.GET("", h.ListByMonth)
+	g.GET("/search", h.Search)
+	g.GET("/duplicates", h.ListDuplicates)
 	g.POST("/copy-fixed", h.CopyFixed)
 	g.GET("/summary", h.MonthlySummary)
 	g.GET("/category-summary", h.CategorySummary)
+	g.PUT("/:id", h.Update)
+	g.DELETE("/:id", h.Delete)
+	g.POST("/:id/confirm", h.Confirm)
+	g.POST("/:id/cancel", h.Cancel)
+	g.GET("/:id/revisions", h.ListRevisions)
+	g.GET("/:id/splits", h.ListSplits)
+	g.PUT("/:id/splits", h.SetSplits)
+	g.PUT("/:id/account", h.SetAccount)
+	g.PUT("/:id/payee", h.SetPayee)
+}
+
+func parseCashFlowFilter(c echo.Context) (cashflow.Filter, error) {
+	var f cashflow.Filter
+
+	if v := c.QueryParam("date_from"); v != "" {
+		d, err := time.Parse("2006-01-02", v)
+		if err != nil {
+			return f, errors.New("invalid date_from format, use YYYY-MM-DD")
+		}
+		f.DateFrom = &d
+	}
+	if v := c.QueryParam("date_to"); v != "" {
+		d, err := time.Parse("2006-01-02", v)
+		if err != nil {
+			return f, errors.New("invalid date_to format, use YYYY-MM-DD")
+		}
+		f.DateTo = &d
+	}
+
+	for _, v := range c.QueryParams()["category_id"] {
+		for _, part := range strings.Split(v, ",") {
+			id, err := strconv.ParseInt(strings.TrimSpace(part), 10, 32)
+			if err != nil {
+				return f, fmt.Errorf("invalid category_id: %s", part)
+			}
+			f.CategoryIDs = append(f.CategoryIDs, int32(id))
+		}
+	}
+
+	for _, v := range c.QueryParams()["tag"] {
+		for _, part := range strings.Split(v, ",") {
+			if part = strings.TrimSpace(part); part != "" {
+				f.Tags = append(f.Tags, part)
+			}
+		}
+	}
+
+	f.Direction = c.QueryParam("direction")
+	f.Title = strings.TrimSpace(c.QueryParam("title"))
+
+	if v := c.QueryParam("min_amount"); v != "" {
+		amount, err := money.Parse(v)
+		if err != nil {
+			return f, errors.New("invalid min_amount")
+		}
+		f.MinAmount = &amount
+	}
+	if v := c.QueryParam("max_amount"); v != "" {
+		amount, err := money.Parse(v)
+		if err != nil {
+			return f, errors.New("invalid max_amount")
+		}
+		f.MaxAmount = &amount
+	}
+	if v := c.QueryParam("is_fixed"); v != "" {
+		fixed, err := strconv.ParseBool(v)
+		if err != nil {
+			return f, errors.New("invalid is_fixed, use true or false")
+		}
+		f.IsFixed = &fixed
+	}
+	f.Status = c.QueryParam("status")
+	if v := c.QueryParam("payment_method_id"); v != "" {
+		id, err := strconv.ParseInt(v, 10, 32)
+		if err != nil {
+			return f, errors.New("invalid payment_method_id")
+		}
+		pmID := int32(id)
+		f.PaymentMethodID = &pmID
+	}
+	if v := c.QueryParam("payee_id"); v != "" {
+		id, err := strconv.ParseInt(v, 10, 32)
+		if err != nil {
+			return f, errors.New("invalid payee_id")
+		}
+		payeeID := int32(id)
+		f.PayeeID = &payeeID
+	}
+
+	f.SortBy = c.QueryParam("sort")
+	switch c.QueryParam("order") {
+	case "", "desc":
+		f.SortDesc = true
+	case "asc":
+		f.SortDesc = false
+	default:
+		return f, errors.New("invalid order, use asc or desc")
+	}
+
+	if v := c.QueryParam("limit"); v != "" {
+		limit, err := strconv.ParseInt(v, 10, 32)
+		if err != nil {
+			return f, errors.New("invalid limit")
+		}
+		f.Limit = int32(limit)
+	}
+	if v := c.QueryParam("offset"); v != "" {
+		offset, err := strconv.ParseInt(v, 10, 32)
+		if err != nil {
+			return f, errors.New("invalid offset")
+		}
+		f.Offset = int32(offset)
+	}
+
+	return f, nil
+}
+
+func cashFlowError(c echo.Context, err error, fallback string) error {
+	switch {
+	case errors.Is(err, cashflow.ErrCashFlowNotFound):
+		return c.JSON(http.StatusNotFound, dto.ErrorResponse{Error: err.Error()})
+	case errors.Is(err, cashflow.ErrCashFlowLinked),
+		errors.Is(err, cashflow.ErrTransferLeg),
+		errors.Is(err, cashflow.ErrCashFlowLocked),
+		errors.Is(err, cashflow.ErrNotPlanned):
+		return c.JSON(http.StatusConflict, dto.ErrorResponse{Error: err.Error()})
+	case errors.Is(err, cashflow.ErrDirectionMismatch),
+		errors.Is(err, cashflow.ErrCategoryNotFound),
+		errors.Is(err, cashflow.ErrAccountNotFound),
+		errors.Is(err, payee.ErrPayeeNotFound),
+		errors.Is(err, cashflow.ErrTransferCategory),
+		errors.Is(err, cashflow.ErrInvalidAmount),
+		errors.Is(err, cashflow.ErrEmptyTitle),
+		errors.Is(err, cashflow.ErrInvalidDate),
+		errors.Is(err, cashflow.ErrInvalidDateRange),
+		errors.Is(err, cashflow.ErrInvalidAmountRange),
+		errors.Is(err, cashflow.ErrInvalidSort),
+		errors.Is(err, cashflow.ErrInvalidDirection),
+		errors.Is(err, cashflow.ErrInvalidPage),
+		errors.Is(err, cashflow.ErrCopySameMonth),
+		errors.Is(err, cashflow.ErrInvalidSplitAmount),
+		errors.Is(err, cashflow.ErrSplitSumMismatch),
+		errors.Is(err, cashflow.ErrInvalidStatus),
+		errors.Is(err, cashflow.ErrInvalidStatusFilter),
+		errors.Is(err, cashflow.ErrExchangeRateNotFound),
+		errors.Is(err, cashflow.ErrIOFNotForeign),
+		errors.Is(err, cashflow.ErrConvertedAmount),
+		errors.Is(err, cashflow.ErrInvalidIOF),
+		errors.Is(err, cashflow.ErrInvalidDays),
+		errors.Is(err, cashflow.ErrInvalidCompare),
+		errors.Is(err, exchange.ErrInvalidCurrency):
+		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: err.Error()})
+	}
+	return c.JSON(http.StatusInternalServerError, dto.ErrorResponse{Error: fmt.Sprintf("%s: %v", fallback, err)})
+}
+
+func toCashFlowDuplicateResponse(d cashflow.DuplicateCandidate) dto.CashFlowDuplicateResponse {
+	return dto.CashFlowDuplicateResponse{
+		ID:              d.ID,
+		Date:            d.Date.Format("2006-01-02"),
+		CategoryID:      d.CategoryID,
+		CategoryName:    d.CategoryName,
+		Direction:       d.Direction,
+		Title:           d.Title,
+		Amount:          d.Amount,
+		PayeeID:         d.PayeeID,
+		PaymentMethodID: d.PaymentMethodID,
+		Status:          d.Status,
+	}
 }
 
 func toCashFlowResponse(cf *cashflow.CashFlow) dto.CashFlowResponse {
-	return dto.CashFlowResponse{
+	var clearedAt string
+	if cf.ClearedAt != nil {
+		clearedAt = cf.ClearedAt.Format(time.RFC3339)
+	}
+	resp := dto.CashFlowResponse{
 		ID:         cf.ID,
 		Date:       cf.Date.Format("2006-01-02"),
 		CategoryID: cf.CategoryID,
@@ -217,5 +874,112 @@ func toCashFlowResponse(cf *cashflow.CashFlow) dto.CashFlowResponse {
 		Title:      cf.Title,
 		Amount:     cf.Amount,
 		IsFixed:    cf.IsFixed,
+		AccountID:  cf.AccountID,
+		PayeeID:    cf.PayeeID,
+		Status:     cf.Status,
+		ClearedAt:  clearedAt,
+		Reconciled: cf.ReconciliationID != nil,
+		Splits:     toSplitResponses(cf.Splits),
+	}
+	if conv := cf.Conversion; conv != nil {
+		resp.Currency = conv.Currency
+		resp.OriginalAmount = &conv.OriginalAmount
+		resp.ExchangeRate = &conv.Rate
+		resp.IOFAmount = &conv.IOFAmount
+	}
+	return resp
+}
+
+func toComparedSummaryResponse(cmp *cashflow.MonthlyComparison) dto.MonthlySummaryResponse {
+	swings := make([]dto.CategorySummaryResponse, len(cmp.Swings))
+	for i, cc := range cmp.Swings {
+		swings[i] = toCategoryComparisonResponse(cc)
+	}
+	return dto.MonthlySummaryResponse{
+		TotalIncome:  cmp.Current.TotalIncome,
+		TotalExpense: cmp.Current.TotalExpense,
+		Balance:      cmp.Current.Balance,
+		Comparison: &dto.SummaryComparisonResponse{
+			Mode:          cmp.Period.Mode,
+			BaselineFrom:  cmp.Period.From.Format("2006-01-02"),
+			BaselineTo:    cmp.Period.To.Format("2006-01-02"),
+			Months:        cmp.Period.Months,
+			TotalIncome:   toChangeResponse(cmp.TotalIncome),
+			TotalExpense:  toChangeResponse(cmp.TotalExpense),
+			Balance:       toChangeResponse(cmp.Balance),
+			BiggestSwings: swings,
+		},
+	}
+}
+
+func toCategoryComparisonResponse(cc cashflow.CategoryComparison) dto.CategorySummaryResponse {
+	change := toChangeResponse(cc.Change)
+	return dto.CategorySummaryResponse{
+		CategoryID:   cc.CategoryID,
+		CategoryName: cc.CategoryName,
+		Direction:    cc.Direction,
+		TotalAmount:  cc.TotalAmount,
+		Baseline:     &change.Baseline,
+		Delta:        &change.Delta,
+		ChangePct:    change.ChangePct,
+		BiggestSwing: cc.BiggestSwing,
+	}
+}
+
+func toChangeResponse(ch cashflow.Change) dto.ChangeResponse {
+	resp := dto.ChangeResponse{Baseline: ch.Baseline, Delta: ch.Delta}
+	if ch.Percent != nil {
+		pct := math.Round(*ch.Percent*100) / 100
+		resp.ChangePct = &pct
+	}
+	return resp
+}
+
+func parseIncludePlanned(c echo.Context) (bool, error) {
+	v := c.QueryParam("include_planned")
+	if v == "" {
+		return false, nil
+	}
+	include, err := strconv.ParseBool(v)
+	if err != nil {
+		return false, errors.New("invalid include_planned, use true or false")
+	}
+	return include, nil
+}
+
+func toCopyFixedItems(items []cashflow.CopyItem) []dto.CopyFixedItemResponse {
+	resp := make([]dto.CopyFixedItemResponse, len(items))
+	for i, item := range items {
+		resp[i] = dto.CopyFixedItemResponse{
+			SourceID:   item.SourceID,
+			Date:       item.Date.Format("2006-01-02"),
+			CategoryID: item.CategoryID,
+			Direction:  item.Direction,
+			Title:      item.Title,
+			Amount:     item.Amount,
+			CashFlowID: item.CashFlowID,
+		}
+	}
+	return resp
+}
+
+func toSplits(req []dto.CashFlowSplitRequest) []cashflow.Split {
+	splits := make([]cashflow.Split, len(req))
+	for i, sp := range req {
+		splits[i] = cashflow.Split{CategoryID: sp.CategoryID, Amount: sp.Amount}
+	}
+	return splits
+}
+
+func toSplitResponses(splits []cashflow.Split) []dto.CashFlowSplitResponse {
+	resp := make([]dto.CashFlowSplitResponse, len(splits))
+	for i, sp := range splits {
+		resp[i] = dto.CashFlowSplitResponse{
+			ID:           sp.ID,
+			CategoryID:   sp.CategoryID,
+			CategoryName: sp.CategoryName,
+			Amount:       sp.Amount,
+		}
 	}
+	return resp
 }
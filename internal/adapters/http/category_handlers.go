@@ -36,9 +36,9 @@ func (h *CategoryHandler) Create(c echo.Context) error {
 		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: "invalid payload"})
 	}
 
-	cat, err := h.service.CreateCategory(c.Request().Context(), req.Name, req.Direction, req.IsBudgetRelevant)
+	cat, err := h.service.CreateCategory(c.Request().Context(), req.Name, req.Direction, req.IsBudgetRelevant, req.Role)
 	if err != nil {
-		if errors.Is(err, category.ErrInvalidDirection) || errors.Is(err, category.ErrEmptyName) {
+		if errors.Is(err, category.ErrInvalidDirection) || errors.Is(err, category.ErrEmptyName) || errors.Is(err, category.ErrInvalidRole) {
 			return c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: err.Error()})
 		}
 		return c.JSON(http.StatusInternalServerError, dto.ErrorResponse{Error: "internal server error"})
@@ -152,9 +152,9 @@ func (h *CategoryHandler) Update(c echo.Context) error {
 		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: "missing required fields"})
 	}
 
-	updated, err := h.service.UpdateCategory(c.Request().Context(), id, *req.Name, *req.Direction, *req.IsBudgetRelevant, *req.IsActive)
+	updated, err := h.service.UpdateCategory(c.Request().Context(), id, *req.Name, *req.Direction, *req.IsBudgetRelevant, *req.IsActive, req.Role)
 	if err != nil {
-		if errors.Is(err, category.ErrInvalidDirection) || errors.Is(err, category.ErrEmptyName) {
+		if errors.Is(err, category.ErrInvalidDirection) || errors.Is(err, category.ErrEmptyName) || errors.Is(err, category.ErrInvalidRole) {
 			return c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: err.Error()})
 		}
 		if errors.Is(err, category.ErrCategoryNotFound) {
@@ -186,5 +186,6 @@ func toCategoryResponse(c *category.Category) dto.CategoryResponse {
 		IsBudgetRelevant: c.IsBudgetRelevant,
 		IsActive:         c.IsActive,
 		InactiveFromMonth: inactiveFromMonth,
+		Role:             c.Role,
 	}
 }
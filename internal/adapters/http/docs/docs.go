@@ -24,9 +24,9 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
-        "/budgets/batch": {
-            "post": {
-                "description": "Sets the planned amount for a specific category across a range of months.",
+        "/accounts": {
+            "get": {
+                "description": "Returns all accounts, active ones first.",
                 "consumes": [
                     "application/json"
                 ],
@@ -34,48 +34,29 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Budgets"
-                ],
-                "summary": "Definir Orçamento em Lote",
-                "parameters": [
-                    {
-                        "description": "Batch Budget Payload",
-                        "name": "payload",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/dto.SetBudgetBatchRequest"
-                        }
-                    }
+                    "Accounts"
                 ],
+                "summary": "Listar Contas",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/dto.AccountResponse"
                             }
                         }
                     },
-                    "400": {
-                        "description": "Bad Request",
-                        "schema": {
-                            "$ref": "#/definitions/dto.ErrorResponse"
-                        }
-                    },
-                    "401": {
-                        "description": "Unauthorized",
+                    "500": {
+                        "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     }
                 }
-            }
-        },
-        "/budgets/items/{id}": {
-            "put": {
-                "description": "Updates the budget item (percentual ou absoluto) for a specific budget item.",
+            },
+            "post": {
+                "description": "Creates an account with its opening balance. The opening balance is the balance at the start of the opening date.",
                 "consumes": [
                     "application/json"
                 ],
@@ -83,32 +64,25 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Budgets"
+                    "Accounts"
                 ],
-                "summary": "Atualizar Item de Orçamento",
+                "summary": "Criar Conta",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "Budget Item ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "description": "Budget Item Payload",
+                        "description": "Account Payload",
                         "name": "payload",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/dto.UpdateBudgetItemRequest"
+                            "$ref": "#/definitions/dto.AccountRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "201": {
+                        "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/dto.BudgetItemResponse"
+                            "$ref": "#/definitions/dto.AccountResponse"
                         }
                     },
                     "400": {
@@ -117,8 +91,8 @@ const docTemplate = `{
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "409": {
+                        "description": "Conflict",
                         "schema": {
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
@@ -126,9 +100,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/budgets/{month}/items": {
-            "put": {
-                "description": "Updates all budget items for a month and validates 100% distribution.",
+        "/accounts/balances": {
+            "get": {
+                "description": "Returns the balance of every account at the end of a date: opening balance plus the cash flows from the opening date up to the date. Defaults to today.",
                 "consumes": [
                     "application/json"
                 ],
@@ -136,37 +110,22 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Budgets"
+                    "Accounts"
                 ],
-                "summary": "Atualizar Orçamento em Lote",
+                "summary": "Saldos das Contas",
                 "parameters": [
                     {
                         "type": "string",
-                        "format": "date",
-                        "example": "2024-03-01",
-                        "description": "Reference Month (YYYY-MM-DD)",
-                        "name": "month",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "description": "Bulk Budget Items Payload",
-                        "name": "payload",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/dto.BulkBudgetItemsRequest"
-                        }
+                        "description": "Date (YYYY-MM-DD)",
+                        "name": "date",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/dto.AccountBalancesResponse"
                         }
                     },
                     "400": {
@@ -175,16 +134,18 @@ const docTemplate = `{
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
+                    "500": {
+                        "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     }
                 }
-            },
-            "post": {
-                "description": "Sets or updates the budget item (percentual or absoluto) for a specific category in a given month.",
+            }
+        },
+        "/accounts/{id}": {
+            "put": {
+                "description": "Updates an account by ID. Send is_active=false to deactivate an account that has history.",
                 "consumes": [
                     "application/json"
                 ],
@@ -192,26 +153,24 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Budgets"
+                    "Accounts"
                 ],
-                "summary": "Definir Item de Orçamento",
+                "summary": "Atualizar Conta",
                 "parameters": [
                     {
-                        "type": "string",
-                        "format": "date",
-                        "example": "2024-03-01",
-                        "description": "Reference Month (YYYY-MM-DD)",
-                        "name": "month",
+                        "type": "integer",
+                        "description": "Account ID",
+                        "name": "id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Budget Item Payload",
+                        "description": "Account Payload",
                         "name": "payload",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/dto.SetBudgetItemRequest"
+                            "$ref": "#/definitions/dto.AccountRequest"
                         }
                     }
                 ],
@@ -219,7 +178,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/dto.BudgetItemResponse"
+                            "$ref": "#/definitions/dto.AccountResponse"
                         }
                     },
                     "400": {
@@ -228,18 +187,22 @@ const docTemplate = `{
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
                         "schema": {
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     }
                 }
-            }
-        },
-        "/budgets/{month}/summary": {
-            "get": {
-                "description": "Returns the budget summary comparing planned vs actual expenses for the month.",
+            },
+            "delete": {
+                "description": "Deletes an account by ID. Accounts with cash flows or payment methods cannot be deleted; deactivate them instead.",
                 "consumes": [
                     "application/json"
                 ],
@@ -247,16 +210,14 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Budgets"
+                    "Accounts"
                 ],
-                "summary": "Visualizar Orçamento (Planned vs Actual)",
+                "summary": "Excluir Conta",
                 "parameters": [
                     {
-                        "type": "string",
-                        "format": "date",
-                        "example": "2024-03-01",
-                        "description": "Reference Month (YYYY-MM-DD)",
-                        "name": "month",
+                        "type": "integer",
+                        "description": "Account ID",
+                        "name": "id",
                         "in": "path",
                         "required": true
                     }
@@ -265,7 +226,10 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/dto.BudgetSummaryResponse"
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     },
                     "400": {
@@ -274,8 +238,14 @@ const docTemplate = `{
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
                         "schema": {
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
@@ -283,9 +253,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/cashflows": {
+        "/accounts/{id}/running-balance": {
             "get": {
-                "description": "Returns a list of cash flows for the specified month.",
+                "description": "Returns the balance at the end of every day with movements in the range. to defaults to today and from to the first day of to's month.",
                 "consumes": [
                     "application/json"
                 ],
@@ -293,28 +263,35 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "CashFlows"
+                    "Accounts"
                 ],
-                "summary": "Listar Fluxos (Extrato)",
+                "summary": "Extrato da Conta",
                 "parameters": [
                     {
-                        "type": "string",
-                        "format": "date",
-                        "example": "2024-03-01",
-                        "description": "Reference Month (YYYY-MM-DD)",
-                        "name": "month",
-                        "in": "query",
+                        "type": "integer",
+                        "description": "Account ID",
+                        "name": "id",
+                        "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Start date (YYYY-MM-DD)",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "End date (YYYY-MM-DD)",
+                        "name": "to",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/dto.CashFlowResponse"
-                            }
+                            "$ref": "#/definitions/dto.RunningBalanceResponse"
                         }
                     },
                     "400": {
@@ -323,16 +300,18 @@ const docTemplate = `{
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     }
                 }
-            },
+            }
+        },
+        "/attachments/prune": {
             "post": {
-                "description": "Creates a new cash flow (income or expense).",
+                "description": "Removes stored files left behind by attachments deleted along with their cash flow, installment plan or picuinha case.",
                 "consumes": [
                     "application/json"
                 ],
@@ -340,35 +319,18 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "CashFlows"
-                ],
-                "summary": "Criar Lançamento",
-                "parameters": [
-                    {
-                        "description": "CashFlow Payload",
-                        "name": "payload",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/dto.CreateCashFlowRequest"
-                        }
-                    }
+                    "Attachments"
                 ],
+                "summary": "Limpar Arquivos Órfãos",
                 "responses": {
-                    "201": {
-                        "description": "Created",
-                        "schema": {
-                            "$ref": "#/definitions/dto.CashFlowResponse"
-                        }
-                    },
-                    "400": {
-                        "description": "Bad Request",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/dto.ErrorResponse"
+                            "$ref": "#/definitions/dto.PruneAttachmentsResponse"
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
+                    "500": {
+                        "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
@@ -376,27 +338,22 @@ const docTemplate = `{
                 }
             }
         },
-        "/cashflows/category-summary": {
+        "/attachments/{id}": {
             "get": {
-                "description": "Returns a list of expenses/incomes grouped by category for the specified month.",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Streams the attachment content with its detected content type.",
                 "produces": [
-                    "application/json"
+                    "application/octet-stream"
                 ],
                 "tags": [
-                    "CashFlows"
+                    "Attachments"
                 ],
-                "summary": "Resumo por Categoria",
+                "summary": "Baixar Anexo",
                 "parameters": [
                     {
-                        "type": "string",
-                        "format": "date",
-                        "example": "2024-03-01",
-                        "description": "Reference Month (YYYY-MM-DD)",
-                        "name": "month",
-                        "in": "query",
+                        "type": "integer",
+                        "description": "Attachment ID",
+                        "name": "id",
+                        "in": "path",
                         "required": true
                     }
                 ],
@@ -404,10 +361,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/dto.CategorySummaryResponse"
-                            }
+                            "type": "file"
                         }
                     },
                     "400": {
@@ -416,18 +370,16 @@ const docTemplate = `{
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     }
                 }
-            }
-        },
-        "/cashflows/copy-fixed": {
-            "post": {
-                "description": "Copies fixed expenses from a source month to a target month.",
+            },
+            "delete": {
+                "description": "Deletes an attachment by ID. The stored file is removed when no other attachment has the same content.",
                 "consumes": [
                     "application/json"
                 ],
@@ -435,18 +387,16 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "CashFlows"
+                    "Attachments"
                 ],
-                "summary": "Copiar Gastos Fixos",
+                "summary": "Excluir Anexo",
                 "parameters": [
                     {
-                        "description": "Copy Fixed Param",
-                        "name": "payload",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/dto.CopyFixedRequest"
-                        }
+                        "type": "integer",
+                        "description": "Attachment ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
@@ -455,7 +405,7 @@ const docTemplate = `{
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
-                                "type": "integer"
+                                "type": "string"
                             }
                         }
                     },
@@ -465,8 +415,8 @@ const docTemplate = `{
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
@@ -474,9 +424,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/cashflows/summary": {
-            "get": {
-                "description": "Returns the total income, expense, and balance for the specified month.",
+        "/budgets/batch": {
+            "post": {
+                "description": "Sets the planned amount for a specific category across a range of months.",
                 "consumes": [
                     "application/json"
                 ],
@@ -484,25 +434,28 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "CashFlows"
+                    "Budgets"
                 ],
-                "summary": "Resumo Mensal",
+                "summary": "Definir Orçamento em Lote",
                 "parameters": [
                     {
-                        "type": "string",
-                        "format": "date",
-                        "example": "2024-03-01",
-                        "description": "Reference Month (YYYY-MM-DD)",
-                        "name": "month",
-                        "in": "query",
-                        "required": true
+                        "description": "Batch Budget Payload",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.SetBudgetBatchRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/dto.MonthlySummaryResponse"
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     },
                     "400": {
@@ -520,9 +473,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/categories": {
-            "get": {
-                "description": "Returns a list of all categories, optionally filtered by active status.",
+        "/budgets/items/{id}": {
+            "put": {
+                "description": "Updates the budget item (percentual ou absoluto) for a specific budget item.",
                 "consumes": [
                     "application/json"
                 ],
@@ -530,63 +483,32 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Categories"
+                    "Budgets"
                 ],
-                "summary": "Listar Categorias",
+                "summary": "Atualizar Item de Orçamento",
                 "parameters": [
                     {
-                        "type": "boolean",
-                        "description": "Filter only active categories",
-                        "name": "active",
-                        "in": "query"
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/dto.CategoryResponse"
-                            }
-                        }
+                        "type": "integer",
+                        "description": "Budget Item ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     },
-                    "401": {
-                        "description": "Unauthorized",
-                        "schema": {
-                            "$ref": "#/definitions/dto.ErrorResponse"
-                        }
-                    }
-                }
-            },
-            "post": {
-                "description": "Creates a new category for financial flows.",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "Categories"
-                ],
-                "summary": "Create Categoria",
-                "parameters": [
                     {
-                        "description": "Category Payload",
+                        "description": "Budget Item Payload",
                         "name": "payload",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/dto.CreateCategoryRequest"
+                            "$ref": "#/definitions/dto.UpdateBudgetItemRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/dto.CategoryResponse"
+                            "$ref": "#/definitions/dto.BudgetItemResponse"
                         }
                     },
                     "400": {
@@ -595,8 +517,8 @@ const docTemplate = `{
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
@@ -604,9 +526,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/categories/{id}": {
+        "/budgets/{month}/items": {
             "put": {
-                "description": "Updates a category by ID.",
+                "description": "Updates all budget items for a month and validates 100% distribution.",
                 "consumes": [
                     "application/json"
                 ],
@@ -614,24 +536,26 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Categories"
+                    "Budgets"
                 ],
-                "summary": "Atualizar Categoria",
+                "summary": "Atualizar Orçamento em Lote",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "Category ID",
-                        "name": "id",
+                        "type": "string",
+                        "format": "date",
+                        "example": "2024-03-01",
+                        "description": "Reference Month (YYYY-MM-DD)",
+                        "name": "month",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Category Payload",
+                        "description": "Bulk Budget Items Payload",
                         "name": "payload",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/dto.UpdateCategoryRequest"
+                            "$ref": "#/definitions/dto.BulkBudgetItemsRequest"
                         }
                     }
                 ],
@@ -639,7 +563,10 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/dto.CategoryResponse"
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     },
                     "400": {
@@ -648,18 +575,16 @@ const docTemplate = `{
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     }
                 }
-            }
-        },
-        "/categories/{id}/deactivate": {
-            "patch": {
-                "description": "Deactivates a specific category by ID.",
+            },
+            "post": {
+                "description": "Sets or updates the budget item (percentual or absoluto) for a specific category in a given month.",
                 "consumes": [
                     "application/json"
                 ],
@@ -667,26 +592,34 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Categories"
+                    "Budgets"
                 ],
-                "summary": "Desativar Categoria",
+                "summary": "Definir Item de Orçamento",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "Category ID",
-                        "name": "id",
+                        "type": "string",
+                        "format": "date",
+                        "example": "2024-03-01",
+                        "description": "Reference Month (YYYY-MM-DD)",
+                        "name": "month",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "Budget Item Payload",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.SetBudgetItemRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/dto.BudgetItemResponse"
                         }
                     },
                     "400": {
@@ -694,13 +627,19 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
                     }
                 }
             }
         },
-        "/installments": {
-            "post": {
-                "description": "Creates a new purchase that is split into multiple installments.",
+        "/budgets/{month}/summary": {
+            "get": {
+                "description": "Returns the budget summary comparing planned vs actual expenses for the month.",
                 "consumes": [
                     "application/json"
                 ],
@@ -708,25 +647,25 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Cards"
+                    "Budgets"
                 ],
-                "summary": "Criar Compra Parcelada",
+                "summary": "Visualizar Orçamento (Planned vs Actual)",
                 "parameters": [
                     {
-                        "description": "Installment Payload",
-                        "name": "payload",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/dto.CreateInstallmentRequest"
-                        }
+                        "type": "string",
+                        "format": "date",
+                        "example": "2024-03-01",
+                        "description": "Reference Month (YYYY-MM-DD)",
+                        "name": "month",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/dto.InstallmentPlanResponse"
+                            "$ref": "#/definitions/dto.BudgetSummaryResponse"
                         }
                     },
                     "400": {
@@ -735,8 +674,8 @@ const docTemplate = `{
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
@@ -744,9 +683,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/payment-methods": {
+        "/cashflows": {
             "get": {
-                "description": "Returns a list of payment methods.",
+                "description": "Returns a list of cash flows for the specified month.",
                 "consumes": [
                     "application/json"
                 ],
@@ -754,21 +693,38 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Cards"
+                    "CashFlows"
+                ],
+                "summary": "Listar Fluxos (Extrato)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "date",
+                        "example": "2024-03-01",
+                        "description": "Reference Month (YYYY-MM-DD)",
+                        "name": "month",
+                        "in": "query",
+                        "required": true
+                    }
                 ],
-                "summary": "Listar Meios de Pagamento",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
                             "type": "array",
                             "items": {
-                                "$ref": "#/definitions/dto.PaymentMethodResponse"
+                                "$ref": "#/definitions/dto.CashFlowResponse"
                             }
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
@@ -776,7 +732,7 @@ const docTemplate = `{
                 }
             },
             "post": {
-                "description": "Creates a new payment method.",
+                "description": "Creates a new cash flow (income or expense), optionally split across several categories. With status PLANNED the flow is expected and stays out of realized totals until confirmed. A flow that looks like an existing one (same direction and amount, close dates, similar title, same payment method) is refused with 409 and the candidates, unless allow_duplicate is set.",
                 "consumes": [
                     "application/json"
                 ],
@@ -784,17 +740,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Cards"
+                    "CashFlows"
                 ],
-                "summary": "Criar Meio de Pagamento",
+                "summary": "Criar Lançamento",
                 "parameters": [
                     {
-                        "description": "Payment Method Payload",
+                        "description": "CashFlow Payload",
                         "name": "payload",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/dto.CreatePaymentMethodRequest"
+                            "$ref": "#/definitions/dto.CreateCashFlowRequest"
                         }
                     }
                 ],
@@ -802,7 +758,7 @@ const docTemplate = `{
                     "201": {
                         "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/dto.PaymentMethodResponse"
+                            "$ref": "#/definitions/dto.CashFlowResponse"
                         }
                     },
                     "400": {
@@ -811,18 +767,24 @@ const docTemplate = `{
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/dto.DuplicateCashFlowErrorResponse"
+                        }
                     }
                 }
             }
         },
-        "/payment-methods/{id}/invoice": {
+        "/cashflows/category-summary": {
             "get": {
-                "description": "Returns the invoice for a credit car payment method in a specific month.",
+                "description": "Returns a list of expenses/incomes grouped by category for the specified month. Only realized flows count unless include_planned is set. With compare, each category also carries its baseline amount, delta and percentage change, and the ones that moved the most are flagged.",
                 "consumes": [
                     "application/json"
                 ],
@@ -830,17 +792,10 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Cards"
+                    "CashFlows"
                 ],
-                "summary": "Visualizar Fatura",
+                "summary": "Resumo por Categoria",
                 "parameters": [
-                    {
-                        "type": "integer",
-                        "description": "Payment Method ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    },
                     {
                         "type": "string",
                         "format": "date",
@@ -849,13 +804,29 @@ const docTemplate = `{
                         "name": "month",
                         "in": "query",
                         "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "default": false,
+                        "description": "Also count planned flows",
+                        "name": "include_planned",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Compare with previous_month, previous_year, avg_3m or avg_12m",
+                        "name": "compare",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/dto.InvoiceResponse"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/dto.CategorySummaryResponse"
+                            }
                         }
                     },
                     "400": {
@@ -864,8 +835,54 @@ const docTemplate = `{
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/cashflows/copy-fixed": {
+            "post": {
+                "description": "Copies fixed expenses from a source month to a target month in a single transaction. Flows already copied are skipped; flows the target month already has with the same title are reported as conflicts. With dry_run nothing is saved.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "CashFlows"
+                ],
+                "summary": "Copiar Gastos Fixos",
+                "parameters": [
+                    {
+                        "description": "Copy Fixed Param",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.CopyFixedRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.CopyFixedResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
@@ -873,9 +890,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/picuinhas/entries": {
+        "/cashflows/duplicates": {
             "get": {
-                "description": "Returns a list of picuinha entries.",
+                "description": "Pairs up existing cash flows with the same direction and amount, at most days apart, with similar titles (or the same payee) and the same payment method when both have one. Cancelled flows and transfers are left out.",
                 "consumes": [
                     "application/json"
                 ],
@@ -883,14 +900,26 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Picuinhas"
+                    "CashFlows"
                 ],
-                "summary": "Listar Lançamentos",
+                "summary": "Lançamentos Duplicados",
                 "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Start date (YYYY-MM-DD)",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "End date (YYYY-MM-DD)",
+                        "name": "to",
+                        "in": "query"
+                    },
                     {
                         "type": "integer",
-                        "description": "Person ID",
-                        "name": "person_id",
+                        "description": "Maximum days apart (default 3, up to 31)",
+                        "name": "days",
                         "in": "query"
                     }
                 ],
@@ -900,7 +929,7 @@ const docTemplate = `{
                         "schema": {
                             "type": "array",
                             "items": {
-                                "$ref": "#/definitions/dto.PicuinhaEntryResponse"
+                                "$ref": "#/definitions/dto.CashFlowDuplicatePairResponse"
                             }
                         }
                     },
@@ -909,17 +938,160 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
+                    }
+                }
+            }
+        },
+        "/cashflows/search": {
+            "get": {
+                "description": "Filters cash flows by date range, categories, direction, title, amount range, fixed flag, payment method, tags and payee.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "CashFlows"
+                ],
+                "summary": "Pesquisar Lançamentos",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "date",
+                        "description": "Start date (YYYY-MM-DD)",
+                        "name": "date_from",
+                        "in": "query"
                     },
-                    "404": {
-                        "description": "Not Found",
+                    {
+                        "type": "string",
+                        "format": "date",
+                        "description": "End date (YYYY-MM-DD)",
+                        "name": "date_to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "integer"
+                        },
+                        "collectionFormat": "csv",
+                        "description": "Category IDs (repeat or comma-separated)",
+                        "name": "category_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "IN or OUT",
+                        "name": "direction",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Case-insensitive title substring",
+                        "name": "title",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Minimum amount",
+                        "name": "min_amount",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Maximum amount",
+                        "name": "max_amount",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Fixed flag",
+                        "name": "is_fixed",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Payment method ID",
+                        "name": "payment_method_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "PLANNED, REALIZED or CANCELLED",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "description": "Tag names (repeat or comma-separated); matches flows with any of them",
+                        "name": "tag",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Payee ID",
+                        "name": "payee_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "default": "date",
+                        "description": "date, amount or title",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "default": "desc",
+                        "description": "asc or desc",
+                        "name": "order",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 50,
+                        "description": "Page size (max 200)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 0,
+                        "description": "Items to skip",
+                        "name": "offset",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.CashFlowPageResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     }
                 }
-            },
-            "post": {
-                "description": "Registers a new financial entry for a specific person.",
+            }
+        },
+        "/cashflows/summary": {
+            "get": {
+                "description": "Returns the total income, expense, and balance for the specified month. Only realized flows count unless include_planned is set. With compare, also returns the change against the baseline (the previous month, the same month last year, or the monthly average of the 3 or 12 months before) and the categories with the biggest swings.",
                 "consumes": [
                     "application/json"
                 ],
@@ -927,25 +1099,38 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Picuinhas"
+                    "CashFlows"
                 ],
-                "summary": "Registrar Entrada/Empréstimo",
+                "summary": "Resumo Mensal",
                 "parameters": [
                     {
-                        "description": "Entry Payload",
-                        "name": "payload",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/dto.AddEntryRequest"
-                        }
+                        "type": "string",
+                        "format": "date",
+                        "example": "2024-03-01",
+                        "description": "Reference Month (YYYY-MM-DD)",
+                        "name": "month",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "default": false,
+                        "description": "Also count planned flows",
+                        "name": "include_planned",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Compare with previous_month, previous_year, avg_3m or avg_12m",
+                        "name": "compare",
+                        "in": "query"
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/dto.PicuinhaEntryResponse"
+                            "$ref": "#/definitions/dto.MonthlySummaryResponse"
                         }
                     },
                     "400": {
@@ -953,13 +1138,19 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
                     }
                 }
             }
         },
-        "/picuinhas/entries/{id}": {
+        "/cashflows/{id}": {
             "put": {
-                "description": "Updates a picuinha entry.",
+                "description": "Updates a cash flow. The previous values are kept as a revision.",
                 "consumes": [
                     "application/json"
                 ],
@@ -967,24 +1158,24 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Picuinhas"
+                    "CashFlows"
                 ],
                 "summary": "Atualizar Lançamento",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Entry ID",
+                        "description": "CashFlow ID",
                         "name": "id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Entry Payload",
+                        "description": "CashFlow Payload",
                         "name": "payload",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/dto.UpdateEntryRequest"
+                            "$ref": "#/definitions/dto.UpdateCashFlowRequest"
                         }
                     }
                 ],
@@ -992,7 +1183,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/dto.PicuinhaEntryResponse"
+                            "$ref": "#/definitions/dto.CashFlowResponse"
                         }
                     },
                     "400": {
@@ -1010,7 +1201,7 @@ const docTemplate = `{
                 }
             },
             "delete": {
-                "description": "Deletes a picuinha entry.",
+                "description": "Deletes a cash flow. Flows linked to installment plans cannot be deleted.",
                 "consumes": [
                     "application/json"
                 ],
@@ -1018,13 +1209,13 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Picuinhas"
+                    "CashFlows"
                 ],
                 "summary": "Excluir Lançamento",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Entry ID",
+                        "description": "CashFlow ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -1051,13 +1242,19 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
                     }
                 }
             }
         },
-        "/picuinhas/persons": {
-            "get": {
-                "description": "Returns a list of persons tracking loans/debts.",
+        "/cashflows/{id}/account": {
+            "put": {
+                "description": "Sets the account a cash flow moved money in or out of. A null account_id detaches it.",
                 "consumes": [
                     "application/json"
                 ],
@@ -1065,29 +1262,166 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Picuinhas"
+                    "CashFlows"
+                ],
+                "summary": "Definir Conta do Lançamento",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "CashFlow ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Account",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.SetCashFlowAccountRequest"
+                        }
+                    }
                 ],
-                "summary": "Listar Pessoas e Saldos",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/dto.PersonResponse"
-                            }
+                            "$ref": "#/definitions/dto.CashFlowResponse"
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "400": {
+                        "description": "Bad Request",
                         "schema": {
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
-                    }
-                }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/cashflows/{id}/attachments": {
+            "get": {
+                "description": "Returns the attachments of a cash flow.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Attachments"
+                ],
+                "summary": "Listar Anexos do Lançamento",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Cash Flow ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/dto.AttachmentResponse"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
             },
             "post": {
-                "description": "Registers a new person for sharing expenses.",
+                "description": "Uploads a receipt or document for a cash flow. The type is detected from the content; uploading the same content again returns the existing attachment.",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Attachments"
+                ],
+                "summary": "Anexar Arquivo ao Lançamento",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Cash Flow ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "file",
+                        "description": "File",
+                        "name": "file",
+                        "in": "formData",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Same content already attached",
+                        "schema": {
+                            "$ref": "#/definitions/dto.AttachmentResponse"
+                        }
+                    },
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/dto.AttachmentResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "413": {
+                        "description": "Request Entity Too Large",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "415": {
+                        "description": "Unsupported Media Type",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/cashflows/{id}/cancel": {
+            "post": {
+                "description": "Records that a planned cash flow will not happen. It stays listed with status CANCELLED but counts in no total, balance or invoice.",
                 "consumes": [
                     "application/json"
                 ],
@@ -1095,25 +1429,81 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Picuinhas"
+                    "CashFlows"
                 ],
-                "summary": "Cadastrar Pessoa",
+                "summary": "Cancelar Lançamento Previsto",
                 "parameters": [
                     {
-                        "description": "Person Payload",
+                        "type": "integer",
+                        "description": "CashFlow ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.CashFlowResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/cashflows/{id}/confirm": {
+            "post": {
+                "description": "Confirms a planned cash flow with its actual date and amount, which default to the planned ones. From then on it counts in realized totals and account balances.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "CashFlows"
+                ],
+                "summary": "Confirmar Lançamento Previsto",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "CashFlow ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Actual values",
                         "name": "payload",
                         "in": "body",
-                        "required": true,
                         "schema": {
-                            "$ref": "#/definitions/dto.CreatePersonRequest"
+                            "$ref": "#/definitions/dto.ConfirmCashFlowRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/dto.PersonResponse"
+                            "$ref": "#/definitions/dto.CashFlowResponse"
                         }
                     },
                     "400": {
@@ -1122,8 +1512,14 @@ const docTemplate = `{
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
                         "schema": {
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
@@ -1131,9 +1527,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/picuinhas/persons/{id}": {
+        "/cashflows/{id}/payee": {
             "put": {
-                "description": "Updates a person in picuinhas.",
+                "description": "Sets the payee of a cash flow. A null payee_id unlinks it.",
                 "consumes": [
                     "application/json"
                 ],
@@ -1141,24 +1537,24 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Picuinhas"
+                    "CashFlows"
                 ],
-                "summary": "Atualizar Pessoa",
+                "summary": "Definir Favorecido do Lançamento",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Person ID",
+                        "description": "CashFlow ID",
                         "name": "id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Person Payload",
+                        "description": "Payee",
                         "name": "payload",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/dto.UpdatePersonRequest"
+                            "$ref": "#/definitions/dto.SetCashFlowPayeeRequest"
                         }
                     }
                 ],
@@ -1166,7 +1562,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/dto.PersonResponse"
+                            "$ref": "#/definitions/dto.CashFlowResponse"
                         }
                     },
                     "400": {
@@ -1182,9 +1578,11 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "delete": {
-                "description": "Deletes a person (only if no entries exist).",
+            }
+        },
+        "/cashflows/{id}/revisions": {
+            "get": {
+                "description": "Returns the original values recorded before each update or delete.",
                 "consumes": [
                     "application/json"
                 ],
@@ -1192,13 +1590,13 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Picuinhas"
+                    "CashFlows"
                 ],
-                "summary": "Excluir Pessoa",
+                "summary": "Histórico do Lançamento",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Person ID",
+                        "description": "CashFlow ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -1208,9 +1606,9 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/dto.CashFlowRevisionResponse"
                             }
                         }
                     },
@@ -1219,121 +1617,6301 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
+                    }
+                }
+            }
+        },
+        "/cashflows/{id}/splits": {
+            "get": {
+                "description": "Returns the category splits of a cash flow. Empty when the flow is not split.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "CashFlows"
+                ],
+                "summary": "Listar Divisões do Lançamento",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "CashFlow ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/dto.CashFlowSplitResponse"
+                            }
+                        }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "400": {
+                        "description": "Bad Request",
                         "schema": {
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     },
-                    "409": {
-                        "description": "Conflict",
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     }
                 }
+            },
+            "put": {
+                "description": "Replaces the category splits of a cash flow. The amounts must add up to the flow amount; an empty list removes the splits.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "CashFlows"
+                ],
+                "summary": "Definir Divisões do Lançamento",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "CashFlow ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Splits",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.SetCashFlowSplitsRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/dto.CashFlowSplitResponse"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/cashflows/{id}/tags": {
+            "get": {
+                "description": "Returns the tags of a cash flow.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Tags"
+                ],
+                "summary": "Listar Tags do Lançamento",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Cash Flow ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/dto.TagResponse"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Replaces the tags of a cash flow. Names that do not exist yet are created; an empty list removes all tags.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Tags"
+                ],
+                "summary": "Definir Tags do Lançamento",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Cash Flow ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Tags",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.SetCashFlowTagsRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/dto.TagResponse"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/categories": {
+            "get": {
+                "description": "Returns a list of all categories, optionally filtered by active status.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Categories"
+                ],
+                "summary": "Listar Categorias",
+                "parameters": [
+                    {
+                        "type": "boolean",
+                        "description": "Filter only active categories",
+                        "name": "active",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/dto.CategoryResponse"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Creates a new category for financial flows.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Categories"
+                ],
+                "summary": "Create Categoria",
+                "parameters": [
+                    {
+                        "description": "Category Payload",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.CreateCategoryRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/dto.CategoryResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/categories/{id}": {
+            "put": {
+                "description": "Updates a category by ID.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Categories"
+                ],
+                "summary": "Atualizar Categoria",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Category ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Category Payload",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.UpdateCategoryRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.CategoryResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/categories/{id}/deactivate": {
+            "patch": {
+                "description": "Deactivates a specific category by ID.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Categories"
+                ],
+                "summary": "Desativar Categoria",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Category ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/exchange-rates": {
+            "get": {
+                "description": "Returns exchange rates by currency, newest first, optionally for one currency.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "ExchangeRates"
+                ],
+                "summary": "Listar Cotações",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Currency (e.g. USD)",
+                        "name": "currency",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/dto.ExchangeRateResponse"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Stores what one unit of a currency is worth in the base currency from a date on. A rate for the same currency and date is replaced.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "ExchangeRates"
+                ],
+                "summary": "Registrar Cotação",
+                "parameters": [
+                    {
+                        "description": "Exchange Rate Payload",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.ExchangeRateRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ExchangeRateResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/exchange-rates/effective": {
+            "get": {
+                "description": "Returns the rate used to convert a currency on a date: the latest one on or before it.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "ExchangeRates"
+                ],
+                "summary": "Obter Cotação Vigente",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Currency (e.g. USD)",
+                        "name": "currency",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Date (YYYY-MM-DD), defaults to today",
+                        "name": "date",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ExchangeRateResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/exchange-rates/import": {
+            "post": {
+                "description": "Reads a CSV with the columns currency, date (YYYY-MM-DD) and rate, separated by commas or semicolons. A header line is skipped. Rates of a currency and date already on file are replaced; nothing is saved if a line is invalid.",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "ExchangeRates"
+                ],
+                "summary": "Importar Cotações (CSV)",
+                "parameters": [
+                    {
+                        "type": "file",
+                        "description": "CSV file",
+                        "name": "file",
+                        "in": "formData",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ExchangeRateImportResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/exchange-rates/{id}": {
+            "delete": {
+                "description": "Deletes an exchange rate by ID. Cash flows already converted keep the rate they were converted with.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "ExchangeRates"
+                ],
+                "summary": "Excluir Cotação",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Exchange Rate ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/imports/commit": {
+            "post": {
+                "description": "Creates all items as cash flows in a single transaction. Nothing is saved if one item fails. Items with an already imported FITID are skipped.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Imports"
+                ],
+                "summary": "Confirmar Importação",
+                "parameters": [
+                    {
+                        "description": "Items to import",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.ImportCommitRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ImportCommitResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/imports/csv/preview": {
+            "post": {
+                "description": "Reads a CSV statement with a mapping profile and returns cash flow candidates with suggested categories and duplicate flags.",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Imports"
+                ],
+                "summary": "Pré-visualizar Importação CSV",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Profile ID",
+                        "name": "profile_id",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "file",
+                        "description": "CSV statement",
+                        "name": "file",
+                        "in": "formData",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ImportPreviewResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/imports/ofx/preview": {
+            "post": {
+                "description": "Reads an OFX/QFX statement and returns cash flow candidates. Transactions whose FITID was already imported are flagged and skipped on commit.",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Imports"
+                ],
+                "summary": "Pré-visualizar Importação OFX",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Payment Method ID (required for credit card statements)",
+                        "name": "payment_method_id",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "file",
+                        "description": "OFX statement",
+                        "name": "file",
+                        "in": "formData",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ImportPreviewResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/imports/profiles": {
+            "get": {
+                "description": "Returns all CSV column-mapping profiles.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Imports"
+                ],
+                "summary": "Listar Perfis de Importação",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/dto.ImportProfileResponse"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Creates a column-mapping profile used to read a bank CSV export.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Imports"
+                ],
+                "summary": "Criar Perfil de Importação",
+                "parameters": [
+                    {
+                        "description": "Profile Payload",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.ImportProfileRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ImportProfileResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/imports/profiles/{id}": {
+            "put": {
+                "description": "Updates a CSV column-mapping profile by ID.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Imports"
+                ],
+                "summary": "Atualizar Perfil de Importação",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Profile ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Profile Payload",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.ImportProfileRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ImportProfileResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Deletes a CSV column-mapping profile by ID.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Imports"
+                ],
+                "summary": "Excluir Perfil de Importação",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Profile ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/installments": {
+            "post": {
+                "description": "Creates a new purchase that is split into multiple installments.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Cards"
+                ],
+                "summary": "Criar Compra Parcelada",
+                "parameters": [
+                    {
+                        "description": "Installment Payload",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.CreateInstallmentRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/dto.InstallmentPlanResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/installments/{id}/attachments": {
+            "get": {
+                "description": "Returns the attachments of an installment plan.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Attachments"
+                ],
+                "summary": "Listar Anexos do Parcelamento",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Installment Plan ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/dto.AttachmentResponse"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Uploads a document for an installment plan. The type is detected from the content; uploading the same content again returns the existing attachment.",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Attachments"
+                ],
+                "summary": "Anexar Arquivo ao Parcelamento",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Installment Plan ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "file",
+                        "description": "File",
+                        "name": "file",
+                        "in": "formData",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Same content already attached",
+                        "schema": {
+                            "$ref": "#/definitions/dto.AttachmentResponse"
+                        }
+                    },
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/dto.AttachmentResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "413": {
+                        "description": "Request Entity Too Large",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "415": {
+                        "description": "Unsupported Media Type",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/payees": {
+            "get": {
+                "description": "Returns all payees with their aliases and the number of cash flows linked to each one.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Payees"
+                ],
+                "summary": "Listar Favorecidos",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/dto.PayeeResponse"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Creates a payee with its aliases and optional default category and payment method. Names and aliases are matched against cash flow titles ignoring case and punctuation, and each one can belong to a single payee.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Payees"
+                ],
+                "summary": "Criar Favorecido",
+                "parameters": [
+                    {
+                        "description": "Payee Payload",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.PayeeRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/dto.PayeeResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/payees/match": {
+            "get": {
+                "description": "Returns the payee a cash flow title belongs to: the one with the longest name or alias the title equals or starts with, ignoring case and punctuation.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Payees"
+                ],
+                "summary": "Identificar Favorecido",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Cash flow title",
+                        "name": "title",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.PayeeResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/payees/{id}": {
+            "get": {
+                "description": "Returns a payee by ID.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Payees"
+                ],
+                "summary": "Obter Favorecido",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Payee ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.PayeeResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Replaces the name, aliases and defaults of a payee. Linked cash flows keep the link.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Payees"
+                ],
+                "summary": "Atualizar Favorecido",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Payee ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Payee Payload",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.PayeeRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.PayeeResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Deletes a payee and its aliases. Its cash flows are kept, without a payee.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Payees"
+                ],
+                "summary": "Excluir Favorecido",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Payee ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/payees/{id}/merge": {
+            "post": {
+                "description": "Moves the cash flows of the source payees to the target, adds their names and aliases to its aliases and deletes them.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Payees"
+                ],
+                "summary": "Mesclar Favorecidos",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Target Payee ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Source payees",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.MergePayeesRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.PayeeResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/payees/{id}/report": {
+            "get": {
+                "description": "Returns the realized totals of a payee by category and by month, optionally between two dates. Split cash flows count per split line.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Reports"
+                ],
+                "summary": "Relatório por Favorecido",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Payee ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Start date (YYYY-MM-DD)",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "End date (YYYY-MM-DD)",
+                        "name": "to",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.PayeeReportResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/payment-methods": {
+            "get": {
+                "description": "Returns a list of payment methods.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Cards"
+                ],
+                "summary": "Listar Meios de Pagamento",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/dto.PaymentMethodResponse"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Creates a new payment method.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Cards"
+                ],
+                "summary": "Criar Meio de Pagamento",
+                "parameters": [
+                    {
+                        "description": "Payment Method Payload",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.CreatePaymentMethodRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/dto.PaymentMethodResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/payment-methods/{id}": {
+            "put": {
+                "description": "Updates a payment method by ID.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Cards"
+                ],
+                "summary": "Atualizar Meio de Pagamento",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Payment Method ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Payment Method Payload",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.UpdatePaymentMethodRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.PaymentMethodResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Deactivates a payment method by ID.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Cards"
+                ],
+                "summary": "Excluir Meio de Pagamento",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Payment Method ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/payment-methods/{id}/account": {
+            "put": {
+                "description": "Links a payment method to an account; new cash flows paid with it are assigned to that account. Credit cards cannot be linked. A null account_id removes the link.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Accounts"
+                ],
+                "summary": "Vincular Meio de Pagamento à Conta",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Payment Method ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Account",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.SetPaymentMethodAccountRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.PaymentMethodResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/payment-methods/{id}/invoice": {
+            "get": {
+                "description": "Returns the invoice for a credit car payment method in a specific month.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Cards"
+                ],
+                "summary": "Visualizar Fatura",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Payment Method ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "format": "date",
+                        "example": "2024-03-01",
+                        "description": "Reference Month (YYYY-MM-DD)",
+                        "name": "month",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.InvoiceResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/picuinhas/cases": {
+            "get": {
+                "description": "Returns a list of cases for a person.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Picuinhas"
+                ],
+                "summary": "Listar Picuinhas",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Person ID",
+                        "name": "person_id",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/dto.CaseResponse"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Creates a new picuinha case (one-off, installment, recurring).",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Picuinhas"
+                ],
+                "summary": "Criar Picuinha",
+                "parameters": [
+                    {
+                        "description": "Case Payload",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.CreateCaseRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/dto.CaseResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/picuinhas/cases/{id}": {
+            "put": {
+                "description": "Updates a picuinha case.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Picuinhas"
+                ],
+                "summary": "Atualizar Picuinha",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Case ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Case Payload",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.CreateCaseRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.CaseResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Deletes a picuinha case.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Picuinhas"
+                ],
+                "summary": "Excluir Picuinha",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Case ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/picuinhas/cases/{id}/attachments": {
+            "get": {
+                "description": "Returns the attachments of a picuinha case.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Attachments"
+                ],
+                "summary": "Listar Anexos da Picuinha",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Case ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/dto.AttachmentResponse"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Uploads a proof (e.g. a transfer receipt) for a picuinha case. Cases are installment plans, so the attachment is also listed under /installments/{id}/attachments.",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Attachments"
+                ],
+                "summary": "Anexar Arquivo à Picuinha",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Case ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "file",
+                        "description": "File",
+                        "name": "file",
+                        "in": "formData",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Same content already attached",
+                        "schema": {
+                            "$ref": "#/definitions/dto.AttachmentResponse"
+                        }
+                    },
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/dto.AttachmentResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "413": {
+                        "description": "Request Entity Too Large",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "415": {
+                        "description": "Unsupported Media Type",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/picuinhas/cases/{id}/installments": {
+            "get": {
+                "description": "Returns installments for a picuinha case.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Picuinhas"
+                ],
+                "summary": "Listar Parcelas de Picuinha",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Case ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/dto.CaseInstallmentResponse"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/picuinhas/installments/{id}": {
+            "put": {
+                "description": "Updates a picuinha installment (paid status/extra).",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Picuinhas"
+                ],
+                "summary": "Atualizar Parcela de Picuinha",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Installment ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Installment Payload",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.UpdateCaseInstallmentRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.CaseInstallmentResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/picuinhas/persons": {
+            "get": {
+                "description": "Returns a list of persons tracking loans/debts.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Picuinhas"
+                ],
+                "summary": "Listar Pessoas e Saldos",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/dto.PersonResponse"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Registers a new person for sharing expenses.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Picuinhas"
+                ],
+                "summary": "Cadastrar Pessoa",
+                "parameters": [
+                    {
+                        "description": "Person Payload",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.CreatePersonRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/dto.PersonResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/picuinhas/persons/{id}": {
+            "put": {
+                "description": "Updates a person in picuinhas.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Picuinhas"
+                ],
+                "summary": "Atualizar Pessoa",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Person ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Person Payload",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.UpdatePersonRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.PersonResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Deletes a person (only if no cases exist).",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Picuinhas"
+                ],
+                "summary": "Excluir Pessoa",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Person ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/reconciliations": {
+            "get": {
+                "description": "Returns reconciliations, most recent statement first, optionally filtered by account or credit card.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Reconciliations"
+                ],
+                "summary": "Listar Conciliações",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Account ID",
+                        "name": "account_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Payment method ID",
+                        "name": "payment_method_id",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/dto.ReconciliationResponse"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Opens a reconciliation of an account or credit card against a bank statement. Returns the worksheet: the flows up to the statement date not locked yet, the cleared balance and the difference to the statement.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Reconciliations"
+                ],
+                "summary": "Iniciar Conciliação",
+                "parameters": [
+                    {
+                        "description": "Reconciliation Payload",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.StartReconciliationRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ReconciliationWorksheetResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/reconciliations/{id}": {
+            "get": {
+                "description": "Returns a reconciliation with its flows. While open, lists the candidate flows with their cleared mark; once completed, the flows it locked.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Reconciliations"
+                ],
+                "summary": "Obter Conciliação",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Reconciliation ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ReconciliationWorksheetResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Deletes an open reconciliation. Cleared marks are kept. Completed reconciliations cannot be cancelled.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Reconciliations"
+                ],
+                "summary": "Cancelar Conciliação",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Reconciliation ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/reconciliations/{id}/clear": {
+            "post": {
+                "description": "Marks flows of the statement as cleared, or unmarks them with cleared=false, and returns the updated worksheet with the new difference.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Reconciliations"
+                ],
+                "summary": "Conferir Lançamentos",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Reconciliation ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Cash flows",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.ClearCashFlowsRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ReconciliationWorksheetResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/reconciliations/{id}/complete": {
+            "post": {
+                "description": "Completes the reconciliation when the difference is zero. The cleared flows get locked: they can no longer be edited, moved to another account or deleted.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Reconciliations"
+                ],
+                "summary": "Concluir Conciliação",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Reconciliation ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ReconciliationWorksheetResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/recurrences": {
+            "get": {
+                "description": "Returns all recurrence rules, active and inactive.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Recurrences"
+                ],
+                "summary": "Listar Regras de Recorrência",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/dto.RecurrenceRuleResponse"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Creates a template cash flow with a weekly, monthly or yearly schedule.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Recurrences"
+                ],
+                "summary": "Criar Regra de Recorrência",
+                "parameters": [
+                    {
+                        "description": "Rule Payload",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.RecurrenceRuleRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/dto.RecurrenceRuleResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/recurrences/materialize": {
+            "post": {
+                "description": "Creates the cash flows of every active rule between from_month and to_month. Occurrences generated before are skipped, so the call can be repeated safely.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Recurrences"
+                ],
+                "summary": "Gerar Lançamentos Recorrentes",
+                "parameters": [
+                    {
+                        "description": "Month range",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.MaterializeRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.MaterializeResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/recurrences/{id}": {
+            "put": {
+                "description": "Updates a recurrence rule by ID. Occurrences already generated are not changed.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Recurrences"
+                ],
+                "summary": "Atualizar Regra de Recorrência",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Rule ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Rule Payload",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.RecurrenceRuleRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.RecurrenceRuleResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Deletes a recurrence rule by ID. Cash flows it generated are kept.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Recurrences"
+                ],
+                "summary": "Excluir Regra de Recorrência",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Rule ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/recurrences/{id}/occurrences": {
+            "get": {
+                "description": "Returns the nominal dates already materialized for a rule and the cash flow generated for each one.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Recurrences"
+                ],
+                "summary": "Listar Ocorrências",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Rule ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/dto.RecurrenceOccurrenceResponse"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/reports/annual/{year}": {
+            "get": {
+                "description": "Returns the realized amount of each category in each month of the year, grouped in income and expense, with the yearly total and monthly average of each row, section totals and the net of the year. Transfers are left out. With include_budget the budgeted amount goes alongside each cell. Appending .csv or .xlsx to the year downloads the grid as a spreadsheet instead of JSON.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json",
+                    "text/csv",
+                    "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+                ],
+                "tags": [
+                    "Reports"
+                ],
+                "summary": "Relatório Anual",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Year, optionally with .csv or .xlsx (e.g. 2024.xlsx)",
+                        "name": "year",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "default": false,
+                        "description": "Add the budgeted amounts",
+                        "name": "include_budget",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.AnnualReportResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/reports/categories/growth": {
+            "get": {
+                "description": "Ranks the expense categories whose monthly spending rises over the range by the slope of their linear trend, steepest first, with their total, monthly average and budget. Categories with a flat or falling trend are left out. Defaults to the last 12 months.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Reports"
+                ],
+                "summary": "Despesas que Mais Crescem",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "First month (YYYY-MM-DD)",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Last month (YYYY-MM-DD)",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Max categories (1-50)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.GrowthResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/reports/categories/{id}/history": {
+            "get": {
+                "description": "Returns, for each month in the range, the category's realized total, its planned budget (null without one), the 3- and 6-month moving averages and the change over the previous month, plus the slope of the linear trend over the range. Defaults to the last 12 months.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Reports"
+                ],
+                "summary": "Histórico da Categoria",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Category ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "First month (YYYY-MM-DD)",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Last month (YYYY-MM-DD)",
+                        "name": "to",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.CategoryHistoryResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/reports/cumulative": {
+            "get": {
+                "description": "Returns, for each month in the range, the income, expense and running balance since the first cash flow, split between own money and picuinhas (flows linked to a picuinha entry or to an installment plan of a person). Transfers are left out. Defaults to the last 12 months; planned installments are added only when include_installments is set.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Reports"
+                ],
+                "summary": "Fluxo de Caixa Acumulado",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "First month (YYYY-MM-DD)",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Last month (YYYY-MM-DD)",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "default": false,
+                        "description": "Also count planned installments",
+                        "name": "include_installments",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.CumulativeResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/reports/forecast": {
+            "get": {
+                "description": "Projects income, expense and ending balance for each of the next months, starting from the balance expected at the end of the current one. Combines planned flows (including future installments), the fixed flows of the latest month that had any, recurrence rules not generated yet, unpaid picuinha installments (money expected back) and the average variable spending per category over the last 6 months. Bands reflect the variability of that spending; their low end also assumes no picuinha money comes back.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Reports"
+                ],
+                "summary": "Previsão de Fluxo de Caixa",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "default": 12,
+                        "description": "Months to project (1-36)",
+                        "name": "months",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ForecastResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/reports/monthly/{month}.pdf": {
+            "get": {
+                "description": "Renders a PDF statement of the month: income and expense summary, realized totals by category with bars, budget against actual (as in the budget summary), credit card invoices with entries in the month, open picuinha balances per person and the full list of cash flows. Summary and categories count realized flows only.",
+                "produces": [
+                    "application/pdf"
+                ],
+                "tags": [
+                    "Reports"
+                ],
+                "summary": "Extrato Mensal em PDF",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "example": "2024-03-01.pdf",
+                        "description": "Reference month followed by .pdf (YYYY-MM-DD.pdf)",
+                        "name": "month",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/reports/payees": {
+            "get": {
+                "description": "Ranks payees by the realized amount of their cash flows in one direction, optionally between two dates.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Reports"
+                ],
+                "summary": "Principais Favorecidos",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "OUT (default) or IN",
+                        "name": "direction",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Start date (YYYY-MM-DD)",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "End date (YYYY-MM-DD)",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Number of payees (max 100)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/dto.PayeeTotalResponse"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/reports/reinvestment": {
+            "get": {
+                "description": "Compares, for each month in the range, the realized inflows of categories with the INVESTMENT role (redemptions and yields) with the contributions to them (their outflows). The gap is inflow minus contributions: positive is money still to be reinvested. The cumulative gap runs since the first investment flow; opening_gap is its value before the range. Defaults to the last 12 months.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Reports"
+                ],
+                "summary": "Reinvestimento",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "First month (YYYY-MM-DD)",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Last month (YYYY-MM-DD)",
+                        "name": "to",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ReinvestmentResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/reports/tags/{tag}": {
+            "get": {
+                "description": "Returns the totals of the cash flows carrying a tag, by category and by month. Split cash flows count per split line.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Reports"
+                ],
+                "summary": "Relatório por Tag",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Tag name",
+                        "name": "tag",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.TagReportResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/rules": {
+            "get": {
+                "description": "Returns all rules, active and inactive, in the order they run.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Rules"
+                ],
+                "summary": "Listar Regras de Categorização",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/dto.RuleResponse"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Creates a rule that sets the category, is_fixed, payee or tags of new cash flows matching its conditions. A rule setting a category only matches flows in the category direction.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Rules"
+                ],
+                "summary": "Criar Regra de Categorização",
+                "parameters": [
+                    {
+                        "description": "Rule Payload",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.RuleRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/dto.RuleResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/rules/test": {
+            "post": {
+                "description": "Lists the existing cash flows an unsaved rule would change, with their values before and after. Nothing is saved. Reconciled, cancelled and transfer cash flows are never changed.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Rules"
+                ],
+                "summary": "Testar Regra de Categorização",
+                "parameters": [
+                    {
+                        "description": "Rule Payload",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.RuleRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Start date (YYYY-MM-DD)",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "End date (YYYY-MM-DD)",
+                        "name": "to",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.RuleApplyResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/rules/{id}": {
+            "get": {
+                "description": "Returns a rule by ID.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Rules"
+                ],
+                "summary": "Obter Regra de Categorização",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Rule ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.RuleResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Replaces a rule. Cash flows it already changed are kept as they are.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Rules"
+                ],
+                "summary": "Atualizar Regra de Categorização",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Rule ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Rule Payload",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.RuleRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.RuleResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Deletes a rule. Cash flows it already changed are kept as they are.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Rules"
+                ],
+                "summary": "Excluir Regra de Categorização",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Rule ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/rules/{id}/apply": {
+            "post": {
+                "description": "Applies a saved rule to the existing cash flows it matches, overriding their category, is_fixed and payee. Category and is_fixed changes are recorded as revisions. Reconciled, cancelled and transfer cash flows are never changed.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Rules"
+                ],
+                "summary": "Aplicar Regra Retroativamente",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Rule ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Start date (YYYY-MM-DD)",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "End date (YYYY-MM-DD)",
+                        "name": "to",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.RuleApplyResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/tags": {
+            "get": {
+                "description": "Returns all tags with the number of cash flows carrying each one.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Tags"
+                ],
+                "summary": "Listar Tags",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/dto.TagResponse"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Creates a tag. The name is stored in lower case with spaces replaced by hyphens.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Tags"
+                ],
+                "summary": "Criar Tag",
+                "parameters": [
+                    {
+                        "description": "Tag Payload",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.TagRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/dto.TagResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/tags/{id}": {
+            "put": {
+                "description": "Renames a tag by ID. Tagged cash flows keep the link.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Tags"
+                ],
+                "summary": "Renomear Tag",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Tag ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Tag Payload",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.TagRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.TagResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Deletes a tag by ID and removes it from every cash flow. The cash flows are kept.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Tags"
+                ],
+                "summary": "Excluir Tag",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Tag ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/transfers": {
+            "get": {
+                "description": "Returns transfers ordered by date, optionally limited to a date range.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Transfers"
+                ],
+                "summary": "Listar Transferências",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Start date (YYYY-MM-DD)",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "End date (YYYY-MM-DD)",
+                        "name": "to",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/dto.TransferResponse"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Moves money between two own accounts. Creates an OUT flow on the source account and an IN flow on the destination, atomically. Transfers change account balances but not income, expense or budget actuals.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Transfers"
+                ],
+                "summary": "Criar Transferência",
+                "parameters": [
+                    {
+                        "description": "Transfer Payload",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.CreateTransferRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/dto.TransferResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/transfers/{id}": {
+            "get": {
+                "description": "Returns a transfer by ID with the IDs of its two cash flows.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Transfers"
+                ],
+                "summary": "Obter Transferência",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Transfer ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.TransferResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Deletes a transfer by ID together with both of its cash flows.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Transfers"
+                ],
+                "summary": "Excluir Transferência",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Transfer ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "dto.AccountBalanceResponse": {
+            "type": "object",
+            "properties": {
+                "account_id": {
+                    "type": "integer"
+                },
+                "balance": {
+                    "$ref": "#/definitions/money.Amount"
+                },
+                "is_active": {
+                    "type": "boolean"
+                },
+                "kind": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.AccountBalancesResponse": {
+            "type": "object",
+            "properties": {
+                "accounts": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.AccountBalanceResponse"
+                    }
+                },
+                "date": {
+                    "type": "string"
+                },
+                "total": {
+                    "$ref": "#/definitions/money.Amount"
+                }
+            }
+        },
+        "dto.AccountDailyBalanceResponse": {
+            "type": "object",
+            "properties": {
+                "balance": {
+                    "description": "at the end of the day",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/money.Amount"
+                        }
+                    ]
+                },
+                "date": {
+                    "type": "string"
+                },
+                "total_expense": {
+                    "$ref": "#/definitions/money.Amount"
+                },
+                "total_income": {
+                    "$ref": "#/definitions/money.Amount"
+                }
+            }
+        },
+        "dto.AccountRequest": {
+            "type": "object",
+            "properties": {
+                "institution": {
+                    "type": "string"
+                },
+                "is_active": {
+                    "description": "updates only; defaults to true",
+                    "type": "boolean"
+                },
+                "kind": {
+                    "description": "CHECKING, SAVINGS, WALLET or BROKERAGE",
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "opening_balance": {
+                    "$ref": "#/definitions/money.Amount"
+                },
+                "opening_date": {
+                    "description": "YYYY-MM-DD",
+                    "type": "string"
+                }
+            }
+        },
+        "dto.AccountResponse": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "integer"
+                },
+                "institution": {
+                    "type": "string"
+                },
+                "is_active": {
+                    "type": "boolean"
+                },
+                "kind": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "opening_balance": {
+                    "$ref": "#/definitions/money.Amount"
+                },
+                "opening_date": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.AnnualCellResponse": {
+            "type": "object",
+            "properties": {
+                "actual": {
+                    "$ref": "#/definitions/money.Amount"
+                },
+                "planned": {
+                    "description": "only with include_budget, when a budget covers it",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/money.Amount"
+                        }
+                    ]
+                }
+            }
+        },
+        "dto.AnnualLineResponse": {
+            "type": "object",
+            "properties": {
+                "average": {
+                    "$ref": "#/definitions/dto.AnnualCellResponse"
+                },
+                "category_id": {
+                    "description": "absent for totals",
+                    "type": "integer"
+                },
+                "category_name": {
+                    "type": "string"
+                },
+                "months": {
+                    "description": "January to December",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.AnnualCellResponse"
+                    }
+                },
+                "total": {
+                    "$ref": "#/definitions/dto.AnnualCellResponse"
+                }
+            }
+        },
+        "dto.AnnualReportResponse": {
+            "type": "object",
+            "properties": {
+                "average_months": {
+                    "type": "integer"
+                },
+                "expense": {
+                    "$ref": "#/definitions/dto.AnnualSectionResponse"
+                },
+                "include_budget": {
+                    "type": "boolean"
+                },
+                "income": {
+                    "$ref": "#/definitions/dto.AnnualSectionResponse"
+                },
+                "net": {
+                    "$ref": "#/definitions/dto.AnnualLineResponse"
+                },
+                "year": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.AnnualSectionResponse": {
+            "type": "object",
+            "properties": {
+                "categories": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.AnnualLineResponse"
+                    }
+                },
+                "direction": {
+                    "type": "string"
+                },
+                "total": {
+                    "$ref": "#/definitions/dto.AnnualLineResponse"
+                }
+            }
+        },
+        "dto.AttachmentResponse": {
+            "type": "object",
+            "properties": {
+                "content_type": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "file_name": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "owner_id": {
+                    "type": "integer"
+                },
+                "owner_type": {
+                    "description": "CASH_FLOW or INSTALLMENT_PLAN",
+                    "type": "string"
+                },
+                "sha256": {
+                    "type": "string"
+                },
+                "size_bytes": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.BandResponse": {
+            "type": "object",
+            "properties": {
+                "high": {
+                    "$ref": "#/definitions/money.Amount"
+                },
+                "low": {
+                    "$ref": "#/definitions/money.Amount"
+                }
+            }
+        },
+        "dto.BudgetItemResponse": {
+            "type": "object",
+            "properties": {
+                "actual_amount": {
+                    "$ref": "#/definitions/money.Amount"
+                },
+                "budget_period_id": {
+                    "type": "integer"
+                },
+                "category_id": {
+                    "type": "integer"
+                },
+                "category_name": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "mode": {
+                    "type": "string"
+                },
+                "planned_amount": {
+                    "$ref": "#/definitions/money.Amount"
+                },
+                "target_percent": {
+                    "type": "number"
+                }
+            }
+        },
+        "dto.BudgetSummaryResponse": {
+            "type": "object",
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.BudgetItemResponse"
+                    }
+                },
+                "month": {
+                    "type": "string"
+                },
+                "total_income": {
+                    "$ref": "#/definitions/money.Amount"
+                }
+            }
+        },
+        "dto.BulkBudgetItemRequest": {
+            "type": "object",
+            "properties": {
+                "category_id": {
+                    "type": "integer"
+                },
+                "target_percent": {
+                    "type": "number"
+                }
+            }
+        },
+        "dto.BulkBudgetItemsRequest": {
+            "type": "object",
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.BulkBudgetItemRequest"
+                    }
+                }
+            }
+        },
+        "dto.CaseInstallmentResponse": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "$ref": "#/definitions/money.Amount"
+                },
+                "case_id": {
+                    "type": "integer"
+                },
+                "due_date": {
+                    "type": "string"
+                },
+                "extra_amount": {
+                    "$ref": "#/definitions/money.Amount"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "installment_number": {
+                    "type": "integer"
+                },
+                "is_paid": {
+                    "type": "boolean"
+                },
+                "paid_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.CaseResponse": {
+            "type": "object",
+            "properties": {
+                "amount_paid": {
+                    "$ref": "#/definitions/money.Amount"
+                },
+                "amount_remaining": {
+                    "$ref": "#/definitions/money.Amount"
+                },
+                "case_type": {
+                    "type": "string"
+                },
+                "category_id": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "installment_amount": {
+                    "$ref": "#/definitions/money.Amount"
+                },
+                "installment_count": {
+                    "type": "integer"
+                },
+                "installment_plan_id": {
+                    "type": "integer"
+                },
+                "installments_paid": {
+                    "type": "integer"
+                },
+                "installments_total": {
+                    "type": "integer"
+                },
+                "interest_rate": {
+                    "type": "number"
+                },
+                "interest_rate_unit": {
+                    "type": "string"
+                },
+                "payment_method_id": {
+                    "type": "integer"
+                },
+                "person_id": {
+                    "type": "integer"
+                },
+                "recurrence_interval_months": {
+                    "type": "integer"
+                },
+                "start_date": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "title": {
+                    "type": "string"
+                },
+                "total_amount": {
+                    "$ref": "#/definitions/money.Amount"
+                }
+            }
+        },
+        "dto.CashFlowDuplicatePairResponse": {
+            "type": "object",
+            "properties": {
+                "days_apart": {
+                    "type": "integer"
+                },
+                "first": {
+                    "$ref": "#/definitions/dto.CashFlowDuplicateResponse"
+                },
+                "second": {
+                    "$ref": "#/definitions/dto.CashFlowDuplicateResponse"
+                },
+                "similarity": {
+                    "description": "title similarity, 0 to 1",
+                    "type": "number"
+                }
+            }
+        },
+        "dto.CashFlowDuplicateResponse": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "$ref": "#/definitions/money.Amount"
+                },
+                "category_id": {
+                    "type": "integer"
+                },
+                "category_name": {
+                    "type": "string"
+                },
+                "date": {
+                    "type": "string"
+                },
+                "direction": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "payee_id": {
+                    "type": "integer"
+                },
+                "payment_method_id": {
+                    "type": "integer"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "title": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.CashFlowPageResponse": {
+            "type": "object",
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.CashFlowSearchItem"
+                    }
+                },
+                "limit": {
+                    "type": "integer"
+                },
+                "offset": {
+                    "type": "integer"
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.CashFlowResponse": {
+            "type": "object",
+            "properties": {
+                "account_id": {
+                    "type": "integer"
+                },
+                "amount": {
+                    "$ref": "#/definitions/money.Amount"
+                },
+                "category_id": {
+                    "type": "integer"
+                },
+                "cleared_at": {
+                    "type": "string"
+                },
+                "currency": {
+                    "description": "Set for flows entered in a foreign currency; amount is converted and\nincludes the IOF.",
+                    "type": "string"
+                },
+                "date": {
+                    "type": "string"
+                },
+                "direction": {
+                    "type": "string"
+                },
+                "exchange_rate": {
+                    "type": "number"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "iof_amount": {
+                    "$ref": "#/definitions/money.Amount"
+                },
+                "is_fixed": {
+                    "type": "boolean"
+                },
+                "original_amount": {
+                    "$ref": "#/definitions/money.Amount"
+                },
+                "payee_id": {
+                    "type": "integer"
+                },
+                "reconciled": {
+                    "type": "boolean"
+                },
+                "splits": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.CashFlowSplitResponse"
+                    }
+                },
+                "status": {
+                    "type": "string"
+                },
+                "title": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.CashFlowRevisionResponse": {
+            "type": "object",
+            "properties": {
+                "action": {
+                    "type": "string"
+                },
+                "amount": {
+                    "$ref": "#/definitions/money.Amount"
+                },
+                "cash_flow_id": {
+                    "type": "integer"
+                },
+                "category_id": {
+                    "type": "integer"
+                },
+                "currency": {
+                    "type": "string"
+                },
+                "date": {
+                    "type": "string"
+                },
+                "direction": {
+                    "type": "string"
+                },
+                "exchange_rate": {
+                    "type": "number"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "iof_amount": {
+                    "$ref": "#/definitions/money.Amount"
+                },
+                "is_fixed": {
+                    "type": "boolean"
+                },
+                "original_amount": {
+                    "$ref": "#/definitions/money.Amount"
+                },
+                "revised_at": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "title": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.CashFlowSearchItem": {
+            "type": "object",
+            "properties": {
+                "account_id": {
+                    "type": "integer"
+                },
+                "amount": {
+                    "$ref": "#/definitions/money.Amount"
+                },
+                "category_id": {
+                    "type": "integer"
+                },
+                "category_name": {
+                    "type": "string"
+                },
+                "cleared": {
+                    "type": "boolean"
+                },
+                "date": {
+                    "type": "string"
+                },
+                "direction": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "is_fixed": {
+                    "type": "boolean"
+                },
+                "payee_id": {
+                    "type": "integer"
+                },
+                "reconciled": {
+                    "type": "boolean"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "title": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.CashFlowSplitRequest": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "$ref": "#/definitions/money.Amount"
+                },
+                "category_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.CashFlowSplitResponse": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "$ref": "#/definitions/money.Amount"
+                },
+                "category_id": {
+                    "type": "integer"
+                },
+                "category_name": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.CategoryGrowthResponse": {
+            "type": "object",
+            "properties": {
+                "category_id": {
+                    "type": "integer"
+                },
+                "category_name": {
+                    "type": "string"
+                },
+                "growth_pct": {
+                    "type": "number"
+                },
+                "monthly_average": {
+                    "$ref": "#/definitions/money.Amount"
+                },
+                "planned": {
+                    "$ref": "#/definitions/money.Amount"
+                },
+                "total": {
+                    "$ref": "#/definitions/money.Amount"
+                },
+                "trend_slope": {
+                    "type": "number"
+                }
+            }
+        },
+        "dto.CategoryHistoryResponse": {
+            "type": "object",
+            "properties": {
+                "category_id": {
+                    "type": "integer"
+                },
+                "category_name": {
+                    "type": "string"
+                },
+                "direction": {
+                    "type": "string"
+                },
+                "from": {
+                    "type": "string"
+                },
+                "months": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.CategoryMonthResponse"
+                    }
+                },
+                "to": {
+                    "type": "string"
+                },
+                "trend_slope": {
+                    "description": "per month",
+                    "type": "number"
+                }
+            }
+        },
+        "dto.CategoryMonthResponse": {
+            "type": "object",
+            "properties": {
+                "change_pct": {
+                    "type": "number"
+                },
+                "month": {
+                    "type": "string"
+                },
+                "moving_avg_3m": {
+                    "$ref": "#/definitions/money.Amount"
+                },
+                "moving_avg_6m": {
+                    "$ref": "#/definitions/money.Amount"
+                },
+                "planned": {
+                    "$ref": "#/definitions/money.Amount"
+                },
+                "total": {
+                    "$ref": "#/definitions/money.Amount"
+                }
+            }
+        },
+        "dto.CategoryResponse": {
+            "type": "object",
+            "properties": {
+                "direction": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "inactive_from_month": {
+                    "type": "string"
+                },
+                "is_active": {
+                    "type": "boolean"
+                },
+                "is_budget_relevant": {
+                    "type": "boolean"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "role": {
+                    "description": "REGULAR, TRANSFER or INVESTMENT",
+                    "type": "string"
+                }
+            }
+        },
+        "dto.CategorySummaryResponse": {
+            "type": "object",
+            "properties": {
+                "baseline_amount": {
+                    "$ref": "#/definitions/money.Amount"
+                },
+                "biggest_swing": {
+                    "type": "boolean"
+                },
+                "category_id": {
+                    "type": "integer"
+                },
+                "category_name": {
+                    "type": "string"
+                },
+                "change_pct": {
+                    "type": "number"
+                },
+                "delta": {
+                    "$ref": "#/definitions/money.Amount"
+                },
+                "direction": {
+                    "type": "string"
+                },
+                "total_amount": {
+                    "$ref": "#/definitions/money.Amount"
+                }
+            }
+        },
+        "dto.ChangeResponse": {
+            "type": "object",
+            "properties": {
+                "baseline": {
+                    "$ref": "#/definitions/money.Amount"
+                },
+                "change_pct": {
+                    "description": "null when the baseline is zero",
+                    "type": "number"
+                },
+                "delta": {
+                    "$ref": "#/definitions/money.Amount"
+                }
+            }
+        },
+        "dto.ClearCashFlowsRequest": {
+            "type": "object",
+            "properties": {
+                "cash_flow_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "cleared": {
+                    "description": "defaults to true; false unmarks",
+                    "type": "boolean"
+                }
+            }
+        },
+        "dto.ConfirmCashFlowRequest": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "description": "actual amount, in the flow currency; defaults to the planned one",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/money.Amount"
+                        }
+                    ]
+                },
+                "date": {
+                    "description": "actual date, YYYY-MM-DD; defaults to the planned one",
+                    "type": "string"
+                }
+            }
+        },
+        "dto.CopyFixedItemResponse": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "$ref": "#/definitions/money.Amount"
+                },
+                "cash_flow_id": {
+                    "type": "integer"
+                },
+                "category_id": {
+                    "type": "integer"
+                },
+                "date": {
+                    "type": "string"
+                },
+                "direction": {
+                    "type": "string"
+                },
+                "source_id": {
+                    "type": "integer"
+                },
+                "title": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.CopyFixedRequest": {
+            "type": "object",
+            "properties": {
+                "dry_run": {
+                    "type": "boolean"
+                },
+                "from_month": {
+                    "type": "string"
+                },
+                "to_month": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.CopyFixedResponse": {
+            "type": "object",
+            "properties": {
+                "conflict_count": {
+                    "type": "integer"
+                },
+                "conflicts": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.CopyFixedItemResponse"
+                    }
+                },
+                "copied_count": {
+                    "type": "integer"
+                },
+                "created": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.CopyFixedItemResponse"
+                    }
+                },
+                "dry_run": {
+                    "type": "boolean"
+                },
+                "skipped": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.CopyFixedItemResponse"
+                    }
+                },
+                "skipped_count": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.CreateCaseRequest": {
+            "type": "object",
+            "properties": {
+                "case_type": {
+                    "type": "string"
+                },
+                "category_id": {
+                    "type": "integer"
+                },
+                "installment_amount": {
+                    "$ref": "#/definitions/money.Amount"
+                },
+                "installment_count": {
+                    "type": "integer"
+                },
+                "installment_plan_id": {
+                    "type": "integer"
+                },
+                "interest_rate": {
+                    "type": "number"
+                },
+                "interest_rate_unit": {
+                    "type": "string"
+                },
+                "payment_method_id": {
+                    "type": "integer"
+                },
+                "person_id": {
+                    "type": "integer"
+                },
+                "recurrence_interval_months": {
+                    "type": "integer"
+                },
+                "start_date": {
+                    "type": "string"
+                },
+                "title": {
+                    "type": "string"
+                },
+                "total_amount": {
+                    "$ref": "#/definitions/money.Amount"
+                }
+            }
+        },
+        "dto.CreateCashFlowRequest": {
+            "type": "object",
+            "properties": {
+                "account_id": {
+                    "type": "integer"
+                },
+                "allow_duplicate": {
+                    "description": "create even if it looks like an existing flow",
+                    "type": "boolean"
+                },
+                "amount": {
+                    "$ref": "#/definitions/money.Amount"
+                },
+                "category_id": {
+                    "type": "integer"
+                },
+                "currency": {
+                    "description": "ISO code; amount is in this currency and gets converted",
+                    "type": "string"
+                },
+                "date": {
+                    "description": "YYYY-MM-DD",
+                    "type": "string"
+                },
+                "direction": {
+                    "type": "string"
+                },
+                "iof_amount": {
+                    "description": "IOF in the base currency, added to the converted amount",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/money.Amount"
+                        }
+                    ]
+                },
+                "is_fixed": {
+                    "type": "boolean"
+                },
+                "payee_id": {
+                    "description": "defaults to the payee matched by title",
+                    "type": "integer"
+                },
+                "splits": {
+                    "description": "must add up to amount",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.CashFlowSplitRequest"
+                    }
+                },
+                "status": {
+                    "description": "PLANNED or REALIZED (default)",
+                    "type": "string"
+                },
+                "title": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.CreateCategoryRequest": {
+            "type": "object",
+            "properties": {
+                "direction": {
+                    "description": "IN or OUT",
+                    "type": "string"
+                },
+                "is_budget_relevant": {
+                    "type": "boolean"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "role": {
+                    "description": "REGULAR (default) or INVESTMENT",
+                    "type": "string"
+                }
+            }
+        },
+        "dto.CreateInstallmentRequest": {
+            "type": "object",
+            "properties": {
+                "amount_mode": {
+                    "type": "string"
+                },
+                "category_id": {
+                    "type": "integer"
+                },
+                "count": {
+                    "type": "integer"
+                },
+                "currency": {
+                    "description": "ISO code; amounts are in this currency and get converted",
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "installment_amount": {
+                    "$ref": "#/definitions/money.Amount"
+                },
+                "iof_amount": {
+                    "description": "overrides the IOF computed for credit cards",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/money.Amount"
+                        }
+                    ]
+                },
+                "payment_method_id": {
+                    "type": "integer"
+                },
+                "purchase_date": {
+                    "description": "YYYY-MM-DD",
+                    "type": "string"
+                },
+                "total_amount": {
+                    "$ref": "#/definitions/money.Amount"
+                }
+            }
+        },
+        "dto.CreatePaymentMethodRequest": {
+            "type": "object",
+            "properties": {
+                "bank_name": {
+                    "type": "string"
+                },
+                "closing_day": {
+                    "type": "integer"
+                },
+                "credit_limit": {
+                    "$ref": "#/definitions/money.Amount"
+                },
+                "due_day": {
+                    "type": "integer"
+                },
+                "kind": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.CreatePersonRequest": {
+            "type": "object",
+            "properties": {
+                "name": {
+                    "type": "string"
+                },
+                "notes": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.CreateTransferRequest": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "$ref": "#/definitions/money.Amount"
+                },
+                "date": {
+                    "description": "YYYY-MM-DD",
+                    "type": "string"
+                },
+                "from_account_id": {
+                    "type": "integer"
+                },
+                "title": {
+                    "description": "defaults to \"Transferência\"",
+                    "type": "string"
+                },
+                "to_account_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.CumulativeMonthResponse": {
+            "type": "object",
+            "properties": {
+                "balance": {
+                    "$ref": "#/definitions/money.Amount"
+                },
+                "expense": {
+                    "$ref": "#/definitions/money.Amount"
+                },
+                "income": {
+                    "$ref": "#/definitions/money.Amount"
+                },
+                "month": {
+                    "description": "YYYY-MM-DD, first day of the month",
+                    "type": "string"
+                },
+                "net": {
+                    "$ref": "#/definitions/money.Amount"
+                },
+                "own": {
+                    "$ref": "#/definitions/dto.ReportTotalsResponse"
+                },
+                "picuinha": {
+                    "$ref": "#/definitions/dto.ReportTotalsResponse"
+                },
+                "planned_installments": {
+                    "$ref": "#/definitions/money.Amount"
+                }
+            }
+        },
+        "dto.CumulativeResponse": {
+            "type": "object",
+            "properties": {
+                "from": {
+                    "type": "string"
+                },
+                "include_installments": {
+                    "type": "boolean"
+                },
+                "months": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.CumulativeMonthResponse"
+                    }
+                },
+                "opening_balance": {
+                    "$ref": "#/definitions/money.Amount"
+                },
+                "to": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.DuplicateCashFlowErrorResponse": {
+            "type": "object",
+            "properties": {
+                "candidates": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.CashFlowDuplicateResponse"
+                    }
+                },
+                "error": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.ErrorResponse": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.ExchangeRateImportResponse": {
+            "type": "object",
+            "properties": {
+                "imported_count": {
+                    "type": "integer"
+                },
+                "rates": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.ExchangeRateResponse"
+                    }
+                }
+            }
+        },
+        "dto.ExchangeRateRequest": {
+            "type": "object",
+            "properties": {
+                "currency": {
+                    "description": "ISO code, e.g. USD",
+                    "type": "string"
+                },
+                "date": {
+                    "description": "YYYY-MM-DD; the rate holds from this day on",
+                    "type": "string"
+                },
+                "rate": {
+                    "description": "base currency per unit",
+                    "type": "number"
+                }
+            }
+        },
+        "dto.ExchangeRateResponse": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "currency": {
+                    "type": "string"
+                },
+                "date": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "rate": {
+                    "type": "number"
+                }
+            }
+        },
+        "dto.ForecastMonthResponse": {
+            "type": "object",
+            "properties": {
+                "balance": {
+                    "$ref": "#/definitions/money.Amount"
+                },
+                "balance_band": {
+                    "$ref": "#/definitions/dto.BandResponse"
+                },
+                "expense": {
+                    "$ref": "#/definitions/money.Amount"
+                },
+                "expense_band": {
+                    "$ref": "#/definitions/dto.BandResponse"
+                },
+                "income": {
+                    "$ref": "#/definitions/money.Amount"
+                },
+                "income_band": {
+                    "$ref": "#/definitions/dto.BandResponse"
+                },
+                "month": {
+                    "type": "string"
+                },
+                "net": {
+                    "$ref": "#/definitions/money.Amount"
+                },
+                "sources": {
+                    "$ref": "#/definitions/dto.ForecastSourcesResponse"
+                }
+            }
+        },
+        "dto.ForecastResponse": {
+            "type": "object",
+            "properties": {
+                "from": {
+                    "type": "string"
+                },
+                "history_from": {
+                    "type": "string"
+                },
+                "history_to": {
+                    "type": "string"
+                },
+                "lowest_balance": {
+                    "description": "pessimistic, the low end of the band",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/money.Amount"
+                        }
+                    ]
+                },
+                "lowest_month": {
+                    "type": "string"
+                },
+                "months": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.ForecastMonthResponse"
+                    }
+                },
+                "opening_balance": {
+                    "$ref": "#/definitions/money.Amount"
+                },
+                "to": {
+                    "type": "string"
+                },
+                "variable_spending": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.VariableSpendingResponse"
+                    }
+                }
+            }
+        },
+        "dto.ForecastSourcesResponse": {
+            "type": "object",
+            "properties": {
+                "fixed_expense": {
+                    "$ref": "#/definitions/money.Amount"
+                },
+                "fixed_income": {
+                    "$ref": "#/definitions/money.Amount"
+                },
+                "installments": {
+                    "$ref": "#/definitions/money.Amount"
+                },
+                "picuinha_receivables": {
+                    "$ref": "#/definitions/money.Amount"
+                },
+                "planned_expense": {
+                    "$ref": "#/definitions/money.Amount"
+                },
+                "planned_income": {
+                    "$ref": "#/definitions/money.Amount"
+                },
+                "recurring_expense": {
+                    "$ref": "#/definitions/money.Amount"
+                },
+                "recurring_income": {
+                    "$ref": "#/definitions/money.Amount"
+                },
+                "variable_expense": {
+                    "$ref": "#/definitions/money.Amount"
+                }
+            }
+        },
+        "dto.GrowthResponse": {
+            "type": "object",
+            "properties": {
+                "categories": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.CategoryGrowthResponse"
+                    }
+                },
+                "from": {
+                    "type": "string"
+                },
+                "to": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.ImportCandidateResponse": {
+            "type": "object",
+            "properties": {
+                "already_imported": {
+                    "type": "boolean"
+                },
+                "amount": {
+                    "$ref": "#/definitions/money.Amount"
+                },
+                "date": {
+                    "type": "string"
+                },
+                "direction": {
+                    "type": "string"
+                },
+                "duplicate": {
+                    "type": "boolean"
+                },
+                "duplicate_of_id": {
+                    "type": "integer"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "external_account": {
+                    "type": "string"
+                },
+                "fitid": {
+                    "type": "string"
+                },
+                "is_fixed": {
+                    "type": "boolean"
+                },
+                "line": {
+                    "type": "integer"
+                },
+                "payee_id": {
+                    "type": "integer"
+                },
+                "suggested_category_id": {
+                    "type": "integer"
+                },
+                "suggested_category_name": {
+                    "type": "string"
+                },
+                "title": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.ImportCommitItem": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "$ref": "#/definitions/money.Amount"
+                },
+                "category_id": {
+                    "type": "integer"
+                },
+                "date": {
+                    "description": "YYYY-MM-DD",
+                    "type": "string"
+                },
+                "direction": {
+                    "type": "string"
+                },
+                "external_account": {
+                    "type": "string"
+                },
+                "fitid": {
+                    "type": "string"
+                },
+                "is_fixed": {
+                    "type": "boolean"
+                },
+                "payee_id": {
+                    "description": "Optional; matched from the title when omitted.",
+                    "type": "integer"
+                },
+                "payment_method_id": {
+                    "description": "Optional, filled from OFX previews.",
+                    "type": "integer"
+                },
+                "title": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.ImportCommitRequest": {
+            "type": "object",
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.ImportCommitItem"
+                    }
+                }
+            }
+        },
+        "dto.ImportCommitResponse": {
+            "type": "object",
+            "properties": {
+                "created_count": {
+                    "type": "integer"
+                },
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.CashFlowResponse"
+                    }
+                },
+                "skipped_count": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.ImportPreviewResponse": {
+            "type": "object",
+            "properties": {
+                "candidates": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.ImportCandidateResponse"
+                    }
+                },
+                "duplicate_count": {
+                    "type": "integer"
+                },
+                "error_count": {
+                    "type": "integer"
+                },
+                "payment_method_id": {
+                    "type": "integer"
+                },
+                "valid_count": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.ImportProfileRequest": {
+            "type": "object",
+            "properties": {
+                "amount_column": {
+                    "description": "zero-based",
+                    "type": "integer"
+                },
+                "date_column": {
+                    "description": "zero-based",
+                    "type": "integer"
+                },
+                "date_format": {
+                    "description": "default DD/MM/YYYY",
+                    "type": "string"
+                },
+                "decimal_comma": {
+                    "description": "default true (1.234,56)",
+                    "type": "boolean"
+                },
+                "delimiter": {
+                    "description": "default \";\"",
+                    "type": "string"
+                },
+                "has_header": {
+                    "description": "default true",
+                    "type": "boolean"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "sign_convention": {
+                    "description": "NEGATIVE_IS_OUT (default) or POSITIVE_IS_OUT",
+                    "type": "string"
+                },
+                "title_column": {
+                    "description": "zero-based",
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.ImportProfileResponse": {
+            "type": "object",
+            "properties": {
+                "amount_column": {
+                    "type": "integer"
+                },
+                "date_column": {
+                    "type": "integer"
+                },
+                "date_format": {
+                    "type": "string"
+                },
+                "decimal_comma": {
+                    "type": "boolean"
+                },
+                "delimiter": {
+                    "type": "string"
+                },
+                "has_header": {
+                    "type": "boolean"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "sign_convention": {
+                    "type": "string"
+                },
+                "title_column": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.InstallmentPlanResponse": {
+            "type": "object",
+            "properties": {
+                "currency": {
+                    "description": "Set for purchases in a foreign currency; the amounts above are\nconverted and include the IOF.",
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "exchange_rate": {
+                    "type": "number"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "installment_amount": {
+                    "$ref": "#/definitions/money.Amount"
+                },
+                "installment_count": {
+                    "type": "integer"
+                },
+                "iof_amount": {
+                    "$ref": "#/definitions/money.Amount"
+                },
+                "original_amount": {
+                    "$ref": "#/definitions/money.Amount"
+                },
+                "payment_method_id": {
+                    "type": "integer"
+                },
+                "start_month": {
+                    "type": "string"
+                },
+                "total_amount": {
+                    "$ref": "#/definitions/money.Amount"
+                }
+            }
+        },
+        "dto.InvoiceEntryResponse": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "$ref": "#/definitions/money.Amount"
+                },
+                "cash_flow_id": {
+                    "type": "integer"
+                },
+                "category_name": {
+                    "type": "string"
+                },
+                "currency": {
+                    "type": "string"
+                },
+                "date": {
+                    "type": "string"
+                },
+                "iof_amount": {
+                    "$ref": "#/definitions/money.Amount"
+                },
+                "original_amount": {
+                    "$ref": "#/definitions/money.Amount"
+                },
+                "title": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.InvoiceResponse": {
+            "type": "object",
+            "properties": {
+                "entries": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.InvoiceEntryResponse"
+                    }
+                },
+                "month": {
+                    "type": "string"
+                },
+                "payment_method_id": {
+                    "type": "integer"
+                },
+                "total": {
+                    "$ref": "#/definitions/money.Amount"
+                },
+                "total_iof": {
+                    "$ref": "#/definitions/money.Amount"
+                },
+                "total_remaining": {
+                    "$ref": "#/definitions/money.Amount"
+                }
+            }
+        },
+        "dto.MaterializeFailureResponse": {
+            "type": "object",
+            "properties": {
+                "date": {
+                    "type": "string"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "rule_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.MaterializeRequest": {
+            "type": "object",
+            "properties": {
+                "from_month": {
+                    "type": "string"
+                },
+                "to_month": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.MaterializeResponse": {
+            "type": "object",
+            "properties": {
+                "created": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.RecurrenceOccurrenceResponse"
+                    }
+                },
+                "created_count": {
+                    "type": "integer"
+                },
+                "failed": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.MaterializeFailureResponse"
+                    }
+                },
+                "failed_count": {
+                    "type": "integer"
+                },
+                "skipped_count": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.MergePayeesRequest": {
+            "type": "object",
+            "properties": {
+                "source_ids": {
+                    "description": "payees folded into the target and deleted",
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                }
+            }
+        },
+        "dto.MonthlySummaryResponse": {
+            "type": "object",
+            "properties": {
+                "balance": {
+                    "$ref": "#/definitions/money.Amount"
+                },
+                "comparison": {
+                    "$ref": "#/definitions/dto.SummaryComparisonResponse"
+                },
+                "total_expense": {
+                    "$ref": "#/definitions/money.Amount"
+                },
+                "total_income": {
+                    "$ref": "#/definitions/money.Amount"
+                }
+            }
+        },
+        "dto.PayeeCategoryTotalResponse": {
+            "type": "object",
+            "properties": {
+                "cash_flow_count": {
+                    "type": "integer"
+                },
+                "category_id": {
+                    "type": "integer"
+                },
+                "category_name": {
+                    "type": "string"
+                },
+                "direction": {
+                    "type": "string"
+                },
+                "total_amount": {
+                    "$ref": "#/definitions/money.Amount"
+                }
+            }
+        },
+        "dto.PayeeMonthTotalResponse": {
+            "type": "object",
+            "properties": {
+                "month": {
+                    "description": "YYYY-MM-DD, first day of the month",
+                    "type": "string"
+                },
+                "total_expense": {
+                    "$ref": "#/definitions/money.Amount"
+                },
+                "total_income": {
+                    "$ref": "#/definitions/money.Amount"
+                }
+            }
+        },
+        "dto.PayeeReportResponse": {
+            "type": "object",
+            "properties": {
+                "by_category": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.PayeeCategoryTotalResponse"
+                    }
+                },
+                "by_month": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.PayeeMonthTotalResponse"
+                    }
+                },
+                "from": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "payee_id": {
+                    "type": "integer"
+                },
+                "to": {
+                    "type": "string"
+                },
+                "total_expense": {
+                    "$ref": "#/definitions/money.Amount"
+                },
+                "total_income": {
+                    "$ref": "#/definitions/money.Amount"
+                }
+            }
+        },
+        "dto.PayeeRequest": {
+            "type": "object",
+            "properties": {
+                "aliases": {
+                    "description": "other spellings found in titles; replaces the current ones on update",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "default_category_id": {
+                    "type": "integer"
+                },
+                "default_payment_method_id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.PayeeResponse": {
+            "type": "object",
+            "properties": {
+                "aliases": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "cash_flow_count": {
+                    "type": "integer"
+                },
+                "default_category_id": {
+                    "type": "integer"
+                },
+                "default_payment_method_id": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.PayeeTotalResponse": {
+            "type": "object",
+            "properties": {
+                "cash_flow_count": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "payee_id": {
+                    "type": "integer"
+                },
+                "total_amount": {
+                    "$ref": "#/definitions/money.Amount"
+                }
+            }
+        },
+        "dto.PaymentMethodResponse": {
+            "type": "object",
+            "properties": {
+                "account_id": {
+                    "type": "integer"
+                },
+                "bank_name": {
+                    "type": "string"
+                },
+                "closing_day": {
+                    "type": "integer"
+                },
+                "credit_limit": {
+                    "$ref": "#/definitions/money.Amount"
+                },
+                "due_day": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "is_active": {
+                    "type": "boolean"
+                },
+                "kind": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.PersonResponse": {
+            "type": "object",
+            "properties": {
+                "balance": {
+                    "$ref": "#/definitions/money.Amount"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "notes": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.PruneAttachmentsResponse": {
+            "type": "object",
+            "properties": {
+                "removed": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.ReconciliationItemResponse": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "$ref": "#/definitions/money.Amount"
+                },
+                "cash_flow_id": {
+                    "type": "integer"
+                },
+                "category_name": {
+                    "type": "string"
+                },
+                "cleared": {
+                    "type": "boolean"
+                },
+                "date": {
+                    "type": "string"
+                },
+                "direction": {
+                    "type": "string"
+                },
+                "title": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.ReconciliationResponse": {
+            "type": "object",
+            "properties": {
+                "account_id": {
+                    "type": "integer"
+                },
+                "cleared_balance": {
+                    "$ref": "#/definitions/money.Amount"
+                },
+                "completed_at": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "difference": {
+                    "$ref": "#/definitions/money.Amount"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "payment_method_id": {
+                    "type": "integer"
+                },
+                "statement_balance": {
+                    "$ref": "#/definitions/money.Amount"
+                },
+                "statement_date": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.ReconciliationWorksheetResponse": {
+            "type": "object",
+            "properties": {
+                "account_id": {
+                    "type": "integer"
+                },
+                "cleared_balance": {
+                    "$ref": "#/definitions/money.Amount"
+                },
+                "completed_at": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "difference": {
+                    "$ref": "#/definitions/money.Amount"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.ReconciliationItemResponse"
+                    }
+                },
+                "payment_method_id": {
+                    "type": "integer"
+                },
+                "statement_balance": {
+                    "$ref": "#/definitions/money.Amount"
+                },
+                "statement_date": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                }
             }
-        }
-    },
-    "definitions": {
-        "dto.AddEntryRequest": {
+        },
+        "dto.RecurrenceOccurrenceResponse": {
+            "type": "object",
+            "properties": {
+                "cash_flow_id": {
+                    "type": "integer"
+                },
+                "date": {
+                    "type": "string"
+                },
+                "rule_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.RecurrenceRuleRequest": {
             "type": "object",
             "properties": {
                 "amount": {
-                    "type": "number"
+                    "$ref": "#/definitions/money.Amount"
                 },
-                "auto_create_flow": {
-                    "type": "boolean"
+                "business_day_adjust": {
+                    "description": "NONE (default), PREVIOUS or NEXT",
+                    "type": "string"
                 },
-                "cash_flow_id": {
-                    "description": "Optional",
+                "by_month_day": {
+                    "description": "1-31 or -1 (last day); defaults to the start date day",
                     "type": "integer"
                 },
-                "kind": {
-                    "description": "PLUS or MINUS",
+                "category_id": {
+                    "type": "integer"
+                },
+                "direction": {
                     "type": "string"
                 },
-                "person_id": {
+                "end_date": {
+                    "description": "YYYY-MM-DD",
+                    "type": "string"
+                },
+                "frequency": {
+                    "description": "WEEKLY, MONTHLY or YEARLY",
+                    "type": "string"
+                },
+                "interval": {
+                    "description": "default 1",
+                    "type": "integer"
+                },
+                "is_active": {
+                    "description": "update only; default true",
+                    "type": "boolean"
+                },
+                "is_fixed": {
+                    "description": "default true",
+                    "type": "boolean"
+                },
+                "payment_method_id": {
                     "type": "integer"
+                },
+                "start_date": {
+                    "description": "YYYY-MM-DD",
+                    "type": "string"
+                },
+                "title": {
+                    "type": "string"
                 }
             }
         },
-        "dto.BudgetItemResponse": {
+        "dto.RecurrenceRuleResponse": {
             "type": "object",
             "properties": {
-                "actual_amount": {
-                    "type": "number"
+                "amount": {
+                    "$ref": "#/definitions/money.Amount"
                 },
-                "budget_period_id": {
+                "business_day_adjust": {
+                    "type": "string"
+                },
+                "by_month_day": {
                     "type": "integer"
                 },
                 "category_id": {
                     "type": "integer"
                 },
-                "category_name": {
+                "direction": {
+                    "type": "string"
+                },
+                "end_date": {
+                    "type": "string"
+                },
+                "frequency": {
                     "type": "string"
                 },
                 "id": {
                     "type": "integer"
                 },
-                "mode": {
+                "interval": {
+                    "type": "integer"
+                },
+                "is_active": {
+                    "type": "boolean"
+                },
+                "is_fixed": {
+                    "type": "boolean"
+                },
+                "payment_method_id": {
+                    "type": "integer"
+                },
+                "start_date": {
                     "type": "string"
                 },
-                "planned_amount": {
-                    "type": "number"
+                "title": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.ReinvestmentMonthResponse": {
+            "type": "object",
+            "properties": {
+                "contributions": {
+                    "description": "money put into investments",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/money.Amount"
+                        }
+                    ]
                 },
-                "target_percent": {
-                    "type": "number"
+                "cumulative_gap": {
+                    "$ref": "#/definitions/money.Amount"
+                },
+                "gap": {
+                    "description": "inflow - contributions; positive is still to reinvest",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/money.Amount"
+                        }
+                    ]
+                },
+                "inflow": {
+                    "description": "redemptions and yields",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/money.Amount"
+                        }
+                    ]
+                },
+                "month": {
+                    "type": "string"
                 }
             }
         },
-        "dto.BudgetSummaryResponse": {
+        "dto.ReinvestmentResponse": {
             "type": "object",
             "properties": {
-                "items": {
+                "closing_gap": {
+                    "$ref": "#/definitions/money.Amount"
+                },
+                "contributions": {
+                    "$ref": "#/definitions/money.Amount"
+                },
+                "from": {
+                    "type": "string"
+                },
+                "gap": {
+                    "$ref": "#/definitions/money.Amount"
+                },
+                "inflow": {
+                    "$ref": "#/definitions/money.Amount"
+                },
+                "months": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/dto.BudgetItemResponse"
+                        "$ref": "#/definitions/dto.ReinvestmentMonthResponse"
                     }
                 },
-                "month": {
-                    "type": "string"
+                "opening_gap": {
+                    "$ref": "#/definitions/money.Amount"
                 },
-                "total_income": {
-                    "type": "number"
+                "to": {
+                    "type": "string"
                 }
             }
         },
-        "dto.BulkBudgetItemRequest": {
+        "dto.ReportTotalsResponse": {
             "type": "object",
             "properties": {
-                "category_id": {
-                    "type": "integer"
+                "balance": {
+                    "description": "running, since the first cash flow",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/money.Amount"
+                        }
+                    ]
                 },
-                "target_percent": {
-                    "type": "number"
+                "expense": {
+                    "$ref": "#/definitions/money.Amount"
+                },
+                "income": {
+                    "$ref": "#/definitions/money.Amount"
+                },
+                "net": {
+                    "$ref": "#/definitions/money.Amount"
                 }
             }
         },
-        "dto.BulkBudgetItemsRequest": {
+        "dto.RuleApplyResponse": {
             "type": "object",
             "properties": {
-                "items": {
+                "changed_count": {
+                    "type": "integer"
+                },
+                "changes": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/dto.BulkBudgetItemRequest"
+                        "$ref": "#/definitions/dto.RuleChangeResponse"
                     }
+                },
+                "dry_run": {
+                    "type": "boolean"
                 }
             }
         },
-        "dto.CashFlowResponse": {
+        "dto.RuleChangeResponse": {
             "type": "object",
             "properties": {
+                "add_tags": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "after": {
+                    "$ref": "#/definitions/dto.RuleValuesResponse"
+                },
                 "amount": {
-                    "type": "number"
+                    "$ref": "#/definitions/money.Amount"
                 },
-                "category_id": {
+                "before": {
+                    "$ref": "#/definitions/dto.RuleValuesResponse"
+                },
+                "cash_flow_id": {
                     "type": "integer"
                 },
                 "date": {
@@ -1342,364 +7920,502 @@ const docTemplate = `{
                 "direction": {
                     "type": "string"
                 },
-                "id": {
-                    "type": "integer"
-                },
-                "is_fixed": {
-                    "type": "boolean"
-                },
                 "title": {
                     "type": "string"
                 }
             }
         },
-        "dto.CategoryResponse": {
+        "dto.RuleRequest": {
             "type": "object",
             "properties": {
+                "add_tags": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
                 "direction": {
                     "type": "string"
                 },
-                "id": {
-                    "type": "integer"
+                "is_active": {
+                    "description": "default true",
+                    "type": "boolean"
                 },
-                "inactive_from_month": {
+                "max_amount": {
+                    "$ref": "#/definitions/money.Amount"
+                },
+                "min_amount": {
+                    "$ref": "#/definitions/money.Amount"
+                },
+                "name": {
                     "type": "string"
                 },
-                "is_active": {
-                    "type": "boolean"
+                "payee_id": {
+                    "type": "integer"
                 },
-                "is_budget_relevant": {
+                "payment_method_id": {
+                    "type": "integer"
+                },
+                "priority": {
+                    "description": "lower runs first",
+                    "type": "integer"
+                },
+                "set_category_id": {
+                    "description": "Actions.",
+                    "type": "integer"
+                },
+                "set_is_fixed": {
                     "type": "boolean"
                 },
-                "name": {
+                "set_payee_id": {
+                    "type": "integer"
+                },
+                "title_pattern": {
+                    "description": "Conditions; a flow must meet all the given ones.",
                     "type": "string"
                 }
             }
         },
-        "dto.CategorySummaryResponse": {
+        "dto.RuleResponse": {
             "type": "object",
             "properties": {
-                "category_name": {
-                    "type": "string"
+                "add_tags": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
                 },
                 "direction": {
                     "type": "string"
                 },
-                "total_amount": {
-                    "type": "number"
+                "id": {
+                    "type": "integer"
+                },
+                "is_active": {
+                    "type": "boolean"
+                },
+                "max_amount": {
+                    "$ref": "#/definitions/money.Amount"
+                },
+                "min_amount": {
+                    "$ref": "#/definitions/money.Amount"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "payee_id": {
+                    "type": "integer"
+                },
+                "payment_method_id": {
+                    "type": "integer"
+                },
+                "priority": {
+                    "type": "integer"
+                },
+                "set_category_id": {
+                    "type": "integer"
+                },
+                "set_category_name": {
+                    "type": "string"
+                },
+                "set_is_fixed": {
+                    "type": "boolean"
+                },
+                "set_payee_id": {
+                    "type": "integer"
+                },
+                "title_pattern": {
+                    "type": "string"
                 }
             }
         },
-        "dto.CopyFixedRequest": {
+        "dto.RuleValuesResponse": {
             "type": "object",
             "properties": {
-                "from_month": {
-                    "type": "string"
+                "category_id": {
+                    "type": "integer"
                 },
-                "to_month": {
-                    "type": "string"
+                "is_fixed": {
+                    "type": "boolean"
+                },
+                "payee_id": {
+                    "type": "integer"
                 }
             }
         },
-        "dto.CreateCashFlowRequest": {
+        "dto.RunningBalanceResponse": {
             "type": "object",
             "properties": {
-                "amount": {
-                    "type": "number"
+                "account": {
+                    "$ref": "#/definitions/dto.AccountResponse"
                 },
-                "category_id": {
-                    "type": "integer"
+                "days": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.AccountDailyBalanceResponse"
+                    }
                 },
-                "date": {
-                    "description": "YYYY-MM-DD",
-                    "type": "string"
+                "end_balance": {
+                    "$ref": "#/definitions/money.Amount"
                 },
-                "direction": {
+                "from": {
                     "type": "string"
                 },
-                "is_fixed": {
-                    "type": "boolean"
+                "start_balance": {
+                    "$ref": "#/definitions/money.Amount"
                 },
-                "title": {
+                "to": {
                     "type": "string"
                 }
             }
         },
-        "dto.CreateCategoryRequest": {
+        "dto.SetBudgetBatchRequest": {
             "type": "object",
             "properties": {
-                "direction": {
-                    "description": "IN or OUT",
+                "category_id": {
+                    "type": "integer"
+                },
+                "end_month": {
                     "type": "string"
                 },
-                "is_budget_relevant": {
-                    "type": "boolean"
+                "mode": {
+                    "type": "string"
                 },
-                "name": {
+                "planned_amount": {
+                    "$ref": "#/definitions/money.Amount"
+                },
+                "start_month": {
                     "type": "string"
+                },
+                "target_percent": {
+                    "type": "number"
                 }
             }
         },
-        "dto.CreateInstallmentRequest": {
+        "dto.SetBudgetItemRequest": {
             "type": "object",
             "properties": {
-                "category_id": {
-                    "type": "integer"
-                },
-                "count": {
+                "category_id": {
                     "type": "integer"
                 },
-                "description": {
+                "mode": {
                     "type": "string"
                 },
-                "payment_method_id": {
-                    "type": "integer"
-                },
-                "purchase_date": {
-                    "description": "YYYY-MM-DD",
-                    "type": "string"
+                "planned_amount": {
+                    "$ref": "#/definitions/money.Amount"
                 },
-                "total_amount": {
+                "target_percent": {
                     "type": "number"
                 }
             }
         },
-        "dto.CreatePaymentMethodRequest": {
+        "dto.SetCashFlowAccountRequest": {
             "type": "object",
             "properties": {
-                "bank_name": {
-                    "type": "string"
-                },
-                "closing_day": {
+                "account_id": {
+                    "description": "null detaches the flow from its account",
                     "type": "integer"
-                },
-                "due_day": {
+                }
+            }
+        },
+        "dto.SetCashFlowPayeeRequest": {
+            "type": "object",
+            "properties": {
+                "payee_id": {
+                    "description": "null unlinks the payee",
                     "type": "integer"
-                },
-                "kind": {
-                    "type": "string"
-                },
-                "name": {
-                    "type": "string"
                 }
             }
         },
-        "dto.CreatePersonRequest": {
+        "dto.SetCashFlowSplitsRequest": {
             "type": "object",
             "properties": {
-                "name": {
-                    "type": "string"
-                },
-                "notes": {
-                    "type": "string"
+                "splits": {
+                    "description": "empty removes the splits",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.CashFlowSplitRequest"
+                    }
                 }
             }
         },
-        "dto.ErrorResponse": {
+        "dto.SetCashFlowTagsRequest": {
             "type": "object",
             "properties": {
-                "error": {
+                "tags": {
+                    "description": "replaces the current tags; unknown names are created",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "dto.SetPaymentMethodAccountRequest": {
+            "type": "object",
+            "properties": {
+                "account_id": {
+                    "description": "null unlinks",
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.StartReconciliationRequest": {
+            "type": "object",
+            "properties": {
+                "account_id": {
+                    "type": "integer"
+                },
+                "payment_method_id": {
+                    "description": "credit card",
+                    "type": "integer"
+                },
+                "statement_balance": {
+                    "description": "ending balance, or amount owed on a card",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/money.Amount"
+                        }
+                    ]
+                },
+                "statement_date": {
+                    "description": "YYYY-MM-DD",
                     "type": "string"
                 }
             }
         },
-        "dto.InstallmentPlanResponse": {
+        "dto.SummaryComparisonResponse": {
             "type": "object",
             "properties": {
-                "description": {
+                "balance": {
+                    "$ref": "#/definitions/dto.ChangeResponse"
+                },
+                "baseline_from": {
                     "type": "string"
                 },
-                "id": {
-                    "type": "integer"
+                "baseline_to": {
+                    "type": "string"
                 },
-                "installment_amount": {
-                    "type": "number"
+                "biggest_swings": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.CategorySummaryResponse"
+                    }
                 },
-                "installment_count": {
-                    "type": "integer"
+                "mode": {
+                    "type": "string"
                 },
-                "payment_method_id": {
+                "months": {
                     "type": "integer"
                 },
-                "start_month": {
-                    "type": "string"
+                "total_expense": {
+                    "$ref": "#/definitions/dto.ChangeResponse"
                 },
-                "total_amount": {
-                    "type": "number"
+                "total_income": {
+                    "$ref": "#/definitions/dto.ChangeResponse"
                 }
             }
         },
-        "dto.InvoiceEntryResponse": {
+        "dto.TagCategoryTotalResponse": {
             "type": "object",
             "properties": {
-                "amount": {
-                    "type": "number"
-                },
-                "cash_flow_id": {
+                "category_id": {
                     "type": "integer"
                 },
                 "category_name": {
                     "type": "string"
                 },
-                "date": {
+                "direction": {
                     "type": "string"
                 },
-                "title": {
-                    "type": "string"
+                "total_amount": {
+                    "$ref": "#/definitions/money.Amount"
                 }
             }
         },
-        "dto.InvoiceResponse": {
+        "dto.TagMonthTotalResponse": {
             "type": "object",
             "properties": {
-                "entries": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/dto.InvoiceEntryResponse"
-                    }
+                "balance": {
+                    "$ref": "#/definitions/money.Amount"
                 },
                 "month": {
+                    "description": "YYYY-MM-DD, first day of the month",
                     "type": "string"
                 },
-                "payment_method_id": {
-                    "type": "integer"
+                "total_expense": {
+                    "$ref": "#/definitions/money.Amount"
                 },
-                "total": {
-                    "type": "number"
+                "total_income": {
+                    "$ref": "#/definitions/money.Amount"
                 }
             }
         },
-        "dto.MonthlySummaryResponse": {
+        "dto.TagReportResponse": {
             "type": "object",
             "properties": {
                 "balance": {
-                    "type": "number"
+                    "$ref": "#/definitions/money.Amount"
+                },
+                "breakdown": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.TagReportRowResponse"
+                    }
+                },
+                "by_category": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.TagCategoryTotalResponse"
+                    }
+                },
+                "by_month": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.TagMonthTotalResponse"
+                    }
+                },
+                "tag": {
+                    "type": "string"
                 },
                 "total_expense": {
-                    "type": "number"
+                    "$ref": "#/definitions/money.Amount"
                 },
                 "total_income": {
-                    "type": "number"
+                    "$ref": "#/definitions/money.Amount"
                 }
             }
         },
-        "dto.PaymentMethodResponse": {
+        "dto.TagReportRowResponse": {
             "type": "object",
             "properties": {
-                "bank_name": {
-                    "type": "string"
-                },
-                "closing_day": {
-                    "type": "integer"
-                },
-                "due_day": {
+                "category_id": {
                     "type": "integer"
                 },
-                "id": {
-                    "type": "integer"
+                "category_name": {
+                    "type": "string"
                 },
-                "is_active": {
-                    "type": "boolean"
+                "direction": {
+                    "type": "string"
                 },
-                "kind": {
+                "month": {
                     "type": "string"
                 },
+                "total_amount": {
+                    "$ref": "#/definitions/money.Amount"
+                }
+            }
+        },
+        "dto.TagRequest": {
+            "type": "object",
+            "properties": {
                 "name": {
                     "type": "string"
                 }
             }
         },
-        "dto.PersonResponse": {
+        "dto.TagResponse": {
             "type": "object",
             "properties": {
-                "balance": {
-                    "type": "number"
+                "cash_flow_count": {
+                    "type": "integer"
                 },
                 "id": {
                     "type": "integer"
                 },
                 "name": {
                     "type": "string"
-                },
-                "notes": {
-                    "type": "string"
                 }
             }
         },
-        "dto.PicuinhaEntryResponse": {
+        "dto.TransferResponse": {
             "type": "object",
             "properties": {
                 "amount": {
-                    "type": "number"
+                    "$ref": "#/definitions/money.Amount"
                 },
-                "cash_flow_id": {
+                "created_at": {
+                    "type": "string"
+                },
+                "date": {
+                    "type": "string"
+                },
+                "from_account_id": {
                     "type": "integer"
                 },
-                "created_at": {
+                "from_account_name": {
                     "type": "string"
                 },
                 "id": {
                     "type": "integer"
                 },
-                "kind": {
+                "in_cash_flow_id": {
+                    "type": "integer"
+                },
+                "out_cash_flow_id": {
+                    "type": "integer"
+                },
+                "title": {
                     "type": "string"
                 },
-                "person_id": {
+                "to_account_id": {
                     "type": "integer"
+                },
+                "to_account_name": {
+                    "type": "string"
                 }
             }
         },
-        "dto.SetBudgetBatchRequest": {
+        "dto.UpdateBudgetItemRequest": {
             "type": "object",
             "properties": {
-                "category_id": {
-                    "type": "integer"
-                },
-                "end_month": {
-                    "type": "string"
-                },
                 "mode": {
                     "type": "string"
                 },
                 "planned_amount": {
-                    "type": "number"
-                },
-                "start_month": {
-                    "type": "string"
+                    "$ref": "#/definitions/money.Amount"
                 },
                 "target_percent": {
                     "type": "number"
                 }
             }
         },
-        "dto.SetBudgetItemRequest": {
+        "dto.UpdateCaseInstallmentRequest": {
             "type": "object",
             "properties": {
-                "category_id": {
-                    "type": "integer"
-                },
-                "mode": {
-                    "type": "string"
+                "extra_amount": {
+                    "$ref": "#/definitions/money.Amount"
                 },
-                "planned_amount": {
-                    "type": "number"
-                },
-                "target_percent": {
-                    "type": "number"
+                "is_paid": {
+                    "type": "boolean"
                 }
             }
         },
-        "dto.UpdateBudgetItemRequest": {
+        "dto.UpdateCashFlowRequest": {
             "type": "object",
             "properties": {
-                "mode": {
+                "amount": {
+                    "$ref": "#/definitions/money.Amount"
+                },
+                "category_id": {
+                    "type": "integer"
+                },
+                "currency": {
+                    "description": "Currency makes amount be in that currency and converts it again at the\nrate of date. Without it, a flow in a foreign currency keeps its\noriginal amount and only amount equal to the current one is accepted.",
                     "type": "string"
                 },
-                "planned_amount": {
-                    "type": "number"
+                "date": {
+                    "description": "YYYY-MM-DD",
+                    "type": "string"
                 },
-                "target_percent": {
-                    "type": "number"
+                "direction": {
+                    "type": "string"
+                },
+                "is_fixed": {
+                    "type": "boolean"
+                },
+                "title": {
+                    "type": "string"
                 }
             }
         },
@@ -1718,24 +8434,36 @@ const docTemplate = `{
                 },
                 "name": {
                     "type": "string"
+                },
+                "role": {
+                    "description": "REGULAR or INVESTMENT; empty keeps the current role",
+                    "type": "string"
                 }
             }
         },
-        "dto.UpdateEntryRequest": {
+        "dto.UpdatePaymentMethodRequest": {
             "type": "object",
             "properties": {
-                "amount": {
-                    "type": "number"
+                "bank_name": {
+                    "type": "string"
+                },
+                "closing_day": {
+                    "type": "integer"
+                },
+                "credit_limit": {
+                    "$ref": "#/definitions/money.Amount"
+                },
+                "due_day": {
+                    "type": "integer"
                 },
-                "auto_create_flow": {
+                "is_active": {
                     "type": "boolean"
                 },
                 "kind": {
-                    "description": "PLUS or MINUS",
                     "type": "string"
                 },
-                "person_id": {
-                    "type": "integer"
+                "name": {
+                    "type": "string"
                 }
             }
         },
@@ -1749,6 +8477,26 @@ const docTemplate = `{
                     "type": "string"
                 }
             }
+        },
+        "dto.VariableSpendingResponse": {
+            "type": "object",
+            "properties": {
+                "average": {
+                    "$ref": "#/definitions/money.Amount"
+                },
+                "category_id": {
+                    "type": "integer"
+                },
+                "category_name": {
+                    "type": "string"
+                },
+                "stddev": {
+                    "type": "number"
+                }
+            }
+        },
+        "money.Amount": {
+            "type": "object"
         }
     },
     "securityDefinitions": {
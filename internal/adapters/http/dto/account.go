@@ -0,0 +1,56 @@
+package dto
+
+import "github.com/LucasSiedschlag/HausHaltsMeister/internal/money"
+
+type AccountRequest struct {
+	Name           string       `json:"name"`
+	Kind           string       `json:"kind"` // CHECKING, SAVINGS, WALLET or BROKERAGE
+	Institution    string       `json:"institution,omitempty"`
+	OpeningBalance money.Amount `json:"opening_balance"`
+	OpeningDate    string       `json:"opening_date"`        // YYYY-MM-DD
+	IsActive       *bool        `json:"is_active,omitempty"` // updates only; defaults to true
+}
+
+type AccountResponse struct {
+	ID             int32        `json:"id"`
+	Name           string       `json:"name"`
+	Kind           string       `json:"kind"`
+	Institution    string       `json:"institution,omitempty"`
+	OpeningBalance money.Amount `json:"opening_balance"`
+	OpeningDate    string       `json:"opening_date"`
+	IsActive       bool         `json:"is_active"`
+}
+
+type AccountBalanceResponse struct {
+	AccountID int32        `json:"account_id"`
+	Name      string       `json:"name"`
+	Kind      string       `json:"kind"`
+	IsActive  bool         `json:"is_active"`
+	Balance   money.Amount `json:"balance"`
+}
+
+type AccountBalancesResponse struct {
+	Date     string                   `json:"date"`
+	Total    money.Amount             `json:"total"`
+	Accounts []AccountBalanceResponse `json:"accounts"`
+}
+
+type AccountDailyBalanceResponse struct {
+	Date         string       `json:"date"`
+	TotalIncome  money.Amount `json:"total_income"`
+	TotalExpense money.Amount `json:"total_expense"`
+	Balance      money.Amount `json:"balance"` // at the end of the day
+}
+
+type RunningBalanceResponse struct {
+	Account      AccountResponse               `json:"account"`
+	From         string                        `json:"from"`
+	To           string                        `json:"to"`
+	StartBalance money.Amount                  `json:"start_balance"`
+	EndBalance   money.Amount                  `json:"end_balance"`
+	Days         []AccountDailyBalanceResponse `json:"days"`
+}
+
+type SetPaymentMethodAccountRequest struct {
+	AccountID *int32 `json:"account_id"` // null unlinks
+}
@@ -0,0 +1,16 @@
+package dto
+
+type AttachmentResponse struct {
+	ID          int32  `json:"id"`
+	OwnerType   string `json:"owner_type"` // CASH_FLOW or INSTALLMENT_PLAN
+	OwnerID     int32  `json:"owner_id"`
+	FileName    string `json:"file_name"`
+	ContentType string `json:"content_type"`
+	SizeBytes   int64  `json:"size_bytes"`
+	SHA256      string `json:"sha256"`
+	CreatedAt   string `json:"created_at"`
+}
+
+type PruneAttachmentsResponse struct {
+	Removed int `json:"removed"`
+}
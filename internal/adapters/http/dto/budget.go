@@ -1,42 +1,44 @@
 package dto
 
+import "github.com/LucasSiedschlag/HausHaltsMeister/internal/money"
+
 type SetBudgetItemRequest struct {
-	CategoryID    int32    `json:"category_id"`
-	Mode          string   `json:"mode"`
-	PlannedAmount *float64 `json:"planned_amount,omitempty"`
-	TargetPercent *float64 `json:"target_percent,omitempty"`
+	CategoryID    int32         `json:"category_id"`
+	Mode          string        `json:"mode"`
+	PlannedAmount *money.Amount `json:"planned_amount,omitempty"`
+	TargetPercent *float64      `json:"target_percent,omitempty"`
 }
 
 type UpdateBudgetItemRequest struct {
-	Mode          string   `json:"mode"`
-	PlannedAmount *float64 `json:"planned_amount,omitempty"`
-	TargetPercent *float64 `json:"target_percent,omitempty"`
+	Mode          string        `json:"mode"`
+	PlannedAmount *money.Amount `json:"planned_amount,omitempty"`
+	TargetPercent *float64      `json:"target_percent,omitempty"`
 }
 
 type BudgetItemResponse struct {
-	ID             int32   `json:"id"`
-	BudgetPeriodID int32   `json:"budget_period_id"`
-	CategoryID     int32   `json:"category_id"`
-	CategoryName   string  `json:"category_name,omitempty"`
-	Mode           string  `json:"mode"`
-	PlannedAmount  float64 `json:"planned_amount"`
-	ActualAmount   float64 `json:"actual_amount"`
-	TargetPercent  float64 `json:"target_percent"`
+	ID             int32        `json:"id"`
+	BudgetPeriodID int32        `json:"budget_period_id"`
+	CategoryID     int32        `json:"category_id"`
+	CategoryName   string       `json:"category_name,omitempty"`
+	Mode           string       `json:"mode"`
+	PlannedAmount  money.Amount `json:"planned_amount"`
+	ActualAmount   money.Amount `json:"actual_amount"`
+	TargetPercent  float64      `json:"target_percent"`
 }
 
 type BudgetSummaryResponse struct {
 	Month       string               `json:"month"`
-	TotalIncome float64              `json:"total_income"`
+	TotalIncome money.Amount         `json:"total_income"`
 	Items       []BudgetItemResponse `json:"items"`
 }
 
 type SetBudgetBatchRequest struct {
-	StartMonth    string   `json:"start_month"`
-	EndMonth      string   `json:"end_month"`
-	CategoryID    int32    `json:"category_id"`
-	Mode          string   `json:"mode"`
-	PlannedAmount *float64 `json:"planned_amount,omitempty"`
-	TargetPercent *float64 `json:"target_percent,omitempty"`
+	StartMonth    string        `json:"start_month"`
+	EndMonth      string        `json:"end_month"`
+	CategoryID    int32         `json:"category_id"`
+	Mode          string        `json:"mode"`
+	PlannedAmount *money.Amount `json:"planned_amount,omitempty"`
+	TargetPercent *float64      `json:"target_percent,omitempty"`
 }
 
 type BulkBudgetItemRequest struct {
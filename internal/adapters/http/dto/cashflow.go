@@ -1,37 +1,216 @@
 package dto
 
+import "github.com/LucasSiedschlag/HausHaltsMeister/internal/money"
+
 type CreateCashFlowRequest struct {
-	Date       string  `json:"date"` // YYYY-MM-DD
-	CategoryID int32   `json:"category_id"`
-	Direction  string  `json:"direction"`
-	Title      string  `json:"title"`
-	Amount     float64 `json:"amount"`
-	IsFixed    bool    `json:"is_fixed"`
+	Date       string                 `json:"date"` // YYYY-MM-DD
+	CategoryID int32                  `json:"category_id"`
+	Direction  string                 `json:"direction"`
+	Title      string                 `json:"title"`
+	Amount     money.Amount           `json:"amount"`
+	IsFixed    bool                   `json:"is_fixed"`
+	AccountID  *int32                 `json:"account_id,omitempty"`
+	PayeeID    *int32                 `json:"payee_id,omitempty"`   // defaults to the payee matched by title
+	Status     string                 `json:"status,omitempty"`     // PLANNED or REALIZED (default)
+	Splits     []CashFlowSplitRequest `json:"splits,omitempty"`     // must add up to amount
+	Currency   string                 `json:"currency,omitempty"`   // ISO code; amount is in this currency and gets converted
+	IOFAmount  *money.Amount          `json:"iof_amount,omitempty"` // IOF in the base currency, added to the converted amount
+
+	AllowDuplicate bool `json:"allow_duplicate,omitempty"` // create even if it looks like an existing flow
+}
+
+type CashFlowSplitRequest struct {
+	CategoryID int32        `json:"category_id"`
+	Amount     money.Amount `json:"amount"`
+}
+
+type SetCashFlowSplitsRequest struct {
+	Splits []CashFlowSplitRequest `json:"splits"` // empty removes the splits
+}
+
+type CashFlowSplitResponse struct {
+	ID           int32        `json:"id"`
+	CategoryID   int32        `json:"category_id"`
+	CategoryName string       `json:"category_name,omitempty"`
+	Amount       money.Amount `json:"amount"`
+}
+
+type UpdateCashFlowRequest struct {
+	Date       string       `json:"date"` // YYYY-MM-DD
+	CategoryID int32        `json:"category_id"`
+	Direction  string       `json:"direction"`
+	Title      string       `json:"title"`
+	Amount     money.Amount `json:"amount"`
+	IsFixed    bool         `json:"is_fixed"`
+	// Currency makes amount be in that currency and converts it again at the
+	// rate of date. Without it, a flow in a foreign currency keeps its
+	// original amount and only amount equal to the current one is accepted.
+	Currency string `json:"currency,omitempty"`
 }
 
 type CashFlowResponse struct {
-	ID         int32   `json:"id"`
-	Date       string  `json:"date"`
-	CategoryID int32   `json:"category_id"`
-	Direction  string  `json:"direction"`
-	Title      string  `json:"title"`
-	Amount     float64 `json:"amount"`
-	IsFixed    bool    `json:"is_fixed"`
+	ID         int32                   `json:"id"`
+	Date       string                  `json:"date"`
+	CategoryID int32                   `json:"category_id"`
+	Direction  string                  `json:"direction"`
+	Title      string                  `json:"title"`
+	Amount     money.Amount            `json:"amount"`
+	IsFixed    bool                    `json:"is_fixed"`
+	AccountID  *int32                  `json:"account_id"`
+	PayeeID    *int32                  `json:"payee_id"`
+	Status     string                  `json:"status"`
+	ClearedAt  string                  `json:"cleared_at,omitempty"`
+	Reconciled bool                    `json:"reconciled"`
+	Splits     []CashFlowSplitResponse `json:"splits,omitempty"`
+
+	// Set for flows entered in a foreign currency; amount is converted and
+	// includes the IOF.
+	Currency       string        `json:"currency,omitempty"`
+	OriginalAmount *money.Amount `json:"original_amount,omitempty"`
+	ExchangeRate   *float64      `json:"exchange_rate,omitempty"`
+	IOFAmount      *money.Amount `json:"iof_amount,omitempty"`
+}
+
+type ConfirmCashFlowRequest struct {
+	Date   string        `json:"date,omitempty"`   // actual date, YYYY-MM-DD; defaults to the planned one
+	Amount *money.Amount `json:"amount,omitempty"` // actual amount, in the flow currency; defaults to the planned one
+}
+
+type SetCashFlowAccountRequest struct {
+	AccountID *int32 `json:"account_id"` // null detaches the flow from its account
+}
+
+type SetCashFlowPayeeRequest struct {
+	PayeeID *int32 `json:"payee_id"` // null unlinks the payee
 }
 
 type MonthlySummaryResponse struct {
-	TotalIncome  float64 `json:"total_income"`
-	TotalExpense float64 `json:"total_expense"`
-	Balance      float64 `json:"balance"`
+	TotalIncome  money.Amount               `json:"total_income"`
+	TotalExpense money.Amount               `json:"total_expense"`
+	Balance      money.Amount               `json:"balance"`
+	Comparison   *SummaryComparisonResponse `json:"comparison,omitempty"`
 }
 
+// CategorySummaryResponse carries the comparison fields only when a
+// comparison mode is requested.
 type CategorySummaryResponse struct {
-	CategoryName string  `json:"category_name"`
-	Direction    string  `json:"direction"`
-	TotalAmount  float64 `json:"total_amount"`
+	CategoryID   int32         `json:"category_id,omitempty"`
+	CategoryName string        `json:"category_name"`
+	Direction    string        `json:"direction"`
+	TotalAmount  money.Amount  `json:"total_amount"`
+	Baseline     *money.Amount `json:"baseline_amount,omitempty"`
+	Delta        *money.Amount `json:"delta,omitempty"`
+	ChangePct    *float64      `json:"change_pct,omitempty"`
+	BiggestSwing bool          `json:"biggest_swing,omitempty"`
+}
+
+type ChangeResponse struct {
+	Baseline  money.Amount `json:"baseline"`
+	Delta     money.Amount `json:"delta"`
+	ChangePct *float64     `json:"change_pct"` // null when the baseline is zero
+}
+
+type SummaryComparisonResponse struct {
+	Mode          string                    `json:"mode"`
+	BaselineFrom  string                    `json:"baseline_from"`
+	BaselineTo    string                    `json:"baseline_to"`
+	Months        int                       `json:"months"`
+	TotalIncome   ChangeResponse            `json:"total_income"`
+	TotalExpense  ChangeResponse            `json:"total_expense"`
+	Balance       ChangeResponse            `json:"balance"`
+	BiggestSwings []CategorySummaryResponse `json:"biggest_swings"`
 }
 
 type CopyFixedRequest struct {
 	FromMonth string `json:"from_month"`
 	ToMonth   string `json:"to_month"`
+	DryRun    bool   `json:"dry_run"`
+}
+
+type CopyFixedItemResponse struct {
+	SourceID   int32        `json:"source_id"`
+	Date       string       `json:"date"`
+	CategoryID int32        `json:"category_id"`
+	Direction  string       `json:"direction"`
+	Title      string       `json:"title"`
+	Amount     money.Amount `json:"amount"`
+	CashFlowID *int32       `json:"cash_flow_id"`
+}
+
+type CopyFixedResponse struct {
+	DryRun        bool                    `json:"dry_run"`
+	CopiedCount   int                     `json:"copied_count"`
+	SkippedCount  int                     `json:"skipped_count"`
+	ConflictCount int                     `json:"conflict_count"`
+	Created       []CopyFixedItemResponse `json:"created"`
+	Skipped       []CopyFixedItemResponse `json:"skipped"`
+	Conflicts     []CopyFixedItemResponse `json:"conflicts"`
+}
+
+type CashFlowRevisionResponse struct {
+	ID             int32         `json:"id"`
+	CashFlowID     int32         `json:"cash_flow_id"`
+	Action         string        `json:"action"`
+	Date           string        `json:"date"`
+	CategoryID     int32         `json:"category_id"`
+	Direction      string        `json:"direction"`
+	Title          string        `json:"title"`
+	Amount         money.Amount  `json:"amount"`
+	IsFixed        bool          `json:"is_fixed"`
+	Status         string        `json:"status"`
+	Currency       string        `json:"currency,omitempty"`
+	OriginalAmount *money.Amount `json:"original_amount,omitempty"`
+	ExchangeRate   *float64      `json:"exchange_rate,omitempty"`
+	IOFAmount      *money.Amount `json:"iof_amount,omitempty"`
+	RevisedAt      string        `json:"revised_at"`
+}
+
+type CashFlowSearchItem struct {
+	ID           int32        `json:"id"`
+	Date         string       `json:"date"`
+	CategoryID   int32        `json:"category_id"`
+	CategoryName string       `json:"category_name"`
+	Direction    string       `json:"direction"`
+	Title        string       `json:"title"`
+	Amount       money.Amount `json:"amount"`
+	IsFixed      bool         `json:"is_fixed"`
+	AccountID    *int32       `json:"account_id"`
+	PayeeID      *int32       `json:"payee_id"`
+	Status       string       `json:"status"`
+	Cleared      bool         `json:"cleared"`
+	Reconciled   bool         `json:"reconciled"`
+}
+
+type CashFlowPageResponse struct {
+	Items  []CashFlowSearchItem `json:"items"`
+	Total  int64                `json:"total"`
+	Limit  int32                `json:"limit"`
+	Offset int32                `json:"offset"`
+}
+
+type CashFlowDuplicateResponse struct {
+	ID              int32        `json:"id"`
+	Date            string       `json:"date"`
+	CategoryID      int32        `json:"category_id"`
+	CategoryName    string       `json:"category_name"`
+	Direction       string       `json:"direction"`
+	Title           string       `json:"title"`
+	Amount          money.Amount `json:"amount"`
+	PayeeID         *int32       `json:"payee_id"`
+	PaymentMethodID *int32       `json:"payment_method_id"`
+	Status          string       `json:"status"`
+}
+
+// DuplicateCashFlowErrorResponse is the 409 returned when a new cash flow
+// looks like one already entered.
+type DuplicateCashFlowErrorResponse struct {
+	Error      string                      `json:"error"`
+	Candidates []CashFlowDuplicateResponse `json:"candidates"`
+}
+
+type CashFlowDuplicatePairResponse struct {
+	First      CashFlowDuplicateResponse `json:"first"`
+	Second     CashFlowDuplicateResponse `json:"second"`
+	DaysApart  int                       `json:"days_apart"`
+	Similarity float64                   `json:"similarity"` // title similarity, 0 to 1
 }
@@ -4,6 +4,7 @@ type CreateCategoryRequest struct {
 	Name             string `json:"name"`
 	Direction        string `json:"direction"` // IN or OUT
 	IsBudgetRelevant bool   `json:"is_budget_relevant"`
+	Role             string `json:"role,omitempty"` // REGULAR (default) or INVESTMENT
 }
 
 type UpdateCategoryRequest struct {
@@ -11,6 +12,7 @@ type UpdateCategoryRequest struct {
 	Direction        *string `json:"direction"` // IN or OUT
 	IsBudgetRelevant *bool   `json:"is_budget_relevant"`
 	IsActive         *bool   `json:"is_active"`
+	Role             string  `json:"role,omitempty"` // REGULAR or INVESTMENT; empty keeps the current role
 }
 
 type CategoryResponse struct {
@@ -20,4 +22,5 @@ type CategoryResponse struct {
 	IsBudgetRelevant bool   `json:"is_budget_relevant"`
 	IsActive         bool   `json:"is_active"`
 	InactiveFromMonth string `json:"inactive_from_month,omitempty"`
+	Role             string `json:"role"` // REGULAR, TRANSFER or INVESTMENT
 }
@@ -0,0 +1,20 @@
+package dto
+
+type ExchangeRateRequest struct {
+	Currency string  `json:"currency"` // ISO code, e.g. USD
+	Date     string  `json:"date"`     // YYYY-MM-DD; the rate holds from this day on
+	Rate     float64 `json:"rate"`     // base currency per unit
+}
+
+type ExchangeRateResponse struct {
+	ID        int32   `json:"id"`
+	Currency  string  `json:"currency"`
+	Date      string  `json:"date"`
+	Rate      float64 `json:"rate"`
+	CreatedAt string  `json:"created_at"`
+}
+
+type ExchangeRateImportResponse struct {
+	ImportedCount int                    `json:"imported_count"`
+	Rates         []ExchangeRateResponse `json:"rates"`
+}
@@ -0,0 +1,81 @@
+package dto
+
+import "github.com/LucasSiedschlag/HausHaltsMeister/internal/money"
+
+type ImportProfileRequest struct {
+	Name           string `json:"name"`
+	Delimiter      string `json:"delimiter"`       // default ";"
+	HasHeader      *bool  `json:"has_header"`      // default true
+	DateColumn     int32  `json:"date_column"`     // zero-based
+	DateFormat     string `json:"date_format"`     // default DD/MM/YYYY
+	TitleColumn    int32  `json:"title_column"`    // zero-based
+	AmountColumn   int32  `json:"amount_column"`   // zero-based
+	DecimalComma   *bool  `json:"decimal_comma"`   // default true (1.234,56)
+	SignConvention string `json:"sign_convention"` // NEGATIVE_IS_OUT (default) or POSITIVE_IS_OUT
+}
+
+type ImportProfileResponse struct {
+	ID             int32  `json:"id"`
+	Name           string `json:"name"`
+	Delimiter      string `json:"delimiter"`
+	HasHeader      bool   `json:"has_header"`
+	DateColumn     int32  `json:"date_column"`
+	DateFormat     string `json:"date_format"`
+	TitleColumn    int32  `json:"title_column"`
+	AmountColumn   int32  `json:"amount_column"`
+	DecimalComma   bool   `json:"decimal_comma"`
+	SignConvention string `json:"sign_convention"`
+}
+
+type ImportCandidateResponse struct {
+	Line                  int          `json:"line"`
+	Date                  string       `json:"date,omitempty"`
+	Title                 string       `json:"title"`
+	Amount                money.Amount `json:"amount"`
+	Direction             string       `json:"direction"`
+	SuggestedCategoryID   *int32       `json:"suggested_category_id"`
+	SuggestedCategoryName string       `json:"suggested_category_name,omitempty"`
+	PayeeID               *int32       `json:"payee_id"`
+	IsFixed               bool         `json:"is_fixed"`
+	Duplicate             bool         `json:"duplicate"`
+	DuplicateOfID         *int32       `json:"duplicate_of_id"`
+	AlreadyImported       bool         `json:"already_imported"`
+	FITID                 string       `json:"fitid,omitempty"`
+	ExternalAccount       string       `json:"external_account,omitempty"`
+	Error                 string       `json:"error,omitempty"`
+}
+
+type ImportPreviewResponse struct {
+	PaymentMethodID *int32                    `json:"payment_method_id,omitempty"`
+	Candidates      []ImportCandidateResponse `json:"candidates"`
+	ValidCount      int                       `json:"valid_count"`
+	DuplicateCount  int                       `json:"duplicate_count"`
+	ErrorCount      int                       `json:"error_count"`
+}
+
+type ImportCommitItem struct {
+	Date       string       `json:"date"` // YYYY-MM-DD
+	CategoryID int32        `json:"category_id"`
+	Direction  string       `json:"direction"`
+	Title      string       `json:"title"`
+	Amount     money.Amount `json:"amount"`
+	IsFixed    bool         `json:"is_fixed"`
+
+	// Optional, filled from OFX previews.
+	PaymentMethodID *int32 `json:"payment_method_id"`
+	FITID           string `json:"fitid"`
+	ExternalAccount string `json:"external_account"`
+
+	// Optional; matched from the title when omitted.
+	PayeeID *int32 `json:"payee_id"`
+}
+
+type ImportCommitRequest struct {
+	Items []ImportCommitItem `json:"items"`
+}
+
+type ImportCommitResponse struct {
+	CreatedCount int                `json:"created_count"`
+	SkippedCount int                `json:"skipped_count"`
+	Items        []CashFlowResponse `json:"items"`
+}
@@ -1,22 +1,33 @@
 package dto
 
+import "github.com/LucasSiedschlag/HausHaltsMeister/internal/money"
+
 type CreateInstallmentRequest struct {
-	Description       string  `json:"description"`
-	AmountMode        string  `json:"amount_mode"`
-	TotalAmount       float64 `json:"total_amount"`
-	InstallmentAmount float64 `json:"installment_amount"`
-	Count             int32   `json:"count"`
-	CategoryID        int32   `json:"category_id"`
-	PaymentMethodID   int32   `json:"payment_method_id"`
-	PurchaseDate      string  `json:"purchase_date"` // YYYY-MM-DD
+	Description       string        `json:"description"`
+	AmountMode        string        `json:"amount_mode"`
+	TotalAmount       money.Amount  `json:"total_amount"`
+	InstallmentAmount money.Amount  `json:"installment_amount"`
+	Count             int32         `json:"count"`
+	CategoryID        int32         `json:"category_id"`
+	PaymentMethodID   int32         `json:"payment_method_id"`
+	PurchaseDate      string        `json:"purchase_date"`        // YYYY-MM-DD
+	Currency          string        `json:"currency,omitempty"`   // ISO code; amounts are in this currency and get converted
+	IOFAmount         *money.Amount `json:"iof_amount,omitempty"` // overrides the IOF computed for credit cards
 }
 
 type InstallmentPlanResponse struct {
-	ID                int32   `json:"id"`
-	Description       string  `json:"description"`
-	TotalAmount       float64 `json:"total_amount"`
-	InstallmentCount  int32   `json:"installment_count"`
-	InstallmentAmount float64 `json:"installment_amount"`
-	StartMonth        string  `json:"start_month"`
-	PaymentMethodID   int32   `json:"payment_method_id"`
+	ID                int32        `json:"id"`
+	Description       string       `json:"description"`
+	TotalAmount       money.Amount `json:"total_amount"`
+	InstallmentCount  int32        `json:"installment_count"`
+	InstallmentAmount money.Amount `json:"installment_amount"`
+	StartMonth        string       `json:"start_month"`
+	PaymentMethodID   int32        `json:"payment_method_id"`
+
+	// Set for purchases in a foreign currency; the amounts above are
+	// converted and include the IOF.
+	Currency       string        `json:"currency,omitempty"`
+	OriginalAmount *money.Amount `json:"original_amount,omitempty"`
+	ExchangeRate   *float64      `json:"exchange_rate,omitempty"`
+	IOFAmount      *money.Amount `json:"iof_amount,omitempty"`
 }
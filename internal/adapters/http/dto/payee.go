@@ -0,0 +1,55 @@
+package dto
+
+import "github.com/LucasSiedschlag/HausHaltsMeister/internal/money"
+
+type PayeeRequest struct {
+	Name                   string   `json:"name"`
+	Aliases                []string `json:"aliases"` // other spellings found in titles; replaces the current ones on update
+	DefaultCategoryID      *int32   `json:"default_category_id,omitempty"`
+	DefaultPaymentMethodID *int32   `json:"default_payment_method_id,omitempty"`
+}
+
+type PayeeResponse struct {
+	ID                     int32    `json:"id"`
+	Name                   string   `json:"name"`
+	Aliases                []string `json:"aliases"`
+	DefaultCategoryID      *int32   `json:"default_category_id"`
+	DefaultPaymentMethodID *int32   `json:"default_payment_method_id"`
+	CashFlowCount          int64    `json:"cash_flow_count"`
+}
+
+type MergePayeesRequest struct {
+	SourceIDs []int32 `json:"source_ids"` // payees folded into the target and deleted
+}
+
+type PayeeCategoryTotalResponse struct {
+	CategoryID    int32        `json:"category_id"`
+	CategoryName  string       `json:"category_name"`
+	Direction     string       `json:"direction"`
+	CashFlowCount int64        `json:"cash_flow_count"`
+	TotalAmount   money.Amount `json:"total_amount"`
+}
+
+type PayeeMonthTotalResponse struct {
+	Month        string       `json:"month"` // YYYY-MM-DD, first day of the month
+	TotalIncome  money.Amount `json:"total_income"`
+	TotalExpense money.Amount `json:"total_expense"`
+}
+
+type PayeeReportResponse struct {
+	PayeeID      int32                        `json:"payee_id"`
+	Name         string                       `json:"name"`
+	From         string                       `json:"from,omitempty"`
+	To           string                       `json:"to,omitempty"`
+	TotalIncome  money.Amount                 `json:"total_income"`
+	TotalExpense money.Amount                 `json:"total_expense"`
+	ByCategory   []PayeeCategoryTotalResponse `json:"by_category"`
+	ByMonth      []PayeeMonthTotalResponse    `json:"by_month"`
+}
+
+type PayeeTotalResponse struct {
+	PayeeID       int32        `json:"payee_id"`
+	Name          string       `json:"name"`
+	CashFlowCount int64        `json:"cash_flow_count"`
+	TotalAmount   money.Amount `json:"total_amount"`
+}
@@ -1,47 +1,54 @@
 package dto
 
+import "github.com/LucasSiedschlag/HausHaltsMeister/internal/money"
+
 type CreatePaymentMethodRequest struct {
-	Name        string   `json:"name"`
-	Kind        string   `json:"kind"`
-	BankName    string   `json:"bank_name"`
-	CreditLimit *float64 `json:"credit_limit"`
-	ClosingDay  *int32   `json:"closing_day"`
-	DueDay      *int32   `json:"due_day"`
+	Name        string        `json:"name"`
+	Kind        string        `json:"kind"`
+	BankName    string        `json:"bank_name"`
+	CreditLimit *money.Amount `json:"credit_limit"`
+	ClosingDay  *int32        `json:"closing_day"`
+	DueDay      *int32        `json:"due_day"`
 }
 
 type UpdatePaymentMethodRequest struct {
-	Name        *string  `json:"name"`
-	Kind        *string  `json:"kind"`
-	BankName    *string  `json:"bank_name"`
-	CreditLimit *float64 `json:"credit_limit"`
-	ClosingDay  *int32   `json:"closing_day"`
-	DueDay      *int32   `json:"due_day"`
-	IsActive    *bool    `json:"is_active"`
+	Name        *string       `json:"name"`
+	Kind        *string       `json:"kind"`
+	BankName    *string       `json:"bank_name"`
+	CreditLimit *money.Amount `json:"credit_limit"`
+	ClosingDay  *int32        `json:"closing_day"`
+	DueDay      *int32        `json:"due_day"`
+	IsActive    *bool         `json:"is_active"`
 }
 
 type PaymentMethodResponse struct {
-	ID          int32    `json:"id"`
-	Name        string   `json:"name"`
-	Kind        string   `json:"kind"`
-	BankName    string   `json:"bank_name"`
-	CreditLimit *float64 `json:"credit_limit"`
-	ClosingDay  *int32   `json:"closing_day"`
-	DueDay      *int32   `json:"due_day"`
-	IsActive    bool     `json:"is_active"`
+	ID          int32         `json:"id"`
+	Name        string        `json:"name"`
+	Kind        string        `json:"kind"`
+	BankName    string        `json:"bank_name"`
+	CreditLimit *money.Amount `json:"credit_limit"`
+	ClosingDay  *int32        `json:"closing_day"`
+	DueDay      *int32        `json:"due_day"`
+	IsActive    bool          `json:"is_active"`
+	AccountID   *int32        `json:"account_id"`
 }
 
 type InvoiceEntryResponse struct {
-	CashFlowID   int32   `json:"cash_flow_id"`
-	Date         string  `json:"date"`
-	Title        string  `json:"title"`
-	Amount       float64 `json:"amount"`
-	CategoryName string  `json:"category_name"`
+	CashFlowID     int32         `json:"cash_flow_id"`
+	Date           string        `json:"date"`
+	Title          string        `json:"title"`
+	Amount         money.Amount  `json:"amount"`
+	CategoryName   string        `json:"category_name"`
+	Currency       string        `json:"currency,omitempty"`
+	OriginalAmount *money.Amount `json:"original_amount,omitempty"`
+	IOFAmount      *money.Amount `json:"iof_amount,omitempty"`
 }
 
 type InvoiceResponse struct {
 	PaymentMethodID int32                  `json:"payment_method_id"`
 	Month           string                 `json:"month"`
-	Total           float64                `json:"total"`
-	TotalRemaining  float64                `json:"total_remaining"`
+	Total           money.Amount           `json:"total"`
+	TotalIOF        money.Amount           `json:"total_iof"`
+	TotalRemaining  money.Amount           `json:"total_remaining"`
 	Entries         []InvoiceEntryResponse `json:"entries"`
 }
@@ -0,0 +1,43 @@
+package dto
+
+import "github.com/LucasSiedschlag/HausHaltsMeister/internal/money"
+
+type StartReconciliationRequest struct {
+	AccountID        *int32       `json:"account_id,omitempty"`
+	PaymentMethodID  *int32       `json:"payment_method_id,omitempty"` // credit card
+	StatementDate    string       `json:"statement_date"`              // YYYY-MM-DD
+	StatementBalance money.Amount `json:"statement_balance"`           // ending balance, or amount owed on a card
+}
+
+type ClearCashFlowsRequest struct {
+	CashFlowIDs []int32 `json:"cash_flow_ids"`
+	Cleared     *bool   `json:"cleared,omitempty"` // defaults to true; false unmarks
+}
+
+type ReconciliationResponse struct {
+	ID               int32        `json:"id"`
+	AccountID        *int32       `json:"account_id,omitempty"`
+	PaymentMethodID  *int32       `json:"payment_method_id,omitempty"`
+	StatementDate    string       `json:"statement_date"`
+	StatementBalance money.Amount `json:"statement_balance"`
+	Status           string       `json:"status"`
+	ClearedBalance   money.Amount `json:"cleared_balance"`
+	Difference       money.Amount `json:"difference"`
+	CreatedAt        string       `json:"created_at"`
+	CompletedAt      string       `json:"completed_at,omitempty"`
+}
+
+type ReconciliationItemResponse struct {
+	CashFlowID   int32        `json:"cash_flow_id"`
+	Date         string       `json:"date"`
+	Direction    string       `json:"direction"`
+	Title        string       `json:"title"`
+	Amount       money.Amount `json:"amount"`
+	CategoryName string       `json:"category_name"`
+	Cleared      bool         `json:"cleared"`
+}
+
+type ReconciliationWorksheetResponse struct {
+	ReconciliationResponse
+	Items []ReconciliationItemResponse `json:"items"`
+}
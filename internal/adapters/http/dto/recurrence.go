@@ -0,0 +1,62 @@
+package dto
+
+import "github.com/LucasSiedschlag/HausHaltsMeister/internal/money"
+
+type RecurrenceRuleRequest struct {
+	Title           string       `json:"title"`
+	CategoryID      int32        `json:"category_id"`
+	Direction       string       `json:"direction"`
+	Amount          money.Amount `json:"amount"`
+	IsFixed         *bool        `json:"is_fixed"` // default true
+	PaymentMethodID *int32       `json:"payment_method_id"`
+
+	Frequency         string `json:"frequency"`           // WEEKLY, MONTHLY or YEARLY
+	Interval          int32  `json:"interval"`            // default 1
+	ByMonthDay        *int32 `json:"by_month_day"`        // 1-31 or -1 (last day); defaults to the start date day
+	BusinessDayAdjust string `json:"business_day_adjust"` // NONE (default), PREVIOUS or NEXT
+	StartDate         string `json:"start_date"`          // YYYY-MM-DD
+	EndDate           string `json:"end_date,omitempty"`  // YYYY-MM-DD
+	IsActive          *bool  `json:"is_active"`           // update only; default true
+}
+
+type RecurrenceRuleResponse struct {
+	ID                int32        `json:"id"`
+	Title             string       `json:"title"`
+	CategoryID        int32        `json:"category_id"`
+	Direction         string       `json:"direction"`
+	Amount            money.Amount `json:"amount"`
+	IsFixed           bool         `json:"is_fixed"`
+	PaymentMethodID   *int32       `json:"payment_method_id"`
+	Frequency         string       `json:"frequency"`
+	Interval          int32        `json:"interval"`
+	ByMonthDay        *int32       `json:"by_month_day"`
+	BusinessDayAdjust string       `json:"business_day_adjust"`
+	StartDate         string       `json:"start_date"`
+	EndDate           string       `json:"end_date,omitempty"`
+	IsActive          bool         `json:"is_active"`
+}
+
+type RecurrenceOccurrenceResponse struct {
+	RuleID     int32  `json:"rule_id"`
+	Date       string `json:"date"`
+	CashFlowID *int32 `json:"cash_flow_id"`
+}
+
+type MaterializeRequest struct {
+	FromMonth string `json:"from_month"`
+	ToMonth   string `json:"to_month"`
+}
+
+type MaterializeFailureResponse struct {
+	RuleID int32  `json:"rule_id"`
+	Date   string `json:"date"`
+	Error  string `json:"error"`
+}
+
+type MaterializeResponse struct {
+	CreatedCount int                            `json:"created_count"`
+	SkippedCount int                            `json:"skipped_count"`
+	FailedCount  int                            `json:"failed_count"`
+	Created      []RecurrenceOccurrenceResponse `json:"created"`
+	Failed       []MaterializeFailureResponse   `json:"failed"`
+}
@@ -0,0 +1,159 @@
+package dto
+
+import "github.com/LucasSiedschlag/HausHaltsMeister/internal/money"
+
+type ReportTotalsResponse struct {
+	Income  money.Amount `json:"income"`
+	Expense money.Amount `json:"expense"`
+	Net     money.Amount `json:"net"`
+	Balance money.Amount `json:"balance"` // running, since the first cash flow
+}
+
+type CumulativeMonthResponse struct {
+	Month               string               `json:"month"` // YYYY-MM-DD, first day of the month
+	Income              money.Amount         `json:"income"`
+	Expense             money.Amount         `json:"expense"`
+	Net                 money.Amount         `json:"net"`
+	Balance             money.Amount         `json:"balance"`
+	Own                 ReportTotalsResponse `json:"own"`
+	Picuinha            ReportTotalsResponse `json:"picuinha"`
+	PlannedInstallments money.Amount         `json:"planned_installments"`
+}
+
+type CumulativeResponse struct {
+	From                string                    `json:"from"`
+	To                  string                    `json:"to"`
+	IncludeInstallments bool                      `json:"include_installments"`
+	OpeningBalance      money.Amount              `json:"opening_balance"`
+	Months              []CumulativeMonthResponse `json:"months"`
+}
+
+type CategoryMonthResponse struct {
+	Month          string        `json:"month"`
+	Total          money.Amount  `json:"total"`
+	Planned        *money.Amount `json:"planned"`
+	MovingAverage3 money.Amount  `json:"moving_avg_3m"`
+	MovingAverage6 money.Amount  `json:"moving_avg_6m"`
+	ChangePct      *float64      `json:"change_pct"`
+}
+
+type CategoryHistoryResponse struct {
+	CategoryID   int32                   `json:"category_id"`
+	CategoryName string                  `json:"category_name"`
+	Direction    string                  `json:"direction"`
+	From         string                  `json:"from"`
+	To           string                  `json:"to"`
+	TrendSlope   float64                 `json:"trend_slope"` // per month
+	Months       []CategoryMonthResponse `json:"months"`
+}
+
+type CategoryGrowthResponse struct {
+	CategoryID     int32         `json:"category_id"`
+	CategoryName   string        `json:"category_name"`
+	Total          money.Amount  `json:"total"`
+	MonthlyAverage money.Amount  `json:"monthly_average"`
+	TrendSlope     float64       `json:"trend_slope"`
+	GrowthPct      float64       `json:"growth_pct"`
+	Planned        *money.Amount `json:"planned"`
+}
+
+type GrowthResponse struct {
+	From       string                   `json:"from"`
+	To         string                   `json:"to"`
+	Categories []CategoryGrowthResponse `json:"categories"`
+}
+
+type BandResponse struct {
+	Low  money.Amount `json:"low"`
+	High money.Amount `json:"high"`
+}
+
+type ForecastSourcesResponse struct {
+	Installments     money.Amount `json:"installments"`
+	PlannedIncome    money.Amount `json:"planned_income"`
+	PlannedExpense   money.Amount `json:"planned_expense"`
+	FixedIncome      money.Amount `json:"fixed_income"`
+	FixedExpense     money.Amount `json:"fixed_expense"`
+	RecurringIncome  money.Amount `json:"recurring_income"`
+	RecurringExpense money.Amount `json:"recurring_expense"`
+	VariableExpense  money.Amount `json:"variable_expense"`
+	Receivables      money.Amount `json:"picuinha_receivables"`
+}
+
+type ForecastMonthResponse struct {
+	Month       string                  `json:"month"`
+	Income      money.Amount            `json:"income"`
+	Expense     money.Amount            `json:"expense"`
+	Net         money.Amount            `json:"net"`
+	Balance     money.Amount            `json:"balance"`
+	IncomeBand  BandResponse            `json:"income_band"`
+	ExpenseBand BandResponse            `json:"expense_band"`
+	BalanceBand BandResponse            `json:"balance_band"`
+	Sources     ForecastSourcesResponse `json:"sources"`
+}
+
+type VariableSpendingResponse struct {
+	CategoryID   int32        `json:"category_id"`
+	CategoryName string       `json:"category_name"`
+	Average      money.Amount `json:"average"`
+	StdDev       float64      `json:"stddev"`
+}
+
+type ForecastResponse struct {
+	From           string                     `json:"from"`
+	To             string                     `json:"to"`
+	OpeningBalance money.Amount               `json:"opening_balance"`
+	HistoryFrom    string                     `json:"history_from"`
+	HistoryTo      string                     `json:"history_to"`
+	Variable       []VariableSpendingResponse `json:"variable_spending"`
+	Months         []ForecastMonthResponse    `json:"months"`
+	LowestMonth    string                     `json:"lowest_month"`
+	LowestBalance  money.Amount               `json:"lowest_balance"` // pessimistic, the low end of the band
+}
+
+type AnnualCellResponse struct {
+	Actual  money.Amount  `json:"actual"`
+	Planned *money.Amount `json:"planned,omitempty"` // only with include_budget, when a budget covers it
+}
+
+type AnnualLineResponse struct {
+	CategoryID   int32                `json:"category_id,omitempty"` // absent for totals
+	CategoryName string               `json:"category_name,omitempty"`
+	Months       []AnnualCellResponse `json:"months"` // January to December
+	Total        AnnualCellResponse   `json:"total"`
+	Average      AnnualCellResponse   `json:"average"`
+}
+
+type AnnualSectionResponse struct {
+	Direction  string               `json:"direction"`
+	Categories []AnnualLineResponse `json:"categories"`
+	Total      AnnualLineResponse   `json:"total"`
+}
+
+type AnnualReportResponse struct {
+	Year          int                   `json:"year"`
+	IncludeBudget bool                  `json:"include_budget"`
+	AverageMonths int                   `json:"average_months"`
+	Income        AnnualSectionResponse `json:"income"`
+	Expense       AnnualSectionResponse `json:"expense"`
+	Net           AnnualLineResponse    `json:"net"`
+}
+
+type ReinvestmentMonthResponse struct {
+	Month         string       `json:"month"`
+	Inflow        money.Amount `json:"inflow"`        // redemptions and yields
+	Contributions money.Amount `json:"contributions"` // money put into investments
+	Gap           money.Amount `json:"gap"`           // inflow - contributions; positive is still to reinvest
+	CumulativeGap money.Amount `json:"cumulative_gap"`
+}
+
+type ReinvestmentResponse struct {
+	From          string                      `json:"from"`
+	To            string                      `json:"to"`
+	OpeningGap    money.Amount                `json:"opening_gap"`
+	Inflow        money.Amount                `json:"inflow"`
+	Contributions money.Amount                `json:"contributions"`
+	Gap           money.Amount                `json:"gap"`
+	ClosingGap    money.Amount                `json:"closing_gap"`
+	Months        []ReinvestmentMonthResponse `json:"months"`
+}
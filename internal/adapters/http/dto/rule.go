@@ -0,0 +1,64 @@
+package dto
+
+import "github.com/LucasSiedschlag/HausHaltsMeister/internal/money"
+
+type RuleRequest struct {
+	Name     string `json:"name"`
+	Priority int32  `json:"priority"`  // lower runs first
+	IsActive *bool  `json:"is_active"` // default true
+
+	// Conditions; a flow must meet all the given ones.
+	TitlePattern    string        `json:"title_pattern,omitempty"` // regular expression, case-insensitive
+	Direction       string        `json:"direction,omitempty"`
+	MinAmount       *money.Amount `json:"min_amount,omitempty"`
+	MaxAmount       *money.Amount `json:"max_amount,omitempty"`
+	PaymentMethodID *int32        `json:"payment_method_id,omitempty"`
+	PayeeID         *int32        `json:"payee_id,omitempty"`
+
+	// Actions.
+	SetCategoryID *int32   `json:"set_category_id,omitempty"`
+	SetIsFixed    *bool    `json:"set_is_fixed,omitempty"`
+	SetPayeeID    *int32   `json:"set_payee_id,omitempty"`
+	AddTags       []string `json:"add_tags,omitempty"`
+}
+
+type RuleResponse struct {
+	ID              int32         `json:"id"`
+	Name            string        `json:"name"`
+	Priority        int32         `json:"priority"`
+	IsActive        bool          `json:"is_active"`
+	TitlePattern    string        `json:"title_pattern,omitempty"`
+	Direction       string        `json:"direction,omitempty"`
+	MinAmount       *money.Amount `json:"min_amount"`
+	MaxAmount       *money.Amount `json:"max_amount"`
+	PaymentMethodID *int32        `json:"payment_method_id"`
+	PayeeID         *int32        `json:"payee_id"`
+	SetCategoryID   *int32        `json:"set_category_id"`
+	SetCategoryName string        `json:"set_category_name,omitempty"`
+	SetIsFixed      *bool         `json:"set_is_fixed"`
+	SetPayeeID      *int32        `json:"set_payee_id"`
+	AddTags         []string      `json:"add_tags"`
+}
+
+type RuleValuesResponse struct {
+	CategoryID int32  `json:"category_id"`
+	IsFixed    bool   `json:"is_fixed"`
+	PayeeID    *int32 `json:"payee_id"`
+}
+
+type RuleChangeResponse struct {
+	CashFlowID int32              `json:"cash_flow_id"`
+	Date       string             `json:"date"`
+	Title      string             `json:"title"`
+	Direction  string             `json:"direction"`
+	Amount     money.Amount       `json:"amount"`
+	Before     RuleValuesResponse `json:"before"`
+	After      RuleValuesResponse `json:"after"`
+	AddTags    []string           `json:"add_tags"`
+}
+
+type RuleApplyResponse struct {
+	DryRun       bool                 `json:"dry_run"`
+	ChangedCount int                  `json:"changed_count"`
+	Changes      []RuleChangeResponse `json:"changes"`
+}
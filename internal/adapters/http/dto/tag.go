@@ -0,0 +1,49 @@
+package dto
+
+import "github.com/LucasSiedschlag/HausHaltsMeister/internal/money"
+
+type TagRequest struct {
+	Name string `json:"name"`
+}
+
+type TagResponse struct {
+	ID            int32  `json:"id"`
+	Name          string `json:"name"`
+	CashFlowCount int64  `json:"cash_flow_count"`
+}
+
+type SetCashFlowTagsRequest struct {
+	Tags []string `json:"tags"` // replaces the current tags; unknown names are created
+}
+
+type TagCategoryTotalResponse struct {
+	CategoryID   int32        `json:"category_id"`
+	CategoryName string       `json:"category_name"`
+	Direction    string       `json:"direction"`
+	TotalAmount  money.Amount `json:"total_amount"`
+}
+
+type TagMonthTotalResponse struct {
+	Month        string       `json:"month"` // YYYY-MM-DD, first day of the month
+	TotalIncome  money.Amount `json:"total_income"`
+	TotalExpense money.Amount `json:"total_expense"`
+	Balance      money.Amount `json:"balance"`
+}
+
+type TagReportRowResponse struct {
+	Month        string       `json:"month"`
+	CategoryID   int32        `json:"category_id"`
+	CategoryName string       `json:"category_name"`
+	Direction    string       `json:"direction"`
+	TotalAmount  money.Amount `json:"total_amount"`
+}
+
+type TagReportResponse struct {
+	Tag          string                     `json:"tag"`
+	TotalIncome  money.Amount               `json:"total_income"`
+	TotalExpense money.Amount               `json:"total_expense"`
+	Balance      money.Amount               `json:"balance"`
+	ByCategory   []TagCategoryTotalResponse `json:"by_category"`
+	ByMonth      []TagMonthTotalResponse    `json:"by_month"`
+	Breakdown    []TagReportRowResponse     `json:"breakdown"`
+}
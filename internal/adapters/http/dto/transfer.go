@@ -0,0 +1,25 @@
+package dto
+
+import "github.com/LucasSiedschlag/HausHaltsMeister/internal/money"
+
+type CreateTransferRequest struct {
+	Date          string       `json:"date"` // YYYY-MM-DD
+	Amount        money.Amount `json:"amount"`
+	FromAccountID int32        `json:"from_account_id"`
+	ToAccountID   int32        `json:"to_account_id"`
+	Title         string       `json:"title,omitempty"` // defaults to "Transferência"
+}
+
+type TransferResponse struct {
+	ID              int32        `json:"id"`
+	Date            string       `json:"date"`
+	Amount          money.Amount `json:"amount"`
+	Title           string       `json:"title"`
+	FromAccountID   int32        `json:"from_account_id"`
+	FromAccountName string       `json:"from_account_name"`
+	ToAccountID     int32        `json:"to_account_id"`
+	ToAccountName   string       `json:"to_account_name"`
+	OutCashFlowID   int32        `json:"out_cash_flow_id"`
+	InCashFlowID    int32        `json:"in_cash_flow_id"`
+	CreatedAt       string       `json:"created_at"`
+}
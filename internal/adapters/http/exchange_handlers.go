@@ -0,0 +1,204 @@
+package http
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/adapters/http/dto"
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/domain/exchange"
+	"github.com/labstack/echo/v4"
+)
+
+type ExchangeHandler struct {
+	service exchange.Service
+}
+
+func NewExchangeHandler(service exchange.Service) *ExchangeHandler {
+	return &ExchangeHandler{service: service}
+}
+
+// SetRate records an exchange rate.
+// @Summary Registrar Cotação
+// @Description Stores what one unit of a currency is worth in the base currency from a date on. A rate for the same currency and date is replaced.
+// @Tags ExchangeRates
+// @Accept json
+// @Produce json
+// @Param payload body dto.ExchangeRateRequest true "Exchange Rate Payload"
+// @Success 201 {object} dto.ExchangeRateResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /exchange-rates [post]
+func (h *ExchangeHandler) SetRate(c echo.Context) error {
+	var req dto.ExchangeRateRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: "invalid payload"})
+	}
+
+	date, err := time.Parse("2006-01-02", req.Date)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: "invalid date format, expected YYYY-MM-DD"})
+	}
+
+	rate, err := h.service.SetRate(c.Request().Context(), exchange.Rate{
+		Currency: req.Currency,
+		Date:     date,
+		Rate:     req.Rate,
+	})
+	if err != nil {
+		return exchangeError(c, err, "failed to save exchange rate")
+	}
+
+	return c.JSON(http.StatusCreated, toExchangeRateResponse(*rate))
+}
+
+// List returns exchange rates.
+// @Summary Listar Cotações
+// @Description Returns exchange rates by currency, newest first, optionally for one currency.
+// @Tags ExchangeRates
+// @Accept json
+// @Produce json
+// @Param currency query string false "Currency (e.g. USD)"
+// @Success 200 {array} dto.ExchangeRateResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /exchange-rates [get]
+func (h *ExchangeHandler) List(c echo.Context) error {
+	list, err := h.service.ListRates(c.Request().Context(), c.QueryParam("currency"))
+	if err != nil {
+		return exchangeError(c, err, "failed to list exchange rates")
+	}
+
+	resp := make([]dto.ExchangeRateResponse, len(list))
+	for i, r := range list {
+		resp[i] = toExchangeRateResponse(r)
+	}
+	return c.JSON(http.StatusOK, resp)
+}
+
+// Effective returns the rate in force on a date.
+// @Summary Obter Cotação Vigente
+// @Description Returns the rate used to convert a currency on a date: the latest one on or before it.
+// @Tags ExchangeRates
+// @Accept json
+// @Produce json
+// @Param currency query string true "Currency (e.g. USD)"
+// @Param date query string false "Date (YYYY-MM-DD), defaults to today"
+// @Success 200 {object} dto.ExchangeRateResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Router /exchange-rates/effective [get]
+func (h *ExchangeHandler) Effective(c echo.Context) error {
+	date := time.Now()
+	if v := c.QueryParam("date"); v != "" {
+		d, err := time.Parse("2006-01-02", v)
+		if err != nil {
+			return c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: "invalid date format, expected YYYY-MM-DD"})
+		}
+		date = d
+	}
+
+	rate, err := h.service.GetEffectiveRate(c.Request().Context(), c.QueryParam("currency"), date)
+	if err != nil {
+		return exchangeError(c, err, "failed to get exchange rate")
+	}
+
+	return c.JSON(http.StatusOK, toExchangeRateResponse(*rate))
+}
+
+// Delete removes an exchange rate.
+// @Summary Excluir Cotação
+// @Description Deletes an exchange rate by ID. Cash flows already converted keep the rate they were converted with.
+// @Tags ExchangeRates
+// @Accept json
+// @Produce json
+// @Param id path int true "Exchange Rate ID"
+// @Success 200 {object} map[string]string
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Router /exchange-rates/{id} [delete]
+func (h *ExchangeHandler) Delete(c echo.Context) error {
+	idStr := c.Param("id")
+	var id int32
+	if _, err := fmt.Sscanf(idStr, "%d", &id); err != nil {
+		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: "invalid id format"})
+	}
+
+	if err := h.service.DeleteRate(c.Request().Context(), id); err != nil {
+		return exchangeError(c, err, "failed to delete exchange rate")
+	}
+
+	return c.JSON(http.StatusOK, map[string]string{"status": "deleted"})
+}
+
+// ImportCSV loads exchange rates from a CSV file.
+// @Summary Importar Cotações (CSV)
+// @Description Reads a CSV with the columns currency, date (YYYY-MM-DD) and rate, separated by commas or semicolons. A header line is skipped. Rates of a currency and date already on file are replaced; nothing is saved if a line is invalid.
+// @Tags ExchangeRates
+// @Accept multipart/form-data
+// @Produce json
+// @Param file formData file true "CSV file"
+// @Success 201 {object} dto.ExchangeRateImportResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /exchange-rates/import [post]
+func (h *ExchangeHandler) ImportCSV(c echo.Context) error {
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: "file is required"})
+	}
+	file, err := fileHeader.Open()
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: "unable to read file"})
+	}
+	defer file.Close()
+
+	result, err := h.service.ImportCSV(c.Request().Context(), file)
+	if err != nil {
+		return exchangeError(c, err, "failed to import exchange rates")
+	}
+
+	rates := make([]dto.ExchangeRateResponse, len(result.Rates))
+	for i, r := range result.Rates {
+		rates[i] = toExchangeRateResponse(r)
+	}
+	return c.JSON(http.StatusCreated, dto.ExchangeRateImportResponse{
+		ImportedCount: len(rates),
+		Rates:         rates,
+	})
+}
+
+func RegisterExchangeRoutes(e *echo.Echo, h *ExchangeHandler) {
+	g := e.Group("/exchange-rates")
+	g.POST("", h.SetRate)
+	g.GET("", h.List)
+	g.GET("/effective", h.Effective)
+	g.POST("/import", h.ImportCSV)
+	g.DELETE("/:id", h.Delete)
+}
+
+func exchangeError(c echo.Context, err error, fallback string) error {
+	switch {
+	case errors.Is(err, exchange.ErrRateNotFound):
+		return c.JSON(http.StatusNotFound, dto.ErrorResponse{Error: err.Error()})
+	case errors.Is(err, exchange.ErrInvalidCurrency),
+		errors.Is(err, exchange.ErrBaseCurrency),
+		errors.Is(err, exchange.ErrInvalidRate),
+		errors.Is(err, exchange.ErrInvalidDate),
+		errors.Is(err, exchange.ErrEmptyFile),
+		errors.Is(err, exchange.ErrInvalidLine):
+		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: err.Error()})
+	}
+	return c.JSON(http.StatusInternalServerError, dto.ErrorResponse{Error: fmt.Sprintf("%s: %v", fallback, err)})
+}
+
+func toExchangeRateResponse(r exchange.Rate) dto.ExchangeRateResponse {
+	return dto.ExchangeRateResponse{
+		ID:        r.ID,
+		Currency:  r.Currency,
+		Date:      r.Date.Format("2006-01-02"),
+		Rate:      r.Rate,
+		CreatedAt: r.CreatedAt.Format(time.RFC3339),
+	}
+}
@@ -0,0 +1,379 @@
+package http
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/adapters/http/dto"
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/domain/cashflow"
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/domain/importer"
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/domain/payment"
+	"github.com/labstack/echo/v4"
+)
+
+type ImportHandler struct {
+	service importer.Service
+}
+
+func NewImportHandler(service importer.Service) *ImportHandler {
+	return &ImportHandler{service: service}
+}
+
+// CreateProfile registers a CSV column-mapping profile.
+// @Summary Criar Perfil de Importação
+// @Description Creates a column-mapping profile used to read a bank CSV export.
+// @Tags Imports
+// @Accept json
+// @Produce json
+// @Param payload body dto.ImportProfileRequest true "Profile Payload"
+// @Success 201 {object} dto.ImportProfileResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /imports/profiles [post]
+func (h *ImportHandler) CreateProfile(c echo.Context) error {
+	var req dto.ImportProfileRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: "invalid payload"})
+	}
+
+	created, err := h.service.CreateProfile(c.Request().Context(), toImportProfile(0, req))
+	if err != nil {
+		return importError(c, err, "failed to create import profile")
+	}
+
+	return c.JSON(http.StatusCreated, toImportProfileResponse(created))
+}
+
+// ListProfiles returns all CSV mapping profiles.
+// @Summary Listar Perfis de Importação
+// @Description Returns all CSV column-mapping profiles.
+// @Tags Imports
+// @Accept json
+// @Produce json
+// @Success 200 {array} dto.ImportProfileResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /imports/profiles [get]
+func (h *ImportHandler) ListProfiles(c echo.Context) error {
+	list, err := h.service.ListProfiles(c.Request().Context())
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, dto.ErrorResponse{Error: "failed to list import profiles"})
+	}
+
+	resp := make([]dto.ImportProfileResponse, len(list))
+	for i, p := range list {
+		resp[i] = toImportProfileResponse(&p)
+	}
+
+	return c.JSON(http.StatusOK, resp)
+}
+
+// UpdateProfile replaces a CSV mapping profile.
+// @Summary Atualizar Perfil de Importação
+// @Description Updates a CSV column-mapping profile by ID.
+// @Tags Imports
+// @Accept json
+// @Produce json
+// @Param id path int true "Profile ID"
+// @Param payload body dto.ImportProfileRequest true "Profile Payload"
+// @Success 200 {object} dto.ImportProfileResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Router /imports/profiles/{id} [put]
+func (h *ImportHandler) UpdateProfile(c echo.Context) error {
+	idStr := c.Param("id")
+	var id int32
+	if _, err := fmt.Sscanf(idStr, "%d", &id); err != nil {
+		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: "invalid id format"})
+	}
+
+	var req dto.ImportProfileRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: "invalid payload"})
+	}
+
+	updated, err := h.service.UpdateProfile(c.Request().Context(), toImportProfile(id, req))
+	if err != nil {
+		return importError(c, err, "failed to update import profile")
+	}
+
+	return c.JSON(http.StatusOK, toImportProfileResponse(updated))
+}
+
+// DeleteProfile removes a CSV mapping profile.
+// @Summary Excluir Perfil de Importação
+// @Description Deletes a CSV column-mapping profile by ID.
+// @Tags Imports
+// @Accept json
+// @Produce json
+// @Param id path int true "Profile ID"
+// @Success 200 {object} map[string]string
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Router /imports/profiles/{id} [delete]
+func (h *ImportHandler) DeleteProfile(c echo.Context) error {
+	idStr := c.Param("id")
+	var id int32
+	if _, err := fmt.Sscanf(idStr, "%d", &id); err != nil {
+		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: "invalid id format"})
+	}
+
+	if err := h.service.DeleteProfile(c.Request().Context(), id); err != nil {
+		return importError(c, err, "failed to delete import profile")
+	}
+
+	return c.JSON(http.StatusOK, map[string]string{"status": "deleted"})
+}
+
+// PreviewCSV parses a bank CSV export without saving anything.
+// @Summary Pré-visualizar Importação CSV
+// @Description Reads a CSV statement with a mapping profile and returns cash flow candidates with suggested categories and duplicate flags.
+// @Tags Imports
+// @Accept multipart/form-data
+// @Produce json
+// @Param profile_id formData int true "Profile ID"
+// @Param file formData file true "CSV statement"
+// @Success 200 {object} dto.ImportPreviewResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Router /imports/csv/preview [post]
+func (h *ImportHandler) PreviewCSV(c echo.Context) error {
+	var profileID int32
+	if _, err := fmt.Sscanf(c.FormValue("profile_id"), "%d", &profileID); err != nil {
+		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: "profile_id is required"})
+	}
+
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: "file is required"})
+	}
+	file, err := fileHeader.Open()
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: "unable to read file"})
+	}
+	defer file.Close()
+
+	preview, err := h.service.PreviewCSV(c.Request().Context(), profileID, file)
+	if err != nil {
+		return importError(c, err, "failed to preview import")
+	}
+
+	return c.JSON(http.StatusOK, toImportPreviewResponse(preview))
+}
+
+// PreviewOFX parses an OFX/QFX statement without saving anything.
+// @Summary Pré-visualizar Importação OFX
+// @Description Reads an OFX/QFX statement and returns cash flow candidates. Transactions whose FITID was already imported are flagged and skipped on commit.
+// @Tags Imports
+// @Accept multipart/form-data
+// @Produce json
+// @Param payment_method_id formData int false "Payment Method ID (required for credit card statements)"
+// @Param file formData file true "OFX statement"
+// @Success 200 {object} dto.ImportPreviewResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Router /imports/ofx/preview [post]
+func (h *ImportHandler) PreviewOFX(c echo.Context) error {
+	var paymentMethodID *int32
+	if v := c.FormValue("payment_method_id"); v != "" {
+		var id int32
+		if _, err := fmt.Sscanf(v, "%d", &id); err != nil {
+			return c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: "invalid payment_method_id"})
+		}
+		paymentMethodID = &id
+	}
+
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: "file is required"})
+	}
+	file, err := fileHeader.Open()
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: "unable to read file"})
+	}
+	defer file.Close()
+
+	preview, err := h.service.PreviewOFX(c.Request().Context(), file, paymentMethodID)
+	if err != nil {
+		return importError(c, err, "failed to preview import")
+	}
+
+	return c.JSON(http.StatusOK, toImportPreviewResponse(preview))
+}
+
+// Commit saves the reviewed import candidates.
+// @Summary Confirmar Importação
+// @Description Creates all items as cash flows in a single transaction. Nothing is saved if one item fails. Items with an already imported FITID are skipped.
+// @Tags Imports
+// @Accept json
+// @Produce json
+// @Param payload body dto.ImportCommitRequest true "Items to import"
+// @Success 201 {object} dto.ImportCommitResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /imports/commit [post]
+func (h *ImportHandler) Commit(c echo.Context) error {
+	var req dto.ImportCommitRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: "invalid payload"})
+	}
+
+	flows := make([]cashflow.CreateCashFlowRequest, len(req.Items))
+	for i, item := range req.Items {
+		date, err := time.Parse("2006-01-02", item.Date)
+		if err != nil {
+			return c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: fmt.Sprintf("item %d: invalid date format, use YYYY-MM-DD", i+1)})
+		}
+		flows[i] = cashflow.CreateCashFlowRequest{
+			Date:            date,
+			CategoryID:      item.CategoryID,
+			Direction:       item.Direction,
+			Title:           item.Title,
+			Amount:          item.Amount,
+			IsFixed:         item.IsFixed,
+			PaymentMethodID: item.PaymentMethodID,
+			FITID:           item.FITID,
+			ExternalAccount: item.ExternalAccount,
+			PayeeID:         item.PayeeID,
+		}
+	}
+
+	result, err := h.service.Commit(c.Request().Context(), flows)
+	if err != nil {
+		return importError(c, err, "failed to import")
+	}
+
+	items := make([]dto.CashFlowResponse, len(result.Created))
+	for i, cf := range result.Created {
+		items[i] = toCashFlowResponse(cf)
+	}
+
+	return c.JSON(http.StatusCreated, dto.ImportCommitResponse{
+		CreatedCount: len(result.Created),
+		SkippedCount: result.Skipped,
+		Items:        items,
+	})
+}
+
+func RegisterImportRoutes(e *echo.Echo, h *ImportHandler) {
+	g := e.Group("/imports")
+	g.POST("/profiles", h.CreateProfile)
+	g.GET("/profiles", h.ListProfiles)
+	g.PUT("/profiles/:id", h.UpdateProfile)
+	g.DELETE("/profiles/:id", h.DeleteProfile)
+	g.POST("/csv/preview", h.PreviewCSV)
+	g.POST("/ofx/preview", h.PreviewOFX)
+	g.POST("/commit", h.Commit)
+}
+
+func importError(c echo.Context, err error, fallback string) error {
+	switch {
+	case errors.Is(err, importer.ErrProfileNotFound),
+		errors.Is(err, payment.ErrPaymentMethodNotFound):
+		return c.JSON(http.StatusNotFound, dto.ErrorResponse{Error: err.Error()})
+	case errors.Is(err, importer.ErrFileTooLarge):
+		return c.JSON(http.StatusRequestEntityTooLarge, dto.ErrorResponse{Error: err.Error()})
+	case errors.Is(err, importer.ErrNameRequired),
+		errors.Is(err, importer.ErrInvalidDelimiter),
+		errors.Is(err, importer.ErrInvalidColumn),
+		errors.Is(err, importer.ErrInvalidDateFormat),
+		errors.Is(err, importer.ErrInvalidSignConvention),
+		errors.Is(err, importer.ErrEmptyFile),
+		errors.Is(err, importer.ErrInvalidOFX),
+		errors.Is(err, importer.ErrPaymentMethodRequired),
+		errors.Is(err, importer.ErrNothingToCommit):
+		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: err.Error()})
+	}
+	// Commit failures caused by an invalid item are reported as bad requests.
+	return cashFlowError(c, err, fallback)
+}
+
+func toImportProfile(id int32, req dto.ImportProfileRequest) importer.Profile {
+	p := importer.Profile{
+		ID:             id,
+		Name:           req.Name,
+		Delimiter:      req.Delimiter,
+		HasHeader:      true,
+		DateColumn:     req.DateColumn,
+		DateFormat:     req.DateFormat,
+		TitleColumn:    req.TitleColumn,
+		AmountColumn:   req.AmountColumn,
+		DecimalComma:   true,
+		SignConvention: req.SignConvention,
+	}
+	if p.Delimiter == "" {
+		p.Delimiter = ";"
+	}
+	if p.DateFormat == "" {
+		p.DateFormat = "DD/MM/YYYY"
+	}
+	if p.SignConvention == "" {
+		p.SignConvention = importer.SignNegativeIsOut
+	}
+	if req.HasHeader != nil {
+		p.HasHeader = *req.HasHeader
+	}
+	if req.DecimalComma != nil {
+		p.DecimalComma = *req.DecimalComma
+	}
+	return p
+}
+
+func toImportProfileResponse(p *importer.Profile) dto.ImportProfileResponse {
+	return dto.ImportProfileResponse{
+		ID:             p.ID,
+		Name:           p.Name,
+		Delimiter:      p.Delimiter,
+		HasHeader:      p.HasHeader,
+		DateColumn:     p.DateColumn,
+		DateFormat:     p.DateFormat,
+		TitleColumn:    p.TitleColumn,
+		AmountColumn:   p.AmountColumn,
+		DecimalComma:   p.DecimalComma,
+		SignConvention: p.SignConvention,
+	}
+}
+
+func toImportPreviewResponse(preview *importer.Preview) dto.ImportPreviewResponse {
+	resp := dto.ImportPreviewResponse{
+		PaymentMethodID: preview.PaymentMethodID,
+		Candidates:      make([]dto.ImportCandidateResponse, len(preview.Candidates)),
+	}
+	for i, cand := range preview.Candidates {
+		item := dto.ImportCandidateResponse{
+			Line:            cand.Line,
+			Title:           cand.Flow.Title,
+			Amount:          cand.Flow.Amount,
+			Direction:       cand.Flow.Direction,
+			Duplicate:       cand.Duplicate,
+			DuplicateOfID:   cand.DuplicateOfID,
+			AlreadyImported: cand.AlreadyImported,
+			FITID:           cand.Flow.FITID,
+			ExternalAccount: cand.Flow.ExternalAccount,
+			PayeeID:         cand.Flow.PayeeID,
+			IsFixed:         cand.Flow.IsFixed,
+			Error:           cand.Error,
+		}
+		if !cand.Flow.Date.IsZero() {
+			item.Date = cand.Flow.Date.Format("2006-01-02")
+		}
+		if cand.Flow.CategoryID != 0 {
+			categoryID := cand.Flow.CategoryID
+			item.SuggestedCategoryID = &categoryID
+			item.SuggestedCategoryName = cand.Flow.CategoryName
+		}
+		resp.Candidates[i] = item
+
+		switch {
+		case cand.Error != "":
+			resp.ErrorCount++
+		case cand.Duplicate:
+			resp.DuplicateCount++
+			resp.ValidCount++
+		default:
+			resp.ValidCount++
+		}
+	}
+	return resp
+}
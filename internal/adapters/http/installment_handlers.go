@@ -9,6 +9,7 @@ import (
 
 	"github.com/LucasSiedschlag/HausHaltsMeister/internal/adapters/http/dto"
 	"github.com/LucasSiedschlag/HausHaltsMeister/internal/domain/cashflow"
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/domain/exchange"
 	"github.com/LucasSiedschlag/HausHaltsMeister/internal/domain/installment"
 	"github.com/LucasSiedschlag/HausHaltsMeister/internal/domain/payment"
 	"github.com/labstack/echo/v4"
@@ -56,13 +57,13 @@ func (h *InstallmentHandler) Create(c echo.Context) error {
 		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: "invalid date format"})
 	}
 
-	if req.TotalAmount > 0 && req.InstallmentAmount > 0 {
+	if req.TotalAmount.IsPositive() && req.InstallmentAmount.IsPositive() {
 		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: "provide only one: total_amount or installment_amount"})
 	}
 
 	amountMode := strings.ToUpper(strings.TrimSpace(req.AmountMode))
 	if amountMode == "" {
-		if req.InstallmentAmount > 0 {
+		if req.InstallmentAmount.IsPositive() {
 			amountMode = "INSTALLMENT"
 		} else {
 			amountMode = "TOTAL"
@@ -72,31 +73,39 @@ func (h *InstallmentHandler) Create(c echo.Context) error {
 	totalAmount := req.TotalAmount
 	switch amountMode {
 	case "TOTAL":
-		if totalAmount <= 0 {
+		if !totalAmount.IsPositive() {
 			return c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: "total_amount must be greater than zero"})
 		}
 	case "INSTALLMENT":
-		if req.InstallmentAmount <= 0 {
+		if !req.InstallmentAmount.IsPositive() {
 			return c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: "installment_amount must be greater than zero"})
 		}
-		totalAmount = req.InstallmentAmount * float64(req.Count)
+		totalAmount = req.InstallmentAmount.Mul(int64(req.Count))
 	default:
 		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: "amount_mode must be TOTAL or INSTALLMENT"})
 	}
 
-	plan, err := h.service.CreateInstallmentPurchase(
-		c.Request().Context(),
-		req.Description,
-		totalAmount,
-		req.Count,
-		req.CategoryID,
-		req.PaymentMethodID,
-		pDate,
-	)
+	plan, err := h.service.CreatePurchase(c.Request().Context(), installment.PurchaseRequest{
+		Description:     req.Description,
+		TotalAmount:     totalAmount,
+		Count:           req.Count,
+		CategoryID:      req.CategoryID,
+		PaymentMethodID: req.PaymentMethodID,
+		PurchaseDate:    pDate,
+		Currency:        req.Currency,
+		IOFAmount:       req.IOFAmount,
+	})
 	if err != nil {
 		if errors.Is(err, installment.ErrInvalidTotalAmount) || errors.Is(err, installment.ErrInvalidCount) {
 			return c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: err.Error()})
 		}
+		if errors.Is(err, cashflow.ErrExchangeRateNotFound) ||
+			errors.Is(err, cashflow.ErrIOFNotForeign) ||
+			errors.Is(err, cashflow.ErrInvalidIOF) ||
+			errors.Is(err, cashflow.ErrInvalidAmount) ||
+			errors.Is(err, exchange.ErrInvalidCurrency) {
+			return c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: err.Error()})
+		}
 		if errors.Is(err, payment.ErrPaymentMethodNotFound) {
 			return c.JSON(http.StatusNotFound, dto.ErrorResponse{Error: err.Error()})
 		}
@@ -118,7 +127,7 @@ func RegisterInstallmentRoutes(e *echo.Echo, h *InstallmentHandler) {
 }
 
 func toInstallmentPlanResponse(p *installment.InstallmentPlan) dto.InstallmentPlanResponse {
-	return dto.InstallmentPlanResponse{
+	resp := dto.InstallmentPlanResponse{
 		ID:                p.ID,
 		Description:       p.Description,
 		TotalAmount:       p.TotalAmount,
@@ -127,4 +136,11 @@ func toInstallmentPlanResponse(p *installment.InstallmentPlan) dto.InstallmentPl
 		StartMonth:        p.StartMonth.Format("2006-01-02"),
 		PaymentMethodID:   p.PaymentMethodID,
 	}
+	if conv := p.Conversion; conv != nil {
+		resp.Currency = conv.Currency
+		resp.OriginalAmount = &conv.OriginalAmount
+		resp.ExchangeRate = &conv.Rate
+		resp.IOFAmount = &conv.IOFAmount
+	}
+	return resp
 }
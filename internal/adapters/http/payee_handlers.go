@@ -0,0 +1,391 @@
+package http
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/adapters/http/dto"
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/domain/category"
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/domain/payee"
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/domain/payment"
+	"github.com/labstack/echo/v4"
+)
+
+type PayeeHandler struct {
+	service payee.Service
+}
+
+func NewPayeeHandler(service payee.Service) *PayeeHandler {
+	return &PayeeHandler{service: service}
+}
+
+// Create registers a payee.
+// @Summary Criar Favorecido
+// @Description Creates a payee with its aliases and optional default category and payment method. Names and aliases are matched against cash flow titles ignoring case and punctuation, and each one can belong to a single payee.
+// @Tags Payees
+// @Accept json
+// @Produce json
+// @Param payload body dto.PayeeRequest true "Payee Payload"
+// @Success 201 {object} dto.PayeeResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 409 {object} dto.ErrorResponse
+// @Router /payees [post]
+func (h *PayeeHandler) Create(c echo.Context) error {
+	var req dto.PayeeRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: "invalid payload"})
+	}
+
+	created, err := h.service.CreatePayee(c.Request().Context(), toPayee(0, req))
+	if err != nil {
+		return payeeError(c, err, "failed to create payee")
+	}
+
+	return c.JSON(http.StatusCreated, toPayeeResponse(*created))
+}
+
+// List returns all payees.
+// @Summary Listar Favorecidos
+// @Description Returns all payees with their aliases and the number of cash flows linked to each one.
+// @Tags Payees
+// @Accept json
+// @Produce json
+// @Success 200 {array} dto.PayeeResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /payees [get]
+func (h *PayeeHandler) List(c echo.Context) error {
+	list, err := h.service.ListPayees(c.Request().Context())
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, dto.ErrorResponse{Error: "failed to list payees"})
+	}
+
+	resp := make([]dto.PayeeResponse, len(list))
+	for i, p := range list {
+		resp[i] = toPayeeResponse(p)
+	}
+	return c.JSON(http.StatusOK, resp)
+}
+
+// Get returns a payee.
+// @Summary Obter Favorecido
+// @Description Returns a payee by ID.
+// @Tags Payees
+// @Accept json
+// @Produce json
+// @Param id path int true "Payee ID"
+// @Success 200 {object} dto.PayeeResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Router /payees/{id} [get]
+func (h *PayeeHandler) Get(c echo.Context) error {
+	idStr := c.Param("id")
+	var id int32
+	if _, err := fmt.Sscanf(idStr, "%d", &id); err != nil {
+		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: "invalid id format"})
+	}
+
+	p, err := h.service.GetPayee(c.Request().Context(), id)
+	if err != nil {
+		return payeeError(c, err, "failed to get payee")
+	}
+
+	return c.JSON(http.StatusOK, toPayeeResponse(*p))
+}
+
+// Update changes a payee.
+// @Summary Atualizar Favorecido
+// @Description Replaces the name, aliases and defaults of a payee. Linked cash flows keep the link.
+// @Tags Payees
+// @Accept json
+// @Produce json
+// @Param id path int true "Payee ID"
+// @Param payload body dto.PayeeRequest true "Payee Payload"
+// @Success 200 {object} dto.PayeeResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 409 {object} dto.ErrorResponse
+// @Router /payees/{id} [put]
+func (h *PayeeHandler) Update(c echo.Context) error {
+	idStr := c.Param("id")
+	var id int32
+	if _, err := fmt.Sscanf(idStr, "%d", &id); err != nil {
+		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: "invalid id format"})
+	}
+
+	var req dto.PayeeRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: "invalid payload"})
+	}
+
+	updated, err := h.service.UpdatePayee(c.Request().Context(), toPayee(id, req))
+	if err != nil {
+		return payeeError(c, err, "failed to update payee")
+	}
+
+	return c.JSON(http.StatusOK, toPayeeResponse(*updated))
+}
+
+// Delete removes a payee.
+// @Summary Excluir Favorecido
+// @Description Deletes a payee and its aliases. Its cash flows are kept, without a payee.
+// @Tags Payees
+// @Accept json
+// @Produce json
+// @Param id path int true "Payee ID"
+// @Success 200 {object} map[string]string
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Router /payees/{id} [delete]
+func (h *PayeeHandler) Delete(c echo.Context) error {
+	idStr := c.Param("id")
+	var id int32
+	if _, err := fmt.Sscanf(idStr, "%d", &id); err != nil {
+		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: "invalid id format"})
+	}
+
+	if err := h.service.DeletePayee(c.Request().Context(), id); err != nil {
+		return payeeError(c, err, "failed to delete payee")
+	}
+
+	return c.JSON(http.StatusOK, map[string]string{"status": "deleted"})
+}
+
+// Match finds the payee of a title.
+// @Summary Identificar Favorecido
+// @Description Returns the payee a cash flow title belongs to: the one with the longest name or alias the title equals or starts with, ignoring case and punctuation.
+// @Tags Payees
+// @Accept json
+// @Produce json
+// @Param title query string true "Cash flow title"
+// @Success 200 {object} dto.PayeeResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Router /payees/match [get]
+func (h *PayeeHandler) Match(c echo.Context) error {
+	p, err := h.service.MatchPayee(c.Request().Context(), c.QueryParam("title"))
+	if err != nil {
+		return payeeError(c, err, "failed to match payee")
+	}
+
+	return c.JSON(http.StatusOK, toPayeeResponse(*p))
+}
+
+// Merge folds duplicate payees into one.
+// @Summary Mesclar Favorecidos
+// @Description Moves the cash flows of the source payees to the target, adds their names and aliases to its aliases and deletes them.
+// @Tags Payees
+// @Accept json
+// @Produce json
+// @Param id path int true "Target Payee ID"
+// @Param payload body dto.MergePayeesRequest true "Source payees"
+// @Success 200 {object} dto.PayeeResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Router /payees/{id}/merge [post]
+func (h *PayeeHandler) Merge(c echo.Context) error {
+	idStr := c.Param("id")
+	var id int32
+	if _, err := fmt.Sscanf(idStr, "%d", &id); err != nil {
+		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: "invalid id format"})
+	}
+
+	var req dto.MergePayeesRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: "invalid payload"})
+	}
+
+	merged, err := h.service.MergePayees(c.Request().Context(), id, req.SourceIDs)
+	if err != nil {
+		return payeeError(c, err, "failed to merge payees")
+	}
+
+	return c.JSON(http.StatusOK, toPayeeResponse(*merged))
+}
+
+// Report totals the cash flows of a payee.
+// @Summary Relatório por Favorecido
+// @Description Returns the realized totals of a payee by category and by month, optionally between two dates. Split cash flows count per split line.
+// @Tags Reports
+// @Accept json
+// @Produce json
+// @Param id path int true "Payee ID"
+// @Param from query string false "Start date (YYYY-MM-DD)"
+// @Param to query string false "End date (YYYY-MM-DD)"
+// @Success 200 {object} dto.PayeeReportResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Router /payees/{id}/report [get]
+func (h *PayeeHandler) Report(c echo.Context) error {
+	idStr := c.Param("id")
+	var id int32
+	if _, err := fmt.Sscanf(idStr, "%d", &id); err != nil {
+		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: "invalid id format"})
+	}
+	from, to, err := parseDateRange(c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: err.Error()})
+	}
+
+	report, err := h.service.GetReport(c.Request().Context(), id, from, to)
+	if err != nil {
+		return payeeError(c, err, "failed to build payee report")
+	}
+
+	resp := dto.PayeeReportResponse{
+		PayeeID:      report.Payee.ID,
+		Name:         report.Payee.Name,
+		TotalIncome:  report.TotalIncome,
+		TotalExpense: report.TotalExpense,
+		ByCategory:   make([]dto.PayeeCategoryTotalResponse, len(report.ByCategory)),
+		ByMonth:      make([]dto.PayeeMonthTotalResponse, len(report.ByMonth)),
+	}
+	if from != nil {
+		resp.From = from.Format("2006-01-02")
+	}
+	if to != nil {
+		resp.To = to.Format("2006-01-02")
+	}
+	for i, t := range report.ByCategory {
+		resp.ByCategory[i] = dto.PayeeCategoryTotalResponse{
+			CategoryID:    t.CategoryID,
+			CategoryName:  t.CategoryName,
+			Direction:     t.Direction,
+			CashFlowCount: t.CashFlowCount,
+			TotalAmount:   t.TotalAmount,
+		}
+	}
+	for i, m := range report.ByMonth {
+		resp.ByMonth[i] = dto.PayeeMonthTotalResponse{
+			Month:        m.Month.Format("2006-01-02"),
+			TotalIncome:  m.TotalIncome,
+			TotalExpense: m.TotalExpense,
+		}
+	}
+
+	return c.JSON(http.StatusOK, resp)
+}
+
+// Top ranks payees by amount.
+// @Summary Principais Favorecidos
+// @Description Ranks payees by the realized amount of their cash flows in one direction, optionally between two dates.
+// @Tags Reports
+// @Accept json
+// @Produce json
+// @Param direction query string false "OUT (default) or IN"
+// @Param from query string false "Start date (YYYY-MM-DD)"
+// @Param to query string false "End date (YYYY-MM-DD)"
+// @Param limit query int false "Number of payees (max 100)" default(10)
+// @Success 200 {array} dto.PayeeTotalResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Router /reports/payees [get]
+func (h *PayeeHandler) Top(c echo.Context) error {
+	from, to, err := parseDateRange(c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: err.Error()})
+	}
+	filter := payee.TopFilter{From: from, To: to, Direction: c.QueryParam("direction")}
+	if v := c.QueryParam("limit"); v != "" {
+		limit, err := strconv.ParseInt(v, 10, 32)
+		if err != nil {
+			return c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: "invalid limit"})
+		}
+		filter.Limit = int32(limit)
+	}
+
+	totals, err := h.service.TopPayees(c.Request().Context(), filter)
+	if err != nil {
+		return payeeError(c, err, "failed to rank payees")
+	}
+
+	resp := make([]dto.PayeeTotalResponse, len(totals))
+	for i, t := range totals {
+		resp[i] = dto.PayeeTotalResponse{
+			PayeeID:       t.PayeeID,
+			Name:          t.Name,
+			CashFlowCount: t.CashFlowCount,
+			TotalAmount:   t.TotalAmount,
+		}
+	}
+	return c.JSON(http.StatusOK, resp)
+}
+
+func RegisterPayeeRoutes(e *echo.Echo, h *PayeeHandler) {
+	g := e.Group("/payees")
+	g.POST("", h.Create)
+	g.GET("", h.List)
+	g.GET("/match", h.Match)
+	g.GET("/:id", h.Get)
+	g.PUT("/:id", h.Update)
+	g.DELETE("/:id", h.Delete)
+	g.POST("/:id/merge", h.Merge)
+	g.GET("/:id/report", h.Report)
+
+	e.GET("/reports/payees", h.Top)
+}
+
+func payeeError(c echo.Context, err error, fallback string) error {
+	switch {
+	case errors.Is(err, payee.ErrPayeeNotFound):
+		return c.JSON(http.StatusNotFound, dto.ErrorResponse{Error: err.Error()})
+	case errors.Is(err, payee.ErrKeyInUse):
+		return c.JSON(http.StatusConflict, dto.ErrorResponse{Error: err.Error()})
+	case errors.Is(err, payee.ErrNameRequired),
+		errors.Is(err, payee.ErrNameTooLong),
+		errors.Is(err, payee.ErrEmptyAlias),
+		errors.Is(err, payee.ErrMergeIntoSelf),
+		errors.Is(err, payee.ErrNothingToMerge),
+		errors.Is(err, payee.ErrInvalidRange),
+		errors.Is(err, payee.ErrInvalidLimit),
+		errors.Is(err, payee.ErrInvalidDir),
+		errors.Is(err, category.ErrCategoryNotFound),
+		errors.Is(err, payment.ErrPaymentMethodNotFound):
+		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: err.Error()})
+	}
+	return c.JSON(http.StatusInternalServerError, dto.ErrorResponse{Error: fmt.Sprintf("%s: %v", fallback, err)})
+}
+
+// parseDateRange reads the optional from and to query parameters.
+func parseDateRange(c echo.Context) (from, to *time.Time, err error) {
+	if v := c.QueryParam("from"); v != "" {
+		d, err := time.Parse("2006-01-02", v)
+		if err != nil {
+			return nil, nil, errors.New("invalid from format, expected YYYY-MM-DD")
+		}
+		from = &d
+	}
+	if v := c.QueryParam("to"); v != "" {
+		d, err := time.Parse("2006-01-02", v)
+		if err != nil {
+			return nil, nil, errors.New("invalid to format, expected YYYY-MM-DD")
+		}
+		to = &d
+	}
+	return from, to, nil
+}
+
+func toPayee(id int32, req dto.PayeeRequest) payee.Payee {
+	return payee.Payee{
+		ID:                     id,
+		Name:                   req.Name,
+		Aliases:                req.Aliases,
+		DefaultCategoryID:      req.DefaultCategoryID,
+		DefaultPaymentMethodID: req.DefaultPaymentMethodID,
+	}
+}
+
+func toPayeeResponse(p payee.Payee) dto.PayeeResponse {
+	aliases := p.Aliases
+	if aliases == nil {
+		aliases = []string{}
+	}
+	return dto.PayeeResponse{
+		ID:                     p.ID,
+		Name:                   p.Name,
+		Aliases:                aliases,
+		DefaultCategoryID:      p.DefaultCategoryID,
+		DefaultPaymentMethodID: p.DefaultPaymentMethodID,
+		CashFlowCount:          p.CashFlowCount,
+	}
+}
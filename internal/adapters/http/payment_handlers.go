@@ -196,11 +196,14 @@ func (h *PaymentHandler) GetInvoice(c echo.Context) error {
 	entries := make([]dto.InvoiceEntryResponse, len(invoice.Entries))
 	for i, e := range invoice.Entries {
 		entries[i] = dto.InvoiceEntryResponse{
-			CashFlowID:   e.CashFlowID,
-			Date:         e.Date.Format("2006-01-02"),
-			Title:        e.Title,
-			Amount:       e.Amount,
-			CategoryName: e.CategoryName,
+			CashFlowID:     e.CashFlowID,
+			Date:           e.Date.Format("2006-01-02"),
+			Title:          e.Title,
+			Amount:         e.Amount,
+			CategoryName:   e.CategoryName,
+			Currency:       e.Currency,
+			OriginalAmount: e.OriginalAmount,
+			IOFAmount:      e.IOFAmount,
 		}
 	}
 
@@ -208,6 +211,7 @@ func (h *PaymentHandler) GetInvoice(c echo.Context) error {
 		PaymentMethodID: invoice.PaymentMethodID,
 		Month:           invoice.Month.Format("2006-01-02"),
 		Total:           invoice.Total,
+		TotalIOF:        invoice.TotalIOF,
 		TotalRemaining:  invoice.TotalRemaining,
 		Entries:         entries,
 	})
@@ -232,5 +236,6 @@ func toPaymentMethodResponse(m *payment.PaymentMethod) dto.PaymentMethodResponse
 		ClosingDay:  m.ClosingDay,
 		DueDay:      m.DueDay,
 		IsActive:    m.IsActive,
+		AccountID:   m.AccountID,
 	}
 }
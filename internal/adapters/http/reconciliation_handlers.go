@@ -0,0 +1,280 @@
+package http
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/adapters/http/dto"
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/domain/account"
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/domain/payment"
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/domain/reconciliation"
+	"github.com/labstack/echo/v4"
+)
+
+type ReconciliationHandler struct {
+	service reconciliation.Service
+}
+
+func NewReconciliationHandler(service reconciliation.Service) *ReconciliationHandler {
+	return &ReconciliationHandler{service: service}
+}
+
+// Start opens a reconciliation against a statement.
+// @Summary Iniciar Conciliação
+// @Description Opens a reconciliation of an account or credit card against a bank statement. Returns the worksheet: the flows up to the statement date not locked yet, the cleared balance and the difference to the statement.
+// @Tags Reconciliations
+// @Accept json
+// @Produce json
+// @Param payload body dto.StartReconciliationRequest true "Reconciliation Payload"
+// @Success 201 {object} dto.ReconciliationWorksheetResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 409 {object} dto.ErrorResponse
+// @Router /reconciliations [post]
+func (h *ReconciliationHandler) Start(c echo.Context) error {
+	var req dto.StartReconciliationRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: "invalid payload"})
+	}
+
+	date, err := time.Parse("2006-01-02", req.StatementDate)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: "invalid statement_date format, expected YYYY-MM-DD"})
+	}
+
+	ws, err := h.service.Start(c.Request().Context(), reconciliation.Reconciliation{
+		Owner: reconciliation.Owner{
+			AccountID:       req.AccountID,
+			PaymentMethodID: req.PaymentMethodID,
+		},
+		StatementDate:    date,
+		StatementBalance: req.StatementBalance,
+	})
+	if err != nil {
+		return reconciliationError(c, err, "failed to start reconciliation")
+	}
+
+	return c.JSON(http.StatusCreated, toReconciliationWorksheetResponse(*ws))
+}
+
+// List returns reconciliations.
+// @Summary Listar Conciliações
+// @Description Returns reconciliations, most recent statement first, optionally filtered by account or credit card.
+// @Tags Reconciliations
+// @Accept json
+// @Produce json
+// @Param account_id query int false "Account ID"
+// @Param payment_method_id query int false "Payment method ID"
+// @Success 200 {array} dto.ReconciliationResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /reconciliations [get]
+func (h *ReconciliationHandler) List(c echo.Context) error {
+	var owner reconciliation.Owner
+	if v := c.QueryParam("account_id"); v != "" {
+		var id int32
+		if _, err := fmt.Sscanf(v, "%d", &id); err != nil {
+			return c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: "invalid account_id format"})
+		}
+		owner.AccountID = &id
+	}
+	if v := c.QueryParam("payment_method_id"); v != "" {
+		var id int32
+		if _, err := fmt.Sscanf(v, "%d", &id); err != nil {
+			return c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: "invalid payment_method_id format"})
+		}
+		owner.PaymentMethodID = &id
+	}
+
+	list, err := h.service.List(c.Request().Context(), owner)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, dto.ErrorResponse{Error: "failed to list reconciliations"})
+	}
+
+	resp := make([]dto.ReconciliationResponse, len(list))
+	for i, r := range list {
+		resp[i] = toReconciliationResponse(r)
+	}
+	return c.JSON(http.StatusOK, resp)
+}
+
+// Get returns the worksheet of a reconciliation.
+// @Summary Obter Conciliação
+// @Description Returns a reconciliation with its flows. While open, lists the candidate flows with their cleared mark; once completed, the flows it locked.
+// @Tags Reconciliations
+// @Accept json
+// @Produce json
+// @Param id path int true "Reconciliation ID"
+// @Success 200 {object} dto.ReconciliationWorksheetResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Router /reconciliations/{id} [get]
+func (h *ReconciliationHandler) Get(c echo.Context) error {
+	idStr := c.Param("id")
+	var id int32
+	if _, err := fmt.Sscanf(idStr, "%d", &id); err != nil {
+		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: "invalid id format"})
+	}
+
+	ws, err := h.service.Get(c.Request().Context(), id)
+	if err != nil {
+		return reconciliationError(c, err, "failed to get reconciliation")
+	}
+
+	return c.JSON(http.StatusOK, toReconciliationWorksheetResponse(*ws))
+}
+
+// Clear marks flows as cleared.
+// @Summary Conferir Lançamentos
+// @Description Marks flows of the statement as cleared, or unmarks them with cleared=false, and returns the updated worksheet with the new difference.
+// @Tags Reconciliations
+// @Accept json
+// @Produce json
+// @Param id path int true "Reconciliation ID"
+// @Param payload body dto.ClearCashFlowsRequest true "Cash flows"
+// @Success 200 {object} dto.ReconciliationWorksheetResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 409 {object} dto.ErrorResponse
+// @Router /reconciliations/{id}/clear [post]
+func (h *ReconciliationHandler) Clear(c echo.Context) error {
+	idStr := c.Param("id")
+	var id int32
+	if _, err := fmt.Sscanf(idStr, "%d", &id); err != nil {
+		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: "invalid id format"})
+	}
+
+	var req dto.ClearCashFlowsRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: "invalid payload"})
+	}
+	cleared := req.Cleared == nil || *req.Cleared
+
+	ws, err := h.service.SetCleared(c.Request().Context(), id, req.CashFlowIDs, cleared)
+	if err != nil {
+		return reconciliationError(c, err, "failed to clear cash flows")
+	}
+
+	return c.JSON(http.StatusOK, toReconciliationWorksheetResponse(*ws))
+}
+
+// Complete finishes a reconciliation.
+// @Summary Concluir Conciliação
+// @Description Completes the reconciliation when the difference is zero. The cleared flows get locked: they can no longer be edited, moved to another account or deleted.
+// @Tags Reconciliations
+// @Accept json
+// @Produce json
+// @Param id path int true "Reconciliation ID"
+// @Success 200 {object} dto.ReconciliationWorksheetResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 409 {object} dto.ErrorResponse
+// @Router /reconciliations/{id}/complete [post]
+func (h *ReconciliationHandler) Complete(c echo.Context) error {
+	idStr := c.Param("id")
+	var id int32
+	if _, err := fmt.Sscanf(idStr, "%d", &id); err != nil {
+		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: "invalid id format"})
+	}
+
+	ws, err := h.service.Complete(c.Request().Context(), id)
+	if err != nil {
+		return reconciliationError(c, err, "failed to complete reconciliation")
+	}
+
+	return c.JSON(http.StatusOK, toReconciliationWorksheetResponse(*ws))
+}
+
+// Cancel discards an open reconciliation.
+// @Summary Cancelar Conciliação
+// @Description Deletes an open reconciliation. Cleared marks are kept. Completed reconciliations cannot be cancelled.
+// @Tags Reconciliations
+// @Accept json
+// @Produce json
+// @Param id path int true "Reconciliation ID"
+// @Success 200 {object} map[string]string
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 409 {object} dto.ErrorResponse
+// @Router /reconciliations/{id} [delete]
+func (h *ReconciliationHandler) Cancel(c echo.Context) error {
+	idStr := c.Param("id")
+	var id int32
+	if _, err := fmt.Sscanf(idStr, "%d", &id); err != nil {
+		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: "invalid id format"})
+	}
+
+	if err := h.service.Cancel(c.Request().Context(), id); err != nil {
+		return reconciliationError(c, err, "failed to cancel reconciliation")
+	}
+
+	return c.JSON(http.StatusOK, map[string]string{"status": "deleted"})
+}
+
+func RegisterReconciliationRoutes(e *echo.Echo, h *ReconciliationHandler) {
+	g := e.Group("/reconciliations")
+	g.POST("", h.Start)
+	g.GET("", h.List)
+	g.GET("/:id", h.Get)
+	g.POST("/:id/clear", h.Clear)
+	g.POST("/:id/complete", h.Complete)
+	g.DELETE("/:id", h.Cancel)
+}
+
+func reconciliationError(c echo.Context, err error, fallback string) error {
+	switch {
+	case errors.Is(err, reconciliation.ErrReconciliationNotFound),
+		errors.Is(err, account.ErrAccountNotFound),
+		errors.Is(err, payment.ErrPaymentMethodNotFound):
+		return c.JSON(http.StatusNotFound, dto.ErrorResponse{Error: err.Error()})
+	case errors.Is(err, reconciliation.ErrInvalidOwner),
+		errors.Is(err, reconciliation.ErrNotACreditCard),
+		errors.Is(err, reconciliation.ErrInvalidDate),
+		errors.Is(err, reconciliation.ErrFlowNotInStatement):
+		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: err.Error()})
+	case errors.Is(err, reconciliation.ErrAlreadyOpen),
+		errors.Is(err, reconciliation.ErrNotOpen),
+		errors.Is(err, reconciliation.ErrNotBalanced):
+		return c.JSON(http.StatusConflict, dto.ErrorResponse{Error: err.Error()})
+	}
+	return c.JSON(http.StatusInternalServerError, dto.ErrorResponse{Error: fmt.Sprintf("%s: %v", fallback, err)})
+}
+
+func toReconciliationResponse(r reconciliation.Reconciliation) dto.ReconciliationResponse {
+	resp := dto.ReconciliationResponse{
+		ID:               r.ID,
+		AccountID:        r.Owner.AccountID,
+		PaymentMethodID:  r.Owner.PaymentMethodID,
+		StatementDate:    r.StatementDate.Format("2006-01-02"),
+		StatementBalance: r.StatementBalance,
+		Status:           r.Status,
+		ClearedBalance:   r.ClearedBalance,
+		Difference:       r.Difference(),
+		CreatedAt:        r.CreatedAt.Format(time.RFC3339),
+	}
+	if r.CompletedAt != nil {
+		resp.CompletedAt = r.CompletedAt.Format(time.RFC3339)
+	}
+	return resp
+}
+
+func toReconciliationWorksheetResponse(ws reconciliation.Worksheet) dto.ReconciliationWorksheetResponse {
+	items := make([]dto.ReconciliationItemResponse, len(ws.Items))
+	for i, item := range ws.Items {
+		items[i] = dto.ReconciliationItemResponse{
+			CashFlowID:   item.CashFlowID,
+			Date:         item.Date.Format("2006-01-02"),
+			Direction:    item.Direction,
+			Title:        item.Title,
+			Amount:       item.Amount,
+			CategoryName: item.CategoryName,
+			Cleared:      item.ClearedAt != nil,
+		}
+	}
+	return dto.ReconciliationWorksheetResponse{
+		ReconciliationResponse: toReconciliationResponse(ws.Reconciliation),
+		Items:                  items,
+	}
+}
@@ -0,0 +1,318 @@
+package http
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/adapters/http/dto"
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/domain/payment"
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/domain/recurrence"
+	"github.com/labstack/echo/v4"
+)
+
+type RecurrenceHandler struct {
+	service recurrence.Service
+}
+
+func NewRecurrenceHandler(service recurrence.Service) *RecurrenceHandler {
+	return &RecurrenceHandler{service: service}
+}
+
+// Create registers a recurrence rule.
+// @Summary Criar Regra de Recorrência
+// @Description Creates a template cash flow with a weekly, monthly or yearly schedule.
+// @Tags Recurrences
+// @Accept json
+// @Produce json
+// @Param payload body dto.RecurrenceRuleRequest true "Rule Payload"
+// @Success 201 {object} dto.RecurrenceRuleResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Router /recurrences [post]
+func (h *RecurrenceHandler) Create(c echo.Context) error {
+	var req dto.RecurrenceRuleRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: "invalid payload"})
+	}
+
+	rule, err := toRecurrenceRule(0, req)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: err.Error()})
+	}
+
+	created, err := h.service.CreateRule(c.Request().Context(), rule)
+	if err != nil {
+		return recurrenceError(c, err, "failed to create recurrence rule")
+	}
+
+	return c.JSON(http.StatusCreated, toRecurrenceRuleResponse(created))
+}
+
+// List returns all recurrence rules.
+// @Summary Listar Regras de Recorrência
+// @Description Returns all recurrence rules, active and inactive.
+// @Tags Recurrences
+// @Accept json
+// @Produce json
+// @Success 200 {array} dto.RecurrenceRuleResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /recurrences [get]
+func (h *RecurrenceHandler) List(c echo.Context) error {
+	list, err := h.service.ListRules(c.Request().Context())
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, dto.ErrorResponse{Error: "failed to list recurrence rules"})
+	}
+
+	resp := make([]dto.RecurrenceRuleResponse, len(list))
+	for i, r := range list {
+		resp[i] = toRecurrenceRuleResponse(&r)
+	}
+
+	return c.JSON(http.StatusOK, resp)
+}
+
+// Update replaces a recurrence rule.
+// @Summary Atualizar Regra de Recorrência
+// @Description Updates a recurrence rule by ID. Occurrences already generated are not changed.
+// @Tags Recurrences
+// @Accept json
+// @Produce json
+// @Param id path int true "Rule ID"
+// @Param payload body dto.RecurrenceRuleRequest true "Rule Payload"
+// @Success 200 {object} dto.RecurrenceRuleResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Router /recurrences/{id} [put]
+func (h *RecurrenceHandler) Update(c echo.Context) error {
+	idStr := c.Param("id")
+	var id int32
+	if _, err := fmt.Sscanf(idStr, "%d", &id); err != nil {
+		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: "invalid id format"})
+	}
+
+	var req dto.RecurrenceRuleRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: "invalid payload"})
+	}
+
+	rule, err := toRecurrenceRule(id, req)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: err.Error()})
+	}
+
+	updated, err := h.service.UpdateRule(c.Request().Context(), rule)
+	if err != nil {
+		return recurrenceError(c, err, "failed to update recurrence rule")
+	}
+
+	return c.JSON(http.StatusOK, toRecurrenceRuleResponse(updated))
+}
+
+// Delete removes a recurrence rule.
+// @Summary Excluir Regra de Recorrência
+// @Description Deletes a recurrence rule by ID. Cash flows it generated are kept.
+// @Tags Recurrences
+// @Accept json
+// @Produce json
+// @Param id path int true "Rule ID"
+// @Success 200 {object} map[string]string
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Router /recurrences/{id} [delete]
+func (h *RecurrenceHandler) Delete(c echo.Context) error {
+	idStr := c.Param("id")
+	var id int32
+	if _, err := fmt.Sscanf(idStr, "%d", &id); err != nil {
+		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: "invalid id format"})
+	}
+
+	if err := h.service.DeleteRule(c.Request().Context(), id); err != nil {
+		return recurrenceError(c, err, "failed to delete recurrence rule")
+	}
+
+	return c.JSON(http.StatusOK, map[string]string{"status": "deleted"})
+}
+
+// ListOccurrences returns the occurrences already generated for a rule.
+// @Summary Listar Ocorrências
+// @Description Returns the nominal dates already materialized for a rule and the cash flow generated for each one.
+// @Tags Recurrences
+// @Accept json
+// @Produce json
+// @Param id path int true "Rule ID"
+// @Success 200 {array} dto.RecurrenceOccurrenceResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Router /recurrences/{id}/occurrences [get]
+func (h *RecurrenceHandler) ListOccurrences(c echo.Context) error {
+	idStr := c.Param("id")
+	var id int32
+	if _, err := fmt.Sscanf(idStr, "%d", &id); err != nil {
+		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: "invalid id format"})
+	}
+
+	list, err := h.service.ListOccurrences(c.Request().Context(), id)
+	if err != nil {
+		return recurrenceError(c, err, "failed to list occurrences")
+	}
+
+	resp := make([]dto.RecurrenceOccurrenceResponse, len(list))
+	for i, o := range list {
+		resp[i] = toRecurrenceOccurrenceResponse(o)
+	}
+
+	return c.JSON(http.StatusOK, resp)
+}
+
+// Materialize generates the cash flows of all active rules for a month range.
+// @Summary Gerar Lançamentos Recorrentes
+// @Description Creates the cash flows of every active rule between from_month and to_month. Occurrences generated before are skipped, so the call can be repeated safely.
+// @Tags Recurrences
+// @Accept json
+// @Produce json
+// @Param payload body dto.MaterializeRequest true "Month range"
+// @Success 200 {object} dto.MaterializeResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /recurrences/materialize [post]
+func (h *RecurrenceHandler) Materialize(c echo.Context) error {
+	var req dto.MaterializeRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: "invalid payload"})
+	}
+
+	from, err := time.Parse("2006-01-02", req.FromMonth)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: "invalid from_month format"})
+	}
+	to, err := time.Parse("2006-01-02", req.ToMonth)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: "invalid to_month format"})
+	}
+
+	result, err := h.service.Materialize(c.Request().Context(), from, to)
+	if err != nil {
+		return recurrenceError(c, err, "failed to materialize recurrences")
+	}
+
+	resp := dto.MaterializeResponse{
+		CreatedCount: len(result.Created),
+		SkippedCount: result.Skipped,
+		FailedCount:  len(result.Failed),
+		Created:      make([]dto.RecurrenceOccurrenceResponse, len(result.Created)),
+		Failed:       make([]dto.MaterializeFailureResponse, len(result.Failed)),
+	}
+	for i, o := range result.Created {
+		resp.Created[i] = toRecurrenceOccurrenceResponse(o)
+	}
+	for i, f := range result.Failed {
+		resp.Failed[i] = dto.MaterializeFailureResponse{
+			RuleID: f.RuleID,
+			Date:   f.Date.Format("2006-01-02"),
+			Error:  f.Error,
+		}
+	}
+
+	return c.JSON(http.StatusOK, resp)
+}
+
+func RegisterRecurrenceRoutes(e *echo.Echo, h *RecurrenceHandler) {
+	g := e.Group("/recurrences")
+	g.POST("", h.Create)
+	g.GET("", h.List)
+	g.POST("/materialize", h.Materialize)
+	g.PUT("/:id", h.Update)
+	g.DELETE("/:id", h.Delete)
+	g.GET("/:id/occurrences", h.ListOccurrences)
+}
+
+func recurrenceError(c echo.Context, err error, fallback string) error {
+	switch {
+	case errors.Is(err, recurrence.ErrRuleNotFound),
+		errors.Is(err, payment.ErrPaymentMethodNotFound):
+		return c.JSON(http.StatusNotFound, dto.ErrorResponse{Error: err.Error()})
+	case errors.Is(err, recurrence.ErrTitleRequired),
+		errors.Is(err, recurrence.ErrInvalidAmount),
+		errors.Is(err, recurrence.ErrInvalidDirection),
+		errors.Is(err, recurrence.ErrInvalidFrequency),
+		errors.Is(err, recurrence.ErrInvalidInterval),
+		errors.Is(err, recurrence.ErrInvalidMonthDay),
+		errors.Is(err, recurrence.ErrInvalidBusinessAdjust),
+		errors.Is(err, recurrence.ErrStartDateRequired),
+		errors.Is(err, recurrence.ErrInvalidEndDate),
+		errors.Is(err, recurrence.ErrInvalidRange):
+		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: err.Error()})
+	}
+	return cashFlowError(c, err, fallback)
+}
+
+func toRecurrenceRule(id int32, req dto.RecurrenceRuleRequest) (recurrence.Rule, error) {
+	rule := recurrence.Rule{
+		ID:                id,
+		Title:             req.Title,
+		CategoryID:        req.CategoryID,
+		Direction:         req.Direction,
+		Amount:            req.Amount,
+		IsFixed:           true,
+		PaymentMethodID:   req.PaymentMethodID,
+		Frequency:         req.Frequency,
+		Interval:          req.Interval,
+		ByMonthDay:        req.ByMonthDay,
+		BusinessDayAdjust: req.BusinessDayAdjust,
+		IsActive:          true,
+	}
+	if req.IsFixed != nil {
+		rule.IsFixed = *req.IsFixed
+	}
+	if req.IsActive != nil {
+		rule.IsActive = *req.IsActive
+	}
+
+	if req.StartDate != "" {
+		start, err := time.Parse("2006-01-02", req.StartDate)
+		if err != nil {
+			return rule, errors.New("invalid start_date format, use YYYY-MM-DD")
+		}
+		rule.StartDate = start
+	}
+	if req.EndDate != "" {
+		end, err := time.Parse("2006-01-02", req.EndDate)
+		if err != nil {
+			return rule, errors.New("invalid end_date format, use YYYY-MM-DD")
+		}
+		rule.EndDate = &end
+	}
+	return rule, nil
+}
+
+func toRecurrenceRuleResponse(r *recurrence.Rule) dto.RecurrenceRuleResponse {
+	resp := dto.RecurrenceRuleResponse{
+		ID:                r.ID,
+		Title:             r.Title,
+		CategoryID:        r.CategoryID,
+		Direction:         r.Direction,
+		Amount:            r.Amount,
+		IsFixed:           r.IsFixed,
+		PaymentMethodID:   r.PaymentMethodID,
+		Frequency:         r.Frequency,
+		Interval:          r.Interval,
+		ByMonthDay:        r.ByMonthDay,
+		BusinessDayAdjust: r.BusinessDayAdjust,
+		StartDate:         r.StartDate.Format("2006-01-02"),
+		IsActive:          r.IsActive,
+	}
+	if r.EndDate != nil {
+		resp.EndDate = r.EndDate.Format("2006-01-02")
+	}
+	return resp
+}
+
+func toRecurrenceOccurrenceResponse(o recurrence.Occurrence) dto.RecurrenceOccurrenceResponse {
+	return dto.RecurrenceOccurrenceResponse{
+		RuleID:     o.RuleID,
+		Date:       o.Date.Format("2006-01-02"),
+		CashFlowID: o.CashFlowID,
+	}
+}
@@ -0,0 +1,129 @@
+package http
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"mime"
+	"net/http"
+	"strconv"
+
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/adapters/http/dto"
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/domain/report"
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/money"
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/xlsx"
+	"github.com/labstack/echo/v4"
+)
+
+const xlsxContentType = "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+
+var monthNames = [12]string{"Jan", "Fev", "Mar", "Abr", "Mai", "Jun", "Jul", "Ago", "Set", "Out", "Nov", "Dez"}
+
+// annualRow is a row of the exported grid; a nil value is a blank cell.
+type annualRow struct {
+	direction string
+	label     string
+	total     bool
+	values    []*money.Amount
+}
+
+// annualGrid lays the annual report out as the spreadsheet rows: the
+// header, the income categories and their total, the expense ones and
+// theirs, and the net. With budgets, every column is followed by the
+// planned one.
+func annualGrid(r *report.AnnualReport) ([]string, []annualRow) {
+	header := []string{"Direção", "Categoria"}
+	for _, name := range append(monthNames[:], "Total", "Média") {
+		if r.IncludeBudget {
+			header = append(header, name+" realizado", name+" orçado")
+		} else {
+			header = append(header, name)
+		}
+	}
+
+	row := func(direction, label string, total bool, line report.AnnualLine) annualRow {
+		cells := append(line.Months[:], line.Total, line.Average)
+		values := make([]*money.Amount, 0, len(header)-2)
+		for _, cell := range cells {
+			actual := cell.Actual
+			values = append(values, &actual)
+			if r.IncludeBudget {
+				values = append(values, cell.Planned)
+			}
+		}
+		return annualRow{direction: direction, label: label, total: total, values: values}
+	}
+
+	var rows []annualRow
+	for _, section := range []report.AnnualSection{r.Income, r.Expense} {
+		for _, line := range section.Categories {
+			rows = append(rows, row(section.Direction, line.CategoryName, false, line))
+		}
+		rows = append(rows, row(section.Direction, "Total", true, section.Total))
+	}
+	rows = append(rows, row("", "Saldo", true, r.Net))
+	return header, rows
+}
+
+func writeAnnualCSV(c echo.Context, r *report.AnnualReport) error {
+	header, rows := annualGrid(r)
+
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+	_ = w.Write(header)
+	for _, row := range rows {
+		record := []string{row.direction, row.label}
+		for _, v := range row.values {
+			if v == nil {
+				record = append(record, "")
+			} else {
+				record = append(record, v.String())
+			}
+		}
+		_ = w.Write(record)
+	}
+	w.Flush()
+	if err := w.Error(); err != nil {
+		return c.JSON(http.StatusInternalServerError, dto.ErrorResponse{Error: fmt.Sprintf("failed to write csv: %v", err)})
+	}
+
+	setAttachment(c, fmt.Sprintf("relatorio-anual-%d.csv", r.Year))
+	return c.Blob(http.StatusOK, "text/csv; charset=utf-8", buf.Bytes())
+}
+
+func writeAnnualXLSX(c echo.Context, r *report.AnnualReport) error {
+	header, rows := annualGrid(r)
+
+	sheet := make([][]xlsx.Cell, 0, len(rows)+1)
+	titles := make([]xlsx.Cell, len(header))
+	for i, h := range header {
+		titles[i] = xlsx.Bold(h)
+	}
+	sheet = append(sheet, titles)
+	for _, row := range rows {
+		cells := []xlsx.Cell{xlsx.Text(row.direction), xlsx.Text(row.label)}
+		if row.total {
+			cells[1] = xlsx.Bold(row.label)
+		}
+		for _, v := range row.values {
+			if v == nil {
+				cells = append(cells, xlsx.Cell{})
+			} else {
+				cells = append(cells, xlsx.Number(v.Float64()))
+			}
+		}
+		sheet = append(sheet, cells)
+	}
+
+	var buf bytes.Buffer
+	if err := xlsx.Write(&buf, strconv.Itoa(r.Year), sheet); err != nil {
+		return c.JSON(http.StatusInternalServerError, dto.ErrorResponse{Error: fmt.Sprintf("failed to write xlsx: %v", err)})
+	}
+
+	setAttachment(c, fmt.Sprintf("relatorio-anual-%d.xlsx", r.Year))
+	return c.Blob(http.StatusOK, xlsxContentType, buf.Bytes())
+}
+
+func setAttachment(c echo.Context, filename string) {
+	c.Response().Header().Set(echo.HeaderContentDisposition, mime.FormatMediaType("attachment", map[string]string{"filename": filename}))
+}
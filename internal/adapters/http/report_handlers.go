@@ -0,0 +1,442 @@
+package http
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/adapters/http/dto"
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/domain/category"
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/domain/report"
+	"github.com/labstack/echo/v4"
+)
+
+type ReportHandler struct {
+	service report.Service
+}
+
+func NewReportHandler(service report.Service) *ReportHandler {
+	return &ReportHandler{service: service}
+}
+
+// GetCumulative returns the month-by-month running balance.
+// @Summary Fluxo de Caixa Acumulado
+// @Description Returns, for each month in the range, the income, expense and running balance since the first cash flow, split between own money and picuinhas (flows linked to a picuinha entry or to an installment plan of a person). Transfers are left out. Defaults to the last 12 months; planned installments are added only when include_installments is set.
+// @Tags Reports
+// @Accept json
+// @Produce json
+// @Param from query string false "First month (YYYY-MM-DD)"
+// @Param to query string false "Last month (YYYY-MM-DD)"
+// @Param include_installments query bool false "Also count planned installments" default(false)
+// @Success 200 {object} dto.CumulativeResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Router /reports/cumulative [get]
+func (h *ReportHandler) GetCumulative(c echo.Context) error {
+	from, to, err := parseDateRange(c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: err.Error()})
+	}
+
+	filter := report.CumulativeFilter{From: from, To: to}
+	if v := c.QueryParam("include_installments"); v != "" {
+		include, err := strconv.ParseBool(v)
+		if err != nil {
+			return c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: "invalid include_installments"})
+		}
+		filter.IncludeInstallments = include
+	}
+
+	result, err := h.service.GetCumulative(c.Request().Context(), filter)
+	if err != nil {
+		return reportError(c, err, "failed to build cumulative cash flow")
+	}
+
+	return c.JSON(http.StatusOK, toCumulativeResponse(result))
+}
+
+// GetCategoryHistory returns a category month by month.
+// @Summary Histórico da Categoria
+// @Description Returns, for each month in the range, the category's realized total, its planned budget (null without one), the 3- and 6-month moving averages and the change over the previous month, plus the slope of the linear trend over the range. Defaults to the last 12 months.
+// @Tags Reports
+// @Accept json
+// @Produce json
+// @Param id path int true "Category ID"
+// @Param from query string false "First month (YYYY-MM-DD)"
+// @Param to query string false "Last month (YYYY-MM-DD)"
+// @Success 200 {object} dto.CategoryHistoryResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Router /reports/categories/{id}/history [get]
+func (h *ReportHandler) GetCategoryHistory(c echo.Context) error {
+	idStr := c.Param("id")
+	var id int32
+	if _, err := fmt.Sscanf(idStr, "%d", &id); err != nil {
+		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: "invalid id format"})
+	}
+	from, to, err := parseDateRange(c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: err.Error()})
+	}
+
+	history, err := h.service.GetCategoryHistory(c.Request().Context(), id, from, to)
+	if err != nil {
+		return reportError(c, err, "failed to build category history")
+	}
+
+	return c.JSON(http.StatusOK, toCategoryHistoryResponse(history))
+}
+
+// GetFastestGrowing ranks the expense categories by trend.
+// @Summary Despesas que Mais Crescem
+// @Description Ranks the expense categories whose monthly spending rises over the range by the slope of their linear trend, steepest first, with their total, monthly average and budget. Categories with a flat or falling trend are left out. Defaults to the last 12 months.
+// @Tags Reports
+// @Accept json
+// @Produce json
+// @Param from query string false "First month (YYYY-MM-DD)"
+// @Param to query string false "Last month (YYYY-MM-DD)"
+// @Param limit query int false "Max categories (1-50)" default(10)
+// @Success 200 {object} dto.GrowthResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Router /reports/categories/growth [get]
+func (h *ReportHandler) GetFastestGrowing(c echo.Context) error {
+	from, to, err := parseDateRange(c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: err.Error()})
+	}
+
+	filter := report.GrowthFilter{From: from, To: to}
+	if v := c.QueryParam("limit"); v != "" {
+		limit, err := strconv.Atoi(v)
+		if err != nil {
+			return c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: "invalid limit"})
+		}
+		if limit < 1 {
+			return c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: report.ErrInvalidLimit.Error()})
+		}
+		filter.Limit = limit
+	}
+
+	growth, err := h.service.GetFastestGrowing(c.Request().Context(), filter)
+	if err != nil {
+		return reportError(c, err, "failed to rank categories")
+	}
+
+	return c.JSON(http.StatusOK, toGrowthResponse(growth))
+}
+
+// GetForecast projects the next months.
+// @Summary Previsão de Fluxo de Caixa
+// @Description Projects income, expense and ending balance for each of the next months, starting from the balance expected at the end of the current one. Combines planned flows (including future installments), the fixed flows of the latest month that had any, recurrence rules not generated yet, unpaid picuinha installments (money expected back) and the average variable spending per category over the last 6 months. Bands reflect the variability of that spending; their low end also assumes no picuinha money comes back.
+// @Tags Reports
+// @Accept json
+// @Produce json
+// @Param months query int false "Months to project (1-36)" default(12)
+// @Success 200 {object} dto.ForecastResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Router /reports/forecast [get]
+func (h *ReportHandler) GetForecast(c echo.Context) error {
+	var months int
+	if v := c.QueryParam("months"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 1 {
+			return c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: report.ErrInvalidMonths.Error()})
+		}
+		months = n
+	}
+
+	forecast, err := h.service.GetForecast(c.Request().Context(), months)
+	if err != nil {
+		return reportError(c, err, "failed to build forecast")
+	}
+
+	return c.JSON(http.StatusOK, toForecastResponse(forecast))
+}
+
+// GetAnnual returns the year as a grid of categories by month.
+// @Summary Relatório Anual
+// @Description Returns the realized amount of each category in each month of the year, grouped in income and expense, with the yearly total and monthly average of each row, section totals and the net of the year. Transfers are left out. With include_budget the budgeted amount goes alongside each cell. Appending .csv or .xlsx to the year downloads the grid as a spreadsheet instead of JSON.
+// @Tags Reports
+// @Accept json
+// @Produce json,text/csv,application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
+// @Param year path string true "Year, optionally with .csv or .xlsx (e.g. 2024.xlsx)"
+// @Param include_budget query bool false "Add the budgeted amounts" default(false)
+// @Success 200 {object} dto.AnnualReportResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Router /reports/annual/{year} [get]
+func (h *ReportHandler) GetAnnual(c echo.Context) error {
+	yearStr, format, _ := strings.Cut(c.Param("year"), ".")
+	year, err := strconv.Atoi(yearStr)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: "invalid year"})
+	}
+	if format != "" && format != "csv" && format != "xlsx" {
+		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: "format must be csv or xlsx"})
+	}
+	var includeBudget bool
+	if v := c.QueryParam("include_budget"); v != "" {
+		includeBudget, err = strconv.ParseBool(v)
+		if err != nil {
+			return c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: "invalid include_budget"})
+		}
+	}
+
+	annual, err := h.service.GetAnnualReport(c.Request().Context(), year, includeBudget)
+	if err != nil {
+		return reportError(c, err, "failed to build annual report")
+	}
+
+	switch format {
+	case "csv":
+		return writeAnnualCSV(c, annual)
+	case "xlsx":
+		return writeAnnualXLSX(c, annual)
+	}
+	return c.JSON(http.StatusOK, toAnnualReportResponse(annual))
+}
+
+// GetReinvestment returns the reinvestment gap month by month.
+// @Summary Reinvestimento
+// @Description Compares, for each month in the range, the realized inflows of categories with the INVESTMENT role (redemptions and yields) with the contributions to them (their outflows). The gap is inflow minus contributions: positive is money still to be reinvested. The cumulative gap runs since the first investment flow; opening_gap is its value before the range. Defaults to the last 12 months.
+// @Tags Reports
+// @Accept json
+// @Produce json
+// @Param from query string false "First month (YYYY-MM-DD)"
+// @Param to query string false "Last month (YYYY-MM-DD)"
+// @Success 200 {object} dto.ReinvestmentResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Router /reports/reinvestment [get]
+func (h *ReportHandler) GetReinvestment(c echo.Context) error {
+	from, to, err := parseDateRange(c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: err.Error()})
+	}
+
+	reinvestment, err := h.service.GetReinvestment(c.Request().Context(), from, to)
+	if err != nil {
+		return reportError(c, err, "failed to build reinvestment")
+	}
+
+	return c.JSON(http.StatusOK, toReinvestmentResponse(reinvestment))
+}
+
+func RegisterReportRoutes(e *echo.Echo, h *ReportHandler) {
+	g := e.Group("/reports")
+	g.GET("/cumulative", h.GetCumulative)
+	g.GET("/forecast", h.GetForecast)
+	g.GET("/annual/:year", h.GetAnnual)
+	g.GET("/reinvestment", h.GetReinvestment)
+	g.GET("/categories/growth", h.GetFastestGrowing)
+	g.GET("/categories/:id/history", h.GetCategoryHistory)
+}
+
+func reportError(c echo.Context, err error, fallback string) error {
+	switch {
+	case errors.Is(err, category.ErrCategoryNotFound):
+		return c.JSON(http.StatusNotFound, dto.ErrorResponse{Error: err.Error()})
+	case errors.Is(err, report.ErrInvalidRange),
+		errors.Is(err, report.ErrRangeTooLong),
+		errors.Is(err, report.ErrInvalidLimit),
+		errors.Is(err, report.ErrInvalidMonths),
+		errors.Is(err, report.ErrInvalidYear):
+		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: err.Error()})
+	}
+	return c.JSON(http.StatusInternalServerError, dto.ErrorResponse{Error: fmt.Sprintf("%s: %v", fallback, err)})
+}
+
+func toReportTotalsResponse(t report.Totals) dto.ReportTotalsResponse {
+	return dto.ReportTotalsResponse{
+		Income:  t.Income,
+		Expense: t.Expense,
+		Net:     t.Net,
+		Balance: t.Balance,
+	}
+}
+
+func toCumulativeResponse(r *report.Cumulative) dto.CumulativeResponse {
+	months := make([]dto.CumulativeMonthResponse, len(r.Months))
+	for i, m := range r.Months {
+		months[i] = dto.CumulativeMonthResponse{
+			Month:               m.Month.Format("2006-01-02"),
+			Income:              m.Total.Income,
+			Expense:             m.Total.Expense,
+			Net:                 m.Total.Net,
+			Balance:             m.Total.Balance,
+			Own:                 toReportTotalsResponse(m.Own),
+			Picuinha:            toReportTotalsResponse(m.Picuinha),
+			PlannedInstallments: m.PlannedInstallments,
+		}
+	}
+	return dto.CumulativeResponse{
+		From:                r.From.Format("2006-01-02"),
+		To:                  r.To.Format("2006-01-02"),
+		IncludeInstallments: r.IncludeInstallments,
+		OpeningBalance:      r.OpeningBalance,
+		Months:              months,
+	}
+}
+
+func toCategoryHistoryResponse(h *report.CategoryHistory) dto.CategoryHistoryResponse {
+	months := make([]dto.CategoryMonthResponse, len(h.Months))
+	for i, m := range h.Months {
+		months[i] = dto.CategoryMonthResponse{
+			Month:          m.Month.Format("2006-01-02"),
+			Total:          m.Total,
+			Planned:        m.Planned,
+			MovingAverage3: m.MovingAverage3,
+			MovingAverage6: m.MovingAverage6,
+			ChangePct:      roundPercent(m.Change),
+		}
+	}
+	return dto.CategoryHistoryResponse{
+		CategoryID:   h.CategoryID,
+		CategoryName: h.CategoryName,
+		Direction:    h.Direction,
+		From:         h.From.Format("2006-01-02"),
+		To:           h.To.Format("2006-01-02"),
+		TrendSlope:   math.Round(h.TrendSlope*100) / 100,
+		Months:       months,
+	}
+}
+
+func toGrowthResponse(g *report.Growth) dto.GrowthResponse {
+	categories := make([]dto.CategoryGrowthResponse, len(g.Categories))
+	for i, c := range g.Categories {
+		categories[i] = dto.CategoryGrowthResponse{
+			CategoryID:     c.CategoryID,
+			CategoryName:   c.CategoryName,
+			Total:          c.Total,
+			MonthlyAverage: c.MonthlyAverage,
+			TrendSlope:     math.Round(c.TrendSlope*100) / 100,
+			GrowthPct:      math.Round(c.GrowthPercent*100) / 100,
+			Planned:        c.Planned,
+		}
+	}
+	return dto.GrowthResponse{
+		From:       g.From.Format("2006-01-02"),
+		To:         g.To.Format("2006-01-02"),
+		Categories: categories,
+	}
+}
+
+func toForecastResponse(f *report.Forecast) dto.ForecastResponse {
+	variable := make([]dto.VariableSpendingResponse, len(f.Variable))
+	for i, v := range f.Variable {
+		variable[i] = dto.VariableSpendingResponse{
+			CategoryID:   v.CategoryID,
+			CategoryName: v.CategoryName,
+			Average:      v.Average,
+			StdDev:       math.Round(v.StdDev*100) / 100,
+		}
+	}
+	months := make([]dto.ForecastMonthResponse, len(f.Months))
+	for i, m := range f.Months {
+		src := m.Sources
+		months[i] = dto.ForecastMonthResponse{
+			Month:       m.Month.Format("2006-01-02"),
+			Income:      m.Income,
+			Expense:     m.Expense,
+			Net:         m.Net,
+			Balance:     m.Balance,
+			IncomeBand:  dto.BandResponse{Low: m.IncomeBand.Low, High: m.IncomeBand.High},
+			ExpenseBand: dto.BandResponse{Low: m.ExpenseBand.Low, High: m.ExpenseBand.High},
+			BalanceBand: dto.BandResponse{Low: m.BalanceBand.Low, High: m.BalanceBand.High},
+			Sources: dto.ForecastSourcesResponse{
+				Installments:     src.Installments,
+				PlannedIncome:    src.PlannedIncome,
+				PlannedExpense:   src.PlannedExpense,
+				FixedIncome:      src.FixedIncome,
+				FixedExpense:     src.FixedExpense,
+				RecurringIncome:  src.RecurringIncome,
+				RecurringExpense: src.RecurringExpense,
+				VariableExpense:  src.VariableExpense,
+				Receivables:      src.Receivables,
+			},
+		}
+	}
+	resp := dto.ForecastResponse{
+		From:           f.From.Format("2006-01-02"),
+		To:             f.To.Format("2006-01-02"),
+		OpeningBalance: f.OpeningBalance,
+		HistoryFrom:    f.HistoryFrom.Format("2006-01-02"),
+		HistoryTo:      f.HistoryTo.Format("2006-01-02"),
+		Variable:       variable,
+		Months:         months,
+	}
+	if f.Lowest != nil {
+		resp.LowestMonth = f.Lowest.Month.Format("2006-01-02")
+		resp.LowestBalance = f.Lowest.BalanceBand.Low
+	}
+	return resp
+}
+
+func toAnnualReportResponse(r *report.AnnualReport) dto.AnnualReportResponse {
+	return dto.AnnualReportResponse{
+		Year:          r.Year,
+		IncludeBudget: r.IncludeBudget,
+		AverageMonths: r.AverageMonths,
+		Income:        toAnnualSectionResponse(r.Income),
+		Expense:       toAnnualSectionResponse(r.Expense),
+		Net:           toAnnualLineResponse(r.Net),
+	}
+}
+
+func toAnnualSectionResponse(s report.AnnualSection) dto.AnnualSectionResponse {
+	categories := make([]dto.AnnualLineResponse, len(s.Categories))
+	for i, line := range s.Categories {
+		categories[i] = toAnnualLineResponse(line)
+	}
+	return dto.AnnualSectionResponse{
+		Direction:  s.Direction,
+		Categories: categories,
+		Total:      toAnnualLineResponse(s.Total),
+	}
+}
+
+func toAnnualLineResponse(l report.AnnualLine) dto.AnnualLineResponse {
+	months := make([]dto.AnnualCellResponse, len(l.Months))
+	for i, cell := range l.Months {
+		months[i] = dto.AnnualCellResponse(cell)
+	}
+	return dto.AnnualLineResponse{
+		CategoryID:   l.CategoryID,
+		CategoryName: l.CategoryName,
+		Months:       months,
+		Total:        dto.AnnualCellResponse(l.Total),
+		Average:      dto.AnnualCellResponse(l.Average),
+	}
+}
+
+func toReinvestmentResponse(r *report.Reinvestment) dto.ReinvestmentResponse {
+	months := make([]dto.ReinvestmentMonthResponse, len(r.Months))
+	for i, m := range r.Months {
+		months[i] = dto.ReinvestmentMonthResponse{
+			Month:         m.Month.Format("2006-01-02"),
+			Inflow:        m.Inflow,
+			Contributions: m.Contributions,
+			Gap:           m.Gap,
+			CumulativeGap: m.CumulativeGap,
+		}
+	}
+	return dto.ReinvestmentResponse{
+		From:          r.From.Format("2006-01-02"),
+		To:            r.To.Format("2006-01-02"),
+		OpeningGap:    r.OpeningGap,
+		Inflow:        r.Inflow,
+		Contributions: r.Contributions,
+		Gap:           r.Gap,
+		ClosingGap:    r.ClosingGap,
+		Months:        months,
+	}
+}
+
+// roundPercent rounds a percentage to 2 decimals, keeping nil.
+func roundPercent(pct *float64) *float64 {
+	if pct == nil {
+		return nil
+	}
+	r := math.Round(*pct*100) / 100
+	return &r
+}
@@ -0,0 +1,356 @@
+package http
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/domain/cashflow"
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/domain/category"
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/domain/report"
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/money"
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/pdf"
+)
+
+const (
+	pdfMargin = 40.0
+	pdfWidth  = pdf.PageWidth - 2*pdfMargin
+	pdfLine   = 14.0
+	pdfSize   = 9.0
+)
+
+var (
+	pdfGray   = pdf.Color{R: 110, G: 110, B: 110}
+	pdfLight  = pdf.Color{R: 225, G: 225, B: 225}
+	pdfGreen  = pdf.Color{R: 46, G: 125, B: 50}
+	pdfRed    = pdf.Color{R: 198, G: 40, B: 40}
+	pdfBlue   = pdf.Color{R: 21, G: 101, B: 192}
+	pdfOrange = pdf.Color{R: 239, G: 108, B: 0}
+)
+
+var monthLongNames = [12]string{"janeiro", "fevereiro", "março", "abril", "maio", "junho", "julho", "agosto", "setembro", "outubro", "novembro", "dezembro"}
+
+var statusNames = map[string]string{
+	cashflow.StatusRealized:  "Realizado",
+	cashflow.StatusPlanned:   "Previsto",
+	cashflow.StatusCancelled: "Cancelado",
+}
+
+// pdfColumn is a column of a table; x is where it starts and right aligns
+// its values to x + width.
+type pdfColumn struct {
+	title string
+	x     float64
+	width float64
+	right bool
+}
+
+// statementPDF lays the statement out top to bottom, breaking pages when
+// the next block does not fit.
+type statementPDF struct {
+	doc   *pdf.Document
+	title string
+	y     float64
+}
+
+func renderStatementPDF(s *report.MonthlyStatement) ([]byte, error) {
+	w := &statementPDF{
+		doc:   pdf.New(),
+		title: fmt.Sprintf("Extrato de %s de %d", monthLongNames[s.Month.Month()-1], s.Month.Year()),
+	}
+	w.page()
+	w.summary(s)
+	w.categories(s)
+	w.budget(s)
+	w.invoices(s)
+	w.picuinhas(s)
+	w.cashFlows(s)
+
+	var buf bytes.Buffer
+	if err := w.doc.Write(&buf); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func (w *statementPDF) page() {
+	w.doc.AddPage()
+	w.doc.Text(pdfMargin, pdfMargin+14, pdf.Bold, 16, pdf.Black, w.title)
+	footer := fmt.Sprintf("Página %d", w.doc.Pages())
+	w.doc.Text(pdf.PageWidth-pdfMargin-pdf.Width(pdf.Regular, 8, footer), pdf.PageHeight-pdfMargin/2, pdf.Regular, 8, pdfGray, footer)
+	w.doc.Line(pdfMargin, pdfMargin+22, pdf.PageWidth-pdfMargin, pdfMargin+22, 1, pdf.Black)
+	w.y = pdfMargin + 44
+}
+
+// need starts a new page unless h more points fit on this one.
+func (w *statementPDF) need(h float64) {
+	if w.y+h > pdf.PageHeight-pdfMargin {
+		w.page()
+	}
+}
+
+func (w *statementPDF) heading(title string) {
+	w.need(3 * pdfLine)
+	w.y += pdfLine / 2
+	w.doc.Text(pdfMargin, w.y, pdf.Bold, 12, pdf.Black, title)
+	w.y += pdfLine
+}
+
+func (w *statementPDF) note(text string) {
+	w.need(pdfLine)
+	w.doc.Text(pdfMargin, w.y, pdf.Regular, pdfSize, pdfGray, text)
+	w.y += pdfLine
+}
+
+func (w *statementPDF) header(cols []pdfColumn) {
+	w.need(2 * pdfLine)
+	values := make([]string, len(cols))
+	for i, c := range cols {
+		values[i] = c.title
+	}
+	w.cells(cols, values, pdf.Bold, pdf.Black)
+	w.doc.Line(pdfMargin, w.y-pdfLine+3, pdf.PageWidth-pdfMargin, w.y-pdfLine+3, 0.5, pdfGray)
+	w.y += 2
+}
+
+// row writes a table row, repeating the header on a new page.
+func (w *statementPDF) row(cols []pdfColumn, values []string, font pdf.Font, color pdf.Color) {
+	if w.y+pdfLine > pdf.PageHeight-pdfMargin {
+		w.page()
+		w.header(cols)
+	}
+	w.cells(cols, values, font, color)
+}
+
+func (w *statementPDF) cells(cols []pdfColumn, values []string, font pdf.Font, color pdf.Color) {
+	for i, c := range cols {
+		text := pdf.Fit(font, pdfSize, c.width, values[i])
+		x := c.x
+		if c.right {
+			x += c.width - pdf.Width(font, pdfSize, text)
+		}
+		w.doc.Text(x, w.y, font, pdfSize, color, text)
+	}
+	w.y += pdfLine
+}
+
+func (w *statementPDF) summary(s *report.MonthlyStatement) {
+	w.heading("Resumo")
+	w.need(50)
+	boxes := []struct {
+		label  string
+		amount money.Amount
+		color  pdf.Color
+	}{
+		{"Entradas", s.Summary.TotalIncome, pdfGreen},
+		{"Saídas", s.Summary.TotalExpense, pdfRed},
+		{"Saldo", s.Summary.Balance, pdfBlue},
+	}
+	width := (pdfWidth - 20) / 3
+	for i, b := range boxes {
+		x := pdfMargin + float64(i)*(width+10)
+		w.doc.Rect(x, w.y, width, 40, pdfLight)
+		w.doc.Rect(x, w.y, 4, 40, b.color)
+		w.doc.Text(x+12, w.y+15, pdf.Regular, pdfSize, pdfGray, b.label)
+		w.doc.Text(x+12, w.y+32, pdf.Bold, 13, b.color, formatBRL(b.amount))
+	}
+	w.y += 50
+	w.note("Somente lançamentos realizados; transferências entre contas não entram.")
+}
+
+func (w *statementPDF) categories(s *report.MonthlyStatement) {
+	w.heading("Por categoria")
+	if len(s.Categories) == 0 {
+		w.note("Nenhum lançamento realizado no mês.")
+		return
+	}
+	for _, direction := range []string{category.DirectionIn, category.DirectionOut} {
+		var items []cashflow.CategorySummary
+		var total, largest money.Amount
+		for _, c := range s.Categories {
+			if c.Direction != direction {
+				continue
+			}
+			items = append(items, c)
+			total = total.Add(c.TotalAmount)
+			if c.TotalAmount.Cmp(largest) > 0 {
+				largest = c.TotalAmount
+			}
+		}
+		if len(items) == 0 {
+			continue
+		}
+
+		label, color := "Entradas", pdfGreen
+		if direction == category.DirectionOut {
+			label, color = "Saídas", pdfRed
+		}
+		w.need(2 * pdfLine)
+		w.doc.Text(pdfMargin, w.y, pdf.Bold, 10, color, label)
+		w.y += pdfLine
+
+		barX, barWidth := pdfMargin+150, pdfWidth-150-130
+		for _, c := range items {
+			w.need(pdfLine)
+			w.doc.Text(pdfMargin, w.y, pdf.Regular, pdfSize, pdf.Black, pdf.Fit(pdf.Regular, pdfSize, 140, c.CategoryName))
+			if largest.IsPositive() && c.TotalAmount.IsPositive() {
+				length := barWidth * c.TotalAmount.Float64() / largest.Float64()
+				w.doc.Rect(barX, w.y-8, length, 9, color)
+			}
+			amount := formatBRL(c.TotalAmount)
+			w.doc.Text(pdf.PageWidth-pdfMargin-50-pdf.Width(pdf.Regular, pdfSize, amount), w.y, pdf.Regular, pdfSize, pdf.Black, amount)
+			pct := share(c.TotalAmount, total)
+			w.doc.Text(pdf.PageWidth-pdfMargin-pdf.Width(pdf.Regular, pdfSize, pct), w.y, pdf.Regular, pdfSize, pdfGray, pct)
+			w.y += pdfLine
+		}
+		w.y += pdfLine / 2
+	}
+}
+
+func (w *statementPDF) budget(s *report.MonthlyStatement) {
+	w.heading("Orçado x realizado")
+	if s.Budget == nil || len(s.Budget.Items) == 0 {
+		w.note("Nenhum orçamento para o mês.")
+		return
+	}
+	cols := []pdfColumn{
+		{title: "Categoria", x: pdfMargin, width: 140},
+		{title: "Orçado", x: pdfMargin + 145, width: 75, right: true},
+		{title: "Realizado", x: pdfMargin + 225, width: 75, right: true},
+		{title: "Restante", x: pdfMargin + 305, width: 75, right: true},
+	}
+	barX, barWidth := pdfMargin+395, pdfWidth-395
+	w.header(cols)
+	var planned, actual money.Amount
+	for _, item := range s.Budget.Items {
+		planned = planned.Add(item.PlannedAmount)
+		actual = actual.Add(item.ActualAmount)
+		left := item.PlannedAmount.Sub(item.ActualAmount)
+		color := pdf.Black
+		if left.IsNegative() {
+			color = pdfRed
+		}
+		w.row(cols, []string{item.CategoryName, formatBRL(item.PlannedAmount), formatBRL(item.ActualAmount), formatBRL(left)}, pdf.Regular, color)
+
+		// The bar fills up to the budget and turns red past it.
+		w.doc.Rect(barX, w.y-pdfLine-8, barWidth, 9, pdfLight)
+		if item.PlannedAmount.IsPositive() {
+			used := item.ActualAmount.Float64() / item.PlannedAmount.Float64()
+			fill := pdfGreen
+			switch {
+			case used > 1:
+				used, fill = 1, pdfRed
+			case used > 0.8:
+				fill = pdfOrange
+			}
+			if used > 0 {
+				w.doc.Rect(barX, w.y-pdfLine-8, barWidth*used, 9, fill)
+			}
+		}
+	}
+	w.row(cols, []string{"Total", formatBRL(planned), formatBRL(actual), formatBRL(planned.Sub(actual))}, pdf.Bold, pdf.Black)
+}
+
+func (w *statementPDF) invoices(s *report.MonthlyStatement) {
+	w.heading("Faturas de cartão")
+	if len(s.Invoices) == 0 {
+		w.note("Nenhuma fatura com lançamentos no mês.")
+		return
+	}
+	cols := []pdfColumn{
+		{title: "Cartão", x: pdfMargin, width: 180},
+		{title: "Vencimento", x: pdfMargin + 185, width: 70},
+		{title: "Lançamentos", x: pdfMargin + 260, width: 70, right: true},
+		{title: "IOF", x: pdfMargin + 335, width: 80, right: true},
+		{title: "Total", x: pdfMargin + 420, width: pdfWidth - 420, right: true},
+	}
+	w.header(cols)
+	var total money.Amount
+	for _, inv := range s.Invoices {
+		due := "-"
+		if inv.DueDay != nil {
+			due = fmt.Sprintf("%02d/%02d/%d", *inv.DueDay, s.Month.Month(), s.Month.Year())
+		}
+		total = total.Add(inv.Invoice.Total)
+		w.row(cols, []string{inv.CardName, due, fmt.Sprint(len(inv.Invoice.Entries)), formatBRL(inv.Invoice.TotalIOF), formatBRL(inv.Invoice.Total)}, pdf.Regular, pdf.Black)
+	}
+	w.row(cols, []string{"Total", "", "", "", formatBRL(total)}, pdf.Bold, pdf.Black)
+}
+
+func (w *statementPDF) picuinhas(s *report.MonthlyStatement) {
+	w.heading("Picuinhas em aberto")
+	if len(s.Picuinhas) == 0 {
+		w.note("Ninguém com saldo em aberto.")
+		return
+	}
+	cols := []pdfColumn{
+		{title: "Pessoa", x: pdfMargin, width: 250},
+		{title: "Situação", x: pdfMargin + 255, width: 120},
+		{title: "Valor", x: pdfMargin + 380, width: pdfWidth - 380, right: true},
+	}
+	w.header(cols)
+	for _, p := range s.Picuinhas {
+		situation, color := "A receber", pdfGreen
+		if p.Balance.IsNegative() {
+			situation, color = "A pagar", pdfRed
+		}
+		w.row(cols, []string{p.Name, situation, formatBRL(p.Balance.Abs())}, pdf.Regular, color)
+	}
+}
+
+func (w *statementPDF) cashFlows(s *report.MonthlyStatement) {
+	w.heading("Lançamentos")
+	if len(s.CashFlows) == 0 {
+		w.note("Nenhum lançamento no mês.")
+		return
+	}
+	cols := []pdfColumn{
+		{title: "Data", x: pdfMargin, width: 50},
+		{title: "Descrição", x: pdfMargin + 55, width: 190},
+		{title: "Categoria", x: pdfMargin + 250, width: 110},
+		{title: "Status", x: pdfMargin + 365, width: 60},
+		{title: "Valor", x: pdfMargin + 430, width: pdfWidth - 430, right: true},
+	}
+	w.header(cols)
+	for _, cf := range s.CashFlows {
+		amount, color := cf.Amount, pdf.Black
+		if cf.Direction == category.DirectionOut {
+			amount, color = amount.Neg(), pdfRed
+		}
+		if cf.Status == cashflow.StatusCancelled {
+			color = pdfGray
+		}
+		status := statusNames[cf.Status]
+		if status == "" {
+			status = cf.Status
+		}
+		w.row(cols, []string{cf.Date.Format("02/01"), cf.Title, cf.CategoryName, status, formatBRL(amount)}, pdf.Regular, color)
+	}
+	w.note(fmt.Sprintf("%d lançamentos. Gerado em %s.", len(s.CashFlows), time.Now().Format("02/01/2006 15:04")))
+}
+
+// formatBRL writes an amount the Brazilian way: R$ 1.234,56.
+func formatBRL(a money.Amount) string {
+	s := a.Abs().String()
+	units, cents, _ := strings.Cut(s, ".")
+	var b strings.Builder
+	for i, d := range units {
+		if i > 0 && (len(units)-i)%3 == 0 {
+			b.WriteByte('.')
+		}
+		b.WriteRune(d)
+	}
+	sign := ""
+	if a.IsNegative() {
+		sign = "-"
+	}
+	return fmt.Sprintf("%sR$ %s,%s", sign, b.String(), cents)
+}
+
+func share(part, total money.Amount) string {
+	if total.IsZero() {
+		return ""
+	}
+	return strings.Replace(fmt.Sprintf("%.1f%%", part.Float64()/total.Float64()*100), ".", ",", 1)
+}
@@ -0,0 +1,323 @@
+package http
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/adapters/http/dto"
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/domain/category"
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/domain/payee"
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/domain/payment"
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/domain/rule"
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/domain/tag"
+	"github.com/labstack/echo/v4"
+)
+
+type RuleHandler struct {
+	service rule.Service
+}
+
+func NewRuleHandler(service rule.Service) *RuleHandler {
+	return &RuleHandler{service: service}
+}
+
+// Create registers a categorization rule.
+// @Summary Criar Regra de Categorização
+// @Description Creates a rule that sets the category, is_fixed, payee or tags of new cash flows matching its conditions. A rule setting a category only matches flows in the category direction.
+// @Tags Rules
+// @Accept json
+// @Produce json
+// @Param payload body dto.RuleRequest true "Rule Payload"
+// @Success 201 {object} dto.RuleResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Router /rules [post]
+func (h *RuleHandler) Create(c echo.Context) error {
+	var req dto.RuleRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: "invalid payload"})
+	}
+
+	created, err := h.service.CreateRule(c.Request().Context(), toRule(0, req))
+	if err != nil {
+		return ruleError(c, err, "failed to create rule")
+	}
+
+	return c.JSON(http.StatusCreated, toRuleResponse(created))
+}
+
+// List returns all categorization rules.
+// @Summary Listar Regras de Categorização
+// @Description Returns all rules, active and inactive, in the order they run.
+// @Tags Rules
+// @Accept json
+// @Produce json
+// @Success 200 {array} dto.RuleResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /rules [get]
+func (h *RuleHandler) List(c echo.Context) error {
+	rules, err := h.service.ListRules(c.Request().Context())
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, dto.ErrorResponse{Error: "failed to list rules"})
+	}
+
+	resp := make([]dto.RuleResponse, len(rules))
+	for i := range rules {
+		resp[i] = toRuleResponse(&rules[i])
+	}
+	return c.JSON(http.StatusOK, resp)
+}
+
+// Get returns a categorization rule.
+// @Summary Obter Regra de Categorização
+// @Description Returns a rule by ID.
+// @Tags Rules
+// @Accept json
+// @Produce json
+// @Param id path int true "Rule ID"
+// @Success 200 {object} dto.RuleResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Router /rules/{id} [get]
+func (h *RuleHandler) Get(c echo.Context) error {
+	idStr := c.Param("id")
+	var id int32
+	if _, err := fmt.Sscanf(idStr, "%d", &id); err != nil {
+		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: "invalid id format"})
+	}
+
+	r, err := h.service.GetRule(c.Request().Context(), id)
+	if err != nil {
+		return ruleError(c, err, "failed to get rule")
+	}
+
+	return c.JSON(http.StatusOK, toRuleResponse(r))
+}
+
+// Update changes a categorization rule.
+// @Summary Atualizar Regra de Categorização
+// @Description Replaces a rule. Cash flows it already changed are kept as they are.
+// @Tags Rules
+// @Accept json
+// @Produce json
+// @Param id path int true "Rule ID"
+// @Param payload body dto.RuleRequest true "Rule Payload"
+// @Success 200 {object} dto.RuleResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Router /rules/{id} [put]
+func (h *RuleHandler) Update(c echo.Context) error {
+	idStr := c.Param("id")
+	var id int32
+	if _, err := fmt.Sscanf(idStr, "%d", &id); err != nil {
+		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: "invalid id format"})
+	}
+
+	var req dto.RuleRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: "invalid payload"})
+	}
+
+	updated, err := h.service.UpdateRule(c.Request().Context(), toRule(id, req))
+	if err != nil {
+		return ruleError(c, err, "failed to update rule")
+	}
+
+	return c.JSON(http.StatusOK, toRuleResponse(updated))
+}
+
+// Delete removes a categorization rule.
+// @Summary Excluir Regra de Categorização
+// @Description Deletes a rule. Cash flows it already changed are kept as they are.
+// @Tags Rules
+// @Accept json
+// @Produce json
+// @Param id path int true "Rule ID"
+// @Success 200 {object} map[string]string
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Router /rules/{id} [delete]
+func (h *RuleHandler) Delete(c echo.Context) error {
+	idStr := c.Param("id")
+	var id int32
+	if _, err := fmt.Sscanf(idStr, "%d", &id); err != nil {
+		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: "invalid id format"})
+	}
+
+	if err := h.service.DeleteRule(c.Request().Context(), id); err != nil {
+		return ruleError(c, err, "failed to delete rule")
+	}
+
+	return c.JSON(http.StatusOK, map[string]string{"status": "deleted"})
+}
+
+// Test shows what a rule would change.
+// @Summary Testar Regra de Categorização
+// @Description Lists the existing cash flows an unsaved rule would change, with their values before and after. Nothing is saved. Reconciled, cancelled and transfer cash flows are never changed.
+// @Tags Rules
+// @Accept json
+// @Produce json
+// @Param payload body dto.RuleRequest true "Rule Payload"
+// @Param from query string false "Start date (YYYY-MM-DD)"
+// @Param to query string false "End date (YYYY-MM-DD)"
+// @Success 200 {object} dto.RuleApplyResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Router /rules/test [post]
+func (h *RuleHandler) Test(c echo.Context) error {
+	var req dto.RuleRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: "invalid payload"})
+	}
+	from, to, err := parseDateRange(c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: err.Error()})
+	}
+
+	result, err := h.service.TestRule(c.Request().Context(), toRule(0, req), rule.Scope{From: from, To: to})
+	if err != nil {
+		return ruleError(c, err, "failed to test rule")
+	}
+
+	return c.JSON(http.StatusOK, toRuleApplyResponse(result))
+}
+
+// Apply runs a rule over existing cash flows.
+// @Summary Aplicar Regra Retroativamente
+// @Description Applies a saved rule to the existing cash flows it matches, overriding their category, is_fixed and payee. Category and is_fixed changes are recorded as revisions. Reconciled, cancelled and transfer cash flows are never changed.
+// @Tags Rules
+// @Accept json
+// @Produce json
+// @Param id path int true "Rule ID"
+// @Param from query string false "Start date (YYYY-MM-DD)"
+// @Param to query string false "End date (YYYY-MM-DD)"
+// @Success 200 {object} dto.RuleApplyResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Router /rules/{id}/apply [post]
+func (h *RuleHandler) Apply(c echo.Context) error {
+	idStr := c.Param("id")
+	var id int32
+	if _, err := fmt.Sscanf(idStr, "%d", &id); err != nil {
+		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: "invalid id format"})
+	}
+	from, to, err := parseDateRange(c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: err.Error()})
+	}
+
+	result, err := h.service.ApplyRule(c.Request().Context(), id, rule.Scope{From: from, To: to})
+	if err != nil {
+		return ruleError(c, err, "failed to apply rule")
+	}
+
+	return c.JSON(http.StatusOK, toRuleApplyResponse(result))
+}
+
+func RegisterRuleRoutes(e *echo.Echo, h *RuleHandler) {
+	g := e.Group("/rules")
+	g.POST("", h.Create)
+	g.GET("", h.List)
+	g.POST("/test", h.Test)
+	g.GET("/:id", h.Get)
+	g.PUT("/:id", h.Update)
+	g.DELETE("/:id", h.Delete)
+	g.POST("/:id/apply", h.Apply)
+}
+
+func ruleError(c echo.Context, err error, fallback string) error {
+	switch {
+	case errors.Is(err, rule.ErrRuleNotFound):
+		return c.JSON(http.StatusNotFound, dto.ErrorResponse{Error: err.Error()})
+	case errors.Is(err, rule.ErrNameRequired),
+		errors.Is(err, rule.ErrNameTooLong),
+		errors.Is(err, rule.ErrNoConditions),
+		errors.Is(err, rule.ErrNoActions),
+		errors.Is(err, rule.ErrInvalidPattern),
+		errors.Is(err, rule.ErrInvalidDirection),
+		errors.Is(err, rule.ErrInvalidAmount),
+		errors.Is(err, rule.ErrInvalidAmountRange),
+		errors.Is(err, rule.ErrCategoryDirection),
+		errors.Is(err, rule.ErrTransferCategory),
+		errors.Is(err, rule.ErrInvalidRange),
+		errors.Is(err, category.ErrCategoryNotFound),
+		errors.Is(err, payment.ErrPaymentMethodNotFound),
+		errors.Is(err, payee.ErrPayeeNotFound),
+		errors.Is(err, tag.ErrEmptyName),
+		errors.Is(err, tag.ErrNameTooLong):
+		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: err.Error()})
+	}
+	// Applying a rule fails like the cash flow update it makes.
+	return cashFlowError(c, err, fallback)
+}
+
+func toRule(id int32, req dto.RuleRequest) rule.Rule {
+	r := rule.Rule{
+		ID:              id,
+		Name:            req.Name,
+		Priority:        req.Priority,
+		IsActive:        true,
+		TitlePattern:    req.TitlePattern,
+		Direction:       req.Direction,
+		MinAmount:       req.MinAmount,
+		MaxAmount:       req.MaxAmount,
+		PaymentMethodID: req.PaymentMethodID,
+		PayeeID:         req.PayeeID,
+		SetCategoryID:   req.SetCategoryID,
+		SetIsFixed:      req.SetIsFixed,
+		SetPayeeID:      req.SetPayeeID,
+		AddTags:         req.AddTags,
+	}
+	if req.IsActive != nil {
+		r.IsActive = *req.IsActive
+	}
+	return r
+}
+
+func toRuleResponse(r *rule.Rule) dto.RuleResponse {
+	tags := r.AddTags
+	if tags == nil {
+		tags = []string{}
+	}
+	return dto.RuleResponse{
+		ID:              r.ID,
+		Name:            r.Name,
+		Priority:        r.Priority,
+		IsActive:        r.IsActive,
+		TitlePattern:    r.TitlePattern,
+		Direction:       r.Direction,
+		MinAmount:       r.MinAmount,
+		MaxAmount:       r.MaxAmount,
+		PaymentMethodID: r.PaymentMethodID,
+		PayeeID:         r.PayeeID,
+		SetCategoryID:   r.SetCategoryID,
+		SetCategoryName: r.SetCategoryName,
+		SetIsFixed:      r.SetIsFixed,
+		SetPayeeID:      r.SetPayeeID,
+		AddTags:         tags,
+	}
+}
+
+func toRuleApplyResponse(result *rule.ApplyResult) dto.RuleApplyResponse {
+	resp := dto.RuleApplyResponse{
+		DryRun:       result.DryRun,
+		ChangedCount: len(result.Changes),
+		Changes:      make([]dto.RuleChangeResponse, len(result.Changes)),
+	}
+	for i, ch := range result.Changes {
+		tags := ch.AddTags
+		if tags == nil {
+			tags = []string{}
+		}
+		resp.Changes[i] = dto.RuleChangeResponse{
+			CashFlowID: ch.CashFlowID,
+			Date:       ch.Date.Format("2006-01-02"),
+			Title:      ch.Title,
+			Direction:  ch.Direction,
+			Amount:     ch.Amount,
+			Before:     dto.RuleValuesResponse{CategoryID: ch.Before.CategoryID, IsFixed: ch.Before.IsFixed, PayeeID: ch.Before.PayeeID},
+			After:      dto.RuleValuesResponse{CategoryID: ch.After.CategoryID, IsFixed: ch.After.IsFixed, PayeeID: ch.After.PayeeID},
+			AddTags:    tags,
+		}
+	}
+	return resp
+}
@@ -0,0 +1,59 @@
+package http
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/adapters/http/dto"
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/domain/report"
+	"github.com/labstack/echo/v4"
+)
+
+type StatementHandler struct {
+	service report.StatementService
+}
+
+func NewStatementHandler(service report.StatementService) *StatementHandler {
+	return &StatementHandler{service: service}
+}
+
+// GetMonthlyPDF renders the printable statement of a month.
+// @Summary Extrato Mensal em PDF
+// @Description Renders a PDF statement of the month: income and expense summary, realized totals by category with bars, budget against actual (as in the budget summary), credit card invoices with entries in the month, open picuinha balances per person and the full list of cash flows. Summary and categories count realized flows only.
+// @Tags Reports
+// @Produce application/pdf
+// @Param month path string true "Reference month followed by .pdf (YYYY-MM-DD.pdf)" example(2024-03-01.pdf)
+// @Success 200 {file} file
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /reports/monthly/{month}.pdf [get]
+func (h *StatementHandler) GetMonthlyPDF(c echo.Context) error {
+	monthStr, ok := strings.CutSuffix(c.Param("month"), ".pdf")
+	if !ok {
+		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: "only .pdf is supported"})
+	}
+	month, err := time.Parse("2006-01-02", monthStr)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: "invalid month format, use YYYY-MM-DD"})
+	}
+
+	statement, err := h.service.GetMonthlyStatement(c.Request().Context(), month)
+	if err != nil {
+		c.Logger().Errorf("failed to build monthly statement for %s: %v", monthStr, err)
+		return c.JSON(http.StatusInternalServerError, dto.ErrorResponse{Error: "failed to build monthly statement"})
+	}
+	content, err := renderStatementPDF(statement)
+	if err != nil {
+		c.Logger().Errorf("failed to render monthly statement pdf for %s: %v", monthStr, err)
+		return c.JSON(http.StatusInternalServerError, dto.ErrorResponse{Error: "failed to render pdf"})
+	}
+
+	setAttachment(c, fmt.Sprintf("extrato-%s.pdf", statement.Month.Format("2006-01")))
+	return c.Blob(http.StatusOK, "application/pdf", content)
+}
+
+func RegisterStatementRoutes(e *echo.Echo, h *StatementHandler) {
+	e.GET("/reports/monthly/:month", h.GetMonthlyPDF)
+}
@@ -0,0 +1,274 @@
+package http
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/adapters/http/dto"
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/domain/tag"
+	"github.com/labstack/echo/v4"
+)
+
+type TagHandler struct {
+	service tag.Service
+}
+
+func NewTagHandler(service tag.Service) *TagHandler {
+	return &TagHandler{service: service}
+}
+
+// Create registers a tag.
+// @Summary Criar Tag
+// @Description Creates a tag. The name is stored in lower case with spaces replaced by hyphens.
+// @Tags Tags
+// @Accept json
+// @Produce json
+// @Param payload body dto.TagRequest true "Tag Payload"
+// @Success 201 {object} dto.TagResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 409 {object} dto.ErrorResponse
+// @Router /tags [post]
+func (h *TagHandler) Create(c echo.Context) error {
+	var req dto.TagRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: "invalid payload"})
+	}
+
+	created, err := h.service.CreateTag(c.Request().Context(), req.Name)
+	if err != nil {
+		return tagError(c, err, "failed to create tag")
+	}
+
+	return c.JSON(http.StatusCreated, toTagResponse(*created))
+}
+
+// List returns all tags.
+// @Summary Listar Tags
+// @Description Returns all tags with the number of cash flows carrying each one.
+// @Tags Tags
+// @Accept json
+// @Produce json
+// @Success 200 {array} dto.TagResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /tags [get]
+func (h *TagHandler) List(c echo.Context) error {
+	list, err := h.service.ListTags(c.Request().Context())
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, dto.ErrorResponse{Error: "failed to list tags"})
+	}
+
+	return c.JSON(http.StatusOK, toTagResponses(list))
+}
+
+// Update renames a tag.
+// @Summary Renomear Tag
+// @Description Renames a tag by ID. Tagged cash flows keep the link.
+// @Tags Tags
+// @Accept json
+// @Produce json
+// @Param id path int true "Tag ID"
+// @Param payload body dto.TagRequest true "Tag Payload"
+// @Success 200 {object} dto.TagResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 409 {object} dto.ErrorResponse
+// @Router /tags/{id} [put]
+func (h *TagHandler) Update(c echo.Context) error {
+	idStr := c.Param("id")
+	var id int32
+	if _, err := fmt.Sscanf(idStr, "%d", &id); err != nil {
+		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: "invalid id format"})
+	}
+
+	var req dto.TagRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: "invalid payload"})
+	}
+
+	updated, err := h.service.UpdateTag(c.Request().Context(), id, req.Name)
+	if err != nil {
+		return tagError(c, err, "failed to update tag")
+	}
+
+	return c.JSON(http.StatusOK, toTagResponse(*updated))
+}
+
+// Delete removes a tag.
+// @Summary Excluir Tag
+// @Description Deletes a tag by ID and removes it from every cash flow. The cash flows are kept.
+// @Tags Tags
+// @Accept json
+// @Produce json
+// @Param id path int true "Tag ID"
+// @Success 200 {object} map[string]string
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Router /tags/{id} [delete]
+func (h *TagHandler) Delete(c echo.Context) error {
+	idStr := c.Param("id")
+	var id int32
+	if _, err := fmt.Sscanf(idStr, "%d", &id); err != nil {
+		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: "invalid id format"})
+	}
+
+	if err := h.service.DeleteTag(c.Request().Context(), id); err != nil {
+		return tagError(c, err, "failed to delete tag")
+	}
+
+	return c.JSON(http.StatusOK, map[string]string{"status": "deleted"})
+}
+
+// ListCashFlowTags returns the tags of a cash flow.
+// @Summary Listar Tags do Lançamento
+// @Description Returns the tags of a cash flow.
+// @Tags Tags
+// @Accept json
+// @Produce json
+// @Param id path int true "Cash Flow ID"
+// @Success 200 {array} dto.TagResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Router /cashflows/{id}/tags [get]
+func (h *TagHandler) ListCashFlowTags(c echo.Context) error {
+	idStr := c.Param("id")
+	var id int32
+	if _, err := fmt.Sscanf(idStr, "%d", &id); err != nil {
+		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: "invalid id format"})
+	}
+
+	list, err := h.service.ListCashFlowTags(c.Request().Context(), id)
+	if err != nil {
+		return tagError(c, err, "failed to list cash flow tags")
+	}
+
+	return c.JSON(http.StatusOK, toTagResponses(list))
+}
+
+// SetCashFlowTags replaces the tags of a cash flow.
+// @Summary Definir Tags do Lançamento
+// @Description Replaces the tags of a cash flow. Names that do not exist yet are created; an empty list removes all tags.
+// @Tags Tags
+// @Accept json
+// @Produce json
+// @Param id path int true "Cash Flow ID"
+// @Param payload body dto.SetCashFlowTagsRequest true "Tags"
+// @Success 200 {array} dto.TagResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Router /cashflows/{id}/tags [put]
+func (h *TagHandler) SetCashFlowTags(c echo.Context) error {
+	idStr := c.Param("id")
+	var id int32
+	if _, err := fmt.Sscanf(idStr, "%d", &id); err != nil {
+		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: "invalid id format"})
+	}
+
+	var req dto.SetCashFlowTagsRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: "invalid payload"})
+	}
+
+	list, err := h.service.SetCashFlowTags(c.Request().Context(), id, req.Tags)
+	if err != nil {
+		return tagError(c, err, "failed to set cash flow tags")
+	}
+
+	return c.JSON(http.StatusOK, toTagResponses(list))
+}
+
+// Report totals the cash flows of a tag.
+// @Summary Relatório por Tag
+// @Description Returns the totals of the cash flows carrying a tag, by category and by month. Split cash flows count per split line.
+// @Tags Reports
+// @Accept json
+// @Produce json
+// @Param tag path string true "Tag name"
+// @Success 200 {object} dto.TagReportResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Router /reports/tags/{tag} [get]
+func (h *TagHandler) Report(c echo.Context) error {
+	report, err := h.service.GetReport(c.Request().Context(), c.Param("tag"))
+	if err != nil {
+		return tagError(c, err, "failed to build tag report")
+	}
+
+	resp := dto.TagReportResponse{
+		Tag:          report.Tag.Name,
+		TotalIncome:  report.TotalIncome,
+		TotalExpense: report.TotalExpense,
+		Balance:      report.TotalIncome.Sub(report.TotalExpense),
+		ByCategory:   make([]dto.TagCategoryTotalResponse, len(report.ByCategory)),
+		ByMonth:      make([]dto.TagMonthTotalResponse, len(report.ByMonth)),
+		Breakdown:    make([]dto.TagReportRowResponse, len(report.Breakdown)),
+	}
+	for i, t := range report.ByCategory {
+		resp.ByCategory[i] = dto.TagCategoryTotalResponse{
+			CategoryID:   t.CategoryID,
+			CategoryName: t.CategoryName,
+			Direction:    t.Direction,
+			TotalAmount:  t.TotalAmount,
+		}
+	}
+	for i, m := range report.ByMonth {
+		resp.ByMonth[i] = dto.TagMonthTotalResponse{
+			Month:        m.Month.Format("2006-01-02"),
+			TotalIncome:  m.TotalIncome,
+			TotalExpense: m.TotalExpense,
+			Balance:      m.TotalIncome.Sub(m.TotalExpense),
+		}
+	}
+	for i, r := range report.Breakdown {
+		resp.Breakdown[i] = dto.TagReportRowResponse{
+			Month:        r.Month.Format("2006-01-02"),
+			CategoryID:   r.CategoryID,
+			CategoryName: r.CategoryName,
+			Direction:    r.Direction,
+			TotalAmount:  r.TotalAmount,
+		}
+	}
+
+	return c.JSON(http.StatusOK, resp)
+}
+
+func RegisterTagRoutes(e *echo.Echo, h *TagHandler) {
+	g := e.Group("/tags")
+	g.POST("", h.Create)
+	g.GET("", h.List)
+	g.PUT("/:id", h.Update)
+	g.DELETE("/:id", h.Delete)
+
+	e.GET("/cashflows/:id/tags", h.ListCashFlowTags)
+	e.PUT("/cashflows/:id/tags", h.SetCashFlowTags)
+	e.GET("/reports/tags/:tag", h.Report)
+}
+
+func tagError(c echo.Context, err error, fallback string) error {
+	switch {
+	case errors.Is(err, tag.ErrTagNotFound):
+		return c.JSON(http.StatusNotFound, dto.ErrorResponse{Error: err.Error()})
+	case errors.Is(err, tag.ErrDuplicateName):
+		return c.JSON(http.StatusConflict, dto.ErrorResponse{Error: err.Error()})
+	case errors.Is(err, tag.ErrEmptyName),
+		errors.Is(err, tag.ErrNameTooLong):
+		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: err.Error()})
+	}
+	return cashFlowError(c, err, fallback)
+}
+
+func toTagResponse(t tag.Tag) dto.TagResponse {
+	return dto.TagResponse{
+		ID:            t.ID,
+		Name:          t.Name,
+		CashFlowCount: t.CashFlowCount,
+	}
+}
+
+func toTagResponses(list []tag.Tag) []dto.TagResponse {
+	resp := make([]dto.TagResponse, len(list))
+	for i, t := range list {
+		resp[i] = toTagResponse(t)
+	}
+	return resp
+}
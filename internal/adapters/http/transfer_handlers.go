@@ -0,0 +1,186 @@
+package http
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/adapters/http/dto"
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/domain/account"
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/domain/transfer"
+	"github.com/labstack/echo/v4"
+)
+
+type TransferHandler struct {
+	service transfer.Service
+}
+
+func NewTransferHandler(service transfer.Service) *TransferHandler {
+	return &TransferHandler{service: service}
+}
+
+// Create registers a transfer between two own accounts.
+// @Summary Criar Transferência
+// @Description Moves money between two own accounts. Creates an OUT flow on the source account and an IN flow on the destination, atomically. Transfers change account balances but not income, expense or budget actuals.
+// @Tags Transfers
+// @Accept json
+// @Produce json
+// @Param payload body dto.CreateTransferRequest true "Transfer Payload"
+// @Success 201 {object} dto.TransferResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Router /transfers [post]
+func (h *TransferHandler) Create(c echo.Context) error {
+	var req dto.CreateTransferRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: "invalid payload"})
+	}
+
+	date, err := time.Parse("2006-01-02", req.Date)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: "invalid date format, expected YYYY-MM-DD"})
+	}
+
+	created, err := h.service.CreateTransfer(c.Request().Context(), transfer.Transfer{
+		Date:          date,
+		Amount:        req.Amount,
+		Title:         req.Title,
+		FromAccountID: req.FromAccountID,
+		ToAccountID:   req.ToAccountID,
+	})
+	if err != nil {
+		return transferError(c, err, "failed to create transfer")
+	}
+
+	return c.JSON(http.StatusCreated, toTransferResponse(*created))
+}
+
+// List returns transfers.
+// @Summary Listar Transferências
+// @Description Returns transfers ordered by date, optionally limited to a date range.
+// @Tags Transfers
+// @Accept json
+// @Produce json
+// @Param from query string false "Start date (YYYY-MM-DD)"
+// @Param to query string false "End date (YYYY-MM-DD)"
+// @Success 200 {array} dto.TransferResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /transfers [get]
+func (h *TransferHandler) List(c echo.Context) error {
+	var from, to *time.Time
+	if v := c.QueryParam("from"); v != "" {
+		d, err := time.Parse("2006-01-02", v)
+		if err != nil {
+			return c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: "invalid from format, expected YYYY-MM-DD"})
+		}
+		from = &d
+	}
+	if v := c.QueryParam("to"); v != "" {
+		d, err := time.Parse("2006-01-02", v)
+		if err != nil {
+			return c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: "invalid to format, expected YYYY-MM-DD"})
+		}
+		to = &d
+	}
+
+	list, err := h.service.ListTransfers(c.Request().Context(), from, to)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, dto.ErrorResponse{Error: "failed to list transfers"})
+	}
+
+	resp := make([]dto.TransferResponse, len(list))
+	for i, t := range list {
+		resp[i] = toTransferResponse(t)
+	}
+	return c.JSON(http.StatusOK, resp)
+}
+
+// Get returns a transfer.
+// @Summary Obter Transferência
+// @Description Returns a transfer by ID with the IDs of its two cash flows.
+// @Tags Transfers
+// @Accept json
+// @Produce json
+// @Param id path int true "Transfer ID"
+// @Success 200 {object} dto.TransferResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Router /transfers/{id} [get]
+func (h *TransferHandler) Get(c echo.Context) error {
+	idStr := c.Param("id")
+	var id int32
+	if _, err := fmt.Sscanf(idStr, "%d", &id); err != nil {
+		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: "invalid id format"})
+	}
+
+	t, err := h.service.GetTransfer(c.Request().Context(), id)
+	if err != nil {
+		return transferError(c, err, "failed to get transfer")
+	}
+
+	return c.JSON(http.StatusOK, toTransferResponse(*t))
+}
+
+// Delete removes a transfer.
+// @Summary Excluir Transferência
+// @Description Deletes a transfer by ID together with both of its cash flows.
+// @Tags Transfers
+// @Accept json
+// @Produce json
+// @Param id path int true "Transfer ID"
+// @Success 200 {object} map[string]string
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Router /transfers/{id} [delete]
+func (h *TransferHandler) Delete(c echo.Context) error {
+	idStr := c.Param("id")
+	var id int32
+	if _, err := fmt.Sscanf(idStr, "%d", &id); err != nil {
+		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: "invalid id format"})
+	}
+
+	if err := h.service.DeleteTransfer(c.Request().Context(), id); err != nil {
+		return transferError(c, err, "failed to delete transfer")
+	}
+
+	return c.JSON(http.StatusOK, map[string]string{"status": "deleted"})
+}
+
+func RegisterTransferRoutes(e *echo.Echo, h *TransferHandler) {
+	g := e.Group("/transfers")
+	g.POST("", h.Create)
+	g.GET("", h.List)
+	g.GET("/:id", h.Get)
+	g.DELETE("/:id", h.Delete)
+}
+
+func transferError(c echo.Context, err error, fallback string) error {
+	switch {
+	case errors.Is(err, transfer.ErrTransferNotFound),
+		errors.Is(err, account.ErrAccountNotFound):
+		return c.JSON(http.StatusNotFound, dto.ErrorResponse{Error: err.Error()})
+	case errors.Is(err, transfer.ErrSameAccount),
+		errors.Is(err, transfer.ErrInvalidAmount),
+		errors.Is(err, transfer.ErrInvalidDate):
+		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: err.Error()})
+	}
+	return cashFlowError(c, err, fallback)
+}
+
+func toTransferResponse(t transfer.Transfer) dto.TransferResponse {
+	return dto.TransferResponse{
+		ID:              t.ID,
+		Date:            t.Date.Format("2006-01-02"),
+		Amount:          t.Amount,
+		Title:           t.Title,
+		FromAccountID:   t.FromAccountID,
+		FromAccountName: t.FromAccountName,
+		ToAccountID:     t.ToAccountID,
+		ToAccountName:   t.ToAccountName,
+		OutCashFlowID:   t.OutCashFlowID,
+		InCashFlowID:    t.InCashFlowID,
+		CreatedAt:       t.CreatedAt.Format(time.RFC3339),
+	}
+}
@@ -0,0 +1,172 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/adapters/postgres/sqlc"
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/domain/account"
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/domain/payment"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type AccountRepository struct {
+	db *pgxpool.Pool
+	q  *sqlc.Queries
+}
+
+func NewAccountRepository(db *pgxpool.Pool) *AccountRepository {
+	return &AccountRepository{
+		db: db,
+		q:  sqlc.New(db),
+	}
+}
+
+func (r *AccountRepository) Create(ctx context.Context, a *account.Account) (*account.Account, error) {
+	row, err := queriesFor(ctx, r.q).CreateAccount(ctx, sqlc.CreateAccountParams{
+		Name:           a.Name,
+		Kind:           a.Kind,
+		Institution:    textFromString(a.Institution),
+		OpeningBalance: a.OpeningBalance,
+		OpeningDate:    pgtype.Date{Time: a.OpeningDate, Valid: true},
+		IsActive:       a.IsActive,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return toAccount(row), nil
+}
+
+func (r *AccountRepository) List(ctx context.Context, activeOnly bool) ([]account.Account, error) {
+	filter := pgtype.Bool{Valid: false}
+	if activeOnly {
+		filter = pgtype.Bool{Bool: true, Valid: true}
+	}
+	rows, err := queriesFor(ctx, r.q).ListAccounts(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	accounts := make([]account.Account, len(rows))
+	for i, row := range rows {
+		accounts[i] = *toAccount(row)
+	}
+	return accounts, nil
+}
+
+func (r *AccountRepository) GetByID(ctx context.Context, id int32) (*account.Account, error) {
+	row, err := queriesFor(ctx, r.q).GetAccount(ctx, id)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return toAccount(row), nil
+}
+
+func (r *AccountRepository) GetByName(ctx context.Context, name string) (*account.Account, error) {
+	row, err := queriesFor(ctx, r.q).GetAccountByName(ctx, name)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return toAccount(row), nil
+}
+
+func (r *AccountRepository) Update(ctx context.Context, a *account.Account) (*account.Account, error) {
+	row, err := queriesFor(ctx, r.q).UpdateAccount(ctx, sqlc.UpdateAccountParams{
+		AccountID:      a.ID,
+		Name:           a.Name,
+		Kind:           a.Kind,
+		Institution:    textFromString(a.Institution),
+		OpeningBalance: a.OpeningBalance,
+		OpeningDate:    pgtype.Date{Time: a.OpeningDate, Valid: true},
+		IsActive:       a.IsActive,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, account.ErrAccountNotFound
+		}
+		return nil, err
+	}
+	return toAccount(row), nil
+}
+
+func (r *AccountRepository) Delete(ctx context.Context, id int32) error {
+	return queriesFor(ctx, r.q).DeleteAccount(ctx, id)
+}
+
+func (r *AccountRepository) CountLinks(ctx context.Context, id int32) (int64, error) {
+	return queriesFor(ctx, r.q).CountAccountLinks(ctx, id)
+}
+
+func (r *AccountRepository) SetPaymentMethodAccount(ctx context.Context, paymentMethodID int32, accountID *int32) error {
+	affected, err := queriesFor(ctx, r.q).SetPaymentMethodAccount(ctx, sqlc.SetPaymentMethodAccountParams{
+		PaymentMethodID: paymentMethodID,
+		AccountID:       int4FromPtr(accountID),
+	})
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return payment.ErrPaymentMethodNotFound
+	}
+	return nil
+}
+
+func (r *AccountRepository) GetBalances(ctx context.Context, asOf time.Time) ([]account.Balance, error) {
+	rows, err := queriesFor(ctx, r.q).GetAccountBalances(ctx, pgtype.Date{Time: asOf, Valid: true})
+	if err != nil {
+		return nil, err
+	}
+
+	balances := make([]account.Balance, len(rows))
+	for i, row := range rows {
+		balances[i] = account.Balance{
+			AccountID: row.AccountID,
+			Name:      row.Name,
+			Kind:      row.Kind,
+			IsActive:  row.IsActive,
+			Balance:   row.Balance,
+		}
+	}
+	return balances, nil
+}
+
+func (r *AccountRepository) ListDailyTotals(ctx context.Context, id int32, to time.Time) ([]account.DailyTotal, error) {
+	rows, err := queriesFor(ctx, r.q).ListAccountDailyTotals(ctx, sqlc.ListAccountDailyTotalsParams{
+		AccountID: id,
+		ToDate:    pgtype.Date{Time: to, Valid: true},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	totals := make([]account.DailyTotal, len(rows))
+	for i, row := range rows {
+		totals[i] = account.DailyTotal{
+			Date:         row.Date.Time,
+			TotalIncome:  row.TotalIncome,
+			TotalExpense: row.TotalExpense,
+		}
+	}
+	return totals, nil
+}
+
+func toAccount(row sqlc.Account) *account.Account {
+	return &account.Account{
+		ID:             row.AccountID,
+		Name:           row.Name,
+		Kind:           row.Kind,
+		Institution:    row.Institution.String,
+		OpeningBalance: row.OpeningBalance,
+		OpeningDate:    row.OpeningDate.Time,
+		IsActive:       row.IsActive,
+	}
+}
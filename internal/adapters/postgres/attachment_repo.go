@@ -0,0 +1,135 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/adapters/postgres/sqlc"
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/domain/attachment"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type AttachmentRepository struct {
+	db *pgxpool.Pool
+	q  *sqlc.Queries
+}
+
+func NewAttachmentRepository(db *pgxpool.Pool) *AttachmentRepository {
+	return &AttachmentRepository{
+		db: db,
+		q:  sqlc.New(db),
+	}
+}
+
+func (r *AttachmentRepository) WithinTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	return withinTx(ctx, r.db, fn)
+}
+
+func (r *AttachmentRepository) Create(ctx context.Context, a *attachment.Attachment) (*attachment.Attachment, error) {
+	cashFlowID, planID := ownerColumns(a.Owner)
+	row, err := queriesFor(ctx, r.q).CreateAttachment(ctx, sqlc.CreateAttachmentParams{
+		CashFlowID:        cashFlowID,
+		InstallmentPlanID: planID,
+		FileName:          a.FileName,
+		ContentType:       a.ContentType,
+		SizeBytes:         a.SizeBytes,
+		Sha256:            a.SHA256,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return toAttachment(row), nil
+}
+
+func (r *AttachmentRepository) GetByID(ctx context.Context, id int32) (*attachment.Attachment, error) {
+	row, err := queriesFor(ctx, r.q).GetAttachment(ctx, id)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return toAttachment(row), nil
+}
+
+func (r *AttachmentRepository) FindByHash(ctx context.Context, owner attachment.Owner, sha256 string) (*attachment.Attachment, error) {
+	cashFlowID, planID := ownerColumns(owner)
+	row, err := queriesFor(ctx, r.q).FindAttachment(ctx, sqlc.FindAttachmentParams{
+		Sha256:            sha256,
+		CashFlowID:        cashFlowID,
+		InstallmentPlanID: planID,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return toAttachment(row), nil
+}
+
+func (r *AttachmentRepository) ListByOwner(ctx context.Context, owner attachment.Owner) ([]attachment.Attachment, error) {
+	cashFlowID, planID := ownerColumns(owner)
+
+	var rows []sqlc.Attachment
+	var err error
+	if owner.Type == attachment.OwnerCashFlow {
+		rows, err = queriesFor(ctx, r.q).ListAttachmentsByCashFlow(ctx, cashFlowID)
+	} else {
+		rows, err = queriesFor(ctx, r.q).ListAttachmentsByInstallmentPlan(ctx, planID)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	list := make([]attachment.Attachment, len(rows))
+	for i, row := range rows {
+		list[i] = *toAttachment(row)
+	}
+	return list, nil
+}
+
+func (r *AttachmentRepository) Delete(ctx context.Context, id int32) error {
+	return queriesFor(ctx, r.q).DeleteAttachment(ctx, id)
+}
+
+func (r *AttachmentRepository) CountByHash(ctx context.Context, sha256 string) (int64, error) {
+	return queriesFor(ctx, r.q).CountAttachmentsBySha256(ctx, sha256)
+}
+
+func (r *AttachmentRepository) LockHash(ctx context.Context, sha256 string) error {
+	return queriesFor(ctx, r.q).LockAttachmentHash(ctx, sha256)
+}
+
+func (r *AttachmentRepository) InstallmentPlanExists(ctx context.Context, id int32) (bool, error) {
+	return queriesFor(ctx, r.q).InstallmentPlanExists(ctx, id)
+}
+
+func ownerColumns(owner attachment.Owner) (cashFlowID, planID pgtype.Int4) {
+	switch owner.Type {
+	case attachment.OwnerCashFlow:
+		cashFlowID = pgtype.Int4{Int32: owner.ID, Valid: true}
+	case attachment.OwnerInstallmentPlan:
+		planID = pgtype.Int4{Int32: owner.ID, Valid: true}
+	}
+	return cashFlowID, planID
+}
+
+func toAttachment(row sqlc.Attachment) *attachment.Attachment {
+	a := &attachment.Attachment{
+		ID:          row.AttachmentID,
+		FileName:    row.FileName,
+		ContentType: row.ContentType,
+		SizeBytes:   row.SizeBytes,
+		SHA256:      row.Sha256,
+		CreatedAt:   row.CreatedAt.Time,
+	}
+	if row.CashFlowID.Valid {
+		a.Owner = attachment.Owner{Type: attachment.OwnerCashFlow, ID: row.CashFlowID.Int32}
+	} else {
+		a.Owner = attachment.Owner{Type: attachment.OwnerInstallmentPlan, ID: row.InstallmentPlanID.Int32}
+	}
+	return a
+}
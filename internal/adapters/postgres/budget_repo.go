@@ -90,8 +90,6 @@ func (r *BudgetRepository) CreatePeriod(ctx context.Context, period *budget.Budg
 
 func (r *BudgetRepository) UpsertItem(ctx context.Context, item *budget.BudgetItem) (*budget.BudgetItem, error) {
 	// Numeric conversion
-	var planned pgtype.Numeric
-	planned.Scan(fmt.Sprintf("%.2f", item.PlannedAmount))
 
 	var target pgtype.Numeric
 	target.Scan(fmt.Sprintf("%.2f", item.TargetPercent))
@@ -106,7 +104,7 @@ func (r *BudgetRepository) UpsertItem(ctx context.Context, item *budget.BudgetIt
 		BudgetPeriodID: item.BudgetPeriodID,
 		CategoryID:     item.CategoryID,
 		Mode:           item.Mode,
-		PlannedAmount:  planned, // Nullable in DB? No, in DB it is. Wait, schema: planned_amount decimal(14,2) (nullable).
+		PlannedAmount:  &item.PlannedAmount, // Nullable in DB? No, in DB it is. Wait, schema: planned_amount decimal(14,2) (nullable).
 		TargetPercent:  target,
 		Notes:          notes,
 	}
@@ -116,7 +114,6 @@ func (r *BudgetRepository) UpsertItem(ctx context.Context, item *budget.BudgetIt
 		return nil, err
 	}
 
-	pVal, _ := row.PlannedAmount.Float64Value()
 	tVal, _ := row.TargetPercent.Float64Value()
 
 	return &budget.BudgetItem{
@@ -124,7 +121,7 @@ func (r *BudgetRepository) UpsertItem(ctx context.Context, item *budget.BudgetIt
 		BudgetPeriodID: row.BudgetPeriodID,
 		CategoryID:     row.CategoryID,
 		Mode:           row.Mode,
-		PlannedAmount:  pVal.Float64,
+		PlannedAmount:  amountFromPtr(row.PlannedAmount),
 		TargetPercent:  tVal.Float64,
 		Notes:          row.Notes.String,
 	}, nil
@@ -138,7 +135,6 @@ func (r *BudgetRepository) GetItemsByPeriod(ctx context.Context, periodID int32)
 
 	items := make([]budget.BudgetItem, len(rows))
 	for i, row := range rows {
-		pVal, _ := row.PlannedAmount.Float64Value()
 		tVal, _ := row.TargetPercent.Float64Value()
 
 		items[i] = budget.BudgetItem{
@@ -147,7 +143,7 @@ func (r *BudgetRepository) GetItemsByPeriod(ctx context.Context, periodID int32)
 			CategoryID:     row.CategoryID,
 			CategoryName:   row.CategoryName,
 			Mode:           row.Mode,
-			PlannedAmount:  pVal.Float64,
+			PlannedAmount:  amountFromPtr(row.PlannedAmount),
 			TargetPercent:  tVal.Float64,
 			Notes:          row.Notes.String,
 		}
@@ -164,7 +160,6 @@ func (r *BudgetRepository) GetItemByID(ctx context.Context, id int32) (*budget.B
 		return nil, err
 	}
 
-	pVal, _ := row.PlannedAmount.Float64Value()
 	tVal, _ := row.TargetPercent.Float64Value()
 
 	return &budget.BudgetItem{
@@ -173,15 +168,13 @@ func (r *BudgetRepository) GetItemByID(ctx context.Context, id int32) (*budget.B
 		CategoryID:     row.CategoryID,
 		CategoryName:   row.CategoryName,
 		Mode:           row.Mode,
-		PlannedAmount:  pVal.Float64,
+		PlannedAmount:  amountFromPtr(row.PlannedAmount),
 		TargetPercent:  tVal.Float64,
 		Notes:          row.Notes.String,
 	}, nil
 }
 
 func (r *BudgetRepository) UpdateItem(ctx context.Context, item *budget.BudgetItem) (*budget.BudgetItem, error) {
-	var planned pgtype.Numeric
-	planned.Scan(fmt.Sprintf("%.2f", item.PlannedAmount))
 
 	var target pgtype.Numeric
 	target.Scan(fmt.Sprintf("%.2f", item.TargetPercent))
@@ -191,7 +184,7 @@ func (r *BudgetRepository) UpdateItem(ctx context.Context, item *budget.BudgetIt
 	params := sqlc.UpdateBudgetItemParams{
 		BudgetItemID:  item.ID,
 		Mode:          item.Mode,
-		PlannedAmount: planned,
+		PlannedAmount: &item.PlannedAmount,
 		TargetPercent: target,
 		Notes:         notes,
 	}
@@ -204,7 +197,6 @@ func (r *BudgetRepository) UpdateItem(ctx context.Context, item *budget.BudgetIt
 		return nil, err
 	}
 
-	pVal, _ := row.PlannedAmount.Float64Value()
 	tVal, _ := row.TargetPercent.Float64Value()
 
 	return &budget.BudgetItem{
@@ -213,7 +205,7 @@ func (r *BudgetRepository) UpdateItem(ctx context.Context, item *budget.BudgetIt
 		CategoryID:     row.CategoryID,
 		CategoryName:   row.CategoryName,
 		Mode:           row.Mode,
-		PlannedAmount:  pVal.Float64,
+		PlannedAmount:  amountFromPtr(row.PlannedAmount),
 		TargetPercent:  tVal.Float64,
 		Notes:          row.Notes.String,
 	}, nil
@@ -2,25 +2,35 @@ package postgres
 
 import (
 	"context"
-	"fmt"
+	"errors"
+	"strings"
 	"time"
 
-	"github.com/jackc/pgx/v5/pgtype"
-	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/LucasSiedschlag/HausHaltsMeister/internal/adapters/postgres/sqlc"
 	"github.com/LucasSiedschlag/HausHaltsMeister/internal/domain/cashflow"
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/domain/payee"
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/money"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
 )
 
 type CashFlowRepository struct {
-	q *sqlc.Queries
+	db *pgxpool.Pool
+	q  *sqlc.Queries
 }
 
 func NewCashFlowRepository(db *pgxpool.Pool) *CashFlowRepository {
 	return &CashFlowRepository{
-		q: sqlc.New(db),
+		db: db,
+		q:  sqlc.New(db),
 	}
 }
 
+func (r *CashFlowRepository) WithinTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	return withinTx(ctx, r.db, fn)
+}
+
 func (r *CashFlowRepository) Create(ctx context.Context, cf *cashflow.CashFlow) (*cashflow.CashFlow, error) {
 	// Convert time.Time to pgtype.Date
 	// pgx/v5 automatically handles time.Time for Date fields usually, but sqlc generates pgtype.Date for 'date' columns.
@@ -31,113 +41,273 @@ func (r *CashFlowRepository) Create(ctx context.Context, cf *cashflow.CashFlow)
 		Valid: true,
 	}
 
-	// Convert float64 to pgtype.Numeric
-	// This is a bit verbose with pgx/v5 pgtype.Numeric.
-	// Let's assume for now we can pass a string or use a helper.
-	// Or even better, let's look at what sqlc generated.
-	// It likely generated 'amount' as pgtype.Numeric.
-	// To simplify, we'll try to use a float helper if one existed, but standard way is via big.Int or string.
-	// Actually, let's verify what `sqlc generate` produced in step 82 (`models.go` is 2387 bytes).
-	// Since I can't check it right now, I will implement a safe float to numeric conversion
-	// or assume the driver handles it if sqlc generated valid Go types.
-	// Wait, standard `sqlc` with `pgx/v5` uses `pgtype.Numeric`.
-
-	// Workaround: We'll scan back as float64. Inserting might require proper Numeric construction.
-	// For simplicity in this "agent" mode, I'll attempt a direct cast if sqlc generated float64,
-	// else I will need to handle Numeric.
-	// Let's rely on `fmt.Sprintf` for float -> numeric string scan.
-
-	var am pgtype.Numeric
-	am.Scan(fmt.Sprintf("%.2f", cf.Amount))
-
 	params := sqlc.CreateCashFlowParams{
-		Date:       pgDate,
-		CategoryID: int32(cf.CategoryID),
-		Direction:  cf.Direction,
-		Title:      cf.Title,
-		Amount:     am,
-		IsFixed:    cf.IsFixed,
+		Date:            pgDate,
+		CategoryID:      int32(cf.CategoryID),
+		Direction:       cf.Direction,
+		Title:           cf.Title,
+		Amount:          cf.Amount,
+		IsFixed:         cf.IsFixed,
+		Fitid:            pgtype.Text{String: cf.FITID, Valid: cf.FITID != ""},
+		ExternalAccount:  pgtype.Text{String: cf.ExternalAccount, Valid: cf.ExternalAccount != ""},
+		SourceCashFlowID: int4FromPtr(cf.SourceCashFlowID),
+		AccountID:        int4FromPtr(cf.AccountID),
+		Status:           cf.Status,
+		PayeeID:          int4FromPtr(cf.PayeeID),
 	}
+	params.Currency, params.OriginalAmount, params.ExchangeRate, params.IofAmount = conversionParams(cf.Conversion)
 
-	row, err := r.q.CreateCashFlow(ctx, params)
+	row, err := queriesFor(ctx, r.q).CreateCashFlow(ctx, params)
 	if err != nil {
 		return nil, err
 	}
 
-	// Convert back
-	val, _ := row.Amount.Float64Value()
+	return toCashFlow(row), nil
+}
 
-	return &cashflow.CashFlow{
-		ID:         row.CashFlowID,
-		Date:       row.Date.Time,
+func (r *CashFlowRepository) CreateExpenseDetail(ctx context.Context, cashFlowID, paymentMethodID int32, isFixed, affectsCardInvoice bool) error {
+	return queriesFor(ctx, r.q).CreateCashFlowExpenseDetail(ctx, sqlc.CreateCashFlowExpenseDetailParams{
+		CashFlowID:         cashFlowID,
+		PaymentMethodID:    pgtype.Int4{Int32: paymentMethodID, Valid: true},
+		IsFixed:            isFixed,
+		AffectsCardInvoice: affectsCardInvoice,
+	})
+}
+
+func (r *CashFlowRepository) CreateSplit(ctx context.Context, cashFlowID int32, split cashflow.Split) (*cashflow.Split, error) {
+	row, err := queriesFor(ctx, r.q).CreateCashFlowSplit(ctx, sqlc.CreateCashFlowSplitParams{
+		CashFlowID: cashFlowID,
+		CategoryID: split.CategoryID,
+		Amount:     split.Amount,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &cashflow.Split{
+		ID:         row.CashFlowSplitID,
 		CategoryID: row.CategoryID,
-		Direction:  row.Direction,
-		Title:      row.Title,
-		Amount:     val.Float64,
-		IsFixed:    row.IsFixed,
+		Amount:     row.Amount,
 	}, nil
 }
 
+func (r *CashFlowRepository) ListSplits(ctx context.Context, cashFlowID int32) ([]cashflow.Split, error) {
+	rows, err := queriesFor(ctx, r.q).ListCashFlowSplits(ctx, cashFlowID)
+	if err != nil {
+		return nil, err
+	}
+
+	splits := make([]cashflow.Split, len(rows))
+	for i, row := range rows {
+		splits[i] = cashflow.Split{
+			ID:           row.CashFlowSplitID,
+			CategoryID:   row.CategoryID,
+			CategoryName: row.CategoryName,
+			Amount:       row.Amount,
+		}
+	}
+	return splits, nil
+}
+
+func (r *CashFlowRepository) DeleteSplits(ctx context.Context, cashFlowID int32) error {
+	return queriesFor(ctx, r.q).DeleteCashFlowSplits(ctx, cashFlowID)
+}
+
 func (r *CashFlowRepository) ListByMonth(ctx context.Context, month time.Time) ([]*cashflow.CashFlow, error) {
 	pgDate := pgtype.Date{
 		Time:  month,
 		Valid: true,
 	}
 
-	rows, err := r.q.ListCashFlowsByMonth(ctx, pgDate)
+	rows, err := queriesFor(ctx, r.q).ListCashFlowsByMonth(ctx, pgDate)
 	if err != nil {
 		return nil, err
 	}
 
 	result := make([]*cashflow.CashFlow, len(rows))
 	for i, row := range rows {
-		val, _ := row.Amount.Float64Value()
 		result[i] = &cashflow.CashFlow{
-			ID:           row.CashFlowID,
-			Date:         row.Date.Time,
-			CategoryID:   row.CategoryID,
-			CategoryName: row.CategoryName,
-			Direction:    row.Direction,
-			Title:        row.Title,
-			Amount:       val.Float64,
-			IsFixed:      row.IsFixed,
+			ID:               row.CashFlowID,
+			Date:             row.Date.Time,
+			CategoryID:       row.CategoryID,
+			CategoryName:     row.CategoryName,
+			Direction:        row.Direction,
+			Title:            row.Title,
+			Amount:           row.Amount,
+			IsFixed:          row.IsFixed,
+			AccountID:        int4ToPtr(row.AccountID),
+			ClearedAt:        timestampToPtr(row.ClearedAt),
+			ReconciliationID: int4ToPtr(row.ReconciliationID),
+			Status:           row.Status,
+			Conversion:       toConversion(row.Currency, row.OriginalAmount, row.ExchangeRate, row.IofAmount),
+			PayeeID:          int4ToPtr(row.PayeeID),
 		}
 	}
 	return result, nil
 }
 
-func (r *CashFlowRepository) GetMonthlySummary(ctx context.Context, month time.Time) (*cashflow.MonthlySummary, error) {
-	pgDate := pgtype.Date{Time: month, Valid: true}
-	row, err := r.q.GetMonthlySummary(ctx, pgDate)
+func (r *CashFlowRepository) ListFixedToCopy(ctx context.Context, month time.Time) ([]*cashflow.CashFlow, error) {
+	rows, err := queriesFor(ctx, r.q).ListFixedCashFlowsToCopy(ctx, pgtype.Date{Time: month, Valid: true})
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*cashflow.CashFlow, len(rows))
+	for i, row := range rows {
+		result[i] = toCashFlow(row)
+	}
+	return result, nil
+}
+
+func (r *CashFlowRepository) ListCopyTargets(ctx context.Context, month time.Time) ([]*cashflow.CashFlow, error) {
+	rows, err := queriesFor(ctx, r.q).ListCashFlowCopyTargets(ctx, pgtype.Date{Time: month, Valid: true})
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*cashflow.CashFlow, len(rows))
+	for i, row := range rows {
+		result[i] = toCashFlow(row)
+	}
+	return result, nil
+}
+
+func (r *CashFlowRepository) ListLinesByMonth(ctx context.Context, month time.Time) ([]cashflow.Line, error) {
+	rows, err := queriesFor(ctx, r.q).ListCashFlowLinesByMonth(ctx, pgtype.Date{Time: month, Valid: true})
+	if err != nil {
+		return nil, err
+	}
+
+	lines := make([]cashflow.Line, len(rows))
+	for i, row := range rows {
+		lines[i] = cashflow.Line{
+			CashFlowID: row.CashFlowID,
+			SplitID:    int4ToPtr(row.CashFlowSplitID),
+			Date:       row.Date.Time,
+			Direction:  row.Direction,
+			CategoryID: row.CategoryID,
+			Amount:     row.Amount,
+		}
+	}
+	return lines, nil
+}
+
+func (r *CashFlowRepository) Search(ctx context.Context, f cashflow.Filter) ([]*cashflow.CashFlow, error) {
+	where := filterParams(f)
+	rows, err := queriesFor(ctx, r.q).SearchCashFlows(ctx, sqlc.SearchCashFlowsParams{
+		DateFrom:        where.DateFrom,
+		DateTo:          where.DateTo,
+		CategoryIds:     where.CategoryIds,
+		Direction:       where.Direction,
+		Title:           where.Title,
+		MinAmount:       where.MinAmount,
+		MaxAmount:       where.MaxAmount,
+		IsFixed:         where.IsFixed,
+		Status:          where.Status,
+		PaymentMethodID: where.PaymentMethodID,
+		Tags:            where.Tags,
+		PayeeID:         where.PayeeID,
+		SortBy:          f.SortBy,
+		SortDesc:        f.SortDesc,
+		PageLimit:       f.Limit,
+		PageOffset:      f.Offset,
+	})
 	if err != nil {
 		return nil, err
 	}
-	// row is struct{ TotalIncome float64, TotalExpense float64 } (checking generated code assumption)
-	// Actually sqlc returns float64 directly if not null, or sql.NullFloat64?
-	// The query used ::float, so it should be float64. But SUM() can be NULL if no rows.
-	// So it's likely float64 or *float64 or sql.NullFloat64.
-	// Let's assume float64 for now, but handle potential mismatch if compilation fails.
-	// Update: query used `SUM(...)::float`. If no rows, returns NULL. = *float64?
-	// I'll check generated code if I could, but I'll use safe dereference logic assuming generated code follows standard PGX.
 
-	// Wait, if I can't check generated code, I should look at `sqlc` defaults.
-	// SUM usually implies null possibility.
+	result := make([]*cashflow.CashFlow, len(rows))
+	for i, row := range rows {
+		result[i] = &cashflow.CashFlow{
+			ID:               row.CashFlowID,
+			Date:             row.Date.Time,
+			CategoryID:       row.CategoryID,
+			CategoryName:     row.CategoryName,
+			Direction:        row.Direction,
+			Title:            row.Title,
+			Amount:           row.Amount,
+			IsFixed:          row.IsFixed,
+			AccountID:        int4ToPtr(row.AccountID),
+			ClearedAt:        timestampToPtr(row.ClearedAt),
+			ReconciliationID: int4ToPtr(row.ReconciliationID),
+			Status:           row.Status,
+			Conversion:       toConversion(row.Currency, row.OriginalAmount, row.ExchangeRate, row.IofAmount),
+			PayeeID:          int4ToPtr(row.PayeeID),
+		}
+	}
+	return result, nil
+}
 
-	// Let's implement optimistically using values.
+func (r *CashFlowRepository) Count(ctx context.Context, f cashflow.Filter) (int64, error) {
+	return queriesFor(ctx, r.q).CountCashFlows(ctx, filterParams(f))
+}
 
+// filterParams maps the optional criteria of a filter to nullable query
+// parameters. A NULL parameter disables the corresponding condition.
+func filterParams(f cashflow.Filter) sqlc.CountCashFlowsParams {
+	var p sqlc.CountCashFlowsParams
+	if f.DateFrom != nil {
+		p.DateFrom = pgtype.Date{Time: *f.DateFrom, Valid: true}
+	}
+	if f.DateTo != nil {
+		p.DateTo = pgtype.Date{Time: *f.DateTo, Valid: true}
+	}
+	if len(f.CategoryIDs) > 0 {
+		p.CategoryIds = f.CategoryIDs
+	}
+	if f.Direction != "" {
+		p.Direction = pgtype.Text{String: f.Direction, Valid: true}
+	}
+	if f.Title != "" {
+		p.Title = pgtype.Text{String: likeEscaper.Replace(f.Title), Valid: true}
+	}
+	p.MinAmount = f.MinAmount
+	p.MaxAmount = f.MaxAmount
+	if f.IsFixed != nil {
+		p.IsFixed = pgtype.Bool{Bool: *f.IsFixed, Valid: true}
+	}
+	if f.Status != "" {
+		p.Status = pgtype.Text{String: f.Status, Valid: true}
+	}
+	if f.PaymentMethodID != nil {
+		p.PaymentMethodID = pgtype.Int4{Int32: *f.PaymentMethodID, Valid: true}
+	}
+	if len(f.Tags) > 0 {
+		p.Tags = f.Tags
+	}
+	if f.PayeeID != nil {
+		p.PayeeID = pgtype.Int4{Int32: *f.PayeeID, Valid: true}
+	}
+	return p
+}
+
+// likeEscaper makes user input match literally inside an ILIKE pattern.
+var likeEscaper = strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`)
+
+func (r *CashFlowRepository) GetMonthlySummary(ctx context.Context, month time.Time, includePlanned bool) (*cashflow.MonthlySummary, error) {
+	pgDate := pgtype.Date{Time: month, Valid: true}
+	row, err := queriesFor(ctx, r.q).GetMonthlySummary(ctx, sqlc.GetMonthlySummaryParams{
+		Month:          pgDate,
+		IncludePlanned: includePlanned,
+	})
+	if err != nil {
+		return nil, err
+	}
 	inc := row.TotalIncome
 	exp := row.TotalExpense
 
 	return &cashflow.MonthlySummary{
 		TotalIncome:  inc,
 		TotalExpense: exp,
-		Balance:      inc - exp,
+		Balance:      inc.Sub(exp),
 	}, nil
 }
 
-func (r *CashFlowRepository) GetCategorySummary(ctx context.Context, month time.Time) ([]cashflow.CategorySummary, error) {
+func (r *CashFlowRepository) GetCategorySummary(ctx context.Context, month time.Time, includePlanned bool) ([]cashflow.CategorySummary, error) {
 	pgDate := pgtype.Date{Time: month, Valid: true}
-	rows, err := r.q.GetCategorySummary(ctx, pgDate)
+	rows, err := queriesFor(ctx, r.q).GetCategorySummary(ctx, sqlc.GetCategorySummaryParams{
+		Month:          pgDate,
+		IncludePlanned: includePlanned,
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -152,3 +322,356 @@ func (r *CashFlowRepository) GetCategorySummary(ctx context.Context, month time.
 	}
 	return summaries, nil
 }
+
+func (r *CashFlowRepository) GetCategoryTotals(ctx context.Context, from, to time.Time, includePlanned bool) ([]cashflow.CategoryTotal, error) {
+	rows, err := queriesFor(ctx, r.q).GetCategoryTotals(ctx, sqlc.GetCategoryTotalsParams{
+		DateFrom:       pgtype.Date{Time: from, Valid: true},
+		DateTo:         pgtype.Date{Time: to, Valid: true},
+		IncludePlanned: includePlanned,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	totals := make([]cashflow.CategoryTotal, len(rows))
+	for i, row := range rows {
+		totals[i] = cashflow.CategoryTotal{
+			CategoryID: row.CategoryID,
+			CategorySummary: cashflow.CategorySummary{
+				CategoryName: row.Name,
+				Direction:    row.Direction,
+				TotalAmount:  row.TotalAmount,
+			},
+		}
+	}
+	return totals, nil
+}
+
+func (r *CashFlowRepository) GetByID(ctx context.Context, id int32) (*cashflow.CashFlow, error) {
+	row, err := queriesFor(ctx, r.q).GetCashFlowByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &cashflow.CashFlow{
+		ID:               row.CashFlowID,
+		Date:             row.Date.Time,
+		CategoryID:       row.CategoryID,
+		CategoryName:     row.CategoryName,
+		Direction:        row.Direction,
+		Title:            row.Title,
+		Amount:           row.Amount,
+		IsFixed:          row.IsFixed,
+		AccountID:        int4ToPtr(row.AccountID),
+		ClearedAt:        timestampToPtr(row.ClearedAt),
+		ReconciliationID: int4ToPtr(row.ReconciliationID),
+		Status:           row.Status,
+		Conversion:       toConversion(row.Currency, row.OriginalAmount, row.ExchangeRate, row.IofAmount),
+		PayeeID:          int4ToPtr(row.PayeeID),
+	}, nil
+}
+
+func (r *CashFlowRepository) Update(ctx context.Context, cf *cashflow.CashFlow) (*cashflow.CashFlow, error) {
+	params := sqlc.UpdateCashFlowParams{
+		CashFlowID: cf.ID,
+		Date:       pgtype.Date{Time: cf.Date, Valid: true},
+		CategoryID: cf.CategoryID,
+		Direction:  cf.Direction,
+		Title:      cf.Title,
+		Amount:     cf.Amount,
+		IsFixed:    cf.IsFixed,
+	}
+	params.Currency, params.OriginalAmount, params.ExchangeRate, params.IofAmount = conversionParams(cf.Conversion)
+
+	row, err := queriesFor(ctx, r.q).UpdateCashFlow(ctx, params)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, cashflow.ErrCashFlowNotFound
+		}
+		return nil, err
+	}
+
+	return toCashFlow(row), nil
+}
+
+// Confirm turns a planned cash flow into a realized one with its actual date,
+// amount and conversion.
+func (r *CashFlowRepository) Confirm(ctx context.Context, id int32, date time.Time, amount money.Amount, conversion *cashflow.Conversion) (*cashflow.CashFlow, error) {
+	params := sqlc.ConfirmCashFlowParams{
+		CashFlowID: id,
+		Date:       pgtype.Date{Time: date, Valid: true},
+		Amount:     amount,
+	}
+	params.Currency, params.OriginalAmount, params.ExchangeRate, params.IofAmount = conversionParams(conversion)
+
+	row, err := queriesFor(ctx, r.q).ConfirmCashFlow(ctx, params)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, cashflow.ErrNotPlanned
+		}
+		return nil, err
+	}
+	return toCashFlow(row), nil
+}
+
+func (r *CashFlowRepository) Cancel(ctx context.Context, id int32) error {
+	rows, err := queriesFor(ctx, r.q).CancelCashFlow(ctx, id)
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return cashflow.ErrNotPlanned
+	}
+	return nil
+}
+
+// Delete removes the cash flow together with its plain expense details.
+// Callers are expected to have checked CountInstallmentLinks first.
+func (r *CashFlowRepository) Delete(ctx context.Context, id int32) error {
+	return r.WithinTx(ctx, func(ctx context.Context) error {
+		q := queriesFor(ctx, r.q)
+		if err := q.DeleteExpenseDetailsByCashFlow(ctx, id); err != nil {
+			return err
+		}
+		return q.DeleteCashFlow(ctx, id)
+	})
+}
+
+func (r *CashFlowRepository) SetAccount(ctx context.Context, id int32, accountID *int32) error {
+	rows, err := queriesFor(ctx, r.q).SetCashFlowAccount(ctx, sqlc.SetCashFlowAccountParams{
+		CashFlowID: id,
+		AccountID:  int4FromPtr(accountID),
+	})
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return cashflow.ErrCashFlowNotFound
+	}
+	return nil
+}
+
+func (r *CashFlowRepository) ListDuplicateCandidates(ctx context.Context, direction string, amount money.Amount, from, to time.Time) ([]cashflow.DuplicateCandidate, error) {
+	rows, err := queriesFor(ctx, r.q).ListDuplicateCandidates(ctx, sqlc.ListDuplicateCandidatesParams{
+		Direction: direction,
+		Amount:    amount,
+		DateFrom:  pgtype.Date{Time: from, Valid: true},
+		DateTo:    pgtype.Date{Time: to, Valid: true},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	candidates := make([]cashflow.DuplicateCandidate, len(rows))
+	for i, row := range rows {
+		candidates[i] = toDuplicateCandidate(sqlc.ListDuplicateSuspectsRow(row))
+	}
+	return candidates, nil
+}
+
+func (r *CashFlowRepository) ListDuplicateSuspects(ctx context.Context, from, to *time.Time, days int) ([]cashflow.DuplicateCandidate, error) {
+	rows, err := queriesFor(ctx, r.q).ListDuplicateSuspects(ctx, sqlc.ListDuplicateSuspectsParams{
+		DateFrom: dateFromPtr(from),
+		DateTo:   dateFromPtr(to),
+		Days:     int32(days),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	candidates := make([]cashflow.DuplicateCandidate, len(rows))
+	for i, row := range rows {
+		candidates[i] = toDuplicateCandidate(row)
+	}
+	return candidates, nil
+}
+
+func (r *CashFlowRepository) AddTags(ctx context.Context, id int32, tagIDs []int32) error {
+	q := queriesFor(ctx, r.q)
+	for _, tagID := range tagIDs {
+		if err := q.AddCashFlowTag(ctx, sqlc.AddCashFlowTagParams{CashFlowID: id, TagID: tagID}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *CashFlowRepository) SetPayee(ctx context.Context, id int32, payeeID *int32) error {
+	rows, err := queriesFor(ctx, r.q).SetCashFlowPayee(ctx, sqlc.SetCashFlowPayeeParams{
+		CashFlowID: id,
+		PayeeID:    int4FromPtr(payeeID),
+	})
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return cashflow.ErrCashFlowNotFound
+	}
+	return nil
+}
+
+// GetPayee returns a payee without its aliases; nil when it does not exist.
+func (r *CashFlowRepository) GetPayee(ctx context.Context, id int32) (*payee.Payee, error) {
+	row, err := queriesFor(ctx, r.q).GetPayee(ctx, id)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return toPayee(row), nil
+}
+
+func (r *CashFlowRepository) MatchPayee(ctx context.Context, title string) (*payee.Payee, error) {
+	id, err := matchPayee(ctx, queriesFor(ctx, r.q), title)
+	if err != nil || id == nil {
+		return nil, err
+	}
+	return r.GetPayee(ctx, *id)
+}
+
+func (r *CashFlowRepository) AccountExists(ctx context.Context, id int32) (bool, error) {
+	return queriesFor(ctx, r.q).AccountExists(ctx, id)
+}
+
+// PaymentMethodAccount returns the account a payment method moves money
+// from. Credit cards have none: their flows hit an account only when the
+// invoice is paid.
+func (r *CashFlowRepository) PaymentMethodAccount(ctx context.Context, paymentMethodID int32) (*int32, error) {
+	accountID, err := queriesFor(ctx, r.q).GetPaymentMethodAccount(ctx, paymentMethodID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return int4ToPtr(accountID), nil
+}
+
+func (r *CashFlowRepository) CountInstallmentLinks(ctx context.Context, id int32) (int64, error) {
+	return queriesFor(ctx, r.q).CountCashFlowInstallmentLinks(ctx, id)
+}
+
+func (r *CashFlowRepository) IsTransferLeg(ctx context.Context, id int32) (bool, error) {
+	return queriesFor(ctx, r.q).IsTransferLeg(ctx, id)
+}
+
+func (r *CashFlowRepository) CreateRevision(ctx context.Context, original *cashflow.CashFlow, action string) error {
+	currency, originalAmount, rate, iof := conversionParams(original.Conversion)
+	return queriesFor(ctx, r.q).CreateCashFlowRevision(ctx, sqlc.CreateCashFlowRevisionParams{
+		CashFlowID:     original.ID,
+		Action:         action,
+		Date:           pgtype.Date{Time: original.Date, Valid: true},
+		CategoryID:     original.CategoryID,
+		Direction:      original.Direction,
+		Title:          original.Title,
+		Amount:         original.Amount,
+		IsFixed:        original.IsFixed,
+		Currency:       currency,
+		OriginalAmount: originalAmount,
+		ExchangeRate:   rate,
+		IofAmount:      iof,
+		Status:         original.Status,
+	})
+}
+
+func (r *CashFlowRepository) ListRevisions(ctx context.Context, id int32) ([]cashflow.Revision, error) {
+	rows, err := queriesFor(ctx, r.q).ListCashFlowRevisions(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	revisions := make([]cashflow.Revision, len(rows))
+	for i, row := range rows {
+		revisions[i] = cashflow.Revision{
+			ID:         row.CashFlowRevisionID,
+			CashFlowID: row.CashFlowID,
+			Action:     row.Action,
+			Date:       row.Date.Time,
+			CategoryID: row.CategoryID,
+			Direction:  row.Direction,
+			Title:      row.Title,
+			Amount:     row.Amount,
+			IsFixed:    row.IsFixed,
+			Status:     row.Status,
+			Conversion: toConversion(row.Currency, row.OriginalAmount, row.ExchangeRate, row.IofAmount),
+			RevisedAt:  row.RevisedAt.Time,
+		}
+	}
+	return revisions, nil
+}
+
+func (r *CashFlowRepository) ExchangeRate(ctx context.Context, currency string, date time.Time) (*float64, error) {
+	row, err := queriesFor(ctx, r.q).GetEffectiveExchangeRate(ctx, sqlc.GetEffectiveExchangeRateParams{
+		Currency: currency,
+		RateDate: pgtype.Date{Time: date, Valid: true},
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return numericToPtr(row.Rate), nil
+}
+
+func toCashFlow(row sqlc.CashFlow) *cashflow.CashFlow {
+	return &cashflow.CashFlow{
+		ID:               row.CashFlowID,
+		Date:             row.Date.Time,
+		CategoryID:       row.CategoryID,
+		Direction:        row.Direction,
+		Title:            row.Title,
+		Amount:           row.Amount,
+		IsFixed:          row.IsFixed,
+		FITID:            row.Fitid.String,
+		ExternalAccount:  row.ExternalAccount.String,
+		SourceCashFlowID: int4ToPtr(row.SourceCashFlowID),
+		AccountID:        int4ToPtr(row.AccountID),
+		ClearedAt:        timestampToPtr(row.ClearedAt),
+		ReconciliationID: int4ToPtr(row.ReconciliationID),
+		Status:           row.Status,
+		Conversion:       toConversion(row.Currency, row.OriginalAmount, row.ExchangeRate, row.IofAmount),
+		PayeeID:          int4ToPtr(row.PayeeID),
+	}
+}
+
+func toDuplicateCandidate(row sqlc.ListDuplicateSuspectsRow) cashflow.DuplicateCandidate {
+	return cashflow.DuplicateCandidate{
+		ID:              row.CashFlowID,
+		Date:            row.Date.Time,
+		CategoryID:      row.CategoryID,
+		CategoryName:    row.CategoryName,
+		Direction:       row.Direction,
+		Title:           row.Title,
+		Amount:          row.Amount,
+		PayeeID:         int4ToPtr(row.PayeeID),
+		PaymentMethodID: int4ToPtr(row.PaymentMethodID),
+		Status:          row.Status,
+	}
+}
+
+// conversionParams splits a conversion into its columns; all NULL without
+// one.
+func conversionParams(c *cashflow.Conversion) (pgtype.Text, *money.Amount, pgtype.Numeric, *money.Amount) {
+	if c == nil {
+		return pgtype.Text{}, nil, pgtype.Numeric{}, nil
+	}
+	original, iof := c.OriginalAmount, c.IOFAmount
+	return pgtype.Text{String: c.Currency, Valid: true}, &original, rateFromValue(c.Rate), &iof
+}
+
+func toConversion(currency pgtype.Text, original *money.Amount, rate pgtype.Numeric, iof *money.Amount) *cashflow.Conversion {
+	if !currency.Valid || original == nil {
+		return nil
+	}
+	return &cashflow.Conversion{
+		Currency:       currency.String,
+		OriginalAmount: *original,
+		Rate:           *numericToPtr(rate),
+		IOFAmount:      amountFromPtr(iof),
+	}
+}
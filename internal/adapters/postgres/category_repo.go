@@ -39,6 +39,7 @@ func (r *CategoryRepository) Create(ctx context.Context, c *category.Category) (
 		Direction:        c.Direction,
 		IsBudgetRelevant: c.IsBudgetRelevant,
 		IsActive:         c.IsActive,
+		Role:             roleOrRegular(c.Role),
 	}
 
 	row, err := r.q.CreateCategory(ctx, params)
@@ -53,6 +54,7 @@ func (r *CategoryRepository) Create(ctx context.Context, c *category.Category) (
 		IsBudgetRelevant: row.IsBudgetRelevant,
 		IsActive:         row.IsActive,
 		InactiveFromMonth: toTimePtr(row.InactiveFromMonth),
+		Role:             row.Role,
 	}, nil
 }
 
@@ -76,6 +78,7 @@ func (r *CategoryRepository) List(ctx context.Context, activeOnly bool) ([]*cate
 			IsBudgetRelevant: row.IsBudgetRelevant,
 			IsActive:         row.IsActive,
 			InactiveFromMonth: toTimePtr(row.InactiveFromMonth),
+			Role:             row.Role,
 		}
 	}
 	return cats, nil
@@ -100,6 +103,7 @@ func (r *CategoryRepository) ListByMonth(ctx context.Context, activeOnly bool, m
 			IsBudgetRelevant: row.IsBudgetRelevant,
 			IsActive:         row.IsActive,
 			InactiveFromMonth: toTimePtr(row.InactiveFromMonth),
+			Role:             row.Role,
 		}
 	}
 	return cats, nil
@@ -121,6 +125,7 @@ func (r *CategoryRepository) GetByID(ctx context.Context, id int32) (*category.C
 		IsBudgetRelevant: row.IsBudgetRelevant,
 		IsActive:         row.IsActive,
 		InactiveFromMonth: toTimePtr(row.InactiveFromMonth),
+		Role:             row.Role,
 	}, nil
 }
 
@@ -131,6 +136,7 @@ func (r *CategoryRepository) Update(ctx context.Context, c *category.Category) (
 		Direction:        c.Direction,
 		IsBudgetRelevant: c.IsBudgetRelevant,
 		IsActive:         c.IsActive,
+		Role:             roleOrRegular(c.Role),
 	}
 
 	row, err := r.q.UpdateCategory(ctx, params)
@@ -148,6 +154,7 @@ func (r *CategoryRepository) Update(ctx context.Context, c *category.Category) (
 		IsBudgetRelevant: row.IsBudgetRelevant,
 		IsActive:         row.IsActive,
 		InactiveFromMonth: toTimePtr(row.InactiveFromMonth),
+		Role:             row.Role,
 	}, nil
 }
 
@@ -171,9 +178,17 @@ func (r *CategoryRepository) Deactivate(ctx context.Context, id int32, inactiveF
 		IsBudgetRelevant: row.IsBudgetRelevant,
 		IsActive:         row.IsActive,
 		InactiveFromMonth: toTimePtr(row.InactiveFromMonth),
+		Role:             row.Role,
 	}, nil
 }
 
+func roleOrRegular(role string) string {
+	if role == "" {
+		return category.RoleRegular
+	}
+	return role
+}
+
 func toTimePtr(value pgtype.Date) *time.Time {
 	if !value.Valid {
 		return nil
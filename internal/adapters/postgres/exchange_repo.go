@@ -0,0 +1,96 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/adapters/postgres/sqlc"
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/domain/exchange"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type ExchangeRepository struct {
+	db *pgxpool.Pool
+	q  *sqlc.Queries
+}
+
+func NewExchangeRepository(db *pgxpool.Pool) *ExchangeRepository {
+	return &ExchangeRepository{
+		db: db,
+		q:  sqlc.New(db),
+	}
+}
+
+func (r *ExchangeRepository) WithinTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	return withinTx(ctx, r.db, fn)
+}
+
+func (r *ExchangeRepository) Upsert(ctx context.Context, rate *exchange.Rate) (*exchange.Rate, error) {
+	row, err := queriesFor(ctx, r.q).UpsertExchangeRate(ctx, sqlc.UpsertExchangeRateParams{
+		Currency: rate.Currency,
+		RateDate: pgtype.Date{Time: rate.Date, Valid: true},
+		Rate:     rateFromValue(rate.Rate),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return toExchangeRate(row), nil
+}
+
+func (r *ExchangeRepository) List(ctx context.Context, currency string) ([]exchange.Rate, error) {
+	rows, err := queriesFor(ctx, r.q).ListExchangeRates(ctx, pgtype.Text{String: currency, Valid: currency != ""})
+	if err != nil {
+		return nil, err
+	}
+
+	rates := make([]exchange.Rate, len(rows))
+	for i, row := range rows {
+		rates[i] = *toExchangeRate(row)
+	}
+	return rates, nil
+}
+
+func (r *ExchangeRepository) Effective(ctx context.Context, currency string, date time.Time) (*exchange.Rate, error) {
+	row, err := queriesFor(ctx, r.q).GetEffectiveExchangeRate(ctx, sqlc.GetEffectiveExchangeRateParams{
+		Currency: currency,
+		RateDate: pgtype.Date{Time: date, Valid: true},
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return toExchangeRate(row), nil
+}
+
+func (r *ExchangeRepository) Delete(ctx context.Context, id int32) (bool, error) {
+	n, err := queriesFor(ctx, r.q).DeleteExchangeRate(ctx, id)
+	if err != nil {
+		return false, err
+	}
+	return n > 0, nil
+}
+
+func toExchangeRate(row sqlc.ExchangeRate) *exchange.Rate {
+	rate, _ := row.Rate.Float64Value()
+	return &exchange.Rate{
+		ID:        row.ExchangeRateID,
+		Currency:  row.Currency,
+		Date:      row.RateDate.Time,
+		Rate:      rate.Float64,
+		CreatedAt: row.CreatedAt.Time,
+	}
+}
+
+// rateFromValue keeps the 8 decimals of exchange rate columns; numericFromValue
+// rounds to cents.
+func rateFromValue(value float64) pgtype.Numeric {
+	var out pgtype.Numeric
+	out.Scan(strconv.FormatFloat(value, 'f', 8, 64))
+	return out
+}
@@ -0,0 +1,160 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/adapters/postgres/sqlc"
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/domain/importer"
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/money"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type ImportRepository struct {
+	db *pgxpool.Pool
+	q  *sqlc.Queries
+}
+
+func NewImportRepository(db *pgxpool.Pool) *ImportRepository {
+	return &ImportRepository{
+		db: db,
+		q:  sqlc.New(db),
+	}
+}
+
+func (r *ImportRepository) WithinTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	return withinTx(ctx, r.db, fn)
+}
+
+func (r *ImportRepository) CreateProfile(ctx context.Context, p *importer.Profile) (*importer.Profile, error) {
+	row, err := queriesFor(ctx, r.q).CreateImportProfile(ctx, sqlc.CreateImportProfileParams{
+		Name:           p.Name,
+		Delimiter:      p.Delimiter,
+		HasHeader:      p.HasHeader,
+		DateColumn:     p.DateColumn,
+		DateFormat:     p.DateFormat,
+		TitleColumn:    p.TitleColumn,
+		AmountColumn:   p.AmountColumn,
+		DecimalComma:   p.DecimalComma,
+		SignConvention: p.SignConvention,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return toImportProfile(row), nil
+}
+
+func (r *ImportRepository) ListProfiles(ctx context.Context) ([]importer.Profile, error) {
+	rows, err := queriesFor(ctx, r.q).ListImportProfiles(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	profiles := make([]importer.Profile, len(rows))
+	for i, row := range rows {
+		profiles[i] = *toImportProfile(row)
+	}
+	return profiles, nil
+}
+
+func (r *ImportRepository) GetProfile(ctx context.Context, id int32) (*importer.Profile, error) {
+	row, err := queriesFor(ctx, r.q).GetImportProfile(ctx, id)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return toImportProfile(row), nil
+}
+
+func (r *ImportRepository) UpdateProfile(ctx context.Context, p *importer.Profile) (*importer.Profile, error) {
+	row, err := queriesFor(ctx, r.q).UpdateImportProfile(ctx, sqlc.UpdateImportProfileParams{
+		ImportProfileID: p.ID,
+		Name:            p.Name,
+		Delimiter:       p.Delimiter,
+		HasHeader:       p.HasHeader,
+		DateColumn:      p.DateColumn,
+		DateFormat:      p.DateFormat,
+		TitleColumn:     p.TitleColumn,
+		AmountColumn:    p.AmountColumn,
+		DecimalComma:    p.DecimalComma,
+		SignConvention:  p.SignConvention,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, importer.ErrProfileNotFound
+		}
+		return nil, err
+	}
+	return toImportProfile(row), nil
+}
+
+func (r *ImportRepository) DeleteProfile(ctx context.Context, id int32) error {
+	return queriesFor(ctx, r.q).DeleteImportProfile(ctx, id)
+}
+
+func (r *ImportRepository) SuggestCategory(ctx context.Context, title, direction string) (*importer.CategorySuggestion, error) {
+	row, err := queriesFor(ctx, r.q).SuggestCategoryForTitle(ctx, sqlc.SuggestCategoryForTitleParams{
+		Title:     title,
+		Direction: direction,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &importer.CategorySuggestion{
+		CategoryID:   row.CategoryID,
+		CategoryName: row.Name,
+	}, nil
+}
+
+func (r *ImportRepository) FindDuplicate(ctx context.Context, date time.Time, direction string, amount money.Amount) (*int32, error) {
+
+	id, err := queriesFor(ctx, r.q).FindDuplicateCashFlow(ctx, sqlc.FindDuplicateCashFlowParams{
+		Date:      pgtype.Date{Time: date, Valid: true},
+		Direction: direction,
+		Amount:    amount,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &id, nil
+}
+
+func (r *ImportRepository) FindByFITID(ctx context.Context, externalAccount, fitid string) (*int32, error) {
+	id, err := queriesFor(ctx, r.q).GetCashFlowByFITID(ctx, sqlc.GetCashFlowByFITIDParams{
+		ExternalAccount: externalAccount,
+		Fitid:           fitid,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &id, nil
+}
+
+func toImportProfile(row sqlc.ImportProfile) *importer.Profile {
+	return &importer.Profile{
+		ID:             row.ImportProfileID,
+		Name:           row.Name,
+		Delimiter:      row.Delimiter,
+		HasHeader:      row.HasHeader,
+		DateColumn:     row.DateColumn,
+		DateFormat:     row.DateFormat,
+		TitleColumn:    row.TitleColumn,
+		AmountColumn:   row.AmountColumn,
+		DecimalComma:   row.DecimalComma,
+		SignConvention: row.SignConvention,
+	}
+}
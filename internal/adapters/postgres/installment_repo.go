@@ -2,7 +2,6 @@ package postgres
 
 import (
 	"context"
-	"fmt"
 
 	"github.com/LucasSiedschlag/HausHaltsMeister/internal/adapters/postgres/sqlc"
 	"github.com/LucasSiedschlag/HausHaltsMeister/internal/domain/installment"
@@ -22,26 +21,29 @@ func NewInstallmentRepository(db *pgxpool.Pool) *InstallmentRepository {
 
 func (r *InstallmentRepository) CreatePlan(ctx context.Context, plan *installment.InstallmentPlan) (*installment.InstallmentPlan, error) {
 	pgDate := pgtype.Date{Time: plan.StartMonth, Valid: true}
-	var total, instAmount pgtype.Numeric
-	total.Scan(fmt.Sprintf("%.2f", plan.TotalAmount))
-	instAmount.Scan(fmt.Sprintf("%.2f", plan.InstallmentAmount))
 	pmID := pgtype.Int4{Int32: plan.PaymentMethodID, Valid: true}
 
-	row, err := r.q.CreateInstallmentPlan(ctx, sqlc.CreateInstallmentPlanParams{
+	params := sqlc.CreateInstallmentPlanParams{
 		Description:            plan.Description,
-		TotalAmount:            total,
+		TotalAmount:            plan.TotalAmount,
 		InstallmentCount:       plan.InstallmentCount,
-		InstallmentAmount:      instAmount,
+		InstallmentAmount:      &plan.InstallmentAmount,
 		StartDate:              pgDate,
 		PaymentMethodID:        pmID,
 		StartsOnCurrentInvoice: true, // Default for now
-	})
+	}
+	if c := plan.Conversion; c != nil {
+		params.Currency = pgtype.Text{String: c.Currency, Valid: true}
+		params.OriginalAmount = &c.OriginalAmount
+		params.ExchangeRate = rateFromValue(c.Rate)
+		params.IofAmount = &c.IOFAmount
+	}
+
+	row, err := r.q.CreateInstallmentPlan(ctx, params)
 	if err != nil {
 		return nil, err
 	}
 
-	tVal, _ := row.TotalAmount.Float64Value()
-	iVal, _ := row.InstallmentAmount.Float64Value()
 	methodID := int32(0)
 	if row.PaymentMethodID.Valid {
 		methodID = row.PaymentMethodID.Int32
@@ -50,11 +52,12 @@ func (r *InstallmentRepository) CreatePlan(ctx context.Context, plan *installmen
 	return &installment.InstallmentPlan{
 		ID:                row.InstallmentPlanID,
 		Description:       row.Description,
-		TotalAmount:       tVal.Float64,
+		TotalAmount:       row.TotalAmount,
 		InstallmentCount:  row.InstallmentCount,
-		InstallmentAmount: iVal.Float64,
+		InstallmentAmount: amountFromPtr(row.InstallmentAmount),
 		StartMonth:        row.StartDate.Time,
 		PaymentMethodID:   methodID,
+		Conversion:        toConversion(row.Currency, row.OriginalAmount, row.ExchangeRate, row.IofAmount),
 	}, nil
 }
 
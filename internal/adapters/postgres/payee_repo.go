@@ -0,0 +1,240 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/adapters/postgres/sqlc"
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/domain/payee"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type PayeeRepository struct {
+	db *pgxpool.Pool
+	q  *sqlc.Queries
+}
+
+func NewPayeeRepository(db *pgxpool.Pool) *PayeeRepository {
+	return &PayeeRepository{
+		db: db,
+		q:  sqlc.New(db),
+	}
+}
+
+func (r *PayeeRepository) WithinTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	return withinTx(ctx, r.db, fn)
+}
+
+func (r *PayeeRepository) Create(ctx context.Context, p *payee.Payee) (*payee.Payee, error) {
+	var created *payee.Payee
+	err := r.WithinTx(ctx, func(ctx context.Context) error {
+		row, err := queriesFor(ctx, r.q).CreatePayee(ctx, sqlc.CreatePayeeParams{
+			Name:                   p.Name,
+			NormalizedName:         payee.Normalize(p.Name),
+			DefaultCategoryID:      int4FromPtr(p.DefaultCategoryID),
+			DefaultPaymentMethodID: int4FromPtr(p.DefaultPaymentMethodID),
+		})
+		if err != nil {
+			return err
+		}
+		created = toPayee(row)
+		created.Aliases, err = r.addAliases(ctx, created.ID, p.Aliases)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return created, nil
+}
+
+func (r *PayeeRepository) List(ctx context.Context) ([]payee.Payee, error) {
+	q := queriesFor(ctx, r.q)
+	rows, err := q.ListPayees(ctx)
+	if err != nil {
+		return nil, err
+	}
+	aliases, err := q.ListPayeeAliases(ctx, pgtype.Int4{})
+	if err != nil {
+		return nil, err
+	}
+	byPayee := make(map[int32][]string)
+	for _, a := range aliases {
+		byPayee[a.PayeeID] = append(byPayee[a.PayeeID], a.Alias)
+	}
+
+	payees := make([]payee.Payee, len(rows))
+	for i, row := range rows {
+		payees[i] = payee.Payee{
+			ID:                     row.PayeeID,
+			Name:                   row.Name,
+			DefaultCategoryID:      int4ToPtr(row.DefaultCategoryID),
+			DefaultPaymentMethodID: int4ToPtr(row.DefaultPaymentMethodID),
+			Aliases:                byPayee[row.PayeeID],
+			CashFlowCount:          row.CashFlowCount,
+		}
+	}
+	return payees, nil
+}
+
+func (r *PayeeRepository) GetByID(ctx context.Context, id int32) (*payee.Payee, error) {
+	q := queriesFor(ctx, r.q)
+	row, err := q.GetPayee(ctx, id)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	p := toPayee(row)
+
+	aliases, err := q.ListPayeeAliases(ctx, pgtype.Int4{Int32: id, Valid: true})
+	if err != nil {
+		return nil, err
+	}
+	for _, a := range aliases {
+		p.Aliases = append(p.Aliases, a.Alias)
+	}
+	return p, nil
+}
+
+// Update also replaces the aliases.
+func (r *PayeeRepository) Update(ctx context.Context, p *payee.Payee) (*payee.Payee, error) {
+	var updated *payee.Payee
+	err := r.WithinTx(ctx, func(ctx context.Context) error {
+		q := queriesFor(ctx, r.q)
+		row, err := q.UpdatePayee(ctx, sqlc.UpdatePayeeParams{
+			PayeeID:                p.ID,
+			Name:                   p.Name,
+			NormalizedName:         payee.Normalize(p.Name),
+			DefaultCategoryID:      int4FromPtr(p.DefaultCategoryID),
+			DefaultPaymentMethodID: int4FromPtr(p.DefaultPaymentMethodID),
+		})
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return payee.ErrPayeeNotFound
+			}
+			return err
+		}
+		if err := q.DeletePayeeAliases(ctx, p.ID); err != nil {
+			return err
+		}
+		updated = toPayee(row)
+		updated.Aliases, err = r.addAliases(ctx, p.ID, p.Aliases)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return updated, nil
+}
+
+func (r *PayeeRepository) Delete(ctx context.Context, id int32) error {
+	return queriesFor(ctx, r.q).DeletePayee(ctx, id)
+}
+
+func (r *PayeeRepository) FindByKey(ctx context.Context, key string) (*int32, error) {
+	id, err := queriesFor(ctx, r.q).FindPayeeByKey(ctx, key)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &id, nil
+}
+
+func (r *PayeeRepository) Match(ctx context.Context, title string) (*int32, error) {
+	return matchPayee(ctx, queriesFor(ctx, r.q), title)
+}
+
+func (r *PayeeRepository) ReassignCashFlows(ctx context.Context, fromID, toID int32) (int64, error) {
+	return queriesFor(ctx, r.q).ReassignPayeeCashFlows(ctx, sqlc.ReassignPayeeCashFlowsParams{
+		ToPayeeID:   toID,
+		FromPayeeID: fromID,
+	})
+}
+
+func (r *PayeeRepository) Report(ctx context.Context, id int32, from, to *time.Time) ([]payee.ReportRow, error) {
+	rows, err := queriesFor(ctx, r.q).GetPayeeReport(ctx, sqlc.GetPayeeReportParams{
+		PayeeID:  id,
+		DateFrom: dateFromPtr(from),
+		DateTo:   dateFromPtr(to),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	report := make([]payee.ReportRow, len(rows))
+	for i, row := range rows {
+		report[i] = payee.ReportRow{
+			Month:         row.Month.Time,
+			CategoryID:    row.CategoryID,
+			CategoryName:  row.CategoryName,
+			Direction:     row.Direction,
+			CashFlowCount: row.CashFlowCount,
+			TotalAmount:   row.TotalAmount,
+		}
+	}
+	return report, nil
+}
+
+func (r *PayeeRepository) Top(ctx context.Context, filter payee.TopFilter) ([]payee.Total, error) {
+	rows, err := queriesFor(ctx, r.q).ListTopPayees(ctx, sqlc.ListTopPayeesParams{
+		Direction: filter.Direction,
+		DateFrom:  dateFromPtr(filter.From),
+		DateTo:    dateFromPtr(filter.To),
+		PageLimit: filter.Limit,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	totals := make([]payee.Total, len(rows))
+	for i, row := range rows {
+		totals[i] = payee.Total{
+			PayeeID:       row.PayeeID,
+			Name:          row.Name,
+			CashFlowCount: row.CashFlowCount,
+			TotalAmount:   row.TotalAmount,
+		}
+	}
+	return totals, nil
+}
+
+func (r *PayeeRepository) addAliases(ctx context.Context, payeeID int32, aliases []string) ([]string, error) {
+	q := queriesFor(ctx, r.q)
+	for _, alias := range aliases {
+		if err := q.AddPayeeAlias(ctx, sqlc.AddPayeeAliasParams{
+			PayeeID:         payeeID,
+			Alias:           alias,
+			NormalizedAlias: payee.Normalize(alias),
+		}); err != nil {
+			return nil, err
+		}
+	}
+	return aliases, nil
+}
+
+// matchPayee looks up the payee of a title; cash flows use it too.
+func matchPayee(ctx context.Context, q *sqlc.Queries, title string) (*int32, error) {
+	id, err := q.MatchPayee(ctx, payee.Normalize(title))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &id, nil
+}
+
+func toPayee(row sqlc.Payee) *payee.Payee {
+	return &payee.Payee{
+		ID:                     row.PayeeID,
+		Name:                   row.Name,
+		DefaultCategoryID:      int4ToPtr(row.DefaultCategoryID),
+		DefaultPaymentMethodID: int4ToPtr(row.DefaultPaymentMethodID),
+	}
+}
@@ -3,11 +3,11 @@ package postgres
 import (
 	"context"
 	"errors"
-	"fmt"
 	"time"
 
 	"github.com/LucasSiedschlag/HausHaltsMeister/internal/adapters/postgres/sqlc"
 	"github.com/LucasSiedschlag/HausHaltsMeister/internal/domain/payment"
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/money"
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/jackc/pgx/v5/pgxpool"
@@ -26,10 +26,6 @@ func NewPaymentRepository(db *pgxpool.Pool) *PaymentRepository {
 func (r *PaymentRepository) Create(ctx context.Context, m *payment.PaymentMethod) (*payment.PaymentMethod, error) {
 	// Nullable handling
 	bank := pgtype.Text{String: m.BankName, Valid: m.BankName != ""}
-	limit := pgtype.Numeric{Valid: false}
-	if m.CreditLimit != nil {
-		limit.Scan(fmt.Sprintf("%.2f", *m.CreditLimit))
-	}
 	cDay := pgtype.Int4{Valid: false}
 	if m.ClosingDay != nil {
 		cDay = pgtype.Int4{Int32: *m.ClosingDay, Valid: true}
@@ -43,7 +39,7 @@ func (r *PaymentRepository) Create(ctx context.Context, m *payment.PaymentMethod
 		Name:        m.Name,
 		Kind:        m.Kind,
 		BankName:    bank,
-		CreditLimit: limit,
+		CreditLimit: m.CreditLimit,
 		ClosingDay:  cDay,
 		DueDay:      dDay,
 		IsActive:    m.IsActive,
@@ -53,12 +49,6 @@ func (r *PaymentRepository) Create(ctx context.Context, m *payment.PaymentMethod
 	}
 
 	var closing, due *int32
-	var creditLimit *float64
-	if row.CreditLimit.Valid {
-		limitVal, _ := row.CreditLimit.Float64Value()
-		value := limitVal.Float64
-		creditLimit = &value
-	}
 	if row.ClosingDay.Valid {
 		closing = &row.ClosingDay.Int32
 	}
@@ -71,19 +61,16 @@ func (r *PaymentRepository) Create(ctx context.Context, m *payment.PaymentMethod
 		Name:        row.Name,
 		Kind:        row.Kind,
 		BankName:    row.BankName.String,
-		CreditLimit: creditLimit,
+		CreditLimit: row.CreditLimit,
 		ClosingDay:  closing,
 		DueDay:      due,
 		IsActive:    row.IsActive,
+		AccountID:   int4ToPtr(row.AccountID),
 	}, nil
 }
 
 func (r *PaymentRepository) Update(ctx context.Context, m *payment.PaymentMethod) (*payment.PaymentMethod, error) {
 	bank := pgtype.Text{String: m.BankName, Valid: m.BankName != ""}
-	limit := pgtype.Numeric{Valid: false}
-	if m.CreditLimit != nil {
-		limit.Scan(fmt.Sprintf("%.2f", *m.CreditLimit))
-	}
 	cDay := pgtype.Int4{Valid: false}
 	if m.ClosingDay != nil {
 		cDay = pgtype.Int4{Int32: *m.ClosingDay, Valid: true}
@@ -98,7 +85,7 @@ func (r *PaymentRepository) Update(ctx context.Context, m *payment.PaymentMethod
 		Name:            m.Name,
 		Kind:            m.Kind,
 		BankName:        bank,
-		CreditLimit:     limit,
+		CreditLimit:     m.CreditLimit,
 		ClosingDay:      cDay,
 		DueDay:          dDay,
 		IsActive:        m.IsActive,
@@ -111,12 +98,6 @@ func (r *PaymentRepository) Update(ctx context.Context, m *payment.PaymentMethod
 	}
 
 	var closing, due *int32
-	var creditLimit *float64
-	if row.CreditLimit.Valid {
-		limitVal, _ := row.CreditLimit.Float64Value()
-		value := limitVal.Float64
-		creditLimit = &value
-	}
 	if row.ClosingDay.Valid {
 		closing = &row.ClosingDay.Int32
 	}
@@ -129,10 +110,11 @@ func (r *PaymentRepository) Update(ctx context.Context, m *payment.PaymentMethod
 		Name:        row.Name,
 		Kind:        row.Kind,
 		BankName:    row.BankName.String,
-		CreditLimit: creditLimit,
+		CreditLimit: row.CreditLimit,
 		ClosingDay:  closing,
 		DueDay:      due,
 		IsActive:    row.IsActive,
+		AccountID:   int4ToPtr(row.AccountID),
 	}, nil
 }
 
@@ -161,12 +143,6 @@ func (r *PaymentRepository) List(ctx context.Context, activeOnly bool) ([]paymen
 	methods := make([]payment.PaymentMethod, len(rows))
 	for i, row := range rows {
 		var closing, due *int32
-		var creditLimit *float64
-		if row.CreditLimit.Valid {
-			limitVal, _ := row.CreditLimit.Float64Value()
-			value := limitVal.Float64
-			creditLimit = &value
-		}
 		if row.ClosingDay.Valid {
 			closing = &row.ClosingDay.Int32
 		}
@@ -178,10 +154,11 @@ func (r *PaymentRepository) List(ctx context.Context, activeOnly bool) ([]paymen
 			Name:        row.Name,
 			Kind:        row.Kind,
 			BankName:    row.BankName.String,
-			CreditLimit: creditLimit,
+			CreditLimit: row.CreditLimit,
 			ClosingDay:  closing,
 			DueDay:      due,
 			IsActive:    row.IsActive,
+			AccountID:   int4ToPtr(row.AccountID),
 		}
 	}
 	return methods, nil
@@ -196,12 +173,6 @@ func (r *PaymentRepository) GetByID(ctx context.Context, id int32) (*payment.Pay
 		return nil, err
 	}
 	var closing, due *int32
-	var creditLimit *float64
-	if row.CreditLimit.Valid {
-		limitVal, _ := row.CreditLimit.Float64Value()
-		value := limitVal.Float64
-		creditLimit = &value
-	}
 	if row.ClosingDay.Valid {
 		closing = &row.ClosingDay.Int32
 	}
@@ -213,10 +184,11 @@ func (r *PaymentRepository) GetByID(ctx context.Context, id int32) (*payment.Pay
 		Name:        row.Name,
 		Kind:        row.Kind,
 		BankName:    row.BankName.String,
-		CreditLimit: creditLimit,
+		CreditLimit: row.CreditLimit,
 		ClosingDay:  closing,
 		DueDay:      due,
 		IsActive:    row.IsActive,
+		AccountID:   int4ToPtr(row.AccountID),
 	}, nil
 }
 
@@ -232,19 +204,21 @@ func (r *PaymentRepository) GetInvoiceEntries(ctx context.Context, paymentMethod
 
 	entries := make([]payment.InvoiceEntry, len(rows))
 	for i, row := range rows {
-		amt, _ := row.Amount.Float64Value()
 		entries[i] = payment.InvoiceEntry{
-			CashFlowID:   row.CashFlowID,
-			Date:         row.Date.Time,
-			Title:        row.Title,
-			Amount:       amt.Float64,
-			CategoryName: row.CategoryName,
+			CashFlowID:     row.CashFlowID,
+			Date:           row.Date.Time,
+			Title:          row.Title,
+			Amount:         row.Amount,
+			CategoryName:   row.CategoryName,
+			Currency:       row.Currency.String,
+			OriginalAmount: row.OriginalAmount,
+			IOFAmount:      row.IofAmount,
 		}
 	}
 	return entries, nil
 }
 
-func (r *PaymentRepository) GetOutstandingAmount(ctx context.Context, paymentMethodID int32, month time.Time) (float64, error) {
+func (r *PaymentRepository) GetOutstandingAmount(ctx context.Context, paymentMethodID int32, month time.Time) (money.Amount, error) {
 	pgDate := pgtype.Date{Time: month, Valid: true}
 	return r.q.GetOutstandingAmount(ctx, sqlc.GetOutstandingAmountParams{
 		PaymentMethodID: pgtype.Int4{Int32: paymentMethodID, Valid: true},
@@ -9,6 +9,7 @@ import (
 
 	"github.com/LucasSiedschlag/HausHaltsMeister/internal/adapters/postgres/sqlc"
 	"github.com/LucasSiedschlag/HausHaltsMeister/internal/domain/picuinha"
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/money"
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/jackc/pgx/v5/pgxpool"
@@ -96,16 +97,15 @@ func (r *PicuinhaRepository) CountCasesByPerson(ctx context.Context, personID in
 	return r.q.CountCasesByPerson(ctx, int4FromPtr(&personID))
 }
 
-func (r *PicuinhaRepository) GetBalance(ctx context.Context, personID int32) (float64, error) {
+func (r *PicuinhaRepository) GetBalance(ctx context.Context, personID int32) (money.Amount, error) {
 	bal, err := r.q.GetPersonBalance(ctx, int4FromPtr(&personID))
 	if err != nil {
 		if err == sql.ErrNoRows {
-			return 0, nil
+			return money.Amount{}, nil
 		}
-		return 0, err
+		return money.Amount{}, err
 	}
-	val, _ := bal.Float64Value()
-	return val.Float64, nil
+	return bal, nil
 }
 
 func (r *PicuinhaRepository) CreateCase(ctx context.Context, picCase *picuinha.Case) (*picuinha.Case, error) {
@@ -117,9 +117,9 @@ func (r *PicuinhaRepository) CreateCase(ctx context.Context, picCase *picuinha.C
 		PersonID:                 int4FromPtr(&picCase.PersonID),
 		Description:              picCase.Title,
 		PlanType:                 picCase.CaseType,
-		TotalAmount:              numericFromPtr(picCase.TotalAmount),
+		TotalAmount:              amountFromPtr(picCase.TotalAmount),
 		InstallmentCount:         count,
-		InstallmentAmount:        numericFromPtr(picCase.InstallmentAmount),
+		InstallmentAmount:        picCase.InstallmentAmount,
 		StartDate:                pgtype.Date{Time: picCase.StartDate, Valid: true},
 		PaymentMethodID:          int4FromPtr(picCase.PaymentMethodID),
 		CategoryID:               int4FromPtr(picCase.CategoryID),
@@ -144,9 +144,9 @@ func (r *PicuinhaRepository) UpdateCase(ctx context.Context, picCase *picuinha.C
 		PersonID:                 int4FromPtr(&picCase.PersonID),
 		Description:              picCase.Title,
 		PlanType:                 picCase.CaseType,
-		TotalAmount:              numericFromPtr(picCase.TotalAmount),
+		TotalAmount:              amountFromPtr(picCase.TotalAmount),
 		InstallmentCount:         count,
-		InstallmentAmount:        numericFromPtr(picCase.InstallmentAmount),
+		InstallmentAmount:        picCase.InstallmentAmount,
 		StartDate:                pgtype.Date{Time: picCase.StartDate, Valid: true},
 		PaymentMethodID:          int4FromPtr(picCase.PaymentMethodID),
 		CategoryID:               int4FromPtr(picCase.CategoryID),
@@ -197,8 +197,8 @@ func (r *PicuinhaRepository) CreateInstallment(ctx context.Context, installment
 		InstallmentPlanID: installment.CaseID,
 		InstallmentNumber: installment.InstallmentNumber,
 		DueDate:           pgtype.Date{Time: installment.DueDate, Valid: true},
-		Amount:            numericFromValue(installment.Amount),
-		ExtraAmount:       numericFromValue(installment.ExtraAmount),
+		Amount:            installment.Amount,
+		ExtraAmount:       installment.ExtraAmount,
 		IsPaid:            installment.IsPaid,
 		PaidAt:            timestampFromPtr(installment.PaidAt),
 	})
@@ -211,8 +211,8 @@ func (r *PicuinhaRepository) CreateInstallment(ctx context.Context, installment
 func (r *PicuinhaRepository) UpdateInstallment(ctx context.Context, installment *picuinha.CaseInstallment) (*picuinha.CaseInstallment, error) {
 	row, err := r.q.UpdatePicuinhaCaseInstallment(ctx, sqlc.UpdatePicuinhaCaseInstallmentParams{
 		InstallmentPlanItemID: installment.ID,
-		Amount:                numericFromValue(installment.Amount),
-		ExtraAmount:           numericFromValue(installment.ExtraAmount),
+		Amount:                installment.Amount,
+		ExtraAmount:           installment.ExtraAmount,
 		IsPaid:                installment.IsPaid,
 		PaidAt:                timestampFromPtr(installment.PaidAt),
 	})
@@ -265,9 +265,9 @@ func mapCaseRow(row sqlc.InstallmentPlan) *picuinha.Case {
 		PersonID:                 personID,
 		Title:                    row.Description,
 		CaseType:                 row.PlanType,
-		TotalAmount:              numericToPtr(row.TotalAmount),
+		TotalAmount:              &row.TotalAmount,
 		InstallmentCount:         installmentCountPtr,
-		InstallmentAmount:        numericToPtr(row.InstallmentAmount),
+		InstallmentAmount:        row.InstallmentAmount,
 		StartDate:                row.StartDate.Time,
 		PaymentMethodID:          int4ToPtr(row.PaymentMethodID),
 		InstallmentPlanID:        planID,
@@ -295,9 +295,9 @@ func mapCaseSummaryRow(row sqlc.ListPicuinhaCasesByPersonRow) picuinha.CaseSumma
 		PersonID:                 personID,
 		Title:                    row.Description,
 		CaseType:                 row.PlanType,
-		TotalAmount:              numericToPtr(row.TotalAmount),
+		TotalAmount:              &row.TotalAmount,
 		InstallmentCount:         &installmentCount,
-		InstallmentAmount:        numericToPtr(row.InstallmentAmount),
+		InstallmentAmount:        row.InstallmentAmount,
 		StartDate:                row.StartDate.Time,
 		PaymentMethodID:          int4ToPtr(row.PaymentMethodID),
 		InstallmentPlanID:        planID,
@@ -319,8 +319,8 @@ func mapCaseSummaryRow(row sqlc.ListPicuinhaCasesByPersonRow) picuinha.CaseSumma
 		Case:              caseData,
 		InstallmentsTotal: total,
 		InstallmentsPaid:  paid,
-		AmountPaid:        numericToValue(row.AmountPaid),
-		AmountRemaining:   numericToValue(row.AmountRemaining),
+		AmountPaid:        row.AmountPaid,
+		AmountRemaining:   row.AmountRemaining,
 		Status:            status,
 	}
 }
@@ -335,8 +335,8 @@ func mapCaseInstallmentRow(row sqlc.InstallmentPlanItem) *picuinha.CaseInstallme
 		CaseID:            row.InstallmentPlanID,
 		InstallmentNumber: row.InstallmentNumber,
 		DueDate:           row.DueDate.Time,
-		Amount:            numericToValue(row.Amount),
-		ExtraAmount:       numericToValue(row.ExtraAmount),
+		Amount:            row.Amount,
+		ExtraAmount:       row.ExtraAmount,
 		IsPaid:            row.IsPaid,
 		PaidAt:            paidAt,
 	}
@@ -363,9 +363,11 @@ func numericToPtr(value pgtype.Numeric) *float64 {
 	return &val.Float64
 }
 
-func numericToValue(value pgtype.Numeric) float64 {
-	val, _ := value.Float64Value()
-	return val.Float64
+func amountFromPtr(value *money.Amount) money.Amount {
+	if value == nil {
+		return money.Amount{}
+	}
+	return *value
 }
 
 func int4FromPtr(value *int32) pgtype.Int4 {
@@ -395,3 +397,10 @@ func timestampFromPtr(value *time.Time) pgtype.Timestamp {
 	}
 	return pgtype.Timestamp{Time: *value, Valid: true}
 }
+
+func timestampToPtr(value pgtype.Timestamp) *time.Time {
+	if !value.Valid {
+		return nil
+	}
+	return &value.Time
+}
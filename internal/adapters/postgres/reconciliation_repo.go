@@ -0,0 +1,188 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/adapters/postgres/sqlc"
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/domain/reconciliation"
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/money"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type ReconciliationRepository struct {
+	db *pgxpool.Pool
+	q  *sqlc.Queries
+}
+
+func NewReconciliationRepository(db *pgxpool.Pool) *ReconciliationRepository {
+	return &ReconciliationRepository{
+		db: db,
+		q:  sqlc.New(db),
+	}
+}
+
+func (r *ReconciliationRepository) WithinTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	return withinTx(ctx, r.db, fn)
+}
+
+func (r *ReconciliationRepository) Create(ctx context.Context, rec *reconciliation.Reconciliation) (*reconciliation.Reconciliation, error) {
+	row, err := queriesFor(ctx, r.q).CreateReconciliation(ctx, sqlc.CreateReconciliationParams{
+		AccountID:        int4FromPtr(rec.Owner.AccountID),
+		PaymentMethodID:  int4FromPtr(rec.Owner.PaymentMethodID),
+		StatementDate:    pgtype.Date{Time: rec.StatementDate, Valid: true},
+		StatementBalance: rec.StatementBalance,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return toReconciliation(row), nil
+}
+
+func (r *ReconciliationRepository) GetByID(ctx context.Context, id int32) (*reconciliation.Reconciliation, error) {
+	row, err := queriesFor(ctx, r.q).GetReconciliation(ctx, id)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return toReconciliation(row), nil
+}
+
+func (r *ReconciliationRepository) GetOpen(ctx context.Context, owner reconciliation.Owner) (*reconciliation.Reconciliation, error) {
+	row, err := queriesFor(ctx, r.q).GetOpenReconciliation(ctx, sqlc.GetOpenReconciliationParams{
+		AccountID:       int4FromPtr(owner.AccountID),
+		PaymentMethodID: int4FromPtr(owner.PaymentMethodID),
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return toReconciliation(row), nil
+}
+
+func (r *ReconciliationRepository) List(ctx context.Context, owner reconciliation.Owner) ([]reconciliation.Reconciliation, error) {
+	rows, err := queriesFor(ctx, r.q).ListReconciliations(ctx, sqlc.ListReconciliationsParams{
+		AccountID:       int4FromPtr(owner.AccountID),
+		PaymentMethodID: int4FromPtr(owner.PaymentMethodID),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]reconciliation.Reconciliation, len(rows))
+	for i, row := range rows {
+		items[i] = *toReconciliation(row)
+	}
+	return items, nil
+}
+
+func (r *ReconciliationRepository) Complete(ctx context.Context, id int32, clearedBalance money.Amount) (*reconciliation.Reconciliation, error) {
+	row, err := queriesFor(ctx, r.q).CompleteReconciliation(ctx, sqlc.CompleteReconciliationParams{
+		ReconciliationID: id,
+		ClearedBalance:   &clearedBalance,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, reconciliation.ErrReconciliationNotFound
+		}
+		return nil, err
+	}
+	return toReconciliation(row), nil
+}
+
+func (r *ReconciliationRepository) Delete(ctx context.Context, id int32) error {
+	return queriesFor(ctx, r.q).DeleteReconciliation(ctx, id)
+}
+
+func (r *ReconciliationRepository) ListCandidates(ctx context.Context, owner reconciliation.Owner, statementDate time.Time) ([]reconciliation.Item, error) {
+	rows, err := queriesFor(ctx, r.q).ListReconciliationCandidates(ctx, sqlc.ListReconciliationCandidatesParams{
+		StatementDate:   pgtype.Date{Time: statementDate, Valid: true},
+		AccountID:       int4FromPtr(owner.AccountID),
+		PaymentMethodID: int4FromPtr(owner.PaymentMethodID),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]reconciliation.Item, len(rows))
+	for i, row := range rows {
+		items[i] = toReconciliationItem(sqlc.ListReconciledCashFlowsRow(row))
+	}
+	return items, nil
+}
+
+func (r *ReconciliationRepository) ListLocked(ctx context.Context, id int32) ([]reconciliation.Item, error) {
+	rows, err := queriesFor(ctx, r.q).ListReconciledCashFlows(ctx, pgtype.Int4{Int32: id, Valid: true})
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]reconciliation.Item, len(rows))
+	for i, row := range rows {
+		items[i] = toReconciliationItem(row)
+	}
+	return items, nil
+}
+
+func (r *ReconciliationRepository) ClearedTotal(ctx context.Context, owner reconciliation.Owner, includeLocked bool, from *time.Time, statementDate time.Time) (money.Amount, error) {
+	return queriesFor(ctx, r.q).GetClearedTotal(ctx, sqlc.GetClearedTotalParams{
+		IncludeReconciled: includeLocked,
+		FromDate:          dateFromPtr(from),
+		AccountID:         int4FromPtr(owner.AccountID),
+		PaymentMethodID:   int4FromPtr(owner.PaymentMethodID),
+		StatementDate:     pgtype.Date{Time: statementDate, Valid: true},
+	})
+}
+
+func (r *ReconciliationRepository) SetCleared(ctx context.Context, cashFlowIDs []int32, cleared bool) error {
+	_, err := queriesFor(ctx, r.q).SetCashFlowsCleared(ctx, sqlc.SetCashFlowsClearedParams{
+		Cleared:     cleared,
+		CashFlowIds: cashFlowIDs,
+	})
+	return err
+}
+
+func (r *ReconciliationRepository) Lock(ctx context.Context, rec *reconciliation.Reconciliation) error {
+	_, err := queriesFor(ctx, r.q).LockClearedCashFlows(ctx, sqlc.LockClearedCashFlowsParams{
+		ReconciliationID: rec.ID,
+		StatementDate:    pgtype.Date{Time: rec.StatementDate, Valid: true},
+		AccountID:        int4FromPtr(rec.Owner.AccountID),
+		PaymentMethodID:  int4FromPtr(rec.Owner.PaymentMethodID),
+	})
+	return err
+}
+
+func toReconciliation(row sqlc.Reconciliation) *reconciliation.Reconciliation {
+	return &reconciliation.Reconciliation{
+		ID: row.ReconciliationID,
+		Owner: reconciliation.Owner{
+			AccountID:       int4ToPtr(row.AccountID),
+			PaymentMethodID: int4ToPtr(row.PaymentMethodID),
+		},
+		StatementDate:    row.StatementDate.Time,
+		StatementBalance: row.StatementBalance,
+		Status:           row.Status,
+		ClearedBalance:   amountFromPtr(row.ClearedBalance),
+		CreatedAt:        row.CreatedAt.Time,
+		CompletedAt:      timestampToPtr(row.CompletedAt),
+	}
+}
+
+func toReconciliationItem(row sqlc.ListReconciledCashFlowsRow) reconciliation.Item {
+	return reconciliation.Item{
+		CashFlowID:   row.CashFlowID,
+		Date:         row.Date.Time,
+		Direction:    row.Direction,
+		Title:        row.Title,
+		Amount:       row.Amount,
+		CategoryName: row.CategoryName,
+		ClearedAt:    timestampToPtr(row.ClearedAt),
+	}
+}
@@ -0,0 +1,172 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/adapters/postgres/sqlc"
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/domain/recurrence"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type RecurrenceRepository struct {
+	db *pgxpool.Pool
+	q  *sqlc.Queries
+}
+
+func NewRecurrenceRepository(db *pgxpool.Pool) *RecurrenceRepository {
+	return &RecurrenceRepository{
+		db: db,
+		q:  sqlc.New(db),
+	}
+}
+
+func (r *RecurrenceRepository) WithinTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	return withinTx(ctx, r.db, fn)
+}
+
+func (r *RecurrenceRepository) Create(ctx context.Context, rule *recurrence.Rule) (*recurrence.Rule, error) {
+	row, err := queriesFor(ctx, r.q).CreateRecurrenceRule(ctx, sqlc.CreateRecurrenceRuleParams{
+		Title:             rule.Title,
+		CategoryID:        rule.CategoryID,
+		Direction:         rule.Direction,
+		Amount:            rule.Amount,
+		IsFixed:           rule.IsFixed,
+		PaymentMethodID:   int4FromPtr(rule.PaymentMethodID),
+		Frequency:         rule.Frequency,
+		IntervalCount:     rule.Interval,
+		ByMonthDay:        int4FromPtr(rule.ByMonthDay),
+		BusinessDayAdjust: rule.BusinessDayAdjust,
+		StartDate:         pgtype.Date{Time: rule.StartDate, Valid: true},
+		EndDate:           dateFromPtr(rule.EndDate),
+		IsActive:          rule.IsActive,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return toRecurrenceRule(row), nil
+}
+
+func (r *RecurrenceRepository) List(ctx context.Context, activeOnly bool) ([]recurrence.Rule, error) {
+	var isActive pgtype.Bool
+	if activeOnly {
+		isActive = pgtype.Bool{Bool: true, Valid: true}
+	}
+
+	rows, err := queriesFor(ctx, r.q).ListRecurrenceRules(ctx, isActive)
+	if err != nil {
+		return nil, err
+	}
+
+	rules := make([]recurrence.Rule, len(rows))
+	for i, row := range rows {
+		rules[i] = *toRecurrenceRule(row)
+	}
+	return rules, nil
+}
+
+func (r *RecurrenceRepository) GetByID(ctx context.Context, id int32) (*recurrence.Rule, error) {
+	row, err := queriesFor(ctx, r.q).GetRecurrenceRule(ctx, id)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return toRecurrenceRule(row), nil
+}
+
+func (r *RecurrenceRepository) Update(ctx context.Context, rule *recurrence.Rule) (*recurrence.Rule, error) {
+	row, err := queriesFor(ctx, r.q).UpdateRecurrenceRule(ctx, sqlc.UpdateRecurrenceRuleParams{
+		RecurrenceRuleID:  rule.ID,
+		Title:             rule.Title,
+		CategoryID:        rule.CategoryID,
+		Direction:         rule.Direction,
+		Amount:            rule.Amount,
+		IsFixed:           rule.IsFixed,
+		PaymentMethodID:   int4FromPtr(rule.PaymentMethodID),
+		Frequency:         rule.Frequency,
+		IntervalCount:     rule.Interval,
+		ByMonthDay:        int4FromPtr(rule.ByMonthDay),
+		BusinessDayAdjust: rule.BusinessDayAdjust,
+		StartDate:         pgtype.Date{Time: rule.StartDate, Valid: true},
+		EndDate:           dateFromPtr(rule.EndDate),
+		IsActive:          rule.IsActive,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, recurrence.ErrRuleNotFound
+		}
+		return nil, err
+	}
+	return toRecurrenceRule(row), nil
+}
+
+func (r *RecurrenceRepository) Delete(ctx context.Context, id int32) error {
+	return queriesFor(ctx, r.q).DeleteRecurrenceRule(ctx, id)
+}
+
+func (r *RecurrenceRepository) ClaimOccurrence(ctx context.Context, ruleID int32, date time.Time) (bool, error) {
+	n, err := queriesFor(ctx, r.q).ClaimRecurrenceOccurrence(ctx, sqlc.ClaimRecurrenceOccurrenceParams{
+		RecurrenceRuleID: ruleID,
+		OccurrenceDate:   pgtype.Date{Time: date, Valid: true},
+	})
+	if err != nil {
+		return false, err
+	}
+	return n > 0, nil
+}
+
+func (r *RecurrenceRepository) SetOccurrenceCashFlow(ctx context.Context, ruleID int32, date time.Time, cashFlowID int32) error {
+	return queriesFor(ctx, r.q).SetRecurrenceOccurrenceCashFlow(ctx, sqlc.SetRecurrenceOccurrenceCashFlowParams{
+		RecurrenceRuleID: ruleID,
+		OccurrenceDate:   pgtype.Date{Time: date, Valid: true},
+		CashFlowID:       pgtype.Int4{Int32: cashFlowID, Valid: true},
+	})
+}
+
+func (r *RecurrenceRepository) ListOccurrences(ctx context.Context, ruleID int32) ([]recurrence.Occurrence, error) {
+	rows, err := queriesFor(ctx, r.q).ListRecurrenceOccurrences(ctx, ruleID)
+	if err != nil {
+		return nil, err
+	}
+
+	occurrences := make([]recurrence.Occurrence, len(rows))
+	for i, row := range rows {
+		occurrences[i] = recurrence.Occurrence{
+			RuleID:     row.RecurrenceRuleID,
+			Date:       row.OccurrenceDate.Time,
+			CashFlowID: int4ToPtr(row.CashFlowID),
+		}
+	}
+	return occurrences, nil
+}
+
+func toRecurrenceRule(row sqlc.RecurrenceRule) *recurrence.Rule {
+	return &recurrence.Rule{
+		ID:                row.RecurrenceRuleID,
+		Title:             row.Title,
+		CategoryID:        row.CategoryID,
+		Direction:         row.Direction,
+		Amount:            row.Amount,
+		IsFixed:           row.IsFixed,
+		PaymentMethodID:   int4ToPtr(row.PaymentMethodID),
+		Frequency:         row.Frequency,
+		Interval:          row.IntervalCount,
+		ByMonthDay:        int4ToPtr(row.ByMonthDay),
+		BusinessDayAdjust: row.BusinessDayAdjust,
+		StartDate:         row.StartDate.Time,
+		EndDate:           toTimePtr(row.EndDate),
+		IsActive:          row.IsActive,
+	}
+}
+
+func dateFromPtr(value *time.Time) pgtype.Date {
+	if value == nil {
+		return pgtype.Date{Valid: false}
+	}
+	return pgtype.Date{Time: *value, Valid: true}
+}
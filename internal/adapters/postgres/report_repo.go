@@ -0,0 +1,229 @@
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/adapters/postgres/sqlc"
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/domain/report"
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/money"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type ReportRepository struct {
+	db *pgxpool.Pool
+	q  *sqlc.Queries
+}
+
+func NewReportRepository(db *pgxpool.Pool) *ReportRepository {
+	return &ReportRepository{
+		db: db,
+		q:  sqlc.New(db),
+	}
+}
+
+func (r *ReportRepository) Cumulative(ctx context.Context, from, to time.Time, includeInstallments bool) ([]report.CumulativeRow, error) {
+	rows, err := queriesFor(ctx, r.q).GetCumulativeCashFlow(ctx, sqlc.GetCumulativeCashFlowParams{
+		DateTo:              pgtype.Date{Time: to, Valid: true},
+		IncludeInstallments: includeInstallments,
+		DateFrom:            pgtype.Date{Time: from, Valid: true},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	months := make([]report.CumulativeRow, len(rows))
+	for i, row := range rows {
+		months[i] = report.CumulativeRow{
+			Month:               row.Month.Time,
+			OwnIncome:           row.OwnIncome,
+			OwnExpense:          row.OwnExpense,
+			PicuinhaIncome:      row.PicuinhaIncome,
+			PicuinhaExpense:     row.PicuinhaExpense,
+			PlannedInstallments: row.PlannedInstallments,
+			OwnBalance:          row.OwnBalance,
+			PicuinhaBalance:     row.PicuinhaBalance,
+		}
+	}
+	return months, nil
+}
+
+func (r *ReportRepository) CategoryHistory(ctx context.Context, categoryID int32, from, to time.Time) ([]report.CategoryHistoryRow, error) {
+	rows, err := queriesFor(ctx, r.q).GetCategoryHistory(ctx, sqlc.GetCategoryHistoryParams{
+		DateFrom:   pgtype.Date{Time: from, Valid: true},
+		DateTo:     pgtype.Date{Time: to, Valid: true},
+		CategoryID: categoryID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	months := make([]report.CategoryHistoryRow, len(rows))
+	for i, row := range rows {
+		months[i] = report.CategoryHistoryRow{
+			Month:          row.Month.Time,
+			Total:          row.Total,
+			Planned:        plannedAmount(row.HasBudget, row.Planned),
+			MovingAverage3: row.Avg3m,
+			MovingAverage6: row.Avg6m,
+			PreviousTotal:  row.PreviousTotal,
+			TrendSlope:     row.TrendSlope,
+		}
+	}
+	return months, nil
+}
+
+func (r *ReportRepository) FastestGrowing(ctx context.Context, from, to time.Time, limit int) ([]report.CategoryGrowth, error) {
+	rows, err := queriesFor(ctx, r.q).ListFastestGrowingCategories(ctx, sqlc.ListFastestGrowingCategoriesParams{
+		DateFrom: pgtype.Date{Time: from, Valid: true},
+		DateTo:   pgtype.Date{Time: to, Valid: true},
+		MaxRows:  int32(limit),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	categories := make([]report.CategoryGrowth, len(rows))
+	for i, row := range rows {
+		categories[i] = report.CategoryGrowth{
+			CategoryID:     row.CategoryID,
+			CategoryName:   row.Name,
+			Total:          row.Total,
+			MonthlyAverage: row.MonthlyAverage,
+			TrendSlope:     row.TrendSlope,
+			Planned:        plannedAmount(row.HasBudget, row.Planned),
+		}
+	}
+	return categories, nil
+}
+
+func plannedAmount(hasBudget bool, planned money.Amount) *money.Amount {
+	if !hasBudget {
+		return nil
+	}
+	return &planned
+}
+
+func (r *ReportRepository) ForecastOpeningBalance(ctx context.Context, month time.Time) (money.Amount, error) {
+	return queriesFor(ctx, r.q).GetForecastOpeningBalance(ctx, pgtype.Date{Time: month, Valid: true})
+}
+
+func (r *ReportRepository) ForecastPlanned(ctx context.Context, from, to time.Time) ([]report.ForecastFlow, error) {
+	rows, err := queriesFor(ctx, r.q).ListForecastPlanned(ctx, sqlc.ListForecastPlannedParams{
+		DateFrom: pgtype.Date{Time: from, Valid: true},
+		DateTo:   pgtype.Date{Time: to, Valid: true},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	flows := make([]report.ForecastFlow, len(rows))
+	for i, row := range rows {
+		flows[i] = report.ForecastFlow{
+			Month:         row.Month.Time,
+			Direction:     row.Direction,
+			CategoryID:    row.CategoryID,
+			Title:         row.Title,
+			Amount:        row.Amount,
+			IsInstallment: row.IsInstallment,
+		}
+	}
+	return flows, nil
+}
+
+func (r *ReportRepository) ForecastFixed(ctx context.Context, month time.Time) ([]report.ForecastFlow, error) {
+	rows, err := queriesFor(ctx, r.q).ListForecastFixed(ctx, pgtype.Date{Time: month, Valid: true})
+	if err != nil {
+		return nil, err
+	}
+
+	flows := make([]report.ForecastFlow, len(rows))
+	for i, row := range rows {
+		flows[i] = report.ForecastFlow{
+			Direction:  row.Direction,
+			CategoryID: row.CategoryID,
+			Title:      row.Title,
+			Amount:     row.Amount,
+		}
+	}
+	return flows, nil
+}
+
+func (r *ReportRepository) ForecastReceivables(ctx context.Context, from, to time.Time) ([]report.Receivable, error) {
+	rows, err := queriesFor(ctx, r.q).ListForecastReceivables(ctx, sqlc.ListForecastReceivablesParams{
+		DateFrom: pgtype.Date{Time: from, Valid: true},
+		DateTo:   pgtype.Date{Time: to, Valid: true},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	receivables := make([]report.Receivable, len(rows))
+	for i, row := range rows {
+		receivables[i] = report.Receivable{Month: row.Month.Time, Amount: row.Amount}
+	}
+	return receivables, nil
+}
+
+func (r *ReportRepository) VariableSpending(ctx context.Context, from, to time.Time) ([]report.VariableSpending, error) {
+	rows, err := queriesFor(ctx, r.q).ListVariableSpending(ctx, sqlc.ListVariableSpendingParams{
+		DateFrom: pgtype.Date{Time: from, Valid: true},
+		DateTo:   pgtype.Date{Time: to, Valid: true},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	spending := make([]report.VariableSpending, len(rows))
+	for i, row := range rows {
+		spending[i] = report.VariableSpending{
+			CategoryID:   row.CategoryID,
+			CategoryName: row.Name,
+			Average:      row.Average,
+			StdDev:       row.Stddev,
+		}
+	}
+	return spending, nil
+}
+
+func (r *ReportRepository) AnnualMatrix(ctx context.Context, yearStart time.Time) ([]report.AnnualRow, error) {
+	rows, err := queriesFor(ctx, r.q).GetAnnualMatrix(ctx, pgtype.Date{Time: yearStart, Valid: true})
+	if err != nil {
+		return nil, err
+	}
+
+	cells := make([]report.AnnualRow, len(rows))
+	for i, row := range rows {
+		cells[i] = report.AnnualRow{
+			CategoryID:   row.CategoryID,
+			CategoryName: row.Name,
+			Direction:    row.Direction,
+			Month:        row.Month.Time,
+			Total:        row.Total,
+			Planned:      plannedAmount(row.HasBudget, row.Planned),
+		}
+	}
+	return cells, nil
+}
+
+func (r *ReportRepository) Reinvestment(ctx context.Context, from, to time.Time) ([]report.ReinvestmentRow, error) {
+	rows, err := queriesFor(ctx, r.q).GetReinvestment(ctx, sqlc.GetReinvestmentParams{
+		DateTo:   pgtype.Date{Time: to, Valid: true},
+		DateFrom: pgtype.Date{Time: from, Valid: true},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	months := make([]report.ReinvestmentRow, len(rows))
+	for i, row := range rows {
+		months[i] = report.ReinvestmentRow{
+			Month:         row.Month.Time,
+			Inflow:        row.Inflow,
+			Contributions: row.Contributions,
+			CumulativeGap: row.CumulativeGap,
+		}
+	}
+	return months, nil
+}
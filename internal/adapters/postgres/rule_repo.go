@@ -0,0 +1,234 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/adapters/postgres/sqlc"
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/domain/rule"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type RuleRepository struct {
+	db *pgxpool.Pool
+	q  *sqlc.Queries
+}
+
+func NewRuleRepository(db *pgxpool.Pool) *RuleRepository {
+	return &RuleRepository{
+		db: db,
+		q:  sqlc.New(db),
+	}
+}
+
+func (r *RuleRepository) WithinTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	return withinTx(ctx, r.db, fn)
+}
+
+func (r *RuleRepository) Create(ctx context.Context, ru *rule.Rule) (*rule.Rule, error) {
+	var created *rule.Rule
+	err := r.WithinTx(ctx, func(ctx context.Context) error {
+		id, err := queriesFor(ctx, r.q).CreateCategorizationRule(ctx, sqlc.CreateCategorizationRuleParams{
+			Name:            ru.Name,
+			Priority:        ru.Priority,
+			IsActive:        ru.IsActive,
+			TitlePattern:    pgtype.Text{String: ru.TitlePattern, Valid: ru.TitlePattern != ""},
+			Direction:       pgtype.Text{String: ru.Direction, Valid: ru.Direction != ""},
+			MinAmount:       ru.MinAmount,
+			MaxAmount:       ru.MaxAmount,
+			PaymentMethodID: int4FromPtr(ru.PaymentMethodID),
+			PayeeID:         int4FromPtr(ru.PayeeID),
+			SetCategoryID:   int4FromPtr(ru.SetCategoryID),
+			SetIsFixed:      boolFromPtr(ru.SetIsFixed),
+			SetPayeeID:      int4FromPtr(ru.SetPayeeID),
+		})
+		if err != nil {
+			return err
+		}
+		if err := r.addTags(ctx, id, ru.AddTagIDs); err != nil {
+			return err
+		}
+		created, err = r.GetByID(ctx, id)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return created, nil
+}
+
+func (r *RuleRepository) List(ctx context.Context, activeOnly bool) ([]rule.Rule, error) {
+	var isActive pgtype.Bool
+	if activeOnly {
+		isActive = pgtype.Bool{Bool: true, Valid: true}
+	}
+
+	q := queriesFor(ctx, r.q)
+	rows, err := q.ListCategorizationRules(ctx, isActive)
+	if err != nil {
+		return nil, err
+	}
+	tags, err := q.ListCategorizationRuleTags(ctx, pgtype.Int4{})
+	if err != nil {
+		return nil, err
+	}
+	byRule := make(map[int32][]sqlc.ListCategorizationRuleTagsRow)
+	for _, t := range tags {
+		byRule[t.CategorizationRuleID] = append(byRule[t.CategorizationRuleID], t)
+	}
+
+	rules := make([]rule.Rule, len(rows))
+	for i, row := range rows {
+		rules[i] = *toRule(sqlc.GetCategorizationRuleRow(row), byRule[row.CategorizationRuleID])
+	}
+	return rules, nil
+}
+
+func (r *RuleRepository) GetByID(ctx context.Context, id int32) (*rule.Rule, error) {
+	q := queriesFor(ctx, r.q)
+	row, err := q.GetCategorizationRule(ctx, id)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	tags, err := q.ListCategorizationRuleTags(ctx, pgtype.Int4{Int32: id, Valid: true})
+	if err != nil {
+		return nil, err
+	}
+	return toRule(row, tags), nil
+}
+
+// Update also replaces the tags.
+func (r *RuleRepository) Update(ctx context.Context, ru *rule.Rule) (*rule.Rule, error) {
+	var updated *rule.Rule
+	err := r.WithinTx(ctx, func(ctx context.Context) error {
+		q := queriesFor(ctx, r.q)
+		_, err := q.UpdateCategorizationRule(ctx, sqlc.UpdateCategorizationRuleParams{
+			CategorizationRuleID: ru.ID,
+			Name:                 ru.Name,
+			Priority:             ru.Priority,
+			IsActive:             ru.IsActive,
+			TitlePattern:         pgtype.Text{String: ru.TitlePattern, Valid: ru.TitlePattern != ""},
+			Direction:            pgtype.Text{String: ru.Direction, Valid: ru.Direction != ""},
+			MinAmount:            ru.MinAmount,
+			MaxAmount:            ru.MaxAmount,
+			PaymentMethodID:      int4FromPtr(ru.PaymentMethodID),
+			PayeeID:              int4FromPtr(ru.PayeeID),
+			SetCategoryID:        int4FromPtr(ru.SetCategoryID),
+			SetIsFixed:           boolFromPtr(ru.SetIsFixed),
+			SetPayeeID:           int4FromPtr(ru.SetPayeeID),
+		})
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return rule.ErrRuleNotFound
+			}
+			return err
+		}
+		if err := q.DeleteCategorizationRuleTags(ctx, ru.ID); err != nil {
+			return err
+		}
+		if err := r.addTags(ctx, ru.ID, ru.AddTagIDs); err != nil {
+			return err
+		}
+		updated, err = r.GetByID(ctx, ru.ID)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return updated, nil
+}
+
+func (r *RuleRepository) Delete(ctx context.Context, id int32) error {
+	return queriesFor(ctx, r.q).DeleteCategorizationRule(ctx, id)
+}
+
+func (r *RuleRepository) ListFlows(ctx context.Context, direction string, scope rule.Scope) ([]rule.Flow, error) {
+	rows, err := queriesFor(ctx, r.q).ListCategorizationCandidates(ctx, sqlc.ListCategorizationCandidatesParams{
+		Direction: pgtype.Text{String: direction, Valid: direction != ""},
+		DateFrom:  dateFromPtr(scope.From),
+		DateTo:    dateFromPtr(scope.To),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	flows := make([]rule.Flow, len(rows))
+	for i, row := range rows {
+		flows[i] = rule.Flow{
+			ID:              row.CashFlowID,
+			Date:            row.Date.Time,
+			Title:           row.Title,
+			Direction:       row.Direction,
+			Amount:          row.Amount,
+			CategoryID:      row.CategoryID,
+			IsFixed:         row.IsFixed,
+			PayeeID:         int4ToPtr(row.PayeeID),
+			PaymentMethodID: int4ToPtr(row.PaymentMethodID),
+			TagIDs:          row.TagIds,
+		}
+	}
+	return flows, nil
+}
+
+func (r *RuleRepository) AddCashFlowTags(ctx context.Context, cashFlowID int32, tagIDs []int32) error {
+	q := queriesFor(ctx, r.q)
+	for _, tagID := range tagIDs {
+		if err := q.AddCashFlowTag(ctx, sqlc.AddCashFlowTagParams{CashFlowID: cashFlowID, TagID: tagID}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *RuleRepository) addTags(ctx context.Context, ruleID int32, tagIDs []int32) error {
+	q := queriesFor(ctx, r.q)
+	for _, tagID := range tagIDs {
+		if err := q.AddCategorizationRuleTag(ctx, sqlc.AddCategorizationRuleTagParams{
+			CategorizationRuleID: ruleID,
+			TagID:                tagID,
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func toRule(row sqlc.GetCategorizationRuleRow, tags []sqlc.ListCategorizationRuleTagsRow) *rule.Rule {
+	ru := &rule.Rule{
+		ID:              row.CategorizationRuleID,
+		Name:            row.Name,
+		Priority:        row.Priority,
+		IsActive:        row.IsActive,
+		TitlePattern:    row.TitlePattern.String,
+		Direction:       row.Direction.String,
+		MinAmount:       row.MinAmount,
+		MaxAmount:       row.MaxAmount,
+		PaymentMethodID: int4ToPtr(row.PaymentMethodID),
+		PayeeID:         int4ToPtr(row.PayeeID),
+		SetCategoryID:   int4ToPtr(row.SetCategoryID),
+		SetCategoryName: row.SetCategoryName.String,
+		SetPayeeID:      int4ToPtr(row.SetPayeeID),
+		AddTags:         make([]string, len(tags)),
+		AddTagIDs:       make([]int32, len(tags)),
+	}
+	if row.SetIsFixed.Valid {
+		ru.SetIsFixed = &row.SetIsFixed.Bool
+	}
+	for i, t := range tags {
+		ru.AddTags[i] = t.Name
+		ru.AddTagIDs[i] = t.TagID
+	}
+	return ru
+}
+
+func boolFromPtr(value *bool) pgtype.Bool {
+	if value == nil {
+		return pgtype.Bool{}
+	}
+	return pgtype.Bool{Bool: *value, Valid: true}
+}
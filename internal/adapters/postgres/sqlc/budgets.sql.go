@@ -8,6 +8,7 @@ package sqlc
 import (
 	"context"
 
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/money"
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
@@ -55,7 +56,7 @@ type GetBudgetItemByIDRow struct {
 	BudgetPeriodID int32
 	CategoryID     int32
 	Mode           string
-	PlannedAmount  pgtype.Numeric
+	PlannedAmount  *money.Amount
 	TargetPercent  pgtype.Numeric
 	Notes          pgtype.Text
 	CategoryName   string
@@ -97,7 +98,7 @@ type GetBudgetItemsByPeriodRow struct {
 	BudgetPeriodID int32
 	CategoryID     int32
 	Mode           string
-	PlannedAmount  pgtype.Numeric
+	PlannedAmount  *money.Amount
 	TargetPercent  pgtype.Numeric
 	Notes          pgtype.Text
 	CategoryName   string
@@ -189,7 +190,7 @@ RETURNING budget_item_id, budget_period_id, category_id, mode, planned_amount, t
 type UpdateBudgetItemParams struct {
 	BudgetItemID  int32
 	Mode          string
-	PlannedAmount pgtype.Numeric
+	PlannedAmount *money.Amount
 	TargetPercent pgtype.Numeric
 	Notes         pgtype.Text
 }
@@ -199,7 +200,7 @@ type UpdateBudgetItemRow struct {
 	BudgetPeriodID int32
 	CategoryID     int32
 	Mode           string
-	PlannedAmount  pgtype.Numeric
+	PlannedAmount  *money.Amount
 	TargetPercent  pgtype.Numeric
 	Notes          pgtype.Text
 	CategoryName   string
@@ -242,7 +243,7 @@ type UpsertBudgetItemParams struct {
 	BudgetPeriodID int32
 	CategoryID     int32
 	Mode           string
-	PlannedAmount  pgtype.Numeric
+	PlannedAmount  *money.Amount
 	TargetPercent  pgtype.Numeric
 	Notes          pgtype.Text
 }
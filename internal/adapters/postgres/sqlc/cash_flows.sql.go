@@ -8,9 +8,169 @@ package sqlc
 import (
 	"context"
 
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/money"
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
+const cancelCashFlow = `-- name: CancelCashFlow :execrows
+WITH details AS (
+  UPDATE expense_details
+  SET is_future = false
+  WHERE cash_flow_id = $1
+)
+UPDATE cash_flows
+SET status = 'CANCELLED'
+WHERE cash_flow_id = $1
+  AND status = 'PLANNED'
+`
+
+func (q *Queries) CancelCashFlow(ctx context.Context, cashFlowID int32) (int64, error) {
+	result, err := q.db.Exec(ctx, cancelCashFlow, cashFlowID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const confirmCashFlow = `-- name: ConfirmCashFlow :one
+WITH details AS (
+  UPDATE expense_details
+  SET is_future = false
+  WHERE cash_flow_id = $1
+)
+UPDATE cash_flows
+SET status = 'REALIZED',
+    date = $2,
+    amount = $3,
+    currency = $4,
+    original_amount = $5,
+    exchange_rate = $6,
+    iof_amount = $7
+WHERE cash_flow_id = $1
+  AND status = 'PLANNED'
+RETURNING cash_flow_id, date, category_id, direction, title, amount, is_fixed, fitid, external_account, source_cash_flow_id, account_id, cleared_at, reconciliation_id, status, currency, original_amount, exchange_rate, iof_amount, payee_id
+`
+
+type ConfirmCashFlowParams struct {
+	CashFlowID     int32
+	Date           pgtype.Date
+	Amount         money.Amount
+	Currency       pgtype.Text
+	OriginalAmount *money.Amount
+	ExchangeRate   pgtype.Numeric
+	IofAmount      *money.Amount
+}
+
+func (q *Queries) ConfirmCashFlow(ctx context.Context, arg ConfirmCashFlowParams) (CashFlow, error) {
+	row := q.db.QueryRow(ctx, confirmCashFlow,
+		arg.CashFlowID,
+		arg.Date,
+		arg.Amount,
+		arg.Currency,
+		arg.OriginalAmount,
+		arg.ExchangeRate,
+		arg.IofAmount,
+	)
+	var i CashFlow
+	err := row.Scan(
+		&i.CashFlowID,
+		&i.Date,
+		&i.CategoryID,
+		&i.Direction,
+		&i.Title,
+		&i.Amount,
+		&i.IsFixed,
+		&i.Fitid,
+		&i.ExternalAccount,
+		&i.SourceCashFlowID,
+		&i.AccountID,
+		&i.ClearedAt,
+		&i.ReconciliationID,
+		&i.Status,
+		&i.Currency,
+		&i.OriginalAmount,
+		&i.ExchangeRate,
+		&i.IofAmount,
+		&i.PayeeID,
+	)
+	return i, err
+}
+
+const countCashFlowInstallmentLinks = `-- name: CountCashFlowInstallmentLinks :one
+SELECT (
+  (SELECT COUNT(*) FROM installment_plan_items ipi WHERE ipi.cash_flow_id = $1::int)
+  + (SELECT COUNT(*) FROM expense_details ed WHERE ed.cash_flow_id = $1::int AND ed.installment_plan_id IS NOT NULL)
+)::bigint AS link_count
+`
+
+func (q *Queries) CountCashFlowInstallmentLinks(ctx context.Context, cashFlowID int32) (int64, error) {
+	row := q.db.QueryRow(ctx, countCashFlowInstallmentLinks, cashFlowID)
+	var link_count int64
+	err := row.Scan(&link_count)
+	return link_count, err
+}
+
+const countCashFlows = `-- name: CountCashFlows :one
+SELECT COUNT(*)
+FROM cash_flows cf
+WHERE ($1::date IS NULL OR cf.date >= $1::date)
+  AND ($2::date IS NULL OR cf.date <= $2::date)
+  AND ($3::int[] IS NULL OR cf.category_id = ANY($3::int[]))
+  AND ($4::text IS NULL OR cf.direction = $4::text)
+  AND ($5::text IS NULL OR cf.title ILIKE '%' || $5::text || '%')
+  AND ($6::numeric IS NULL OR cf.amount >= $6::numeric)
+  AND ($7::numeric IS NULL OR cf.amount <= $7::numeric)
+  AND ($8::boolean IS NULL OR cf.is_fixed = $8::boolean)
+  AND ($9::text IS NULL OR cf.status = $9::text)
+  AND ($10::int IS NULL OR EXISTS (
+    SELECT 1 FROM expense_details ed
+    WHERE ed.cash_flow_id = cf.cash_flow_id
+      AND ed.payment_method_id = $10::int
+  ))
+  AND ($11::text[] IS NULL OR EXISTS (
+    SELECT 1 FROM cash_flow_tags cft
+    JOIN tags t ON t.tag_id = cft.tag_id
+    WHERE cft.cash_flow_id = cf.cash_flow_id
+      AND t.name = ANY($11::text[])
+  ))
+  AND ($12::int IS NULL OR cf.payee_id = $12::int)
+`
+
+type CountCashFlowsParams struct {
+	DateFrom        pgtype.Date
+	DateTo          pgtype.Date
+	CategoryIds     []int32
+	Direction       pgtype.Text
+	Title           pgtype.Text
+	MinAmount       *money.Amount
+	MaxAmount       *money.Amount
+	IsFixed         pgtype.Bool
+	Status          pgtype.Text
+	PaymentMethodID pgtype.Int4
+	Tags            []string
+	PayeeID         pgtype.Int4
+}
+
+func (q *Queries) CountCashFlows(ctx context.Context, arg CountCashFlowsParams) (int64, error) {
+	row := q.db.QueryRow(ctx, countCashFlows,
+		arg.DateFrom,
+		arg.DateTo,
+		arg.CategoryIds,
+		arg.Direction,
+		arg.Title,
+		arg.MinAmount,
+		arg.MaxAmount,
+		arg.IsFixed,
+		arg.Status,
+		arg.PaymentMethodID,
+		arg.Tags,
+		arg.PayeeID,
+	)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
 const createCashFlow = `-- name: CreateCashFlow :one
 INSERT INTO cash_flows (
   date,
@@ -18,20 +178,40 @@ INSERT INTO cash_flows (
   direction,
   title,
   amount,
-  is_fixed
+  is_fixed,
+  fitid,
+  external_account,
+  source_cash_flow_id,
+  account_id,
+  status,
+  currency,
+  original_amount,
+  exchange_rate,
+  iof_amount,
+  payee_id
 ) VALUES (
-  $1, $2, $3, $4, $5, $6
+  $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16
 )
-RETURNING cash_flow_id, date, category_id, direction, title, amount, is_fixed
+RETURNING cash_flow_id, date, category_id, direction, title, amount, is_fixed, fitid, external_account, source_cash_flow_id, account_id, cleared_at, reconciliation_id, status, currency, original_amount, exchange_rate, iof_amount, payee_id
 `
 
 type CreateCashFlowParams struct {
-	Date       pgtype.Date
-	CategoryID int32
-	Direction  string
-	Title      string
-	Amount     pgtype.Numeric
-	IsFixed    bool
+	Date             pgtype.Date
+	CategoryID       int32
+	Direction        string
+	Title            string
+	Amount           money.Amount
+	IsFixed          bool
+	Fitid            pgtype.Text
+	ExternalAccount  pgtype.Text
+	SourceCashFlowID pgtype.Int4
+	AccountID        pgtype.Int4
+	Status           string
+	Currency         pgtype.Text
+	OriginalAmount   *money.Amount
+	ExchangeRate     pgtype.Numeric
+	IofAmount        *money.Amount
+	PayeeID          pgtype.Int4
 }
 
 func (q *Queries) CreateCashFlow(ctx context.Context, arg CreateCashFlowParams) (CashFlow, error) {
@@ -42,6 +222,16 @@ func (q *Queries) CreateCashFlow(ctx context.Context, arg CreateCashFlowParams)
 		arg.Title,
 		arg.Amount,
 		arg.IsFixed,
+		arg.Fitid,
+		arg.ExternalAccount,
+		arg.SourceCashFlowID,
+		arg.AccountID,
+		arg.Status,
+		arg.Currency,
+		arg.OriginalAmount,
+		arg.ExchangeRate,
+		arg.IofAmount,
+		arg.PayeeID,
 	)
 	var i CashFlow
 	err := row.Scan(
@@ -52,6 +242,237 @@ func (q *Queries) CreateCashFlow(ctx context.Context, arg CreateCashFlowParams)
 		&i.Title,
 		&i.Amount,
 		&i.IsFixed,
+		&i.Fitid,
+		&i.ExternalAccount,
+		&i.SourceCashFlowID,
+		&i.AccountID,
+		&i.ClearedAt,
+		&i.ReconciliationID,
+		&i.Status,
+		&i.Currency,
+		&i.OriginalAmount,
+		&i.ExchangeRate,
+		&i.IofAmount,
+		&i.PayeeID,
+	)
+	return i, err
+}
+
+const createCashFlowExpenseDetail = `-- name: CreateCashFlowExpenseDetail :exec
+INSERT INTO expense_details (cash_flow_id, payment_method_id, is_fixed, is_future, affects_card_invoice)
+VALUES ($1, $2, $3, (SELECT status = 'PLANNED' FROM cash_flows WHERE cash_flow_id = $1), $4)
+`
+
+type CreateCashFlowExpenseDetailParams struct {
+	CashFlowID         int32
+	PaymentMethodID    pgtype.Int4
+	IsFixed            bool
+	AffectsCardInvoice bool
+}
+
+func (q *Queries) CreateCashFlowExpenseDetail(ctx context.Context, arg CreateCashFlowExpenseDetailParams) error {
+	_, err := q.db.Exec(ctx, createCashFlowExpenseDetail,
+		arg.CashFlowID,
+		arg.PaymentMethodID,
+		arg.IsFixed,
+		arg.AffectsCardInvoice,
+	)
+	return err
+}
+
+const createCashFlowRevision = `-- name: CreateCashFlowRevision :exec
+INSERT INTO cash_flow_revisions (
+  cash_flow_id,
+  action,
+  date,
+  category_id,
+  direction,
+  title,
+  amount,
+  is_fixed,
+  currency,
+  original_amount,
+  exchange_rate,
+  iof_amount,
+  status
+) VALUES (
+  $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13
+)
+`
+
+type CreateCashFlowRevisionParams struct {
+	CashFlowID     int32
+	Action         string
+	Date           pgtype.Date
+	CategoryID     int32
+	Direction      string
+	Title          string
+	Amount         money.Amount
+	IsFixed        bool
+	Currency       pgtype.Text
+	OriginalAmount *money.Amount
+	ExchangeRate   pgtype.Numeric
+	IofAmount      *money.Amount
+	Status         string
+}
+
+func (q *Queries) CreateCashFlowRevision(ctx context.Context, arg CreateCashFlowRevisionParams) error {
+	_, err := q.db.Exec(ctx, createCashFlowRevision,
+		arg.CashFlowID,
+		arg.Action,
+		arg.Date,
+		arg.CategoryID,
+		arg.Direction,
+		arg.Title,
+		arg.Amount,
+		arg.IsFixed,
+		arg.Currency,
+		arg.OriginalAmount,
+		arg.ExchangeRate,
+		arg.IofAmount,
+		arg.Status,
+	)
+	return err
+}
+
+const createCashFlowSplit = `-- name: CreateCashFlowSplit :one
+INSERT INTO cash_flow_splits (cash_flow_id, category_id, amount)
+VALUES ($1, $2, $3)
+RETURNING cash_flow_split_id, cash_flow_id, category_id, amount
+`
+
+type CreateCashFlowSplitParams struct {
+	CashFlowID int32
+	CategoryID int32
+	Amount     money.Amount
+}
+
+func (q *Queries) CreateCashFlowSplit(ctx context.Context, arg CreateCashFlowSplitParams) (CashFlowSplit, error) {
+	row := q.db.QueryRow(ctx, createCashFlowSplit, arg.CashFlowID, arg.CategoryID, arg.Amount)
+	var i CashFlowSplit
+	err := row.Scan(
+		&i.CashFlowSplitID,
+		&i.CashFlowID,
+		&i.CategoryID,
+		&i.Amount,
+	)
+	return i, err
+}
+
+const deleteCashFlow = `-- name: DeleteCashFlow :exec
+DELETE FROM cash_flows
+WHERE cash_flow_id = $1
+`
+
+func (q *Queries) DeleteCashFlow(ctx context.Context, cashFlowID int32) error {
+	_, err := q.db.Exec(ctx, deleteCashFlow, cashFlowID)
+	return err
+}
+
+const deleteCashFlowSplits = `-- name: DeleteCashFlowSplits :exec
+DELETE FROM cash_flow_splits
+WHERE cash_flow_id = $1
+`
+
+func (q *Queries) DeleteCashFlowSplits(ctx context.Context, cashFlowID int32) error {
+	_, err := q.db.Exec(ctx, deleteCashFlowSplits, cashFlowID)
+	return err
+}
+
+const deleteExpenseDetailsByCashFlow = `-- name: DeleteExpenseDetailsByCashFlow :exec
+DELETE FROM expense_details
+WHERE cash_flow_id = $1
+`
+
+func (q *Queries) DeleteExpenseDetailsByCashFlow(ctx context.Context, cashFlowID int32) error {
+	_, err := q.db.Exec(ctx, deleteExpenseDetailsByCashFlow, cashFlowID)
+	return err
+}
+
+const getCashFlowByFITID = `-- name: GetCashFlowByFITID :one
+SELECT cash_flow_id
+FROM cash_flows
+WHERE COALESCE(external_account, '') = $1::text
+  AND fitid = $2::text
+`
+
+type GetCashFlowByFITIDParams struct {
+	ExternalAccount string
+	Fitid           string
+}
+
+func (q *Queries) GetCashFlowByFITID(ctx context.Context, arg GetCashFlowByFITIDParams) (int32, error) {
+	row := q.db.QueryRow(ctx, getCashFlowByFITID, arg.ExternalAccount, arg.Fitid)
+	var cash_flow_id int32
+	err := row.Scan(&cash_flow_id)
+	return cash_flow_id, err
+}
+
+const getCashFlowByID = `-- name: GetCashFlowByID :one
+SELECT
+  cf.cash_flow_id,
+  cf.date,
+  cf.category_id,
+  cf.direction,
+  cf.title,
+  cf.amount,
+  cf.is_fixed,
+  cf.account_id,
+  cf.cleared_at,
+  cf.reconciliation_id,
+  cf.status,
+  cf.currency,
+  cf.original_amount,
+  cf.exchange_rate,
+  cf.iof_amount,
+  cf.payee_id,
+  fc.name AS category_name
+FROM cash_flows cf
+JOIN flow_categories fc ON fc.category_id = cf.category_id
+WHERE cf.cash_flow_id = $1
+`
+
+type GetCashFlowByIDRow struct {
+	CashFlowID       int32
+	Date             pgtype.Date
+	CategoryID       int32
+	Direction        string
+	Title            string
+	Amount           money.Amount
+	IsFixed          bool
+	AccountID        pgtype.Int4
+	ClearedAt        pgtype.Timestamp
+	ReconciliationID pgtype.Int4
+	Status           string
+	Currency         pgtype.Text
+	OriginalAmount   *money.Amount
+	ExchangeRate     pgtype.Numeric
+	IofAmount        *money.Amount
+	PayeeID          pgtype.Int4
+	CategoryName     string
+}
+
+func (q *Queries) GetCashFlowByID(ctx context.Context, cashFlowID int32) (GetCashFlowByIDRow, error) {
+	row := q.db.QueryRow(ctx, getCashFlowByID, cashFlowID)
+	var i GetCashFlowByIDRow
+	err := row.Scan(
+		&i.CashFlowID,
+		&i.Date,
+		&i.CategoryID,
+		&i.Direction,
+		&i.Title,
+		&i.Amount,
+		&i.IsFixed,
+		&i.AccountID,
+		&i.ClearedAt,
+		&i.ReconciliationID,
+		&i.Status,
+		&i.Currency,
+		&i.OriginalAmount,
+		&i.ExchangeRate,
+		&i.IofAmount,
+		&i.PayeeID,
+		&i.CategoryName,
 	)
 	return i, err
 }
@@ -60,22 +481,28 @@ const getCategorySummary = `-- name: GetCategorySummary :many
 SELECT
   fc.name,
   fc.direction,
-  SUM(cf.amount)::float AS total_amount
-FROM cash_flows cf
-JOIN flow_categories fc ON fc.category_id = cf.category_id
-WHERE date_trunc('month', cf.date) = date_trunc('month', $1::date)
+  SUM(cl.amount)::numeric AS total_amount
+FROM cash_flow_lines cl
+JOIN flow_categories fc ON fc.category_id = cl.category_id
+WHERE date_trunc('month', cl.date) = date_trunc('month', $1::date)
+  AND (cl.status = 'REALIZED' OR $2::boolean)
 GROUP BY fc.name, fc.direction
 ORDER BY total_amount DESC
 `
 
+type GetCategorySummaryParams struct {
+	Month          pgtype.Date
+	IncludePlanned bool
+}
+
 type GetCategorySummaryRow struct {
 	Name        string
 	Direction   string
-	TotalAmount float64
+	TotalAmount money.Amount
 }
 
-func (q *Queries) GetCategorySummary(ctx context.Context, dollar_1 pgtype.Date) ([]GetCategorySummaryRow, error) {
-	rows, err := q.db.Query(ctx, getCategorySummary, dollar_1)
+func (q *Queries) GetCategorySummary(ctx context.Context, arg GetCategorySummaryParams) ([]GetCategorySummaryRow, error) {
+	rows, err := q.db.Query(ctx, getCategorySummary, arg.Month, arg.IncludePlanned)
 	if err != nil {
 		return nil, err
 	}
@@ -94,26 +521,282 @@ func (q *Queries) GetCategorySummary(ctx context.Context, dollar_1 pgtype.Date)
 	return items, nil
 }
 
+const getCategoryTotals = `-- name: GetCategoryTotals :many
+SELECT
+  fc.category_id,
+  fc.name,
+  fc.direction,
+  SUM(cl.amount)::numeric AS total_amount
+FROM cash_flow_lines cl
+JOIN flow_categories fc ON fc.category_id = cl.category_id
+WHERE cl.date >= date_trunc('month', $1::date)
+  AND cl.date < date_trunc('month', $2::date) + interval '1 month'
+  AND (cl.status = 'REALIZED' OR $3::boolean)
+GROUP BY fc.category_id, fc.name, fc.direction
+ORDER BY total_amount DESC
+`
+
+type GetCategoryTotalsParams struct {
+	DateFrom       pgtype.Date
+	DateTo         pgtype.Date
+	IncludePlanned bool
+}
+
+type GetCategoryTotalsRow struct {
+	CategoryID  int32
+	Name        string
+	Direction   string
+	TotalAmount money.Amount
+}
+
+func (q *Queries) GetCategoryTotals(ctx context.Context, arg GetCategoryTotalsParams) ([]GetCategoryTotalsRow, error) {
+	rows, err := q.db.Query(ctx, getCategoryTotals, arg.DateFrom, arg.DateTo, arg.IncludePlanned)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetCategoryTotalsRow
+	for rows.Next() {
+		var i GetCategoryTotalsRow
+		if err := rows.Scan(
+			&i.CategoryID,
+			&i.Name,
+			&i.Direction,
+			&i.TotalAmount,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const getMonthlySummary = `-- name: GetMonthlySummary :one
 SELECT
-  SUM(CASE WHEN direction = 'IN' THEN amount ELSE 0 END)::float AS total_income,
-  SUM(CASE WHEN direction = 'OUT' THEN amount ELSE 0 END)::float AS total_expense
-FROM cash_flows
+  COALESCE(SUM(CASE WHEN direction = 'IN' THEN amount ELSE 0 END), 0)::numeric AS total_income,
+  COALESCE(SUM(CASE WHEN direction = 'OUT' THEN amount ELSE 0 END), 0)::numeric AS total_expense
+FROM cash_flow_lines
 WHERE date_trunc('month', date) = date_trunc('month', $1::date)
+  AND (status = 'REALIZED' OR $2::boolean)
 `
 
+type GetMonthlySummaryParams struct {
+	Month          pgtype.Date
+	IncludePlanned bool
+}
+
 type GetMonthlySummaryRow struct {
-	TotalIncome  float64
-	TotalExpense float64
+	TotalIncome  money.Amount
+	TotalExpense money.Amount
 }
 
-func (q *Queries) GetMonthlySummary(ctx context.Context, dollar_1 pgtype.Date) (GetMonthlySummaryRow, error) {
-	row := q.db.QueryRow(ctx, getMonthlySummary, dollar_1)
+func (q *Queries) GetMonthlySummary(ctx context.Context, arg GetMonthlySummaryParams) (GetMonthlySummaryRow, error) {
+	row := q.db.QueryRow(ctx, getMonthlySummary, arg.Month, arg.IncludePlanned)
 	var i GetMonthlySummaryRow
 	err := row.Scan(&i.TotalIncome, &i.TotalExpense)
 	return i, err
 }
 
+const getPaymentMethodAccount = `-- name: GetPaymentMethodAccount :one
+SELECT account_id
+FROM payment_methods
+WHERE payment_method_id = $1
+  AND kind <> 'CREDIT_CARD'
+`
+
+func (q *Queries) GetPaymentMethodAccount(ctx context.Context, paymentMethodID int32) (pgtype.Int4, error) {
+	row := q.db.QueryRow(ctx, getPaymentMethodAccount, paymentMethodID)
+	var account_id pgtype.Int4
+	err := row.Scan(&account_id)
+	return account_id, err
+}
+
+const isTransferLeg = `-- name: IsTransferLeg :one
+SELECT EXISTS (
+  SELECT 1
+  FROM transfers t
+  WHERE t.out_cash_flow_id = $1 OR t.in_cash_flow_id = $1
+) AS is_leg
+`
+
+func (q *Queries) IsTransferLeg(ctx context.Context, cashFlowID int32) (bool, error) {
+	row := q.db.QueryRow(ctx, isTransferLeg, cashFlowID)
+	var is_leg bool
+	err := row.Scan(&is_leg)
+	return is_leg, err
+}
+
+const listCashFlowCopyTargets = `-- name: ListCashFlowCopyTargets :many
+SELECT cash_flow_id, date, category_id, direction, title, amount, is_fixed, fitid, external_account, source_cash_flow_id, account_id, cleared_at, reconciliation_id, status, currency, original_amount, exchange_rate, iof_amount, payee_id
+FROM cash_flows
+WHERE date_trunc('month', date) = date_trunc('month', $1::date)
+ORDER BY date, cash_flow_id
+`
+
+func (q *Queries) ListCashFlowCopyTargets(ctx context.Context, dollar_1 pgtype.Date) ([]CashFlow, error) {
+	rows, err := q.db.Query(ctx, listCashFlowCopyTargets, dollar_1)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []CashFlow
+	for rows.Next() {
+		var i CashFlow
+		if err := rows.Scan(
+			&i.CashFlowID,
+			&i.Date,
+			&i.CategoryID,
+			&i.Direction,
+			&i.Title,
+			&i.Amount,
+			&i.IsFixed,
+			&i.Fitid,
+			&i.ExternalAccount,
+			&i.SourceCashFlowID,
+			&i.AccountID,
+			&i.ClearedAt,
+			&i.ReconciliationID,
+			&i.Status,
+			&i.Currency,
+			&i.OriginalAmount,
+			&i.ExchangeRate,
+			&i.IofAmount,
+			&i.PayeeID,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listCashFlowLinesByMonth = `-- name: ListCashFlowLinesByMonth :many
+SELECT cash_flow_id, cash_flow_split_id, date, direction, category_id, amount, status
+FROM cash_flow_lines
+WHERE date_trunc('month', date) = date_trunc('month', $1::date)
+  AND status = 'REALIZED'
+ORDER BY date, cash_flow_id, cash_flow_split_id
+`
+
+func (q *Queries) ListCashFlowLinesByMonth(ctx context.Context, dollar_1 pgtype.Date) ([]CashFlowLine, error) {
+	rows, err := q.db.Query(ctx, listCashFlowLinesByMonth, dollar_1)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []CashFlowLine
+	for rows.Next() {
+		var i CashFlowLine
+		if err := rows.Scan(
+			&i.CashFlowID,
+			&i.CashFlowSplitID,
+			&i.Date,
+			&i.Direction,
+			&i.CategoryID,
+			&i.Amount,
+			&i.Status,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listCashFlowRevisions = `-- name: ListCashFlowRevisions :many
+SELECT cash_flow_revision_id, cash_flow_id, action, date, category_id, direction, title, amount, is_fixed, revised_at, currency, original_amount, exchange_rate, iof_amount, status
+FROM cash_flow_revisions
+WHERE cash_flow_id = $1
+ORDER BY revised_at DESC, cash_flow_revision_id DESC
+`
+
+func (q *Queries) ListCashFlowRevisions(ctx context.Context, cashFlowID int32) ([]CashFlowRevision, error) {
+	rows, err := q.db.Query(ctx, listCashFlowRevisions, cashFlowID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []CashFlowRevision
+	for rows.Next() {
+		var i CashFlowRevision
+		if err := rows.Scan(
+			&i.CashFlowRevisionID,
+			&i.CashFlowID,
+			&i.Action,
+			&i.Date,
+			&i.CategoryID,
+			&i.Direction,
+			&i.Title,
+			&i.Amount,
+			&i.IsFixed,
+			&i.RevisedAt,
+			&i.Currency,
+			&i.OriginalAmount,
+			&i.ExchangeRate,
+			&i.IofAmount,
+			&i.Status,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listCashFlowSplits = `-- name: ListCashFlowSplits :many
+SELECT s.cash_flow_split_id, s.cash_flow_id, s.category_id, s.amount, fc.name AS category_name
+FROM cash_flow_splits s
+JOIN flow_categories fc ON fc.category_id = s.category_id
+WHERE s.cash_flow_id = $1
+ORDER BY s.cash_flow_split_id
+`
+
+type ListCashFlowSplitsRow struct {
+	CashFlowSplitID int32
+	CashFlowID      int32
+	CategoryID      int32
+	Amount          money.Amount
+	CategoryName    string
+}
+
+func (q *Queries) ListCashFlowSplits(ctx context.Context, cashFlowID int32) ([]ListCashFlowSplitsRow, error) {
+	rows, err := q.db.Query(ctx, listCashFlowSplits, cashFlowID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListCashFlowSplitsRow
+	for rows.Next() {
+		var i ListCashFlowSplitsRow
+		if err := rows.Scan(
+			&i.CashFlowSplitID,
+			&i.CashFlowID,
+			&i.CategoryID,
+			&i.Amount,
+			&i.CategoryName,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const listCashFlowsByMonth = `-- name: ListCashFlowsByMonth :many
 SELECT
   cf.cash_flow_id,
@@ -123,6 +806,15 @@ SELECT
   cf.title,
   cf.amount,
   cf.is_fixed,
+  cf.account_id,
+  cf.cleared_at,
+  cf.reconciliation_id,
+  cf.status,
+  cf.currency,
+  cf.original_amount,
+  cf.exchange_rate,
+  cf.iof_amount,
+  cf.payee_id,
   fc.name AS category_name
 FROM cash_flows cf
 JOIN flow_categories fc ON fc.category_id = cf.category_id
@@ -131,14 +823,23 @@ ORDER BY cf.date, cf.cash_flow_id
 `
 
 type ListCashFlowsByMonthRow struct {
-	CashFlowID   int32
-	Date         pgtype.Date
-	CategoryID   int32
-	Direction    string
-	Title        string
-	Amount       pgtype.Numeric
-	IsFixed      bool
-	CategoryName string
+	CashFlowID       int32
+	Date             pgtype.Date
+	CategoryID       int32
+	Direction        string
+	Title            string
+	Amount           money.Amount
+	IsFixed          bool
+	AccountID        pgtype.Int4
+	ClearedAt        pgtype.Timestamp
+	ReconciliationID pgtype.Int4
+	Status           string
+	Currency         pgtype.Text
+	OriginalAmount   *money.Amount
+	ExchangeRate     pgtype.Numeric
+	IofAmount        *money.Amount
+	PayeeID          pgtype.Int4
+	CategoryName     string
 }
 
 func (q *Queries) ListCashFlowsByMonth(ctx context.Context, dollar_1 pgtype.Date) ([]ListCashFlowsByMonthRow, error) {
@@ -158,7 +859,102 @@ func (q *Queries) ListCashFlowsByMonth(ctx context.Context, dollar_1 pgtype.Date
 			&i.Title,
 			&i.Amount,
 			&i.IsFixed,
+			&i.AccountID,
+			&i.ClearedAt,
+			&i.ReconciliationID,
+			&i.Status,
+			&i.Currency,
+			&i.OriginalAmount,
+			&i.ExchangeRate,
+			&i.IofAmount,
+			&i.PayeeID,
+			&i.CategoryName,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listDuplicateCandidates = `-- name: ListDuplicateCandidates :many
+SELECT
+  cf.cash_flow_id,
+  cf.date,
+  cf.category_id,
+  fc.name AS category_name,
+  cf.direction,
+  cf.title,
+  cf.amount,
+  cf.payee_id,
+  cf.status,
+  ed.payment_method_id
+FROM cash_flows cf
+JOIN flow_categories fc ON fc.category_id = cf.category_id
+LEFT JOIN LATERAL (
+  SELECT d.payment_method_id
+  FROM expense_details d
+  WHERE d.cash_flow_id = cf.cash_flow_id
+    AND d.payment_method_id IS NOT NULL
+  ORDER BY d.expense_detail_id
+  LIMIT 1
+) ed ON true
+WHERE cf.direction = $1
+  AND cf.amount = $2
+  AND cf.date BETWEEN $3 AND $4
+  AND cf.status <> 'CANCELLED'
+  AND fc.role <> 'TRANSFER'
+ORDER BY cf.date, cf.cash_flow_id
+`
+
+type ListDuplicateCandidatesParams struct {
+	Direction string
+	Amount    money.Amount
+	DateFrom  pgtype.Date
+	DateTo    pgtype.Date
+}
+
+type ListDuplicateCandidatesRow struct {
+	CashFlowID      int32
+	Date            pgtype.Date
+	CategoryID      int32
+	CategoryName    string
+	Direction       string
+	Title           string
+	Amount          money.Amount
+	PayeeID         pgtype.Int4
+	Status          string
+	PaymentMethodID pgtype.Int4
+}
+
+func (q *Queries) ListDuplicateCandidates(ctx context.Context, arg ListDuplicateCandidatesParams) ([]ListDuplicateCandidatesRow, error) {
+	rows, err := q.db.Query(ctx, listDuplicateCandidates,
+		arg.Direction,
+		arg.Amount,
+		arg.DateFrom,
+		arg.DateTo,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListDuplicateCandidatesRow
+	for rows.Next() {
+		var i ListDuplicateCandidatesRow
+		if err := rows.Scan(
+			&i.CashFlowID,
+			&i.Date,
+			&i.CategoryID,
 			&i.CategoryName,
+			&i.Direction,
+			&i.Title,
+			&i.Amount,
+			&i.PayeeID,
+			&i.Status,
+			&i.PaymentMethodID,
 		); err != nil {
 			return nil, err
 		}
@@ -169,3 +965,400 @@ func (q *Queries) ListCashFlowsByMonth(ctx context.Context, dollar_1 pgtype.Date
 	}
 	return items, nil
 }
+
+const listDuplicateSuspects = `-- name: ListDuplicateSuspects :many
+SELECT
+  cf.cash_flow_id,
+  cf.date,
+  cf.category_id,
+  fc.name AS category_name,
+  cf.direction,
+  cf.title,
+  cf.amount,
+  cf.payee_id,
+  cf.status,
+  ed.payment_method_id
+FROM cash_flows cf
+JOIN flow_categories fc ON fc.category_id = cf.category_id
+LEFT JOIN LATERAL (
+  SELECT d.payment_method_id
+  FROM expense_details d
+  WHERE d.cash_flow_id = cf.cash_flow_id
+    AND d.payment_method_id IS NOT NULL
+  ORDER BY d.expense_detail_id
+  LIMIT 1
+) ed ON true
+WHERE cf.status <> 'CANCELLED'
+  AND fc.role <> 'TRANSFER'
+  AND ($1::date IS NULL OR cf.date >= $1::date)
+  AND ($2::date IS NULL OR cf.date <= $2::date)
+  AND EXISTS (
+    SELECT 1
+    FROM cash_flows o
+    JOIN flow_categories ofc ON ofc.category_id = o.category_id
+    WHERE o.cash_flow_id <> cf.cash_flow_id
+      AND o.direction = cf.direction
+      AND o.amount = cf.amount
+      AND o.status <> 'CANCELLED'
+      AND ofc.role <> 'TRANSFER'
+      AND o.date BETWEEN cf.date - $3::int AND cf.date + $3::int
+  )
+ORDER BY cf.direction, cf.amount, cf.date, cf.cash_flow_id
+`
+
+type ListDuplicateSuspectsParams struct {
+	DateFrom pgtype.Date
+	DateTo   pgtype.Date
+	Days     int32
+}
+
+type ListDuplicateSuspectsRow struct {
+	CashFlowID      int32
+	Date            pgtype.Date
+	CategoryID      int32
+	CategoryName    string
+	Direction       string
+	Title           string
+	Amount          money.Amount
+	PayeeID         pgtype.Int4
+	Status          string
+	PaymentMethodID pgtype.Int4
+}
+
+func (q *Queries) ListDuplicateSuspects(ctx context.Context, arg ListDuplicateSuspectsParams) ([]ListDuplicateSuspectsRow, error) {
+	rows, err := q.db.Query(ctx, listDuplicateSuspects, arg.DateFrom, arg.DateTo, arg.Days)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListDuplicateSuspectsRow
+	for rows.Next() {
+		var i ListDuplicateSuspectsRow
+		if err := rows.Scan(
+			&i.CashFlowID,
+			&i.Date,
+			&i.CategoryID,
+			&i.CategoryName,
+			&i.Direction,
+			&i.Title,
+			&i.Amount,
+			&i.PayeeID,
+			&i.Status,
+			&i.PaymentMethodID,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listFixedCashFlowsToCopy = `-- name: ListFixedCashFlowsToCopy :many
+SELECT cf.cash_flow_id, cf.date, cf.category_id, cf.direction, cf.title, cf.amount, cf.is_fixed, cf.fitid, cf.external_account, cf.source_cash_flow_id, cf.account_id, cf.cleared_at, cf.reconciliation_id, cf.status, cf.currency, cf.original_amount, cf.exchange_rate, cf.iof_amount, cf.payee_id
+FROM cash_flows cf
+WHERE date_trunc('month', cf.date) = date_trunc('month', $1::date)
+  AND cf.is_fixed = true
+  AND cf.status <> 'CANCELLED'
+  AND NOT EXISTS (
+    SELECT 1 FROM recurrence_occurrences ro
+    WHERE ro.cash_flow_id = cf.cash_flow_id
+  )
+ORDER BY cf.date, cf.cash_flow_id
+`
+
+func (q *Queries) ListFixedCashFlowsToCopy(ctx context.Context, dollar_1 pgtype.Date) ([]CashFlow, error) {
+	rows, err := q.db.Query(ctx, listFixedCashFlowsToCopy, dollar_1)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []CashFlow
+	for rows.Next() {
+		var i CashFlow
+		if err := rows.Scan(
+			&i.CashFlowID,
+			&i.Date,
+			&i.CategoryID,
+			&i.Direction,
+			&i.Title,
+			&i.Amount,
+			&i.IsFixed,
+			&i.Fitid,
+			&i.ExternalAccount,
+			&i.SourceCashFlowID,
+			&i.AccountID,
+			&i.ClearedAt,
+			&i.ReconciliationID,
+			&i.Status,
+			&i.Currency,
+			&i.OriginalAmount,
+			&i.ExchangeRate,
+			&i.IofAmount,
+			&i.PayeeID,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const searchCashFlows = `-- name: SearchCashFlows :many
+SELECT
+  cf.cash_flow_id,
+  cf.date,
+  cf.category_id,
+  cf.direction,
+  cf.title,
+  cf.amount,
+  cf.is_fixed,
+  cf.account_id,
+  cf.cleared_at,
+  cf.reconciliation_id,
+  cf.status,
+  cf.currency,
+  cf.original_amount,
+  cf.exchange_rate,
+  cf.iof_amount,
+  cf.payee_id,
+  fc.name AS category_name
+FROM cash_flows cf
+JOIN flow_categories fc ON fc.category_id = cf.category_id
+WHERE ($1::date IS NULL OR cf.date >= $1::date)
+  AND ($2::date IS NULL OR cf.date <= $2::date)
+  AND ($3::int[] IS NULL OR cf.category_id = ANY($3::int[]))
+  AND ($4::text IS NULL OR cf.direction = $4::text)
+  AND ($5::text IS NULL OR cf.title ILIKE '%' || $5::text || '%')
+  AND ($6::numeric IS NULL OR cf.amount >= $6::numeric)
+  AND ($7::numeric IS NULL OR cf.amount <= $7::numeric)
+  AND ($8::boolean IS NULL OR cf.is_fixed = $8::boolean)
+  AND ($9::text IS NULL OR cf.status = $9::text)
+  AND ($10::int IS NULL OR EXISTS (
+    SELECT 1 FROM expense_details ed
+    WHERE ed.cash_flow_id = cf.cash_flow_id
+      AND ed.payment_method_id = $10::int
+  ))
+  AND ($11::text[] IS NULL OR EXISTS (
+    SELECT 1 FROM cash_flow_tags cft
+    JOIN tags t ON t.tag_id = cft.tag_id
+    WHERE cft.cash_flow_id = cf.cash_flow_id
+      AND t.name = ANY($11::text[])
+  ))
+  AND ($12::int IS NULL OR cf.payee_id = $12::int)
+ORDER BY
+  CASE WHEN $13::text = 'date' AND NOT $14::boolean THEN cf.date END ASC,
+  CASE WHEN $13::text = 'date' AND $14::boolean THEN cf.date END DESC,
+  CASE WHEN $13::text = 'amount' AND NOT $14::boolean THEN cf.amount END ASC,
+  CASE WHEN $13::text = 'amount' AND $14::boolean THEN cf.amount END DESC,
+  CASE WHEN $13::text = 'title' AND NOT $14::boolean THEN cf.title END ASC,
+  CASE WHEN $13::text = 'title' AND $14::boolean THEN cf.title END DESC,
+  cf.cash_flow_id
+LIMIT $15::int OFFSET $16::int
+`
+
+type SearchCashFlowsParams struct {
+	DateFrom        pgtype.Date
+	DateTo          pgtype.Date
+	CategoryIds     []int32
+	Direction       pgtype.Text
+	Title           pgtype.Text
+	MinAmount       *money.Amount
+	MaxAmount       *money.Amount
+	IsFixed         pgtype.Bool
+	Status          pgtype.Text
+	PaymentMethodID pgtype.Int4
+	Tags            []string
+	PayeeID         pgtype.Int4
+	SortBy          string
+	SortDesc        bool
+	PageLimit       int32
+	PageOffset      int32
+}
+
+type SearchCashFlowsRow struct {
+	CashFlowID       int32
+	Date             pgtype.Date
+	CategoryID       int32
+	Direction        string
+	Title            string
+	Amount           money.Amount
+	IsFixed          bool
+	AccountID        pgtype.Int4
+	ClearedAt        pgtype.Timestamp
+	ReconciliationID pgtype.Int4
+	Status           string
+	Currency         pgtype.Text
+	OriginalAmount   *money.Amount
+	ExchangeRate     pgtype.Numeric
+	IofAmount        *money.Amount
+	PayeeID          pgtype.Int4
+	CategoryName     string
+}
+
+func (q *Queries) SearchCashFlows(ctx context.Context, arg SearchCashFlowsParams) ([]SearchCashFlowsRow, error) {
+	rows, err := q.db.Query(ctx, searchCashFlows,
+		arg.DateFrom,
+		arg.DateTo,
+		arg.CategoryIds,
+		arg.Direction,
+		arg.Title,
+		arg.MinAmount,
+		arg.MaxAmount,
+		arg.IsFixed,
+		arg.Status,
+		arg.PaymentMethodID,
+		arg.Tags,
+		arg.PayeeID,
+		arg.SortBy,
+		arg.SortDesc,
+		arg.PageLimit,
+		arg.PageOffset,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []SearchCashFlowsRow
+	for rows.Next() {
+		var i SearchCashFlowsRow
+		if err := rows.Scan(
+			&i.CashFlowID,
+			&i.Date,
+			&i.CategoryID,
+			&i.Direction,
+			&i.Title,
+			&i.Amount,
+			&i.IsFixed,
+			&i.AccountID,
+			&i.ClearedAt,
+			&i.ReconciliationID,
+			&i.Status,
+			&i.Currency,
+			&i.OriginalAmount,
+			&i.ExchangeRate,
+			&i.IofAmount,
+			&i.PayeeID,
+			&i.CategoryName,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const setCashFlowAccount = `-- name: SetCashFlowAccount :execrows
+UPDATE cash_flows
+SET account_id = $2
+WHERE cash_flow_id = $1
+`
+
+type SetCashFlowAccountParams struct {
+	CashFlowID int32
+	AccountID  pgtype.Int4
+}
+
+func (q *Queries) SetCashFlowAccount(ctx context.Context, arg SetCashFlowAccountParams) (int64, error) {
+	result, err := q.db.Exec(ctx, setCashFlowAccount, arg.CashFlowID, arg.AccountID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const setCashFlowPayee = `-- name: SetCashFlowPayee :execrows
+UPDATE cash_flows
+SET payee_id = $2
+WHERE cash_flow_id = $1
+`
+
+type SetCashFlowPayeeParams struct {
+	CashFlowID int32
+	PayeeID    pgtype.Int4
+}
+
+func (q *Queries) SetCashFlowPayee(ctx context.Context, arg SetCashFlowPayeeParams) (int64, error) {
+	result, err := q.db.Exec(ctx, setCashFlowPayee, arg.CashFlowID, arg.PayeeID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const updateCashFlow = `-- name: UpdateCashFlow :one
+UPDATE cash_flows
+SET date = $2,
+    category_id = $3,
+    direction = $4,
+    title = $5,
+    amount = $6,
+    is_fixed = $7,
+    currency = $8,
+    original_amount = $9,
+    exchange_rate = $10,
+    iof_amount = $11
+WHERE cash_flow_id = $1
+RETURNING cash_flow_id, date, category_id, direction, title, amount, is_fixed, fitid, external_account, source_cash_flow_id, account_id, cleared_at, reconciliation_id, status, currency, original_amount, exchange_rate, iof_amount, payee_id
+`
+
+type UpdateCashFlowParams struct {
+	CashFlowID     int32
+	Date           pgtype.Date
+	CategoryID     int32
+	Direction      string
+	Title          string
+	Amount         money.Amount
+	IsFixed        bool
+	Currency       pgtype.Text
+	OriginalAmount *money.Amount
+	ExchangeRate   pgtype.Numeric
+	IofAmount      *money.Amount
+}
+
+func (q *Queries) UpdateCashFlow(ctx context.Context, arg UpdateCashFlowParams) (CashFlow, error) {
+	row := q.db.QueryRow(ctx, updateCashFlow,
+		arg.CashFlowID,
+		arg.Date,
+		arg.CategoryID,
+		arg.Direction,
+		arg.Title,
+		arg.Amount,
+		arg.IsFixed,
+		arg.Currency,
+		arg.OriginalAmount,
+		arg.ExchangeRate,
+		arg.IofAmount,
+	)
+	var i CashFlow
+	err := row.Scan(
+		&i.CashFlowID,
+		&i.Date,
+		&i.CategoryID,
+		&i.Direction,
+		&i.Title,
+		&i.Amount,
+		&i.IsFixed,
+		&i.Fitid,
+		&i.ExternalAccount,
+		&i.SourceCashFlowID,
+		&i.AccountID,
+		&i.ClearedAt,
+		&i.ReconciliationID,
+		&i.Status,
+		&i.Currency,
+		&i.OriginalAmount,
+		&i.ExchangeRate,
+		&i.IofAmount,
+		&i.PayeeID,
+	)
+	return i, err
+}
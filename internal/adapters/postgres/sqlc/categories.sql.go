@@ -16,11 +16,12 @@ INSERT INTO flow_categories (
   name,
   direction,
   is_budget_relevant,
-  is_active
+  is_active,
+  role
 ) VALUES (
-  $1, $2, $3, $4
+  $1, $2, $3, $4, $5
 )
-RETURNING category_id, name, direction, is_budget_relevant, is_active, inactive_from_month
+RETURNING category_id, name, direction, is_budget_relevant, is_active, inactive_from_month, role
 `
 
 type CreateCategoryParams struct {
@@ -28,6 +29,7 @@ type CreateCategoryParams struct {
 	Direction        string
 	IsBudgetRelevant bool
 	IsActive         bool
+	Role             string
 }
 
 func (q *Queries) CreateCategory(ctx context.Context, arg CreateCategoryParams) (FlowCategory, error) {
@@ -36,6 +38,7 @@ func (q *Queries) CreateCategory(ctx context.Context, arg CreateCategoryParams)
 		arg.Direction,
 		arg.IsBudgetRelevant,
 		arg.IsActive,
+		arg.Role,
 	)
 	var i FlowCategory
 	err := row.Scan(
@@ -45,6 +48,7 @@ func (q *Queries) CreateCategory(ctx context.Context, arg CreateCategoryParams)
 		&i.IsBudgetRelevant,
 		&i.IsActive,
 		&i.InactiveFromMonth,
+		&i.Role,
 	)
 	return i, err
 }
@@ -54,7 +58,7 @@ UPDATE flow_categories
 SET is_active = false,
     inactive_from_month = $2
 WHERE category_id = $1
-RETURNING category_id, name, direction, is_budget_relevant, is_active, inactive_from_month
+RETURNING category_id, name, direction, is_budget_relevant, is_active, inactive_from_month, role
 `
 
 type DeactivateCategoryParams struct {
@@ -72,12 +76,13 @@ func (q *Queries) DeactivateCategory(ctx context.Context, arg DeactivateCategory
 		&i.IsBudgetRelevant,
 		&i.IsActive,
 		&i.InactiveFromMonth,
+		&i.Role,
 	)
 	return i, err
 }
 
 const getCategoryByID = `-- name: GetCategoryByID :one
-SELECT category_id, name, direction, is_budget_relevant, is_active, inactive_from_month
+SELECT category_id, name, direction, is_budget_relevant, is_active, inactive_from_month, role
 FROM flow_categories
 WHERE category_id = $1
 `
@@ -92,12 +97,13 @@ func (q *Queries) GetCategoryByID(ctx context.Context, categoryID int32) (FlowCa
 		&i.IsBudgetRelevant,
 		&i.IsActive,
 		&i.InactiveFromMonth,
+		&i.Role,
 	)
 	return i, err
 }
 
 const listCategories = `-- name: ListCategories :many
-SELECT category_id, name, direction, is_budget_relevant, is_active, inactive_from_month
+SELECT category_id, name, direction, is_budget_relevant, is_active, inactive_from_month, role
 FROM flow_categories
 WHERE ($1::boolean = false OR is_active = true)
 ORDER BY name
@@ -119,6 +125,7 @@ func (q *Queries) ListCategories(ctx context.Context, dollar_1 bool) ([]FlowCate
 			&i.IsBudgetRelevant,
 			&i.IsActive,
 			&i.InactiveFromMonth,
+			&i.Role,
 		); err != nil {
 			return nil, err
 		}
@@ -131,7 +138,7 @@ func (q *Queries) ListCategories(ctx context.Context, dollar_1 bool) ([]FlowCate
 }
 
 const listCategoriesByMonth = `-- name: ListCategoriesByMonth :many
-SELECT category_id, name, direction, is_budget_relevant, is_active, inactive_from_month
+SELECT category_id, name, direction, is_budget_relevant, is_active, inactive_from_month, role
 FROM flow_categories fc
 WHERE (
   $1::boolean = false
@@ -179,6 +186,7 @@ func (q *Queries) ListCategoriesByMonth(ctx context.Context, arg ListCategoriesB
 			&i.IsBudgetRelevant,
 			&i.IsActive,
 			&i.InactiveFromMonth,
+			&i.Role,
 		); err != nil {
 			return nil, err
 		}
@@ -195,9 +203,10 @@ UPDATE flow_categories
 SET name = $2,
     direction = $3,
     is_budget_relevant = $4,
-    is_active = $5
+    is_active = $5,
+    role = $6
 WHERE category_id = $1
-RETURNING category_id, name, direction, is_budget_relevant, is_active, inactive_from_month
+RETURNING category_id, name, direction, is_budget_relevant, is_active, inactive_from_month, role
 `
 
 type UpdateCategoryParams struct {
@@ -206,6 +215,7 @@ type UpdateCategoryParams struct {
 	Direction        string
 	IsBudgetRelevant bool
 	IsActive         bool
+	Role             string
 }
 
 func (q *Queries) UpdateCategory(ctx context.Context, arg UpdateCategoryParams) (FlowCategory, error) {
@@ -215,6 +225,7 @@ func (q *Queries) UpdateCategory(ctx context.Context, arg UpdateCategoryParams)
 		arg.Direction,
 		arg.IsBudgetRelevant,
 		arg.IsActive,
+		arg.Role,
 	)
 	var i FlowCategory
 	err := row.Scan(
@@ -224,6 +235,7 @@ func (q *Queries) UpdateCategory(ctx context.Context, arg UpdateCategoryParams)
 		&i.IsBudgetRelevant,
 		&i.IsActive,
 		&i.InactiveFromMonth,
+		&i.Role,
 	)
 	return i, err
 }
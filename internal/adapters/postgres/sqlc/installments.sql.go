@@ -8,12 +8,13 @@ package sqlc
 import (
 	"context"
 
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/money"
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
 const createExpenseDetail = `-- name: CreateExpenseDetail :exec
 INSERT INTO expense_details (cash_flow_id, payment_method_id, is_fixed, is_future, installment_plan_id, affects_card_invoice)
-VALUES ($1, $2, false, true, $3, $4)
+VALUES ($1, $2, false, (SELECT status = 'PLANNED' FROM cash_flows WHERE cash_flow_id = $1), $3, $4)
 `
 
 type CreateExpenseDetailParams struct {
@@ -34,19 +35,23 @@ func (q *Queries) CreateExpenseDetail(ctx context.Context, arg CreateExpenseDeta
 }
 
 const createInstallmentPlan = `-- name: CreateInstallmentPlan :one
-INSERT INTO installment_plans (description, total_amount, installment_count, installment_amount, start_date, payment_method_id, starts_on_current_invoice)
-VALUES ($1, $2, $3, $4, $5, $6, $7)
-RETURNING installment_plan_id, description, total_amount, installment_count, installment_amount, start_date, payment_method_id, starts_on_current_invoice, plan_type, person_id, category_id, interest_rate, interest_rate_unit, recurrence_interval_months, created_at
+INSERT INTO installment_plans (description, total_amount, installment_count, installment_amount, start_date, payment_method_id, starts_on_current_invoice, currency, original_amount, exchange_rate, iof_amount)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+RETURNING installment_plan_id, description, total_amount, installment_count, installment_amount, start_date, payment_method_id, starts_on_current_invoice, plan_type, person_id, category_id, interest_rate, interest_rate_unit, recurrence_interval_months, created_at, currency, original_amount, exchange_rate, iof_amount
 `
 
 type CreateInstallmentPlanParams struct {
 	Description            string
-	TotalAmount            pgtype.Numeric
+	TotalAmount            money.Amount
 	InstallmentCount       int32
-	InstallmentAmount      pgtype.Numeric
+	InstallmentAmount      *money.Amount
 	StartDate              pgtype.Date
 	PaymentMethodID        pgtype.Int4
 	StartsOnCurrentInvoice bool
+	Currency               pgtype.Text
+	OriginalAmount         *money.Amount
+	ExchangeRate           pgtype.Numeric
+	IofAmount              *money.Amount
 }
 
 func (q *Queries) CreateInstallmentPlan(ctx context.Context, arg CreateInstallmentPlanParams) (InstallmentPlan, error) {
@@ -58,6 +63,10 @@ func (q *Queries) CreateInstallmentPlan(ctx context.Context, arg CreateInstallme
 		arg.StartDate,
 		arg.PaymentMethodID,
 		arg.StartsOnCurrentInvoice,
+		arg.Currency,
+		arg.OriginalAmount,
+		arg.ExchangeRate,
+		arg.IofAmount,
 	)
 	var i InstallmentPlan
 	err := row.Scan(
@@ -76,6 +85,10 @@ func (q *Queries) CreateInstallmentPlan(ctx context.Context, arg CreateInstallme
 		&i.InterestRateUnit,
 		&i.RecurrenceIntervalMonths,
 		&i.CreatedAt,
+		&i.Currency,
+		&i.OriginalAmount,
+		&i.ExchangeRate,
+		&i.IofAmount,
 	)
 	return i, err
 }
@@ -5,15 +5,40 @@
 package sqlc
 
 import (
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/money"
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
+// Onde o dinheiro está: conta corrente, poupança, carteira ou saldo em corretora. O saldo inicial vale a partir de opening_date; lançamentos anteriores já estão nele.
+type Account struct {
+	AccountID      int32
+	Name           string
+	Kind           string
+	Institution    pgtype.Text
+	OpeningBalance money.Amount
+	OpeningDate    pgtype.Date
+	IsActive       bool
+	CreatedAt      pgtype.Timestamp
+}
+
+// Comprovantes e documentos de um lançamento ou de um parcelamento/picuinha. O conteúdo fica no blob store, endereçado pelo sha256.
+type Attachment struct {
+	AttachmentID      int32
+	CashFlowID        pgtype.Int4
+	InstallmentPlanID pgtype.Int4
+	FileName          string
+	ContentType       string
+	SizeBytes         int64
+	Sha256            string
+	CreatedAt         pgtype.Timestamp
+}
+
 type BudgetItem struct {
 	BudgetItemID   int32
 	BudgetPeriodID int32
 	CategoryID     int32
 	Mode           string
-	PlannedAmount  pgtype.Numeric
+	PlannedAmount  *money.Amount
 	TargetPercent  pgtype.Numeric
 	Notes          pgtype.Text
 }
@@ -27,13 +52,103 @@ type BudgetPeriod struct {
 
 // Para casos 1,2 e 3 (entradas), você preenche apenas: date, category_id (Ganho/Investimento), title, amount.
 type CashFlow struct {
+	CashFlowID       int32
+	Date             pgtype.Date
+	CategoryID       int32
+	Direction        string
+	Title            string
+	Amount           money.Amount
+	IsFixed          bool
+	Fitid            pgtype.Text
+	ExternalAccount  pgtype.Text
+	SourceCashFlowID pgtype.Int4
+	AccountID        pgtype.Int4
+	ClearedAt        pgtype.Timestamp
+	ReconciliationID pgtype.Int4
+	// PLANNED: previsto, fora dos totais realizados e dos saldos até ser confirmado. CANCELLED: previsto que não aconteceu, fora de tudo.
+	Status string
+	// Moeda em que o lançamento foi feito, quando não é a moeda base. amount já está convertido.
+	Currency       pgtype.Text
+	OriginalAmount *money.Amount
+	ExchangeRate   pgtype.Numeric
+	// IOF incluído em amount, cobrado em compras no cartão em moeda estrangeira.
+	IofAmount *money.Amount
+	PayeeID   pgtype.Int4
+}
+
+type CashFlowLine struct {
+	CashFlowID      int32
+	CashFlowSplitID pgtype.Int4
+	Date            pgtype.Date
+	Direction       string
+	CategoryID      int32
+	Amount          money.Amount
+	Status          string
+}
+
+// Valores originais de um lançamento antes de cada alteração ou exclusão. Sem FK para preservar o histórico de lançamentos excluídos.
+type CashFlowRevision struct {
+	CashFlowRevisionID int32
+	CashFlowID         int32
+	Action             string
+	Date               pgtype.Date
+	CategoryID         int32
+	Direction          string
+	Title              string
+	Amount             money.Amount
+	IsFixed            bool
+	RevisedAt          pgtype.Timestamp
+	Currency           pgtype.Text
+	OriginalAmount     *money.Amount
+	ExchangeRate       pgtype.Numeric
+	IofAmount          *money.Amount
+	Status             string
+}
+
+// Divisão de um lançamento entre várias categorias (ex.: compra de mercado com itens de Custos Fixos e Prazeres). A soma das linhas é igual ao amount do lançamento.
+type CashFlowSplit struct {
+	CashFlowSplitID int32
+	CashFlowID      int32
+	CategoryID      int32
+	Amount          money.Amount
+}
+
+type CashFlowTag struct {
 	CashFlowID int32
-	Date       pgtype.Date
-	CategoryID int32
-	Direction  string
-	Title      string
-	Amount     pgtype.Numeric
-	IsFixed    bool
+	TagID      int32
+}
+
+// Regras de categorização automática. Condições (title_pattern é regex sem diferenciar maiúsculas, direction, faixa de valor, meio de pagamento, favorecido) precisam valer todas; ações set_* e tags são aplicadas ao criar lançamentos. Regras rodam por priority crescente; em cada campo vale a primeira regra que o define.
+type CategorizationRule struct {
+	CategorizationRuleID int32
+	Name                 string
+	Priority             int32
+	IsActive             bool
+	TitlePattern         pgtype.Text
+	Direction            pgtype.Text
+	MinAmount            *money.Amount
+	MaxAmount            *money.Amount
+	PaymentMethodID      pgtype.Int4
+	PayeeID              pgtype.Int4
+	SetCategoryID        pgtype.Int4
+	SetIsFixed           pgtype.Bool
+	SetPayeeID           pgtype.Int4
+	CreatedAt            pgtype.Timestamp
+}
+
+// Tags adicionadas pela regra aos lançamentos.
+type CategorizationRuleTag struct {
+	CategorizationRuleID int32
+	TagID                int32
+}
+
+// Cotação de uma moeda na moeda base (quanto vale 1 unidade). Vale a partir de rate_date até a próxima cotação.
+type ExchangeRate struct {
+	ExchangeRateID int32
+	Currency       string
+	RateDate       pgtype.Date
+	Rate           pgtype.Numeric
+	CreatedAt      pgtype.Timestamp
 }
 
 // Entradas (Ganhos/Investimentos) NÃO precisam de registro aqui. Apenas saídas mais complexas.
@@ -55,15 +170,32 @@ type FlowCategory struct {
 	IsBudgetRelevant  bool
 	IsActive          bool
 	InactiveFromMonth pgtype.Date
+	// Papel da categoria nos relatórios. TRANSFER fica fora de receitas, despesas e orçamento.
+	Role string
+}
+
+// Mapeamento de colunas de extratos CSV por banco. Colunas são índices a partir de 0.
+type ImportProfile struct {
+	ImportProfileID int32
+	Name            string
+	Delimiter       string
+	HasHeader       bool
+	DateColumn      int32
+	DateFormat      string
+	TitleColumn     int32
+	AmountColumn    int32
+	DecimalComma    bool
+	SignConvention  string
+	CreatedAt       pgtype.Timestamp
 }
 
 // As parcelas individuais serão representadas por vários cash_flows (SAÍDAS), cada um amarrado ao installment_plan via expense_details.
 type InstallmentPlan struct {
 	InstallmentPlanID        int32
 	Description              string
-	TotalAmount              pgtype.Numeric
+	TotalAmount              money.Amount
 	InstallmentCount         int32
-	InstallmentAmount        pgtype.Numeric
+	InstallmentAmount        *money.Amount
 	StartDate                pgtype.Date
 	PaymentMethodID          pgtype.Int4
 	StartsOnCurrentInvoice   bool
@@ -74,6 +206,10 @@ type InstallmentPlan struct {
 	InterestRateUnit         pgtype.Text
 	RecurrenceIntervalMonths pgtype.Int4
 	CreatedAt                pgtype.Timestamp
+	Currency                 pgtype.Text
+	OriginalAmount           *money.Amount
+	ExchangeRate             pgtype.Numeric
+	IofAmount                *money.Amount
 }
 
 type InstallmentPlanItem struct {
@@ -81,13 +217,31 @@ type InstallmentPlanItem struct {
 	InstallmentPlanID     int32
 	InstallmentNumber     int32
 	DueDate               pgtype.Date
-	Amount                pgtype.Numeric
-	ExtraAmount           pgtype.Numeric
+	Amount                money.Amount
+	ExtraAmount           money.Amount
 	IsPaid                bool
 	PaidAt                pgtype.Timestamp
 	CashFlowID            pgtype.Int4
 }
 
+// Favorecido/estabelecimento: quem recebeu ou pagou, com categoria e meio de pagamento padrão.
+type Payee struct {
+	PayeeID                int32
+	Name                   string
+	NormalizedName         string
+	DefaultCategoryID      pgtype.Int4
+	DefaultPaymentMethodID pgtype.Int4
+	CreatedAt              pgtype.Timestamp
+}
+
+// Outras grafias do favorecido no título dos lançamentos (ex.: UBER *TRIP). normalized_alias: minúsculas, só letras, dígitos e espaços simples.
+type PayeeAlias struct {
+	PayeeAliasID    int32
+	PayeeID         int32
+	Alias           string
+	NormalizedAlias string
+}
+
 type PaymentMethod struct {
 	PaymentMethodID int32
 	Name            string
@@ -96,7 +250,8 @@ type PaymentMethod struct {
 	ClosingDay      pgtype.Int4
 	DueDay          pgtype.Int4
 	IsActive        bool
-	CreditLimit     pgtype.Numeric
+	CreditLimit     *money.Amount
+	AccountID       pgtype.Int4
 }
 
 type PicuinhaPerson struct {
@@ -104,3 +259,60 @@ type PicuinhaPerson struct {
 	Name     string
 	Notes    pgtype.Text
 }
+
+// Conferência de uma conta ou cartão contra o extrato do banco. Ao concluir, os lançamentos conferidos (cleared_at) recebem o reconciliation_id e ficam travados.
+type Reconciliation struct {
+	ReconciliationID int32
+	AccountID        pgtype.Int4
+	PaymentMethodID  pgtype.Int4
+	StatementDate    pgtype.Date
+	StatementBalance money.Amount
+	Status           string
+	ClearedBalance   *money.Amount
+	CreatedAt        pgtype.Timestamp
+	CompletedAt      pgtype.Timestamp
+}
+
+// Ocorrências já geradas por regra. occurrence_date é a data nominal (antes do ajuste de dia útil); garante que a geração seja idempotente. cash_flow_id fica nulo se o lançamento for excluído, e a ocorrência não é gerada de novo.
+type RecurrenceOccurrence struct {
+	RecurrenceRuleID int32
+	OccurrenceDate   pgtype.Date
+	CashFlowID       pgtype.Int4
+	CreatedAt        pgtype.Timestamp
+}
+
+// Lançamento modelo + regra de repetição (semanal, mensal, anual). by_month_day = -1 significa último dia do mês.
+type RecurrenceRule struct {
+	RecurrenceRuleID  int32
+	Title             string
+	CategoryID        int32
+	Direction         string
+	Amount            money.Amount
+	IsFixed           bool
+	PaymentMethodID   pgtype.Int4
+	Frequency         string
+	IntervalCount     int32
+	ByMonthDay        pgtype.Int4
+	BusinessDayAdjust string
+	StartDate         pgtype.Date
+	EndDate           pgtype.Date
+	IsActive          bool
+	CreatedAt         pgtype.Timestamp
+}
+
+// Marcadores livres que atravessam categorias (ex.: viagem-floripa-2026, reforma-cozinha). Nome sempre em minúsculas, sem espaços.
+type Tag struct {
+	TagID     int32
+	Name      string
+	CreatedAt pgtype.Timestamp
+}
+
+// Movimentação entre contas próprias: uma saída na conta de origem e uma entrada na de destino, criadas juntas. Não conta como receita nem despesa, só nos saldos das contas.
+type Transfer struct {
+	TransferID    int32
+	FromAccountID int32
+	ToAccountID   int32
+	OutCashFlowID int32
+	InCashFlowID  int32
+	CreatedAt     pgtype.Timestamp
+}
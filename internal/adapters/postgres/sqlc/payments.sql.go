@@ -8,20 +8,21 @@ package sqlc
 import (
 	"context"
 
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/money"
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
 const createPaymentMethod = `-- name: CreatePaymentMethod :one
 INSERT INTO payment_methods (name, kind, bank_name, credit_limit, closing_day, due_day, is_active)
 VALUES ($1, $2, $3, $4, $5, $6, $7)
-RETURNING payment_method_id, name, kind, bank_name, credit_limit, closing_day, due_day, is_active
+RETURNING payment_method_id, name, kind, bank_name, credit_limit, closing_day, due_day, is_active, account_id
 `
 
 type CreatePaymentMethodParams struct {
 	Name        string
 	Kind        string
 	BankName    pgtype.Text
-	CreditLimit pgtype.Numeric
+	CreditLimit *money.Amount
 	ClosingDay  pgtype.Int4
 	DueDay      pgtype.Int4
 	IsActive    bool
@@ -32,10 +33,11 @@ type CreatePaymentMethodRow struct {
 	Name            string
 	Kind            string
 	BankName        pgtype.Text
-	CreditLimit     pgtype.Numeric
+	CreditLimit     *money.Amount
 	ClosingDay      pgtype.Int4
 	DueDay          pgtype.Int4
 	IsActive        bool
+	AccountID       pgtype.Int4
 }
 
 func (q *Queries) CreatePaymentMethod(ctx context.Context, arg CreatePaymentMethodParams) (CreatePaymentMethodRow, error) {
@@ -58,6 +60,7 @@ func (q *Queries) CreatePaymentMethod(ctx context.Context, arg CreatePaymentMeth
 		&i.ClosingDay,
 		&i.DueDay,
 		&i.IsActive,
+		&i.AccountID,
 	)
 	return i, err
 }
@@ -68,12 +71,16 @@ SELECT
     cf.date, 
     cf.title, 
     cf.amount, 
+    cf.currency,
+    cf.original_amount,
+    cf.iof_amount,
     cat.name as category_name
 FROM cash_flows cf
 JOIN flow_categories cat ON cf.category_id = cat.category_id
 JOIN expense_details ed ON cf.cash_flow_id = ed.cash_flow_id
 WHERE ed.payment_method_id = $1 
   AND DATE_TRUNC('month', cf.date) = DATE_TRUNC('month', $2::date)
+  AND cf.status <> 'CANCELLED'
 ORDER BY cf.date ASC
 `
 
@@ -83,11 +90,14 @@ type GetInvoiceEntriesParams struct {
 }
 
 type GetInvoiceEntriesRow struct {
-	CashFlowID   int32
-	Date         pgtype.Date
-	Title        string
-	Amount       pgtype.Numeric
-	CategoryName string
+	CashFlowID     int32
+	Date           pgtype.Date
+	Title          string
+	Amount         money.Amount
+	Currency       pgtype.Text
+	OriginalAmount *money.Amount
+	IofAmount      *money.Amount
+	CategoryName   string
 }
 
 func (q *Queries) GetInvoiceEntries(ctx context.Context, arg GetInvoiceEntriesParams) ([]GetInvoiceEntriesRow, error) {
@@ -104,6 +114,9 @@ func (q *Queries) GetInvoiceEntries(ctx context.Context, arg GetInvoiceEntriesPa
 			&i.Date,
 			&i.Title,
 			&i.Amount,
+			&i.Currency,
+			&i.OriginalAmount,
+			&i.IofAmount,
 			&i.CategoryName,
 		); err != nil {
 			return nil, err
@@ -117,11 +130,12 @@ func (q *Queries) GetInvoiceEntries(ctx context.Context, arg GetInvoiceEntriesPa
 }
 
 const getOutstandingAmount = `-- name: GetOutstandingAmount :one
-SELECT COALESCE(SUM(cf.amount), 0)::float
+SELECT COALESCE(SUM(cf.amount), 0)::numeric
 FROM cash_flows cf
 JOIN expense_details ed ON cf.cash_flow_id = ed.cash_flow_id
 WHERE ed.payment_method_id = $1
   AND ed.affects_card_invoice = true
+  AND cf.status <> 'CANCELLED'
   AND DATE_TRUNC('month', cf.date) >= DATE_TRUNC('month', $2::date)
 `
 
@@ -130,15 +144,15 @@ type GetOutstandingAmountParams struct {
 	Column2         pgtype.Date
 }
 
-func (q *Queries) GetOutstandingAmount(ctx context.Context, arg GetOutstandingAmountParams) (float64, error) {
+func (q *Queries) GetOutstandingAmount(ctx context.Context, arg GetOutstandingAmountParams) (money.Amount, error) {
 	row := q.db.QueryRow(ctx, getOutstandingAmount, arg.PaymentMethodID, arg.Column2)
-	var column_1 float64
+	var column_1 money.Amount
 	err := row.Scan(&column_1)
 	return column_1, err
 }
 
 const getPaymentMethod = `-- name: GetPaymentMethod :one
-SELECT payment_method_id, name, kind, bank_name, credit_limit, closing_day, due_day, is_active
+SELECT payment_method_id, name, kind, bank_name, credit_limit, closing_day, due_day, is_active, account_id
 FROM payment_methods
 WHERE payment_method_id = $1
 `
@@ -148,10 +162,11 @@ type GetPaymentMethodRow struct {
 	Name            string
 	Kind            string
 	BankName        pgtype.Text
-	CreditLimit     pgtype.Numeric
+	CreditLimit     *money.Amount
 	ClosingDay      pgtype.Int4
 	DueDay          pgtype.Int4
 	IsActive        bool
+	AccountID       pgtype.Int4
 }
 
 func (q *Queries) GetPaymentMethod(ctx context.Context, paymentMethodID int32) (GetPaymentMethodRow, error) {
@@ -166,12 +181,13 @@ func (q *Queries) GetPaymentMethod(ctx context.Context, paymentMethodID int32) (
 		&i.ClosingDay,
 		&i.DueDay,
 		&i.IsActive,
+		&i.AccountID,
 	)
 	return i, err
 }
 
 const listPaymentMethods = `-- name: ListPaymentMethods :many
-SELECT payment_method_id, name, kind, bank_name, credit_limit, closing_day, due_day, is_active
+SELECT payment_method_id, name, kind, bank_name, credit_limit, closing_day, due_day, is_active, account_id
 FROM payment_methods
 WHERE ($1::boolean IS NULL OR is_active = $1)
 ORDER BY name
@@ -182,10 +198,11 @@ type ListPaymentMethodsRow struct {
 	Name            string
 	Kind            string
 	BankName        pgtype.Text
-	CreditLimit     pgtype.Numeric
+	CreditLimit     *money.Amount
 	ClosingDay      pgtype.Int4
 	DueDay          pgtype.Int4
 	IsActive        bool
+	AccountID       pgtype.Int4
 }
 
 func (q *Queries) ListPaymentMethods(ctx context.Context, isActive pgtype.Bool) ([]ListPaymentMethodsRow, error) {
@@ -206,6 +223,7 @@ func (q *Queries) ListPaymentMethods(ctx context.Context, isActive pgtype.Bool)
 			&i.ClosingDay,
 			&i.DueDay,
 			&i.IsActive,
+			&i.AccountID,
 		); err != nil {
 			return nil, err
 		}
@@ -227,7 +245,7 @@ SET name = $2,
     due_day = $7,
     is_active = $8
 WHERE payment_method_id = $1
-RETURNING payment_method_id, name, kind, bank_name, credit_limit, closing_day, due_day, is_active
+RETURNING payment_method_id, name, kind, bank_name, credit_limit, closing_day, due_day, is_active, account_id
 `
 
 type UpdatePaymentMethodParams struct {
@@ -235,7 +253,7 @@ type UpdatePaymentMethodParams struct {
 	Name            string
 	Kind            string
 	BankName        pgtype.Text
-	CreditLimit     pgtype.Numeric
+	CreditLimit     *money.Amount
 	ClosingDay      pgtype.Int4
 	DueDay          pgtype.Int4
 	IsActive        bool
@@ -246,10 +264,11 @@ type UpdatePaymentMethodRow struct {
 	Name            string
 	Kind            string
 	BankName        pgtype.Text
-	CreditLimit     pgtype.Numeric
+	CreditLimit     *money.Amount
 	ClosingDay      pgtype.Int4
 	DueDay          pgtype.Int4
 	IsActive        bool
+	AccountID       pgtype.Int4
 }
 
 func (q *Queries) UpdatePaymentMethod(ctx context.Context, arg UpdatePaymentMethodParams) (UpdatePaymentMethodRow, error) {
@@ -273,6 +292,7 @@ func (q *Queries) UpdatePaymentMethod(ctx context.Context, arg UpdatePaymentMeth
 		&i.ClosingDay,
 		&i.DueDay,
 		&i.IsActive,
+		&i.AccountID,
 	)
 	return i, err
 }
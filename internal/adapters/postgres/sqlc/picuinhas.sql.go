@@ -8,6 +8,7 @@ package sqlc
 import (
 	"context"
 
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/money"
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
@@ -60,16 +61,17 @@ INSERT INTO installment_plans (
 VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
 RETURNING installment_plan_id, description, total_amount, installment_count, installment_amount,
   start_date, payment_method_id, starts_on_current_invoice, plan_type, person_id,
-  category_id, interest_rate, interest_rate_unit, recurrence_interval_months, created_at
+  category_id, interest_rate, interest_rate_unit, recurrence_interval_months, created_at,
+  currency, original_amount, exchange_rate, iof_amount
 `
 
 type CreatePicuinhaCaseParams struct {
 	PersonID                 pgtype.Int4
 	Description              string
 	PlanType                 string
-	TotalAmount              pgtype.Numeric
+	TotalAmount              money.Amount
 	InstallmentCount         int32
-	InstallmentAmount        pgtype.Numeric
+	InstallmentAmount        *money.Amount
 	StartDate                pgtype.Date
 	PaymentMethodID          pgtype.Int4
 	CategoryID               pgtype.Int4
@@ -110,6 +112,10 @@ func (q *Queries) CreatePicuinhaCase(ctx context.Context, arg CreatePicuinhaCase
 		&i.InterestRateUnit,
 		&i.RecurrenceIntervalMonths,
 		&i.CreatedAt,
+		&i.Currency,
+		&i.OriginalAmount,
+		&i.ExchangeRate,
+		&i.IofAmount,
 	)
 	return i, err
 }
@@ -133,8 +139,8 @@ type CreatePicuinhaCaseInstallmentParams struct {
 	InstallmentPlanID int32
 	InstallmentNumber int32
 	DueDate           pgtype.Date
-	Amount            pgtype.Numeric
-	ExtraAmount       pgtype.Numeric
+	Amount            money.Amount
+	ExtraAmount       money.Amount
 	IsPaid            bool
 	PaidAt            pgtype.Timestamp
 }
@@ -216,9 +222,9 @@ SELECT (
 )::decimal
 `
 
-func (q *Queries) GetPersonBalance(ctx context.Context, personID pgtype.Int4) (pgtype.Numeric, error) {
+func (q *Queries) GetPersonBalance(ctx context.Context, personID pgtype.Int4) (money.Amount, error) {
 	row := q.db.QueryRow(ctx, getPersonBalance, personID)
-	var column_1 pgtype.Numeric
+	var column_1 money.Amount
 	err := row.Scan(&column_1)
 	return column_1, err
 }
@@ -226,7 +232,8 @@ func (q *Queries) GetPersonBalance(ctx context.Context, personID pgtype.Int4) (p
 const getPicuinhaCase = `-- name: GetPicuinhaCase :one
 SELECT installment_plan_id, description, total_amount, installment_count, installment_amount,
   start_date, payment_method_id, starts_on_current_invoice, plan_type, person_id,
-  category_id, interest_rate, interest_rate_unit, recurrence_interval_months, created_at
+  category_id, interest_rate, interest_rate_unit, recurrence_interval_months, created_at,
+  currency, original_amount, exchange_rate, iof_amount
 FROM installment_plans
 WHERE installment_plan_id = $1
   AND person_id IS NOT NULL
@@ -251,6 +258,10 @@ func (q *Queries) GetPicuinhaCase(ctx context.Context, installmentPlanID int32)
 		&i.InterestRateUnit,
 		&i.RecurrenceIntervalMonths,
 		&i.CreatedAt,
+		&i.Currency,
+		&i.OriginalAmount,
+		&i.ExchangeRate,
+		&i.IofAmount,
 	)
 	return i, err
 }
@@ -375,9 +386,9 @@ type ListPicuinhaCasesByPersonRow struct {
 	PersonID                 pgtype.Int4
 	Description              string
 	PlanType                 string
-	TotalAmount              pgtype.Numeric
+	TotalAmount              money.Amount
 	InstallmentCount         int32
-	InstallmentAmount        pgtype.Numeric
+	InstallmentAmount        *money.Amount
 	StartDate                pgtype.Date
 	PaymentMethodID          pgtype.Int4
 	CategoryID               pgtype.Int4
@@ -387,8 +398,8 @@ type ListPicuinhaCasesByPersonRow struct {
 	CreatedAt                pgtype.Timestamp
 	InstallmentsTotal        int64
 	InstallmentsPaid         int64
-	AmountPaid               pgtype.Numeric
-	AmountRemaining          pgtype.Numeric
+	AmountPaid               money.Amount
+	AmountRemaining          money.Amount
 }
 
 func (q *Queries) ListPicuinhaCasesByPerson(ctx context.Context, personID pgtype.Int4) ([]ListPicuinhaCasesByPersonRow, error) {
@@ -468,7 +479,8 @@ SET person_id = $2,
 WHERE installment_plan_id = $1
 RETURNING installment_plan_id, description, total_amount, installment_count, installment_amount,
   start_date, payment_method_id, starts_on_current_invoice, plan_type, person_id,
-  category_id, interest_rate, interest_rate_unit, recurrence_interval_months, created_at
+  category_id, interest_rate, interest_rate_unit, recurrence_interval_months, created_at,
+  currency, original_amount, exchange_rate, iof_amount
 `
 
 type UpdatePicuinhaCaseParams struct {
@@ -476,9 +488,9 @@ type UpdatePicuinhaCaseParams struct {
 	PersonID                 pgtype.Int4
 	Description              string
 	PlanType                 string
-	TotalAmount              pgtype.Numeric
+	TotalAmount              money.Amount
 	InstallmentCount         int32
-	InstallmentAmount        pgtype.Numeric
+	InstallmentAmount        *money.Amount
 	StartDate                pgtype.Date
 	PaymentMethodID          pgtype.Int4
 	CategoryID               pgtype.Int4
@@ -520,6 +532,10 @@ func (q *Queries) UpdatePicuinhaCase(ctx context.Context, arg UpdatePicuinhaCase
 		&i.InterestRateUnit,
 		&i.RecurrenceIntervalMonths,
 		&i.CreatedAt,
+		&i.Currency,
+		&i.OriginalAmount,
+		&i.ExchangeRate,
+		&i.IofAmount,
 	)
 	return i, err
 }
@@ -537,8 +553,8 @@ RETURNING installment_plan_item_id, installment_plan_id, installment_number, due
 
 type UpdatePicuinhaCaseInstallmentParams struct {
 	InstallmentPlanItemID int32
-	Amount                pgtype.Numeric
-	ExtraAmount           pgtype.Numeric
+	Amount                money.Amount
+	ExtraAmount           money.Amount
 	IsPaid                bool
 	PaidAt                pgtype.Timestamp
 }
@@ -0,0 +1,142 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/adapters/postgres/sqlc"
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/domain/tag"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type TagRepository struct {
+	db *pgxpool.Pool
+	q  *sqlc.Queries
+}
+
+func NewTagRepository(db *pgxpool.Pool) *TagRepository {
+	return &TagRepository{
+		db: db,
+		q:  sqlc.New(db),
+	}
+}
+
+func (r *TagRepository) WithinTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	return withinTx(ctx, r.db, fn)
+}
+
+func (r *TagRepository) Create(ctx context.Context, name string) (*tag.Tag, error) {
+	row, err := queriesFor(ctx, r.q).CreateTag(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	return &tag.Tag{ID: row.TagID, Name: row.Name}, nil
+}
+
+func (r *TagRepository) List(ctx context.Context) ([]tag.Tag, error) {
+	rows, err := queriesFor(ctx, r.q).ListTags(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	tags := make([]tag.Tag, len(rows))
+	for i, row := range rows {
+		tags[i] = tag.Tag{
+			ID:            row.TagID,
+			Name:          row.Name,
+			CashFlowCount: row.CashFlowCount,
+		}
+	}
+	return tags, nil
+}
+
+func (r *TagRepository) GetByID(ctx context.Context, id int32) (*tag.Tag, error) {
+	row, err := queriesFor(ctx, r.q).GetTag(ctx, id)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &tag.Tag{ID: row.TagID, Name: row.Name}, nil
+}
+
+func (r *TagRepository) GetByName(ctx context.Context, name string) (*tag.Tag, error) {
+	row, err := queriesFor(ctx, r.q).GetTagByName(ctx, name)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &tag.Tag{ID: row.TagID, Name: row.Name}, nil
+}
+
+func (r *TagRepository) Update(ctx context.Context, id int32, name string) (*tag.Tag, error) {
+	row, err := queriesFor(ctx, r.q).UpdateTag(ctx, sqlc.UpdateTagParams{
+		TagID: id,
+		Name:  name,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, tag.ErrTagNotFound
+		}
+		return nil, err
+	}
+	return &tag.Tag{ID: row.TagID, Name: row.Name}, nil
+}
+
+func (r *TagRepository) Delete(ctx context.Context, id int32) error {
+	return queriesFor(ctx, r.q).DeleteTag(ctx, id)
+}
+
+func (r *TagRepository) ListByCashFlow(ctx context.Context, cashFlowID int32) ([]tag.Tag, error) {
+	rows, err := queriesFor(ctx, r.q).ListCashFlowTags(ctx, cashFlowID)
+	if err != nil {
+		return nil, err
+	}
+
+	tags := make([]tag.Tag, len(rows))
+	for i, row := range rows {
+		tags[i] = tag.Tag{ID: row.TagID, Name: row.Name}
+	}
+	return tags, nil
+}
+
+func (r *TagRepository) SetCashFlowTags(ctx context.Context, cashFlowID int32, tagIDs []int32) error {
+	return r.WithinTx(ctx, func(ctx context.Context) error {
+		q := queriesFor(ctx, r.q)
+		if err := q.DeleteCashFlowTags(ctx, cashFlowID); err != nil {
+			return err
+		}
+		for _, id := range tagIDs {
+			if err := q.AddCashFlowTag(ctx, sqlc.AddCashFlowTagParams{
+				CashFlowID: cashFlowID,
+				TagID:      id,
+			}); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func (r *TagRepository) Report(ctx context.Context, tagID int32) ([]tag.ReportRow, error) {
+	rows, err := queriesFor(ctx, r.q).GetTagReport(ctx, tagID)
+	if err != nil {
+		return nil, err
+	}
+
+	report := make([]tag.ReportRow, len(rows))
+	for i, row := range rows {
+		report[i] = tag.ReportRow{
+			Month:        row.Month.Time,
+			CategoryID:   row.CategoryID,
+			CategoryName: row.CategoryName,
+			Direction:    row.Direction,
+			TotalAmount:  row.TotalAmount,
+		}
+	}
+	return report, nil
+}
@@ -0,0 +1,113 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/adapters/postgres/sqlc"
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/domain/transfer"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type TransferRepository struct {
+	db *pgxpool.Pool
+	q  *sqlc.Queries
+}
+
+func NewTransferRepository(db *pgxpool.Pool) *TransferRepository {
+	return &TransferRepository{
+		db: db,
+		q:  sqlc.New(db),
+	}
+}
+
+func (r *TransferRepository) WithinTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	return withinTx(ctx, r.db, fn)
+}
+
+func (r *TransferRepository) Create(ctx context.Context, t *transfer.Transfer) (*transfer.Transfer, error) {
+	row, err := queriesFor(ctx, r.q).CreateTransfer(ctx, sqlc.CreateTransferParams{
+		FromAccountID: t.FromAccountID,
+		ToAccountID:   t.ToAccountID,
+		OutCashFlowID: t.OutCashFlowID,
+		InCashFlowID:  t.InCashFlowID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	created := *t
+	created.ID = row.TransferID
+	created.CreatedAt = row.CreatedAt.Time
+	return &created, nil
+}
+
+func (r *TransferRepository) GetByID(ctx context.Context, id int32) (*transfer.Transfer, error) {
+	row, err := queriesFor(ctx, r.q).GetTransfer(ctx, id)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	t := toTransfer(sqlc.ListTransfersRow(row))
+	return &t, nil
+}
+
+func (r *TransferRepository) List(ctx context.Context, from, to *time.Time) ([]transfer.Transfer, error) {
+	rows, err := queriesFor(ctx, r.q).ListTransfers(ctx, sqlc.ListTransfersParams{
+		DateFrom: dateFromPtr(from),
+		DateTo:   dateFromPtr(to),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	transfers := make([]transfer.Transfer, len(rows))
+	for i, row := range rows {
+		transfers[i] = toTransfer(row)
+	}
+	return transfers, nil
+}
+
+func (r *TransferRepository) Delete(ctx context.Context, t *transfer.Transfer) error {
+	return r.WithinTx(ctx, func(ctx context.Context) error {
+		q := queriesFor(ctx, r.q)
+		if err := q.DeleteTransfer(ctx, t.ID); err != nil {
+			return err
+		}
+		if err := q.DeleteCashFlow(ctx, t.OutCashFlowID); err != nil {
+			return err
+		}
+		return q.DeleteCashFlow(ctx, t.InCashFlowID)
+	})
+}
+
+func (r *TransferRepository) CategoryFor(ctx context.Context, direction string) (int32, error) {
+	id, err := queriesFor(ctx, r.q).GetTransferCategory(ctx, direction)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return 0, transfer.ErrNoCategory
+		}
+		return 0, err
+	}
+	return id, nil
+}
+
+func toTransfer(row sqlc.ListTransfersRow) transfer.Transfer {
+	return transfer.Transfer{
+		ID:              row.TransferID,
+		Date:            row.Date.Time,
+		Amount:          row.Amount,
+		Title:           row.Title,
+		FromAccountID:   row.FromAccountID,
+		FromAccountName: row.FromAccountName,
+		ToAccountID:     row.ToAccountID,
+		ToAccountName:   row.ToAccountName,
+		OutCashFlowID:   row.OutCashFlowID,
+		InCashFlowID:    row.InCashFlowID,
+		CreatedAt:       row.CreatedAt.Time,
+	}
+}
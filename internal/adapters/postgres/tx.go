@@ -0,0 +1,39 @@
+package postgres
+
+import (
+	"context"
+
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/adapters/postgres/sqlc"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type txKey struct{}
+
+// withinTx runs fn inside a database transaction carried by ctx.
+// Nested calls reuse the outer transaction, so a service can compose
+// several repositories (and services) into a single atomic unit.
+func withinTx(ctx context.Context, db *pgxpool.Pool, fn func(ctx context.Context) error) error {
+	if _, ok := ctx.Value(txKey{}).(pgx.Tx); ok {
+		return fn(ctx)
+	}
+
+	tx, err := db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	if err := fn(context.WithValue(ctx, txKey{}, tx)); err != nil {
+		return err
+	}
+	return tx.Commit(ctx)
+}
+
+// queriesFor returns q bound to the transaction in ctx, if there is one.
+func queriesFor(ctx context.Context, q *sqlc.Queries) *sqlc.Queries {
+	if tx, ok := ctx.Value(txKey{}).(pgx.Tx); ok {
+		return q.WithTx(tx)
+	}
+	return q
+}
@@ -3,6 +3,7 @@ package config
 import (
 	"fmt"
 	"os"
+	"strconv"
 
 	"github.com/joho/godotenv"
 )
@@ -10,6 +11,18 @@ import (
 type Config struct {
 	DBUrl string
 	Port  string
+
+	AttachmentsDir     string
+	AttachmentMaxBytes int64
+
+	// BaseCurrency is what amounts are kept and reported in; flows in other
+	// currencies are converted. IOFRate is charged on foreign card purchases.
+	BaseCurrency string
+	IOFRate      float64
+
+	// DuplicateDays is how many days apart two cash flows may be and still
+	// be taken for the same entry.
+	DuplicateDays int
 }
 
 func Load() *Config {
@@ -34,8 +47,13 @@ func Load() *Config {
 	port := getEnvOrDefault("PORT", "8080")
 
 	return &Config{
-		DBUrl: dbUrl,
-		Port:  port,
+		DBUrl:              dbUrl,
+		Port:               port,
+		AttachmentsDir:     getEnvOrDefault("ATTACHMENTS_DIR", "data/attachments"),
+		AttachmentMaxBytes: getEnvInt64OrDefault("ATTACHMENT_MAX_BYTES", 10<<20),
+		BaseCurrency:       getEnvOrDefault("BASE_CURRENCY", "BRL"),
+		IOFRate:            getEnvFloat64OrDefault("IOF_RATE", 0.035),
+		DuplicateDays:      int(getEnvInt64OrDefault("DUPLICATE_DAYS", 3)),
 	}
 }
 
@@ -45,3 +63,21 @@ func getEnvOrDefault(key, fallback string) string {
 	}
 	return fallback
 }
+
+func getEnvInt64OrDefault(key string, fallback int64) int64 {
+	if val := os.Getenv(key); val != "" {
+		if n, err := strconv.ParseInt(val, 10, 64); err == nil {
+			return n
+		}
+	}
+	return fallback
+}
+
+func getEnvFloat64OrDefault(key string, fallback float64) float64 {
+	if val := os.Getenv(key); val != "" {
+		if f, err := strconv.ParseFloat(val, 64); err == nil {
+			return f
+		}
+	}
+	return fallback
+}
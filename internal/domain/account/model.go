@@ -0,0 +1,86 @@
+package account
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/money"
+)
+
+var (
+	ErrAccountNotFound     = errors.New("account not found")
+	ErrNameRequired        = errors.New("name is required")
+	ErrInvalidKind         = errors.New("kind must be one of: CHECKING, SAVINGS, WALLET, BROKERAGE")
+	ErrOpeningDateRequired = errors.New("opening_date is required")
+	ErrDuplicateName       = errors.New("an account with this name already exists")
+	ErrAccountInUse        = errors.New("account has cash flows or payment methods; deactivate it instead")
+	ErrInvalidRange        = errors.New("from must not be after to")
+	ErrCreditCardAccount   = errors.New("credit cards cannot be linked to an account")
+)
+
+const (
+	KindChecking  = "CHECKING"
+	KindSavings   = "SAVINGS"
+	KindWallet    = "WALLET"
+	KindBrokerage = "BROKERAGE" // cash held at a broker, not the investments
+)
+
+// Account is where money sits. The opening balance is the balance at the
+// start of OpeningDate; cash flows dated before it are already included.
+type Account struct {
+	ID             int32
+	Name           string
+	Kind           string
+	Institution    string // Optional
+	OpeningBalance money.Amount
+	OpeningDate    time.Time
+	IsActive       bool
+}
+
+func (a *Account) Validate() error {
+	a.Name = strings.TrimSpace(a.Name)
+	if a.Name == "" {
+		return ErrNameRequired
+	}
+	switch a.Kind {
+	case KindChecking, KindSavings, KindWallet, KindBrokerage:
+	default:
+		return ErrInvalidKind
+	}
+	if a.OpeningDate.IsZero() {
+		return ErrOpeningDateRequired
+	}
+	return nil
+}
+
+type Balance struct {
+	AccountID int32
+	Name      string
+	Kind      string
+	IsActive  bool
+	Balance   money.Amount
+}
+
+// DailyTotal is the movement of an account on one day.
+type DailyTotal struct {
+	Date         time.Time
+	TotalIncome  money.Amount
+	TotalExpense money.Amount
+}
+
+type DailyBalance struct {
+	DailyTotal
+	Balance money.Amount // at the end of the day
+}
+
+// RunningBalance is an account statement condensed by day. Only days with
+// movements are listed.
+type RunningBalance struct {
+	Account      Account
+	From         time.Time
+	To           time.Time
+	StartBalance money.Amount // at the start of From
+	EndBalance   money.Amount // at the end of To
+	Days         []DailyBalance
+}
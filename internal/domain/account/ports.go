@@ -0,0 +1,32 @@
+package account
+
+import (
+	"context"
+	"time"
+
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/domain/payment"
+)
+
+type Repository interface {
+	Create(ctx context.Context, a *Account) (*Account, error)
+	List(ctx context.Context, activeOnly bool) ([]Account, error)
+	GetByID(ctx context.Context, id int32) (*Account, error)
+	GetByName(ctx context.Context, name string) (*Account, error)
+	Update(ctx context.Context, a *Account) (*Account, error)
+	Delete(ctx context.Context, id int32) error
+	CountLinks(ctx context.Context, id int32) (int64, error)
+	SetPaymentMethodAccount(ctx context.Context, paymentMethodID int32, accountID *int32) error
+	GetBalances(ctx context.Context, asOf time.Time) ([]Balance, error)
+	// ListDailyTotals returns the movements from the opening date up to to.
+	ListDailyTotals(ctx context.Context, id int32, to time.Time) ([]DailyTotal, error)
+}
+
+type Service interface {
+	CreateAccount(ctx context.Context, a Account) (*Account, error)
+	ListAccounts(ctx context.Context) ([]Account, error)
+	UpdateAccount(ctx context.Context, a Account) (*Account, error)
+	DeleteAccount(ctx context.Context, id int32) error
+	GetBalances(ctx context.Context, asOf time.Time) ([]Balance, error)
+	GetRunningBalance(ctx context.Context, id int32, from, to time.Time) (*RunningBalance, error)
+	LinkPaymentMethod(ctx context.Context, paymentMethodID int32, accountID *int32) (*payment.PaymentMethod, error)
+}
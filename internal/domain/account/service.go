@@ -0,0 +1,158 @@
+package account
+
+import (
+	"context"
+	"time"
+
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/domain/payment"
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/money"
+)
+
+type AccountService struct {
+	repo    Repository
+	payRepo payment.Repository
+}
+
+func NewService(repo Repository, payRepo payment.Repository) *AccountService {
+	return &AccountService{
+		repo:    repo,
+		payRepo: payRepo,
+	}
+}
+
+func (s *AccountService) CreateAccount(ctx context.Context, a Account) (*Account, error) {
+	a.IsActive = true
+	if err := a.Validate(); err != nil {
+		return nil, err
+	}
+	if err := s.ensureUniqueName(ctx, a.Name, 0); err != nil {
+		return nil, err
+	}
+	return s.repo.Create(ctx, &a)
+}
+
+func (s *AccountService) ListAccounts(ctx context.Context) ([]Account, error) {
+	return s.repo.List(ctx, false)
+}
+
+func (s *AccountService) UpdateAccount(ctx context.Context, a Account) (*Account, error) {
+	if err := a.Validate(); err != nil {
+		return nil, err
+	}
+	existing, err := s.repo.GetByID(ctx, a.ID)
+	if err != nil {
+		return nil, err
+	}
+	if existing == nil {
+		return nil, ErrAccountNotFound
+	}
+	if err := s.ensureUniqueName(ctx, a.Name, a.ID); err != nil {
+		return nil, err
+	}
+	return s.repo.Update(ctx, &a)
+}
+
+// DeleteAccount only removes accounts nothing points to, so balances never
+// lose history.
+func (s *AccountService) DeleteAccount(ctx context.Context, id int32) error {
+	existing, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	if existing == nil {
+		return ErrAccountNotFound
+	}
+	links, err := s.repo.CountLinks(ctx, id)
+	if err != nil {
+		return err
+	}
+	if links > 0 {
+		return ErrAccountInUse
+	}
+	return s.repo.Delete(ctx, id)
+}
+
+func (s *AccountService) GetBalances(ctx context.Context, asOf time.Time) ([]Balance, error) {
+	return s.repo.GetBalances(ctx, asOf)
+}
+
+// GetRunningBalance walks the account from its opening balance and reports
+// the balance at the end of every day with movements between from and to.
+// A from before the opening date starts at the opening date.
+func (s *AccountService) GetRunningBalance(ctx context.Context, id int32, from, to time.Time) (*RunningBalance, error) {
+	if from.After(to) {
+		return nil, ErrInvalidRange
+	}
+	acc, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if acc == nil {
+		return nil, ErrAccountNotFound
+	}
+	if from.Before(acc.OpeningDate) {
+		from = acc.OpeningDate
+	}
+
+	totals, err := s.repo.ListDailyTotals(ctx, id, to)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &RunningBalance{Account: *acc, From: from, To: to, Days: []DailyBalance{}}
+	balance := acc.OpeningBalance
+	if to.Before(acc.OpeningDate) {
+		balance = money.Amount{}
+	}
+	result.StartBalance = balance
+	for _, t := range totals {
+		balance = balance.Add(t.TotalIncome).Sub(t.TotalExpense)
+		if t.Date.Before(from) {
+			result.StartBalance = balance
+			continue
+		}
+		result.Days = append(result.Days, DailyBalance{DailyTotal: t, Balance: balance})
+	}
+	result.EndBalance = balance
+	return result, nil
+}
+
+// LinkPaymentMethod maps a debit, PIX or cash method to the account it moves
+// money from; new flows paid with it land there. nil unlinks it.
+func (s *AccountService) LinkPaymentMethod(ctx context.Context, paymentMethodID int32, accountID *int32) (*payment.PaymentMethod, error) {
+	method, err := s.payRepo.GetByID(ctx, paymentMethodID)
+	if err != nil {
+		return nil, err
+	}
+	if method == nil {
+		return nil, payment.ErrPaymentMethodNotFound
+	}
+	if accountID != nil {
+		if method.Kind == payment.KindCreditCard {
+			return nil, ErrCreditCardAccount
+		}
+		acc, err := s.repo.GetByID(ctx, *accountID)
+		if err != nil {
+			return nil, err
+		}
+		if acc == nil {
+			return nil, ErrAccountNotFound
+		}
+	}
+
+	if err := s.repo.SetPaymentMethodAccount(ctx, paymentMethodID, accountID); err != nil {
+		return nil, err
+	}
+	return s.payRepo.GetByID(ctx, paymentMethodID)
+}
+
+func (s *AccountService) ensureUniqueName(ctx context.Context, name string, id int32) error {
+	other, err := s.repo.GetByName(ctx, name)
+	if err != nil {
+		return err
+	}
+	if other != nil && other.ID != id {
+		return ErrDuplicateName
+	}
+	return nil
+}
@@ -0,0 +1,82 @@
+package attachment
+
+import (
+	"errors"
+	"path/filepath"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+var (
+	ErrAttachmentNotFound      = errors.New("attachment not found")
+	ErrInstallmentPlanNotFound = errors.New("installment plan not found")
+	ErrInvalidOwnerType        = errors.New("invalid attachment owner type")
+	ErrEmptyFile               = errors.New("file is empty")
+	ErrFileTooLarge            = errors.New("file exceeds the attachment size limit")
+	ErrUnsupportedType         = errors.New("unsupported file type, use PDF, JPEG, PNG, GIF, WEBP or plain text")
+	ErrBlobNotFound            = errors.New("attachment content not found in the blob store")
+)
+
+const (
+	OwnerCashFlow        = "CASH_FLOW"
+	OwnerInstallmentPlan = "INSTALLMENT_PLAN" // card installments and picuinha cases
+
+	// DefaultMaxBytes is used when no limit is configured.
+	DefaultMaxBytes = 10 << 20
+
+	maxFileNameLength = 255
+)
+
+// allowedTypes are matched against the sniffed content type, never the one
+// sent by the client.
+var allowedTypes = map[string]bool{
+	"application/pdf": true,
+	"image/jpeg":      true,
+	"image/png":       true,
+	"image/gif":       true,
+	"image/webp":      true,
+	"text/plain":      true,
+}
+
+type Owner struct {
+	Type string
+	ID   int32
+}
+
+func (o Owner) Validate() error {
+	if o.Type != OwnerCashFlow && o.Type != OwnerInstallmentPlan {
+		return ErrInvalidOwnerType
+	}
+	return nil
+}
+
+type Attachment struct {
+	ID          int32
+	Owner       Owner
+	FileName    string
+	ContentType string
+	SizeBytes   int64
+	SHA256      string // hex; also the blob store key
+	CreatedAt   time.Time
+}
+
+// IsAllowedType reports whether a sniffed content type may be stored.
+func IsAllowedType(contentType string) bool {
+	mediaType, _, _ := strings.Cut(contentType, ";")
+	return allowedTypes[strings.TrimSpace(mediaType)]
+}
+
+// CleanFileName keeps only the base name of an uploaded file, since browsers
+// may send a full client path.
+func CleanFileName(name string) string {
+	name = strings.ReplaceAll(name, "\\", "/")
+	name = strings.TrimSpace(filepath.Base(name))
+	if name == "" || name == "." || name == "/" {
+		return "attachment"
+	}
+	if utf8.RuneCountInString(name) > maxFileNameLength {
+		name = string([]rune(name)[:maxFileNameLength])
+	}
+	return name
+}
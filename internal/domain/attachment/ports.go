@@ -0,0 +1,42 @@
+package attachment
+
+import (
+	"context"
+	"io"
+)
+
+type Repository interface {
+	WithinTx(ctx context.Context, fn func(ctx context.Context) error) error
+	Create(ctx context.Context, a *Attachment) (*Attachment, error)
+	GetByID(ctx context.Context, id int32) (*Attachment, error)
+	FindByHash(ctx context.Context, owner Owner, sha256 string) (*Attachment, error)
+	ListByOwner(ctx context.Context, owner Owner) ([]Attachment, error)
+	Delete(ctx context.Context, id int32) error
+	CountByHash(ctx context.Context, sha256 string) (int64, error)
+	// LockHash holds a lock on the content until the transaction in ctx
+	// ends, so a blob is never removed while a record for it is created.
+	LockHash(ctx context.Context, sha256 string) error
+	InstallmentPlanExists(ctx context.Context, id int32) (bool, error)
+}
+
+// BlobStore keeps attachment contents addressed by their SHA-256, so equal
+// files are stored once no matter how many records point to them.
+type BlobStore interface {
+	Put(ctx context.Context, key string, r io.Reader) error
+	Open(ctx context.Context, key string) (io.ReadCloser, error)
+	Delete(ctx context.Context, key string) error
+	// Keys lists every stored blob.
+	Keys(ctx context.Context) ([]string, error)
+}
+
+type Service interface {
+	// Upload returns the existing attachment and false when the owner
+	// already has a file with the same content.
+	Upload(ctx context.Context, owner Owner, fileName string, r io.Reader) (*Attachment, bool, error)
+	List(ctx context.Context, owner Owner) ([]Attachment, error)
+	Open(ctx context.Context, id int32) (*Attachment, io.ReadCloser, error)
+	Delete(ctx context.Context, id int32) error
+	// Prune removes the blobs no record points to anymore, such as those of
+	// deleted cash flows and installment plans, and returns how many.
+	Prune(ctx context.Context) (int, error)
+}
@@ -0,0 +1,224 @@
+package attachment
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/domain/cashflow"
+)
+
+type AttachmentService struct {
+	repo     Repository
+	blobs    BlobStore
+	cfRepo   cashflow.Repository
+	maxBytes int64
+}
+
+func NewService(repo Repository, blobs BlobStore, cfRepo cashflow.Repository, maxBytes int64) *AttachmentService {
+	if maxBytes <= 0 {
+		maxBytes = DefaultMaxBytes
+	}
+	return &AttachmentService{
+		repo:     repo,
+		blobs:    blobs,
+		cfRepo:   cfRepo,
+		maxBytes: maxBytes,
+	}
+}
+
+func (s *AttachmentService) Upload(ctx context.Context, owner Owner, fileName string, r io.Reader) (*Attachment, bool, error) {
+	if err := s.ensureOwner(ctx, owner); err != nil {
+		return nil, false, err
+	}
+
+	data, err := io.ReadAll(io.LimitReader(r, s.maxBytes+1))
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to read file: %w", err)
+	}
+	if len(data) == 0 {
+		return nil, false, ErrEmptyFile
+	}
+	if int64(len(data)) > s.maxBytes {
+		return nil, false, ErrFileTooLarge
+	}
+
+	contentType := http.DetectContentType(data)
+	if !IsAllowedType(contentType) {
+		return nil, false, ErrUnsupportedType
+	}
+
+	sum := sha256.Sum256(data)
+	hash := hex.EncodeToString(sum[:])
+
+	var (
+		created *Attachment
+		isNew   bool
+	)
+	err = s.repo.WithinTx(ctx, func(ctx context.Context) error {
+		if err := s.repo.LockHash(ctx, hash); err != nil {
+			return err
+		}
+		existing, err := s.repo.FindByHash(ctx, owner, hash)
+		if err != nil {
+			return err
+		}
+		if existing != nil {
+			created = existing
+			return nil
+		}
+
+		// The blob is written first: a failed insert leaves at most an
+		// unreferenced blob, which Prune removes, never a record without
+		// content.
+		if err := s.blobs.Put(ctx, hash, bytes.NewReader(data)); err != nil {
+			return fmt.Errorf("failed to store file: %w", err)
+		}
+		created, err = s.repo.Create(ctx, &Attachment{
+			Owner:       owner,
+			FileName:    CleanFileName(fileName),
+			ContentType: contentType,
+			SizeBytes:   int64(len(data)),
+			SHA256:      hash,
+		})
+		isNew = true
+		return err
+	})
+	if err != nil {
+		return nil, false, err
+	}
+	return created, isNew, nil
+}
+
+func (s *AttachmentService) List(ctx context.Context, owner Owner) ([]Attachment, error) {
+	if err := s.ensureOwner(ctx, owner); err != nil {
+		return nil, err
+	}
+	return s.repo.ListByOwner(ctx, owner)
+}
+
+// Open returns the attachment and its content. The caller closes the reader.
+func (s *AttachmentService) Open(ctx context.Context, id int32) (*Attachment, io.ReadCloser, error) {
+	a, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, nil, err
+	}
+	if a == nil {
+		return nil, nil, ErrAttachmentNotFound
+	}
+
+	rc, err := s.blobs.Open(ctx, a.SHA256)
+	if err != nil {
+		return nil, nil, err
+	}
+	return a, rc, nil
+}
+
+// Delete removes the record, and the blob once no other record uses it. The
+// blob goes only after the record is gone for good.
+func (s *AttachmentService) Delete(ctx context.Context, id int32) error {
+	var (
+		hash      string
+		remaining int64
+	)
+	err := s.repo.WithinTx(ctx, func(ctx context.Context) error {
+		a, err := s.repo.GetByID(ctx, id)
+		if err != nil {
+			return err
+		}
+		if a == nil {
+			return ErrAttachmentNotFound
+		}
+		hash = a.SHA256
+
+		if err := s.repo.LockHash(ctx, hash); err != nil {
+			return err
+		}
+		if err := s.repo.Delete(ctx, id); err != nil {
+			return err
+		}
+		remaining, err = s.repo.CountByHash(ctx, hash)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+	if remaining == 0 {
+		if _, err := s.removeBlob(ctx, hash); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Prune removes the blobs left behind by records deleted along with their
+// cash flow or installment plan.
+func (s *AttachmentService) Prune(ctx context.Context) (int, error) {
+	keys, err := s.blobs.Keys(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to list files: %w", err)
+	}
+
+	removed := 0
+	for _, key := range keys {
+		ok, err := s.removeBlob(ctx, key)
+		if err != nil {
+			return removed, err
+		}
+		if ok {
+			removed++
+		}
+	}
+	return removed, nil
+}
+
+// removeBlob deletes the blob if no record uses it, checking under the lock
+// uploads take, so one being attached meanwhile is kept.
+func (s *AttachmentService) removeBlob(ctx context.Context, hash string) (bool, error) {
+	removed := false
+	err := s.repo.WithinTx(ctx, func(ctx context.Context) error {
+		if err := s.repo.LockHash(ctx, hash); err != nil {
+			return err
+		}
+		count, err := s.repo.CountByHash(ctx, hash)
+		if err != nil || count > 0 {
+			return err
+		}
+		if err := s.blobs.Delete(ctx, hash); err != nil {
+			return fmt.Errorf("failed to delete file: %w", err)
+		}
+		removed = true
+		return nil
+	})
+	return removed, err
+}
+
+func (s *AttachmentService) ensureOwner(ctx context.Context, owner Owner) error {
+	if err := owner.Validate(); err != nil {
+		return err
+	}
+
+	switch owner.Type {
+	case OwnerCashFlow:
+		flow, err := s.cfRepo.GetByID(ctx, owner.ID)
+		if err != nil {
+			return err
+		}
+		if flow == nil {
+			return cashflow.ErrCashFlowNotFound
+		}
+	case OwnerInstallmentPlan:
+		exists, err := s.repo.InstallmentPlanExists(ctx, owner.ID)
+		if err != nil {
+			return err
+		}
+		if !exists {
+			return ErrInstallmentPlanNotFound
+		}
+	}
+	return nil
+}
@@ -3,6 +3,8 @@ package budget
 import (
 	"errors"
 	"time"
+
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/money"
 )
 
 var (
@@ -24,7 +26,7 @@ type BudgetPeriod struct {
 	Month        time.Time
 	AnalysisMode string
 	IsClosed     bool
-	TotalIncome  float64
+	TotalIncome  money.Amount
 	Items        []BudgetItem
 }
 
@@ -34,8 +36,8 @@ type BudgetItem struct {
 	CategoryID     int32
 	CategoryName   string
 	Mode           string // ABSOLUTE or PERCENT
-	PlannedAmount  float64
-	ActualAmount   float64 // Calculated at runtime
+	PlannedAmount  money.Amount
+	ActualAmount   money.Amount // Calculated at runtime
 	TargetPercent  float64
 	Notes          string
 }
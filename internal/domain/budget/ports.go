@@ -3,6 +3,8 @@ package budget
 import (
 	"context"
 	"time"
+
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/money"
 )
 
 type Repository interface {
@@ -17,8 +19,8 @@ type Repository interface {
 
 type Service interface {
 	GetOrCreatePeriod(ctx context.Context, month time.Time) (*BudgetPeriod, error)
-	SetBudgetItem(ctx context.Context, month time.Time, categoryID int32, mode string, plannedAmount float64, targetPercent float64) (*BudgetItem, error)
+	SetBudgetItem(ctx context.Context, month time.Time, categoryID int32, mode string, plannedAmount money.Amount, targetPercent float64) (*BudgetItem, error)
 	GetBudgetSummary(ctx context.Context, month time.Time) (*BudgetPeriod, error)
-	SetBudgetBatch(ctx context.Context, startMonth, endMonth time.Time, categoryID int32, mode string, plannedAmount float64, targetPercent float64) error
-	UpdateBudgetItem(ctx context.Context, id int32, mode string, plannedAmount float64, targetPercent float64) (*BudgetItem, error)
+	SetBudgetBatch(ctx context.Context, startMonth, endMonth time.Time, categoryID int32, mode string, plannedAmount money.Amount, targetPercent float64) error
+	UpdateBudgetItem(ctx context.Context, id int32, mode string, plannedAmount money.Amount, targetPercent float64) (*BudgetItem, error)
 }
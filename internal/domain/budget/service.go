@@ -7,6 +7,7 @@ import (
 
 	"github.com/LucasSiedschlag/HausHaltsMeister/internal/domain/cashflow"
 	"github.com/LucasSiedschlag/HausHaltsMeister/internal/domain/category"
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/money"
 )
 
 type BudgetService struct {
@@ -49,7 +50,7 @@ func (s *BudgetService) GetOrCreatePeriod(ctx context.Context, month time.Time)
 	return created, nil
 }
 
-func (s *BudgetService) SetBudgetItem(ctx context.Context, month time.Time, categoryID int32, mode string, plannedAmount float64, targetPercent float64) (*BudgetItem, error) {
+func (s *BudgetService) SetBudgetItem(ctx context.Context, month time.Time, categoryID int32, mode string, plannedAmount money.Amount, targetPercent float64) (*BudgetItem, error) {
 	// 1. Validate Category (Must be OUT and Active, potentially)
 	cat, err := s.catRepo.GetByID(ctx, categoryID)
 	if err != nil {
@@ -81,7 +82,7 @@ func (s *BudgetService) SetBudgetItem(ctx context.Context, month time.Time, cate
 
 	// 3. Upsert Item
 	if mode == ModePercentOfIncome {
-		plannedAmount = 0
+		plannedAmount = money.Amount{}
 	}
 	item := &BudgetItem{
 		BudgetPeriodID: period.ID,
@@ -115,9 +116,9 @@ func (s *BudgetService) GetBudgetSummary(ctx context.Context, month time.Time) (
 		}
 	}
 
-	// 2. Get Actuals (CashFlows)
+	// 2. Get Actuals (CashFlows, broken down by split)
 	// Assuming month is the 1st of the month
-	flows, err := s.cfRepo.ListByMonth(ctx, month)
+	lines, err := s.cfRepo.ListLinesByMonth(ctx, month)
 	if err != nil {
 		return nil, err
 	}
@@ -132,23 +133,23 @@ func (s *BudgetService) GetBudgetSummary(ctx context.Context, month time.Time) (
 	}
 
 	// 3. Aggregate Actuals by Category and total income for budget-relevant IN categories
-	actuals := make(map[int32]float64)
-	totalIncome := 0.0
-	for _, f := range flows {
+	actuals := make(map[int32]money.Amount)
+	var totalIncome money.Amount
+	for _, f := range lines {
 		if cat, ok := categoryMap[f.CategoryID]; ok {
 			if f.Direction == category.DirectionIn && cat.Direction == category.DirectionIn && cat.IsBudgetRelevant {
-				totalIncome += f.Amount
+				totalIncome = totalIncome.Add(f.Amount)
 			}
 		}
 		if f.Direction == "OUT" {
-			actuals[f.CategoryID] += f.Amount
+			actuals[f.CategoryID] = actuals[f.CategoryID].Add(f.Amount)
 		}
 	}
 
 	// 4. Enrich Items
 	for i := range period.Items {
 		if period.Items[i].Mode == ModePercentOfIncome {
-			period.Items[i].PlannedAmount = totalIncome * (period.Items[i].TargetPercent / 100.0)
+			period.Items[i].PlannedAmount = totalIncome.MulRate(period.Items[i].TargetPercent / 100.0)
 		}
 		period.Items[i].ActualAmount = actuals[period.Items[i].CategoryID]
 	}
@@ -157,7 +158,7 @@ func (s *BudgetService) GetBudgetSummary(ctx context.Context, month time.Time) (
 	return period, nil
 }
 
-func (s *BudgetService) SetBudgetBatch(ctx context.Context, startMonth, endMonth time.Time, categoryID int32, mode string, plannedAmount float64, targetPercent float64) error {
+func (s *BudgetService) SetBudgetBatch(ctx context.Context, startMonth, endMonth time.Time, categoryID int32, mode string, plannedAmount money.Amount, targetPercent float64) error {
 	// Normalize to 1st of month
 	current := time.Date(startMonth.Year(), startMonth.Month(), 1, 0, 0, 0, 0, time.UTC)
 	end := time.Date(endMonth.Year(), endMonth.Month(), 1, 0, 0, 0, 0, time.UTC)
@@ -172,7 +173,7 @@ func (s *BudgetService) SetBudgetBatch(ctx context.Context, startMonth, endMonth
 	return nil
 }
 
-func (s *BudgetService) UpdateBudgetItem(ctx context.Context, id int32, mode string, plannedAmount float64, targetPercent float64) (*BudgetItem, error) {
+func (s *BudgetService) UpdateBudgetItem(ctx context.Context, id int32, mode string, plannedAmount money.Amount, targetPercent float64) (*BudgetItem, error) {
 	if err := validateBudgetInput(mode, plannedAmount, targetPercent); err != nil {
 		return nil, err
 	}
@@ -186,7 +187,7 @@ func (s *BudgetService) UpdateBudgetItem(ctx context.Context, id int32, mode str
 	}
 
 	if mode == ModePercentOfIncome {
-		plannedAmount = 0
+		plannedAmount = money.Amount{}
 	}
 	item.Mode = mode
 	item.PlannedAmount = plannedAmount
@@ -199,7 +200,7 @@ func (s *BudgetService) UpdateBudgetItem(ctx context.Context, id int32, mode str
 	return updated, nil
 }
 
-func validateBudgetInput(mode string, plannedAmount float64, targetPercent float64) error {
+func validateBudgetInput(mode string, plannedAmount money.Amount, targetPercent float64) error {
 	switch mode {
 	case ModePercentOfIncome:
 		if targetPercent < 0 || targetPercent > 100 {
@@ -207,7 +208,7 @@ func validateBudgetInput(mode string, plannedAmount float64, targetPercent float
 		}
 		return nil
 	case ModeAbsolute:
-		if plannedAmount < 0 {
+		if plannedAmount.IsNegative() {
 			return ErrInvalidAmount
 		}
 		return nil
@@ -2,13 +2,53 @@ package cashflow
 
 import (
 	"errors"
+	"fmt"
+	"strings"
 	"time"
+
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/domain/payee"
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/money"
 )
 
 var (
 	ErrInvalidAmount = errors.New("amount must be greater than zero")
 	ErrEmptyTitle    = errors.New("title cannot be empty")
 	ErrInvalidDate   = errors.New("date is required")
+
+	ErrInvalidDateRange   = errors.New("date_from must not be after date_to")
+	ErrInvalidAmountRange = errors.New("min_amount must not be greater than max_amount")
+	ErrInvalidSort        = errors.New("sort must be one of: date, amount, title")
+	ErrInvalidDirection   = errors.New("direction must be IN or OUT")
+	ErrInvalidPage        = errors.New("limit and offset must not be negative")
+
+	ErrInvalidSplitAmount = errors.New("split amount must be greater than zero")
+	ErrSplitSumMismatch   = errors.New("split amounts must add up to the cash flow amount")
+
+	ErrInvalidStatus       = errors.New("status must be PLANNED or REALIZED")
+	ErrInvalidStatusFilter = errors.New("status must be one of: PLANNED, REALIZED, CANCELLED")
+
+	ErrExchangeRateNotFound = errors.New("no exchange rate for the currency on or before the cash flow date")
+	ErrIOFNotForeign        = errors.New("iof_amount only applies to amounts in a foreign currency")
+	ErrInvalidIOF           = errors.New("iof_amount must not be negative")
+	ErrConvertedAmount      = errors.New("the amount of a cash flow in a foreign currency can only change together with its currency")
+
+	ErrPossibleDuplicate = errors.New("cash flow looks like a duplicate of an existing one")
+	ErrInvalidDays       = errors.New("days must be between 0 and 31")
+
+	ErrInvalidCompare = errors.New("compare must be one of: previous_month, previous_year, avg_3m, avg_12m")
+)
+
+// DefaultIOFRate is the IOF charged on card purchases in a foreign currency
+// (3.5% since 2025), unless configured otherwise.
+const DefaultIOFRate = 0.035
+
+// A cash flow is either expected (planned) or has happened (realized). Planned
+// flows stay out of realized totals and account balances until confirmed, or
+// are cancelled when they do not happen.
+const (
+	StatusPlanned   = "PLANNED"
+	StatusRealized  = "REALIZED"
+	StatusCancelled = "CANCELLED"
 )
 
 type CashFlow struct {
@@ -18,24 +58,334 @@ type CashFlow struct {
 	CategoryName string // Enriched field for display
 	Direction    string
 	Title        string
-	Amount       float64
+	Amount       money.Amount
 	IsFixed      bool
+
+	// Set for flows imported from OFX statements.
+	FITID           string
+	ExternalAccount string
+
+	// Set for copies made by CopyFixedExpenses.
+	SourceCashFlowID *int32
+
+	// AccountID is where the money moved, when known.
+	AccountID *int32
+
+	// PayeeID is who the money went to or came from, when known.
+	PayeeID *int32
+
+	// ClearedAt is set when the flow was checked against a bank statement;
+	// ReconciliationID once that reconciliation is completed, which locks it.
+	ClearedAt        *time.Time
+	ReconciliationID *int32
+
+	Status string
+
+	// Conversion is set for flows entered in a foreign currency. Amount is
+	// always in the base currency.
+	Conversion *Conversion
+
+	// Splits, when present, spread the amount over several categories.
+	// Loaded only where noted.
+	Splits []Split
+}
+
+// Conversion records how an amount in a foreign currency became the amount
+// in the base currency: OriginalAmount times Rate, plus IOFAmount.
+type Conversion struct {
+	Currency       string
+	OriginalAmount money.Amount
+	Rate           float64 // base currency per unit of Currency, in force on the date
+	IOFAmount      money.Amount
+}
+
+// Split is one category share of a cash flow. The splits of a flow add up to
+// its amount; reports aggregate them instead of the flow's own category.
+type Split struct {
+	ID           int32
+	CategoryID   int32
+	CategoryName string
+	Amount       money.Amount
+}
+
+// Line is what summaries aggregate: one per split, or the whole flow with
+// its own category when it has no splits.
+type Line struct {
+	CashFlowID int32
+	SplitID    *int32
+	Date       time.Time
+	Direction  string
+	CategoryID int32
+	Amount     money.Amount
+}
+
+// ValidateSplits checks that every split is positive and that together they
+// add up exactly to amount.
+func ValidateSplits(amount money.Amount, splits []Split) error {
+	var total money.Amount
+	for _, sp := range splits {
+		if !sp.Amount.IsPositive() {
+			return ErrInvalidSplitAmount
+		}
+		total = total.Add(sp.Amount)
+	}
+	if total != amount {
+		return ErrSplitSumMismatch
+	}
+	return nil
+}
+
+// CreateCashFlowRequest carries everything needed to create a cash flow,
+// including the optional links the positional CreateCashFlow leaves out.
+type CreateCashFlowRequest struct {
+	Date       time.Time
+	CategoryID int32
+	Direction  string
+	Title      string
+	Amount     money.Amount
+	IsFixed    bool
+
+	// PaymentMethodID adds expense details so the flow shows up on the
+	// payment method (e.g. on a credit card invoice).
+	PaymentMethodID    *int32
+	AffectsCardInvoice bool
+
+	FITID           string
+	ExternalAccount string
+
+	SourceCashFlowID *int32
+
+	// AccountID defaults to the account of the payment method, for methods
+	// that move money directly (debit card, PIX, cash).
+	AccountID *int32
+
+	// PayeeID links the flow to a payee. Without it, the payee whose name or
+	// alias the title starts with is linked, if any. A zero CategoryID takes
+	// the payee's default category when its direction fits.
+	PayeeID *int32
+
+	// Status is REALIZED when empty; PLANNED enters an expected flow.
+	Status string
+
+	// Currency, when other than the base currency, means Amount is in that
+	// currency. It is converted at the rate in force on Date, plus IOF:
+	// IOFAmount when given, otherwise computed for card purchases
+	// (AffectsCardInvoice).
+	Currency  string
+	IOFAmount *money.Amount
+
+	// Conversion, when set, is stored as is and Amount is taken to be in
+	// the base currency already. Installment plans convert the purchase
+	// once and pass each installment's share.
+	Conversion *Conversion
+
+	// Splits are optional; each one needs a category of the same direction.
+	Splits []Split
+
+	// CheckDuplicates makes Create fail with a *DuplicateError when the
+	// flow looks like one already entered. Flows generated by the system
+	// (copies, recurrences, installments, reviewed imports) leave it off.
+	CheckDuplicates bool
+}
+
+const (
+	RevisionActionUpdate = "UPDATE"
+	RevisionActionDelete = "DELETE"
+)
+
+// Revision is a snapshot of a cash flow as it was before an update or delete.
+type Revision struct {
+	ID         int32
+	CashFlowID int32
+	Action     string
+	Date       time.Time
+	CategoryID int32
+	Direction  string
+	Title      string
+	Amount     money.Amount
+	IsFixed    bool
+	Status     string
+	Conversion *Conversion // nil for flows in the base currency
+	RevisedAt  time.Time
+}
+
+const (
+	SortByDate   = "date"
+	SortByAmount = "amount"
+	SortByTitle  = "title"
+
+	DefaultPageLimit = 50
+	MaxPageLimit     = 200
+)
+
+// Filter narrows a cash flow search. Zero values (nil pointers, empty
+// strings and slices) mean "no restriction" for that criterion.
+type Filter struct {
+	DateFrom        *time.Time
+	DateTo          *time.Time
+	CategoryIDs     []int32
+	Direction       string
+	Title           string // case-insensitive substring
+	MinAmount       *money.Amount
+	MaxAmount       *money.Amount
+	IsFixed         *bool
+	PaymentMethodID *int32
+	Tags            []string // flows with any of these tags
+	PayeeID         *int32
+	Status          string
+
+	SortBy   string
+	SortDesc bool
+	Limit    int32
+	Offset   int32
+}
+
+// Normalize validates the filter and fills in paging and sorting defaults.
+func (f *Filter) Normalize() error {
+	if f.DateFrom != nil && f.DateTo != nil && f.DateFrom.After(*f.DateTo) {
+		return ErrInvalidDateRange
+	}
+	if f.MinAmount != nil && f.MaxAmount != nil && f.MinAmount.Cmp(*f.MaxAmount) > 0 {
+		return ErrInvalidAmountRange
+	}
+	if f.Direction != "" && f.Direction != "IN" && f.Direction != "OUT" {
+		return ErrInvalidDirection
+	}
+	switch f.Status {
+	case "", StatusPlanned, StatusRealized, StatusCancelled:
+	default:
+		return ErrInvalidStatusFilter
+	}
+	for i, tag := range f.Tags {
+		f.Tags[i] = strings.ToLower(strings.TrimSpace(tag))
+	}
+
+	switch f.SortBy {
+	case "":
+		f.SortBy = SortByDate
+	case SortByDate, SortByAmount, SortByTitle:
+	default:
+		return ErrInvalidSort
+	}
+
+	if f.Limit < 0 || f.Offset < 0 {
+		return ErrInvalidPage
+	}
+	if f.Limit == 0 {
+		f.Limit = DefaultPageLimit
+	}
+	if f.Limit > MaxPageLimit {
+		f.Limit = MaxPageLimit
+	}
+	return nil
+}
+
+// Page is one slice of a search result plus the total number of matches.
+type Page struct {
+	Items  []*CashFlow
+	Total  int64
+	Limit  int32
+	Offset int32
 }
 
 type MonthlySummary struct {
-	TotalIncome  float64 `json:"total_income"`
-	TotalExpense float64 `json:"total_expense"`
-	Balance      float64 `json:"balance"`
+	TotalIncome  money.Amount `json:"total_income"`
+	TotalExpense money.Amount `json:"total_expense"`
+	Balance      money.Amount `json:"balance"`
 }
 
 type CategorySummary struct {
-	CategoryName string  `json:"category_name"`
-	Direction    string  `json:"direction"`
-	TotalAmount  float64 `json:"total_amount"`
+	CategoryName string       `json:"category_name"`
+	Direction    string       `json:"direction"`
+	TotalAmount  money.Amount `json:"total_amount"`
+}
+
+// A summary month can be compared with the month before, the same month a
+// year before, or the average of the 3 or 12 months before it.
+const (
+	ComparePreviousMonth = "previous_month"
+	ComparePreviousYear  = "previous_year"
+	CompareAverage3      = "avg_3m"
+	CompareAverage12     = "avg_12m"
+)
+
+// SwingCount is how many categories are flagged as the biggest swings.
+const SwingCount = 3
+
+// ComparisonPeriod is what a month is compared against: the months from
+// From through To, both month starts.
+type ComparisonPeriod struct {
+	Mode   string
+	Month  time.Time
+	From   time.Time
+	To     time.Time
+	Months int
+}
+
+// NewComparisonPeriod returns the baseline of month for the mode.
+func NewComparisonPeriod(month time.Time, mode string) (ComparisonPeriod, error) {
+	month = time.Date(month.Year(), month.Month(), 1, 0, 0, 0, 0, time.UTC)
+	p := ComparisonPeriod{Mode: mode, Month: month, To: month.AddDate(0, -1, 0), Months: 1}
+	switch mode {
+	case ComparePreviousMonth:
+	case ComparePreviousYear:
+		p.To = month.AddDate(-1, 0, 0)
+	case CompareAverage3:
+		p.Months = 3
+	case CompareAverage12:
+		p.Months = 12
+	default:
+		return ComparisonPeriod{}, ErrInvalidCompare
+	}
+	p.From = p.To.AddDate(0, 1-p.Months, 0)
+	return p, nil
 }
 
-func New(date time.Time, categoryID int32, direction, title string, amount float64, isFixed bool) (*CashFlow, error) {
-	if amount <= 0 {
+// Change is how an amount moved from the baseline, which is a monthly
+// average over longer periods. Percent is nil when the baseline is zero.
+type Change struct {
+	Baseline money.Amount
+	Delta    money.Amount
+	Percent  *float64
+}
+
+// NewChange compares current with baseline. The percentage is taken over
+// the baseline's absolute value, so a balance going from -100 to -50 is a
+// 50% rise.
+func NewChange(current, baseline money.Amount) Change {
+	c := Change{Baseline: baseline, Delta: current.Sub(baseline)}
+	if !baseline.IsZero() {
+		pct := c.Delta.Float64() / baseline.Abs().Float64() * 100
+		c.Percent = &pct
+	}
+	return c
+}
+
+// CategoryTotal is a category summary that keeps the category's ID.
+type CategoryTotal struct {
+	CategoryID int32
+	CategorySummary
+}
+
+type CategoryComparison struct {
+	CategoryTotal
+	Change
+	// BiggestSwing flags the SwingCount categories that moved the most.
+	BiggestSwing bool
+}
+
+type MonthlyComparison struct {
+	Period       ComparisonPeriod
+	Current      MonthlySummary
+	TotalIncome  Change
+	TotalExpense Change
+	Balance      Change
+	// Swings are the flagged categories, biggest first.
+	Swings []CategoryComparison
+}
+
+func New(date time.Time, categoryID int32, direction, title string, amount money.Amount, isFixed bool) (*CashFlow, error) {
+	if !amount.IsPositive() {
 		return nil, ErrInvalidAmount
 	}
 	if title == "" {
@@ -54,5 +404,166 @@ func New(date time.Time, categoryID int32, direction, title string, amount float
 		Title:      title,
 		Amount:     amount,
 		IsFixed:    isFixed,
+		Status:     StatusRealized,
 	}, nil
 }
+
+// CopyItem is a fixed flow considered by CopyFixedExpenses, with the date it
+// gets in the target month.
+type CopyItem struct {
+	SourceID   int32
+	Date       time.Time
+	CategoryID int32
+	Direction  string
+	Title      string
+	Amount     money.Amount
+	CashFlowID *int32 // the copy, or the existing flow for skipped and conflicting items
+}
+
+// CopyReport tells what CopyFixedExpenses did, or would do on a dry run.
+type CopyReport struct {
+	DryRun  bool
+	Created []CopyItem
+	Skipped []CopyItem // already copied by a previous run
+	// Conflicts are flows the target month already has with the same title,
+	// category and direction but that were not copied from the source, e.g.
+	// entered by hand. They are not copied.
+	Conflicts []CopyItem
+}
+
+// Facts are what categorization rules look at in a flow.
+type Facts struct {
+	Title           string
+	Direction       string
+	Amount          money.Amount // in the base currency
+	PaymentMethodID *int32
+	PayeeID         *int32
+}
+
+// Actions are what the rules matching a flow set on it. Each field comes from
+// the first rule, in priority order, that sets it; tags add up. Nil fields
+// are left alone.
+type Actions struct {
+	RuleIDs      []int32
+	CategoryID   *int32
+	CategoryName string
+	IsFixed      *bool
+	PayeeID      *int32
+	TagIDs       []int32
+}
+
+// Duplicate detection looks for flows entered twice, e.g. from the web and
+// from a script: same direction and amount, dates at most DuplicateDays
+// apart, the same payment method when both have one, and titles that are
+// alike or the same payee.
+const (
+	DefaultDuplicateDays = 3
+	MaxDuplicateDays     = 31
+	MinTitleSimilarity   = 0.6
+)
+
+// DuplicateCandidate is an existing flow as duplicate detection sees it.
+type DuplicateCandidate struct {
+	ID              int32
+	Date            time.Time
+	CategoryID      int32
+	CategoryName    string
+	Direction       string
+	Title           string
+	Amount          money.Amount
+	PayeeID         *int32
+	PaymentMethodID *int32
+	Status          string
+}
+
+// DuplicateError is returned by Create when CheckDuplicates finds flows the
+// new one may repeat. It matches ErrPossibleDuplicate.
+type DuplicateError struct {
+	Candidates []DuplicateCandidate
+}
+
+func (e *DuplicateError) Error() string {
+	return fmt.Sprintf("%v: %d candidate(s)", ErrPossibleDuplicate, len(e.Candidates))
+}
+
+func (e *DuplicateError) Unwrap() error {
+	return ErrPossibleDuplicate
+}
+
+// DuplicateFilter narrows the duplicates report. Days is how far apart two
+// flows may be; nil means the service default.
+type DuplicateFilter struct {
+	From *time.Time
+	To   *time.Time
+	Days *int
+}
+
+// DuplicatePair is two existing flows that look like the same entry. First
+// is the older one.
+type DuplicatePair struct {
+	First      DuplicateCandidate
+	Second     DuplicateCandidate
+	DaysApart  int
+	Similarity float64
+}
+
+// LooksDuplicate tells whether b looks like a repeat of a, which must already
+// have the same direction and amount, and how alike their titles are.
+func LooksDuplicate(a, b DuplicateCandidate, days int) (float64, bool) {
+	apart := daysApart(a.Date, b.Date)
+	if apart > days {
+		return 0, false
+	}
+	if a.PaymentMethodID != nil && b.PaymentMethodID != nil && *a.PaymentMethodID != *b.PaymentMethodID {
+		return 0, false
+	}
+	similarity := TitleSimilarity(a.Title, b.Title)
+	if a.PayeeID != nil && b.PayeeID != nil && *a.PayeeID == *b.PayeeID {
+		return similarity, true
+	}
+	return similarity, similarity >= MinTitleSimilarity
+}
+
+// TitleSimilarity compares two titles after normalizing them like payee
+// names, from 0 (nothing alike) to 1. A title that starts the other as whole
+// words counts as 1 ("Uber" and "UBER *TRIP 1234"); otherwise it is the Dice
+// coefficient of their letter pairs.
+func TitleSimilarity(a, b string) float64 {
+	a, b = payee.Normalize(a), payee.Normalize(b)
+	if a == "" || b == "" {
+		return 0
+	}
+	if len(a) > len(b) {
+		a, b = b, a
+	}
+	if a == b || strings.HasPrefix(b, a+" ") {
+		return 1
+	}
+
+	ra, rb := []rune(a), []rune(b)
+	pairs := make(map[[2]rune]int)
+	for i := 0; i+1 < len(ra); i++ {
+		pairs[[2]rune{ra[i], ra[i+1]}]++
+	}
+	var shared int
+	for i := 0; i+1 < len(rb); i++ {
+		pair := [2]rune{rb[i], rb[i+1]}
+		if pairs[pair] > 0 {
+			pairs[pair]--
+			shared++
+		}
+	}
+	total := len(ra) - 1 + len(rb) - 1
+	if total <= 0 {
+		return 0
+	}
+	return float64(2*shared) / float64(total)
+}
+
+func daysApart(a, b time.Time) int {
+	d := int(b.Sub(a).Hours() / 24)
+	if d < 0 {
+		return -d
+	}
+	return d
+}
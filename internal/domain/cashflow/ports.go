@@ -3,19 +3,93 @@ package cashflow
 import (
 	"context"
 	"time"
+
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/domain/category"
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/domain/payee"
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/money"
 )
 
 type Repository interface {
+	WithinTx(ctx context.Context, fn func(ctx context.Context) error) error
 	Create(ctx context.Context, flow *CashFlow) (*CashFlow, error)
+	CreateExpenseDetail(ctx context.Context, cashFlowID, paymentMethodID int32, isFixed, affectsCardInvoice bool) error
+	CreateSplit(ctx context.Context, cashFlowID int32, split Split) (*Split, error)
+	ListSplits(ctx context.Context, cashFlowID int32) ([]Split, error)
+	DeleteSplits(ctx context.Context, cashFlowID int32) error
+	GetByID(ctx context.Context, id int32) (*CashFlow, error)
+	Update(ctx context.Context, flow *CashFlow) (*CashFlow, error)
+	Delete(ctx context.Context, id int32) error
+	// Confirm and Cancel only apply to planned flows; others get ErrNotPlanned.
+	Confirm(ctx context.Context, id int32, date time.Time, amount money.Amount, conversion *Conversion) (*CashFlow, error)
+	Cancel(ctx context.Context, id int32) error
+	CountInstallmentLinks(ctx context.Context, id int32) (int64, error)
+	IsTransferLeg(ctx context.Context, id int32) (bool, error)
+	SetAccount(ctx context.Context, id int32, accountID *int32) error
+	SetPayee(ctx context.Context, id int32, payeeID *int32) error
+	AddTags(ctx context.Context, id int32, tagIDs []int32) error
+	// GetPayee and MatchPayee return nil when there is no such payee.
+	GetPayee(ctx context.Context, id int32) (*payee.Payee, error)
+	MatchPayee(ctx context.Context, title string) (*payee.Payee, error)
+	AccountExists(ctx context.Context, id int32) (bool, error)
+	PaymentMethodAccount(ctx context.Context, paymentMethodID int32) (*int32, error)
+	// ExchangeRate returns the rate of currency in force on date; nil when
+	// there is none.
+	ExchangeRate(ctx context.Context, currency string, date time.Time) (*float64, error)
+	CreateRevision(ctx context.Context, original *CashFlow, action string) error
+	ListRevisions(ctx context.Context, id int32) ([]Revision, error)
 	ListByMonth(ctx context.Context, month time.Time) ([]*CashFlow, error)
-	GetMonthlySummary(ctx context.Context, month time.Time) (*MonthlySummary, error)
-	GetCategorySummary(ctx context.Context, month time.Time) ([]CategorySummary, error)
+	// ListFixedToCopy returns the fixed flows of the month, except those
+	// generated by recurrence rules.
+	ListFixedToCopy(ctx context.Context, month time.Time) ([]*CashFlow, error)
+	ListCopyTargets(ctx context.Context, month time.Time) ([]*CashFlow, error)
+	// ListLinesByMonth returns the month's flows broken down by split.
+	ListLinesByMonth(ctx context.Context, month time.Time) ([]Line, error)
+	Search(ctx context.Context, filter Filter) ([]*CashFlow, error)
+	Count(ctx context.Context, filter Filter) (int64, error)
+	GetMonthlySummary(ctx context.Context, month time.Time, includePlanned bool) (*MonthlySummary, error)
+	GetCategorySummary(ctx context.Context, month time.Time, includePlanned bool) ([]CategorySummary, error)
+	// GetCategoryTotals totals each category from the month of from through
+	// the month of to.
+	GetCategoryTotals(ctx context.Context, from, to time.Time, includePlanned bool) ([]CategoryTotal, error)
+	// ListDuplicateCandidates returns the flows with the direction and
+	// amount dated between from and to; ListDuplicateSuspects the flows
+	// having another one with the same direction and amount at most days
+	// apart. Both leave out cancelled flows and transfers.
+	ListDuplicateCandidates(ctx context.Context, direction string, amount money.Amount, from, to time.Time) ([]DuplicateCandidate, error)
+	ListDuplicateSuspects(ctx context.Context, from, to *time.Time, days int) ([]DuplicateCandidate, error)
 }
 
 type Service interface {
-	CreateCashFlow(ctx context.Context, date time.Time, categoryID int32, direction, title string, amount float64, isFixed bool) (*CashFlow, error)
+	CreateCashFlow(ctx context.Context, date time.Time, categoryID int32, direction, title string, amount money.Amount, isFixed bool) (*CashFlow, error)
+	Create(ctx context.Context, req CreateCashFlowRequest) (*CashFlow, error)
+	Convert(ctx context.Context, currency string, date time.Time, amount money.Amount, iof *money.Amount, cardPurchase bool) (money.Amount, *Conversion, error)
+	UpdateCashFlow(ctx context.Context, id int32, date time.Time, categoryID int32, direction, title string, amount money.Amount, isFixed bool, currency string) (*CashFlow, error)
+	DeleteCashFlow(ctx context.Context, id int32) error
+	ConfirmCashFlow(ctx context.Context, id int32, date *time.Time, amount *money.Amount) (*CashFlow, error)
+	CancelCashFlow(ctx context.Context, id int32) (*CashFlow, error)
+	ListRevisions(ctx context.Context, id int32) ([]Revision, error)
+	ListSplits(ctx context.Context, id int32) ([]Split, error)
+	SetSplits(ctx context.Context, id int32, splits []Split) ([]Split, error)
+	SetAccount(ctx context.Context, id int32, accountID *int32) (*CashFlow, error)
+	SetPayee(ctx context.Context, id int32, payeeID *int32) (*CashFlow, error)
+	ResolvePayee(ctx context.Context, payeeID *int32, title string) (*payee.Payee, error)
+	PayeeCategory(ctx context.Context, p *payee.Payee, direction string) (*category.Category, error)
+	EvaluateRules(ctx context.Context, facts Facts) (*Actions, error)
+	PrepareRules(ctx context.Context) (context.Context, error)
 	ListCashFlows(ctx context.Context, month time.Time) ([]*CashFlow, error)
-	CopyFixedExpenses(ctx context.Context, fromMonth, toMonth time.Time) (int, error)
-	GetMonthlySummary(ctx context.Context, month time.Time) (*MonthlySummary, error)
-	GetCategorySummary(ctx context.Context, month time.Time) ([]CategorySummary, error)
+	SearchCashFlows(ctx context.Context, filter Filter) (*Page, error)
+	CopyFixedExpenses(ctx context.Context, fromMonth, toMonth time.Time, dryRun bool) (*CopyReport, error)
+	ListDuplicates(ctx context.Context, filter DuplicateFilter) ([]DuplicatePair, error)
+	GetMonthlySummary(ctx context.Context, month time.Time, includePlanned bool) (*MonthlySummary, error)
+	GetCategorySummary(ctx context.Context, month time.Time, includePlanned bool) ([]CategorySummary, error)
+	CompareMonthlySummary(ctx context.Context, month time.Time, includePlanned bool, mode string) (*MonthlyComparison, error)
+	CompareCategorySummary(ctx context.Context, month time.Time, includePlanned bool, mode string) ([]CategoryComparison, error)
+}
+
+// RuleEvaluator runs the user's categorization rules against a flow about to
+// be created.
+type RuleEvaluator interface {
+	Evaluate(ctx context.Context, facts Facts) (*Actions, error)
+	// Prepare loads the rules once, for evaluating a batch of flows.
+	Prepare(ctx context.Context) (RuleEvaluator, error)
 }
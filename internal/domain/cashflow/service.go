@@ -4,109 +4,1096 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"sort"
+	"strings"
 	"time"
 
 	"github.com/LucasSiedschlag/HausHaltsMeister/internal/domain/category"
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/domain/exchange"
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/domain/payee"
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/money"
 )
 
 var (
 	ErrCategoryNotFound  = errors.New("category not found")
 	ErrDirectionMismatch = errors.New("cash flow direction does not match category direction")
+	ErrCashFlowNotFound  = errors.New("cash flow not found")
+	ErrCashFlowLinked    = errors.New("cash flow is linked to an installment plan")
+	ErrCopySameMonth     = errors.New("from_month and to_month must be different months")
+	ErrAccountNotFound   = errors.New("account not found")
+	ErrTransferLeg       = errors.New("cash flow is part of a transfer; change the transfer instead")
+	ErrTransferCategory  = errors.New("transfer categories are reserved for transfers")
+	ErrCashFlowLocked    = errors.New("cash flow is locked by a completed reconciliation")
+	ErrNotPlanned        = errors.New("only planned cash flows can be confirmed or cancelled")
 )
 
 type CashFlowService struct {
 	repo    Repository
 	catRepo category.Repository
+	rules   RuleEvaluator
+
+	baseCurrency string
+	iofRate      float64
+
+	duplicateDays int
 }
 
 func NewService(repo Repository, catRepo category.Repository) *CashFlowService {
 	return &CashFlowService{
-		repo:    repo,
-		catRepo: catRepo,
+		repo:         repo,
+		catRepo:      catRepo,
+		baseCurrency: exchange.DefaultBaseCurrency,
+		iofRate:      DefaultIOFRate,
+
+		duplicateDays: DefaultDuplicateDays,
 	}
 }
 
-func (s *CashFlowService) CreateCashFlow(ctx context.Context, date time.Time, categoryID int32, direction, title string, amount float64, isFixed bool) (*CashFlow, error) {
-	newFlow, err := New(date, categoryID, direction, title, amount, isFixed)
+// SetCurrency changes the base currency amounts are kept in and the IOF rate
+// charged on foreign card purchases.
+func (s *CashFlowService) SetCurrency(baseCurrency string, iofRate float64) {
+	s.baseCurrency = baseCurrency
+	s.iofRate = iofRate
+}
+
+// SetDuplicateDays changes how many days apart two flows may be and still
+// be taken for duplicates.
+func (s *CashFlowService) SetDuplicateDays(days int) {
+	s.duplicateDays = days
+}
+
+// SetRules makes Create run the categorization rules of r. Without it no
+// rules are applied.
+func (s *CashFlowService) SetRules(r RuleEvaluator) {
+	s.rules = r
+}
+
+// Convert turns amount in currency into the base currency at the rate in
+// force on date. IOF is added on top: iof when given, otherwise the
+// configured rate for card purchases. The conversion is nil when currency is
+// the base currency.
+func (s *CashFlowService) Convert(ctx context.Context, currency string, date time.Time, amount money.Amount, iof *money.Amount, cardPurchase bool) (money.Amount, *Conversion, error) {
+	currency, err := exchange.NormalizeCurrency(currency)
+	if err != nil {
+		return money.Amount{}, nil, err
+	}
+	if currency == s.baseCurrency {
+		if iof != nil {
+			return money.Amount{}, nil, ErrIOFNotForeign
+		}
+		return amount, nil, nil
+	}
+	if !amount.IsPositive() {
+		return money.Amount{}, nil, ErrInvalidAmount
+	}
+	if iof != nil && iof.IsNegative() {
+		return money.Amount{}, nil, ErrInvalidIOF
+	}
+
+	rate, err := s.repo.ExchangeRate(ctx, currency, date)
+	if err != nil {
+		return money.Amount{}, nil, err
+	}
+	if rate == nil {
+		return money.Amount{}, nil, ErrExchangeRateNotFound
+	}
+
+	converted := amount.MulRate(*rate)
+	conversion := &Conversion{Currency: currency, OriginalAmount: amount, Rate: *rate}
+	switch {
+	case iof != nil:
+		conversion.IOFAmount = *iof
+	case cardPurchase:
+		conversion.IOFAmount = converted.MulRate(s.iofRate)
+	}
+	return converted.Add(conversion.IOFAmount), conversion, nil
+}
+
+func (s *CashFlowService) CreateCashFlow(ctx context.Context, date time.Time, categoryID int32, direction, title string, amount money.Amount, isFixed bool) (*CashFlow, error) {
+	return s.Create(ctx, CreateCashFlowRequest{
+		Date:       date,
+		CategoryID: categoryID,
+		Direction:  direction,
+		Title:      title,
+		Amount:     amount,
+		IsFixed:    isFixed,
+	})
+}
+
+// Create validates and stores a cash flow together with its optional
+// expense details and splits, atomically.
+func (s *CashFlowService) Create(ctx context.Context, req CreateCashFlowRequest) (*CashFlow, error) {
+	p, err := s.ResolvePayee(ctx, req.PayeeID, req.Title)
+	if err != nil {
+		return nil, err
+	}
+	amount, conversion, err := s.convertRequest(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	// Rules fill in what the request leaves open; the payee's default
+	// category comes last.
+	facts := Facts{Title: req.Title, Direction: req.Direction, Amount: amount, PaymentMethodID: req.PaymentMethodID}
+	if p != nil {
+		facts.PayeeID = &p.ID
+	}
+	actions, err := s.EvaluateRules(ctx, facts)
+	if err != nil {
+		return nil, err
+	}
+	if req.PayeeID == nil && actions.PayeeID != nil {
+		if p, err = s.ResolvePayee(ctx, actions.PayeeID, ""); err != nil {
+			return nil, err
+		}
+	}
+	if req.CategoryID == 0 && actions.CategoryID != nil {
+		req.CategoryID = *actions.CategoryID
+	}
+	if !req.IsFixed && actions.IsFixed != nil {
+		req.IsFixed = *actions.IsFixed
+	}
+	if p != nil && req.CategoryID == 0 {
+		cat, err := s.PayeeCategory(ctx, p, req.Direction)
+		if err != nil {
+			return nil, err
+		}
+		if cat != nil {
+			req.CategoryID = cat.ID
+		}
+	}
+	newFlow, err := New(req.Date, req.CategoryID, req.Direction, req.Title, amount, req.IsFixed)
 	if err != nil {
 		return nil, fmt.Errorf("domain validation failed: %w", err)
 	}
+	newFlow.Conversion = conversion
+	if p != nil {
+		newFlow.PayeeID = &p.ID
+	}
+	newFlow.FITID = req.FITID
+	newFlow.ExternalAccount = req.ExternalAccount
+	newFlow.SourceCashFlowID = req.SourceCashFlowID
+	switch req.Status {
+	case "":
+	case StatusPlanned, StatusRealized:
+		newFlow.Status = req.Status
+	default:
+		return nil, ErrInvalidStatus
+	}
 
-	// Validate Category and Direction
-	cat, err := s.catRepo.GetByID(ctx, categoryID)
+	if err := s.validateCategory(ctx, req.CategoryID, req.Direction); err != nil {
+		return nil, err
+	}
+	if err := s.validateSplits(ctx, newFlow, req.Splits); err != nil {
+		return nil, err
+	}
+	newFlow.AccountID, err = s.resolveAccount(ctx, req.AccountID, req.PaymentMethodID)
+	if err != nil {
+		return nil, err
+	}
+	if req.CheckDuplicates {
+		if err := s.checkDuplicates(ctx, newFlow, req.PaymentMethodID); err != nil {
+			return nil, err
+		}
+	}
+
+	var created *CashFlow
+	err = s.repo.WithinTx(ctx, func(ctx context.Context) error {
+		created, err = s.repo.Create(ctx, newFlow)
+		if err != nil {
+			return err
+		}
+		created.Splits, err = s.createSplits(ctx, created.ID, req.Splits)
+		if err != nil {
+			return err
+		}
+		if len(actions.TagIDs) > 0 {
+			if err := s.repo.AddTags(ctx, created.ID, actions.TagIDs); err != nil {
+				return err
+			}
+		}
+		if req.PaymentMethodID == nil {
+			return nil
+		}
+		return s.repo.CreateExpenseDetail(ctx, created.ID, *req.PaymentMethodID, req.IsFixed, req.AffectsCardInvoice)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return created, nil
+}
+
+// UpdateCashFlow corrects an existing cash flow. The previous values are kept
+// as a revision so history never silently changes meaning.
+//
+// With currency, amount is in that currency and converted as on Create.
+// Without it, a flow entered in a foreign currency keeps its original amount
+// and is converted again when the date changes; its amount in the base
+// currency cannot be edited directly.
+func (s *CashFlowService) UpdateCashFlow(ctx context.Context, id int32, date time.Time, categoryID int32, direction, title string, amount money.Amount, isFixed bool, currency string) (*CashFlow, error) {
+	changed, err := New(date, categoryID, direction, title, amount, isFixed)
+	if err != nil {
+		return nil, fmt.Errorf("domain validation failed: %w", err)
+	}
+	changed.ID = id
+
+	if err := s.ensureNotTransferLeg(ctx, id); err != nil {
+		return nil, err
+	}
+	if err := s.validateCategory(ctx, categoryID, direction); err != nil {
+		return nil, err
+	}
+
+	var updated *CashFlow
+	err = s.repo.WithinTx(ctx, func(ctx context.Context) error {
+		existing, err := s.repo.GetByID(ctx, id)
+		if err != nil {
+			return err
+		}
+		if existing == nil {
+			return ErrCashFlowNotFound
+		}
+		if existing.ReconciliationID != nil {
+			return ErrCashFlowLocked
+		}
+		if err := s.updateConversion(ctx, existing, changed, currency); err != nil {
+			return err
+		}
+		// Splits must keep adding up to the amount, in the new direction.
+		splits, err := s.repo.ListSplits(ctx, id)
+		if err != nil {
+			return err
+		}
+		if err := s.validateSplits(ctx, changed, splits); err != nil {
+			return err
+		}
+		if sameValues(existing, changed) {
+			updated = existing
+			return nil
+		}
+
+		if err := s.repo.CreateRevision(ctx, existing, RevisionActionUpdate); err != nil {
+			return fmt.Errorf("failed to record revision: %w", err)
+		}
+		updated, err = s.repo.Update(ctx, changed)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return updated, nil
+}
+
+// DeleteCashFlow removes a cash flow, keeping its last values as a revision.
+// Flows that belong to an installment plan cannot be deleted individually.
+func (s *CashFlowService) DeleteCashFlow(ctx context.Context, id int32) error {
+	return s.repo.WithinTx(ctx, func(ctx context.Context) error {
+		existing, err := s.repo.GetByID(ctx, id)
+		if err != nil {
+			return err
+		}
+		if existing == nil {
+			return ErrCashFlowNotFound
+		}
+		if existing.ReconciliationID != nil {
+			return ErrCashFlowLocked
+		}
+
+		links, err := s.repo.CountInstallmentLinks(ctx, id)
+		if err != nil {
+			return err
+		}
+		if links > 0 {
+			return ErrCashFlowLinked
+		}
+		if err := s.ensureNotTransferLeg(ctx, id); err != nil {
+			return err
+		}
+
+		if err := s.repo.CreateRevision(ctx, existing, RevisionActionDelete); err != nil {
+			return fmt.Errorf("failed to record revision: %w", err)
+		}
+		return s.repo.Delete(ctx, id)
+	})
+}
+
+// ConfirmCashFlow marks a planned cash flow as realized. The date and amount
+// default to the planned ones; the planned flow is kept as a revision.
+func (s *CashFlowService) ConfirmCashFlow(ctx context.Context, id int32, date *time.Time, amount *money.Amount) (*CashFlow, error) {
+	var confirmed *CashFlow
+	err := s.repo.WithinTx(ctx, func(ctx context.Context) error {
+		existing, err := s.repo.GetByID(ctx, id)
+		if err != nil {
+			return err
+		}
+		if existing == nil {
+			return ErrCashFlowNotFound
+		}
+		if existing.Status != StatusPlanned {
+			return ErrNotPlanned
+		}
+
+		actual := *existing
+		if date != nil {
+			actual.Date = *date
+		}
+		if amount != nil {
+			if !amount.IsPositive() {
+				return ErrInvalidAmount
+			}
+			actual.Amount = *amount
+		}
+		// A flow in a foreign currency is confirmed in that currency, at
+		// the rate of the actual date.
+		if c := existing.Conversion; c != nil && (date != nil || amount != nil) {
+			original := c.OriginalAmount
+			if amount != nil {
+				original = *amount
+			}
+			actual.Amount, actual.Conversion, err = s.reconvert(ctx, c, actual.Date, original)
+			if err != nil {
+				return err
+			}
+		}
+		// Splits must keep adding up to the actual amount.
+		splits, err := s.repo.ListSplits(ctx, id)
+		if err != nil {
+			return err
+		}
+		if len(splits) > 0 {
+			if err := ValidateSplits(actual.Amount, splits); err != nil {
+				return err
+			}
+		}
+
+		if err := s.repo.CreateRevision(ctx, existing, RevisionActionUpdate); err != nil {
+			return fmt.Errorf("failed to record revision: %w", err)
+		}
+		confirmed, err = s.repo.Confirm(ctx, id, actual.Date, actual.Amount, actual.Conversion)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return confirmed, nil
+}
+
+// CancelCashFlow records that a planned cash flow will not happen. It stays
+// listed but counts nowhere.
+func (s *CashFlowService) CancelCashFlow(ctx context.Context, id int32) (*CashFlow, error) {
+	var cancelled *CashFlow
+	err := s.repo.WithinTx(ctx, func(ctx context.Context) error {
+		existing, err := s.repo.GetByID(ctx, id)
+		if err != nil {
+			return err
+		}
+		if existing == nil {
+			return ErrCashFlowNotFound
+		}
+		if existing.Status != StatusPlanned {
+			return ErrNotPlanned
+		}
+
+		if err := s.repo.CreateRevision(ctx, existing, RevisionActionUpdate); err != nil {
+			return fmt.Errorf("failed to record revision: %w", err)
+		}
+		if err := s.repo.Cancel(ctx, id); err != nil {
+			return err
+		}
+		cancelled, err = s.repo.GetByID(ctx, id)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return cancelled, nil
+}
+
+// ListSplits returns the splits of a cash flow; empty when it is not split.
+func (s *CashFlowService) ListSplits(ctx context.Context, id int32) ([]Split, error) {
+	existing, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if existing == nil {
+		return nil, ErrCashFlowNotFound
+	}
+	return s.repo.ListSplits(ctx, id)
+}
+
+// SetSplits replaces the splits of a cash flow. An empty list removes them,
+// so the flow counts again under its own category.
+func (s *CashFlowService) SetSplits(ctx context.Context, id int32, splits []Split) ([]Split, error) {
+	var result []Split
+	err := s.repo.WithinTx(ctx, func(ctx context.Context) error {
+		existing, err := s.repo.GetByID(ctx, id)
+		if err != nil {
+			return err
+		}
+		if existing == nil {
+			return ErrCashFlowNotFound
+		}
+		if existing.ReconciliationID != nil {
+			return ErrCashFlowLocked
+		}
+		if err := s.ensureNotTransferLeg(ctx, id); err != nil {
+			return err
+		}
+		if err := s.validateSplits(ctx, existing, splits); err != nil {
+			return err
+		}
+
+		if err := s.repo.DeleteSplits(ctx, id); err != nil {
+			return err
+		}
+		if _, err := s.createSplits(ctx, id, splits); err != nil {
+			return err
+		}
+		result, err = s.repo.ListSplits(ctx, id)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// SetAccount moves a cash flow to another account; nil detaches it.
+func (s *CashFlowService) SetAccount(ctx context.Context, id int32, accountID *int32) (*CashFlow, error) {
+	existing, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if existing == nil {
+		return nil, ErrCashFlowNotFound
+	}
+	if existing.ReconciliationID != nil {
+		return nil, ErrCashFlowLocked
+	}
+	if err := s.ensureNotTransferLeg(ctx, id); err != nil {
+		return nil, err
+	}
+	if err := s.validateAccount(ctx, accountID); err != nil {
+		return nil, err
+	}
+	if err := s.repo.SetAccount(ctx, id, accountID); err != nil {
+		return nil, err
+	}
+	return s.repo.GetByID(ctx, id)
+}
+
+// SetPayee links a cash flow to another payee; nil unlinks it.
+func (s *CashFlowService) SetPayee(ctx context.Context, id int32, payeeID *int32) (*CashFlow, error) {
+	existing, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if existing == nil {
+		return nil, ErrCashFlowNotFound
+	}
+	if payeeID != nil {
+		if _, err := s.ResolvePayee(ctx, payeeID, ""); err != nil {
+			return nil, err
+		}
+	}
+	if err := s.repo.SetPayee(ctx, id, payeeID); err != nil {
+		return nil, err
+	}
+	return s.repo.GetByID(ctx, id)
+}
+
+// ResolvePayee returns the payee of a flow: payeeID when given, otherwise
+// the payee whose name or alias the title starts with. It is nil when no
+// payee matches.
+func (s *CashFlowService) ResolvePayee(ctx context.Context, payeeID *int32, title string) (*payee.Payee, error) {
+	if payeeID == nil {
+		return s.repo.MatchPayee(ctx, title)
+	}
+	p, err := s.repo.GetPayee(ctx, *payeeID)
+	if err != nil {
+		return nil, err
+	}
+	if p == nil {
+		return nil, payee.ErrPayeeNotFound
+	}
+	return p, nil
+}
+
+// EvaluateRules returns what the categorization rules set on a flow with
+// these facts. The actions are empty when no rules are configured.
+func (s *CashFlowService) EvaluateRules(ctx context.Context, facts Facts) (*Actions, error) {
+	rules := s.rules
+	if prepared, ok := ctx.Value(rulesKey{}).(RuleEvaluator); ok {
+		rules = prepared
+	}
+	if rules == nil {
+		return &Actions{}, nil
+	}
+	actions, err := rules.Evaluate(ctx, facts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to evaluate rules: %w", err)
+	}
+	return actions, nil
+}
+
+type rulesKey struct{}
+
+// PrepareRules loads the categorization rules once and returns a context in
+// which Create and EvaluateRules reuse them. Batches of flows run under it
+// instead of loading the rules for every flow.
+func (s *CashFlowService) PrepareRules(ctx context.Context) (context.Context, error) {
+	if s.rules == nil {
+		return ctx, nil
+	}
+	if _, ok := ctx.Value(rulesKey{}).(RuleEvaluator); ok {
+		return ctx, nil
+	}
+	prepared, err := s.rules.Prepare(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load rules: %w", err)
+	}
+	return context.WithValue(ctx, rulesKey{}, prepared), nil
+}
+
+// PayeeCategory returns the default category of p when it can be used for a
+// flow in direction, nil otherwise.
+func (s *CashFlowService) PayeeCategory(ctx context.Context, p *payee.Payee, direction string) (*category.Category, error) {
+	if p.DefaultCategoryID == nil {
+		return nil, nil
+	}
+	cat, err := s.catRepo.GetByID(ctx, *p.DefaultCategoryID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get category: %w", err)
 	}
-	if cat == nil {
-		return nil, ErrCategoryNotFound
+	if cat == nil || cat.Direction != direction || cat.Role == category.RoleTransfer {
+		return nil, nil
 	}
+	return cat, nil
+}
 
-	if cat.Direction != direction {
-		return nil, ErrDirectionMismatch
+// updateConversion works out the amount and conversion of changed, an
+// update of existing, as described on UpdateCashFlow.
+func (s *CashFlowService) updateConversion(ctx context.Context, existing, changed *CashFlow, currency string) error {
+	var err error
+	c := existing.Conversion
+	switch {
+	case currency != "":
+		changed.Amount, changed.Conversion, err = s.Convert(ctx, currency, changed.Date, changed.Amount, nil, c != nil && !c.IOFAmount.IsZero())
+	case c == nil:
+	case changed.Amount != existing.Amount:
+		err = ErrConvertedAmount
+	case changed.Date.Equal(existing.Date):
+		changed.Conversion = c
+	default:
+		changed.Amount, changed.Conversion, err = s.reconvert(ctx, c, changed.Date, c.OriginalAmount)
 	}
+	return err
+}
+
+// reconvert converts the original amount of a flow in a foreign currency
+// again, at the rate in force on date. IOF is charged again at the
+// configured rate when the flow had any.
+func (s *CashFlowService) reconvert(ctx context.Context, c *Conversion, date time.Time, original money.Amount) (money.Amount, *Conversion, error) {
+	return s.Convert(ctx, c.Currency, date, original, nil, !c.IOFAmount.IsZero())
+}
+
+// convertRequest returns the amount of req in the base currency and how it
+// was converted, if it was.
+func (s *CashFlowService) convertRequest(ctx context.Context, req CreateCashFlowRequest) (money.Amount, *Conversion, error) {
+	if req.Conversion != nil {
+		return req.Amount, req.Conversion, nil
+	}
+	if req.Currency == "" {
+		if req.IOFAmount != nil {
+			return money.Amount{}, nil, ErrIOFNotForeign
+		}
+		return req.Amount, nil, nil
+	}
+	return s.Convert(ctx, req.Currency, req.Date, req.Amount, req.IOFAmount, req.AffectsCardInvoice)
+}
+
+// resolveAccount picks the explicit account or, failing that, the one linked
+// to the payment method.
+func (s *CashFlowService) resolveAccount(ctx context.Context, accountID, paymentMethodID *int32) (*int32, error) {
+	if accountID != nil {
+		return accountID, s.validateAccount(ctx, accountID)
+	}
+	if paymentMethodID == nil {
+		return nil, nil
+	}
+	return s.repo.PaymentMethodAccount(ctx, *paymentMethodID)
+}
 
-	return s.repo.Create(ctx, newFlow)
+func (s *CashFlowService) validateAccount(ctx context.Context, accountID *int32) error {
+	if accountID == nil {
+		return nil
+	}
+	exists, err := s.repo.AccountExists(ctx, *accountID)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return ErrAccountNotFound
+	}
+	return nil
+}
+
+// ensureNotTransferLeg keeps the two legs of a transfer in step: they are
+// only changed or removed through the transfer.
+func (s *CashFlowService) ensureNotTransferLeg(ctx context.Context, id int32) error {
+	isLeg, err := s.repo.IsTransferLeg(ctx, id)
+	if err != nil {
+		return err
+	}
+	if isLeg {
+		return ErrTransferLeg
+	}
+	return nil
+}
+
+func (s *CashFlowService) validateSplits(ctx context.Context, flow *CashFlow, splits []Split) error {
+	if len(splits) == 0 {
+		return nil
+	}
+	if err := ValidateSplits(flow.Amount, splits); err != nil {
+		return err
+	}
+	for _, sp := range splits {
+		if err := s.validateCategory(ctx, sp.CategoryID, flow.Direction); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *CashFlowService) createSplits(ctx context.Context, cashFlowID int32, splits []Split) ([]Split, error) {
+	created := make([]Split, 0, len(splits))
+	for _, sp := range splits {
+		split, err := s.repo.CreateSplit(ctx, cashFlowID, sp)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create split: %w", err)
+		}
+		created = append(created, *split)
+	}
+	return created, nil
+}
+
+func (s *CashFlowService) ListRevisions(ctx context.Context, id int32) ([]Revision, error) {
+	return s.repo.ListRevisions(ctx, id)
 }
 
 func (s *CashFlowService) ListCashFlows(ctx context.Context, month time.Time) ([]*CashFlow, error) {
 	return s.repo.ListByMonth(ctx, month)
 }
 
-func (s *CashFlowService) CopyFixedExpenses(ctx context.Context, fromMonth, toMonth time.Time) (int, error) {
-	// 1. List from previous month
-	sourceFlows, err := s.repo.ListByMonth(ctx, fromMonth)
+func (s *CashFlowService) SearchCashFlows(ctx context.Context, filter Filter) (*Page, error) {
+	if err := filter.Normalize(); err != nil {
+		return nil, err
+	}
+
+	items, err := s.repo.Search(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search cash flows: %w", err)
+	}
+	total, err := s.repo.Count(ctx, filter)
 	if err != nil {
-		return 0, fmt.Errorf("failed to list source month expenses: %w", err)
+		return nil, fmt.Errorf("failed to count cash flows: %w", err)
+	}
+
+	return &Page{
+		Items:  items,
+		Total:  total,
+		Limit:  filter.Limit,
+		Offset: filter.Offset,
+	}, nil
+}
+
+// CopyFixedExpenses copies the fixed flows of fromMonth into toMonth, keeping
+// the day of the month (clamped to the last day). Each copy is linked to its
+// source, so running it again skips what was already copied. Flows generated
+// by recurrence rules are left to the rule. Everything runs in one
+// transaction; with dryRun nothing is written and the report shows what
+// would happen.
+func (s *CashFlowService) CopyFixedExpenses(ctx context.Context, fromMonth, toMonth time.Time, dryRun bool) (*CopyReport, error) {
+	if fromMonth.Year() == toMonth.Year() && fromMonth.Month() == toMonth.Month() {
+		return nil, ErrCopySameMonth
 	}
 
-	count := 0
-	for _, flow := range sourceFlows {
-		if !flow.IsFixed {
-			continue
+	report := &CopyReport{DryRun: dryRun}
+	err := s.repo.WithinTx(ctx, func(ctx context.Context) error {
+		ctx, err := s.PrepareRules(ctx)
+		if err != nil {
+			return err
+		}
+		sources, err := s.repo.ListFixedToCopy(ctx, fromMonth)
+		if err != nil {
+			return fmt.Errorf("failed to list source month expenses: %w", err)
+		}
+		targets, err := s.repo.ListCopyTargets(ctx, toMonth)
+		if err != nil {
+			return fmt.Errorf("failed to list target month flows: %w", err)
 		}
 
-		// 2. Clone to new month
-		// Adjust date to same day in target month
-		_, _, day := flow.Date.Date()
+		for _, flow := range sources {
+			item := CopyItem{
+				SourceID:   flow.ID,
+				Date:       sameDayIn(flow.Date, toMonth),
+				CategoryID: flow.CategoryID,
+				Direction:  flow.Direction,
+				Title:      flow.Title,
+				Amount:     flow.Amount,
+			}
 
-		// Simple Date Construction: toMonth Year/Month, same Day.
-		// Handle overflow (e.g. Jan 31 -> Feb 31 doesn't exist)
-		// time.Date automaticaly normalizes Feb 31 to March 3.
-		// We might want to clamp to end of month.
-		// However, for MVP/Agent: Standard normalization is acceptable or logic to clamp.
-		// Let's rely on standard time.Date normalization for now, or use a helper if we wanted perfection.
-		// For financial fixed expenses (rent 10th), usually safe. For 31st, it might jump.
-		// Let's clamp to last day of month if necessary.
+			if existing := findCopy(targets, flow); existing != nil {
+				item.CashFlowID = &existing.ID
+				report.Skipped = append(report.Skipped, item)
+				continue
+			}
+			if existing := findConflict(targets, flow); existing != nil {
+				item.CashFlowID = &existing.ID
+				report.Conflicts = append(report.Conflicts, item)
+				continue
+			}
 
-		targetDate := time.Date(toMonth.Year(), toMonth.Month(), day, 0, 0, 0, 0, toMonth.Location())
+			// Foreign amounts are converted again at the rate of the new date,
+			// with IOF when the source had it.
+			amount, conversion := flow.Amount, (*Conversion)(nil)
+			if flow.Conversion != nil {
+				amount, conversion, err = s.Convert(ctx, flow.Conversion.Currency, item.Date, flow.Conversion.OriginalAmount, nil, !flow.Conversion.IOFAmount.IsZero())
+				if err != nil {
+					return fmt.Errorf("failed to convert flow %d: %w", flow.ID, err)
+				}
+				item.Amount = amount
+			}
 
-		// If targetDate jumped to next month (e.g. was 31st, became 1st of next), rollback to end of current month
-		if targetDate.Month() != toMonth.Month() {
-			// Set to day 0 of date's month -> Last day of previous (target) month
-			targetDate = time.Date(targetDate.Year(), targetDate.Month(), 0, 0, 0, 0, 0, targetDate.Location())
+			if !dryRun {
+				splits, err := s.repo.ListSplits(ctx, flow.ID)
+				if err != nil {
+					return err
+				}
+				if amount != flow.Amount {
+					splits = scaleSplits(splits, flow.Amount, amount)
+				}
+				sourceID := flow.ID
+				created, err := s.Create(ctx, CreateCashFlowRequest{
+					Date:             item.Date,
+					CategoryID:       flow.CategoryID,
+					Direction:        flow.Direction,
+					Title:            flow.Title,
+					Amount:           amount,
+					IsFixed:          true, // Keep it fixed for next month too
+					SourceCashFlowID: &sourceID,
+					AccountID:        flow.AccountID,
+					PayeeID:          flow.PayeeID,
+					Conversion:       conversion,
+					Splits:           splits,
+				})
+				if err != nil {
+					return fmt.Errorf("failed to copy flow %d: %w", flow.ID, err)
+				}
+				item.CashFlowID = &created.ID
+			}
+			report.Created = append(report.Created, item)
 		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return report, nil
+}
 
-		_, err := s.CreateCashFlow(
-			ctx,
-			targetDate,
-			flow.CategoryID,
-			flow.Direction,
-			flow.Title,
-			flow.Amount,
-			true, // Keep it fixed for next month too
-		)
-		if err != nil {
-			return count, fmt.Errorf("failed to copy flow %d: %w", flow.ID, err)
+// scaleSplits spreads to over splits in the proportions they have of from.
+// The cents lost to rounding go to the largest split, so they add up to to.
+func scaleSplits(splits []Split, from, to money.Amount) []Split {
+	if len(splits) == 0 || from.IsZero() {
+		return splits
+	}
+	rate := to.Float64() / from.Float64()
+	scaled := make([]Split, len(splits))
+	largest := 0
+	var total money.Amount
+	for i, sp := range splits {
+		scaled[i] = sp
+		scaled[i].Amount = sp.Amount.MulRate(rate)
+		total = total.Add(scaled[i].Amount)
+		if sp.Amount.Cmp(splits[largest].Amount) > 0 {
+			largest = i
 		}
-		count++
 	}
-	return count, nil
+	scaled[largest].Amount = scaled[largest].Amount.Add(to.Sub(total))
+	return scaled
+}
+
+// sameDayIn moves date to the same day in month. Days the month does not
+// have (e.g. Jan 31 -> Feb) are clamped to its last day.
+func sameDayIn(date, month time.Time) time.Time {
+	lastDay := time.Date(month.Year(), month.Month()+1, 0, 0, 0, 0, 0, month.Location()).Day()
+	day := date.Day()
+	if day > lastDay {
+		day = lastDay
+	}
+	return time.Date(month.Year(), month.Month(), day, 0, 0, 0, 0, month.Location())
 }
 
-func (s *CashFlowService) GetMonthlySummary(ctx context.Context, month time.Time) (*MonthlySummary, error) {
-	return s.repo.GetMonthlySummary(ctx, month)
+func findCopy(targets []*CashFlow, source *CashFlow) *CashFlow {
+	for _, t := range targets {
+		if t.SourceCashFlowID != nil && *t.SourceCashFlowID == source.ID {
+			return t
+		}
+	}
+	return nil
 }
 
-func (s *CashFlowService) GetCategorySummary(ctx context.Context, month time.Time) ([]CategorySummary, error) {
-	return s.repo.GetCategorySummary(ctx, month)
+func findConflict(targets []*CashFlow, source *CashFlow) *CashFlow {
+	for _, t := range targets {
+		if t.SourceCashFlowID == nil &&
+			t.CategoryID == source.CategoryID &&
+			t.Direction == source.Direction &&
+			strings.EqualFold(strings.TrimSpace(t.Title), strings.TrimSpace(source.Title)) {
+			return t
+		}
+	}
+	return nil
+}
+
+// GetMonthlySummary totals the realized flows of the month, plus the planned
+// ones when includePlanned is set.
+func (s *CashFlowService) GetMonthlySummary(ctx context.Context, month time.Time, includePlanned bool) (*MonthlySummary, error) {
+	return s.repo.GetMonthlySummary(ctx, month, includePlanned)
+}
+
+func (s *CashFlowService) GetCategorySummary(ctx context.Context, month time.Time, includePlanned bool) ([]CategorySummary, error) {
+	return s.repo.GetCategorySummary(ctx, month, includePlanned)
+}
+
+// CompareMonthlySummary compares the month's totals with the baseline of the
+// mode, listing the categories with the biggest swings.
+func (s *CashFlowService) CompareMonthlySummary(ctx context.Context, month time.Time, includePlanned bool, mode string) (*MonthlyComparison, error) {
+	period, err := NewComparisonPeriod(month, mode)
+	if err != nil {
+		return nil, err
+	}
+	current, baseline, err := s.comparisonTotals(ctx, period, includePlanned)
+	if err != nil {
+		return nil, err
+	}
+
+	summary := summarize(current)
+	base := summarize(baseline)
+	income := average(base.TotalIncome, period.Months)
+	expense := average(base.TotalExpense, period.Months)
+
+	result := &MonthlyComparison{
+		Period:       period,
+		Current:      summary,
+		TotalIncome:  NewChange(summary.TotalIncome, income),
+		TotalExpense: NewChange(summary.TotalExpense, expense),
+		Balance:      NewChange(summary.Balance, income.Sub(expense)),
+		Swings:       []CategoryComparison{},
+	}
+	for _, c := range compareCategories(current, baseline, period.Months) {
+		if c.BiggestSwing {
+			result.Swings = append(result.Swings, c)
+		}
+	}
+	sort.SliceStable(result.Swings, func(i, j int) bool {
+		return result.Swings[i].Delta.Abs().Cmp(result.Swings[j].Delta.Abs()) > 0
+	})
+	return result, nil
+}
+
+// CompareCategorySummary compares each category's total in the month with
+// the baseline of the mode. Categories only in the baseline are listed with
+// a zero total.
+func (s *CashFlowService) CompareCategorySummary(ctx context.Context, month time.Time, includePlanned bool, mode string) ([]CategoryComparison, error) {
+	period, err := NewComparisonPeriod(month, mode)
+	if err != nil {
+		return nil, err
+	}
+	current, baseline, err := s.comparisonTotals(ctx, period, includePlanned)
+	if err != nil {
+		return nil, err
+	}
+	return compareCategories(current, baseline, period.Months), nil
+}
+
+func (s *CashFlowService) comparisonTotals(ctx context.Context, period ComparisonPeriod, includePlanned bool) (current, baseline []CategoryTotal, err error) {
+	current, err = s.repo.GetCategoryTotals(ctx, period.Month, period.Month, includePlanned)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get category totals: %w", err)
+	}
+	baseline, err = s.repo.GetCategoryTotals(ctx, period.From, period.To, includePlanned)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get baseline totals: %w", err)
+	}
+	return current, baseline, nil
+}
+
+func summarize(totals []CategoryTotal) MonthlySummary {
+	var summary MonthlySummary
+	for _, t := range totals {
+		if t.Direction == category.DirectionIn {
+			summary.TotalIncome = summary.TotalIncome.Add(t.TotalAmount)
+		} else {
+			summary.TotalExpense = summary.TotalExpense.Add(t.TotalAmount)
+		}
+	}
+	summary.Balance = summary.TotalIncome.Sub(summary.TotalExpense)
+	return summary
+}
+
+func average(total money.Amount, months int) money.Amount {
+	return total.MulRate(1 / float64(months))
+}
+
+// compareCategories pairs the month's totals with the baseline ones,
+// averaged per month, and flags the SwingCount categories whose amount moved
+// the most, in either direction.
+func compareCategories(current, baseline []CategoryTotal, months int) []CategoryComparison {
+	comparisons := make([]CategoryComparison, 0, len(current))
+	seen := make(map[int32]bool)
+	for _, t := range current {
+		seen[t.CategoryID] = true
+		comparisons = append(comparisons, CategoryComparison{CategoryTotal: t})
+	}
+	base := make(map[int32]money.Amount)
+	for _, t := range baseline {
+		base[t.CategoryID] = average(t.TotalAmount, months)
+		if !seen[t.CategoryID] {
+			t.TotalAmount = money.Amount{}
+			seen[t.CategoryID] = true
+			comparisons = append(comparisons, CategoryComparison{CategoryTotal: t})
+		}
+	}
+	for i := range comparisons {
+		c := &comparisons[i]
+		c.Change = NewChange(c.TotalAmount, base[c.CategoryID])
+	}
+
+	sort.SliceStable(comparisons, func(i, j int) bool {
+		if cmp := comparisons[i].TotalAmount.Cmp(comparisons[j].TotalAmount); cmp != 0 {
+			return cmp > 0
+		}
+		return comparisons[i].Baseline.Cmp(comparisons[j].Baseline) > 0
+	})
+
+	bySwing := make([]int, len(comparisons))
+	for i := range bySwing {
+		bySwing[i] = i
+	}
+	sort.SliceStable(bySwing, func(i, j int) bool {
+		return comparisons[bySwing[i]].Delta.Abs().Cmp(comparisons[bySwing[j]].Delta.Abs()) > 0
+	})
+	for n, i := range bySwing {
+		if n == SwingCount || comparisons[i].Delta.IsZero() {
+			break
+		}
+		comparisons[i].BiggestSwing = true
+	}
+	return comparisons
+}
+
+func (s *CashFlowService) validateCategory(ctx context.Context, categoryID int32, direction string) error {
+	cat, err := s.catRepo.GetByID(ctx, categoryID)
+	if err != nil {
+		return fmt.Errorf("failed to get category: %w", err)
+	}
+	if cat == nil {
+		return ErrCategoryNotFound
+	}
+
+	if cat.Direction != direction {
+		return ErrDirectionMismatch
+	}
+	if cat.Role == category.RoleTransfer {
+		return ErrTransferCategory
+	}
+	return nil
+}
+
+func sameValues(a, b *CashFlow) bool {
+	return a.Date.Equal(b.Date) &&
+		a.CategoryID == b.CategoryID &&
+		a.Direction == b.Direction &&
+		a.Title == b.Title &&
+		a.Amount == b.Amount &&
+		a.IsFixed == b.IsFixed &&
+		sameConversion(a.Conversion, b.Conversion)
+}
+
+func sameConversion(a, b *Conversion) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
+// ListDuplicates pairs up the existing flows that look like the same entry,
+// optionally between from and to.
+func (s *CashFlowService) ListDuplicates(ctx context.Context, filter DuplicateFilter) ([]DuplicatePair, error) {
+	if filter.From != nil && filter.To != nil && filter.From.After(*filter.To) {
+		return nil, ErrInvalidDateRange
+	}
+	days := s.duplicateDays
+	if filter.Days != nil {
+		days = *filter.Days
+	}
+	if days < 0 || days > MaxDuplicateDays {
+		return nil, ErrInvalidDays
+	}
+
+	suspects, err := s.repo.ListDuplicateSuspects(ctx, filter.From, filter.To, days)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list duplicates: %w", err)
+	}
+
+	// Suspects come sorted by direction, amount and date, so each one only
+	// needs comparing with the ones right after it.
+	pairs := []DuplicatePair{}
+	for i := range suspects {
+		for j := i + 1; j < len(suspects); j++ {
+			a, b := suspects[i], suspects[j]
+			if a.Direction != b.Direction || a.Amount != b.Amount || daysApart(a.Date, b.Date) > days {
+				break
+			}
+			if similarity, ok := LooksDuplicate(a, b, days); ok {
+				pairs = append(pairs, DuplicatePair{First: a, Second: b, DaysApart: daysApart(a.Date, b.Date), Similarity: similarity})
+			}
+		}
+	}
+	sort.SliceStable(pairs, func(i, j int) bool {
+		return pairs[i].First.Date.Before(pairs[j].First.Date)
+	})
+	return pairs, nil
+}
+
+// checkDuplicates fails with a *DuplicateError when flow looks like one
+// already entered.
+func (s *CashFlowService) checkDuplicates(ctx context.Context, flow *CashFlow, paymentMethodID *int32) error {
+	from := flow.Date.AddDate(0, 0, -s.duplicateDays)
+	to := flow.Date.AddDate(0, 0, s.duplicateDays)
+	existing, err := s.repo.ListDuplicateCandidates(ctx, flow.Direction, flow.Amount, from, to)
+	if err != nil {
+		return fmt.Errorf("failed to check duplicates: %w", err)
+	}
+
+	candidate := DuplicateCandidate{
+		Date:            flow.Date,
+		Direction:       flow.Direction,
+		Title:           flow.Title,
+		Amount:          flow.Amount,
+		PayeeID:         flow.PayeeID,
+		PaymentMethodID: paymentMethodID,
+	}
+	var matches []DuplicateCandidate
+	for _, e := range existing {
+		if _, ok := LooksDuplicate(e, candidate, s.duplicateDays); ok {
+			matches = append(matches, e)
+		}
+	}
+	if len(matches) > 0 {
+		return &DuplicateError{Candidates: matches}
+	}
+	return nil
 }
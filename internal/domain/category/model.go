@@ -11,10 +11,18 @@ const (
 	DirectionOut = "OUT"
 )
 
+// Roles decide how a category counts in reports.
+const (
+	RoleRegular    = "REGULAR"
+	RoleTransfer   = "TRANSFER"   // legs of transfers between own accounts; never income or expense
+	RoleInvestment = "INVESTMENT" // contributions (OUT) and redemptions or yields (IN) that feed the reinvestment calculation
+)
+
 var (
 	ErrInvalidDirection = errors.New("invalid direction: must be IN or OUT")
 	ErrEmptyName        = errors.New("category name cannot be empty")
 	ErrCategoryNotFound = errors.New("category not found")
+	ErrInvalidRole      = errors.New("invalid role: must be REGULAR or INVESTMENT")
 )
 
 type Category struct {
@@ -24,6 +32,7 @@ type Category struct {
 	IsBudgetRelevant bool
 	IsActive         bool
 	InactiveFromMonth *time.Time
+	Role             string
 }
 
 func New(name, direction string, isBudgetRelevant bool) (*Category, error) {
@@ -39,5 +48,20 @@ func New(name, direction string, isBudgetRelevant bool) (*Category, error) {
 		Direction:        direction,
 		IsBudgetRelevant: isBudgetRelevant,
 		IsActive:         true,
+		Role:             RoleRegular,
 	}, nil
 }
+
+// SetRole changes the role of the category. Transfer categories belong to
+// the transfers and keep theirs; the others switch between REGULAR and
+// INVESTMENT.
+func (c *Category) SetRole(role string) error {
+	if role == c.Role {
+		return nil
+	}
+	if c.Role == RoleTransfer || (role != RoleRegular && role != RoleInvestment) {
+		return ErrInvalidRole
+	}
+	c.Role = role
+	return nil
+}
@@ -15,9 +15,9 @@ type Repository interface {
 }
 
 type Service interface {
-	CreateCategory(ctx context.Context, name, direction string, isBudgetRelevant bool) (*Category, error)
+	CreateCategory(ctx context.Context, name, direction string, isBudgetRelevant bool, role string) (*Category, error)
 	ListCategories(ctx context.Context, activeOnly bool) ([]*Category, error)
 	ListCategoriesByMonth(ctx context.Context, activeOnly bool, month time.Time) ([]*Category, error)
 	DeactivateCategory(ctx context.Context, id int32, inactiveFromMonth time.Time) error
-	UpdateCategory(ctx context.Context, id int32, name, direction string, isBudgetRelevant, isActive bool) (*Category, error)
+	UpdateCategory(ctx context.Context, id int32, name, direction string, isBudgetRelevant, isActive bool, role string) (*Category, error)
 }
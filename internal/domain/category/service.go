@@ -14,11 +14,17 @@ func NewService(repo Repository) *CategoryService {
 	return &CategoryService{repo: repo}
 }
 
-func (s *CategoryService) CreateCategory(ctx context.Context, name, direction string, isBudgetRelevant bool) (*Category, error) {
+// CreateCategory creates a category; an empty role means REGULAR.
+func (s *CategoryService) CreateCategory(ctx context.Context, name, direction string, isBudgetRelevant bool, role string) (*Category, error) {
 	newCat, err := New(name, direction, isBudgetRelevant)
 	if err != nil {
 		return nil, fmt.Errorf("validation error: %w", err)
 	}
+	if role != "" {
+		if err := newCat.SetRole(role); err != nil {
+			return nil, fmt.Errorf("validation error: %w", err)
+		}
+	}
 
 	createdCat, err := s.repo.Create(ctx, newCat)
 	if err != nil {
@@ -51,7 +57,9 @@ func (s *CategoryService) DeactivateCategory(ctx context.Context, id int32, inac
 	return nil
 }
 
-func (s *CategoryService) UpdateCategory(ctx context.Context, id int32, name, direction string, isBudgetRelevant, isActive bool) (*Category, error) {
+// UpdateCategory replaces the category fields; an empty role keeps the
+// current one.
+func (s *CategoryService) UpdateCategory(ctx context.Context, id int32, name, direction string, isBudgetRelevant, isActive bool, role string) (*Category, error) {
 	existing, err := s.repo.GetByID(ctx, id)
 	if err != nil {
 		return nil, fmt.Errorf("failed to update category: %w", err)
@@ -67,6 +75,12 @@ func (s *CategoryService) UpdateCategory(ctx context.Context, id int32, name, di
 	updated.ID = id
 	updated.IsActive = isActive
 	updated.InactiveFromMonth = existing.InactiveFromMonth
+	updated.Role = existing.Role
+	if role != "" {
+		if err := updated.SetRole(role); err != nil {
+			return nil, err
+		}
+	}
 
 	updated, err = s.repo.Update(ctx, updated)
 	if err != nil {
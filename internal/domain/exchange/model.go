@@ -0,0 +1,67 @@
+package exchange
+
+import (
+	"errors"
+	"strings"
+	"time"
+)
+
+var (
+	ErrRateNotFound    = errors.New("exchange rate not found")
+	ErrInvalidCurrency = errors.New("currency must be a 3-letter ISO 4217 code")
+	ErrBaseCurrency    = errors.New("the base currency has no exchange rate")
+	ErrInvalidRate     = errors.New("rate must be greater than zero")
+	ErrInvalidDate     = errors.New("date is required")
+	ErrEmptyFile       = errors.New("file has no exchange rates")
+	ErrInvalidLine     = errors.New("invalid line, expected currency, date (YYYY-MM-DD) and rate")
+)
+
+// DefaultBaseCurrency is the currency amounts are kept in unless configured
+// otherwise.
+const DefaultBaseCurrency = "BRL"
+
+// Rate is what one unit of Currency is worth in the base currency from Date
+// on, until the next rate of the same currency.
+type Rate struct {
+	ID        int32
+	Currency  string
+	Date      time.Time
+	Rate      float64
+	CreatedAt time.Time
+}
+
+func (r *Rate) Validate() error {
+	currency, err := NormalizeCurrency(r.Currency)
+	if err != nil {
+		return err
+	}
+	r.Currency = currency
+	if r.Date.IsZero() {
+		return ErrInvalidDate
+	}
+	if r.Rate <= 0 {
+		return ErrInvalidRate
+	}
+	return nil
+}
+
+// NormalizeCurrency upper-cases a currency code and checks it has three
+// letters, e.g. "usd" -> "USD".
+func NormalizeCurrency(code string) (string, error) {
+	code = strings.ToUpper(strings.TrimSpace(code))
+	if len(code) != 3 {
+		return "", ErrInvalidCurrency
+	}
+	for _, c := range code {
+		if c < 'A' || c > 'Z' {
+			return "", ErrInvalidCurrency
+		}
+	}
+	return code, nil
+}
+
+// ImportResult tells what a CSV import stored. Rates of a currency and date
+// already on file are replaced.
+type ImportResult struct {
+	Rates []Rate
+}
@@ -0,0 +1,27 @@
+package exchange
+
+import (
+	"context"
+	"io"
+	"time"
+)
+
+type Repository interface {
+	WithinTx(ctx context.Context, fn func(ctx context.Context) error) error
+	// Upsert stores the rate, replacing the one of the same currency and date.
+	Upsert(ctx context.Context, rate *Rate) (*Rate, error)
+	// List returns the rates of currency, or of all currencies when empty,
+	// newest first.
+	List(ctx context.Context, currency string) ([]Rate, error)
+	// Effective returns the latest rate on or before date; nil when there is none.
+	Effective(ctx context.Context, currency string, date time.Time) (*Rate, error)
+	Delete(ctx context.Context, id int32) (bool, error)
+}
+
+type Service interface {
+	SetRate(ctx context.Context, rate Rate) (*Rate, error)
+	ListRates(ctx context.Context, currency string) ([]Rate, error)
+	GetEffectiveRate(ctx context.Context, currency string, date time.Time) (*Rate, error)
+	DeleteRate(ctx context.Context, id int32) error
+	ImportCSV(ctx context.Context, r io.Reader) (*ImportResult, error)
+}
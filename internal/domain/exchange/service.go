@@ -0,0 +1,179 @@
+package exchange
+
+import (
+	"bufio"
+	"context"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type ExchangeService struct {
+	repo         Repository
+	baseCurrency string
+}
+
+func NewService(repo Repository, baseCurrency string) *ExchangeService {
+	return &ExchangeService{
+		repo:         repo,
+		baseCurrency: baseCurrency,
+	}
+}
+
+// SetRate stores the rate of a currency on a date, replacing any previous
+// rate for that same day.
+func (s *ExchangeService) SetRate(ctx context.Context, rate Rate) (*Rate, error) {
+	if err := s.validate(&rate); err != nil {
+		return nil, err
+	}
+	return s.repo.Upsert(ctx, &rate)
+}
+
+func (s *ExchangeService) ListRates(ctx context.Context, currency string) ([]Rate, error) {
+	if currency != "" {
+		var err error
+		if currency, err = NormalizeCurrency(currency); err != nil {
+			return nil, err
+		}
+	}
+	return s.repo.List(ctx, currency)
+}
+
+// GetEffectiveRate returns the rate in force on date: the latest one on or
+// before it.
+func (s *ExchangeService) GetEffectiveRate(ctx context.Context, currency string, date time.Time) (*Rate, error) {
+	currency, err := NormalizeCurrency(currency)
+	if err != nil {
+		return nil, err
+	}
+	rate, err := s.repo.Effective(ctx, currency, date)
+	if err != nil {
+		return nil, err
+	}
+	if rate == nil {
+		return nil, ErrRateNotFound
+	}
+	return rate, nil
+}
+
+func (s *ExchangeService) DeleteRate(ctx context.Context, id int32) error {
+	deleted, err := s.repo.Delete(ctx, id)
+	if err != nil {
+		return err
+	}
+	if !deleted {
+		return ErrRateNotFound
+	}
+	return nil
+}
+
+// ImportCSV stores the rates of a CSV file with the columns currency, date
+// (YYYY-MM-DD) and rate, separated by commas or semicolons; with semicolons
+// the rate may use a decimal comma. A header line is skipped. Nothing is
+// stored if a line is invalid.
+func (s *ExchangeService) ImportCSV(ctx context.Context, r io.Reader) (*ImportResult, error) {
+	buffered := bufio.NewReader(r)
+	first, _ := buffered.Peek(512)
+	reader := csv.NewReader(buffered)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+	decimalComma := false
+	if strings.Contains(firstLine(string(first)), ";") {
+		reader.Comma = ';'
+		decimalComma = true
+	}
+
+	var rates []Rate
+	line := 0
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		line++
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, ErrInvalidLine)
+		}
+		if isBlank(record) {
+			continue
+		}
+		rate, err := parseRate(record, decimalComma)
+		if err != nil {
+			if line == 1 {
+				continue // header
+			}
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+		if err := s.validate(&rate); err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+		rates = append(rates, rate)
+	}
+	if len(rates) == 0 {
+		return nil, ErrEmptyFile
+	}
+
+	result := &ImportResult{}
+	err := s.repo.WithinTx(ctx, func(ctx context.Context) error {
+		for _, rate := range rates {
+			stored, err := s.repo.Upsert(ctx, &rate)
+			if err != nil {
+				return err
+			}
+			result.Rates = append(result.Rates, *stored)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (s *ExchangeService) validate(rate *Rate) error {
+	if err := rate.Validate(); err != nil {
+		return err
+	}
+	if rate.Currency == s.baseCurrency {
+		return ErrBaseCurrency
+	}
+	return nil
+}
+
+func parseRate(record []string, decimalComma bool) (Rate, error) {
+	if len(record) < 3 {
+		return Rate{}, ErrInvalidLine
+	}
+	date, err := time.Parse("2006-01-02", strings.TrimSpace(record[1]))
+	if err != nil {
+		return Rate{}, ErrInvalidLine
+	}
+	value := strings.TrimSpace(record[2])
+	if decimalComma && strings.Contains(value, ",") {
+		value = strings.ReplaceAll(strings.ReplaceAll(value, ".", ""), ",", ".")
+	}
+	rate, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return Rate{}, ErrInvalidLine
+	}
+	return Rate{Currency: record[0], Date: date, Rate: rate}, nil
+}
+
+func firstLine(s string) string {
+	if i := strings.IndexAny(s, "\r\n"); i >= 0 {
+		return s[:i]
+	}
+	return s
+}
+
+func isBlank(record []string) bool {
+	for _, field := range record {
+		if strings.TrimSpace(field) != "" {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,204 @@
+package importer
+
+import (
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/money"
+)
+
+// ParseAmount reads a bank formatted amount. With decimalComma the Brazilian
+// format is expected (1.234,56); otherwise the US one (1,234.56). Negative
+// values may use a leading or trailing minus sign or parentheses.
+func ParseAmount(raw string, decimalComma bool) (money.Amount, error) {
+	s := strings.TrimSpace(raw)
+	s = strings.TrimPrefix(s, "R$")
+	s = strings.Map(func(r rune) rune {
+		if r == ' ' || r == '\u00a0' {
+			return -1
+		}
+		return r
+	}, s)
+
+	negative := false
+	switch {
+	case strings.HasPrefix(s, "(") && strings.HasSuffix(s, ")"):
+		negative, s = true, s[1:len(s)-1]
+	case strings.HasSuffix(s, "-"):
+		negative, s = true, strings.TrimSuffix(s, "-")
+	case strings.HasPrefix(s, "-"):
+		negative, s = true, strings.TrimPrefix(s, "-")
+	case strings.HasPrefix(s, "+"):
+		s = strings.TrimPrefix(s, "+")
+	}
+	s = strings.TrimPrefix(s, "R$")
+
+	if decimalComma {
+		s = strings.ReplaceAll(s, ".", "")
+		s = strings.Replace(s, ",", ".", 1)
+	} else {
+		s = strings.ReplaceAll(s, ",", "")
+	}
+
+	value, err := money.Parse(s)
+	if err != nil {
+		return money.Amount{}, fmt.Errorf("invalid amount %q", raw)
+	}
+	if negative {
+		value = value.Neg()
+	}
+	return value, nil
+}
+
+// dateLayout converts a DD/MM/YYYY style format into a Go time layout.
+func dateLayout(format string) string {
+	r := strings.NewReplacer("YYYY", "2006", "YY", "06", "MM", "01", "DD", "02")
+	return r.Replace(format)
+}
+
+type record struct {
+	line   int
+	fields []string
+}
+
+// readRecords decodes the upload (UTF-8 or Latin-1, as many banks still
+// export) and splits it using the profile delimiter.
+func readRecords(r io.Reader, p *Profile) ([]record, error) {
+	data, err := io.ReadAll(io.LimitReader(r, MaxFileSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(data) > MaxFileSize {
+		return nil, ErrFileTooLarge
+	}
+	data = bytes.TrimPrefix(data, []byte("\xef\xbb\xbf"))
+	if !utf8.Valid(data) {
+		data = latin1ToUTF8(data)
+	}
+
+	reader := csv.NewReader(bytes.NewReader(data))
+	reader.Comma, _ = utf8.DecodeRuneInString(p.Delimiter)
+	reader.FieldsPerRecord = -1
+	reader.LazyQuotes = true
+	reader.TrimLeadingSpace = true
+
+	var records []record
+	for {
+		fields, err := reader.Read()
+		if err == io.EOF {
+			return records, nil
+		}
+		if err != nil {
+			var parseErr *csv.ParseError
+			if errors.As(err, &parseErr) {
+				return nil, fmt.Errorf("invalid csv at line %d: %w", parseErr.Line, parseErr.Err)
+			}
+			return nil, err
+		}
+		line, _ := reader.FieldPos(0)
+		records = append(records, record{line: line, fields: fields})
+	}
+}
+
+func latin1ToUTF8(data []byte) []byte {
+	runes := make([]rune, len(data))
+	for i, b := range data {
+		runes[i] = rune(b)
+	}
+	return []byte(string(runes))
+}
+
+// parseCSV turns the records of a statement into candidates. Lines that
+// cannot be read are kept with Error set, so the preview shows them.
+func parseCSV(r io.Reader, p *Profile) ([]Candidate, error) {
+	records, err := readRecords(r, p)
+	if err != nil {
+		return nil, err
+	}
+
+	layout := dateLayout(p.DateFormat)
+	var candidates []Candidate
+	for i, rec := range records {
+		if i == 0 && p.HasHeader {
+			continue
+		}
+		if isBlank(rec.fields) {
+			continue
+		}
+
+		c := Candidate{Line: rec.line}
+		if err := fillFromRecord(&c, rec.fields, p, layout); err != nil {
+			c.Error = err.Error()
+		}
+		candidates = append(candidates, c)
+	}
+
+	if len(candidates) == 0 {
+		return nil, ErrEmptyFile
+	}
+	return candidates, nil
+}
+
+func fillFromRecord(c *Candidate, record []string, p *Profile, layout string) error {
+	field := func(col int32) (string, error) {
+		if int(col) >= len(record) {
+			return "", fmt.Errorf("missing column %d", col)
+		}
+		return strings.TrimSpace(record[col]), nil
+	}
+
+	rawDate, err := field(p.DateColumn)
+	if err != nil {
+		return err
+	}
+	date, err := time.Parse(layout, rawDate)
+	if err != nil {
+		return fmt.Errorf("invalid date %q, expected %s", rawDate, p.DateFormat)
+	}
+
+	title, err := field(p.TitleColumn)
+	if err != nil {
+		return err
+	}
+
+	rawAmount, err := field(p.AmountColumn)
+	if err != nil {
+		return err
+	}
+	amount, err := ParseAmount(rawAmount, p.DecimalComma)
+	if err != nil {
+		return err
+	}
+	if amount.IsZero() {
+		return errors.New("amount is zero")
+	}
+
+	direction := "IN"
+	if amount.IsNegative() == (p.SignConvention == SignNegativeIsOut) {
+		direction = "OUT"
+	}
+
+	c.Flow.Date = date
+	c.Flow.Title = title
+	c.Flow.Amount = amount.Abs()
+	c.Flow.Direction = direction
+	if title == "" {
+		return errors.New("title is empty")
+	}
+	return nil
+}
+
+func isBlank(record []string) bool {
+	for _, f := range record {
+		if strings.TrimSpace(f) != "" {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,103 @@
+package importer
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/domain/cashflow"
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/money"
+)
+
+var (
+	ErrProfileNotFound       = errors.New("import profile not found")
+	ErrNameRequired          = errors.New("name is required")
+	ErrInvalidDelimiter      = errors.New("delimiter must be a single character")
+	ErrInvalidColumn         = errors.New("column indexes must be zero or greater")
+	ErrInvalidDateFormat     = errors.New("date format must contain DD, MM and YYYY (or YY)")
+	ErrInvalidSignConvention = errors.New("sign convention must be NEGATIVE_IS_OUT or POSITIVE_IS_OUT")
+	ErrFileTooLarge          = errors.New("file is too large")
+	ErrEmptyFile             = errors.New("file has no transactions")
+	ErrNothingToCommit       = errors.New("no items to import")
+	ErrInvalidOFX            = errors.New("file is not a valid OFX statement")
+	ErrPaymentMethodRequired = errors.New("payment_method_id is required for credit card statements")
+)
+
+const (
+	// SignNegativeIsOut is the usual checking-account export: debits are negative.
+	SignNegativeIsOut = "NEGATIVE_IS_OUT"
+	// SignPositiveIsOut is the usual credit card export: purchases are positive.
+	SignPositiveIsOut = "POSITIVE_IS_OUT"
+
+	MaxFileSize = 5 << 20
+)
+
+// Profile describes how to read the CSV export of a given bank.
+type Profile struct {
+	ID             int32
+	Name           string
+	Delimiter      string
+	HasHeader      bool
+	DateColumn     int32
+	DateFormat     string // e.g. DD/MM/YYYY
+	TitleColumn    int32
+	AmountColumn   int32
+	DecimalComma   bool // 1.234,56 instead of 1,234.56
+	SignConvention string
+}
+
+func (p *Profile) Validate() error {
+	if strings.TrimSpace(p.Name) == "" {
+		return ErrNameRequired
+	}
+	if len([]rune(p.Delimiter)) != 1 {
+		return ErrInvalidDelimiter
+	}
+	if p.DateColumn < 0 || p.TitleColumn < 0 || p.AmountColumn < 0 {
+		return ErrInvalidColumn
+	}
+	if !strings.Contains(p.DateFormat, "DD") || !strings.Contains(p.DateFormat, "MM") || !strings.Contains(p.DateFormat, "YY") {
+		return ErrInvalidDateFormat
+	}
+	if p.SignConvention != SignNegativeIsOut && p.SignConvention != SignPositiveIsOut {
+		return ErrInvalidSignConvention
+	}
+	return nil
+}
+
+// Candidate is a statement line converted to a cash flow that has not been
+// saved yet. Flow.CategoryID holds the suggested category (zero when there
+// is no suggestion).
+type Candidate struct {
+	Line          int
+	Flow          cashflow.CashFlow
+	Duplicate     bool
+	DuplicateOfID *int32 // existing cash flow, nil when the duplicate is inside the file
+	// AlreadyImported means the FITID was imported before; commit skips it.
+	AlreadyImported bool
+	Error           string
+}
+
+type Preview struct {
+	// PaymentMethodID is set for OFX previews tied to a payment method.
+	PaymentMethodID *int32
+	Candidates      []Candidate
+}
+
+type CommitResult struct {
+	Created []*cashflow.CashFlow
+	Skipped int // already imported FITIDs
+}
+
+// CategorySuggestion is the category most used for a given title.
+type CategorySuggestion struct {
+	CategoryID   int32
+	CategoryName string
+}
+
+type duplicateKey struct {
+	date      time.Time
+	direction string
+	amount    money.Amount
+	title     string
+}
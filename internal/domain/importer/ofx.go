@@ -0,0 +1,166 @@
+package importer
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// ofxStatement is what we need from an OFX/QFX file: the account and its
+// transactions. Both the SGML (1.x) and XML (2.x) flavours are accepted.
+type ofxStatement struct {
+	Account      string
+	CreditCard   bool
+	Transactions []ofxTransaction
+}
+
+type ofxTransaction struct {
+	FITID  string
+	Posted string
+	Amount string
+	Name   string
+	Memo   string
+}
+
+func parseOFX(r io.Reader) (*ofxStatement, error) {
+	data, err := io.ReadAll(io.LimitReader(r, MaxFileSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(data) > MaxFileSize {
+		return nil, ErrFileTooLarge
+	}
+	if !utf8.Valid(data) {
+		data = latin1ToUTF8(data)
+	}
+
+	start := bytes.Index(bytes.ToUpper(data), []byte("<OFX>"))
+	if start < 0 {
+		return nil, ErrInvalidOFX
+	}
+	body := string(data[start:])
+
+	stmt := &ofxStatement{}
+	var current *ofxTransaction
+	for len(body) > 0 {
+		open := strings.IndexByte(body, '<')
+		if open < 0 {
+			break
+		}
+		end := strings.IndexByte(body[open:], '>')
+		if end < 0 {
+			break
+		}
+		tag := strings.ToUpper(strings.TrimSpace(body[open+1 : open+end]))
+		body = body[open+end+1:]
+
+		// Value runs until the next tag; SGML files do not close leaf tags.
+		next := strings.IndexByte(body, '<')
+		value := body
+		if next >= 0 {
+			value = body[:next]
+		}
+		value = strings.TrimSpace(value)
+
+		switch tag {
+		case "CCSTMTRS":
+			stmt.CreditCard = true
+		case "ACCTID":
+			if stmt.Account == "" {
+				stmt.Account = value
+			}
+		case "STMTTRN":
+			stmt.Transactions = append(stmt.Transactions, ofxTransaction{})
+			current = &stmt.Transactions[len(stmt.Transactions)-1]
+		case "/STMTTRN":
+			current = nil
+		}
+		if current == nil {
+			continue
+		}
+		switch tag {
+		case "FITID":
+			current.FITID = value
+		case "DTPOSTED":
+			current.Posted = value
+		case "TRNAMT":
+			current.Amount = value
+		case "NAME":
+			current.Name = value
+		case "MEMO":
+			current.Memo = value
+		}
+	}
+
+	if len(stmt.Transactions) == 0 {
+		return nil, ErrEmptyFile
+	}
+	return stmt, nil
+}
+
+// parseOFXDate reads YYYYMMDD[HHMMSS[.XXX]][TZ]; only the date matters.
+func parseOFXDate(raw string) (time.Time, error) {
+	if len(raw) < 8 {
+		return time.Time{}, fmt.Errorf("invalid date %q", raw)
+	}
+	date, err := time.Parse("20060102", raw[:8])
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid date %q", raw)
+	}
+	return date, nil
+}
+
+func ofxCandidates(stmt *ofxStatement) []Candidate {
+	candidates := make([]Candidate, len(stmt.Transactions))
+	for i, trn := range stmt.Transactions {
+		c := Candidate{Line: i + 1}
+		c.Flow.FITID = trn.FITID
+		c.Flow.ExternalAccount = stmt.Account
+		if err := fillFromOFX(&c, trn); err != nil {
+			c.Error = err.Error()
+		}
+		candidates[i] = c
+	}
+	return candidates
+}
+
+func fillFromOFX(c *Candidate, trn ofxTransaction) error {
+	if trn.FITID == "" {
+		return errors.New("missing FITID")
+	}
+	date, err := parseOFXDate(trn.Posted)
+	if err != nil {
+		return err
+	}
+
+	// Some Brazilian banks write TRNAMT with a decimal comma.
+	decimalComma := strings.Contains(trn.Amount, ",") && !strings.Contains(trn.Amount, ".")
+	amount, err := ParseAmount(trn.Amount, decimalComma)
+	if err != nil {
+		return err
+	}
+	if amount.IsZero() {
+		return errors.New("amount is zero")
+	}
+
+	title := trn.Name
+	if title == "" {
+		title = trn.Memo
+	}
+
+	c.Flow.Date = date
+	c.Flow.Title = title
+	c.Flow.Amount = amount.Abs()
+	c.Flow.Direction = "IN"
+	if amount.IsNegative() {
+		c.Flow.Direction = "OUT"
+	}
+	if title == "" {
+		return errors.New("title is empty")
+	}
+	return nil
+}
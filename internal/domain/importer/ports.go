@@ -0,0 +1,32 @@
+package importer
+
+import (
+	"context"
+	"io"
+	"time"
+
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/domain/cashflow"
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/money"
+)
+
+type Repository interface {
+	WithinTx(ctx context.Context, fn func(ctx context.Context) error) error
+	CreateProfile(ctx context.Context, profile *Profile) (*Profile, error)
+	ListProfiles(ctx context.Context) ([]Profile, error)
+	GetProfile(ctx context.Context, id int32) (*Profile, error)
+	UpdateProfile(ctx context.Context, profile *Profile) (*Profile, error)
+	DeleteProfile(ctx context.Context, id int32) error
+	SuggestCategory(ctx context.Context, title, direction string) (*CategorySuggestion, error)
+	FindDuplicate(ctx context.Context, date time.Time, direction string, amount money.Amount) (*int32, error)
+	FindByFITID(ctx context.Context, externalAccount, fitid string) (*int32, error)
+}
+
+type Service interface {
+	CreateProfile(ctx context.Context, profile Profile) (*Profile, error)
+	ListProfiles(ctx context.Context) ([]Profile, error)
+	UpdateProfile(ctx context.Context, profile Profile) (*Profile, error)
+	DeleteProfile(ctx context.Context, id int32) error
+	PreviewCSV(ctx context.Context, profileID int32, file io.Reader) (*Preview, error)
+	PreviewOFX(ctx context.Context, file io.Reader, paymentMethodID *int32) (*Preview, error)
+	Commit(ctx context.Context, items []cashflow.CreateCashFlowRequest) (*CommitResult, error)
+}
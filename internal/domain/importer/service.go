@@ -0,0 +1,327 @@
+package importer
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/domain/cashflow"
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/domain/payee"
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/domain/payment"
+)
+
+type ImportService struct {
+	repo      Repository
+	cashflows cashflow.Service
+	payRepo   payment.Repository
+}
+
+func NewService(repo Repository, cashflows cashflow.Service, payRepo payment.Repository) *ImportService {
+	return &ImportService{
+		repo:      repo,
+		cashflows: cashflows,
+		payRepo:   payRepo,
+	}
+}
+
+func (s *ImportService) CreateProfile(ctx context.Context, profile Profile) (*Profile, error) {
+	if err := profile.Validate(); err != nil {
+		return nil, err
+	}
+	return s.repo.CreateProfile(ctx, &profile)
+}
+
+func (s *ImportService) ListProfiles(ctx context.Context) ([]Profile, error) {
+	return s.repo.ListProfiles(ctx)
+}
+
+func (s *ImportService) UpdateProfile(ctx context.Context, profile Profile) (*Profile, error) {
+	existing, err := s.repo.GetProfile(ctx, profile.ID)
+	if err != nil {
+		return nil, err
+	}
+	if existing == nil {
+		return nil, ErrProfileNotFound
+	}
+	if err := profile.Validate(); err != nil {
+		return nil, err
+	}
+	return s.repo.UpdateProfile(ctx, &profile)
+}
+
+func (s *ImportService) DeleteProfile(ctx context.Context, id int32) error {
+	existing, err := s.repo.GetProfile(ctx, id)
+	if err != nil {
+		return err
+	}
+	if existing == nil {
+		return ErrProfileNotFound
+	}
+	return s.repo.DeleteProfile(ctx, id)
+}
+
+// PreviewCSV parses a statement with the given profile without saving
+// anything. Each candidate carries a suggested category and is flagged when
+// it looks like an existing cash flow or an earlier line of the same file.
+func (s *ImportService) PreviewCSV(ctx context.Context, profileID int32, file io.Reader) (*Preview, error) {
+	profile, err := s.repo.GetProfile(ctx, profileID)
+	if err != nil {
+		return nil, err
+	}
+	if profile == nil {
+		return nil, ErrProfileNotFound
+	}
+
+	candidates, err := parseCSV(file, profile)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.enrich(ctx, candidates, nil); err != nil {
+		return nil, err
+	}
+	return &Preview{Candidates: candidates}, nil
+}
+
+// PreviewOFX parses an OFX/QFX statement without saving anything. Card
+// statements must name the payment method the transactions belong to, so
+// they land on its invoice once committed.
+func (s *ImportService) PreviewOFX(ctx context.Context, file io.Reader, paymentMethodID *int32) (*Preview, error) {
+	stmt, err := parseOFX(file)
+	if err != nil {
+		return nil, err
+	}
+
+	if paymentMethodID != nil {
+		pm, err := s.payRepo.GetByID(ctx, *paymentMethodID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get payment method: %w", err)
+		}
+		if pm == nil {
+			return nil, payment.ErrPaymentMethodNotFound
+		}
+	} else if stmt.CreditCard {
+		return nil, ErrPaymentMethodRequired
+	}
+
+	candidates := ofxCandidates(stmt)
+	for i := range candidates {
+		c := &candidates[i]
+		if c.Error != "" {
+			continue
+		}
+		existingID, err := s.repo.FindByFITID(ctx, c.Flow.ExternalAccount, c.Flow.FITID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to check FITID: %w", err)
+		}
+		if existingID != nil {
+			c.AlreadyImported = true
+			c.Duplicate = true
+			c.DuplicateOfID = existingID
+		}
+	}
+
+	if err := s.enrich(ctx, candidates, paymentMethodID); err != nil {
+		return nil, err
+	}
+	return &Preview{PaymentMethodID: paymentMethodID, Candidates: candidates}, nil
+}
+
+// Commit saves the reviewed candidates. Every flow goes through the cash
+// flow service, and the whole batch is rolled back if any of them fails.
+// Items whose FITID was already imported are skipped, so overlapping
+// statements can be imported again safely.
+func (s *ImportService) Commit(ctx context.Context, items []cashflow.CreateCashFlowRequest) (*CommitResult, error) {
+	if len(items) == 0 {
+		return nil, ErrNothingToCommit
+	}
+
+	result := &CommitResult{Created: make([]*cashflow.CashFlow, 0, len(items))}
+	err := s.repo.WithinTx(ctx, func(ctx context.Context) error {
+		ctx, err := s.cashflows.PrepareRules(ctx)
+		if err != nil {
+			return err
+		}
+		methods := make(map[int32]*payment.PaymentMethod)
+		for i, item := range items {
+			if item.FITID != "" {
+				existingID, err := s.repo.FindByFITID(ctx, item.ExternalAccount, item.FITID)
+				if err != nil {
+					return fmt.Errorf("item %d: %w", i+1, err)
+				}
+				if existingID != nil {
+					result.Skipped++
+					continue
+				}
+			}
+
+			// Expense details are only kept for outflows; a card refund
+			// must not add to the invoice total.
+			if item.Direction != "OUT" {
+				item.PaymentMethodID = nil
+			} else if item.PaymentMethodID == nil {
+				p, err := s.cashflows.ResolvePayee(ctx, item.PayeeID, item.Title)
+				if err != nil {
+					return fmt.Errorf("item %d: %w", i+1, err)
+				}
+				if p != nil {
+					item.PaymentMethodID = p.DefaultPaymentMethodID
+				}
+			}
+			if item.PaymentMethodID != nil {
+				pm, ok := methods[*item.PaymentMethodID]
+				if !ok {
+					var err error
+					pm, err = s.payRepo.GetByID(ctx, *item.PaymentMethodID)
+					if err != nil {
+						return fmt.Errorf("item %d: %w", i+1, err)
+					}
+					if pm == nil {
+						return fmt.Errorf("item %d: %w", i+1, payment.ErrPaymentMethodNotFound)
+					}
+					methods[*item.PaymentMethodID] = pm
+				}
+				item.AffectsCardInvoice = pm.Kind == payment.KindCreditCard
+			}
+
+			flow, err := s.cashflows.Create(ctx, item)
+			if err != nil {
+				return fmt.Errorf("item %d: %w", i+1, err)
+			}
+			result.Created = append(result.Created, flow)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// enrich flags duplicates and fills in the payee, category and is_fixed a
+// candidate would get. paymentMethodID is the method OFX lines are tied to.
+func (s *ImportService) enrich(ctx context.Context, candidates []Candidate, paymentMethodID *int32) error {
+	ctx, err := s.cashflows.PrepareRules(ctx)
+	if err != nil {
+		return err
+	}
+	seen := make(map[duplicateKey]bool)
+	suggestions := make(map[string]*CategorySuggestion)
+	payees := make(map[string]*payee.Payee)
+
+	for i := range candidates {
+		c := &candidates[i]
+		if c.Error != "" {
+			continue
+		}
+
+		// Lines with a FITID are told apart by it, not by their values.
+		if c.Flow.FITID == "" {
+			key := duplicateKey{
+				date:      c.Flow.Date,
+				direction: c.Flow.Direction,
+				amount:    c.Flow.Amount,
+				title:     strings.ToLower(c.Flow.Title),
+			}
+			if seen[key] {
+				c.Duplicate = true
+			}
+			seen[key] = true
+		}
+
+		if !c.AlreadyImported {
+			existingID, err := s.repo.FindDuplicate(ctx, c.Flow.Date, c.Flow.Direction, c.Flow.Amount)
+			if err != nil {
+				return fmt.Errorf("failed to check duplicates: %w", err)
+			}
+			if existingID != nil {
+				c.Duplicate = true
+				c.DuplicateOfID = existingID
+			}
+		}
+
+		titleKey := strings.ToLower(c.Flow.Title)
+		p, ok := payees[titleKey]
+		if !ok {
+			var err error
+			p, err = s.cashflows.ResolvePayee(ctx, nil, c.Flow.Title)
+			if err != nil {
+				return fmt.Errorf("failed to match payee: %w", err)
+			}
+			payees[titleKey] = p
+		}
+
+		// Rules see the line as the cash flow it would become, so the
+		// preview shows what commit will save.
+		facts := cashflow.Facts{
+			Title:     c.Flow.Title,
+			Direction: c.Flow.Direction,
+			Amount:    c.Flow.Amount,
+		}
+		if c.Flow.Direction == "OUT" {
+			facts.PaymentMethodID = paymentMethodID
+		}
+		if p != nil {
+			facts.PayeeID = &p.ID
+		}
+		actions, err := s.cashflows.EvaluateRules(ctx, facts)
+		if err != nil {
+			return err
+		}
+		if actions.PayeeID != nil {
+			p, err = s.cashflows.ResolvePayee(ctx, actions.PayeeID, c.Flow.Title)
+			if err != nil {
+				return fmt.Errorf("failed to match payee: %w", err)
+			}
+		}
+		if p != nil {
+			c.Flow.PayeeID = &p.ID
+		}
+		if actions.IsFixed != nil {
+			c.Flow.IsFixed = *actions.IsFixed
+		}
+		if actions.CategoryID != nil {
+			c.Flow.CategoryID = *actions.CategoryID
+			c.Flow.CategoryName = actions.CategoryName
+			continue
+		}
+
+		// The default category of the payee wins over the one most used
+		// for the title.
+		cacheKey := c.Flow.Direction + "|" + titleKey
+		if p != nil {
+			cacheKey = fmt.Sprintf("%s|%d|%s", c.Flow.Direction, p.ID, titleKey)
+		}
+		suggestion, ok := suggestions[cacheKey]
+		if !ok {
+			suggestion, err = s.suggestCategory(ctx, p, c.Flow.Title, c.Flow.Direction)
+			if err != nil {
+				return err
+			}
+			suggestions[cacheKey] = suggestion
+		}
+		if suggestion != nil {
+			c.Flow.CategoryID = suggestion.CategoryID
+			c.Flow.CategoryName = suggestion.CategoryName
+		}
+	}
+	return nil
+}
+
+func (s *ImportService) suggestCategory(ctx context.Context, p *payee.Payee, title, direction string) (*CategorySuggestion, error) {
+	if p != nil {
+		cat, err := s.cashflows.PayeeCategory(ctx, p, direction)
+		if err != nil {
+			return nil, err
+		}
+		if cat != nil {
+			return &CategorySuggestion{CategoryID: cat.ID, CategoryName: cat.Name}, nil
+		}
+	}
+	suggestion, err := s.repo.SuggestCategory(ctx, title, direction)
+	if err != nil {
+		return nil, fmt.Errorf("failed to suggest category: %w", err)
+	}
+	return suggestion, nil
+}
@@ -3,6 +3,9 @@ package installment
 import (
 	"errors"
 	"time"
+
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/domain/cashflow"
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/money"
 )
 
 var (
@@ -13,30 +16,75 @@ var (
 type InstallmentPlan struct {
 	ID                int32
 	Description       string
-	TotalAmount       float64
+	TotalAmount       money.Amount
 	InstallmentCount  int32
-	InstallmentAmount float64
+	InstallmentAmount money.Amount
 	StartMonth        time.Time
 	PaymentMethodID   int32
+
+	// Conversion is set for purchases in a foreign currency; TotalAmount is
+	// the converted total, IOF included.
+	Conversion *cashflow.Conversion
 }
 
-func NewPlan(description string, totalAmount float64, count int32, startMonth time.Time, paymentMethodID int32) (*InstallmentPlan, error) {
-	if totalAmount <= 0 {
+// PurchaseRequest carries an installment purchase. When Currency is other
+// than the base currency, TotalAmount is in that currency and is converted
+// once, at the rate of PurchaseDate; IOFAmount overrides the IOF computed
+// for credit cards.
+type PurchaseRequest struct {
+	Description     string
+	TotalAmount     money.Amount
+	Count           int32
+	CategoryID      int32
+	PaymentMethodID int32
+	PurchaseDate    time.Time
+	Currency        string
+	IOFAmount       *money.Amount
+}
+
+func NewPlan(description string, totalAmount money.Amount, count int32, startMonth time.Time, paymentMethodID int32) (*InstallmentPlan, error) {
+	if !totalAmount.IsPositive() {
 		return nil, ErrInvalidTotalAmount
 	}
 	if count < 1 {
 		return nil, ErrInvalidCount
 	}
 
-	// Simple Division
-	amountPerInstallment := totalAmount / float64(count)
+	// The regular installment; the first ones may carry an extra cent (see Amounts).
+	parts := totalAmount.Split(int(count))
 
 	return &InstallmentPlan{
 		Description:       description,
 		TotalAmount:       totalAmount,
 		InstallmentCount:  count,
-		InstallmentAmount: amountPerInstallment,
+		InstallmentAmount: parts[len(parts)-1],
 		StartMonth:        startMonth,
 		PaymentMethodID:   paymentMethodID,
 	}, nil
 }
+
+// Amounts returns the value of each installment. They add up exactly to
+// TotalAmount: the cents that do not divide evenly go to the first ones.
+func (p *InstallmentPlan) Amounts() []money.Amount {
+	return p.TotalAmount.Split(int(p.InstallmentCount))
+}
+
+// Conversions returns each installment's share of the plan's conversion,
+// split like Amounts; all nil for plans in the base currency.
+func (p *InstallmentPlan) Conversions() []*cashflow.Conversion {
+	shares := make([]*cashflow.Conversion, p.InstallmentCount)
+	if p.Conversion == nil {
+		return shares
+	}
+	originals := p.Conversion.OriginalAmount.Split(int(p.InstallmentCount))
+	iofs := p.Conversion.IOFAmount.Split(int(p.InstallmentCount))
+	for i := range shares {
+		shares[i] = &cashflow.Conversion{
+			Currency:       p.Conversion.Currency,
+			OriginalAmount: originals[i],
+			Rate:           p.Conversion.Rate,
+			IOFAmount:      iofs[i],
+		}
+	}
+	return shares
+}
@@ -3,6 +3,8 @@ package installment
 import (
 	"context"
 	"time"
+
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/money"
 )
 
 type Repository interface {
@@ -11,5 +13,6 @@ type Repository interface {
 }
 
 type Service interface {
-	CreateInstallmentPurchase(ctx context.Context, description string, totalAmount float64, count int32, categoryID int32, paymentMethodID int32, purchaseDate time.Time) (*InstallmentPlan, error)
+	CreateInstallmentPurchase(ctx context.Context, description string, totalAmount money.Amount, count int32, categoryID int32, paymentMethodID int32, purchaseDate time.Time) (*InstallmentPlan, error)
+	CreatePurchase(ctx context.Context, req PurchaseRequest) (*InstallmentPlan, error)
 }
@@ -7,6 +7,7 @@ import (
 
 	"github.com/LucasSiedschlag/HausHaltsMeister/internal/domain/cashflow"
 	"github.com/LucasSiedschlag/HausHaltsMeister/internal/domain/payment"
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/money"
 )
 
 type InstallmentService struct {
@@ -23,9 +24,21 @@ func NewService(repo Repository, cfService *cashflow.CashFlowService, payRepo pa
 	}
 }
 
-func (s *InstallmentService) CreateInstallmentPurchase(ctx context.Context, description string, totalAmount float64, count int32, categoryID int32, paymentMethodID int32, purchaseDate time.Time) (*InstallmentPlan, error) {
+func (s *InstallmentService) CreateInstallmentPurchase(ctx context.Context, description string, totalAmount money.Amount, count int32, categoryID int32, paymentMethodID int32, purchaseDate time.Time) (*InstallmentPlan, error) {
+	return s.CreatePurchase(ctx, PurchaseRequest{
+		Description:     description,
+		TotalAmount:     totalAmount,
+		Count:           count,
+		CategoryID:      categoryID,
+		PaymentMethodID: paymentMethodID,
+		PurchaseDate:    purchaseDate,
+	})
+}
+
+// CreatePurchase creates the plan and one cash flow per installment.
+func (s *InstallmentService) CreatePurchase(ctx context.Context, req PurchaseRequest) (*InstallmentPlan, error) {
 	// 1. Get Payment Method
-	pm, err := s.payRepo.GetByID(ctx, paymentMethodID)
+	pm, err := s.payRepo.GetByID(ctx, req.PaymentMethodID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get payment method: %w", err)
 	}
@@ -33,13 +46,26 @@ func (s *InstallmentService) CreateInstallmentPurchase(ctx context.Context, desc
 		return nil, payment.ErrPaymentMethodNotFound
 	}
 
-	firstDueDate := calculateFirstDueDate(pm, purchaseDate)
+	firstDueDate := calculateFirstDueDate(pm, req.PurchaseDate)
+
+	// Foreign purchases are converted once, at the rate of the purchase date.
+	totalAmount := req.TotalAmount
+	var conversion *cashflow.Conversion
+	if req.Currency != "" {
+		totalAmount, conversion, err = s.cfService.Convert(ctx, req.Currency, req.PurchaseDate, req.TotalAmount, req.IOFAmount, pm.Kind == payment.KindCreditCard)
+		if err != nil {
+			return nil, err
+		}
+	} else if req.IOFAmount != nil {
+		return nil, cashflow.ErrIOFNotForeign
+	}
 
 	// 2. Initial Plan Object (without ID)
-	plan, err := NewPlan(description, totalAmount, count, firstDueDate, paymentMethodID)
+	plan, err := NewPlan(req.Description, totalAmount, req.Count, firstDueDate, req.PaymentMethodID)
 	if err != nil {
 		return nil, err
 	}
+	plan.Conversion = conversion
 
 	// 3. Persist Plan
 	createdPlan, err := s.repo.CreatePlan(ctx, plan)
@@ -48,7 +74,8 @@ func (s *InstallmentService) CreateInstallmentPurchase(ctx context.Context, desc
 	}
 
 	// 4. Generate CashFlows
-	installmentAmount := createdPlan.InstallmentAmount
+	amounts := createdPlan.Amounts()
+	conversions := createdPlan.Conversions()
 
 	// Date Logic
 	// If Credit Card:
@@ -111,20 +138,40 @@ func (s *InstallmentService) CreateInstallmentPurchase(ctx context.Context, desc
 	//   //   Its payment is on the corresponding Due Day.
 
 	currentDueDate := firstDueDate
+	now := time.Now()
+	nextMonth := time.Date(now.Year(), now.Month()+1, 1, 0, 0, 0, 0, currentDueDate.Location())
 
-	for i := 0; i < int(count); i++ {
-		title := fmt.Sprintf("%s (%d/%d)", description, i+1, count)
+	ctx, err = s.cfService.PrepareRules(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for i := 0; i < int(req.Count); i++ {
+		title := fmt.Sprintf("%s (%d/%d)", req.Description, i+1, req.Count)
+
+		// Installments of future months are planned until they are paid.
+		status := cashflow.StatusRealized
+		if !currentDueDate.Before(nextMonth) {
+			status = cashflow.StatusPlanned
+		}
 
 		// Create CashFlow
 		// Direction OUT implied for purchases
-		cf, err := s.cfService.CreateCashFlow(ctx, currentDueDate, categoryID, "OUT", title, installmentAmount, false)
+		cf, err := s.cfService.Create(ctx, cashflow.CreateCashFlowRequest{
+			Date:       currentDueDate,
+			CategoryID: req.CategoryID,
+			Direction:  "OUT",
+			Title:      title,
+			Amount:     amounts[i],
+			Status:     status,
+			Conversion: conversions[i],
+		})
 		if err != nil {
 			return nil, fmt.Errorf("failed to create installment %d: %w", i+1, err)
 		}
 
 		// Link ExpenseDetail
 		affectsCard := (pm.Kind == "CREDIT_CARD")
-		err = s.repo.CreateExpenseDetail(ctx, cf.ID, paymentMethodID, createdPlan.ID, affectsCard)
+		err = s.repo.CreateExpenseDetail(ctx, cf.ID, req.PaymentMethodID, createdPlan.ID, affectsCard)
 		if err != nil {
 			return nil, fmt.Errorf("failed to link installment %d: %w", i+1, err)
 		}
@@ -0,0 +1,143 @@
+package payee
+
+import (
+	"errors"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/money"
+)
+
+var (
+	ErrPayeeNotFound  = errors.New("payee not found")
+	ErrNameRequired   = errors.New("name is required")
+	ErrNameTooLong    = errors.New("name and aliases must have at most 100 characters")
+	ErrEmptyAlias     = errors.New("alias must contain letters or digits")
+	ErrKeyInUse       = errors.New("name or alias already belongs to another payee")
+	ErrMergeIntoSelf  = errors.New("a payee cannot be merged into itself")
+	ErrNothingToMerge = errors.New("source_ids is required")
+	ErrInvalidRange   = errors.New("from must not be after to")
+	ErrInvalidLimit   = errors.New("limit must be between 1 and 100")
+	ErrInvalidDir     = errors.New("direction must be IN or OUT")
+)
+
+const (
+	MaxNameLength = 100
+
+	DefaultTopLimit = 10
+	MaxTopLimit     = 100
+)
+
+// Payee is who money was paid to or received from. Its name and aliases are
+// matched against cash flow titles, so "UBER *TRIP" and "Uber Trip" end up
+// on the same payee.
+type Payee struct {
+	ID                     int32
+	Name                   string
+	DefaultCategoryID      *int32
+	DefaultPaymentMethodID *int32
+	Aliases                []string // loaded by Get and List
+	CashFlowCount          int64    // filled by List
+}
+
+// Normalize reduces a name, alias or title to the key used for matching:
+// lower case, with every run of characters other than letters and digits
+// turned into a single space ("UBER *TRIP" -> "uber trip").
+func Normalize(s string) string {
+	fields := strings.FieldsFunc(strings.ToLower(s), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	return strings.Join(fields, " ")
+}
+
+func (p *Payee) Validate() error {
+	p.Name = strings.TrimSpace(p.Name)
+	if Normalize(p.Name) == "" {
+		return ErrNameRequired
+	}
+	if utf8.RuneCountInString(p.Name) > MaxNameLength {
+		return ErrNameTooLong
+	}
+
+	// Aliases are kept as typed, once per key and never repeating the name.
+	seen := map[string]bool{Normalize(p.Name): true}
+	aliases := make([]string, 0, len(p.Aliases))
+	for _, alias := range p.Aliases {
+		alias = strings.TrimSpace(alias)
+		key := Normalize(alias)
+		if key == "" {
+			return ErrEmptyAlias
+		}
+		if utf8.RuneCountInString(alias) > MaxNameLength {
+			return ErrNameTooLong
+		}
+		if !seen[key] {
+			seen[key] = true
+			aliases = append(aliases, alias)
+		}
+	}
+	p.Aliases = aliases
+	return nil
+}
+
+// Keys returns the normalized name followed by the normalized aliases.
+func (p *Payee) Keys() []string {
+	keys := []string{Normalize(p.Name)}
+	for _, alias := range p.Aliases {
+		keys = append(keys, Normalize(alias))
+	}
+	return keys
+}
+
+// Report sums the realized flows of a payee. Split flows count per split
+// line.
+type Report struct {
+	Payee        Payee
+	From, To     *time.Time
+	TotalIncome  money.Amount
+	TotalExpense money.Amount
+	ByCategory   []CategoryTotal
+	ByMonth      []MonthTotal
+}
+
+// CategoryTotal counts a split flow once in each of its categories.
+type CategoryTotal struct {
+	CategoryID    int32
+	CategoryName  string
+	Direction     string
+	CashFlowCount int64
+	TotalAmount   money.Amount
+}
+
+type MonthTotal struct {
+	Month        time.Time
+	TotalIncome  money.Amount
+	TotalExpense money.Amount
+}
+
+type ReportRow struct {
+	Month         time.Time
+	CategoryID    int32
+	CategoryName  string
+	Direction     string
+	CashFlowCount int64
+	TotalAmount   money.Amount
+}
+
+// TopFilter selects the payees ranked by TopPayees. Direction defaults to
+// OUT (where the money went) and Limit to DefaultTopLimit.
+type TopFilter struct {
+	From      *time.Time
+	To        *time.Time
+	Direction string
+	Limit     int32
+}
+
+type Total struct {
+	PayeeID       int32
+	Name          string
+	CashFlowCount int64
+	TotalAmount   money.Amount
+}
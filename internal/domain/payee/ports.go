@@ -0,0 +1,34 @@
+package payee
+
+import (
+	"context"
+	"time"
+)
+
+type Repository interface {
+	WithinTx(ctx context.Context, fn func(ctx context.Context) error) error
+	Create(ctx context.Context, p *Payee) (*Payee, error)
+	List(ctx context.Context) ([]Payee, error)
+	GetByID(ctx context.Context, id int32) (*Payee, error)
+	Update(ctx context.Context, p *Payee) (*Payee, error)
+	Delete(ctx context.Context, id int32) error
+	// FindByKey returns the payee whose normalized name or alias is key.
+	FindByKey(ctx context.Context, key string) (*int32, error)
+	// Match returns the payee of a cash flow title; nil when none matches.
+	Match(ctx context.Context, title string) (*int32, error)
+	ReassignCashFlows(ctx context.Context, fromID, toID int32) (int64, error)
+	Report(ctx context.Context, id int32, from, to *time.Time) ([]ReportRow, error)
+	Top(ctx context.Context, filter TopFilter) ([]Total, error)
+}
+
+type Service interface {
+	CreatePayee(ctx context.Context, p Payee) (*Payee, error)
+	ListPayees(ctx context.Context) ([]Payee, error)
+	GetPayee(ctx context.Context, id int32) (*Payee, error)
+	UpdatePayee(ctx context.Context, p Payee) (*Payee, error)
+	DeletePayee(ctx context.Context, id int32) error
+	MatchPayee(ctx context.Context, title string) (*Payee, error)
+	MergePayees(ctx context.Context, targetID int32, sourceIDs []int32) (*Payee, error)
+	GetReport(ctx context.Context, id int32, from, to *time.Time) (*Report, error)
+	TopPayees(ctx context.Context, filter TopFilter) ([]Total, error)
+}
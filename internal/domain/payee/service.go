@@ -0,0 +1,279 @@
+package payee
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/domain/category"
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/domain/payment"
+)
+
+type PayeeService struct {
+	repo    Repository
+	catRepo category.Repository
+	payRepo payment.Repository
+}
+
+func NewService(repo Repository, catRepo category.Repository, payRepo payment.Repository) *PayeeService {
+	return &PayeeService{
+		repo:    repo,
+		catRepo: catRepo,
+		payRepo: payRepo,
+	}
+}
+
+func (s *PayeeService) CreatePayee(ctx context.Context, p Payee) (*Payee, error) {
+	if err := s.validate(ctx, &p); err != nil {
+		return nil, err
+	}
+
+	var created *Payee
+	err := s.repo.WithinTx(ctx, func(ctx context.Context) error {
+		if err := s.ensureKeysFree(ctx, &p); err != nil {
+			return err
+		}
+		var err error
+		created, err = s.repo.Create(ctx, &p)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return created, nil
+}
+
+func (s *PayeeService) ListPayees(ctx context.Context) ([]Payee, error) {
+	return s.repo.List(ctx)
+}
+
+func (s *PayeeService) GetPayee(ctx context.Context, id int32) (*Payee, error) {
+	p, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if p == nil {
+		return nil, ErrPayeeNotFound
+	}
+	return p, nil
+}
+
+// UpdatePayee replaces the name, aliases and defaults of a payee. Cash flows
+// already linked keep the link.
+func (s *PayeeService) UpdatePayee(ctx context.Context, p Payee) (*Payee, error) {
+	if err := s.validate(ctx, &p); err != nil {
+		return nil, err
+	}
+
+	var updated *Payee
+	err := s.repo.WithinTx(ctx, func(ctx context.Context) error {
+		if _, err := s.GetPayee(ctx, p.ID); err != nil {
+			return err
+		}
+		if err := s.ensureKeysFree(ctx, &p); err != nil {
+			return err
+		}
+		var err error
+		updated, err = s.repo.Update(ctx, &p)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return updated, nil
+}
+
+// DeletePayee removes a payee and its aliases. Its cash flows stay, without
+// a payee.
+func (s *PayeeService) DeletePayee(ctx context.Context, id int32) error {
+	if _, err := s.GetPayee(ctx, id); err != nil {
+		return err
+	}
+	return s.repo.Delete(ctx, id)
+}
+
+// MatchPayee returns the payee a cash flow title belongs to: the one with
+// the longest name or alias the normalized title equals or starts with, as a
+// whole word.
+func (s *PayeeService) MatchPayee(ctx context.Context, title string) (*Payee, error) {
+	id, err := s.repo.Match(ctx, title)
+	if err != nil {
+		return nil, err
+	}
+	if id == nil {
+		return nil, ErrPayeeNotFound
+	}
+	return s.GetPayee(ctx, *id)
+}
+
+// MergePayees folds duplicate payees into target: their cash flows move to
+// target, and their names and aliases become aliases of target so later
+// titles keep matching. The sources are then deleted.
+func (s *PayeeService) MergePayees(ctx context.Context, targetID int32, sourceIDs []int32) (*Payee, error) {
+	if len(sourceIDs) == 0 {
+		return nil, ErrNothingToMerge
+	}
+
+	var merged *Payee
+	err := s.repo.WithinTx(ctx, func(ctx context.Context) error {
+		target, err := s.GetPayee(ctx, targetID)
+		if err != nil {
+			return err
+		}
+
+		done := make(map[int32]bool, len(sourceIDs))
+		for _, id := range sourceIDs {
+			if id == targetID {
+				return ErrMergeIntoSelf
+			}
+			if done[id] {
+				continue
+			}
+			done[id] = true
+
+			source, err := s.GetPayee(ctx, id)
+			if err != nil {
+				return fmt.Errorf("payee %d: %w", id, err)
+			}
+			if _, err := s.repo.ReassignCashFlows(ctx, source.ID, target.ID); err != nil {
+				return err
+			}
+			if err := s.repo.Delete(ctx, source.ID); err != nil {
+				return err
+			}
+			target.Aliases = append(target.Aliases, source.Name)
+			target.Aliases = append(target.Aliases, source.Aliases...)
+		}
+
+		if err := target.Validate(); err != nil {
+			return err
+		}
+		merged, err = s.repo.Update(ctx, target)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return merged, nil
+}
+
+// GetReport totals the realized flows of a payee by category and by month,
+// optionally between from and to.
+func (s *PayeeService) GetReport(ctx context.Context, id int32, from, to *time.Time) (*Report, error) {
+	if from != nil && to != nil && from.After(*to) {
+		return nil, ErrInvalidRange
+	}
+	p, err := s.GetPayee(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := s.repo.Report(ctx, id, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build payee report: %w", err)
+	}
+
+	report := &Report{Payee: *p, From: from, To: to}
+	categories := make(map[int32]*CategoryTotal)
+	months := make(map[string]*MonthTotal)
+	for _, row := range rows {
+		cat, ok := categories[row.CategoryID]
+		if !ok {
+			cat = &CategoryTotal{CategoryID: row.CategoryID, CategoryName: row.CategoryName, Direction: row.Direction}
+			categories[row.CategoryID] = cat
+		}
+		cat.CashFlowCount += row.CashFlowCount
+		cat.TotalAmount = cat.TotalAmount.Add(row.TotalAmount)
+
+		key := row.Month.Format("2006-01")
+		month, ok := months[key]
+		if !ok {
+			month = &MonthTotal{Month: row.Month}
+			months[key] = month
+		}
+		if row.Direction == "IN" {
+			month.TotalIncome = month.TotalIncome.Add(row.TotalAmount)
+			report.TotalIncome = report.TotalIncome.Add(row.TotalAmount)
+		} else {
+			month.TotalExpense = month.TotalExpense.Add(row.TotalAmount)
+			report.TotalExpense = report.TotalExpense.Add(row.TotalAmount)
+		}
+	}
+
+	for _, cat := range categories {
+		report.ByCategory = append(report.ByCategory, *cat)
+	}
+	sort.Slice(report.ByCategory, func(i, j int) bool {
+		return report.ByCategory[i].TotalAmount.Cmp(report.ByCategory[j].TotalAmount) > 0
+	})
+	for _, month := range months {
+		report.ByMonth = append(report.ByMonth, *month)
+	}
+	sort.Slice(report.ByMonth, func(i, j int) bool {
+		return report.ByMonth[i].Month.Before(report.ByMonth[j].Month)
+	})
+	return report, nil
+}
+
+// TopPayees ranks payees by the realized amount of their flows in one
+// direction.
+func (s *PayeeService) TopPayees(ctx context.Context, filter TopFilter) ([]Total, error) {
+	if filter.From != nil && filter.To != nil && filter.From.After(*filter.To) {
+		return nil, ErrInvalidRange
+	}
+	switch filter.Direction {
+	case "":
+		filter.Direction = "OUT"
+	case "IN", "OUT":
+	default:
+		return nil, ErrInvalidDir
+	}
+	if filter.Limit == 0 {
+		filter.Limit = DefaultTopLimit
+	}
+	if filter.Limit < 1 || filter.Limit > MaxTopLimit {
+		return nil, ErrInvalidLimit
+	}
+	return s.repo.Top(ctx, filter)
+}
+
+func (s *PayeeService) validate(ctx context.Context, p *Payee) error {
+	if err := p.Validate(); err != nil {
+		return err
+	}
+	if p.DefaultCategoryID != nil {
+		cat, err := s.catRepo.GetByID(ctx, *p.DefaultCategoryID)
+		if err != nil {
+			return err
+		}
+		if cat == nil {
+			return category.ErrCategoryNotFound
+		}
+	}
+	if p.DefaultPaymentMethodID != nil {
+		pm, err := s.payRepo.GetByID(ctx, *p.DefaultPaymentMethodID)
+		if err != nil {
+			return err
+		}
+		if pm == nil {
+			return payment.ErrPaymentMethodNotFound
+		}
+	}
+	return nil
+}
+
+// ensureKeysFree checks that no other payee already answers to the name or
+// aliases of p.
+func (s *PayeeService) ensureKeysFree(ctx context.Context, p *Payee) error {
+	for _, key := range p.Keys() {
+		owner, err := s.repo.FindByKey(ctx, key)
+		if err != nil {
+			return err
+		}
+		if owner != nil && *owner != p.ID {
+			return fmt.Errorf("%w: %q", ErrKeyInUse, key)
+		}
+	}
+	return nil
+}
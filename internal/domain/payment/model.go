@@ -1,6 +1,10 @@
 package payment
 
-import "errors"
+import (
+	"errors"
+
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/money"
+)
 
 var (
 	ErrNameRequired          = errors.New("name is required")
@@ -23,11 +27,12 @@ type PaymentMethod struct {
 	ID          int32
 	Name        string
 	Kind        string
-	BankName    string   // Optional
-	CreditLimit *float64 // Optional
-	ClosingDay  *int32   // Optional, specific for Credit Card
-	DueDay      *int32   // Optional, specific for Credit Card
+	BankName    string        // Optional
+	CreditLimit *money.Amount // Optional
+	ClosingDay  *int32        // Optional, specific for Credit Card
+	DueDay      *int32        // Optional, specific for Credit Card
 	IsActive    bool
+	AccountID   *int32 // Optional, linked through the account domain; not for Credit Card
 }
 
 // EnsureValid checks basic rules
@@ -45,7 +50,7 @@ func (p *PaymentMethod) Validate() error {
 		if p.DueDay != nil && (*p.DueDay < 1 || *p.DueDay > 31) {
 			return ErrInvalidDueDay
 		}
-		if p.CreditLimit != nil && *p.CreditLimit <= 0 {
+		if p.CreditLimit != nil && !p.CreditLimit.IsPositive() {
 			return ErrInvalidCreditLimit
 		}
 	}
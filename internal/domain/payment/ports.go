@@ -3,21 +3,30 @@ package payment
 import (
 	"context"
 	"time"
+
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/money"
 )
 
 type InvoiceEntry struct {
 	CashFlowID   int32
 	Date         time.Time
 	Title        string
-	Amount       float64
+	Amount       money.Amount
 	CategoryName string
+
+	// Set for purchases in a foreign currency; Amount is converted and
+	// includes the IOF.
+	Currency       string
+	OriginalAmount *money.Amount
+	IOFAmount      *money.Amount
 }
 
 type Invoice struct {
 	PaymentMethodID int32
 	Month           time.Time
-	Total           float64
-	TotalRemaining  float64
+	Total           money.Amount
+	TotalIOF        money.Amount // already in Total
+	TotalRemaining  money.Amount
 	Entries         []InvoiceEntry
 }
 
@@ -27,13 +36,13 @@ type Repository interface {
 	GetByID(ctx context.Context, id int32) (*PaymentMethod, error)
 	Update(ctx context.Context, method *PaymentMethod) (*PaymentMethod, error)
 	GetInvoiceEntries(ctx context.Context, paymentMethodID int32, month time.Time) ([]InvoiceEntry, error)
-	GetOutstandingAmount(ctx context.Context, paymentMethodID int32, month time.Time) (float64, error)
+	GetOutstandingAmount(ctx context.Context, paymentMethodID int32, month time.Time) (money.Amount, error)
 }
 
 type Service interface {
-	CreatePaymentMethod(ctx context.Context, name, kind, bankName string, creditLimit *float64, closingDay, dueDay *int32) (*PaymentMethod, error)
+	CreatePaymentMethod(ctx context.Context, name, kind, bankName string, creditLimit *money.Amount, closingDay, dueDay *int32) (*PaymentMethod, error)
 	ListPaymentMethods(ctx context.Context) ([]PaymentMethod, error)
 	GetInvoice(ctx context.Context, paymentMethodID int32, month time.Time) (*Invoice, error)
-	UpdatePaymentMethod(ctx context.Context, id int32, name, kind, bankName string, creditLimit *float64, closingDay, dueDay *int32, isActive bool) (*PaymentMethod, error)
+	UpdatePaymentMethod(ctx context.Context, id int32, name, kind, bankName string, creditLimit *money.Amount, closingDay, dueDay *int32, isActive bool) (*PaymentMethod, error)
 	DeletePaymentMethod(ctx context.Context, id int32) error
 }
@@ -3,6 +3,8 @@ package payment
 import (
 	"context"
 	"time"
+
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/money"
 )
 
 type PaymentService struct {
@@ -13,7 +15,7 @@ func NewService(repo Repository) *PaymentService {
 	return &PaymentService{repo: repo}
 }
 
-func (s *PaymentService) CreatePaymentMethod(ctx context.Context, name, kind, bankName string, creditLimit *float64, closingDay, dueDay *int32) (*PaymentMethod, error) {
+func (s *PaymentService) CreatePaymentMethod(ctx context.Context, name, kind, bankName string, creditLimit *money.Amount, closingDay, dueDay *int32) (*PaymentMethod, error) {
 	m := &PaymentMethod{
 		Name:        name,
 		Kind:        kind,
@@ -34,7 +36,7 @@ func (s *PaymentService) ListPaymentMethods(ctx context.Context) ([]PaymentMetho
 	return s.repo.List(ctx, false)
 }
 
-func (s *PaymentService) UpdatePaymentMethod(ctx context.Context, id int32, name, kind, bankName string, creditLimit *float64, closingDay, dueDay *int32, isActive bool) (*PaymentMethod, error) {
+func (s *PaymentService) UpdatePaymentMethod(ctx context.Context, id int32, name, kind, bankName string, creditLimit *money.Amount, closingDay, dueDay *int32, isActive bool) (*PaymentMethod, error) {
 	existing, err := s.repo.GetByID(ctx, id)
 	if err != nil {
 		return nil, err
@@ -84,9 +86,12 @@ func (s *PaymentService) GetInvoice(ctx context.Context, paymentMethodID int32,
 		return nil, err
 	}
 
-	var total float64
+	var total, totalIOF money.Amount
 	for _, e := range entries {
-		total += e.Amount
+		total = total.Add(e.Amount)
+		if e.IOFAmount != nil {
+			totalIOF = totalIOF.Add(*e.IOFAmount)
+		}
 	}
 
 	totalRemaining, err := s.repo.GetOutstandingAmount(ctx, paymentMethodID, month)
@@ -98,6 +103,7 @@ func (s *PaymentService) GetInvoice(ctx context.Context, paymentMethodID int32,
 		PaymentMethodID: paymentMethodID,
 		Month:           month,
 		Total:           total,
+		TotalIOF:        totalIOF,
 		TotalRemaining:  totalRemaining,
 		Entries:         entries,
 	}, nil
@@ -3,6 +3,8 @@ package picuinha
 import (
 	"errors"
 	"time"
+
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/money"
 )
 
 var (
@@ -60,7 +62,7 @@ type Person struct {
 	ID      int32
 	Name    string
 	Notes   string
-	Balance float64 // Calculated field
+	Balance money.Amount // Calculated field
 }
 
 type Case struct {
@@ -68,9 +70,9 @@ type Case struct {
 	PersonID                 int32
 	Title                    string
 	CaseType                 string
-	TotalAmount              *float64
+	TotalAmount              *money.Amount
 	InstallmentCount         *int32
-	InstallmentAmount        *float64
+	InstallmentAmount        *money.Amount
 	StartDate                time.Time
 	PaymentMethodID          *int32
 	InstallmentPlanID        *int32
@@ -85,8 +87,8 @@ type CaseSummary struct {
 	Case
 	InstallmentsTotal int32
 	InstallmentsPaid  int32
-	AmountPaid        float64
-	AmountRemaining   float64
+	AmountPaid        money.Amount
+	AmountRemaining   money.Amount
 	Status            string
 }
 
@@ -95,8 +97,8 @@ type CaseInstallment struct {
 	CaseID            int32
 	InstallmentNumber int32
 	DueDate           time.Time
-	Amount            float64
-	ExtraAmount       float64
+	Amount            money.Amount
+	ExtraAmount       money.Amount
 	IsPaid            bool
 	PaidAt            *time.Time
 }
@@ -105,9 +107,9 @@ type CreateCaseRequest struct {
 	PersonID                 int32
 	Title                    string
 	CaseType                 string
-	TotalAmount              float64
+	TotalAmount              money.Amount
 	InstallmentCount         int32
-	InstallmentAmount        float64
+	InstallmentAmount        money.Amount
 	StartDate                time.Time
 	PaymentMethodID          *int32
 	InstallmentPlanID        *int32
@@ -119,5 +121,5 @@ type CreateCaseRequest struct {
 
 type UpdateInstallmentRequest struct {
 	IsPaid      bool
-	ExtraAmount float64
+	ExtraAmount money.Amount
 }
@@ -2,6 +2,8 @@ package picuinha
 
 import (
 	"context"
+
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/money"
 )
 
 type Repository interface {
@@ -12,7 +14,7 @@ type Repository interface {
 	DeletePerson(ctx context.Context, id int32) error
 	CountCasesByPerson(ctx context.Context, personID int32) (int64, error)
 
-	GetBalance(ctx context.Context, personID int32) (float64, error)
+	GetBalance(ctx context.Context, personID int32) (money.Amount, error)
 
 	CreateCase(ctx context.Context, picCase *Case) (*Case, error)
 	UpdateCase(ctx context.Context, picCase *Case) (*Case, error)
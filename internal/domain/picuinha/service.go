@@ -3,6 +3,8 @@ package picuinha
 import (
 	"context"
 	"time"
+
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/money"
 )
 
 type PicuinhaService struct {
@@ -156,9 +158,11 @@ func (s *PicuinhaService) CreateCase(ctx context.Context, req CreateCaseRequest)
 		interval = *recurrenceInterval
 	}
 
-	amount := float64(0)
-	if installmentAmount != nil {
-		amount = *installmentAmount
+	// The installments add up exactly to the total; with a total that does not
+	// divide evenly the first ones carry the extra cents.
+	amounts := make([]money.Amount, count)
+	if totalAmount != nil {
+		amounts = totalAmount.Split(int(count))
 	}
 
 	existingInstallments, err := s.repo.ListInstallmentsByCase(ctx, created.ID)
@@ -172,8 +176,8 @@ func (s *PicuinhaService) CreateCase(ctx context.Context, req CreateCaseRequest)
 				CaseID:            created.ID,
 				InstallmentNumber: i,
 				DueDate:           dueDate,
-				Amount:            amount,
-				ExtraAmount:       0,
+				Amount:            amounts[i-1],
+				ExtraAmount:       money.Amount{},
 				IsPaid:            false,
 			})
 			if err != nil {
@@ -317,14 +321,14 @@ func isValidCaseType(caseType string) bool {
 	}
 }
 
-func normalizeCaseAmounts(req CreateCaseRequest) (*float64, *float64, *int32, *int32, error) {
+func normalizeCaseAmounts(req CreateCaseRequest) (*money.Amount, *money.Amount, *int32, *int32, error) {
 	switch req.CaseType {
 	case CaseTypeOneOff:
-		if req.TotalAmount <= 0 && req.InstallmentAmount <= 0 {
+		if !req.TotalAmount.IsPositive() && !req.InstallmentAmount.IsPositive() {
 			return nil, nil, nil, nil, ErrAmountRequired
 		}
 		amount := req.TotalAmount
-		if amount <= 0 {
+		if !amount.IsPositive() {
 			amount = req.InstallmentAmount
 		}
 		total := amount
@@ -335,25 +339,26 @@ func normalizeCaseAmounts(req CreateCaseRequest) (*float64, *float64, *int32, *i
 		if req.InstallmentCount <= 0 {
 			return nil, nil, nil, nil, ErrInstallmentCount
 		}
-		if req.TotalAmount <= 0 && req.InstallmentAmount <= 0 {
+		if !req.TotalAmount.IsPositive() && !req.InstallmentAmount.IsPositive() {
 			return nil, nil, nil, nil, ErrAmountRequired
 		}
 		total := req.TotalAmount
 		installmentAmount := req.InstallmentAmount
-		if total <= 0 {
-			total = installmentAmount * float64(req.InstallmentCount)
+		if !total.IsPositive() {
+			total = installmentAmount.Mul(int64(req.InstallmentCount))
 		}
-		if installmentAmount <= 0 {
-			installmentAmount = total / float64(req.InstallmentCount)
+		if !installmentAmount.IsPositive() {
+			parts := total.Split(int(req.InstallmentCount))
+			installmentAmount = parts[len(parts)-1]
 		}
 		count := req.InstallmentCount
 		return &total, &installmentAmount, &count, nil, nil
 	case CaseTypeRecurring:
-		if req.InstallmentAmount <= 0 && req.TotalAmount <= 0 {
+		if !req.InstallmentAmount.IsPositive() && !req.TotalAmount.IsPositive() {
 			return nil, nil, nil, nil, ErrAmountRequired
 		}
 		installmentAmount := req.InstallmentAmount
-		if installmentAmount <= 0 {
+		if !installmentAmount.IsPositive() {
 			installmentAmount = req.TotalAmount
 		}
 		count := int32(24)
@@ -361,7 +366,7 @@ func normalizeCaseAmounts(req CreateCaseRequest) (*float64, *float64, *int32, *i
 		if req.RecurrenceIntervalMonths != nil && *req.RecurrenceIntervalMonths > 0 {
 			interval = *req.RecurrenceIntervalMonths
 		}
-		total := installmentAmount * float64(count)
+		total := installmentAmount.Mul(int64(count))
 		return &total, &installmentAmount, &count, &interval, nil
 	default:
 		return nil, nil, nil, nil, ErrCaseTypeInvalid
@@ -0,0 +1,88 @@
+package reconciliation
+
+import (
+	"errors"
+	"time"
+
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/money"
+)
+
+var (
+	ErrReconciliationNotFound = errors.New("reconciliation not found")
+	ErrInvalidOwner           = errors.New("exactly one of account_id or payment_method_id is required")
+	ErrNotACreditCard         = errors.New("only credit cards can be reconciled by payment method; use the account otherwise")
+	ErrInvalidDate            = errors.New("statement_date is required")
+	ErrAlreadyOpen            = errors.New("there is already an open reconciliation for this account or card")
+	ErrNotOpen                = errors.New("reconciliation is already completed")
+	ErrNotBalanced            = errors.New("cleared balance does not match the statement balance")
+	ErrFlowNotInStatement     = errors.New("cash flow does not belong to this statement")
+)
+
+const (
+	StatusOpen      = "OPEN"
+	StatusCompleted = "COMPLETED"
+)
+
+// Owner is the account or the credit card being reconciled. Exactly one is set.
+type Owner struct {
+	AccountID       *int32
+	PaymentMethodID *int32
+}
+
+func (o Owner) Validate() error {
+	if (o.AccountID == nil) == (o.PaymentMethodID == nil) {
+		return ErrInvalidOwner
+	}
+	return nil
+}
+
+// Reconciliation checks an account or card against a bank statement.
+//
+// For accounts StatementBalance is the ending balance of the statement and is
+// compared with the opening balance plus every cleared flow. For credit cards
+// it is the amount owed on the statement, compared with the cleared charges
+// (minus refunds) not covered by an earlier reconciliation.
+type Reconciliation struct {
+	ID               int32
+	Owner            Owner
+	StatementDate    time.Time
+	StatementBalance money.Amount
+	Status           string
+	ClearedBalance   money.Amount // set while open from the cleared flows; stored on completion
+	CreatedAt        time.Time
+	CompletedAt      *time.Time
+}
+
+// Difference is what is still missing for the cleared flows to match the
+// statement. A reconciliation can only be completed at zero.
+func (r Reconciliation) Difference() money.Amount {
+	return r.StatementBalance.Sub(r.ClearedBalance)
+}
+
+func (r *Reconciliation) Validate() error {
+	if err := r.Owner.Validate(); err != nil {
+		return err
+	}
+	if r.StatementDate.IsZero() {
+		return ErrInvalidDate
+	}
+	return nil
+}
+
+// Item is a flow on the reconciliation worksheet.
+type Item struct {
+	CashFlowID   int32
+	Date         time.Time
+	Direction    string
+	Title        string
+	Amount       money.Amount
+	CategoryName string
+	ClearedAt    *time.Time
+}
+
+// Worksheet is a reconciliation with its flows: the candidates while open,
+// the locked flows once completed.
+type Worksheet struct {
+	Reconciliation
+	Items []Item
+}
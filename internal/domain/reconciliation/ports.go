@@ -0,0 +1,36 @@
+package reconciliation
+
+import (
+	"context"
+	"time"
+
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/money"
+)
+
+type Repository interface {
+	WithinTx(ctx context.Context, fn func(ctx context.Context) error) error
+	Create(ctx context.Context, r *Reconciliation) (*Reconciliation, error)
+	GetByID(ctx context.Context, id int32) (*Reconciliation, error)
+	GetOpen(ctx context.Context, owner Owner) (*Reconciliation, error)
+	List(ctx context.Context, owner Owner) ([]Reconciliation, error)
+	Complete(ctx context.Context, id int32, clearedBalance money.Amount) (*Reconciliation, error)
+	Delete(ctx context.Context, id int32) error
+	// ListCandidates returns the flows of the owner up to the statement date
+	// that no completed reconciliation has locked yet.
+	ListCandidates(ctx context.Context, owner Owner, statementDate time.Time) ([]Item, error)
+	ListLocked(ctx context.Context, id int32) ([]Item, error)
+	// ClearedTotal sums the cleared flows of the owner up to the statement
+	// date, IN positive and OUT negative, from the given date when set.
+	ClearedTotal(ctx context.Context, owner Owner, includeLocked bool, from *time.Time, statementDate time.Time) (money.Amount, error)
+	SetCleared(ctx context.Context, cashFlowIDs []int32, cleared bool) error
+	Lock(ctx context.Context, r *Reconciliation) error
+}
+
+type Service interface {
+	Start(ctx context.Context, r Reconciliation) (*Worksheet, error)
+	List(ctx context.Context, owner Owner) ([]Reconciliation, error)
+	Get(ctx context.Context, id int32) (*Worksheet, error)
+	SetCleared(ctx context.Context, id int32, cashFlowIDs []int32, cleared bool) (*Worksheet, error)
+	Complete(ctx context.Context, id int32) (*Worksheet, error)
+	Cancel(ctx context.Context, id int32) error
+}
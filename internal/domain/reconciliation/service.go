@@ -0,0 +1,218 @@
+package reconciliation
+
+import (
+	"context"
+
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/domain/account"
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/domain/payment"
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/money"
+)
+
+type ReconciliationService struct {
+	repo    Repository
+	accRepo account.Repository
+	payRepo payment.Repository
+}
+
+func NewService(repo Repository, accRepo account.Repository, payRepo payment.Repository) *ReconciliationService {
+	return &ReconciliationService{
+		repo:    repo,
+		accRepo: accRepo,
+		payRepo: payRepo,
+	}
+}
+
+// Start opens a reconciliation for a statement. Only one can be open per
+// account or card at a time.
+func (s *ReconciliationService) Start(ctx context.Context, r Reconciliation) (*Worksheet, error) {
+	if err := r.Validate(); err != nil {
+		return nil, err
+	}
+	if err := s.ensureOwner(ctx, r.Owner); err != nil {
+		return nil, err
+	}
+	open, err := s.repo.GetOpen(ctx, r.Owner)
+	if err != nil {
+		return nil, err
+	}
+	if open != nil {
+		return nil, ErrAlreadyOpen
+	}
+
+	created, err := s.repo.Create(ctx, &r)
+	if err != nil {
+		return nil, err
+	}
+	return s.worksheet(ctx, created)
+}
+
+func (s *ReconciliationService) List(ctx context.Context, owner Owner) ([]Reconciliation, error) {
+	return s.repo.List(ctx, owner)
+}
+
+func (s *ReconciliationService) Get(ctx context.Context, id int32) (*Worksheet, error) {
+	r, err := s.get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return s.worksheet(ctx, r)
+}
+
+// SetCleared marks flows of the statement as cleared, or back as uncleared.
+// All ids must be candidates of the reconciliation, or nothing changes.
+func (s *ReconciliationService) SetCleared(ctx context.Context, id int32, cashFlowIDs []int32, cleared bool) (*Worksheet, error) {
+	r, err := s.getOpen(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	candidates, err := s.repo.ListCandidates(ctx, r.Owner, r.StatementDate)
+	if err != nil {
+		return nil, err
+	}
+	known := make(map[int32]bool, len(candidates))
+	for _, item := range candidates {
+		known[item.CashFlowID] = true
+	}
+	for _, cfID := range cashFlowIDs {
+		if !known[cfID] {
+			return nil, ErrFlowNotInStatement
+		}
+	}
+
+	if err := s.repo.SetCleared(ctx, cashFlowIDs, cleared); err != nil {
+		return nil, err
+	}
+	return s.worksheet(ctx, r)
+}
+
+// Complete locks the cleared flows once they add up to the statement balance.
+// Locked flows can no longer be edited, moved or deleted.
+func (s *ReconciliationService) Complete(ctx context.Context, id int32) (*Worksheet, error) {
+	var completed *Reconciliation
+	err := s.repo.WithinTx(ctx, func(ctx context.Context) error {
+		r, err := s.getOpen(ctx, id)
+		if err != nil {
+			return err
+		}
+		r.ClearedBalance, err = s.clearedBalance(ctx, r)
+		if err != nil {
+			return err
+		}
+		if !r.Difference().IsZero() {
+			return ErrNotBalanced
+		}
+
+		if err := s.repo.Lock(ctx, r); err != nil {
+			return err
+		}
+		completed, err = s.repo.Complete(ctx, r.ID, r.ClearedBalance)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return s.worksheet(ctx, completed)
+}
+
+// Cancel discards an open reconciliation. The cleared marks are kept for the
+// next attempt.
+func (s *ReconciliationService) Cancel(ctx context.Context, id int32) error {
+	if _, err := s.getOpen(ctx, id); err != nil {
+		return err
+	}
+	return s.repo.Delete(ctx, id)
+}
+
+func (s *ReconciliationService) worksheet(ctx context.Context, r *Reconciliation) (*Worksheet, error) {
+	ws := &Worksheet{Reconciliation: *r}
+	var err error
+	if r.Status == StatusCompleted {
+		ws.Items, err = s.repo.ListLocked(ctx, r.ID)
+		return ws, err
+	}
+
+	ws.ClearedBalance, err = s.clearedBalance(ctx, r)
+	if err != nil {
+		return nil, err
+	}
+	ws.Items, err = s.repo.ListCandidates(ctx, r.Owner, r.StatementDate)
+	if err != nil {
+		return nil, err
+	}
+	return ws, nil
+}
+
+// clearedBalance is the balance the bank should show given the cleared flows
+// up to the statement date: the running account balance, or the amount owed
+// on the card statement. Flows after that date may still be cleared by an
+// earlier reconciliation that was cancelled.
+func (s *ReconciliationService) clearedBalance(ctx context.Context, r *Reconciliation) (money.Amount, error) {
+	if r.Owner.AccountID != nil {
+		acc, err := s.accRepo.GetByID(ctx, *r.Owner.AccountID)
+		if err != nil {
+			return money.Amount{}, err
+		}
+		if acc == nil {
+			return money.Amount{}, account.ErrAccountNotFound
+		}
+		total, err := s.repo.ClearedTotal(ctx, r.Owner, true, &acc.OpeningDate, r.StatementDate)
+		if err != nil {
+			return money.Amount{}, err
+		}
+		return acc.OpeningBalance.Add(total), nil
+	}
+
+	total, err := s.repo.ClearedTotal(ctx, r.Owner, false, nil, r.StatementDate)
+	if err != nil {
+		return money.Amount{}, err
+	}
+	return total.Neg(), nil
+}
+
+func (s *ReconciliationService) ensureOwner(ctx context.Context, owner Owner) error {
+	if owner.AccountID != nil {
+		acc, err := s.accRepo.GetByID(ctx, *owner.AccountID)
+		if err != nil {
+			return err
+		}
+		if acc == nil {
+			return account.ErrAccountNotFound
+		}
+		return nil
+	}
+
+	method, err := s.payRepo.GetByID(ctx, *owner.PaymentMethodID)
+	if err != nil {
+		return err
+	}
+	if method == nil {
+		return payment.ErrPaymentMethodNotFound
+	}
+	if method.Kind != payment.KindCreditCard {
+		return ErrNotACreditCard
+	}
+	return nil
+}
+
+func (s *ReconciliationService) get(ctx context.Context, id int32) (*Reconciliation, error) {
+	r, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if r == nil {
+		return nil, ErrReconciliationNotFound
+	}
+	return r, nil
+}
+
+func (s *ReconciliationService) getOpen(ctx context.Context, id int32) (*Reconciliation, error) {
+	r, err := s.get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if r.Status != StatusOpen {
+		return nil, ErrNotOpen
+	}
+	return r, nil
+}
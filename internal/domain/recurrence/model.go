@@ -0,0 +1,178 @@
+package recurrence
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/money"
+)
+
+var (
+	ErrRuleNotFound          = errors.New("recurrence rule not found")
+	ErrTitleRequired         = errors.New("title is required")
+	ErrInvalidAmount         = errors.New("amount must be greater than zero")
+	ErrInvalidDirection      = errors.New("direction must be IN or OUT")
+	ErrInvalidFrequency      = errors.New("frequency must be WEEKLY, MONTHLY or YEARLY")
+	ErrInvalidInterval       = errors.New("interval must be at least 1")
+	ErrInvalidMonthDay       = errors.New("by_month_day must be between 1 and 31, or -1 for the last day")
+	ErrInvalidBusinessAdjust = errors.New("business_day_adjust must be NONE, PREVIOUS or NEXT")
+	ErrStartDateRequired     = errors.New("start date is required")
+	ErrInvalidEndDate        = errors.New("end date must not be before start date")
+	ErrInvalidRange          = errors.New("from month must not be after to month")
+)
+
+const (
+	FrequencyWeekly  = "WEEKLY"
+	FrequencyMonthly = "MONTHLY"
+	FrequencyYearly  = "YEARLY"
+
+	AdjustNone     = "NONE"
+	AdjustPrevious = "PREVIOUS" // weekend -> Friday before
+	AdjustNext     = "NEXT"     // weekend -> Monday after
+
+	// LastDayOfMonth as ByMonthDay always lands on the month's last day.
+	LastDayOfMonth = -1
+)
+
+// Rule is a template cash flow plus an RRULE-like schedule.
+type Rule struct {
+	ID              int32
+	Title           string
+	CategoryID      int32
+	Direction       string
+	Amount          money.Amount
+	IsFixed         bool
+	PaymentMethodID *int32
+
+	Frequency         string
+	Interval          int32
+	ByMonthDay        *int32 // MONTHLY/YEARLY only; defaults to the start date day
+	BusinessDayAdjust string
+	StartDate         time.Time
+	EndDate           *time.Time
+	IsActive          bool
+}
+
+func (r *Rule) Validate() error {
+	if strings.TrimSpace(r.Title) == "" {
+		return ErrTitleRequired
+	}
+	if !r.Amount.IsPositive() {
+		return ErrInvalidAmount
+	}
+	if r.Direction != "IN" && r.Direction != "OUT" {
+		return ErrInvalidDirection
+	}
+	switch r.Frequency {
+	case FrequencyWeekly, FrequencyMonthly, FrequencyYearly:
+	default:
+		return ErrInvalidFrequency
+	}
+	if r.Interval < 1 {
+		return ErrInvalidInterval
+	}
+	if r.ByMonthDay != nil && *r.ByMonthDay != LastDayOfMonth && (*r.ByMonthDay < 1 || *r.ByMonthDay > 31) {
+		return ErrInvalidMonthDay
+	}
+	switch r.BusinessDayAdjust {
+	case AdjustNone, AdjustPrevious, AdjustNext:
+	default:
+		return ErrInvalidBusinessAdjust
+	}
+	if r.StartDate.IsZero() {
+		return ErrStartDateRequired
+	}
+	if r.EndDate != nil && r.EndDate.Before(r.StartDate) {
+		return ErrInvalidEndDate
+	}
+	return nil
+}
+
+// Occurrence links a nominal date of a rule to the cash flow generated for it.
+type Occurrence struct {
+	RuleID     int32
+	Date       time.Time // nominal date, before business day adjustment
+	CashFlowID *int32    // nil when the generated flow was deleted
+}
+
+// Dates returns the nominal occurrence dates of the rule between from and
+// to (both inclusive).
+func (r *Rule) Dates(from, to time.Time) []time.Time {
+	var dates []time.Time
+	last := to
+	if r.EndDate != nil && r.EndDate.Before(last) {
+		last = *r.EndDate
+	}
+
+	for k := 0; ; k++ {
+		date := r.nth(k)
+		if date.After(last) {
+			break
+		}
+		if date.Before(r.StartDate) || date.Before(from) {
+			continue
+		}
+		dates = append(dates, date)
+	}
+	return dates
+}
+
+// nth returns the k-th nominal date counting from the start date.
+func (r *Rule) nth(k int) time.Time {
+	start := r.StartDate
+	step := k * int(r.Interval)
+
+	switch r.Frequency {
+	case FrequencyWeekly:
+		return start.AddDate(0, 0, 7*step)
+	case FrequencyYearly:
+		return r.dayInMonth(start.Year()+step, start.Month())
+	default:
+		first := time.Date(start.Year(), start.Month()+time.Month(step), 1, 0, 0, 0, 0, time.UTC)
+		return r.dayInMonth(first.Year(), first.Month())
+	}
+}
+
+// dayInMonth applies ByMonthDay, clamping to the month length (31 -> Feb 28).
+func (r *Rule) dayInMonth(year int, month time.Month) time.Time {
+	lastDay := time.Date(year, month+1, 0, 0, 0, 0, 0, time.UTC).Day()
+
+	day := r.StartDate.Day()
+	if r.ByMonthDay != nil {
+		day = int(*r.ByMonthDay)
+	}
+	if day == LastDayOfMonth || day > lastDay {
+		day = lastDay
+	}
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+// Adjust moves a weekend date according to BusinessDayAdjust. Holidays are
+// not taken into account.
+func (r *Rule) Adjust(date time.Time) time.Time {
+	switch r.BusinessDayAdjust {
+	case AdjustPrevious:
+		for date.Weekday() == time.Saturday || date.Weekday() == time.Sunday {
+			date = date.AddDate(0, 0, -1)
+		}
+	case AdjustNext:
+		for date.Weekday() == time.Saturday || date.Weekday() == time.Sunday {
+			date = date.AddDate(0, 0, 1)
+		}
+	}
+	return date
+}
+
+// MaterializeResult reports what a materialization run did.
+type MaterializeResult struct {
+	Created []Occurrence
+	Skipped int // occurrences generated by a previous run
+	Failed  []Failure
+}
+
+type Failure struct {
+	RuleID int32
+	Date   time.Time
+	Error  string
+}
@@ -0,0 +1,29 @@
+package recurrence
+
+import (
+	"context"
+	"time"
+)
+
+type Repository interface {
+	WithinTx(ctx context.Context, fn func(ctx context.Context) error) error
+	Create(ctx context.Context, rule *Rule) (*Rule, error)
+	List(ctx context.Context, activeOnly bool) ([]Rule, error)
+	GetByID(ctx context.Context, id int32) (*Rule, error)
+	Update(ctx context.Context, rule *Rule) (*Rule, error)
+	Delete(ctx context.Context, id int32) error
+	// ClaimOccurrence records the occurrence and reports false when a
+	// previous run already did.
+	ClaimOccurrence(ctx context.Context, ruleID int32, date time.Time) (bool, error)
+	SetOccurrenceCashFlow(ctx context.Context, ruleID int32, date time.Time, cashFlowID int32) error
+	ListOccurrences(ctx context.Context, ruleID int32) ([]Occurrence, error)
+}
+
+type Service interface {
+	CreateRule(ctx context.Context, rule Rule) (*Rule, error)
+	ListRules(ctx context.Context) ([]Rule, error)
+	UpdateRule(ctx context.Context, rule Rule) (*Rule, error)
+	DeleteRule(ctx context.Context, id int32) error
+	ListOccurrences(ctx context.Context, ruleID int32) ([]Occurrence, error)
+	Materialize(ctx context.Context, fromMonth, toMonth time.Time) (*MaterializeResult, error)
+}
@@ -0,0 +1,207 @@
+package recurrence
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/domain/cashflow"
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/domain/category"
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/domain/payment"
+)
+
+type RecurrenceService struct {
+	repo      Repository
+	cashflows cashflow.Service
+	catRepo   category.Repository
+	payRepo   payment.Repository
+}
+
+func NewService(repo Repository, cashflows cashflow.Service, catRepo category.Repository, payRepo payment.Repository) *RecurrenceService {
+	return &RecurrenceService{
+		repo:      repo,
+		cashflows: cashflows,
+		catRepo:   catRepo,
+		payRepo:   payRepo,
+	}
+}
+
+func (s *RecurrenceService) CreateRule(ctx context.Context, rule Rule) (*Rule, error) {
+	rule.IsActive = true
+	if err := s.validate(ctx, &rule); err != nil {
+		return nil, err
+	}
+	return s.repo.Create(ctx, &rule)
+}
+
+func (s *RecurrenceService) ListRules(ctx context.Context) ([]Rule, error) {
+	return s.repo.List(ctx, false)
+}
+
+// UpdateRule changes the template and schedule. Occurrences already
+// generated are kept as they are; only future runs use the new values.
+func (s *RecurrenceService) UpdateRule(ctx context.Context, rule Rule) (*Rule, error) {
+	existing, err := s.repo.GetByID(ctx, rule.ID)
+	if err != nil {
+		return nil, err
+	}
+	if existing == nil {
+		return nil, ErrRuleNotFound
+	}
+	if err := s.validate(ctx, &rule); err != nil {
+		return nil, err
+	}
+	return s.repo.Update(ctx, &rule)
+}
+
+// DeleteRule removes the rule and its occurrence records. Cash flows it
+// generated stay untouched.
+func (s *RecurrenceService) DeleteRule(ctx context.Context, id int32) error {
+	existing, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	if existing == nil {
+		return ErrRuleNotFound
+	}
+	return s.repo.Delete(ctx, id)
+}
+
+func (s *RecurrenceService) ListOccurrences(ctx context.Context, ruleID int32) ([]Occurrence, error) {
+	existing, err := s.repo.GetByID(ctx, ruleID)
+	if err != nil {
+		return nil, err
+	}
+	if existing == nil {
+		return nil, ErrRuleNotFound
+	}
+	return s.repo.ListOccurrences(ctx, ruleID)
+}
+
+// Materialize generates the cash flows of every active rule for the months
+// between fromMonth and toMonth. It is idempotent: occurrences produced by
+// an earlier run (even if their flow was deleted since) are skipped. Each
+// occurrence is created atomically with its cash flow; a failing one is
+// reported and does not stop the others.
+func (s *RecurrenceService) Materialize(ctx context.Context, fromMonth, toMonth time.Time) (*MaterializeResult, error) {
+	from := time.Date(fromMonth.Year(), fromMonth.Month(), 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(toMonth.Year(), toMonth.Month()+1, 0, 0, 0, 0, 0, time.UTC)
+	if from.After(to) {
+		return nil, ErrInvalidRange
+	}
+
+	rules, err := s.repo.List(ctx, true)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list rules: %w", err)
+	}
+	ctx, err = s.cashflows.PrepareRules(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &MaterializeResult{}
+	for i := range rules {
+		rule := &rules[i]
+
+		var affectsCard bool
+		if rule.PaymentMethodID != nil {
+			pm, err := s.payRepo.GetByID(ctx, *rule.PaymentMethodID)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get payment method: %w", err)
+			}
+			affectsCard = pm != nil && pm.Kind == payment.KindCreditCard
+		}
+
+		for _, date := range rule.Dates(from, to) {
+			occurrence, created, err := s.materializeOne(ctx, rule, date, affectsCard)
+			if err != nil {
+				result.Failed = append(result.Failed, Failure{RuleID: rule.ID, Date: date, Error: err.Error()})
+				continue
+			}
+			if !created {
+				result.Skipped++
+				continue
+			}
+			result.Created = append(result.Created, *occurrence)
+		}
+	}
+	return result, nil
+}
+
+func (s *RecurrenceService) materializeOne(ctx context.Context, rule *Rule, date time.Time, affectsCard bool) (*Occurrence, bool, error) {
+	var occurrence *Occurrence
+	err := s.repo.WithinTx(ctx, func(ctx context.Context) error {
+		claimed, err := s.repo.ClaimOccurrence(ctx, rule.ID, date)
+		if err != nil || !claimed {
+			return err
+		}
+
+		// Occurrences of future months are planned until they happen, like
+		// installments.
+		flowDate := rule.Adjust(date)
+		now := time.Now()
+		status := cashflow.StatusRealized
+		if !flowDate.Before(time.Date(now.Year(), now.Month()+1, 1, 0, 0, 0, 0, time.UTC)) {
+			status = cashflow.StatusPlanned
+		}
+
+		flow, err := s.cashflows.Create(ctx, cashflow.CreateCashFlowRequest{
+			Date:               flowDate,
+			CategoryID:         rule.CategoryID,
+			Direction:          rule.Direction,
+			Title:              rule.Title,
+			Amount:             rule.Amount,
+			IsFixed:            rule.IsFixed,
+			PaymentMethodID:    rule.PaymentMethodID,
+			AffectsCardInvoice: affectsCard,
+			Status:             status,
+		})
+		if err != nil {
+			return err
+		}
+		if err := s.repo.SetOccurrenceCashFlow(ctx, rule.ID, date, flow.ID); err != nil {
+			return err
+		}
+
+		occurrence = &Occurrence{RuleID: rule.ID, Date: date, CashFlowID: &flow.ID}
+		return nil
+	})
+	if err != nil {
+		return nil, false, err
+	}
+	return occurrence, occurrence != nil, nil
+}
+
+func (s *RecurrenceService) validate(ctx context.Context, rule *Rule) error {
+	if rule.Interval == 0 {
+		rule.Interval = 1
+	}
+	if rule.BusinessDayAdjust == "" {
+		rule.BusinessDayAdjust = AdjustNone
+	}
+	if err := rule.Validate(); err != nil {
+		return err
+	}
+
+	cat, err := s.catRepo.GetByID(ctx, rule.CategoryID)
+	if err != nil {
+		return fmt.Errorf("failed to get category: %w", err)
+	}
+	if cat == nil {
+		return cashflow.ErrCategoryNotFound
+	}
+	if cat.Direction != rule.Direction {
+		return cashflow.ErrDirectionMismatch
+	}
+
+	if rule.PaymentMethodID != nil {
+		pm, err := s.payRepo.GetByID(ctx, *rule.PaymentMethodID)
+		if err != nil {
+			return fmt.Errorf("failed to get payment method: %w", err)
+		}
+		if pm == nil {
+			return payment.ErrPaymentMethodNotFound
+		}
+	}
+	return nil
+}
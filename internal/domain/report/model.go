@@ -0,0 +1,386 @@
+package report
+
+import (
+	"errors"
+	"time"
+
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/domain/budget"
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/domain/cashflow"
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/domain/payment"
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/domain/picuinha"
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/money"
+)
+
+var (
+	ErrInvalidRange  = errors.New("from must not be after to")
+	ErrRangeTooLong  = errors.New("range must cover at most 120 months")
+	ErrInvalidLimit  = errors.New("limit must be between 1 and 50")
+	ErrInvalidMonths = errors.New("months must be between 1 and 36")
+	ErrInvalidYear   = errors.New("year must be between 1900 and 9999")
+)
+
+const (
+	// DefaultMonths is how many months a report covers, up to the current
+	// one, when the range is not given.
+	DefaultMonths = 12
+	MaxMonths     = 120
+
+	DefaultGrowthLimit = 10
+	MaxGrowthLimit     = 50
+
+	DefaultForecastMonths = 12
+	MaxForecastMonths     = 36
+	// ForecastHistoryMonths is how many complete months before the current
+	// one the variable spending averages look at.
+	ForecastHistoryMonths = 6
+	// ForecastZ is the z-score of the forecast bands: about 80% of the
+	// months should land inside them.
+	ForecastZ = 1.2816
+)
+
+// CumulativeFilter selects the months of the cumulative cash flow. From and
+// To are truncated to their months.
+type CumulativeFilter struct {
+	From *time.Time
+	To   *time.Time
+	// IncludeInstallments adds the planned installments of purchases, so
+	// future months show what is already committed.
+	IncludeInstallments bool
+}
+
+// Totals are the flows of one month and the balance they add up to since
+// the first flow ever recorded.
+type Totals struct {
+	Income  money.Amount
+	Expense money.Amount
+	Net     money.Amount
+	Balance money.Amount
+}
+
+// CumulativeMonth splits a month between own money and picuinhas: flows
+// linked to a picuinha entry or to an installment plan of a person, i.e.
+// money lent to or paid for someone else.
+type CumulativeMonth struct {
+	Month    time.Time
+	Total    Totals
+	Own      Totals
+	Picuinha Totals
+	// PlannedInstallments is the part of the expense that is still planned;
+	// zero unless installments are included.
+	PlannedInstallments money.Amount
+}
+
+// Cumulative is the month-by-month evolution of the balance. Transfers
+// between accounts are left out, as they do not change it.
+type Cumulative struct {
+	From                time.Time
+	To                  time.Time
+	IncludeInstallments bool
+	OpeningBalance      money.Amount // balance before From
+	Months              []CumulativeMonth
+}
+
+// CumulativeRow is one month as the repository returns it; the balances
+// already run over all earlier months.
+type CumulativeRow struct {
+	Month               time.Time
+	OwnIncome           money.Amount
+	OwnExpense          money.Amount
+	PicuinhaIncome      money.Amount
+	PicuinhaExpense     money.Amount
+	PlannedInstallments money.Amount
+	OwnBalance          money.Amount
+	PicuinhaBalance     money.Amount
+}
+
+// CategoryMonth is a category's realized total in a month next to its
+// budget, if any, and its moving averages.
+type CategoryMonth struct {
+	Month   time.Time
+	Total   money.Amount
+	Planned *money.Amount
+	// MovingAverage3 and MovingAverage6 average the month with the 2 or 5
+	// before it, months without flows counting as zero.
+	MovingAverage3 money.Amount
+	MovingAverage6 money.Amount
+	// Change is the percentage over the previous month; nil when that was
+	// zero.
+	Change *float64
+}
+
+// CategoryHistory is a category month by month. TrendSlope is the slope of
+// the least squares line over the monthly totals: how much the category
+// grows (or shrinks, when negative) per month.
+type CategoryHistory struct {
+	CategoryID   int32
+	CategoryName string
+	Direction    string
+	From         time.Time
+	To           time.Time
+	TrendSlope   float64
+	Months       []CategoryMonth
+}
+
+type CategoryHistoryRow struct {
+	Month          time.Time
+	Total          money.Amount
+	Planned        *money.Amount
+	MovingAverage3 money.Amount
+	MovingAverage6 money.Amount
+	PreviousTotal  money.Amount
+	TrendSlope     float64
+}
+
+type GrowthFilter struct {
+	From  *time.Time
+	To    *time.Time
+	Limit int
+}
+
+// CategoryGrowth ranks an expense category by its trend over the range.
+type CategoryGrowth struct {
+	CategoryID     int32
+	CategoryName   string
+	Total          money.Amount
+	MonthlyAverage money.Amount
+	TrendSlope     float64
+	// GrowthPercent is the slope over the monthly average, the growth per
+	// month relative to the category's size.
+	GrowthPercent float64
+	// Planned is the budget summed over the range; nil without any.
+	Planned *money.Amount
+}
+
+type Growth struct {
+	From       time.Time
+	To         time.Time
+	Categories []CategoryGrowth
+}
+
+// ForecastFlow is a flow the forecast expects: a planned cash flow, or a
+// fixed one of the latest month that had any, repeated every month.
+type ForecastFlow struct {
+	Month         time.Time // zero for fixed flows
+	Direction     string
+	CategoryID    int32
+	Title         string
+	Amount        money.Amount
+	IsInstallment bool
+}
+
+// Receivable is what people owe in a month for picuinha installments not
+// paid yet; overdue ones count in the first forecast month.
+type Receivable struct {
+	Month  time.Time
+	Amount money.Amount
+}
+
+// VariableSpending is what a category spends in a month, on average, apart
+// from fixed flows, installments, recurrences and picuinhas. StdDev is the
+// monthly standard deviation.
+type VariableSpending struct {
+	CategoryID   int32
+	CategoryName string
+	Average      money.Amount
+	StdDev       float64
+}
+
+// ForecastSources breaks a forecast month down by where the money comes
+// from.
+type ForecastSources struct {
+	Installments     money.Amount // planned installments of purchases
+	PlannedIncome    money.Amount // other planned flows
+	PlannedExpense   money.Amount
+	FixedIncome      money.Amount
+	FixedExpense     money.Amount
+	RecurringIncome  money.Amount // occurrences of recurrence rules not generated yet
+	RecurringExpense money.Amount
+	VariableExpense  money.Amount
+	Receivables      money.Amount // picuinha installments expected back
+}
+
+// Band is the range a forecast value should fall in.
+type Band struct {
+	Low  money.Amount
+	High money.Amount
+}
+
+// ForecastMonth is the expected income, expense and ending balance of a
+// month. The bands widen with the variability of the variable spending,
+// and the low ones assume no picuinha money comes back.
+type ForecastMonth struct {
+	Month       time.Time
+	Income      money.Amount
+	Expense     money.Amount
+	Net         money.Amount
+	Balance     money.Amount
+	IncomeBand  Band
+	ExpenseBand Band
+	BalanceBand Band
+	Sources     ForecastSources
+}
+
+// Forecast projects the months after the current one. OpeningBalance is the
+// balance expected at the end of the current month: realized flows plus the
+// ones still planned for it.
+type Forecast struct {
+	From           time.Time
+	To             time.Time
+	OpeningBalance money.Amount
+	// HistoryFrom and HistoryTo are the months the variable spending is
+	// averaged over.
+	HistoryFrom time.Time
+	HistoryTo   time.Time
+	Variable    []VariableSpending
+	Months      []ForecastMonth
+	// Lowest is the month with the lowest pessimistic balance.
+	Lowest *ForecastMonth
+}
+
+// AnnualCell is a realized amount next to the budget for it; Planned is nil
+// when no budget covers it.
+type AnnualCell struct {
+	Actual  money.Amount
+	Planned *money.Amount
+}
+
+func (c AnnualCell) add(o AnnualCell) AnnualCell {
+	sum := AnnualCell{Actual: c.Actual.Add(o.Actual)}
+	if c.Planned != nil || o.Planned != nil {
+		var planned money.Amount
+		if c.Planned != nil {
+			planned = *c.Planned
+		}
+		if o.Planned != nil {
+			planned = planned.Add(*o.Planned)
+		}
+		sum.Planned = &planned
+	}
+	return sum
+}
+
+func (c AnnualCell) neg() AnnualCell {
+	n := AnnualCell{Actual: c.Actual.Neg()}
+	if c.Planned != nil {
+		planned := c.Planned.Neg()
+		n.Planned = &planned
+	}
+	return n
+}
+
+// AnnualLine is a row of the annual report: a category, or the total of a
+// section, month by month.
+type AnnualLine struct {
+	CategoryID   int32 // zero for totals
+	CategoryName string
+	Months       [12]AnnualCell
+	Total        AnnualCell
+	Average      AnnualCell
+}
+
+// AnnualSection groups the categories of a direction, by name.
+type AnnualSection struct {
+	Direction  string
+	Categories []AnnualLine
+	Total      AnnualLine
+}
+
+// AnnualReport is the year as a grid: categories by month, income apart
+// from expenses. Averages divide by AverageMonths, the months of the year
+// elapsed so far (12 once it is over).
+type AnnualReport struct {
+	Year          int
+	IncludeBudget bool
+	AverageMonths int
+	Income        AnnualSection
+	Expense       AnnualSection
+	Net           AnnualLine // income minus expense
+}
+
+// AnnualRow is a category's realized total in a month and its budget, as
+// the repository returns them.
+type AnnualRow struct {
+	CategoryID   int32
+	CategoryName string
+	Direction    string
+	Month        time.Time
+	Total        money.Amount
+	Planned      *money.Amount
+}
+
+// ReinvestmentMonth compares what came back from investments in a month,
+// redemptions and yields, with what went into them. A positive gap is
+// money still to be reinvested.
+type ReinvestmentMonth struct {
+	Month         time.Time
+	Inflow        money.Amount // IN flows of INVESTMENT categories
+	Contributions money.Amount // OUT flows of INVESTMENT categories
+	Gap           money.Amount // Inflow minus Contributions
+	CumulativeGap money.Amount // gap since the first investment flow
+}
+
+// Reinvestment is the reinvestment gap month by month. Only realized flows
+// of categories with the INVESTMENT role count.
+type Reinvestment struct {
+	From          time.Time
+	To            time.Time
+	OpeningGap    money.Amount // cumulative gap before From
+	Inflow        money.Amount
+	Contributions money.Amount
+	Gap           money.Amount
+	ClosingGap    money.Amount // cumulative gap at the end of To
+	Months        []ReinvestmentMonth
+}
+
+// ReinvestmentRow is one month as the repository returns it; the gap
+// already runs over all earlier months.
+type ReinvestmentRow struct {
+	Month         time.Time
+	Inflow        money.Amount
+	Contributions money.Amount
+	CumulativeGap money.Amount
+}
+
+// StatementInvoice is the invoice of a credit card for the month.
+type StatementInvoice struct {
+	CardName string
+	DueDay   *int32
+	Invoice  *payment.Invoice
+}
+
+// MonthlyStatement gathers what a printed month shows: the realized
+// summary and categories, the budget against the actual spending, the card
+// invoices, who still owes or is owed picuinhas, and every cash flow.
+type MonthlyStatement struct {
+	Month      time.Time
+	Summary    *cashflow.MonthlySummary
+	Categories []cashflow.CategorySummary
+	Budget     *budget.BudgetPeriod
+	Invoices   []StatementInvoice // only cards with entries in the month
+	Picuinhas  []picuinha.Person  // only people with an open balance
+	CashFlows  []*cashflow.CashFlow
+}
+
+func monthOf(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, time.UTC)
+}
+
+// monthRange resolves an optional range to whole months, defaulting to the
+// DefaultMonths ending in the current month.
+func monthRange(from, to *time.Time, now time.Time) (time.Time, time.Time, error) {
+	end := monthOf(now)
+	if to != nil {
+		end = monthOf(*to)
+	}
+	start := end.AddDate(0, -(DefaultMonths - 1), 0)
+	if from != nil {
+		start = monthOf(*from)
+	}
+	if start.After(end) {
+		return start, end, ErrInvalidRange
+	}
+	if months := (end.Year()-start.Year())*12 + int(end.Month()-start.Month()) + 1; months > MaxMonths {
+		return start, end, ErrRangeTooLong
+	}
+	return start, end, nil
+}
@@ -0,0 +1,49 @@
+package report
+
+import (
+	"context"
+	"time"
+
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/money"
+)
+
+type Repository interface {
+	// Cumulative returns one row per month from from to to, both month
+	// starts, with balances running since the first flow.
+	Cumulative(ctx context.Context, from, to time.Time, includeInstallments bool) ([]CumulativeRow, error)
+	// CategoryHistory returns one row per month from from to to for the
+	// category.
+	CategoryHistory(ctx context.Context, categoryID int32, from, to time.Time) ([]CategoryHistoryRow, error)
+	// FastestGrowing returns up to limit expense categories with a rising
+	// trend over the range, the steepest first.
+	FastestGrowing(ctx context.Context, from, to time.Time, limit int) ([]CategoryGrowth, error)
+	// ForecastOpeningBalance is the realized balance plus the flows still
+	// planned for month.
+	ForecastOpeningBalance(ctx context.Context, month time.Time) (money.Amount, error)
+	ForecastPlanned(ctx context.Context, from, to time.Time) ([]ForecastFlow, error)
+	// ForecastFixed returns the fixed flows of the latest month up to month
+	// that has any, leaving out recurrences and installments.
+	ForecastFixed(ctx context.Context, month time.Time) ([]ForecastFlow, error)
+	ForecastReceivables(ctx context.Context, from, to time.Time) ([]Receivable, error)
+	VariableSpending(ctx context.Context, from, to time.Time) ([]VariableSpending, error)
+	// AnnualMatrix returns the realized total and budget of each category,
+	// transfers aside, for every month of the year of yearStart that has
+	// either, ordered by direction, category name and month.
+	AnnualMatrix(ctx context.Context, yearStart time.Time) ([]AnnualRow, error)
+	// Reinvestment returns one row per month from from to to, both month
+	// starts, with the gap running since the first investment flow.
+	Reinvestment(ctx context.Context, from, to time.Time) ([]ReinvestmentRow, error)
+}
+
+type Service interface {
+	GetCumulative(ctx context.Context, filter CumulativeFilter) (*Cumulative, error)
+	GetCategoryHistory(ctx context.Context, categoryID int32, from, to *time.Time) (*CategoryHistory, error)
+	GetFastestGrowing(ctx context.Context, filter GrowthFilter) (*Growth, error)
+	GetForecast(ctx context.Context, months int) (*Forecast, error)
+	GetAnnualReport(ctx context.Context, year int, includeBudget bool) (*AnnualReport, error)
+	GetReinvestment(ctx context.Context, from, to *time.Time) (*Reinvestment, error)
+}
+
+type StatementService interface {
+	GetMonthlyStatement(ctx context.Context, month time.Time) (*MonthlyStatement, error)
+}
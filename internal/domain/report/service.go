@@ -0,0 +1,455 @@
+package report
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/domain/category"
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/domain/recurrence"
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/money"
+)
+
+type ReportService struct {
+	repo    Repository
+	catRepo category.Repository
+	recRepo recurrence.Repository
+}
+
+func NewService(repo Repository, catRepo category.Repository, recRepo recurrence.Repository) *ReportService {
+	return &ReportService{repo: repo, catRepo: catRepo, recRepo: recRepo}
+}
+
+// GetCumulative builds the cumulative cash flow: income, expense, net and
+// running balance for each month, for own money and picuinhas apart.
+func (s *ReportService) GetCumulative(ctx context.Context, filter CumulativeFilter) (*Cumulative, error) {
+	from, to, err := monthRange(filter.From, filter.To, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := s.repo.Cumulative(ctx, from, to, filter.IncludeInstallments)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build cumulative cash flow: %w", err)
+	}
+
+	report := &Cumulative{
+		From:                from,
+		To:                  to,
+		IncludeInstallments: filter.IncludeInstallments,
+		Months:              make([]CumulativeMonth, len(rows)),
+	}
+	for i, row := range rows {
+		own := totals(row.OwnIncome, row.OwnExpense)
+		own.Balance = row.OwnBalance
+		picuinha := totals(row.PicuinhaIncome, row.PicuinhaExpense)
+		picuinha.Balance = row.PicuinhaBalance
+		total := totals(row.OwnIncome.Add(row.PicuinhaIncome), row.OwnExpense.Add(row.PicuinhaExpense))
+		total.Balance = row.OwnBalance.Add(row.PicuinhaBalance)
+
+		report.Months[i] = CumulativeMonth{
+			Month:               row.Month,
+			Total:               total,
+			Own:                 own,
+			Picuinha:            picuinha,
+			PlannedInstallments: row.PlannedInstallments,
+		}
+	}
+	if len(report.Months) > 0 {
+		first := report.Months[0].Total
+		report.OpeningBalance = first.Balance.Sub(first.Net)
+	}
+	return report, nil
+}
+
+// GetCategoryHistory builds the monthly history of a category with its
+// budget, moving averages and trend.
+func (s *ReportService) GetCategoryHistory(ctx context.Context, categoryID int32, from, to *time.Time) (*CategoryHistory, error) {
+	start, end, err := monthRange(from, to, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	cat, err := s.catRepo.GetByID(ctx, categoryID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get category: %w", err)
+	}
+	if cat == nil {
+		return nil, category.ErrCategoryNotFound
+	}
+
+	rows, err := s.repo.CategoryHistory(ctx, categoryID, start, end)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build category history: %w", err)
+	}
+
+	history := &CategoryHistory{
+		CategoryID:   cat.ID,
+		CategoryName: cat.Name,
+		Direction:    cat.Direction,
+		From:         start,
+		To:           end,
+		Months:       make([]CategoryMonth, len(rows)),
+	}
+	for i, row := range rows {
+		history.TrendSlope = row.TrendSlope
+		history.Months[i] = CategoryMonth{
+			Month:          row.Month,
+			Total:          row.Total,
+			Planned:        row.Planned,
+			MovingAverage3: row.MovingAverage3,
+			MovingAverage6: row.MovingAverage6,
+			Change:         percentChange(row.Total, row.PreviousTotal),
+		}
+	}
+	return history, nil
+}
+
+// GetFastestGrowing ranks the expense categories whose spending rises the
+// fastest over the range.
+func (s *ReportService) GetFastestGrowing(ctx context.Context, filter GrowthFilter) (*Growth, error) {
+	start, end, err := monthRange(filter.From, filter.To, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	limit := filter.Limit
+	if limit == 0 {
+		limit = DefaultGrowthLimit
+	}
+	if limit < 1 || limit > MaxGrowthLimit {
+		return nil, ErrInvalidLimit
+	}
+
+	categories, err := s.repo.FastestGrowing(ctx, start, end, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to rank categories: %w", err)
+	}
+	for i := range categories {
+		c := &categories[i]
+		if !c.MonthlyAverage.IsZero() {
+			c.GrowthPercent = c.TrendSlope / c.MonthlyAverage.Float64() * 100
+		}
+	}
+	return &Growth{From: start, To: end, Categories: categories}, nil
+}
+
+// GetForecast projects income, expense and balance for the given number of
+// months after the current one.
+func (s *ReportService) GetForecast(ctx context.Context, months int) (*Forecast, error) {
+	if months == 0 {
+		months = DefaultForecastMonths
+	}
+	if months < 1 || months > MaxForecastMonths {
+		return nil, ErrInvalidMonths
+	}
+	return s.forecast(ctx, monthOf(time.Now()), months)
+}
+
+func (s *ReportService) forecast(ctx context.Context, current time.Time, months int) (*Forecast, error) {
+	from := current.AddDate(0, 1, 0)
+	to := current.AddDate(0, months, 0)
+	forecast := &Forecast{
+		From:        from,
+		To:          to,
+		HistoryFrom: current.AddDate(0, -ForecastHistoryMonths, 0),
+		HistoryTo:   current.AddDate(0, -1, 0),
+		Months:      make([]ForecastMonth, months),
+	}
+	for i := range forecast.Months {
+		forecast.Months[i].Month = from.AddDate(0, i, 0)
+	}
+	index := func(date time.Time) int {
+		return (date.Year()-from.Year())*12 + int(date.Month()-from.Month())
+	}
+
+	var err error
+	forecast.OpeningBalance, err = s.repo.ForecastOpeningBalance(ctx, current)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get opening balance: %w", err)
+	}
+
+	// Planned flows, remembering them so fixed flows already copied into a
+	// month are not counted twice.
+	planned, err := s.repo.ForecastPlanned(ctx, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list planned flows: %w", err)
+	}
+	copied := make([]map[string]bool, months)
+	for i := range copied {
+		copied[i] = make(map[string]bool)
+	}
+	for _, f := range planned {
+		i := index(f.Month)
+		src := &forecast.Months[i].Sources
+		switch {
+		case f.Direction == category.DirectionIn:
+			src.PlannedIncome = src.PlannedIncome.Add(f.Amount)
+		case f.IsInstallment:
+			src.Installments = src.Installments.Add(f.Amount)
+		default:
+			src.PlannedExpense = src.PlannedExpense.Add(f.Amount)
+		}
+		copied[i][fixedKey(f)] = true
+	}
+
+	fixed, err := s.repo.ForecastFixed(ctx, current)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list fixed flows: %w", err)
+	}
+	for i := range forecast.Months {
+		src := &forecast.Months[i].Sources
+		for _, f := range fixed {
+			if copied[i][fixedKey(f)] {
+				continue
+			}
+			if f.Direction == category.DirectionIn {
+				src.FixedIncome = src.FixedIncome.Add(f.Amount)
+			} else {
+				src.FixedExpense = src.FixedExpense.Add(f.Amount)
+			}
+		}
+	}
+
+	if err := s.addRecurrences(ctx, forecast, index); err != nil {
+		return nil, err
+	}
+
+	receivables, err := s.repo.ForecastReceivables(ctx, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list picuinha receivables: %w", err)
+	}
+	for _, r := range receivables {
+		src := &forecast.Months[index(r.Month)].Sources
+		src.Receivables = src.Receivables.Add(r.Amount)
+	}
+
+	forecast.Variable, err = s.repo.VariableSpending(ctx, forecast.HistoryFrom, forecast.HistoryTo)
+	if err != nil {
+		return nil, fmt.Errorf("failed to average variable spending: %w", err)
+	}
+	var variable money.Amount
+	var variance float64
+	for _, v := range forecast.Variable {
+		variable = variable.Add(v.Average)
+		variance += v.StdDev * v.StdDev
+	}
+	sigma := math.Sqrt(variance)
+
+	// Months are assumed independent, so the balance spread grows with the
+	// square root of the months elapsed.
+	balance := forecast.OpeningBalance
+	var receivable money.Amount
+	for i := range forecast.Months {
+		m := &forecast.Months[i]
+		src := &m.Sources
+		src.VariableExpense = variable
+
+		m.Income = money.Sum(src.PlannedIncome, src.FixedIncome, src.RecurringIncome, src.Receivables)
+		m.Expense = money.Sum(src.Installments, src.PlannedExpense, src.FixedExpense, src.RecurringExpense, src.VariableExpense)
+		m.Net = m.Income.Sub(m.Expense)
+		balance = balance.Add(m.Net)
+		m.Balance = balance
+		receivable = receivable.Add(src.Receivables)
+
+		spread := money.FromFloat(ForecastZ * sigma)
+		if spread.Cmp(variable) > 0 {
+			spread = variable
+		}
+		m.IncomeBand = Band{Low: m.Income.Sub(src.Receivables), High: m.Income}
+		m.ExpenseBand = Band{Low: m.Expense.Sub(spread), High: m.Expense.Add(spread)}
+
+		drift := money.FromFloat(ForecastZ * sigma * math.Sqrt(float64(i+1)))
+		m.BalanceBand = Band{Low: m.Balance.Sub(drift).Sub(receivable), High: m.Balance.Add(drift)}
+
+		if forecast.Lowest == nil || m.BalanceBand.Low.Cmp(forecast.Lowest.BalanceBand.Low) < 0 {
+			forecast.Lowest = m
+		}
+	}
+	return forecast, nil
+}
+
+// addRecurrences adds the occurrences of active recurrence rules that were
+// not generated yet. Generated ones in future months are planned flows,
+// counted with the others.
+func (s *ReportService) addRecurrences(ctx context.Context, forecast *Forecast, index func(time.Time) int) error {
+	rules, err := s.recRepo.List(ctx, true)
+	if err != nil {
+		return fmt.Errorf("failed to list recurrence rules: %w", err)
+	}
+	last := forecast.To.AddDate(0, 1, -1)
+	for i := range rules {
+		rule := &rules[i]
+		occurrences, err := s.recRepo.ListOccurrences(ctx, rule.ID)
+		if err != nil {
+			return fmt.Errorf("failed to list occurrences: %w", err)
+		}
+		generated := make(map[string]bool, len(occurrences))
+		for _, o := range occurrences {
+			generated[o.Date.Format("2006-01-02")] = true
+		}
+		for _, date := range rule.Dates(forecast.From, last) {
+			if generated[date.Format("2006-01-02")] {
+				continue
+			}
+			src := &forecast.Months[index(date)].Sources
+			if rule.Direction == category.DirectionIn {
+				src.RecurringIncome = src.RecurringIncome.Add(rule.Amount)
+			} else {
+				src.RecurringExpense = src.RecurringExpense.Add(rule.Amount)
+			}
+		}
+	}
+	return nil
+}
+
+// GetAnnualReport builds the grid of the year: realized amounts of each
+// category by month, with totals and averages, and the budgets alongside
+// when includeBudget is set.
+func (s *ReportService) GetAnnualReport(ctx context.Context, year int, includeBudget bool) (*AnnualReport, error) {
+	if year < 1900 || year > 9999 {
+		return nil, ErrInvalidYear
+	}
+	start := time.Date(year, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+	rows, err := s.repo.AnnualMatrix(ctx, start)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build annual report: %w", err)
+	}
+
+	report := &AnnualReport{
+		Year:          year,
+		IncludeBudget: includeBudget,
+		AverageMonths: 12,
+		Income:        AnnualSection{Direction: category.DirectionIn},
+		Expense:       AnnualSection{Direction: category.DirectionOut},
+	}
+	if now := time.Now(); now.Year() == year {
+		report.AverageMonths = int(now.Month())
+	}
+
+	var line *AnnualLine
+	var direction string
+	flush := func() {
+		// Without budgets, a category that only had one is not in the year.
+		if line == nil || (!includeBudget && !hasActuals(line)) {
+			return
+		}
+		section := &report.Expense
+		if direction == category.DirectionIn {
+			section = &report.Income
+		}
+		section.Categories = append(section.Categories, *line)
+	}
+	for _, row := range rows {
+		if line == nil || line.CategoryID != row.CategoryID {
+			flush()
+			line = &AnnualLine{CategoryID: row.CategoryID, CategoryName: row.CategoryName}
+			direction = row.Direction
+		}
+		cell := AnnualCell{Actual: row.Total}
+		if includeBudget {
+			cell.Planned = row.Planned
+		}
+		line.Months[row.Month.Month()-1] = cell
+	}
+	flush()
+
+	for _, section := range []*AnnualSection{&report.Income, &report.Expense} {
+		for i := range section.Categories {
+			report.finish(&section.Categories[i])
+			for m, cell := range section.Categories[i].Months {
+				section.Total.Months[m] = section.Total.Months[m].add(cell)
+			}
+		}
+		report.finish(&section.Total)
+	}
+	for m := range report.Net.Months {
+		report.Net.Months[m] = report.Income.Total.Months[m].add(report.Expense.Total.Months[m].neg())
+	}
+	report.finish(&report.Net)
+	return report, nil
+}
+
+// finish sums a line's months into its total and average.
+func (r *AnnualReport) finish(line *AnnualLine) {
+	line.Total = AnnualCell{}
+	for _, cell := range line.Months {
+		line.Total = line.Total.add(cell)
+	}
+	rate := 1 / float64(r.AverageMonths)
+	line.Average = AnnualCell{Actual: line.Total.Actual.MulRate(rate)}
+	if line.Total.Planned != nil {
+		planned := line.Total.Planned.MulRate(rate)
+		line.Average.Planned = &planned
+	}
+}
+
+func hasActuals(line *AnnualLine) bool {
+	for _, cell := range line.Months {
+		if !cell.Actual.IsZero() {
+			return true
+		}
+	}
+	return false
+}
+
+// GetReinvestment compares, month by month, the redemptions and yields of
+// investment categories with the contributions to them, and tracks the gap
+// left to reinvest.
+func (s *ReportService) GetReinvestment(ctx context.Context, from, to *time.Time) (*Reinvestment, error) {
+	start, end, err := monthRange(from, to, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := s.repo.Reinvestment(ctx, start, end)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build reinvestment: %w", err)
+	}
+
+	report := &Reinvestment{
+		From:   start,
+		To:     end,
+		Months: make([]ReinvestmentMonth, len(rows)),
+	}
+	for i, row := range rows {
+		gap := row.Inflow.Sub(row.Contributions)
+		report.Months[i] = ReinvestmentMonth{
+			Month:         row.Month,
+			Inflow:        row.Inflow,
+			Contributions: row.Contributions,
+			Gap:           gap,
+			CumulativeGap: row.CumulativeGap,
+		}
+		report.Inflow = report.Inflow.Add(row.Inflow)
+		report.Contributions = report.Contributions.Add(row.Contributions)
+	}
+	report.Gap = report.Inflow.Sub(report.Contributions)
+	if len(report.Months) > 0 {
+		first := report.Months[0]
+		report.OpeningGap = first.CumulativeGap.Sub(first.Gap)
+		report.ClosingGap = report.Months[len(report.Months)-1].CumulativeGap
+	}
+	return report, nil
+}
+
+// fixedKey matches a fixed flow with its copies, the way the fixed expenses
+// copy does: same category, direction and title.
+func fixedKey(f ForecastFlow) string {
+	return fmt.Sprintf("%d|%s|%s", f.CategoryID, f.Direction, strings.ToLower(strings.TrimSpace(f.Title)))
+}
+
+// percentChange is how much current moved over previous, in percent; nil
+// when previous is zero.
+func percentChange(current, previous money.Amount) *float64 {
+	if previous.IsZero() {
+		return nil
+	}
+	pct := current.Sub(previous).Float64() / previous.Abs().Float64() * 100
+	return &pct
+}
+
+func totals(income, expense money.Amount) Totals {
+	return Totals{Income: income, Expense: expense, Net: income.Sub(expense)}
+}
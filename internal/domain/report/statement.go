@@ -0,0 +1,78 @@
+package report
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/domain/budget"
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/domain/cashflow"
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/domain/payment"
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/domain/picuinha"
+)
+
+// MonthlyStatementService builds the monthly statement from the services
+// of each domain, so it shows the same numbers as their own endpoints.
+type MonthlyStatementService struct {
+	cfService  cashflow.Service
+	budService budget.Service
+	payService payment.Service
+	picService picuinha.Service
+}
+
+func NewStatementService(cfService cashflow.Service, budService budget.Service, payService payment.Service, picService picuinha.Service) *MonthlyStatementService {
+	return &MonthlyStatementService{cfService: cfService, budService: budService, payService: payService, picService: picService}
+}
+
+func (s *MonthlyStatementService) GetMonthlyStatement(ctx context.Context, month time.Time) (*MonthlyStatement, error) {
+	month = monthOf(month)
+	statement := &MonthlyStatement{Month: month}
+
+	var err error
+	statement.Summary, err = s.cfService.GetMonthlySummary(ctx, month, false)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get monthly summary: %w", err)
+	}
+	statement.Categories, err = s.cfService.GetCategorySummary(ctx, month, false)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get category summary: %w", err)
+	}
+	statement.Budget, err = s.budService.GetBudgetSummary(ctx, month)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get budget summary: %w", err)
+	}
+
+	methods, err := s.payService.ListPaymentMethods(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list payment methods: %w", err)
+	}
+	for _, m := range methods {
+		if m.Kind != payment.KindCreditCard {
+			continue
+		}
+		invoice, err := s.payService.GetInvoice(ctx, m.ID, month)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get invoice of %s: %w", m.Name, err)
+		}
+		if len(invoice.Entries) == 0 {
+			continue
+		}
+		statement.Invoices = append(statement.Invoices, StatementInvoice{CardName: m.Name, DueDay: m.DueDay, Invoice: invoice})
+	}
+
+	people, err := s.picService.ListPersons(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list people: %w", err)
+	}
+	for _, p := range people {
+		if !p.Balance.IsZero() {
+			statement.Picuinhas = append(statement.Picuinhas, p)
+		}
+	}
+
+	statement.CashFlows, err = s.cfService.ListCashFlows(ctx, month)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list cash flows: %w", err)
+	}
+	return statement, nil
+}
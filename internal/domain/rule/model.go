@@ -0,0 +1,267 @@
+package rule
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/domain/cashflow"
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/money"
+)
+
+var (
+	ErrRuleNotFound       = errors.New("rule not found")
+	ErrNameRequired       = errors.New("name is required")
+	ErrNameTooLong        = errors.New("name must have at most 100 characters")
+	ErrNoConditions       = errors.New("rule needs at least one condition")
+	ErrNoActions          = errors.New("rule needs at least one action")
+	ErrInvalidPattern     = errors.New("invalid title_pattern")
+	ErrInvalidDirection   = errors.New("direction must be IN or OUT")
+	ErrInvalidAmount      = errors.New("min_amount and max_amount must be greater than zero")
+	ErrInvalidAmountRange = errors.New("min_amount must not be greater than max_amount")
+	ErrCategoryDirection  = errors.New("set_category_id direction does not match rule direction")
+	ErrTransferCategory   = errors.New("transfer categories cannot be set by rules")
+	ErrInvalidRange       = errors.New("from must not be after to")
+)
+
+const MaxNameLength = 100
+
+// Rule sets fields of the flows that meet all of its conditions. Rules run
+// from the lowest Priority up, ties in creation order.
+type Rule struct {
+	ID       int32
+	Name     string
+	Priority int32
+	IsActive bool
+
+	// Conditions. TitlePattern is a regular expression matched anywhere in
+	// the title, ignoring case; amounts are inclusive.
+	TitlePattern    string
+	Direction       string
+	MinAmount       *money.Amount
+	MaxAmount       *money.Amount
+	PaymentMethodID *int32
+	PayeeID         *int32
+
+	// Actions.
+	SetCategoryID   *int32
+	SetCategoryName string // loaded by Get and List
+	SetIsFixed      *bool
+	SetPayeeID      *int32
+	AddTags         []string
+	AddTagIDs       []int32 // resolved from AddTags by the service
+}
+
+func (r *Rule) Validate() error {
+	r.Name = strings.TrimSpace(r.Name)
+	if r.Name == "" {
+		return ErrNameRequired
+	}
+	if utf8.RuneCountInString(r.Name) > MaxNameLength {
+		return ErrNameTooLong
+	}
+	if _, err := r.compile(); err != nil {
+		return err
+	}
+	switch r.Direction {
+	case "", "IN", "OUT":
+	default:
+		return ErrInvalidDirection
+	}
+	if (r.MinAmount != nil && !r.MinAmount.IsPositive()) || (r.MaxAmount != nil && !r.MaxAmount.IsPositive()) {
+		return ErrInvalidAmount
+	}
+	if r.MinAmount != nil && r.MaxAmount != nil && r.MinAmount.Cmp(*r.MaxAmount) > 0 {
+		return ErrInvalidAmountRange
+	}
+	if r.TitlePattern == "" && r.Direction == "" && r.MinAmount == nil && r.MaxAmount == nil &&
+		r.PaymentMethodID == nil && r.PayeeID == nil {
+		return ErrNoConditions
+	}
+	if r.SetCategoryID == nil && r.SetIsFixed == nil && r.SetPayeeID == nil && len(r.AddTags) == 0 {
+		return ErrNoActions
+	}
+	return nil
+}
+
+func (r *Rule) compile() (*regexp.Regexp, error) {
+	if r.TitlePattern == "" {
+		return nil, nil
+	}
+	re, err := regexp.Compile("(?i)" + r.TitlePattern)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidPattern, err)
+	}
+	return re, nil
+}
+
+func (r *Rule) matches(f cashflow.Facts, title *regexp.Regexp) bool {
+	switch {
+	case title != nil && !title.MatchString(f.Title),
+		r.Direction != "" && r.Direction != f.Direction,
+		r.MinAmount != nil && f.Amount.Cmp(*r.MinAmount) < 0,
+		r.MaxAmount != nil && f.Amount.Cmp(*r.MaxAmount) > 0,
+		r.PaymentMethodID != nil && (f.PaymentMethodID == nil || *f.PaymentMethodID != *r.PaymentMethodID),
+		r.PayeeID != nil && (f.PayeeID == nil || *f.PayeeID != *r.PayeeID):
+		return false
+	}
+	return true
+}
+
+// Engine evaluates a set of rules, compiled once.
+type Engine struct {
+	rules  []Rule
+	titles []*regexp.Regexp
+}
+
+// NewEngine expects rules in priority order.
+func NewEngine(rules []Rule) (*Engine, error) {
+	e := &Engine{rules: rules, titles: make([]*regexp.Regexp, len(rules))}
+	for i := range rules {
+		re, err := rules[i].compile()
+		if err != nil {
+			return nil, fmt.Errorf("rule %d: %w", rules[i].ID, err)
+		}
+		e.titles[i] = re
+	}
+	return e, nil
+}
+
+func (e *Engine) Evaluate(f cashflow.Facts) *cashflow.Actions {
+	actions := &cashflow.Actions{}
+	seenTags := make(map[int32]bool)
+	for i, r := range e.rules {
+		if !r.matches(f, e.titles[i]) {
+			continue
+		}
+		actions.RuleIDs = append(actions.RuleIDs, r.ID)
+		if actions.CategoryID == nil && r.SetCategoryID != nil {
+			actions.CategoryID = r.SetCategoryID
+			actions.CategoryName = r.SetCategoryName
+		}
+		if actions.IsFixed == nil && r.SetIsFixed != nil {
+			actions.IsFixed = r.SetIsFixed
+		}
+		if actions.PayeeID == nil && r.SetPayeeID != nil {
+			actions.PayeeID = r.SetPayeeID
+		}
+		for _, id := range r.AddTagIDs {
+			if !seenTags[id] {
+				seenTags[id] = true
+				actions.TagIDs = append(actions.TagIDs, id)
+			}
+		}
+	}
+	return actions
+}
+
+// Scope limits the existing flows a rule is tested or applied against.
+type Scope struct {
+	From *time.Time
+	To   *time.Time
+}
+
+// Flow is an existing cash flow as rules see it. Reconciled, cancelled and
+// transfer flows are never listed.
+type Flow struct {
+	ID              int32
+	Date            time.Time
+	Title           string
+	Direction       string
+	Amount          money.Amount
+	CategoryID      int32
+	IsFixed         bool
+	PayeeID         *int32
+	PaymentMethodID *int32
+	TagIDs          []int32
+}
+
+func (f *Flow) facts() cashflow.Facts {
+	return cashflow.Facts{
+		Title:           f.Title,
+		Direction:       f.Direction,
+		Amount:          f.Amount,
+		PaymentMethodID: f.PaymentMethodID,
+		PayeeID:         f.PayeeID,
+	}
+}
+
+// Values are the fields of a flow a rule can change.
+type Values struct {
+	CategoryID int32
+	IsFixed    bool
+	PayeeID    *int32
+}
+
+// Change is what a rule does, or would do, to an existing flow.
+type Change struct {
+	CashFlowID int32
+	Date       time.Time
+	Title      string
+	Direction  string
+	Amount     money.Amount
+	Before     Values
+	After      Values
+	AddTags    []string
+	addTagIDs  []int32
+}
+
+// change returns what r does to f: nil when f does not meet the conditions
+// or already has everything r sets. Tags are matched by position in AddTags
+// and AddTagIDs; a zero ID is a tag that does not exist yet.
+func (r *Rule) change(f Flow, title *regexp.Regexp) *Change {
+	if !r.matches(f.facts(), title) {
+		return nil
+	}
+	c := &Change{
+		CashFlowID: f.ID,
+		Date:       f.Date,
+		Title:      f.Title,
+		Direction:  f.Direction,
+		Amount:     f.Amount,
+		Before:     Values{CategoryID: f.CategoryID, IsFixed: f.IsFixed, PayeeID: f.PayeeID},
+	}
+	c.After = c.Before
+	if r.SetCategoryID != nil {
+		c.After.CategoryID = *r.SetCategoryID
+	}
+	if r.SetIsFixed != nil {
+		c.After.IsFixed = *r.SetIsFixed
+	}
+	if r.SetPayeeID != nil {
+		c.After.PayeeID = r.SetPayeeID
+	}
+
+	has := make(map[int32]bool, len(f.TagIDs))
+	for _, id := range f.TagIDs {
+		has[id] = true
+	}
+	for i, name := range r.AddTags {
+		id := r.AddTagIDs[i]
+		if id == 0 || !has[id] {
+			c.AddTags = append(c.AddTags, name)
+			c.addTagIDs = append(c.addTagIDs, id)
+		}
+	}
+
+	if c.Before.CategoryID == c.After.CategoryID && c.Before.IsFixed == c.After.IsFixed &&
+		samePayee(c.Before.PayeeID, c.After.PayeeID) && len(c.AddTags) == 0 {
+		return nil
+	}
+	return c
+}
+
+func samePayee(a, b *int32) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
+type ApplyResult struct {
+	DryRun  bool
+	Changes []Change
+}
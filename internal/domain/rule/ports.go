@@ -0,0 +1,34 @@
+package rule
+
+import (
+	"context"
+
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/domain/cashflow"
+)
+
+type Repository interface {
+	WithinTx(ctx context.Context, fn func(ctx context.Context) error) error
+	// Create and Update also store AddTagIDs.
+	Create(ctx context.Context, r *Rule) (*Rule, error)
+	// List returns the rules in priority order.
+	List(ctx context.Context, activeOnly bool) ([]Rule, error)
+	GetByID(ctx context.Context, id int32) (*Rule, error)
+	Update(ctx context.Context, r *Rule) (*Rule, error)
+	Delete(ctx context.Context, id int32) error
+	// ListFlows returns the flows rules may change, oldest first.
+	ListFlows(ctx context.Context, direction string, scope Scope) ([]Flow, error)
+	AddCashFlowTags(ctx context.Context, cashFlowID int32, tagIDs []int32) error
+}
+
+type Service interface {
+	CreateRule(ctx context.Context, r Rule) (*Rule, error)
+	ListRules(ctx context.Context) ([]Rule, error)
+	GetRule(ctx context.Context, id int32) (*Rule, error)
+	UpdateRule(ctx context.Context, r Rule) (*Rule, error)
+	DeleteRule(ctx context.Context, id int32) error
+	// TestRule shows what an unsaved rule would change in existing flows.
+	TestRule(ctx context.Context, r Rule, scope Scope) (*ApplyResult, error)
+	// ApplyRule runs a saved rule over existing flows.
+	ApplyRule(ctx context.Context, id int32, scope Scope) (*ApplyResult, error)
+	Evaluate(ctx context.Context, facts cashflow.Facts) (*cashflow.Actions, error)
+}
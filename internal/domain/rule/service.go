@@ -0,0 +1,302 @@
+package rule
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/domain/cashflow"
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/domain/category"
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/domain/payment"
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/domain/tag"
+)
+
+type RuleService struct {
+	repo      Repository
+	cashflows cashflow.Service
+	catRepo   category.Repository
+	payRepo   payment.Repository
+	tagRepo   tag.Repository
+}
+
+func NewService(repo Repository, cashflows cashflow.Service, catRepo category.Repository, payRepo payment.Repository, tagRepo tag.Repository) *RuleService {
+	return &RuleService{
+		repo:      repo,
+		cashflows: cashflows,
+		catRepo:   catRepo,
+		payRepo:   payRepo,
+		tagRepo:   tagRepo,
+	}
+}
+
+// CreateRule stores a rule. Tags it adds that do not exist yet are created.
+func (s *RuleService) CreateRule(ctx context.Context, r Rule) (*Rule, error) {
+	if err := s.validate(ctx, &r); err != nil {
+		return nil, err
+	}
+
+	var created *Rule
+	err := s.repo.WithinTx(ctx, func(ctx context.Context) error {
+		if err := s.resolveTags(ctx, &r, true); err != nil {
+			return err
+		}
+		var err error
+		created, err = s.repo.Create(ctx, &r)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return created, nil
+}
+
+func (s *RuleService) ListRules(ctx context.Context) ([]Rule, error) {
+	return s.repo.List(ctx, false)
+}
+
+func (s *RuleService) GetRule(ctx context.Context, id int32) (*Rule, error) {
+	r, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if r == nil {
+		return nil, ErrRuleNotFound
+	}
+	return r, nil
+}
+
+// UpdateRule replaces a rule. Flows it already changed are left as they are.
+func (s *RuleService) UpdateRule(ctx context.Context, r Rule) (*Rule, error) {
+	if err := s.validate(ctx, &r); err != nil {
+		return nil, err
+	}
+
+	var updated *Rule
+	err := s.repo.WithinTx(ctx, func(ctx context.Context) error {
+		if _, err := s.GetRule(ctx, r.ID); err != nil {
+			return err
+		}
+		if err := s.resolveTags(ctx, &r, true); err != nil {
+			return err
+		}
+		var err error
+		updated, err = s.repo.Update(ctx, &r)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return updated, nil
+}
+
+func (s *RuleService) DeleteRule(ctx context.Context, id int32) error {
+	if _, err := s.GetRule(ctx, id); err != nil {
+		return err
+	}
+	return s.repo.Delete(ctx, id)
+}
+
+// TestRule lists the existing flows an unsaved rule would change and how,
+// without changing anything or creating tags.
+func (s *RuleService) TestRule(ctx context.Context, r Rule, scope Scope) (*ApplyResult, error) {
+	if scope.From != nil && scope.To != nil && scope.From.After(*scope.To) {
+		return nil, ErrInvalidRange
+	}
+	if err := s.validate(ctx, &r); err != nil {
+		return nil, err
+	}
+	if err := s.resolveTags(ctx, &r, false); err != nil {
+		return nil, err
+	}
+
+	changes, err := s.changes(ctx, &r, scope)
+	if err != nil {
+		return nil, err
+	}
+	return &ApplyResult{DryRun: true, Changes: changes}, nil
+}
+
+// ApplyRule runs a saved rule, active or not, over the existing flows in
+// scope. Unlike on new flows, the rule overrides the category, is_fixed and
+// payee the flows already have. Category and is_fixed changes go through the
+// cash flow update, so each one leaves a revision. All flows change or none
+// do.
+func (s *RuleService) ApplyRule(ctx context.Context, id int32, scope Scope) (*ApplyResult, error) {
+	if scope.From != nil && scope.To != nil && scope.From.After(*scope.To) {
+		return nil, ErrInvalidRange
+	}
+
+	result := &ApplyResult{}
+	err := s.repo.WithinTx(ctx, func(ctx context.Context) error {
+		r, err := s.GetRule(ctx, id)
+		if err != nil {
+			return err
+		}
+		result.Changes, err = s.changes(ctx, r, scope)
+		if err != nil {
+			return err
+		}
+
+		for _, c := range result.Changes {
+			if err := s.apply(ctx, c); err != nil {
+				return fmt.Errorf("cash flow %d: %w", c.CashFlowID, err)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// Evaluate runs the active rules against the facts of a new flow. It is the
+// cashflow.RuleEvaluator used by cash flow creation.
+func (s *RuleService) Evaluate(ctx context.Context, facts cashflow.Facts) (*cashflow.Actions, error) {
+	prepared, err := s.Prepare(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return prepared.Evaluate(ctx, facts)
+}
+
+// Prepare compiles the active rules into an engine that evaluates a batch
+// of flows without loading them again.
+func (s *RuleService) Prepare(ctx context.Context) (cashflow.RuleEvaluator, error) {
+	rules, err := s.repo.List(ctx, true)
+	if err != nil {
+		return nil, err
+	}
+	engine, err := NewEngine(rules)
+	if err != nil {
+		return nil, err
+	}
+	return preparedEngine{engine}, nil
+}
+
+// preparedEngine is the cashflow.RuleEvaluator over an engine already built.
+type preparedEngine struct {
+	engine *Engine
+}
+
+func (p preparedEngine) Evaluate(_ context.Context, facts cashflow.Facts) (*cashflow.Actions, error) {
+	return p.engine.Evaluate(facts), nil
+}
+
+func (p preparedEngine) Prepare(context.Context) (cashflow.RuleEvaluator, error) {
+	return p, nil
+}
+
+func (s *RuleService) changes(ctx context.Context, r *Rule, scope Scope) ([]Change, error) {
+	title, err := r.compile()
+	if err != nil {
+		return nil, err
+	}
+	flows, err := s.repo.ListFlows(ctx, r.Direction, scope)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list cash flows: %w", err)
+	}
+
+	changes := make([]Change, 0)
+	for _, f := range flows {
+		if c := r.change(f, title); c != nil {
+			changes = append(changes, *c)
+		}
+	}
+	return changes, nil
+}
+
+func (s *RuleService) apply(ctx context.Context, c Change) error {
+	if c.Before.CategoryID != c.After.CategoryID || c.Before.IsFixed != c.After.IsFixed {
+		if _, err := s.cashflows.UpdateCashFlow(ctx, c.CashFlowID, c.Date, c.After.CategoryID, c.Direction, c.Title, c.Amount, c.After.IsFixed, ""); err != nil {
+			return err
+		}
+	}
+	if !samePayee(c.Before.PayeeID, c.After.PayeeID) {
+		if _, err := s.cashflows.SetPayee(ctx, c.CashFlowID, c.After.PayeeID); err != nil {
+			return err
+		}
+	}
+	if len(c.addTagIDs) > 0 {
+		return s.repo.AddCashFlowTags(ctx, c.CashFlowID, c.addTagIDs)
+	}
+	return nil
+}
+
+func (s *RuleService) validate(ctx context.Context, r *Rule) error {
+	if err := r.Validate(); err != nil {
+		return err
+	}
+
+	if r.SetCategoryID != nil {
+		cat, err := s.catRepo.GetByID(ctx, *r.SetCategoryID)
+		if err != nil {
+			return err
+		}
+		if cat == nil {
+			return category.ErrCategoryNotFound
+		}
+		if cat.Role == category.RoleTransfer {
+			return ErrTransferCategory
+		}
+		// A rule setting a category only matches flows it fits.
+		if r.Direction == "" {
+			r.Direction = cat.Direction
+		} else if r.Direction != cat.Direction {
+			return ErrCategoryDirection
+		}
+		r.SetCategoryName = cat.Name
+	}
+	if r.PaymentMethodID != nil {
+		pm, err := s.payRepo.GetByID(ctx, *r.PaymentMethodID)
+		if err != nil {
+			return err
+		}
+		if pm == nil {
+			return payment.ErrPaymentMethodNotFound
+		}
+	}
+	for _, id := range []*int32{r.PayeeID, r.SetPayeeID} {
+		if id == nil {
+			continue
+		}
+		if _, err := s.cashflows.ResolvePayee(ctx, id, ""); err != nil {
+			return err
+		}
+	}
+
+	names := make([]string, 0, len(r.AddTags))
+	seen := make(map[string]bool, len(r.AddTags))
+	for _, name := range r.AddTags {
+		name, err := tag.Normalize(name)
+		if err != nil {
+			return err
+		}
+		if !seen[name] {
+			seen[name] = true
+			names = append(names, name)
+		}
+	}
+	r.AddTags = names
+	return nil
+}
+
+// resolveTags fills AddTagIDs from AddTags, creating missing tags when
+// create is set and leaving their IDs zero otherwise.
+func (s *RuleService) resolveTags(ctx context.Context, r *Rule, create bool) error {
+	r.AddTagIDs = make([]int32, len(r.AddTags))
+	for i, name := range r.AddTags {
+		t, err := s.tagRepo.GetByName(ctx, name)
+		if err != nil {
+			return err
+		}
+		if t == nil && create {
+			if t, err = s.tagRepo.Create(ctx, name); err != nil {
+				return fmt.Errorf("failed to create tag %q: %w", name, err)
+			}
+		}
+		if t != nil {
+			r.AddTagIDs[i] = t.ID
+		}
+	}
+	return nil
+}
@@ -0,0 +1,69 @@
+package tag
+
+import (
+	"errors"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/money"
+)
+
+var (
+	ErrTagNotFound   = errors.New("tag not found")
+	ErrEmptyName     = errors.New("tag name cannot be empty")
+	ErrNameTooLong   = errors.New("tag name must have at most 50 characters")
+	ErrDuplicateName = errors.New("a tag with this name already exists")
+)
+
+const MaxNameLength = 50
+
+type Tag struct {
+	ID            int32
+	Name          string
+	CashFlowCount int64 // filled by List
+}
+
+// Normalize turns free text into a tag name: trimmed, lower case and with
+// runs of whitespace replaced by a hyphen ("Reforma Cozinha" -> "reforma-cozinha").
+func Normalize(name string) (string, error) {
+	name = strings.Join(strings.Fields(strings.ToLower(name)), "-")
+	if name == "" {
+		return "", ErrEmptyName
+	}
+	if utf8.RuneCountInString(name) > MaxNameLength {
+		return "", ErrNameTooLong
+	}
+	return name, nil
+}
+
+// Report sums the flows carrying a tag. Split flows count per split line.
+type Report struct {
+	Tag          Tag
+	TotalIncome  money.Amount
+	TotalExpense money.Amount
+	ByCategory   []CategoryTotal
+	ByMonth      []MonthTotal
+	Breakdown    []ReportRow // month x category
+}
+
+type CategoryTotal struct {
+	CategoryID   int32
+	CategoryName string
+	Direction    string
+	TotalAmount  money.Amount
+}
+
+type MonthTotal struct {
+	Month        time.Time
+	TotalIncome  money.Amount
+	TotalExpense money.Amount
+}
+
+type ReportRow struct {
+	Month        time.Time
+	CategoryID   int32
+	CategoryName string
+	Direction    string
+	TotalAmount  money.Amount
+}
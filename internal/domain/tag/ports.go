@@ -0,0 +1,26 @@
+package tag
+
+import "context"
+
+type Repository interface {
+	WithinTx(ctx context.Context, fn func(ctx context.Context) error) error
+	Create(ctx context.Context, name string) (*Tag, error)
+	List(ctx context.Context) ([]Tag, error)
+	GetByID(ctx context.Context, id int32) (*Tag, error)
+	GetByName(ctx context.Context, name string) (*Tag, error)
+	Update(ctx context.Context, id int32, name string) (*Tag, error)
+	Delete(ctx context.Context, id int32) error
+	ListByCashFlow(ctx context.Context, cashFlowID int32) ([]Tag, error)
+	SetCashFlowTags(ctx context.Context, cashFlowID int32, tagIDs []int32) error
+	Report(ctx context.Context, tagID int32) ([]ReportRow, error)
+}
+
+type Service interface {
+	CreateTag(ctx context.Context, name string) (*Tag, error)
+	ListTags(ctx context.Context) ([]Tag, error)
+	UpdateTag(ctx context.Context, id int32, name string) (*Tag, error)
+	DeleteTag(ctx context.Context, id int32) error
+	ListCashFlowTags(ctx context.Context, cashFlowID int32) ([]Tag, error)
+	SetCashFlowTags(ctx context.Context, cashFlowID int32, names []string) ([]Tag, error)
+	GetReport(ctx context.Context, name string) (*Report, error)
+}
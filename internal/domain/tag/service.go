@@ -0,0 +1,203 @@
+package tag
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/domain/cashflow"
+)
+
+type TagService struct {
+	repo   Repository
+	cfRepo cashflow.Repository
+}
+
+func NewService(repo Repository, cfRepo cashflow.Repository) *TagService {
+	return &TagService{
+		repo:   repo,
+		cfRepo: cfRepo,
+	}
+}
+
+func (s *TagService) CreateTag(ctx context.Context, name string) (*Tag, error) {
+	name, err := Normalize(name)
+	if err != nil {
+		return nil, err
+	}
+	existing, err := s.repo.GetByName(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	if existing != nil {
+		return nil, ErrDuplicateName
+	}
+	return s.repo.Create(ctx, name)
+}
+
+func (s *TagService) ListTags(ctx context.Context) ([]Tag, error) {
+	return s.repo.List(ctx)
+}
+
+// UpdateTag renames a tag; the flows carrying it keep the link.
+func (s *TagService) UpdateTag(ctx context.Context, id int32, name string) (*Tag, error) {
+	name, err := Normalize(name)
+	if err != nil {
+		return nil, err
+	}
+	existing, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if existing == nil {
+		return nil, ErrTagNotFound
+	}
+	other, err := s.repo.GetByName(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	if other != nil && other.ID != id {
+		return nil, ErrDuplicateName
+	}
+	return s.repo.Update(ctx, id, name)
+}
+
+// DeleteTag removes the tag from every flow. The flows themselves stay.
+func (s *TagService) DeleteTag(ctx context.Context, id int32) error {
+	existing, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	if existing == nil {
+		return ErrTagNotFound
+	}
+	return s.repo.Delete(ctx, id)
+}
+
+func (s *TagService) ListCashFlowTags(ctx context.Context, cashFlowID int32) ([]Tag, error) {
+	if err := s.ensureCashFlow(ctx, cashFlowID); err != nil {
+		return nil, err
+	}
+	return s.repo.ListByCashFlow(ctx, cashFlowID)
+}
+
+// SetCashFlowTags replaces the tags of a cash flow. Tags are free-form:
+// names that do not exist yet are created.
+func (s *TagService) SetCashFlowTags(ctx context.Context, cashFlowID int32, names []string) ([]Tag, error) {
+	normalized := make([]string, 0, len(names))
+	seen := make(map[string]bool, len(names))
+	for _, name := range names {
+		name, err := Normalize(name)
+		if err != nil {
+			return nil, err
+		}
+		if !seen[name] {
+			seen[name] = true
+			normalized = append(normalized, name)
+		}
+	}
+
+	var tags []Tag
+	err := s.repo.WithinTx(ctx, func(ctx context.Context) error {
+		if err := s.ensureCashFlow(ctx, cashFlowID); err != nil {
+			return err
+		}
+
+		ids := make([]int32, 0, len(normalized))
+		for _, name := range normalized {
+			t, err := s.repo.GetByName(ctx, name)
+			if err != nil {
+				return err
+			}
+			if t == nil {
+				if t, err = s.repo.Create(ctx, name); err != nil {
+					return fmt.Errorf("failed to create tag %q: %w", name, err)
+				}
+			}
+			ids = append(ids, t.ID)
+		}
+
+		if err := s.repo.SetCashFlowTags(ctx, cashFlowID, ids); err != nil {
+			return err
+		}
+		var err error
+		tags, err = s.repo.ListByCashFlow(ctx, cashFlowID)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return tags, nil
+}
+
+// GetReport totals the flows of a tag by category and by month, so a trip
+// or project can be costed end to end.
+func (s *TagService) GetReport(ctx context.Context, name string) (*Report, error) {
+	name, err := Normalize(name)
+	if err != nil {
+		return nil, err
+	}
+	t, err := s.repo.GetByName(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	if t == nil {
+		return nil, ErrTagNotFound
+	}
+
+	rows, err := s.repo.Report(ctx, t.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build tag report: %w", err)
+	}
+
+	report := &Report{Tag: *t, Breakdown: rows}
+	categories := make(map[int32]*CategoryTotal)
+	months := make(map[string]*MonthTotal)
+	for _, row := range rows {
+		cat, ok := categories[row.CategoryID]
+		if !ok {
+			cat = &CategoryTotal{CategoryID: row.CategoryID, CategoryName: row.CategoryName, Direction: row.Direction}
+			categories[row.CategoryID] = cat
+		}
+		cat.TotalAmount = cat.TotalAmount.Add(row.TotalAmount)
+
+		key := row.Month.Format("2006-01")
+		month, ok := months[key]
+		if !ok {
+			month = &MonthTotal{Month: row.Month}
+			months[key] = month
+		}
+		if row.Direction == "IN" {
+			month.TotalIncome = month.TotalIncome.Add(row.TotalAmount)
+			report.TotalIncome = report.TotalIncome.Add(row.TotalAmount)
+		} else {
+			month.TotalExpense = month.TotalExpense.Add(row.TotalAmount)
+			report.TotalExpense = report.TotalExpense.Add(row.TotalAmount)
+		}
+	}
+
+	for _, cat := range categories {
+		report.ByCategory = append(report.ByCategory, *cat)
+	}
+	sort.Slice(report.ByCategory, func(i, j int) bool {
+		return report.ByCategory[i].TotalAmount.Cmp(report.ByCategory[j].TotalAmount) > 0
+	})
+	for _, month := range months {
+		report.ByMonth = append(report.ByMonth, *month)
+	}
+	sort.Slice(report.ByMonth, func(i, j int) bool {
+		return report.ByMonth[i].Month.Before(report.ByMonth[j].Month)
+	})
+	return report, nil
+}
+
+func (s *TagService) ensureCashFlow(ctx context.Context, id int32) error {
+	flow, err := s.cfRepo.GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	if flow == nil {
+		return cashflow.ErrCashFlowNotFound
+	}
+	return nil
+}
@@ -0,0 +1,53 @@
+package transfer
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/money"
+)
+
+var (
+	ErrTransferNotFound = errors.New("transfer not found")
+	ErrSameAccount      = errors.New("from and to accounts must be different")
+	ErrInvalidAmount    = errors.New("amount must be greater than zero")
+	ErrInvalidDate      = errors.New("date is required")
+	ErrNoCategory       = errors.New("no active transfer category for this direction")
+)
+
+const DefaultTitle = "Transferência"
+
+// Transfer moves money between two own accounts. It is stored as an OUT
+// flow on the source account and an IN flow on the destination, both in
+// TRANSFER categories, so balances move while income and expense do not.
+type Transfer struct {
+	ID              int32
+	Date            time.Time
+	Amount          money.Amount
+	Title           string
+	FromAccountID   int32
+	FromAccountName string
+	ToAccountID     int32
+	ToAccountName   string
+	OutCashFlowID   int32
+	InCashFlowID    int32
+	CreatedAt       time.Time
+}
+
+func (t *Transfer) Validate() error {
+	if t.Date.IsZero() {
+		return ErrInvalidDate
+	}
+	if !t.Amount.IsPositive() {
+		return ErrInvalidAmount
+	}
+	if t.FromAccountID == t.ToAccountID {
+		return ErrSameAccount
+	}
+	t.Title = strings.TrimSpace(t.Title)
+	if t.Title == "" {
+		t.Title = DefaultTitle
+	}
+	return nil
+}
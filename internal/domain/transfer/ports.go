@@ -0,0 +1,23 @@
+package transfer
+
+import (
+	"context"
+	"time"
+)
+
+type Repository interface {
+	WithinTx(ctx context.Context, fn func(ctx context.Context) error) error
+	Create(ctx context.Context, t *Transfer) (*Transfer, error)
+	GetByID(ctx context.Context, id int32) (*Transfer, error)
+	List(ctx context.Context, from, to *time.Time) ([]Transfer, error)
+	// Delete removes the transfer and both of its legs.
+	Delete(ctx context.Context, t *Transfer) error
+	CategoryFor(ctx context.Context, direction string) (int32, error)
+}
+
+type Service interface {
+	CreateTransfer(ctx context.Context, t Transfer) (*Transfer, error)
+	GetTransfer(ctx context.Context, id int32) (*Transfer, error)
+	ListTransfers(ctx context.Context, from, to *time.Time) ([]Transfer, error)
+	DeleteTransfer(ctx context.Context, id int32) error
+}
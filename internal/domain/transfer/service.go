@@ -0,0 +1,121 @@
+package transfer
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/domain/account"
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/domain/cashflow"
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/domain/category"
+)
+
+type TransferService struct {
+	repo    Repository
+	cfRepo  cashflow.Repository
+	accRepo account.Repository
+}
+
+func NewService(repo Repository, cfRepo cashflow.Repository, accRepo account.Repository) *TransferService {
+	return &TransferService{
+		repo:    repo,
+		cfRepo:  cfRepo,
+		accRepo: accRepo,
+	}
+}
+
+// CreateTransfer stores both legs and the link between them atomically.
+func (s *TransferService) CreateTransfer(ctx context.Context, t Transfer) (*Transfer, error) {
+	if err := t.Validate(); err != nil {
+		return nil, err
+	}
+	for _, id := range []int32{t.FromAccountID, t.ToAccountID} {
+		acc, err := s.accRepo.GetByID(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		if acc == nil {
+			return nil, account.ErrAccountNotFound
+		}
+	}
+
+	var created *Transfer
+	err := s.repo.WithinTx(ctx, func(ctx context.Context) error {
+		out, err := s.createLeg(ctx, t, category.DirectionOut, t.FromAccountID)
+		if err != nil {
+			return err
+		}
+		in, err := s.createLeg(ctx, t, category.DirectionIn, t.ToAccountID)
+		if err != nil {
+			return err
+		}
+		t.OutCashFlowID = out.ID
+		t.InCashFlowID = in.ID
+
+		stored, err := s.repo.Create(ctx, &t)
+		if err != nil {
+			return err
+		}
+		created, err = s.repo.GetByID(ctx, stored.ID)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return created, nil
+}
+
+func (s *TransferService) GetTransfer(ctx context.Context, id int32) (*Transfer, error) {
+	t, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if t == nil {
+		return nil, ErrTransferNotFound
+	}
+	return t, nil
+}
+
+func (s *TransferService) ListTransfers(ctx context.Context, from, to *time.Time) ([]Transfer, error) {
+	return s.repo.List(ctx, from, to)
+}
+
+func (s *TransferService) DeleteTransfer(ctx context.Context, id int32) error {
+	return s.repo.WithinTx(ctx, func(ctx context.Context) error {
+		t, err := s.repo.GetByID(ctx, id)
+		if err != nil {
+			return err
+		}
+		if t == nil {
+			return ErrTransferNotFound
+		}
+		for _, legID := range []int32{t.OutCashFlowID, t.InCashFlowID} {
+			leg, err := s.cfRepo.GetByID(ctx, legID)
+			if err != nil {
+				return err
+			}
+			if leg != nil && leg.ReconciliationID != nil {
+				return cashflow.ErrCashFlowLocked
+			}
+		}
+		return s.repo.Delete(ctx, t)
+	})
+}
+
+func (s *TransferService) createLeg(ctx context.Context, t Transfer, direction string, accountID int32) (*cashflow.CashFlow, error) {
+	categoryID, err := s.repo.CategoryFor(ctx, direction)
+	if err != nil {
+		return nil, err
+	}
+	leg, err := cashflow.New(t.Date, categoryID, direction, t.Title, t.Amount, false)
+	if err != nil {
+		return nil, err
+	}
+	leg.AccountID = &accountID
+
+	created, err := s.cfRepo.Create(ctx, leg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create %s leg: %w", direction, err)
+	}
+	return created, nil
+}
@@ -0,0 +1,217 @@
+// Package money holds amounts of money as an exact number of cents, so sums
+// of cash flows, installments and invoices reconcile to the cent (RNF-05).
+package money
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+var (
+	ErrInvalidAmount = errors.New("invalid amount")
+	ErrOutOfRange    = errors.New("amount out of range")
+)
+
+// Amount is a value in cents. The zero value is zero.
+type Amount struct {
+	cents int64
+}
+
+// FromCents returns the amount of c cents.
+func FromCents(c int64) Amount {
+	return Amount{cents: c}
+}
+
+// FromFloat rounds f to the nearest cent, halves away from zero. Use it only
+// where a float is inherent (rates, percentages); parse user input with Parse.
+func FromFloat(f float64) Amount {
+	return Amount{cents: int64(math.Round(f * 100))}
+}
+
+// Parse reads a decimal such as "1234.56", "-3" or "1e3". Digits past the
+// cents are rounded half away from zero.
+func Parse(s string) (Amount, error) {
+	s = strings.TrimSpace(s)
+	if strings.Contains(s, "/") {
+		return Amount{}, fmt.Errorf("%w: %q", ErrInvalidAmount, s)
+	}
+	r, ok := new(big.Rat).SetString(s)
+	if !ok {
+		return Amount{}, fmt.Errorf("%w: %q", ErrInvalidAmount, s)
+	}
+	return fromRat(r.Mul(r, big.NewRat(100, 1)))
+}
+
+// MustParse is like Parse but panics on error. Meant for constants and tests.
+func MustParse(s string) Amount {
+	a, err := Parse(s)
+	if err != nil {
+		panic(err)
+	}
+	return a
+}
+
+// fromRat rounds a number of cents to an integer, halves away from zero.
+func fromRat(r *big.Rat) (Amount, error) {
+	num := new(big.Int).Abs(r.Num())
+	q, rem := new(big.Int).QuoRem(num, r.Denom(), new(big.Int))
+	if rem.Lsh(rem, 1).Cmp(r.Denom()) >= 0 {
+		q.Add(q, big.NewInt(1))
+	}
+	if r.Sign() < 0 {
+		q.Neg(q)
+	}
+	if !q.IsInt64() {
+		return Amount{}, ErrOutOfRange
+	}
+	return Amount{cents: q.Int64()}, nil
+}
+
+// Sum adds the amounts.
+func Sum(amounts ...Amount) Amount {
+	var total Amount
+	for _, a := range amounts {
+		total.cents += a.cents
+	}
+	return total
+}
+
+func (a Amount) Cents() int64 { return a.cents }
+
+// Float64 is the amount in currency units. Use it only for display or ratios.
+func (a Amount) Float64() float64 { return float64(a.cents) / 100 }
+
+func (a Amount) Add(b Amount) Amount { return Amount{cents: a.cents + b.cents} }
+func (a Amount) Sub(b Amount) Amount { return Amount{cents: a.cents - b.cents} }
+func (a Amount) Neg() Amount         { return Amount{cents: -a.cents} }
+func (a Amount) Mul(n int64) Amount  { return Amount{cents: a.cents * n} }
+
+func (a Amount) Abs() Amount {
+	if a.cents < 0 {
+		return a.Neg()
+	}
+	return a
+}
+
+// MulRate multiplies by a rate (0.15 for 15%), rounding to the cent.
+func (a Amount) MulRate(rate float64) Amount {
+	return Amount{cents: int64(math.Round(float64(a.cents) * rate))}
+}
+
+// Split divides the amount in n parts that add up exactly to it. The cents
+// that do not divide evenly go to the first parts: 100.00 / 3 is 33.34,
+// 33.33, 33.33.
+func (a Amount) Split(n int) []Amount {
+	if n < 1 {
+		return nil
+	}
+	base, rem := a.cents/int64(n), a.cents%int64(n)
+	step := int64(1)
+	if rem < 0 {
+		rem, step = -rem, -1
+	}
+	parts := make([]Amount, n)
+	for i := range parts {
+		parts[i].cents = base
+		if int64(i) < rem {
+			parts[i].cents += step
+		}
+	}
+	return parts
+}
+
+// Cmp returns -1, 0 or +1 as a is less than, equal to or greater than b.
+func (a Amount) Cmp(b Amount) int {
+	switch {
+	case a.cents < b.cents:
+		return -1
+	case a.cents > b.cents:
+		return 1
+	}
+	return 0
+}
+
+func (a Amount) Sign() int        { return a.Cmp(Amount{}) }
+func (a Amount) IsZero() bool     { return a.cents == 0 }
+func (a Amount) IsPositive() bool { return a.cents > 0 }
+func (a Amount) IsNegative() bool { return a.cents < 0 }
+
+// String formats the amount with two decimals and a dot: "-1234.50".
+func (a Amount) String() string {
+	sign := ""
+	c := a.cents
+	if c < 0 {
+		sign = "-"
+	}
+	u := uint64(c)
+	if c < 0 {
+		u = uint64(-c)
+	}
+	return fmt.Sprintf("%s%d.%02d", sign, u/100, u%100)
+}
+
+// MarshalJSON writes the amount as a JSON number with two decimals.
+func (a Amount) MarshalJSON() ([]byte, error) {
+	return []byte(a.String()), nil
+}
+
+// UnmarshalJSON reads a JSON number (or a numeric string) without going
+// through float64.
+func (a *Amount) UnmarshalJSON(data []byte) error {
+	s := string(data)
+	if s == "null" {
+		return nil
+	}
+	if unquoted, err := strconv.Unquote(s); err == nil {
+		s = unquoted
+	}
+	parsed, err := Parse(s)
+	if err != nil {
+		return err
+	}
+	*a = parsed
+	return nil
+}
+
+// NumericValue implements pgtype.NumericValuer, so amounts bind to numeric
+// columns directly.
+func (a Amount) NumericValue() (pgtype.Numeric, error) {
+	return pgtype.Numeric{Int: big.NewInt(a.cents), Exp: -2, Valid: true}, nil
+}
+
+// ScanNumeric implements pgtype.NumericScanner.
+func (a *Amount) ScanNumeric(v pgtype.Numeric) error {
+	if !v.Valid {
+		return errors.New("cannot scan NULL into money.Amount")
+	}
+	if v.NaN || v.InfinityModifier != pgtype.Finite {
+		return fmt.Errorf("%w: not a finite number", ErrInvalidAmount)
+	}
+	r := new(big.Rat).SetInt(v.Int)
+	exp := v.Exp + 2
+	scale := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(absInt32(exp))), nil)
+	if exp >= 0 {
+		r.Mul(r, new(big.Rat).SetInt(scale))
+	} else {
+		r.Quo(r, new(big.Rat).SetInt(scale))
+	}
+	parsed, err := fromRat(r)
+	if err != nil {
+		return err
+	}
+	*a = parsed
+	return nil
+}
+
+func absInt32(v int32) int32 {
+	if v < 0 {
+		return -v
+	}
+	return v
+}
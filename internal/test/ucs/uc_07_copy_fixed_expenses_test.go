@@ -15,6 +15,7 @@ import (
 	"github.com/LucasSiedschlag/HausHaltsMeister/internal/adapters/postgres"
 	"github.com/LucasSiedschlag/HausHaltsMeister/internal/domain/cashflow"
 	"github.com/LucasSiedschlag/HausHaltsMeister/internal/domain/category"
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/money"
 	"github.com/LucasSiedschlag/HausHaltsMeister/internal/test/harness"
 )
 
@@ -40,10 +41,10 @@ func TestUC07_CopyFixedExpenses(t *testing.T) {
 
 	// 1. Create Jan Expenses
 	// Fixed
-	_, err := cfService.CreateCashFlow(ctx, time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC), fixedCat.ID, "OUT", "Internet Jan", 100.0, true)
+	_, err := cfService.CreateCashFlow(ctx, time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC), fixedCat.ID, "OUT", "Internet Jan", money.MustParse("100.00"), true)
 	require.NoError(t, err)
 	// Variable
-	_, err = cfService.CreateCashFlow(ctx, time.Date(2024, 1, 20, 0, 0, 0, 0, time.UTC), varCat.ID, "OUT", "Jantar Jan", 200.0, false)
+	_, err = cfService.CreateCashFlow(ctx, time.Date(2024, 1, 20, 0, 0, 0, 0, time.UTC), varCat.ID, "OUT", "Jantar Jan", money.MustParse("200.00"), false)
 	require.NoError(t, err)
 
 	// 2. Copy to Feb
@@ -17,6 +17,7 @@ import (
 	"github.com/LucasSiedschlag/HausHaltsMeister/internal/domain/budget"
 	"github.com/LucasSiedschlag/HausHaltsMeister/internal/domain/cashflow"
 	"github.com/LucasSiedschlag/HausHaltsMeister/internal/domain/category"
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/money"
 	"github.com/LucasSiedschlag/HausHaltsMeister/internal/test/harness"
 )
 
@@ -49,9 +50,9 @@ func TestUC10_BudgetManagement(t *testing.T) {
 
 	// 1. Create CashFlow for Mar 2024 to verify "Used" amount logic
 	mar1 := time.Date(2024, 3, 5, 0, 0, 0, 0, time.UTC)
-	_, err := cfService.CreateCashFlow(ctx, mar1, foodCat.ID, "OUT", "Groceries", 150.0, false)
+	_, err := cfService.CreateCashFlow(ctx, mar1, foodCat.ID, "OUT", "Groceries", money.MustParse("150.00"), false)
 	require.NoError(t, err)
-	_, err = cfService.CreateCashFlow(ctx, mar1, incomeCat.ID, "IN", "Salario", 1000.0, false)
+	_, err = cfService.CreateCashFlow(ctx, mar1, incomeCat.ID, "IN", "Salario", money.MustParse("1000.00"), false)
 	require.NoError(t, err)
 
 	monthParam := "2024-03-01"
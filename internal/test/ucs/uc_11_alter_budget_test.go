@@ -17,6 +17,7 @@ import (
 	"github.com/LucasSiedschlag/HausHaltsMeister/internal/domain/budget"
 	"github.com/LucasSiedschlag/HausHaltsMeister/internal/domain/cashflow"
 	"github.com/LucasSiedschlag/HausHaltsMeister/internal/domain/category"
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/money"
 	"github.com/LucasSiedschlag/HausHaltsMeister/internal/test/harness"
 )
 
@@ -47,7 +48,7 @@ func TestUC11_AlterBudget(t *testing.T) {
 	incomeCat, _ := catRepo.Create(ctx, &category.Category{Name: "Ganho", Direction: "IN", IsActive: true, IsBudgetRelevant: true})
 
 	cfService := cashflow.NewService(cfRepo, catRepo)
-	_, err := cfService.CreateCashFlow(ctx, time.Date(2024, 4, 10, 0, 0, 0, 0, time.UTC), incomeCat.ID, "IN", "Salario", 2000.0, false)
+	_, err := cfService.CreateCashFlow(ctx, time.Date(2024, 4, 10, 0, 0, 0, 0, time.UTC), incomeCat.ID, "IN", "Salario", money.MustParse("2000.00"), false)
 	require.NoError(t, err)
 
 	monthParam := "2024-04-01"
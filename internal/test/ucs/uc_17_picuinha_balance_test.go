@@ -14,6 +14,7 @@ import (
 	"github.com/LucasSiedschlag/HausHaltsMeister/internal/adapters/http"
 	"github.com/LucasSiedschlag/HausHaltsMeister/internal/adapters/postgres"
 	"github.com/LucasSiedschlag/HausHaltsMeister/internal/domain/picuinha"
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/money"
 	"github.com/LucasSiedschlag/HausHaltsMeister/internal/test/harness"
 )
 
@@ -43,7 +44,7 @@ func TestUC17_PicuinhaBalance(t *testing.T) {
 		PersonID:    person.ID,
 		Title:       "Compra avulsa",
 		CaseType:    picuinha.CaseTypeOneOff,
-		TotalAmount: 100.0,
+		TotalAmount: money.MustParse("100.00"),
 		StartDate:   time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
 	})
 	require.NoError(t, err)
@@ -52,7 +53,7 @@ func TestUC17_PicuinhaBalance(t *testing.T) {
 		PersonID:    person.ID,
 		Title:       "Compra quitada",
 		CaseType:    picuinha.CaseTypeOneOff,
-		TotalAmount: 30.0,
+		TotalAmount: money.MustParse("30.00"),
 		StartDate:   time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
 	})
 	require.NoError(t, err)
@@ -62,7 +63,7 @@ func TestUC17_PicuinhaBalance(t *testing.T) {
 	require.Len(t, installments, 1)
 	_, err = picService.UpdateInstallment(ctx, installments[0].ID, picuinha.UpdateInstallmentRequest{
 		IsPaid:      true,
-		ExtraAmount: 0,
+		ExtraAmount: money.Amount{},
 	})
 	require.NoError(t, err)
 
@@ -15,6 +15,7 @@ import (
 	"github.com/LucasSiedschlag/HausHaltsMeister/internal/adapters/postgres"
 	"github.com/LucasSiedschlag/HausHaltsMeister/internal/domain/cashflow"
 	"github.com/LucasSiedschlag/HausHaltsMeister/internal/domain/category"
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/money"
 	"github.com/LucasSiedschlag/HausHaltsMeister/internal/test/harness"
 )
 
@@ -40,11 +41,11 @@ func TestUC18_Dashboard(t *testing.T) {
 
 	// Create Data for Jan
 	jan1 := time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC)
-	_, err := cfService.CreateCashFlow(ctx, jan1, inCat.ID, "IN", "Jan Salary", 5000.0, false)
+	_, err := cfService.CreateCashFlow(ctx, jan1, inCat.ID, "IN", "Jan Salary", money.MustParse("5000.00"), false)
 	require.NoError(t, err)
-	_, err = cfService.CreateCashFlow(ctx, jan1, outCat.ID, "OUT", "Jan Food", 1200.0, false)
+	_, err = cfService.CreateCashFlow(ctx, jan1, outCat.ID, "OUT", "Jan Food", money.MustParse("1200.00"), false)
 	require.NoError(t, err)
-	_, err = cfService.CreateCashFlow(ctx, jan1, outCat.ID, "OUT", "Jan Snacks", 300.0, false)
+	_, err = cfService.CreateCashFlow(ctx, jan1, outCat.ID, "OUT", "Jan Snacks", money.MustParse("300.00"), false)
 	require.NoError(t, err)
 
 	t.Run("UC-18: Monthly Summary", func(t *testing.T) {
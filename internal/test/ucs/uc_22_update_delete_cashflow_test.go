@@ -0,0 +1,121 @@
+package ucs
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	std_http "net/http"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/adapters/http"
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/adapters/postgres"
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/domain/cashflow"
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/domain/category"
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/domain/installment"
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/domain/payment"
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/money"
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/test/harness"
+)
+
+func TestUC22_UpdateDeleteCashFlow(t *testing.T) {
+	db := harness.SetupTestDB(t)
+	defer db.Pool.Close()
+
+	catRepo := postgres.NewCategoryRepository(db.Pool)
+	cfRepo := postgres.NewCashFlowRepository(db.Pool)
+	payRepo := postgres.NewPaymentRepository(db.Pool)
+	instRepo := postgres.NewInstallmentRepository(db.Pool)
+	cfService := cashflow.NewService(cfRepo, catRepo)
+	instService := installment.NewService(instRepo, cfService, payRepo)
+	cfHandler := http.NewCashFlowHandler(cfService)
+
+	e := echo.New()
+	http.RegisterCashFlowRoutes(e, cfHandler)
+	client := harness.NewHTTPClient(e)
+
+	ctx := context.Background()
+	inCat, _ := catRepo.Create(ctx, &category.Category{Name: "Salary", Direction: "IN", IsActive: true})
+	outCat, _ := catRepo.Create(ctx, &category.Category{Name: "Food", Direction: "OUT", IsActive: true})
+
+	flow, err := cfService.CreateCashFlow(ctx, time.Date(2024, 3, 5, 0, 0, 0, 0, time.UTC), outCat.ID, "OUT", "Mercadoo", money.MustParse("120.00"), false)
+	require.NoError(t, err)
+
+	t.Run("Update keeps original values as revision", func(t *testing.T) {
+		payload := map[string]interface{}{
+			"date":        "2024-03-05",
+			"category_id": outCat.ID,
+			"direction":   "OUT",
+			"title":       "Mercado",
+			"amount":      125.5,
+		}
+		rec := client.Request(t, "PUT", fmt.Sprintf("/cashflows/%d", flow.ID), payload)
+		require.Equal(t, std_http.StatusOK, rec.Code)
+
+		var updated map[string]interface{}
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &updated))
+		assert.Equal(t, "Mercado", updated["title"])
+		assert.Equal(t, 125.5, updated["amount"])
+
+		revRec := client.Request(t, "GET", fmt.Sprintf("/cashflows/%d/revisions", flow.ID), nil)
+		require.Equal(t, std_http.StatusOK, revRec.Code)
+
+		var revisions []map[string]interface{}
+		require.NoError(t, json.Unmarshal(revRec.Body.Bytes(), &revisions))
+		require.Len(t, revisions, 1)
+		assert.Equal(t, "UPDATE", revisions[0]["action"])
+		assert.Equal(t, "Mercadoo", revisions[0]["title"])
+		assert.Equal(t, 120.0, revisions[0]["amount"])
+	})
+
+	t.Run("Update enforces category direction", func(t *testing.T) {
+		payload := map[string]interface{}{
+			"date":        "2024-03-05",
+			"category_id": inCat.ID,
+			"direction":   "OUT",
+			"title":       "Mercado",
+			"amount":      125.5,
+		}
+		rec := client.Request(t, "PUT", fmt.Sprintf("/cashflows/%d", flow.ID), payload)
+		require.Equal(t, std_http.StatusBadRequest, rec.Code)
+	})
+
+	t.Run("Delete records revision", func(t *testing.T) {
+		rec := client.Request(t, "DELETE", fmt.Sprintf("/cashflows/%d", flow.ID), nil)
+		require.Equal(t, std_http.StatusOK, rec.Code)
+
+		listRec := client.Request(t, "GET", "/cashflows?month=2024-03-01", nil)
+		require.Equal(t, std_http.StatusOK, listRec.Code)
+		var list []map[string]interface{}
+		require.NoError(t, json.Unmarshal(listRec.Body.Bytes(), &list))
+		assert.Empty(t, list)
+
+		revRec := client.Request(t, "GET", fmt.Sprintf("/cashflows/%d/revisions", flow.ID), nil)
+		var revisions []map[string]interface{}
+		require.NoError(t, json.Unmarshal(revRec.Body.Bytes(), &revisions))
+		require.Len(t, revisions, 2)
+		assert.Equal(t, "DELETE", revisions[0]["action"])
+
+		missing := client.Request(t, "DELETE", fmt.Sprintf("/cashflows/%d", flow.ID), nil)
+		assert.Equal(t, std_http.StatusNotFound, missing.Code)
+	})
+
+	t.Run("Delete is blocked for installment flows", func(t *testing.T) {
+		closingDay, dueDay := int32(1), int32(10)
+		card, err := payRepo.Create(ctx, &payment.PaymentMethod{Name: "Card", Kind: payment.KindCreditCard, ClosingDay: &closingDay, DueDay: &dueDay, IsActive: true})
+		require.NoError(t, err)
+		_, err = instService.CreateInstallmentPurchase(ctx, "TV", money.MustParse("300.00"), 3, outCat.ID, card.ID, time.Date(2024, 4, 20, 0, 0, 0, 0, time.UTC))
+		require.NoError(t, err)
+
+		flows, err := cfService.ListCashFlows(ctx, time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC))
+		require.NoError(t, err)
+		require.NotEmpty(t, flows)
+
+		rec := client.Request(t, "DELETE", fmt.Sprintf("/cashflows/%d", flows[0].ID), nil)
+		assert.Equal(t, std_http.StatusConflict, rec.Code)
+	})
+}
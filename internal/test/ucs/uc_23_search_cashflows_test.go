@@ -0,0 +1,91 @@
+package ucs
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	std_http "net/http"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/adapters/http"
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/adapters/postgres"
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/domain/cashflow"
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/domain/category"
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/money"
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/test/harness"
+)
+
+func TestUC23_SearchCashFlows(t *testing.T) {
+	db := harness.SetupTestDB(t)
+	defer db.Pool.Close()
+
+	catRepo := postgres.NewCategoryRepository(db.Pool)
+	cfRepo := postgres.NewCashFlowRepository(db.Pool)
+	cfService := cashflow.NewService(cfRepo, catRepo)
+	cfHandler := http.NewCashFlowHandler(cfService)
+
+	e := echo.New()
+	http.RegisterCashFlowRoutes(e, cfHandler)
+	client := harness.NewHTTPClient(e)
+
+	ctx := context.Background()
+	transport, _ := catRepo.Create(ctx, &category.Category{Name: "Transporte", Direction: "OUT", IsActive: true})
+	food, _ := catRepo.Create(ctx, &category.Category{Name: "Alimentação", Direction: "OUT", IsActive: true})
+
+	for month := 1; month <= 6; month++ {
+		date := time.Date(2024, time.Month(month), 10, 0, 0, 0, 0, time.UTC)
+		_, err := cfService.CreateCashFlow(ctx, date, transport.ID, "OUT", "Uber Centro", money.FromCents(int64(20+month)*100), false)
+		require.NoError(t, err)
+		_, err = cfService.CreateCashFlow(ctx, date, food.ID, "OUT", "Mercado", money.MustParse("300.00"), false)
+		require.NoError(t, err)
+	}
+	_, err := cfService.CreateCashFlow(ctx, time.Date(2023, 12, 10, 0, 0, 0, 0, time.UTC), transport.ID, "OUT", "uber aeroporto", money.MustParse("80.00"), false)
+	require.NoError(t, err)
+
+	search := func(t *testing.T, query string) map[string]interface{} {
+		rec := client.Request(t, "GET", "/cashflows/search?"+query, nil)
+		require.Equal(t, std_http.StatusOK, rec.Code, rec.Body.String())
+		var resp map[string]interface{}
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+		return resp
+	}
+
+	t.Run("Title and date range", func(t *testing.T) {
+		resp := search(t, "title=uber&date_from=2024-01-01&date_to=2024-12-31")
+		assert.Equal(t, 6.0, resp["total"])
+		items := resp["items"].([]interface{})
+		require.Len(t, items, 6)
+		first := items[0].(map[string]interface{})
+		assert.Equal(t, "2024-06-10", first["date"]) // default: newest first
+		assert.Equal(t, "Transporte", first["category_name"])
+	})
+
+	t.Run("Category and amount range sorted by amount", func(t *testing.T) {
+		resp := search(t, fmt.Sprintf("category_id=%d&min_amount=22&max_amount=24&sort=amount&order=asc", transport.ID))
+		items := resp["items"].([]interface{})
+		require.Len(t, items, 3)
+		assert.Equal(t, 22.0, items[0].(map[string]interface{})["amount"])
+		assert.Equal(t, 24.0, items[2].(map[string]interface{})["amount"])
+	})
+
+	t.Run("Pagination", func(t *testing.T) {
+		resp := search(t, fmt.Sprintf("category_id=%d,%d&limit=5&offset=10", transport.ID, food.ID))
+		assert.Equal(t, 13.0, resp["total"])
+		assert.Len(t, resp["items"].([]interface{}), 3)
+		assert.Equal(t, 5.0, resp["limit"])
+		assert.Equal(t, 10.0, resp["offset"])
+	})
+
+	t.Run("Invalid filters", func(t *testing.T) {
+		rec := client.Request(t, "GET", "/cashflows/search?date_from=2024-02-01&date_to=2024-01-01", nil)
+		assert.Equal(t, std_http.StatusBadRequest, rec.Code)
+
+		rec = client.Request(t, "GET", "/cashflows/search?sort=category", nil)
+		assert.Equal(t, std_http.StatusBadRequest, rec.Code)
+	})
+}
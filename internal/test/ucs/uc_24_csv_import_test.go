@@ -0,0 +1,135 @@
+package ucs
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	std_http "net/http"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/adapters/http"
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/adapters/postgres"
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/domain/cashflow"
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/domain/category"
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/domain/importer"
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/money"
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/test/harness"
+)
+
+func TestUC24_CSVImport(t *testing.T) {
+	db := harness.SetupTestDB(t)
+	defer db.Pool.Close()
+
+	catRepo := postgres.NewCategoryRepository(db.Pool)
+	cfRepo := postgres.NewCashFlowRepository(db.Pool)
+	payRepo := postgres.NewPaymentRepository(db.Pool)
+	impRepo := postgres.NewImportRepository(db.Pool)
+	cfService := cashflow.NewService(cfRepo, catRepo)
+	impService := importer.NewService(impRepo, cfService, payRepo)
+
+	e := echo.New()
+	http.RegisterCashFlowRoutes(e, http.NewCashFlowHandler(cfService))
+	http.RegisterImportRoutes(e, http.NewImportHandler(impService))
+	client := harness.NewHTTPClient(e)
+
+	ctx := context.Background()
+	transport, _ := catRepo.Create(ctx, &category.Category{Name: "Transporte", Direction: "OUT", IsActive: true})
+	salary, _ := catRepo.Create(ctx, &category.Category{Name: "Salário", Direction: "IN", IsActive: true})
+
+	// History used for the category suggestion and the duplicate flag.
+	_, err := cfService.CreateCashFlow(ctx, time.Date(2024, 2, 3, 0, 0, 0, 0, time.UTC), transport.ID, "OUT", "UBER TRIP", money.MustParse("18.90"), false)
+	require.NoError(t, err)
+	_, err = cfService.CreateCashFlow(ctx, time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC), salary.ID, "IN", "Salário", money.MustParse("5000.00"), false)
+	require.NoError(t, err)
+
+	var profileID int32
+	t.Run("Create mapping profile", func(t *testing.T) {
+		payload := map[string]interface{}{
+			"name":          "Banco X",
+			"date_column":   0,
+			"title_column":  1,
+			"amount_column": 2,
+		}
+		rec := client.Request(t, "POST", "/imports/profiles", payload)
+		require.Equal(t, std_http.StatusCreated, rec.Code, rec.Body.String())
+
+		var resp map[string]interface{}
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+		assert.Equal(t, ";", resp["delimiter"])
+		assert.Equal(t, "DD/MM/YYYY", resp["date_format"])
+		assert.Equal(t, true, resp["decimal_comma"])
+		profileID = int32(resp["id"].(float64))
+	})
+
+	csv := "Data;Descrição;Valor\n" +
+		"01/03/2024;Salário;5.000,00\n" +
+		"05/03/2024;UBER TRIP;-1.234,56\n" +
+		"06/03/2024;Padaria;-12,30\n" +
+		"xx/03/2024;Linha quebrada;-1,00\n"
+
+	var preview map[string]interface{}
+	t.Run("Preview flags duplicates and suggests categories", func(t *testing.T) {
+		rec := client.Upload(t, "/imports/csv/preview", map[string]string{"profile_id": fmt.Sprint(profileID)}, "file", "extrato.csv", []byte(csv))
+		require.Equal(t, std_http.StatusOK, rec.Code, rec.Body.String())
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &preview))
+
+		candidates := preview["candidates"].([]interface{})
+		require.Len(t, candidates, 4)
+
+		income := candidates[0].(map[string]interface{})
+		assert.Equal(t, "IN", income["direction"])
+		assert.Equal(t, 5000.0, income["amount"])
+		assert.Equal(t, true, income["duplicate"])
+		assert.Equal(t, float64(salary.ID), income["suggested_category_id"])
+
+		uber := candidates[1].(map[string]interface{})
+		assert.Equal(t, "OUT", uber["direction"])
+		assert.Equal(t, 1234.56, uber["amount"])
+		assert.Equal(t, false, uber["duplicate"])
+		assert.Equal(t, float64(transport.ID), uber["suggested_category_id"])
+
+		broken := candidates[3].(map[string]interface{})
+		assert.NotEmpty(t, broken["error"])
+		assert.Equal(t, 1.0, preview["error_count"])
+		assert.Equal(t, 1.0, preview["duplicate_count"])
+	})
+
+	t.Run("Commit is all or nothing", func(t *testing.T) {
+		payload := map[string]interface{}{
+			"items": []map[string]interface{}{
+				{"date": "2024-03-05", "category_id": transport.ID, "direction": "OUT", "title": "UBER TRIP", "amount": 1234.56},
+				{"date": "2024-03-06", "category_id": salary.ID, "direction": "OUT", "title": "Padaria", "amount": 12.30},
+			},
+		}
+		rec := client.Request(t, "POST", "/imports/commit", payload)
+		require.Equal(t, std_http.StatusBadRequest, rec.Code)
+
+		flows, err := cfService.ListCashFlows(ctx, time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC))
+		require.NoError(t, err)
+		assert.Len(t, flows, 1)
+	})
+
+	t.Run("Commit creates cash flows", func(t *testing.T) {
+		payload := map[string]interface{}{
+			"items": []map[string]interface{}{
+				{"date": "2024-03-05", "category_id": transport.ID, "direction": "OUT", "title": "UBER TRIP", "amount": 1234.56},
+				{"date": "2024-03-06", "category_id": transport.ID, "direction": "OUT", "title": "Padaria", "amount": 12.30},
+			},
+		}
+		rec := client.Request(t, "POST", "/imports/commit", payload)
+		require.Equal(t, std_http.StatusCreated, rec.Code, rec.Body.String())
+
+		var resp map[string]interface{}
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+		assert.Equal(t, 2.0, resp["created_count"])
+
+		flows, err := cfService.ListCashFlows(ctx, time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC))
+		require.NoError(t, err)
+		assert.Len(t, flows, 3)
+	})
+}
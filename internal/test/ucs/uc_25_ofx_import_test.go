@@ -0,0 +1,144 @@
+package ucs
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	std_http "net/http"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/adapters/http"
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/adapters/postgres"
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/domain/cashflow"
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/domain/category"
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/domain/importer"
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/domain/payment"
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/money"
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/test/harness"
+)
+
+const ofxCardStatement = `OFXHEADER:100
+DATA:OFXSGML
+VERSION:102
+CHARSET:1252
+
+<OFX>
+<CREDITCARDMSGSRSV1><CCSTMTTRNRS><CCSTMTRS>
+<CURDEF>BRL
+<CCACCTFROM><ACCTID>5555-1234</CCACCTFROM>
+<BANKTRANLIST>
+<STMTTRN>
+<TRNTYPE>DEBIT
+<DTPOSTED>20240305120000[-3:BRT]
+<TRNAMT>-45.90
+<FITID>tx-001
+<MEMO>PADARIA CENTRAL
+</STMTTRN>
+<STMTTRN>
+<TRNTYPE>DEBIT
+<DTPOSTED>20240307
+<TRNAMT>-120.00
+<FITID>tx-002
+<MEMO>POSTO SHELL
+</STMTTRN>
+</BANKTRANLIST>
+</CCSTMTRS></CCSTMTTRNRS></CREDITCARDMSGSRSV1>
+</OFX>
+`
+
+func TestUC25_OFXImport(t *testing.T) {
+	db := harness.SetupTestDB(t)
+	defer db.Pool.Close()
+
+	catRepo := postgres.NewCategoryRepository(db.Pool)
+	cfRepo := postgres.NewCashFlowRepository(db.Pool)
+	payRepo := postgres.NewPaymentRepository(db.Pool)
+	impRepo := postgres.NewImportRepository(db.Pool)
+	cfService := cashflow.NewService(cfRepo, catRepo)
+	payService := payment.NewService(payRepo)
+	impService := importer.NewService(impRepo, cfService, payRepo)
+
+	e := echo.New()
+	http.RegisterImportRoutes(e, http.NewImportHandler(impService))
+	client := harness.NewHTTPClient(e)
+
+	ctx := context.Background()
+	food, _ := catRepo.Create(ctx, &category.Category{Name: "Alimentação", Direction: "OUT", IsActive: true})
+	closingDay, dueDay := int32(25), int32(5)
+	card, err := payService.CreatePaymentMethod(ctx, "Cartão", payment.KindCreditCard, "Banco", nil, &closingDay, &dueDay)
+	require.NoError(t, err)
+
+	preview := func(t *testing.T) []interface{} {
+		rec := client.Upload(t, "/imports/ofx/preview", map[string]string{"payment_method_id": fmt.Sprint(card.ID)}, "file", "fatura.ofx", []byte(ofxCardStatement))
+		require.Equal(t, std_http.StatusOK, rec.Code, rec.Body.String())
+		var resp map[string]interface{}
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+		return resp["candidates"].([]interface{})
+	}
+
+	commit := func(t *testing.T, candidates []interface{}) map[string]interface{} {
+		items := make([]map[string]interface{}, len(candidates))
+		for i, c := range candidates {
+			cand := c.(map[string]interface{})
+			items[i] = map[string]interface{}{
+				"date":              cand["date"],
+				"category_id":       food.ID,
+				"direction":         cand["direction"],
+				"title":             cand["title"],
+				"amount":            cand["amount"],
+				"payment_method_id": card.ID,
+				"fitid":             cand["fitid"],
+				"external_account":  cand["external_account"],
+			}
+		}
+		rec := client.Request(t, "POST", "/imports/commit", map[string]interface{}{"items": items})
+		require.Equal(t, std_http.StatusCreated, rec.Code, rec.Body.String())
+		var resp map[string]interface{}
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+		return resp
+	}
+
+	t.Run("Card statements require a payment method", func(t *testing.T) {
+		rec := client.Upload(t, "/imports/ofx/preview", nil, "file", "fatura.ofx", []byte(ofxCardStatement))
+		assert.Equal(t, std_http.StatusBadRequest, rec.Code)
+	})
+
+	t.Run("First import creates flows on the card invoice", func(t *testing.T) {
+		candidates := preview(t)
+		require.Len(t, candidates, 2)
+		first := candidates[0].(map[string]interface{})
+		assert.Equal(t, "tx-001", first["fitid"])
+		assert.Equal(t, "5555-1234", first["external_account"])
+		assert.Equal(t, 45.9, first["amount"])
+		assert.Equal(t, "OUT", first["direction"])
+		assert.Equal(t, false, first["already_imported"])
+
+		resp := commit(t, candidates)
+		assert.Equal(t, 2.0, resp["created_count"])
+
+		invoice, err := payService.GetInvoice(ctx, card.ID, time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC))
+		require.NoError(t, err)
+		assert.Len(t, invoice.Entries, 2)
+		assert.Equal(t, money.MustParse("165.90"), invoice.Total)
+	})
+
+	t.Run("Re-import never duplicates", func(t *testing.T) {
+		candidates := preview(t)
+		for _, c := range candidates {
+			assert.Equal(t, true, c.(map[string]interface{})["already_imported"])
+		}
+
+		resp := commit(t, candidates)
+		assert.Equal(t, 0.0, resp["created_count"])
+		assert.Equal(t, 2.0, resp["skipped_count"])
+
+		flows, err := cfService.ListCashFlows(ctx, time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC))
+		require.NoError(t, err)
+		assert.Len(t, flows, 2)
+	})
+}
@@ -0,0 +1,159 @@
+package ucs
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	std_http "net/http"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/adapters/http"
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/adapters/postgres"
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/domain/cashflow"
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/domain/category"
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/domain/recurrence"
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/test/harness"
+)
+
+func TestUC26_RecurrenceRules(t *testing.T) {
+	db := harness.SetupTestDB(t)
+	defer db.Pool.Close()
+
+	catRepo := postgres.NewCategoryRepository(db.Pool)
+	cfRepo := postgres.NewCashFlowRepository(db.Pool)
+	payRepo := postgres.NewPaymentRepository(db.Pool)
+	recRepo := postgres.NewRecurrenceRepository(db.Pool)
+	cfService := cashflow.NewService(cfRepo, catRepo)
+	recService := recurrence.NewService(recRepo, cfService, catRepo, payRepo)
+	recHandler := http.NewRecurrenceHandler(recService)
+
+	e := echo.New()
+	http.RegisterRecurrenceRoutes(e, recHandler)
+	client := harness.NewHTTPClient(e)
+
+	ctx := context.Background()
+	housing, _ := catRepo.Create(ctx, &category.Category{Name: "Moradia", Direction: "OUT", IsActive: true})
+	salary, _ := catRepo.Create(ctx, &category.Category{Name: "Salário", Direction: "IN", IsActive: true})
+
+	var quarterlyID int32
+
+	t.Run("Create rules", func(t *testing.T) {
+		// Every 3 months on day 10 until December.
+		rec := client.Request(t, "POST", "/recurrences", map[string]interface{}{
+			"title":        "Condomínio",
+			"category_id":  housing.ID,
+			"direction":    "OUT",
+			"amount":       450.0,
+			"frequency":    "MONTHLY",
+			"interval":     3,
+			"by_month_day": 10,
+			"start_date":   "2024-01-10",
+			"end_date":     "2024-12-31",
+		})
+		require.Equal(t, std_http.StatusCreated, rec.Code, rec.Body.String())
+		var created map[string]interface{}
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &created))
+		quarterlyID = int32(created["id"].(float64))
+		assert.Equal(t, "NONE", created["business_day_adjust"])
+
+		// Last business day of every month.
+		rec = client.Request(t, "POST", "/recurrences", map[string]interface{}{
+			"title":               "Salário",
+			"category_id":         salary.ID,
+			"direction":           "IN",
+			"amount":              5000.0,
+			"frequency":           "MONTHLY",
+			"by_month_day":        -1,
+			"business_day_adjust": "PREVIOUS",
+			"start_date":          "2024-01-01",
+		})
+		require.Equal(t, std_http.StatusCreated, rec.Code, rec.Body.String())
+	})
+
+	t.Run("Invalid rules", func(t *testing.T) {
+		rec := client.Request(t, "POST", "/recurrences", map[string]interface{}{
+			"title":       "Aluguel",
+			"category_id": salary.ID,
+			"direction":   "OUT",
+			"amount":      100.0,
+			"frequency":   "MONTHLY",
+			"start_date":  "2024-01-01",
+		})
+		assert.Equal(t, std_http.StatusBadRequest, rec.Code)
+
+		rec = client.Request(t, "POST", "/recurrences", map[string]interface{}{
+			"title":       "Aluguel",
+			"category_id": housing.ID,
+			"direction":   "OUT",
+			"amount":      100.0,
+			"frequency":   "DAILY",
+			"start_date":  "2024-01-01",
+		})
+		assert.Equal(t, std_http.StatusBadRequest, rec.Code)
+	})
+
+	t.Run("Materialize is idempotent", func(t *testing.T) {
+		payload := map[string]interface{}{"from_month": "2024-01-01", "to_month": "2024-06-01"}
+		rec := client.Request(t, "POST", "/recurrences/materialize", payload)
+		require.Equal(t, std_http.StatusOK, rec.Code, rec.Body.String())
+		var first map[string]interface{}
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &first))
+		assert.Equal(t, 8.0, first["created_count"]) // 2 quarterly + 6 salaries
+		assert.Equal(t, 0.0, first["skipped_count"])
+
+		rec = client.Request(t, "POST", "/recurrences/materialize", payload)
+		require.Equal(t, std_http.StatusOK, rec.Code)
+		var second map[string]interface{}
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &second))
+		assert.Equal(t, 0.0, second["created_count"])
+		assert.Equal(t, 8.0, second["skipped_count"])
+
+		// March 2024 ends on a Sunday: the salary moves to Friday the 29th.
+		flows, err := cfService.ListCashFlows(ctx, time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC))
+		require.NoError(t, err)
+		require.Len(t, flows, 1)
+		assert.Equal(t, "2024-03-29", flows[0].Date.Format("2006-01-02"))
+
+		flows, err = cfService.ListCashFlows(ctx, time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC))
+		require.NoError(t, err)
+		assert.Len(t, flows, 2)
+	})
+
+	t.Run("Occurrences link rule and cash flow", func(t *testing.T) {
+		rec := client.Request(t, "GET", fmt.Sprintf("/recurrences/%d/occurrences", quarterlyID), nil)
+		require.Equal(t, std_http.StatusOK, rec.Code)
+		var occurrences []map[string]interface{}
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &occurrences))
+		require.Len(t, occurrences, 2)
+		assert.Equal(t, "2024-01-10", occurrences[0]["date"])
+		assert.Equal(t, "2024-04-10", occurrences[1]["date"])
+		assert.NotNil(t, occurrences[0]["cash_flow_id"])
+	})
+
+	t.Run("Deleted flows are not generated again", func(t *testing.T) {
+		flows, err := cfService.ListCashFlows(ctx, time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+		require.NoError(t, err)
+		for _, f := range flows {
+			require.NoError(t, cfService.DeleteCashFlow(ctx, f.ID))
+		}
+
+		rec := client.Request(t, "POST", "/recurrences/materialize", map[string]interface{}{"from_month": "2024-01-01", "to_month": "2024-01-01"})
+		require.Equal(t, std_http.StatusOK, rec.Code)
+		var result map[string]interface{}
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &result))
+		assert.Equal(t, 0.0, result["created_count"])
+	})
+
+	t.Run("Delete rule", func(t *testing.T) {
+		rec := client.Request(t, "DELETE", fmt.Sprintf("/recurrences/%d", quarterlyID), nil)
+		require.Equal(t, std_http.StatusOK, rec.Code)
+
+		rec = client.Request(t, "GET", fmt.Sprintf("/recurrences/%d/occurrences", quarterlyID), nil)
+		assert.Equal(t, std_http.StatusNotFound, rec.Code)
+	})
+}
@@ -0,0 +1,112 @@
+package ucs
+
+import (
+	"context"
+	"encoding/json"
+	std_http "net/http"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/adapters/http"
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/adapters/postgres"
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/domain/cashflow"
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/domain/category"
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/domain/recurrence"
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/money"
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/test/harness"
+)
+
+func TestUC27_CopyFixedReport(t *testing.T) {
+	db := harness.SetupTestDB(t)
+	defer db.Pool.Close()
+
+	catRepo := postgres.NewCategoryRepository(db.Pool)
+	cfRepo := postgres.NewCashFlowRepository(db.Pool)
+	payRepo := postgres.NewPaymentRepository(db.Pool)
+	recRepo := postgres.NewRecurrenceRepository(db.Pool)
+	cfService := cashflow.NewService(cfRepo, catRepo)
+	recService := recurrence.NewService(recRepo, cfService, catRepo, payRepo)
+	cfHandler := http.NewCashFlowHandler(cfService)
+
+	e := echo.New()
+	http.RegisterCashFlowRoutes(e, cfHandler)
+	client := harness.NewHTTPClient(e)
+
+	ctx := context.Background()
+	fixedCat, _ := catRepo.Create(ctx, &category.Category{Name: "Fixa", Direction: "OUT", IsActive: true})
+
+	jan := func(day int) time.Time { return time.Date(2024, 1, day, 0, 0, 0, 0, time.UTC) }
+	_, err := cfService.CreateCashFlow(ctx, jan(10), fixedCat.ID, "OUT", "Aluguel", money.MustParse("1500.00"), true)
+	require.NoError(t, err)
+	_, err = cfService.CreateCashFlow(ctx, jan(31), fixedCat.ID, "OUT", "Internet", money.MustParse("100.00"), true)
+	require.NoError(t, err)
+	_, err = cfService.CreateCashFlow(ctx, jan(15), fixedCat.ID, "OUT", "Academia", money.MustParse("90.00"), true)
+	require.NoError(t, err)
+
+	// Already entered by hand in February.
+	_, err = cfService.CreateCashFlow(ctx, time.Date(2024, 2, 15, 0, 0, 0, 0, time.UTC), fixedCat.ID, "OUT", "academia", money.MustParse("95.00"), true)
+	require.NoError(t, err)
+
+	// Generated by a recurrence rule: left to the rule.
+	_, err = recService.CreateRule(ctx, recurrence.Rule{
+		Title: "Condomínio", CategoryID: fixedCat.ID, Direction: "OUT", Amount: money.MustParse("450.00"), IsFixed: true,
+		Frequency: recurrence.FrequencyMonthly, StartDate: jan(5),
+	})
+	require.NoError(t, err)
+	_, err = recService.Materialize(ctx, jan(1), jan(1))
+	require.NoError(t, err)
+
+	copyFixed := func(t *testing.T, dryRun bool) map[string]interface{} {
+		payload := map[string]interface{}{"from_month": "2024-01-01", "to_month": "2024-02-01", "dry_run": dryRun}
+		rec := client.Request(t, "POST", "/cashflows/copy-fixed", payload)
+		require.Equal(t, std_http.StatusOK, rec.Code, rec.Body.String())
+		var res map[string]interface{}
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &res))
+		return res
+	}
+	countFeb := func(t *testing.T) int {
+		flows, err := cfService.ListCashFlows(ctx, time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC))
+		require.NoError(t, err)
+		return len(flows)
+	}
+
+	t.Run("Dry run reports without saving", func(t *testing.T) {
+		res := copyFixed(t, true)
+		assert.Equal(t, true, res["dry_run"])
+		assert.Equal(t, 2.0, res["copied_count"])
+		assert.Equal(t, 1.0, res["conflict_count"])
+		assert.Equal(t, "Academia", res["conflicts"].([]interface{})[0].(map[string]interface{})["title"])
+		assert.Equal(t, 1, countFeb(t))
+	})
+
+	t.Run("Copy creates linked flows", func(t *testing.T) {
+		res := copyFixed(t, false)
+		assert.Equal(t, 2.0, res["copied_count"])
+		assert.Equal(t, 0.0, res["skipped_count"])
+		assert.Equal(t, 1.0, res["conflict_count"])
+
+		created := res["created"].([]interface{})
+		internet := created[1].(map[string]interface{})
+		assert.Equal(t, "Internet", internet["title"])
+		assert.Equal(t, "2024-02-29", internet["date"]) // clamped to the month's last day
+		assert.NotNil(t, internet["cash_flow_id"])
+		assert.Equal(t, 3, countFeb(t))
+	})
+
+	t.Run("Second copy is a no-op", func(t *testing.T) {
+		res := copyFixed(t, false)
+		assert.Equal(t, 0.0, res["copied_count"])
+		assert.Equal(t, 2.0, res["skipped_count"])
+		assert.Equal(t, 3, countFeb(t))
+	})
+
+	t.Run("Same month is rejected", func(t *testing.T) {
+		payload := map[string]interface{}{"from_month": "2024-01-01", "to_month": "2024-01-20"}
+		rec := client.Request(t, "POST", "/cashflows/copy-fixed", payload)
+		assert.Equal(t, std_http.StatusBadRequest, rec.Code)
+	})
+}
@@ -0,0 +1,147 @@
+package ucs
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	std_http "net/http"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/adapters/http"
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/adapters/postgres"
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/domain/budget"
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/domain/cashflow"
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/domain/category"
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/money"
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/test/harness"
+)
+
+func TestUC28_SplitCashFlows(t *testing.T) {
+	db := harness.SetupTestDB(t)
+	defer db.Pool.Close()
+
+	catRepo := postgres.NewCategoryRepository(db.Pool)
+	cfRepo := postgres.NewCashFlowRepository(db.Pool)
+	budRepo := postgres.NewBudgetRepository(db.Pool)
+	cfService := cashflow.NewService(cfRepo, catRepo)
+	budService := budget.NewService(budRepo, catRepo, cfRepo)
+
+	e := echo.New()
+	http.RegisterCashFlowRoutes(e, http.NewCashFlowHandler(cfService))
+	http.RegisterBudgetRoutes(e, http.NewBudgetHandler(budService))
+	client := harness.NewHTTPClient(e)
+
+	ctx := context.Background()
+	fixed, _ := catRepo.Create(ctx, &category.Category{Name: "Custos Fixos", Direction: "OUT", IsActive: true, IsBudgetRelevant: true})
+	fun, _ := catRepo.Create(ctx, &category.Category{Name: "Prazeres", Direction: "OUT", IsActive: true, IsBudgetRelevant: true})
+	salary, _ := catRepo.Create(ctx, &category.Category{Name: "Salário", Direction: "IN", IsActive: true})
+
+	var flowID int32
+
+	t.Run("Create split flow", func(t *testing.T) {
+		rec := client.Request(t, "POST", "/cashflows", map[string]interface{}{
+			"date":        "2024-05-10",
+			"category_id": fixed.ID,
+			"direction":   "OUT",
+			"title":       "Supermercado",
+			"amount":      300.0,
+			"splits": []map[string]interface{}{
+				{"category_id": fixed.ID, "amount": 220.0},
+				{"category_id": fun.ID, "amount": 80.0},
+			},
+		})
+		require.Equal(t, std_http.StatusCreated, rec.Code, rec.Body.String())
+		var created map[string]interface{}
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &created))
+		flowID = int32(created["id"].(float64))
+		assert.Len(t, created["splits"], 2)
+	})
+
+	t.Run("Invalid splits", func(t *testing.T) {
+		base := map[string]interface{}{
+			"date":        "2024-05-11",
+			"category_id": fixed.ID,
+			"direction":   "OUT",
+			"title":       "Farmácia",
+			"amount":      100.0,
+		}
+
+		base["splits"] = []map[string]interface{}{{"category_id": fixed.ID, "amount": 60.0}, {"category_id": fun.ID, "amount": 30.0}}
+		rec := client.Request(t, "POST", "/cashflows", base)
+		assert.Equal(t, std_http.StatusBadRequest, rec.Code)
+
+		base["splits"] = []map[string]interface{}{{"category_id": fixed.ID, "amount": 60.0}, {"category_id": salary.ID, "amount": 40.0}}
+		rec = client.Request(t, "POST", "/cashflows", base)
+		assert.Equal(t, std_http.StatusBadRequest, rec.Code)
+
+		// The amount can no longer change without the splits.
+		rec = client.Request(t, "PUT", fmt.Sprintf("/cashflows/%d", flowID), map[string]interface{}{
+			"date":        "2024-05-10",
+			"category_id": fixed.ID,
+			"direction":   "OUT",
+			"title":       "Supermercado",
+			"amount":      310.0,
+		})
+		assert.Equal(t, std_http.StatusBadRequest, rec.Code)
+	})
+
+	t.Run("Summaries aggregate by split", func(t *testing.T) {
+		rec := client.Request(t, "GET", "/cashflows/category-summary?month=2024-05-01", nil)
+		require.Equal(t, std_http.StatusOK, rec.Code)
+		var summary []map[string]interface{}
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &summary))
+		totals := map[string]float64{}
+		for _, s := range summary {
+			totals[s["category_name"].(string)] = s["total_amount"].(float64)
+		}
+		assert.Equal(t, map[string]float64{"Custos Fixos": 220.0, "Prazeres": 80.0}, totals)
+
+		rec = client.Request(t, "GET", "/cashflows/summary?month=2024-05-01", nil)
+		require.Equal(t, std_http.StatusOK, rec.Code)
+		var monthly map[string]interface{}
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &monthly))
+		assert.Equal(t, 300.0, monthly["total_expense"])
+	})
+
+	t.Run("Budget actuals use splits", func(t *testing.T) {
+		_, err := budService.SetBudgetItem(ctx, time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC), fun.ID, budget.ModeAbsolute, money.MustParse("100.00"), 0)
+		require.NoError(t, err)
+
+		rec := client.Request(t, "GET", "/budgets/2024-05-01/summary", nil)
+		require.Equal(t, std_http.StatusOK, rec.Code)
+		var res map[string]interface{}
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &res))
+		items := res["items"].([]interface{})
+		require.Len(t, items, 1)
+		assert.Equal(t, 80.0, items[0].(map[string]interface{})["actual_amount"])
+	})
+
+	t.Run("Replace and remove splits", func(t *testing.T) {
+		path := fmt.Sprintf("/cashflows/%d/splits", flowID)
+		rec := client.Request(t, "PUT", path, map[string]interface{}{
+			"splits": []map[string]interface{}{
+				{"category_id": fixed.ID, "amount": 150.0},
+				{"category_id": fun.ID, "amount": 150.0},
+			},
+		})
+		require.Equal(t, std_http.StatusOK, rec.Code, rec.Body.String())
+		var splits []map[string]interface{}
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &splits))
+		require.Len(t, splits, 2)
+		assert.Equal(t, "Prazeres", splits[1]["category_name"])
+
+		rec = client.Request(t, "PUT", path, map[string]interface{}{"splits": []interface{}{}})
+		require.Equal(t, std_http.StatusOK, rec.Code)
+
+		summary, err := cfService.GetCategorySummary(ctx, time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC), false)
+		require.NoError(t, err)
+		require.Len(t, summary, 1)
+		assert.Equal(t, "Custos Fixos", summary[0].CategoryName)
+		assert.Equal(t, money.MustParse("300.00"), summary[0].TotalAmount)
+	})
+}
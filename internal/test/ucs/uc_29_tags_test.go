@@ -0,0 +1,134 @@
+package ucs
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	std_http "net/http"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/adapters/http"
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/adapters/postgres"
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/domain/cashflow"
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/domain/category"
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/domain/tag"
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/money"
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/test/harness"
+)
+
+func TestUC29_Tags(t *testing.T) {
+	db := harness.SetupTestDB(t)
+	defer db.Pool.Close()
+
+	catRepo := postgres.NewCategoryRepository(db.Pool)
+	cfRepo := postgres.NewCashFlowRepository(db.Pool)
+	tagRepo := postgres.NewTagRepository(db.Pool)
+	cfService := cashflow.NewService(cfRepo, catRepo)
+	tagService := tag.NewService(tagRepo, cfRepo)
+
+	e := echo.New()
+	http.RegisterCashFlowRoutes(e, http.NewCashFlowHandler(cfService))
+	http.RegisterTagRoutes(e, http.NewTagHandler(tagService))
+	client := harness.NewHTTPClient(e)
+
+	ctx := context.Background()
+	travel, _ := catRepo.Create(ctx, &category.Category{Name: "Viagem", Direction: "OUT", IsActive: true})
+	food, _ := catRepo.Create(ctx, &category.Category{Name: "Alimentação", Direction: "OUT", IsActive: true})
+
+	hotel, err := cfService.CreateCashFlow(ctx, time.Date(2024, 7, 5, 0, 0, 0, 0, time.UTC), travel.ID, "OUT", "Hotel", money.MustParse("900.00"), false)
+	require.NoError(t, err)
+	dinner, err := cfService.Create(ctx, cashflow.CreateCashFlowRequest{
+		Date: time.Date(2024, 8, 2, 0, 0, 0, 0, time.UTC), CategoryID: travel.ID, Direction: "OUT", Title: "Restaurante", Amount: money.MustParse("200.00"),
+		Splits: []cashflow.Split{{CategoryID: travel.ID, Amount: money.MustParse("50.00")}, {CategoryID: food.ID, Amount: money.MustParse("150.00")}},
+	})
+	require.NoError(t, err)
+	_, err = cfService.CreateCashFlow(ctx, time.Date(2024, 7, 10, 0, 0, 0, 0, time.UTC), food.ID, "OUT", "Mercado", money.MustParse("300.00"), false)
+	require.NoError(t, err)
+
+	t.Run("Tag CRUD", func(t *testing.T) {
+		rec := client.Request(t, "POST", "/tags", map[string]interface{}{"name": "  Reforma Cozinha "})
+		require.Equal(t, std_http.StatusCreated, rec.Code, rec.Body.String())
+		var created map[string]interface{}
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &created))
+		assert.Equal(t, "reforma-cozinha", created["name"])
+		id := int32(created["id"].(float64))
+
+		rec = client.Request(t, "POST", "/tags", map[string]interface{}{"name": "reforma cozinha"})
+		assert.Equal(t, std_http.StatusConflict, rec.Code)
+
+		rec = client.Request(t, "POST", "/tags", map[string]interface{}{"name": "   "})
+		assert.Equal(t, std_http.StatusBadRequest, rec.Code)
+
+		rec = client.Request(t, "PUT", fmt.Sprintf("/tags/%d", id), map[string]interface{}{"name": "obra"})
+		require.Equal(t, std_http.StatusOK, rec.Code)
+
+		rec = client.Request(t, "DELETE", fmt.Sprintf("/tags/%d", id), nil)
+		require.Equal(t, std_http.StatusOK, rec.Code)
+
+		rec = client.Request(t, "DELETE", fmt.Sprintf("/tags/%d", id), nil)
+		assert.Equal(t, std_http.StatusNotFound, rec.Code)
+	})
+
+	t.Run("Tag cash flows", func(t *testing.T) {
+		rec := client.Request(t, "PUT", fmt.Sprintf("/cashflows/%d/tags", hotel.ID), map[string]interface{}{"tags": []string{"Viagem Chile", "ferias", "viagem chile"}})
+		require.Equal(t, std_http.StatusOK, rec.Code, rec.Body.String())
+		var tags []map[string]interface{}
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &tags))
+		require.Len(t, tags, 2)
+		assert.Equal(t, "ferias", tags[0]["name"])
+		assert.Equal(t, "viagem-chile", tags[1]["name"])
+
+		rec = client.Request(t, "PUT", fmt.Sprintf("/cashflows/%d/tags", dinner.ID), map[string]interface{}{"tags": []string{"viagem-chile"}})
+		require.Equal(t, std_http.StatusOK, rec.Code)
+
+		rec = client.Request(t, "PUT", "/cashflows/99999/tags", map[string]interface{}{"tags": []string{"x"}})
+		assert.Equal(t, std_http.StatusNotFound, rec.Code)
+
+		list, err := tagService.ListTags(ctx)
+		require.NoError(t, err)
+		require.Len(t, list, 2)
+		assert.Equal(t, "viagem-chile", list[1].Name)
+		assert.Equal(t, int64(2), list[1].CashFlowCount)
+	})
+
+	t.Run("Search by tag", func(t *testing.T) {
+		rec := client.Request(t, "GET", "/cashflows/search?tag=viagem-chile", nil)
+		require.Equal(t, std_http.StatusOK, rec.Code, rec.Body.String())
+		var page map[string]interface{}
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &page))
+		assert.Equal(t, 2.0, page["total"])
+
+		rec = client.Request(t, "GET", "/cashflows/search?tag=ferias", nil)
+		require.Equal(t, std_http.StatusOK, rec.Code)
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &page))
+		assert.Equal(t, 1.0, page["total"])
+	})
+
+	t.Run("Tag report", func(t *testing.T) {
+		rec := client.Request(t, "GET", "/reports/tags/Viagem%20Chile", nil)
+		require.Equal(t, std_http.StatusOK, rec.Code, rec.Body.String())
+		var report map[string]interface{}
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &report))
+		assert.Equal(t, 1100.0, report["total_expense"])
+
+		totals := map[string]float64{}
+		for _, c := range report["by_category"].([]interface{}) {
+			row := c.(map[string]interface{})
+			totals[row["category_name"].(string)] = row["total_amount"].(float64)
+		}
+		assert.Equal(t, map[string]float64{"Viagem": 950.0, "Alimentação": 150.0}, totals)
+
+		months := report["by_month"].([]interface{})
+		require.Len(t, months, 2)
+		assert.Equal(t, "2024-07-01", months[0].(map[string]interface{})["month"])
+		assert.Equal(t, 900.0, months[0].(map[string]interface{})["total_expense"])
+
+		rec = client.Request(t, "GET", "/reports/tags/desconhecida", nil)
+		assert.Equal(t, std_http.StatusNotFound, rec.Code)
+	})
+}
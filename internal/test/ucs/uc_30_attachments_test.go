@@ -0,0 +1,164 @@
+package ucs
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	std_http "net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/adapters/blobstore"
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/adapters/http"
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/adapters/postgres"
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/domain/attachment"
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/domain/cashflow"
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/domain/category"
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/domain/installment"
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/domain/payment"
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/money"
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/test/harness"
+)
+
+func TestUC30_Attachments(t *testing.T) {
+	db := harness.SetupTestDB(t)
+	defer db.Pool.Close()
+
+	dir := t.TempDir()
+	blobs, err := blobstore.NewLocalStore(dir)
+	require.NoError(t, err)
+
+	catRepo := postgres.NewCategoryRepository(db.Pool)
+	cfRepo := postgres.NewCashFlowRepository(db.Pool)
+	payRepo := postgres.NewPaymentRepository(db.Pool)
+	instRepo := postgres.NewInstallmentRepository(db.Pool)
+	attRepo := postgres.NewAttachmentRepository(db.Pool)
+	cfService := cashflow.NewService(cfRepo, catRepo)
+	instService := installment.NewService(instRepo, cfService, payRepo)
+	attService := attachment.NewService(attRepo, blobs, cfRepo, 1024)
+
+	e := echo.New()
+	http.RegisterAttachmentRoutes(e, http.NewAttachmentHandler(attService))
+	client := harness.NewHTTPClient(e)
+
+	ctx := context.Background()
+	outCat, _ := catRepo.Create(ctx, &category.Category{Name: "Saúde", Direction: "OUT", IsActive: true})
+	flow, err := cfService.CreateCashFlow(ctx, time.Date(2024, 3, 5, 0, 0, 0, 0, time.UTC), outCat.ID, "OUT", "Consulta", money.MustParse("250.00"), false)
+	require.NoError(t, err)
+
+	receipt := []byte("%PDF-1.4\n1 0 obj << /Type /Catalog >> endobj\n%%EOF\n")
+	var attachmentID int32
+	var hash string
+
+	t.Run("Upload sniffs type and deduplicates", func(t *testing.T) {
+		rec := client.Upload(t, fmt.Sprintf("/cashflows/%d/attachments", flow.ID), nil, "file", `C:\fakepath\recibo.pdf`, receipt)
+		require.Equal(t, std_http.StatusCreated, rec.Code, rec.Body.String())
+		var created map[string]interface{}
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &created))
+		attachmentID = int32(created["id"].(float64))
+		hash = created["sha256"].(string)
+		assert.Equal(t, "recibo.pdf", created["file_name"])
+		assert.Equal(t, "application/pdf", created["content_type"])
+		assert.Equal(t, "CASH_FLOW", created["owner_type"])
+		assert.Len(t, hash, 64)
+
+		rec = client.Upload(t, fmt.Sprintf("/cashflows/%d/attachments", flow.ID), nil, "file", "copia.pdf", receipt)
+		require.Equal(t, std_http.StatusOK, rec.Code)
+		var again map[string]interface{}
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &again))
+		assert.Equal(t, float64(attachmentID), again["id"])
+
+		_, err := os.Stat(filepath.Join(dir, hash[:2], hash))
+		assert.NoError(t, err)
+	})
+
+	t.Run("Invalid uploads", func(t *testing.T) {
+		path := fmt.Sprintf("/cashflows/%d/attachments", flow.ID)
+
+		rec := client.Upload(t, path, nil, "file", "nota.pdf", []byte{'P', 'K', 3, 4, 0, 0, 0, 0})
+		assert.Equal(t, std_http.StatusUnsupportedMediaType, rec.Code)
+
+		rec = client.Upload(t, path, nil, "file", "grande.txt", bytes.Repeat([]byte("a"), 1025))
+		assert.Equal(t, std_http.StatusRequestEntityTooLarge, rec.Code)
+
+		rec = client.Upload(t, path, nil, "file", "vazio.txt", nil)
+		assert.Equal(t, std_http.StatusBadRequest, rec.Code)
+
+		rec = client.Upload(t, "/cashflows/99999/attachments", nil, "file", "recibo.pdf", receipt)
+		assert.Equal(t, std_http.StatusNotFound, rec.Code)
+	})
+
+	t.Run("Installment plan shares the blob", func(t *testing.T) {
+		closingDay, dueDay := int32(1), int32(10)
+		card, err := payRepo.Create(ctx, &payment.PaymentMethod{Name: "Card", Kind: payment.KindCreditCard, ClosingDay: &closingDay, DueDay: &dueDay, IsActive: true})
+		require.NoError(t, err)
+		plan, err := instService.CreateInstallmentPurchase(ctx, "Óculos", money.MustParse("600.00"), 3, outCat.ID, card.ID, time.Date(2024, 3, 10, 0, 0, 0, 0, time.UTC))
+		require.NoError(t, err)
+
+		rec := client.Upload(t, fmt.Sprintf("/installments/%d/attachments", plan.ID), nil, "file", "nota.pdf", receipt)
+		require.Equal(t, std_http.StatusCreated, rec.Code, rec.Body.String())
+
+		rec = client.Request(t, "GET", fmt.Sprintf("/installments/%d/attachments", plan.ID), nil)
+		require.Equal(t, std_http.StatusOK, rec.Code)
+		var list []map[string]interface{}
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &list))
+		require.Len(t, list, 1)
+		assert.Equal(t, hash, list[0]["sha256"])
+
+		// The blob stays while the plan still references it.
+		rec = client.Request(t, "DELETE", fmt.Sprintf("/attachments/%d", attachmentID), nil)
+		require.Equal(t, std_http.StatusOK, rec.Code)
+		_, err = os.Stat(filepath.Join(dir, hash[:2], hash))
+		assert.NoError(t, err)
+
+		rec = client.Request(t, "GET", fmt.Sprintf("/attachments/%d", int32(list[0]["id"].(float64))), nil)
+		require.Equal(t, std_http.StatusOK, rec.Code)
+		assert.Equal(t, "application/pdf", rec.Header().Get("Content-Type"))
+		assert.Contains(t, rec.Header().Get("Content-Disposition"), "nota.pdf")
+		assert.Equal(t, receipt, rec.Body.Bytes())
+
+		rec = client.Request(t, "DELETE", fmt.Sprintf("/attachments/%d", int32(list[0]["id"].(float64))), nil)
+		require.Equal(t, std_http.StatusOK, rec.Code)
+		_, err = os.Stat(filepath.Join(dir, hash[:2], hash))
+		assert.True(t, os.IsNotExist(err))
+	})
+
+	t.Run("Deleted attachment is gone", func(t *testing.T) {
+		rec := client.Request(t, "GET", fmt.Sprintf("/attachments/%d", attachmentID), nil)
+		assert.Equal(t, std_http.StatusNotFound, rec.Code)
+
+		rec = client.Request(t, "GET", fmt.Sprintf("/cashflows/%d/attachments", flow.ID), nil)
+		require.Equal(t, std_http.StatusOK, rec.Code)
+		assert.JSONEq(t, "[]", rec.Body.String())
+	})
+
+	t.Run("Prune removes blobs of deleted cash flows", func(t *testing.T) {
+		other, err := cfService.CreateCashFlow(ctx, time.Date(2024, 3, 6, 0, 0, 0, 0, time.UTC), outCat.ID, "OUT", "Exame", money.MustParse("90.00"), false)
+		require.NoError(t, err)
+		rec := client.Upload(t, fmt.Sprintf("/cashflows/%d/attachments", other.ID), nil, "file", "exame.pdf", receipt)
+		require.Equal(t, std_http.StatusCreated, rec.Code, rec.Body.String())
+
+		// Nothing to remove while the record exists.
+		rec = client.Request(t, "POST", "/attachments/prune", nil)
+		require.Equal(t, std_http.StatusOK, rec.Code)
+		assert.JSONEq(t, `{"removed":0}`, rec.Body.String())
+
+		// The record goes with the cash flow, the blob stays until pruned.
+		require.NoError(t, cfService.DeleteCashFlow(ctx, other.ID))
+		_, err = os.Stat(filepath.Join(dir, hash[:2], hash))
+		require.NoError(t, err)
+
+		rec = client.Request(t, "POST", "/attachments/prune", nil)
+		require.Equal(t, std_http.StatusOK, rec.Code)
+		assert.JSONEq(t, `{"removed":1}`, rec.Body.String())
+		_, err = os.Stat(filepath.Join(dir, hash[:2], hash))
+		assert.True(t, os.IsNotExist(err))
+	})
+}
@@ -0,0 +1,168 @@
+package ucs
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	std_http "net/http"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/adapters/http"
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/adapters/postgres"
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/domain/account"
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/domain/cashflow"
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/domain/category"
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/domain/payment"
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/money"
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/test/harness"
+)
+
+func TestUC31_Accounts(t *testing.T) {
+	db := harness.SetupTestDB(t)
+	defer db.Pool.Close()
+
+	catRepo := postgres.NewCategoryRepository(db.Pool)
+	cfRepo := postgres.NewCashFlowRepository(db.Pool)
+	payRepo := postgres.NewPaymentRepository(db.Pool)
+	accRepo := postgres.NewAccountRepository(db.Pool)
+	cfService := cashflow.NewService(cfRepo, catRepo)
+	accService := account.NewService(accRepo, payRepo)
+
+	e := echo.New()
+	http.RegisterCashFlowRoutes(e, http.NewCashFlowHandler(cfService))
+	http.RegisterAccountRoutes(e, http.NewAccountHandler(accService))
+	client := harness.NewHTTPClient(e)
+
+	ctx := context.Background()
+	food, _ := catRepo.Create(ctx, &category.Category{Name: "Alimentação", Direction: "OUT", IsActive: true})
+	salary, _ := catRepo.Create(ctx, &category.Category{Name: "Salário", Direction: "IN", IsActive: true})
+	pix, err := payRepo.Create(ctx, &payment.PaymentMethod{Name: "Pix Nubank", Kind: payment.KindPix, IsActive: true})
+	require.NoError(t, err)
+	closingDay, dueDay := int32(5), int32(12)
+	card, err := payRepo.Create(ctx, &payment.PaymentMethod{Name: "Card", Kind: payment.KindCreditCard, ClosingDay: &closingDay, DueDay: &dueDay, IsActive: true})
+	require.NoError(t, err)
+
+	var checkingID, walletID int32
+	day := func(d int) time.Time { return time.Date(2024, 3, d, 0, 0, 0, 0, time.UTC) }
+
+	t.Run("Create accounts", func(t *testing.T) {
+		rec := client.Request(t, "POST", "/accounts", map[string]interface{}{
+			"name":            "Nubank",
+			"kind":            "CHECKING",
+			"institution":     "Nu Pagamentos",
+			"opening_balance": 1000.0,
+			"opening_date":    "2024-03-01",
+		})
+		require.Equal(t, std_http.StatusCreated, rec.Code, rec.Body.String())
+		var created map[string]interface{}
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &created))
+		checkingID = int32(created["id"].(float64))
+		assert.Equal(t, true, created["is_active"])
+
+		rec = client.Request(t, "POST", "/accounts", map[string]interface{}{
+			"name":         "Carteira",
+			"kind":         "WALLET",
+			"opening_date": "2024-03-01",
+		})
+		require.Equal(t, std_http.StatusCreated, rec.Code, rec.Body.String())
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &created))
+		walletID = int32(created["id"].(float64))
+
+		rec = client.Request(t, "POST", "/accounts", map[string]interface{}{"name": "nubank", "kind": "CHECKING", "opening_date": "2024-03-01"})
+		assert.Equal(t, std_http.StatusConflict, rec.Code)
+
+		rec = client.Request(t, "POST", "/accounts", map[string]interface{}{"name": "Cofre", "kind": "CRYPTO", "opening_date": "2024-03-01"})
+		assert.Equal(t, std_http.StatusBadRequest, rec.Code)
+	})
+
+	t.Run("Link payment methods", func(t *testing.T) {
+		rec := client.Request(t, "PUT", fmt.Sprintf("/payment-methods/%d/account", pix.ID), map[string]interface{}{"account_id": checkingID})
+		require.Equal(t, std_http.StatusOK, rec.Code, rec.Body.String())
+		var method map[string]interface{}
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &method))
+		assert.Equal(t, float64(checkingID), method["account_id"])
+
+		rec = client.Request(t, "PUT", fmt.Sprintf("/payment-methods/%d/account", card.ID), map[string]interface{}{"account_id": checkingID})
+		assert.Equal(t, std_http.StatusBadRequest, rec.Code)
+	})
+
+	t.Run("Cash flows land in accounts", func(t *testing.T) {
+		// Inherited from the payment method.
+		flow, err := cfService.Create(ctx, cashflow.CreateCashFlowRequest{
+			Date: day(5), CategoryID: food.ID, Direction: "OUT", Title: "Mercado", Amount: money.MustParse("200.00"), PaymentMethodID: &pix.ID,
+		})
+		require.NoError(t, err)
+		require.NotNil(t, flow.AccountID)
+		assert.Equal(t, checkingID, *flow.AccountID)
+
+		rec := client.Request(t, "POST", "/cashflows", map[string]interface{}{
+			"date": "2024-03-10", "category_id": salary.ID, "direction": "IN", "title": "Salário", "amount": 3000.0, "account_id": checkingID,
+		})
+		require.Equal(t, std_http.StatusCreated, rec.Code, rec.Body.String())
+
+		rec = client.Request(t, "POST", "/cashflows", map[string]interface{}{
+			"date": "2024-03-10", "category_id": food.ID, "direction": "OUT", "title": "Padaria", "amount": 30.0, "account_id": 9999,
+		})
+		assert.Equal(t, std_http.StatusBadRequest, rec.Code)
+
+		// Entered without an account and moved to the wallet afterwards.
+		loose, err := cfService.CreateCashFlow(ctx, day(10), food.ID, "OUT", "Feira", money.MustParse("50.00"), false)
+		require.NoError(t, err)
+		rec = client.Request(t, "PUT", fmt.Sprintf("/cashflows/%d/account", loose.ID), map[string]interface{}{"account_id": walletID})
+		require.Equal(t, std_http.StatusOK, rec.Code, rec.Body.String())
+		var moved map[string]interface{}
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &moved))
+		assert.Equal(t, float64(walletID), moved["account_id"])
+	})
+
+	t.Run("Balances", func(t *testing.T) {
+		rec := client.Request(t, "GET", "/accounts/balances?date=2024-03-31", nil)
+		require.Equal(t, std_http.StatusOK, rec.Code, rec.Body.String())
+		var res map[string]interface{}
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &res))
+		balances := map[string]float64{}
+		for _, a := range res["accounts"].([]interface{}) {
+			item := a.(map[string]interface{})
+			balances[item["name"].(string)] = item["balance"].(float64)
+		}
+		assert.Equal(t, map[string]float64{"Nubank": 3800.0, "Carteira": -50.0}, balances)
+		assert.Equal(t, 3750.0, res["total"])
+
+		rec = client.Request(t, "GET", "/accounts/balances?date=2024-03-07", nil)
+		require.Equal(t, std_http.StatusOK, rec.Code)
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &res))
+		assert.Equal(t, 800.0, res["total"])
+	})
+
+	t.Run("Running balance", func(t *testing.T) {
+		rec := client.Request(t, "GET", fmt.Sprintf("/accounts/%d/running-balance?from=2024-03-06&to=2024-03-31", checkingID), nil)
+		require.Equal(t, std_http.StatusOK, rec.Code, rec.Body.String())
+		var res map[string]interface{}
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &res))
+		assert.Equal(t, 800.0, res["start_balance"])
+		assert.Equal(t, 3800.0, res["end_balance"])
+		days := res["days"].([]interface{})
+		require.Len(t, days, 1)
+		assert.Equal(t, "2024-03-10", days[0].(map[string]interface{})["date"])
+
+		rec = client.Request(t, "GET", fmt.Sprintf("/accounts/%d/running-balance?from=2024-04-01&to=2024-03-01", checkingID), nil)
+		assert.Equal(t, std_http.StatusBadRequest, rec.Code)
+	})
+
+	t.Run("Accounts in use cannot be deleted", func(t *testing.T) {
+		rec := client.Request(t, "DELETE", fmt.Sprintf("/accounts/%d", checkingID), nil)
+		assert.Equal(t, std_http.StatusConflict, rec.Code)
+
+		rec = client.Request(t, "POST", "/accounts", map[string]interface{}{"name": "Poupança", "kind": "SAVINGS", "opening_date": "2024-03-01"})
+		require.Equal(t, std_http.StatusCreated, rec.Code)
+		var created map[string]interface{}
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &created))
+		rec = client.Request(t, "DELETE", fmt.Sprintf("/accounts/%d", int32(created["id"].(float64))), nil)
+		assert.Equal(t, std_http.StatusOK, rec.Code)
+	})
+}
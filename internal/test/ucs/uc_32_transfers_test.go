@@ -0,0 +1,146 @@
+package ucs
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	std_http "net/http"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/adapters/http"
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/adapters/postgres"
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/domain/account"
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/domain/cashflow"
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/domain/category"
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/domain/transfer"
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/money"
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/test/harness"
+)
+
+func TestUC32_Transfers(t *testing.T) {
+	db := harness.SetupTestDB(t)
+	defer db.Pool.Close()
+
+	catRepo := postgres.NewCategoryRepository(db.Pool)
+	cfRepo := postgres.NewCashFlowRepository(db.Pool)
+	payRepo := postgres.NewPaymentRepository(db.Pool)
+	accRepo := postgres.NewAccountRepository(db.Pool)
+	trRepo := postgres.NewTransferRepository(db.Pool)
+	cfService := cashflow.NewService(cfRepo, catRepo)
+	accService := account.NewService(accRepo, payRepo)
+	trService := transfer.NewService(trRepo, cfRepo, accRepo)
+
+	e := echo.New()
+	http.RegisterCashFlowRoutes(e, http.NewCashFlowHandler(cfService))
+	http.RegisterAccountRoutes(e, http.NewAccountHandler(accService))
+	http.RegisterTransferRoutes(e, http.NewTransferHandler(trService))
+	client := harness.NewHTTPClient(e)
+
+	ctx := context.Background()
+	march := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	salary, _ := catRepo.Create(ctx, &category.Category{Name: "Salário", Direction: "IN", IsActive: true})
+	checking, err := accService.CreateAccount(ctx, account.Account{Name: "Corrente", Kind: account.KindChecking, OpeningBalance: money.MustParse("500.00"), OpeningDate: march})
+	require.NoError(t, err)
+	savings, err := accService.CreateAccount(ctx, account.Account{Name: "Poupança", Kind: account.KindSavings, OpeningDate: march})
+	require.NoError(t, err)
+
+	_, err = cfService.Create(ctx, cashflow.CreateCashFlowRequest{
+		Date: time.Date(2024, 3, 5, 0, 0, 0, 0, time.UTC), CategoryID: salary.ID, Direction: "IN", Title: "Salário", Amount: money.MustParse("3000.00"), AccountID: &checking.ID,
+	})
+	require.NoError(t, err)
+
+	var transferID, outLegID int32
+
+	balances := func(t *testing.T) map[string]float64 {
+		rec := client.Request(t, "GET", "/accounts/balances?date=2024-03-31", nil)
+		require.Equal(t, std_http.StatusOK, rec.Code, rec.Body.String())
+		var res map[string]interface{}
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &res))
+		out := map[string]float64{}
+		for _, a := range res["accounts"].([]interface{}) {
+			item := a.(map[string]interface{})
+			out[item["name"].(string)] = item["balance"].(float64)
+		}
+		return out
+	}
+
+	t.Run("Create transfer", func(t *testing.T) {
+		rec := client.Request(t, "POST", "/transfers", map[string]interface{}{
+			"date":            "2024-03-06",
+			"amount":          1000.0,
+			"from_account_id": checking.ID,
+			"to_account_id":   savings.ID,
+		})
+		require.Equal(t, std_http.StatusCreated, rec.Code, rec.Body.String())
+		var created map[string]interface{}
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &created))
+		transferID = int32(created["id"].(float64))
+		outLegID = int32(created["out_cash_flow_id"].(float64))
+		assert.Equal(t, "Transferência", created["title"])
+		assert.Equal(t, "Corrente", created["from_account_name"])
+		assert.Equal(t, "Poupança", created["to_account_name"])
+
+		rec = client.Request(t, "POST", "/transfers", map[string]interface{}{
+			"date": "2024-03-06", "amount": 10.0, "from_account_id": checking.ID, "to_account_id": checking.ID,
+		})
+		assert.Equal(t, std_http.StatusBadRequest, rec.Code)
+	})
+
+	t.Run("Balances move, summaries do not", func(t *testing.T) {
+		assert.Equal(t, map[string]float64{"Corrente": 2500.0, "Poupança": 1000.0}, balances(t))
+
+		summary, err := cfService.GetMonthlySummary(ctx, march, false)
+		require.NoError(t, err)
+		assert.Equal(t, money.MustParse("3000.00"), summary.TotalIncome)
+		assert.True(t, summary.TotalExpense.IsZero())
+
+		categories, err := cfService.GetCategorySummary(ctx, march, false)
+		require.NoError(t, err)
+		require.Len(t, categories, 1)
+		assert.Equal(t, "Salário", categories[0].CategoryName)
+
+		// Both legs still show up in the statement.
+		flows, err := cfService.ListCashFlows(ctx, march)
+		require.NoError(t, err)
+		assert.Len(t, flows, 3)
+	})
+
+	t.Run("Legs only change through the transfer", func(t *testing.T) {
+		rec := client.Request(t, "DELETE", fmt.Sprintf("/cashflows/%d", outLegID), nil)
+		assert.Equal(t, std_http.StatusConflict, rec.Code)
+
+		rec = client.Request(t, "PUT", fmt.Sprintf("/cashflows/%d/account", outLegID), map[string]interface{}{"account_id": savings.ID})
+		assert.Equal(t, std_http.StatusConflict, rec.Code)
+
+		leg, err := cfService.ListCashFlows(ctx, march)
+		require.NoError(t, err)
+		var transferCategoryID int32
+		for _, f := range leg {
+			if f.ID == outLegID {
+				transferCategoryID = f.CategoryID
+			}
+		}
+		rec = client.Request(t, "POST", "/cashflows", map[string]interface{}{
+			"date": "2024-03-07", "category_id": transferCategoryID, "direction": "OUT", "title": "Manual", "amount": 50.0,
+		})
+		assert.Equal(t, std_http.StatusBadRequest, rec.Code)
+	})
+
+	t.Run("Delete transfer removes both legs", func(t *testing.T) {
+		rec := client.Request(t, "DELETE", fmt.Sprintf("/transfers/%d", transferID), nil)
+		require.Equal(t, std_http.StatusOK, rec.Code)
+
+		rec = client.Request(t, "GET", fmt.Sprintf("/transfers/%d", transferID), nil)
+		assert.Equal(t, std_http.StatusNotFound, rec.Code)
+
+		assert.Equal(t, map[string]float64{"Corrente": 3500.0, "Poupança": 0.0}, balances(t))
+		flows, err := cfService.ListCashFlows(ctx, march)
+		require.NoError(t, err)
+		assert.Len(t, flows, 1)
+	})
+}
@@ -0,0 +1,190 @@
+package ucs
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	std_http "net/http"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/adapters/http"
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/adapters/postgres"
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/domain/account"
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/domain/cashflow"
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/domain/category"
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/domain/payment"
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/domain/reconciliation"
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/money"
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/test/harness"
+)
+
+func TestUC33_Reconciliation(t *testing.T) {
+	db := harness.SetupTestDB(t)
+	defer db.Pool.Close()
+
+	catRepo := postgres.NewCategoryRepository(db.Pool)
+	cfRepo := postgres.NewCashFlowRepository(db.Pool)
+	payRepo := postgres.NewPaymentRepository(db.Pool)
+	accRepo := postgres.NewAccountRepository(db.Pool)
+	reconRepo := postgres.NewReconciliationRepository(db.Pool)
+	cfService := cashflow.NewService(cfRepo, catRepo)
+	accService := account.NewService(accRepo, payRepo)
+	reconService := reconciliation.NewService(reconRepo, accRepo, payRepo)
+
+	e := echo.New()
+	http.RegisterCashFlowRoutes(e, http.NewCashFlowHandler(cfService))
+	http.RegisterReconciliationRoutes(e, http.NewReconciliationHandler(reconService))
+	client := harness.NewHTTPClient(e)
+
+	ctx := context.Background()
+	day := func(d int) time.Time { return time.Date(2024, 3, d, 0, 0, 0, 0, time.UTC) }
+	food, _ := catRepo.Create(ctx, &category.Category{Name: "Alimentação", Direction: "OUT", IsActive: true})
+	salary, _ := catRepo.Create(ctx, &category.Category{Name: "Salário", Direction: "IN", IsActive: true})
+	pix, err := payRepo.Create(ctx, &payment.PaymentMethod{Name: "Pix", Kind: payment.KindPix, IsActive: true})
+	require.NoError(t, err)
+	checking, err := accService.CreateAccount(ctx, account.Account{Name: "Corrente", Kind: account.KindChecking, OpeningBalance: money.MustParse("1000.00"), OpeningDate: day(1)})
+	require.NoError(t, err)
+
+	create := func(d int, cat *category.Category, title string, amount money.Amount) int32 {
+		flow, err := cfService.Create(ctx, cashflow.CreateCashFlowRequest{
+			Date: day(d), CategoryID: cat.ID, Direction: cat.Direction, Title: title, Amount: amount, AccountID: &checking.ID,
+		})
+		require.NoError(t, err)
+		return flow.ID
+	}
+	salaryID := create(5, salary, "Salário", money.MustParse("3000.00"))
+	groceryID := create(10, food, "Mercado", money.MustParse("200.00"))
+	laterID := create(25, food, "Restaurante", money.MustParse("80.00"))
+
+	var reconID int32
+	worksheet := func(t *testing.T, body []byte) map[string]interface{} {
+		var res map[string]interface{}
+		require.NoError(t, json.Unmarshal(body, &res))
+		return res
+	}
+
+	t.Run("Start reconciliation", func(t *testing.T) {
+		rec := client.Request(t, "POST", "/reconciliations", map[string]interface{}{
+			"account_id":        checking.ID,
+			"statement_date":    "2024-03-20",
+			"statement_balance": 3800.0,
+		})
+		require.Equal(t, std_http.StatusCreated, rec.Code, rec.Body.String())
+		res := worksheet(t, rec.Body.Bytes())
+		reconID = int32(res["id"].(float64))
+		assert.Equal(t, "OPEN", res["status"])
+		assert.Equal(t, 1000.0, res["cleared_balance"])
+		assert.Equal(t, 2800.0, res["difference"])
+		// Flows after the statement date are not part of it.
+		assert.Len(t, res["items"].([]interface{}), 2)
+
+		rec = client.Request(t, "POST", "/reconciliations", map[string]interface{}{
+			"account_id": checking.ID, "statement_date": "2024-03-31", "statement_balance": 0.0,
+		})
+		assert.Equal(t, std_http.StatusConflict, rec.Code)
+
+		rec = client.Request(t, "POST", "/reconciliations", map[string]interface{}{
+			"payment_method_id": pix.ID, "statement_date": "2024-03-20", "statement_balance": 0.0,
+		})
+		assert.Equal(t, std_http.StatusBadRequest, rec.Code)
+	})
+
+	t.Run("Clear flows", func(t *testing.T) {
+		rec := client.Request(t, "POST", fmt.Sprintf("/reconciliations/%d/clear", reconID), map[string]interface{}{
+			"cash_flow_ids": []int32{salaryID},
+		})
+		require.Equal(t, std_http.StatusOK, rec.Code, rec.Body.String())
+		res := worksheet(t, rec.Body.Bytes())
+		assert.Equal(t, 4000.0, res["cleared_balance"])
+		assert.Equal(t, -200.0, res["difference"])
+
+		rec = client.Request(t, "POST", fmt.Sprintf("/reconciliations/%d/clear", reconID), map[string]interface{}{
+			"cash_flow_ids": []int32{laterID},
+		})
+		assert.Equal(t, std_http.StatusBadRequest, rec.Code)
+	})
+
+	t.Run("Complete requires a zero difference", func(t *testing.T) {
+		rec := client.Request(t, "POST", fmt.Sprintf("/reconciliations/%d/complete", reconID), nil)
+		assert.Equal(t, std_http.StatusConflict, rec.Code)
+
+		rec = client.Request(t, "POST", fmt.Sprintf("/reconciliations/%d/clear", reconID), map[string]interface{}{
+			"cash_flow_ids": []int32{groceryID},
+		})
+		require.Equal(t, std_http.StatusOK, rec.Code, rec.Body.String())
+		assert.Equal(t, 0.0, worksheet(t, rec.Body.Bytes())["difference"])
+
+		rec = client.Request(t, "POST", fmt.Sprintf("/reconciliations/%d/complete", reconID), nil)
+		require.Equal(t, std_http.StatusOK, rec.Code, rec.Body.String())
+		res := worksheet(t, rec.Body.Bytes())
+		assert.Equal(t, "COMPLETED", res["status"])
+		assert.Equal(t, 3800.0, res["cleared_balance"])
+		assert.NotEmpty(t, res["completed_at"])
+		assert.Len(t, res["items"].([]interface{}), 2)
+
+		rec = client.Request(t, "DELETE", fmt.Sprintf("/reconciliations/%d", reconID), nil)
+		assert.Equal(t, std_http.StatusConflict, rec.Code)
+	})
+
+	t.Run("Reconciled flows are locked", func(t *testing.T) {
+		rec := client.Request(t, "PUT", fmt.Sprintf("/cashflows/%d", groceryID), map[string]interface{}{
+			"date": "2024-03-10", "category_id": food.ID, "direction": "OUT", "title": "Mercado", "amount": 250.0,
+		})
+		assert.Equal(t, std_http.StatusConflict, rec.Code)
+
+		rec = client.Request(t, "DELETE", fmt.Sprintf("/cashflows/%d", groceryID), nil)
+		assert.Equal(t, std_http.StatusConflict, rec.Code)
+
+		rec = client.Request(t, "PUT", fmt.Sprintf("/cashflows/%d/splits", groceryID), map[string]interface{}{
+			"splits": []map[string]interface{}{{"category_id": food.ID, "amount": 250.0}},
+		})
+		assert.Equal(t, std_http.StatusConflict, rec.Code)
+
+		rec = client.Request(t, "GET", "/cashflows?month=2024-03-01", nil)
+		require.Equal(t, std_http.StatusOK, rec.Code, rec.Body.String())
+		var flows []map[string]interface{}
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &flows))
+		reconciled := map[float64]bool{}
+		for _, f := range flows {
+			reconciled[f["id"].(float64)] = f["reconciled"].(bool)
+		}
+		assert.Equal(t, map[float64]bool{float64(salaryID): true, float64(groceryID): true, float64(laterID): false}, reconciled)
+
+		// The next statement starts from the locked balance.
+		rec = client.Request(t, "POST", "/reconciliations", map[string]interface{}{
+			"account_id": checking.ID, "statement_date": "2024-03-31", "statement_balance": 3720.0,
+		})
+		require.Equal(t, std_http.StatusCreated, rec.Code, rec.Body.String())
+		res := worksheet(t, rec.Body.Bytes())
+		reconID = int32(res["id"].(float64))
+		assert.Equal(t, 3800.0, res["cleared_balance"])
+		assert.Len(t, res["items"].([]interface{}), 1)
+	})
+
+	t.Run("Cleared flows after an earlier statement date do not count", func(t *testing.T) {
+		// Cancelling keeps the flow of the 25th cleared.
+		rec := client.Request(t, "POST", fmt.Sprintf("/reconciliations/%d/clear", reconID), map[string]interface{}{
+			"cash_flow_ids": []int32{laterID},
+		})
+		require.Equal(t, std_http.StatusOK, rec.Code, rec.Body.String())
+		assert.Equal(t, 3720.0, worksheet(t, rec.Body.Bytes())["cleared_balance"])
+		rec = client.Request(t, "DELETE", fmt.Sprintf("/reconciliations/%d", reconID), nil)
+		require.Equal(t, std_http.StatusOK, rec.Code, rec.Body.String())
+
+		rec = client.Request(t, "POST", "/reconciliations", map[string]interface{}{
+			"account_id": checking.ID, "statement_date": "2024-03-24", "statement_balance": 3800.0,
+		})
+		require.Equal(t, std_http.StatusCreated, rec.Code, rec.Body.String())
+		res := worksheet(t, rec.Body.Bytes())
+		assert.Equal(t, 3800.0, res["cleared_balance"])
+		assert.Equal(t, 0.0, res["difference"])
+
+		rec = client.Request(t, "POST", fmt.Sprintf("/reconciliations/%d/complete", int32(res["id"].(float64))), nil)
+		require.Equal(t, std_http.StatusOK, rec.Code, rec.Body.String())
+	})
+}
@@ -0,0 +1,195 @@
+package ucs
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	std_http "net/http"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/adapters/http"
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/adapters/postgres"
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/domain/account"
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/domain/cashflow"
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/domain/category"
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/domain/installment"
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/domain/payment"
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/money"
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/test/harness"
+)
+
+func TestUC34_PlannedCashFlows(t *testing.T) {
+	db := harness.SetupTestDB(t)
+	defer db.Pool.Close()
+
+	catRepo := postgres.NewCategoryRepository(db.Pool)
+	cfRepo := postgres.NewCashFlowRepository(db.Pool)
+	payRepo := postgres.NewPaymentRepository(db.Pool)
+	accRepo := postgres.NewAccountRepository(db.Pool)
+	instRepo := postgres.NewInstallmentRepository(db.Pool)
+	cfService := cashflow.NewService(cfRepo, catRepo)
+	accService := account.NewService(accRepo, payRepo)
+	instService := installment.NewService(instRepo, cfService, payRepo)
+
+	e := echo.New()
+	http.RegisterCashFlowRoutes(e, http.NewCashFlowHandler(cfService))
+	http.RegisterAccountRoutes(e, http.NewAccountHandler(accService))
+	client := harness.NewHTTPClient(e)
+
+	ctx := context.Background()
+	food, _ := catRepo.Create(ctx, &category.Category{Name: "Alimentação", Direction: "OUT", IsActive: true})
+	salary, _ := catRepo.Create(ctx, &category.Category{Name: "Salário", Direction: "IN", IsActive: true})
+	checking, err := accService.CreateAccount(ctx, account.Account{Name: "Corrente", Kind: account.KindChecking, OpeningDate: time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)})
+	require.NoError(t, err)
+
+	_, err = cfService.Create(ctx, cashflow.CreateCashFlowRequest{
+		Date: time.Date(2024, 3, 5, 0, 0, 0, 0, time.UTC), CategoryID: salary.ID, Direction: "IN", Title: "Salário", Amount: money.MustParse("3000.00"), AccountID: &checking.ID,
+	})
+	require.NoError(t, err)
+
+	var plannedID, skippedID int32
+
+	summary := func(t *testing.T, includePlanned bool) map[string]interface{} {
+		rec := client.Request(t, "GET", fmt.Sprintf("/cashflows/summary?month=2024-03-01&include_planned=%t", includePlanned), nil)
+		require.Equal(t, std_http.StatusOK, rec.Code, rec.Body.String())
+		var res map[string]interface{}
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &res))
+		return res
+	}
+	balance := func(t *testing.T) float64 {
+		rec := client.Request(t, "GET", "/accounts/balances?date=2024-03-31", nil)
+		require.Equal(t, std_http.StatusOK, rec.Code, rec.Body.String())
+		var res map[string]interface{}
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &res))
+		return res["total"].(float64)
+	}
+
+	t.Run("Planned flows stay out of realized totals", func(t *testing.T) {
+		rec := client.Request(t, "POST", "/cashflows", map[string]interface{}{
+			"date": "2024-03-15", "category_id": food.ID, "direction": "OUT", "title": "Mercado do mês", "amount": 800.0, "account_id": checking.ID, "status": "PLANNED",
+		})
+		require.Equal(t, std_http.StatusCreated, rec.Code, rec.Body.String())
+		var created map[string]interface{}
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &created))
+		plannedID = int32(created["id"].(float64))
+		assert.Equal(t, "PLANNED", created["status"])
+
+		rec = client.Request(t, "POST", "/cashflows", map[string]interface{}{
+			"date": "2024-03-20", "category_id": food.ID, "direction": "OUT", "title": "Jantar", "amount": 150.0, "status": "PLANNED",
+		})
+		require.Equal(t, std_http.StatusCreated, rec.Code, rec.Body.String())
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &created))
+		skippedID = int32(created["id"].(float64))
+
+		rec = client.Request(t, "POST", "/cashflows", map[string]interface{}{
+			"date": "2024-03-20", "category_id": food.ID, "direction": "OUT", "title": "Jantar", "amount": 150.0, "status": "CANCELLED",
+		})
+		assert.Equal(t, std_http.StatusBadRequest, rec.Code)
+
+		assert.Equal(t, 0.0, summary(t, false)["total_expense"])
+		assert.Equal(t, 950.0, summary(t, true)["total_expense"])
+		assert.Equal(t, 3000.0, balance(t))
+
+		rec = client.Request(t, "GET", "/cashflows/search?status=PLANNED", nil)
+		require.Equal(t, std_http.StatusOK, rec.Code, rec.Body.String())
+		var page map[string]interface{}
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &page))
+		assert.Equal(t, 2.0, page["total"])
+	})
+
+	t.Run("Confirm with the actual date and amount", func(t *testing.T) {
+		rec := client.Request(t, "POST", fmt.Sprintf("/cashflows/%d/confirm", plannedID), map[string]interface{}{
+			"date": "2024-03-16", "amount": 812.4,
+		})
+		require.Equal(t, std_http.StatusOK, rec.Code, rec.Body.String())
+		var confirmed map[string]interface{}
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &confirmed))
+		assert.Equal(t, "REALIZED", confirmed["status"])
+		assert.Equal(t, "2024-03-16", confirmed["date"])
+		assert.Equal(t, 812.4, confirmed["amount"])
+
+		assert.Equal(t, 812.4, summary(t, false)["total_expense"])
+		assert.Equal(t, 2187.6, balance(t))
+
+		// The planned values are kept in the history.
+		revisions, err := cfService.ListRevisions(ctx, plannedID)
+		require.NoError(t, err)
+		require.Len(t, revisions, 1)
+		assert.Equal(t, money.MustParse("800.00"), revisions[0].Amount)
+		assert.Equal(t, cashflow.StatusPlanned, revisions[0].Status)
+
+		rec = client.Request(t, "POST", fmt.Sprintf("/cashflows/%d/confirm", plannedID), map[string]interface{}{})
+		assert.Equal(t, std_http.StatusConflict, rec.Code)
+	})
+
+	t.Run("Cancel a planned flow", func(t *testing.T) {
+		rec := client.Request(t, "POST", fmt.Sprintf("/cashflows/%d/cancel", skippedID), nil)
+		require.Equal(t, std_http.StatusOK, rec.Code, rec.Body.String())
+		var cancelled map[string]interface{}
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &cancelled))
+		assert.Equal(t, "CANCELLED", cancelled["status"])
+
+		assert.Equal(t, 812.4, summary(t, true)["total_expense"])
+
+		// The history shows the flow was planned before being cancelled.
+		rec = client.Request(t, "GET", fmt.Sprintf("/cashflows/%d/revisions", skippedID), nil)
+		require.Equal(t, std_http.StatusOK, rec.Code)
+		var revisions []map[string]interface{}
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &revisions))
+		require.Len(t, revisions, 1)
+		assert.Equal(t, "UPDATE", revisions[0]["action"])
+		assert.Equal(t, "PLANNED", revisions[0]["status"])
+
+		rec = client.Request(t, "POST", fmt.Sprintf("/cashflows/%d/confirm", skippedID), nil)
+		assert.Equal(t, std_http.StatusConflict, rec.Code)
+		rec = client.Request(t, "POST", fmt.Sprintf("/cashflows/%d/cancel", skippedID), nil)
+		assert.Equal(t, std_http.StatusConflict, rec.Code)
+	})
+
+	t.Run("Future installments start planned", func(t *testing.T) {
+		pix, err := payRepo.Create(ctx, &payment.PaymentMethod{Name: "Boleto", Kind: payment.KindPix, IsActive: true})
+		require.NoError(t, err)
+		now := time.Now().UTC()
+		today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+		_, err = instService.CreateInstallmentPurchase(ctx, "Curso", money.MustParse("300.00"), 3, food.ID, pix.ID, today)
+		require.NoError(t, err)
+
+		isFuture := func(id int32) bool {
+			var future bool
+			require.NoError(t, db.Pool.QueryRow(ctx, "SELECT is_future FROM expense_details WHERE cash_flow_id = $1", id).Scan(&future))
+			return future
+		}
+
+		var secondID int32
+		for i := 0; i < 3; i++ {
+			flows, err := cfService.ListCashFlows(ctx, today.AddDate(0, i, 0))
+			require.NoError(t, err)
+			var found bool
+			for _, f := range flows {
+				if f.Title == fmt.Sprintf("Curso (%d/3)", i+1) {
+					found = true
+					if i == 0 {
+						assert.Equal(t, cashflow.StatusRealized, f.Status)
+					} else {
+						assert.Equal(t, cashflow.StatusPlanned, f.Status)
+					}
+					// is_future mirrors the status.
+					assert.Equal(t, i > 0, isFuture(f.ID))
+					if i == 1 {
+						secondID = f.ID
+					}
+				}
+			}
+			assert.True(t, found, "installment %d", i+1)
+		}
+
+		_, err = cfService.ConfirmCashFlow(ctx, secondID, nil, nil)
+		require.NoError(t, err)
+		assert.False(t, isFuture(secondID))
+	})
+}
@@ -0,0 +1,96 @@
+package ucs
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	std_http "net/http"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/adapters/http"
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/adapters/postgres"
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/domain/cashflow"
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/domain/category"
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/domain/installment"
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/domain/payment"
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/money"
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/test/harness"
+)
+
+func TestUC35_MoneyPrecision(t *testing.T) {
+	db := harness.SetupTestDB(t)
+	defer db.Pool.Close()
+
+	catRepo := postgres.NewCategoryRepository(db.Pool)
+	cfRepo := postgres.NewCashFlowRepository(db.Pool)
+	payRepo := postgres.NewPaymentRepository(db.Pool)
+	instRepo := postgres.NewInstallmentRepository(db.Pool)
+	cfService := cashflow.NewService(cfRepo, catRepo)
+	instService := installment.NewService(instRepo, cfService, payRepo)
+
+	e := echo.New()
+	http.RegisterCashFlowRoutes(e, http.NewCashFlowHandler(cfService))
+	client := harness.NewHTTPClient(e)
+
+	ctx := context.Background()
+	food, _ := catRepo.Create(ctx, &category.Category{Name: "Alimentação", Direction: "OUT", IsActive: true})
+	pix, err := payRepo.Create(ctx, &payment.PaymentMethod{Name: "Boleto", Kind: payment.KindPix, IsActive: true})
+	require.NoError(t, err)
+
+	t.Run("Installments add up to the total", func(t *testing.T) {
+		plan, err := instService.CreateInstallmentPurchase(ctx, "Cadeira", money.MustParse("100.00"), 3, food.ID, pix.ID, time.Date(2024, 1, 10, 0, 0, 0, 0, time.UTC))
+		require.NoError(t, err)
+		assert.Equal(t, money.MustParse("33.33"), plan.InstallmentAmount)
+
+		var amounts []money.Amount
+		for i := 0; i < 3; i++ {
+			flows, err := cfService.ListCashFlows(ctx, time.Date(2024, time.Month(1+i), 1, 0, 0, 0, 0, time.UTC))
+			require.NoError(t, err)
+			for _, f := range flows {
+				if f.Title == fmt.Sprintf("Cadeira (%d/3)", i+1) {
+					amounts = append(amounts, f.Amount)
+				}
+			}
+		}
+		assert.Equal(t, []money.Amount{money.MustParse("33.34"), money.MustParse("33.33"), money.MustParse("33.33")}, amounts)
+		assert.Equal(t, plan.TotalAmount, money.Sum(amounts...))
+	})
+
+	t.Run("Sums are exact", func(t *testing.T) {
+		for i := 0; i < 3; i++ {
+			_, err := cfService.CreateCashFlow(ctx, time.Date(2024, 4, 2, 0, 0, 0, 0, time.UTC), food.ID, "OUT", "Café", money.MustParse("0.10"), false)
+			require.NoError(t, err)
+		}
+		summary, err := cfService.GetMonthlySummary(ctx, time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC), false)
+		require.NoError(t, err)
+		assert.Equal(t, money.MustParse("0.30"), summary.TotalExpense)
+		assert.Equal(t, money.MustParse("-0.30"), summary.Balance)
+	})
+
+	t.Run("Empty month sums to zero", func(t *testing.T) {
+		summary, err := cfService.GetMonthlySummary(ctx, time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC), false)
+		require.NoError(t, err)
+		assert.True(t, summary.TotalIncome.IsZero())
+		assert.True(t, summary.TotalExpense.IsZero())
+	})
+
+	t.Run("JSON amounts are read as decimals", func(t *testing.T) {
+		// 10.005 has no exact float64; read as a float it rounds down to 10.00.
+		rec := client.Request(t, "POST", "/cashflows", map[string]interface{}{
+			"date": "2024-05-03", "category_id": food.ID, "direction": "OUT", "title": "Pão", "amount": json.Number("10.005"),
+		})
+		require.Equal(t, std_http.StatusCreated, rec.Code, rec.Body.String())
+		assert.Contains(t, rec.Body.String(), `"amount":10.01`)
+
+		rec = client.Request(t, "GET", "/cashflows/search?min_amount=10.01&max_amount=10.01", nil)
+		require.Equal(t, std_http.StatusOK, rec.Code, rec.Body.String())
+		var res map[string]interface{}
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &res))
+		assert.Equal(t, float64(1), res["total"])
+	})
+}
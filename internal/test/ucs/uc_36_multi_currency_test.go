@@ -0,0 +1,258 @@
+package ucs
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	std_http "net/http"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/adapters/http"
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/adapters/http/dto"
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/adapters/postgres"
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/domain/cashflow"
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/domain/category"
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/domain/exchange"
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/domain/installment"
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/domain/payment"
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/money"
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/test/harness"
+)
+
+func TestUC36_MultiCurrency(t *testing.T) {
+	db := harness.SetupTestDB(t)
+	defer db.Pool.Close()
+
+	catRepo := postgres.NewCategoryRepository(db.Pool)
+	cfRepo := postgres.NewCashFlowRepository(db.Pool)
+	payRepo := postgres.NewPaymentRepository(db.Pool)
+	instRepo := postgres.NewInstallmentRepository(db.Pool)
+	exRepo := postgres.NewExchangeRepository(db.Pool)
+	cfService := cashflow.NewService(cfRepo, catRepo)
+	cfService.SetCurrency("BRL", 0.035)
+	instService := installment.NewService(instRepo, cfService, payRepo)
+	payService := payment.NewService(payRepo)
+	exService := exchange.NewService(exRepo, "BRL")
+
+	e := echo.New()
+	http.RegisterCashFlowRoutes(e, http.NewCashFlowHandler(cfService))
+	http.RegisterExchangeRoutes(e, http.NewExchangeHandler(exService))
+	client := harness.NewHTTPClient(e)
+
+	ctx := context.Background()
+	travel, _ := catRepo.Create(ctx, &category.Category{Name: "Viagem", Direction: "OUT", IsActive: true})
+	closingDay, dueDay := int32(1), int32(10)
+	card, err := payRepo.Create(ctx, &payment.PaymentMethod{Name: "Card", Kind: payment.KindCreditCard, ClosingDay: &closingDay, DueDay: &dueDay, IsActive: true})
+	require.NoError(t, err)
+
+	t.Run("Register and import rates", func(t *testing.T) {
+		rec := client.Request(t, std_http.MethodPost, "/exchange-rates", dto.ExchangeRateRequest{Currency: "usd", Date: "2024-06-01", Rate: 5})
+		require.Equal(t, std_http.StatusCreated, rec.Code, rec.Body.String())
+		var rate dto.ExchangeRateResponse
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &rate))
+		assert.Equal(t, "USD", rate.Currency)
+
+		csv := "moeda;data;cotação\nUSD;2024-06-15;5,50\nEUR;2024-06-01;6,00\n"
+		rec = client.Upload(t, "/exchange-rates/import", nil, "file", "cotacoes.csv", []byte(csv))
+		require.Equal(t, std_http.StatusCreated, rec.Code, rec.Body.String())
+		var imported dto.ExchangeRateImportResponse
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &imported))
+		assert.Equal(t, 2, imported.ImportedCount)
+
+		rec = client.Request(t, std_http.MethodGet, "/exchange-rates/effective?currency=USD&date=2024-06-20", nil)
+		require.Equal(t, std_http.StatusOK, rec.Code)
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &rate))
+		assert.Equal(t, 5.5, rate.Rate)
+		assert.Equal(t, "2024-06-15", rate.Date)
+	})
+
+	t.Run("Rates in the base currency are rejected", func(t *testing.T) {
+		rec := client.Request(t, std_http.MethodPost, "/exchange-rates", dto.ExchangeRateRequest{Currency: "BRL", Date: "2024-06-01", Rate: 1})
+		assert.Equal(t, std_http.StatusBadRequest, rec.Code)
+	})
+
+	t.Run("Foreign cash flows are converted at the rate of their date", func(t *testing.T) {
+		for _, tc := range []struct {
+			date     string
+			expected string
+			rate     float64
+		}{
+			{"2024-06-10", "50.00", 5},
+			{"2024-06-20", "55.00", 5.5},
+		} {
+			rec := client.Request(t, std_http.MethodPost, "/cashflows", dto.CreateCashFlowRequest{
+				Date: tc.date, CategoryID: travel.ID, Direction: "OUT", Title: "Museu", Amount: money.MustParse("10.00"), Currency: "USD",
+			})
+			require.Equal(t, std_http.StatusCreated, rec.Code, rec.Body.String())
+			var resp dto.CashFlowResponse
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+			assert.Equal(t, money.MustParse(tc.expected), resp.Amount)
+			assert.Equal(t, "USD", resp.Currency)
+			require.NotNil(t, resp.OriginalAmount)
+			assert.Equal(t, money.MustParse("10.00"), *resp.OriginalAmount)
+			require.NotNil(t, resp.ExchangeRate)
+			assert.Equal(t, tc.rate, *resp.ExchangeRate)
+			require.NotNil(t, resp.IOFAmount)
+			assert.True(t, resp.IOFAmount.IsZero())
+		}
+
+		summary, err := cfService.GetMonthlySummary(ctx, time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC), false)
+		require.NoError(t, err)
+		assert.Equal(t, money.MustParse("105.00"), summary.TotalExpense)
+	})
+
+	t.Run("Currency without a rate on the date is rejected", func(t *testing.T) {
+		rec := client.Request(t, std_http.MethodPost, "/cashflows", dto.CreateCashFlowRequest{
+			Date: "2024-05-10", CategoryID: travel.ID, Direction: "OUT", Title: "Hotel", Amount: money.MustParse("10.00"), Currency: "USD",
+		})
+		assert.Equal(t, std_http.StatusBadRequest, rec.Code)
+
+		rec = client.Request(t, std_http.MethodPost, "/cashflows", dto.CreateCashFlowRequest{
+			Date: "2024-06-10", CategoryID: travel.ID, Direction: "OUT", Title: "Hotel", Amount: money.MustParse("10.00"), Currency: "JPY",
+		})
+		assert.Equal(t, std_http.StatusBadRequest, rec.Code)
+	})
+
+	t.Run("Card purchases abroad pay IOF, split across installments", func(t *testing.T) {
+		plan, err := instService.CreatePurchase(ctx, installment.PurchaseRequest{
+			Description:     "Passagem",
+			TotalAmount:     money.MustParse("100.00"),
+			Count:           2,
+			CategoryID:      travel.ID,
+			PaymentMethodID: card.ID,
+			PurchaseDate:    time.Date(2024, 6, 16, 0, 0, 0, 0, time.UTC),
+			Currency:        "USD",
+		})
+		require.NoError(t, err)
+		require.NotNil(t, plan.Conversion)
+		assert.Equal(t, money.MustParse("569.25"), plan.TotalAmount)
+		assert.Equal(t, money.MustParse("19.25"), plan.Conversion.IOFAmount)
+
+		invoice, err := payService.GetInvoice(ctx, card.ID, time.Date(2024, 7, 1, 0, 0, 0, 0, time.UTC))
+		require.NoError(t, err)
+		require.Len(t, invoice.Entries, 1)
+		assert.Equal(t, money.MustParse("284.63"), invoice.Total)
+		assert.Equal(t, money.MustParse("9.63"), invoice.TotalIOF)
+		assert.Equal(t, "USD", invoice.Entries[0].Currency)
+		require.NotNil(t, invoice.Entries[0].OriginalAmount)
+		assert.Equal(t, money.MustParse("50.00"), *invoice.Entries[0].OriginalAmount)
+
+		invoice, err = payService.GetInvoice(ctx, card.ID, time.Date(2024, 8, 1, 0, 0, 0, 0, time.UTC))
+		require.NoError(t, err)
+		assert.Equal(t, money.MustParse("284.62"), invoice.Total)
+		assert.Equal(t, money.MustParse("9.62"), invoice.TotalIOF)
+	})
+
+	t.Run("Editing a foreign cash flow converts it again", func(t *testing.T) {
+		rec := client.Request(t, std_http.MethodPost, "/cashflows", dto.CreateCashFlowRequest{
+			Date: "2024-06-10", CategoryID: travel.ID, Direction: "OUT", Title: "Jantar", Amount: money.MustParse("10.00"), Currency: "USD",
+		})
+		require.Equal(t, std_http.StatusCreated, rec.Code, rec.Body.String())
+		var created dto.CashFlowResponse
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &created))
+		path := fmt.Sprintf("/cashflows/%d", created.ID)
+
+		// A new date takes the rate of that date, for the same 10 USD.
+		update := dto.UpdateCashFlowRequest{
+			Date: "2024-06-20", CategoryID: travel.ID, Direction: "OUT", Title: "Jantar", Amount: money.MustParse("50.00"),
+		}
+		rec = client.Request(t, std_http.MethodPut, path, update)
+		require.Equal(t, std_http.StatusOK, rec.Code, rec.Body.String())
+		var resp dto.CashFlowResponse
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+		assert.Equal(t, money.MustParse("55.00"), resp.Amount)
+		require.NotNil(t, resp.OriginalAmount)
+		assert.Equal(t, money.MustParse("10.00"), *resp.OriginalAmount)
+		require.NotNil(t, resp.ExchangeRate)
+		assert.Equal(t, 5.5, *resp.ExchangeRate)
+
+		// The amount in reais follows the original one.
+		update.Amount = money.MustParse("60.00")
+		rec = client.Request(t, std_http.MethodPut, path, update)
+		assert.Equal(t, std_http.StatusBadRequest, rec.Code)
+
+		update.Amount, update.Currency = money.MustParse("20.00"), "USD"
+		rec = client.Request(t, std_http.MethodPut, path, update)
+		require.Equal(t, std_http.StatusOK, rec.Code, rec.Body.String())
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+		assert.Equal(t, money.MustParse("110.00"), resp.Amount)
+		assert.Equal(t, "USD", resp.Currency)
+		require.NotNil(t, resp.OriginalAmount)
+		assert.Equal(t, money.MustParse("20.00"), *resp.OriginalAmount)
+
+		// The history keeps how each earlier version was converted.
+		rec = client.Request(t, std_http.MethodGet, path+"/revisions", nil)
+		require.Equal(t, std_http.StatusOK, rec.Code)
+		var revisions []dto.CashFlowRevisionResponse
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &revisions))
+		require.Len(t, revisions, 2)
+		first := revisions[1]
+		assert.Equal(t, money.MustParse("50.00"), first.Amount)
+		assert.Equal(t, "USD", first.Currency)
+		require.NotNil(t, first.OriginalAmount)
+		assert.Equal(t, money.MustParse("10.00"), *first.OriginalAmount)
+		require.NotNil(t, first.ExchangeRate)
+		assert.Equal(t, 5.0, *first.ExchangeRate)
+		require.NotNil(t, revisions[0].ExchangeRate)
+		assert.Equal(t, 5.5, *revisions[0].ExchangeRate)
+	})
+
+	t.Run("Confirming a foreign cash flow converts it at the actual date", func(t *testing.T) {
+		rec := client.Request(t, std_http.MethodPost, "/cashflows", dto.CreateCashFlowRequest{
+			Date: "2024-06-10", CategoryID: travel.ID, Direction: "OUT", Title: "Assinatura", Amount: money.MustParse("10.00"), Currency: "USD", Status: cashflow.StatusPlanned,
+		})
+		require.Equal(t, std_http.StatusCreated, rec.Code, rec.Body.String())
+		var planned dto.CashFlowResponse
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &planned))
+		assert.Equal(t, money.MustParse("50.00"), planned.Amount)
+
+		actual := money.MustParse("12.00")
+		rec = client.Request(t, std_http.MethodPost, fmt.Sprintf("/cashflows/%d/confirm", planned.ID), dto.ConfirmCashFlowRequest{Date: "2024-06-20", Amount: &actual})
+		require.Equal(t, std_http.StatusOK, rec.Code, rec.Body.String())
+		var confirmed dto.CashFlowResponse
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &confirmed))
+		assert.Equal(t, money.MustParse("66.00"), confirmed.Amount)
+		require.NotNil(t, confirmed.OriginalAmount)
+		assert.Equal(t, money.MustParse("12.00"), *confirmed.OriginalAmount)
+		require.NotNil(t, confirmed.ExchangeRate)
+		assert.Equal(t, 5.5, *confirmed.ExchangeRate)
+	})
+
+	t.Run("Copying a split foreign fixed flow rescales the splits", func(t *testing.T) {
+		food, _ := catRepo.Create(ctx, &category.Category{Name: "Alimentação", Direction: "OUT", IsActive: true})
+		rec := client.Request(t, std_http.MethodPost, "/exchange-rates", dto.ExchangeRateRequest{Currency: "USD", Date: "2024-08-01", Rate: 6})
+		require.Equal(t, std_http.StatusCreated, rec.Code, rec.Body.String())
+
+		// 10 USD at 5.50, split 33.00 and 22.00.
+		source, err := cfService.Create(ctx, cashflow.CreateCashFlowRequest{
+			Date: time.Date(2024, 7, 10, 0, 0, 0, 0, time.UTC), CategoryID: travel.ID, Direction: "OUT", Title: "Seguro viagem",
+			Amount: money.MustParse("10.00"), Currency: "USD", IsFixed: true,
+			Splits: []cashflow.Split{
+				{CategoryID: travel.ID, Amount: money.MustParse("33.00")},
+				{CategoryID: food.ID, Amount: money.MustParse("22.00")},
+			},
+		})
+		require.NoError(t, err)
+		require.Equal(t, money.MustParse("55.00"), source.Amount)
+
+		report, err := cfService.CopyFixedExpenses(ctx, time.Date(2024, 7, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 8, 1, 0, 0, 0, 0, time.UTC), false)
+		require.NoError(t, err)
+		require.Len(t, report.Created, 1)
+		assert.Equal(t, money.MustParse("60.00"), report.Created[0].Amount)
+
+		splits, err := cfService.ListSplits(ctx, *report.Created[0].CashFlowID)
+		require.NoError(t, err)
+		require.Len(t, splits, 2)
+		amounts := map[int32]money.Amount{}
+		for _, sp := range splits {
+			amounts[sp.CategoryID] = sp.Amount
+		}
+		assert.Equal(t, money.MustParse("36.00"), amounts[travel.ID])
+		assert.Equal(t, money.MustParse("24.00"), amounts[food.ID])
+	})
+}
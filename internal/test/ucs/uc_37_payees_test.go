@@ -0,0 +1,174 @@
+package ucs
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	std_http "net/http"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/adapters/http"
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/adapters/http/dto"
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/adapters/postgres"
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/domain/cashflow"
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/domain/category"
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/domain/payee"
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/money"
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/test/harness"
+)
+
+func TestUC37_Payees(t *testing.T) {
+	db := harness.SetupTestDB(t)
+	defer db.Pool.Close()
+
+	catRepo := postgres.NewCategoryRepository(db.Pool)
+	cfRepo := postgres.NewCashFlowRepository(db.Pool)
+	payRepo := postgres.NewPaymentRepository(db.Pool)
+	payeeRepo := postgres.NewPayeeRepository(db.Pool)
+	cfService := cashflow.NewService(cfRepo, catRepo)
+	payeeService := payee.NewService(payeeRepo, catRepo, payRepo)
+
+	e := echo.New()
+	http.RegisterCashFlowRoutes(e, http.NewCashFlowHandler(cfService))
+	http.RegisterPayeeRoutes(e, http.NewPayeeHandler(payeeService))
+	client := harness.NewHTTPClient(e)
+
+	ctx := context.Background()
+	transport, _ := catRepo.Create(ctx, &category.Category{Name: "Transporte", Direction: "OUT", IsActive: true})
+	food, _ := catRepo.Create(ctx, &category.Category{Name: "Alimentação", Direction: "OUT", IsActive: true})
+
+	var uber, uberDup dto.PayeeResponse
+
+	t.Run("Create payees", func(t *testing.T) {
+		rec := client.Request(t, std_http.MethodPost, "/payees", dto.PayeeRequest{
+			Name:              "Uber",
+			Aliases:           []string{"UBER *TRIP", "uber trip"},
+			DefaultCategoryID: &transport.ID,
+		})
+		require.Equal(t, std_http.StatusCreated, rec.Code, rec.Body.String())
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &uber))
+		assert.Equal(t, []string{"UBER *TRIP"}, uber.Aliases) // same key, kept once
+
+		rec = client.Request(t, std_http.MethodPost, "/payees", dto.PayeeRequest{Name: "Uber Eats BR"})
+		require.Equal(t, std_http.StatusCreated, rec.Code, rec.Body.String())
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &uberDup))
+	})
+
+	t.Run("Names and aliases belong to a single payee", func(t *testing.T) {
+		rec := client.Request(t, std_http.MethodPost, "/payees", dto.PayeeRequest{Name: "Uber do Brasil", Aliases: []string{"Uber-Trip"}})
+		assert.Equal(t, std_http.StatusConflict, rec.Code)
+
+		rec = client.Request(t, std_http.MethodPost, "/payees", dto.PayeeRequest{Name: " *** "})
+		assert.Equal(t, std_http.StatusBadRequest, rec.Code)
+	})
+
+	var flowID int32
+
+	t.Run("Cash flows are linked by title and take the default category", func(t *testing.T) {
+		rec := client.Request(t, std_http.MethodPost, "/cashflows", dto.CreateCashFlowRequest{
+			Date: "2024-03-05", Direction: "OUT", Title: "UBER *TRIP 1234", Amount: money.MustParse("25.00"),
+		})
+		require.Equal(t, std_http.StatusCreated, rec.Code, rec.Body.String())
+		var flow dto.CashFlowResponse
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &flow))
+		require.NotNil(t, flow.PayeeID)
+		assert.Equal(t, uber.ID, *flow.PayeeID)
+		assert.Equal(t, transport.ID, flow.CategoryID)
+		flowID = flow.ID
+
+		// The longest matching key wins.
+		rec = client.Request(t, std_http.MethodPost, "/cashflows", dto.CreateCashFlowRequest{
+			Date: "2024-04-10", CategoryID: food.ID, Direction: "OUT", Title: "Uber Eats BR pedido", Amount: money.MustParse("40.00"),
+		})
+		require.Equal(t, std_http.StatusCreated, rec.Code, rec.Body.String())
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &flow))
+		require.NotNil(t, flow.PayeeID)
+		assert.Equal(t, uberDup.ID, *flow.PayeeID)
+
+		// Titles that only contain the key inside a word do not match.
+		rec = client.Request(t, std_http.MethodGet, "/payees/match?title=Uberlandia", nil)
+		assert.Equal(t, std_http.StatusNotFound, rec.Code)
+	})
+
+	t.Run("Payee can be changed and removed from a cash flow", func(t *testing.T) {
+		rec := client.Request(t, std_http.MethodPut, fmt.Sprintf("/cashflows/%d/payee", flowID), dto.SetCashFlowPayeeRequest{})
+		require.Equal(t, std_http.StatusOK, rec.Code, rec.Body.String())
+		var flow dto.CashFlowResponse
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &flow))
+		assert.Nil(t, flow.PayeeID)
+
+		rec = client.Request(t, std_http.MethodPut, fmt.Sprintf("/cashflows/%d/payee", flowID), dto.SetCashFlowPayeeRequest{PayeeID: &uber.ID})
+		require.Equal(t, std_http.StatusOK, rec.Code, rec.Body.String())
+
+		missing := int32(999999)
+		rec = client.Request(t, std_http.MethodPut, fmt.Sprintf("/cashflows/%d/payee", flowID), dto.SetCashFlowPayeeRequest{PayeeID: &missing})
+		assert.Equal(t, std_http.StatusBadRequest, rec.Code)
+	})
+
+	t.Run("Merge duplicates", func(t *testing.T) {
+		rec := client.Request(t, std_http.MethodPost, fmt.Sprintf("/payees/%d/merge", uber.ID), dto.MergePayeesRequest{SourceIDs: []int32{uber.ID}})
+		assert.Equal(t, std_http.StatusBadRequest, rec.Code)
+
+		rec = client.Request(t, std_http.MethodPost, fmt.Sprintf("/payees/%d/merge", uber.ID), dto.MergePayeesRequest{SourceIDs: []int32{uberDup.ID}})
+		require.Equal(t, std_http.StatusOK, rec.Code, rec.Body.String())
+		var merged dto.PayeeResponse
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &merged))
+		assert.Contains(t, merged.Aliases, "Uber Eats BR")
+
+		rec = client.Request(t, std_http.MethodGet, fmt.Sprintf("/payees/%d", uberDup.ID), nil)
+		assert.Equal(t, std_http.StatusNotFound, rec.Code)
+
+		rec = client.Request(t, std_http.MethodGet, "/payees/match?title=UBER%20EATS%20BR%20*123", nil)
+		require.Equal(t, std_http.StatusOK, rec.Code)
+		var matched dto.PayeeResponse
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &matched))
+		assert.Equal(t, uber.ID, matched.ID)
+
+		rec = client.Request(t, std_http.MethodGet, fmt.Sprintf("/cashflows/search?payee_id=%d", uber.ID), nil)
+		require.Equal(t, std_http.StatusOK, rec.Code)
+		var page map[string]interface{}
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &page))
+		assert.Equal(t, 2.0, page["total"])
+	})
+
+	t.Run("Spending report and top payees", func(t *testing.T) {
+		rec := client.Request(t, std_http.MethodGet, fmt.Sprintf("/payees/%d/report?from=2024-01-01&to=2024-12-31", uber.ID), nil)
+		require.Equal(t, std_http.StatusOK, rec.Code, rec.Body.String())
+		var report dto.PayeeReportResponse
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &report))
+		assert.Equal(t, money.MustParse("65.00"), report.TotalExpense)
+		require.Len(t, report.ByCategory, 2)
+		assert.Equal(t, "Alimentação", report.ByCategory[0].CategoryName)
+		require.Len(t, report.ByMonth, 2)
+		assert.Equal(t, "2024-03-01", report.ByMonth[0].Month)
+
+		rec = client.Request(t, std_http.MethodGet, "/payees/999999/report", nil)
+		assert.Equal(t, std_http.StatusNotFound, rec.Code)
+
+		rec = client.Request(t, std_http.MethodGet, "/reports/payees?from=2024-01-01&to=2024-12-31&limit=5", nil)
+		require.Equal(t, std_http.StatusOK, rec.Code, rec.Body.String())
+		var top []dto.PayeeTotalResponse
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &top))
+		require.Len(t, top, 1)
+		assert.Equal(t, "Uber", top[0].Name)
+		assert.Equal(t, int64(2), top[0].CashFlowCount)
+
+		rec = client.Request(t, std_http.MethodGet, "/reports/payees?direction=SIDEWAYS", nil)
+		assert.Equal(t, std_http.StatusBadRequest, rec.Code)
+	})
+
+	t.Run("Deleting a payee keeps its cash flows", func(t *testing.T) {
+		rec := client.Request(t, std_http.MethodDelete, fmt.Sprintf("/payees/%d", uber.ID), nil)
+		require.Equal(t, std_http.StatusOK, rec.Code)
+
+		rec = client.Request(t, std_http.MethodGet, "/cashflows/search?title=uber", nil)
+		require.Equal(t, std_http.StatusOK, rec.Code)
+		var page map[string]interface{}
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &page))
+		assert.Equal(t, 2.0, page["total"])
+	})
+}
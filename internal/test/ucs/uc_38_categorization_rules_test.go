@@ -0,0 +1,239 @@
+package ucs
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	std_http "net/http"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/adapters/http"
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/adapters/http/dto"
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/adapters/postgres"
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/domain/cashflow"
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/domain/category"
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/domain/rule"
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/domain/tag"
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/money"
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/test/harness"
+)
+
+func TestUC38_CategorizationRules(t *testing.T) {
+	db := harness.SetupTestDB(t)
+	defer db.Pool.Close()
+
+	catRepo := postgres.NewCategoryRepository(db.Pool)
+	cfRepo := postgres.NewCashFlowRepository(db.Pool)
+	payRepo := postgres.NewPaymentRepository(db.Pool)
+	tagRepo := postgres.NewTagRepository(db.Pool)
+	ruleRepo := postgres.NewRuleRepository(db.Pool)
+	cfService := cashflow.NewService(cfRepo, catRepo)
+	ruleService := rule.NewService(ruleRepo, cfService, catRepo, payRepo, tagRepo)
+	cfService.SetRules(ruleService)
+	tagService := tag.NewService(tagRepo, cfRepo)
+
+	e := echo.New()
+	http.RegisterCashFlowRoutes(e, http.NewCashFlowHandler(cfService))
+	http.RegisterTagRoutes(e, http.NewTagHandler(tagService))
+	http.RegisterRuleRoutes(e, http.NewRuleHandler(ruleService))
+	client := harness.NewHTTPClient(e)
+
+	ctx := context.Background()
+	misc, _ := catRepo.Create(ctx, &category.Category{Name: "Diversos", Direction: "OUT", IsActive: true})
+	streaming, _ := catRepo.Create(ctx, &category.Category{Name: "Streaming", Direction: "OUT", IsActive: true})
+	market, _ := catRepo.Create(ctx, &category.Category{Name: "Mercado", Direction: "OUT", IsActive: true})
+
+	// Created before any rule exists, so only a retroactive run changes it.
+	old, err := cfService.CreateCashFlow(ctx, time.Date(2024, 1, 10, 0, 0, 0, 0, time.UTC), misc.ID, "OUT", "NETFLIX.COM", money.MustParse("39.90"), false)
+	require.NoError(t, err)
+
+	var netflix dto.RuleResponse
+	isFixed := true
+
+	t.Run("Invalid rules are rejected", func(t *testing.T) {
+		rec := client.Request(t, std_http.MethodPost, "/rules", dto.RuleRequest{Name: "Sem condição", SetCategoryID: &streaming.ID})
+		assert.Equal(t, std_http.StatusBadRequest, rec.Code)
+
+		rec = client.Request(t, std_http.MethodPost, "/rules", dto.RuleRequest{Name: "Regex ruim", TitlePattern: "netflix(", SetCategoryID: &streaming.ID})
+		assert.Equal(t, std_http.StatusBadRequest, rec.Code)
+
+		rec = client.Request(t, std_http.MethodPost, "/rules", dto.RuleRequest{Name: "Direção errada", TitlePattern: "netflix", Direction: "IN", SetCategoryID: &streaming.ID})
+		assert.Equal(t, std_http.StatusBadRequest, rec.Code)
+	})
+
+	t.Run("Create rules", func(t *testing.T) {
+		rec := client.Request(t, std_http.MethodPost, "/rules", dto.RuleRequest{
+			Name:          "Netflix",
+			TitlePattern:  `^netflix`,
+			SetCategoryID: &streaming.ID,
+			SetIsFixed:    &isFixed,
+			AddTags:       []string{"assinaturas"},
+		})
+		require.Equal(t, std_http.StatusCreated, rec.Code, rec.Body.String())
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &netflix))
+		assert.Equal(t, "OUT", netflix.Direction) // taken from the category
+		assert.Equal(t, "Streaming", netflix.SetCategoryName)
+
+		// Big supermarket runs win over the generic rule below because of
+		// their priority.
+		minAmount := money.MustParse("500.00")
+		rec = client.Request(t, std_http.MethodPost, "/rules", dto.RuleRequest{
+			Name: "Rancho", Priority: 1, TitlePattern: "mercado", MinAmount: &minAmount, SetCategoryID: &market.ID, AddTags: []string{"rancho"},
+		})
+		require.Equal(t, std_http.StatusCreated, rec.Code, rec.Body.String())
+		rec = client.Request(t, std_http.MethodPost, "/rules", dto.RuleRequest{
+			Name: "Genérico", Priority: 2, TitlePattern: "mercado", SetCategoryID: &misc.ID, AddTags: []string{"casa"},
+		})
+		require.Equal(t, std_http.StatusCreated, rec.Code, rec.Body.String())
+	})
+
+	t.Run("New cash flows are categorized by the rules", func(t *testing.T) {
+		rec := client.Request(t, std_http.MethodPost, "/cashflows", dto.CreateCashFlowRequest{
+			Date: "2024-02-10", Direction: "OUT", Title: "Netflix mensal", Amount: money.MustParse("39.90"),
+		})
+		require.Equal(t, std_http.StatusCreated, rec.Code, rec.Body.String())
+		var flow dto.CashFlowResponse
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &flow))
+		assert.Equal(t, streaming.ID, flow.CategoryID)
+		assert.True(t, flow.IsFixed)
+
+		rec = client.Request(t, std_http.MethodGet, fmt.Sprintf("/cashflows/%d/tags", flow.ID), nil)
+		require.Equal(t, std_http.StatusOK, rec.Code)
+		var tags []dto.TagResponse
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &tags))
+		require.Len(t, tags, 1)
+		assert.Equal(t, "assinaturas", tags[0].Name)
+
+		rec = client.Request(t, std_http.MethodPost, "/cashflows", dto.CreateCashFlowRequest{
+			Date: "2024-02-12", Direction: "OUT", Title: "Supermercado Big", Amount: money.MustParse("650.00"),
+		})
+		require.Equal(t, std_http.StatusCreated, rec.Code, rec.Body.String())
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &flow))
+		assert.Equal(t, market.ID, flow.CategoryID)
+
+		// Every matching rule adds its tags.
+		rec = client.Request(t, std_http.MethodGet, fmt.Sprintf("/cashflows/%d/tags", flow.ID), nil)
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &tags))
+		assert.Len(t, tags, 2)
+
+		rec = client.Request(t, std_http.MethodPost, "/cashflows", dto.CreateCashFlowRequest{
+			Date: "2024-02-13", Direction: "OUT", Title: "Mercadinho", Amount: money.MustParse("30.00"),
+		})
+		require.Equal(t, std_http.StatusCreated, rec.Code, rec.Body.String())
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &flow))
+		assert.Equal(t, misc.ID, flow.CategoryID)
+	})
+
+	t.Run("An explicit category wins over the rules", func(t *testing.T) {
+		rec := client.Request(t, std_http.MethodPost, "/cashflows", dto.CreateCashFlowRequest{
+			Date: "2024-02-15", CategoryID: misc.ID, Direction: "OUT", Title: "Netflix presente", Amount: money.MustParse("50.00"),
+		})
+		require.Equal(t, std_http.StatusCreated, rec.Code, rec.Body.String())
+		var flow dto.CashFlowResponse
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &flow))
+		assert.Equal(t, misc.ID, flow.CategoryID)
+	})
+
+	t.Run("Test lists the changes without saving", func(t *testing.T) {
+		rec := client.Request(t, std_http.MethodPost, "/rules/test?from=2024-01-01&to=2024-01-31", dto.RuleRequest{
+			Name: "Netflix", TitlePattern: `^netflix`, SetCategoryID: &streaming.ID, AddTags: []string{"novo"},
+		})
+		require.Equal(t, std_http.StatusOK, rec.Code, rec.Body.String())
+		var result dto.RuleApplyResponse
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &result))
+		assert.True(t, result.DryRun)
+		require.Equal(t, 1, result.ChangedCount)
+		assert.Equal(t, old.ID, result.Changes[0].CashFlowID)
+		assert.Equal(t, misc.ID, result.Changes[0].Before.CategoryID)
+		assert.Equal(t, streaming.ID, result.Changes[0].After.CategoryID)
+
+		rec = client.Request(t, std_http.MethodGet, "/tags", nil)
+		var tags []dto.TagResponse
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &tags))
+		for _, tg := range tags {
+			assert.NotEqual(t, "novo", tg.Name)
+		}
+
+		rec = client.Request(t, std_http.MethodPost, "/rules/test?from=2024-02-01&to=2024-01-01", dto.RuleRequest{
+			Name: "Netflix", TitlePattern: `^netflix`, SetCategoryID: &streaming.ID,
+		})
+		assert.Equal(t, std_http.StatusBadRequest, rec.Code)
+	})
+
+	t.Run("Apply changes existing cash flows", func(t *testing.T) {
+		rec := client.Request(t, std_http.MethodPost, fmt.Sprintf("/rules/%d/apply", netflix.ID), nil)
+		require.Equal(t, std_http.StatusOK, rec.Code, rec.Body.String())
+		var result dto.RuleApplyResponse
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &result))
+		assert.False(t, result.DryRun)
+		// The old flow and the one with an explicit category.
+		assert.Equal(t, 2, result.ChangedCount)
+
+		flow, err := cfRepo.GetByID(ctx, old.ID)
+		require.NoError(t, err)
+		assert.Equal(t, streaming.ID, flow.CategoryID)
+		assert.True(t, flow.IsFixed)
+
+		rec = client.Request(t, std_http.MethodGet, fmt.Sprintf("/cashflows/%d/revisions", old.ID), nil)
+		require.Equal(t, std_http.StatusOK, rec.Code)
+		var revisions []dto.CashFlowRevisionResponse
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &revisions))
+		assert.NotEmpty(t, revisions)
+
+		// Running it again finds nothing left to change.
+		rec = client.Request(t, std_http.MethodPost, fmt.Sprintf("/rules/%d/apply", netflix.ID), nil)
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &result))
+		assert.Equal(t, 0, result.ChangedCount)
+	})
+
+	t.Run("Inactive and deleted rules stop running", func(t *testing.T) {
+		inactive := false
+		rec := client.Request(t, std_http.MethodPut, fmt.Sprintf("/rules/%d", netflix.ID), dto.RuleRequest{
+			Name: "Netflix", IsActive: &inactive, TitlePattern: `^netflix`, SetCategoryID: &streaming.ID,
+		})
+		require.Equal(t, std_http.StatusOK, rec.Code, rec.Body.String())
+
+		rec = client.Request(t, std_http.MethodPost, "/cashflows", dto.CreateCashFlowRequest{
+			Date: "2024-03-10", CategoryID: misc.ID, Direction: "OUT", Title: "Netflix", Amount: money.MustParse("39.90"),
+		})
+		require.Equal(t, std_http.StatusCreated, rec.Code, rec.Body.String())
+		var flow dto.CashFlowResponse
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &flow))
+		assert.False(t, flow.IsFixed)
+
+		rec = client.Request(t, std_http.MethodDelete, fmt.Sprintf("/rules/%d", netflix.ID), nil)
+		require.Equal(t, std_http.StatusOK, rec.Code)
+		rec = client.Request(t, std_http.MethodGet, fmt.Sprintf("/rules/%d", netflix.ID), nil)
+		assert.Equal(t, std_http.StatusNotFound, rec.Code)
+	})
+
+	t.Run("Prepared rules are loaded once for a batch", func(t *testing.T) {
+		rec := client.Request(t, std_http.MethodPost, "/rules", dto.RuleRequest{Name: "Spotify", TitlePattern: "spotify", SetCategoryID: &streaming.ID})
+		require.Equal(t, std_http.StatusCreated, rec.Code, rec.Body.String())
+		var spotify dto.RuleResponse
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &spotify))
+
+		batch, err := cfService.PrepareRules(ctx)
+		require.NoError(t, err)
+		rec = client.Request(t, std_http.MethodDelete, fmt.Sprintf("/rules/%d", spotify.ID), nil)
+		require.Equal(t, std_http.StatusOK, rec.Code)
+
+		// The batch keeps the rules it was prepared with.
+		flow, err := cfService.Create(batch, cashflow.CreateCashFlowRequest{
+			Date: time.Date(2024, 3, 12, 0, 0, 0, 0, time.UTC), Direction: "OUT", Title: "Spotify", Amount: money.MustParse("21.90"),
+		})
+		require.NoError(t, err)
+		assert.Equal(t, streaming.ID, flow.CategoryID)
+
+		flow, err = cfService.Create(ctx, cashflow.CreateCashFlowRequest{
+			Date: time.Date(2024, 3, 12, 0, 0, 0, 0, time.UTC), CategoryID: misc.ID, Direction: "OUT", Title: "Spotify", Amount: money.MustParse("21.90"),
+		})
+		require.NoError(t, err)
+		assert.Equal(t, misc.ID, flow.CategoryID)
+	})
+}
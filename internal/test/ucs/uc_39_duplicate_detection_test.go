@@ -0,0 +1,121 @@
+package ucs
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	std_http "net/http"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/adapters/http"
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/adapters/http/dto"
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/adapters/postgres"
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/domain/cashflow"
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/domain/category"
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/domain/payment"
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/money"
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/test/harness"
+)
+
+func TestUC39_DuplicateDetection(t *testing.T) {
+	db := harness.SetupTestDB(t)
+	defer db.Pool.Close()
+
+	catRepo := postgres.NewCategoryRepository(db.Pool)
+	cfRepo := postgres.NewCashFlowRepository(db.Pool)
+	payRepo := postgres.NewPaymentRepository(db.Pool)
+	cfService := cashflow.NewService(cfRepo, catRepo)
+
+	e := echo.New()
+	http.RegisterCashFlowRoutes(e, http.NewCashFlowHandler(cfService))
+	client := harness.NewHTTPClient(e)
+
+	ctx := context.Background()
+	food, _ := catRepo.Create(ctx, &category.Category{Name: "Alimentação", Direction: "OUT", IsActive: true})
+	health, _ := catRepo.Create(ctx, &category.Category{Name: "Saúde", Direction: "OUT", IsActive: true})
+
+	create := func(date, title, amount string, allow bool, categoryID int32) *std_http.Response {
+		rec := client.Request(t, std_http.MethodPost, "/cashflows", dto.CreateCashFlowRequest{
+			Date: date, CategoryID: categoryID, Direction: "OUT", Title: title, Amount: money.MustParse(amount), AllowDuplicate: allow,
+		})
+		return rec.Result()
+	}
+
+	var original dto.CashFlowResponse
+
+	t.Run("Repeated entry is refused with the candidates", func(t *testing.T) {
+		rec := client.Request(t, std_http.MethodPost, "/cashflows", dto.CreateCashFlowRequest{
+			Date: "2024-05-10", CategoryID: food.ID, Direction: "OUT", Title: "Mercado Extra", Amount: money.MustParse("120.00"),
+		})
+		require.Equal(t, std_http.StatusCreated, rec.Code, rec.Body.String())
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &original))
+
+		rec = client.Request(t, std_http.MethodPost, "/cashflows", dto.CreateCashFlowRequest{
+			Date: "2024-05-12", CategoryID: food.ID, Direction: "OUT", Title: "MERCADO EXTRA 1234", Amount: money.MustParse("120.00"),
+		})
+		require.Equal(t, std_http.StatusConflict, rec.Code, rec.Body.String())
+		var conflict dto.DuplicateCashFlowErrorResponse
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &conflict))
+		require.Len(t, conflict.Candidates, 1)
+		assert.Equal(t, original.ID, conflict.Candidates[0].ID)
+		assert.Equal(t, "Alimentação", conflict.Candidates[0].CategoryName)
+	})
+
+	t.Run("Override creates it anyway", func(t *testing.T) {
+		assert.Equal(t, std_http.StatusCreated, create("2024-05-12", "MERCADO EXTRA 1234", "120.00", true, food.ID).StatusCode)
+	})
+
+	t.Run("Different title, amount or distant date is not a duplicate", func(t *testing.T) {
+		assert.Equal(t, std_http.StatusCreated, create("2024-05-11", "Farmácia Popular", "120.00", false, health.ID).StatusCode)
+		assert.Equal(t, std_http.StatusCreated, create("2024-05-10", "Mercado Extra", "121.00", false, food.ID).StatusCode)
+		assert.Equal(t, std_http.StatusCreated, create("2024-05-20", "Mercado Extra", "120.00", false, food.ID).StatusCode)
+	})
+
+	t.Run("Different payment methods are not duplicates", func(t *testing.T) {
+		pix, err := payRepo.Create(ctx, &payment.PaymentMethod{Name: "Pix", Kind: payment.KindPix, IsActive: true})
+		require.NoError(t, err)
+		other, err := payRepo.Create(ctx, &payment.PaymentMethod{Name: "Pix Inter", Kind: payment.KindPix, IsActive: true})
+		require.NoError(t, err)
+
+		req := cashflow.CreateCashFlowRequest{
+			Date: time.Date(2024, 6, 3, 0, 0, 0, 0, time.UTC), CategoryID: food.ID, Direction: "OUT", Title: "Padaria",
+			Amount: money.MustParse("15.00"), PaymentMethodID: &pix.ID, CheckDuplicates: true,
+		}
+		_, err = cfService.Create(ctx, req)
+		require.NoError(t, err)
+
+		_, err = cfService.Create(ctx, req)
+		var dup *cashflow.DuplicateError
+		require.True(t, errors.As(err, &dup))
+		assert.ErrorIs(t, err, cashflow.ErrPossibleDuplicate)
+
+		req.PaymentMethodID = &other.ID
+		_, err = cfService.Create(ctx, req)
+		require.NoError(t, err)
+	})
+
+	t.Run("Report lists suspected duplicates", func(t *testing.T) {
+		rec := client.Request(t, std_http.MethodGet, "/cashflows/duplicates?from=2024-05-01&to=2024-05-31", nil)
+		require.Equal(t, std_http.StatusOK, rec.Code, rec.Body.String())
+		var pairs []dto.CashFlowDuplicatePairResponse
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &pairs))
+		require.Len(t, pairs, 1)
+		assert.Equal(t, original.ID, pairs[0].First.ID)
+		assert.Equal(t, 2, pairs[0].DaysApart)
+		assert.Equal(t, 1.0, pairs[0].Similarity)
+
+		// A wider window also pairs the entry ten days later.
+		rec = client.Request(t, std_http.MethodGet, "/cashflows/duplicates?from=2024-05-01&to=2024-05-31&days=10", nil)
+		require.Equal(t, std_http.StatusOK, rec.Code)
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &pairs))
+		assert.Len(t, pairs, 3)
+
+		rec = client.Request(t, std_http.MethodGet, "/cashflows/duplicates?days=40", nil)
+		assert.Equal(t, std_http.StatusBadRequest, rec.Code)
+	})
+}
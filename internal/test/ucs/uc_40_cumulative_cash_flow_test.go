@@ -0,0 +1,127 @@
+package ucs
+
+import (
+	"context"
+	"encoding/json"
+	std_http "net/http"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/adapters/http"
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/adapters/http/dto"
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/adapters/postgres"
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/domain/cashflow"
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/domain/category"
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/domain/installment"
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/domain/payment"
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/domain/picuinha"
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/domain/report"
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/money"
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/test/harness"
+)
+
+func TestUC40_CumulativeCashFlow(t *testing.T) {
+	db := harness.SetupTestDB(t)
+	defer db.Pool.Close()
+
+	catRepo := postgres.NewCategoryRepository(db.Pool)
+	cfRepo := postgres.NewCashFlowRepository(db.Pool)
+	payRepo := postgres.NewPaymentRepository(db.Pool)
+	cfService := cashflow.NewService(cfRepo, catRepo)
+	instService := installment.NewService(postgres.NewInstallmentRepository(db.Pool), cfService, payRepo)
+	picService := picuinha.NewService(postgres.NewPicuinhaRepository(db.Pool))
+	reportService := report.NewService(postgres.NewReportRepository(db.Pool), catRepo, postgres.NewRecurrenceRepository(db.Pool))
+
+	e := echo.New()
+	http.RegisterReportRoutes(e, http.NewReportHandler(reportService))
+	client := harness.NewHTTPClient(e)
+
+	ctx := context.Background()
+	salary, _ := catRepo.Create(ctx, &category.Category{Name: "Salário", Direction: "IN", IsActive: true})
+	market, _ := catRepo.Create(ctx, &category.Category{Name: "Mercado", Direction: "OUT", IsActive: true})
+	pix, err := payRepo.Create(ctx, &payment.PaymentMethod{Name: "Pix", Kind: payment.KindPix, IsActive: true})
+	require.NoError(t, err)
+
+	create := func(date, direction string, categoryID int32, amount string) {
+		d, _ := time.Parse("2006-01-02", date)
+		_, err := cfService.Create(ctx, cashflow.CreateCashFlowRequest{
+			Date: d, CategoryID: categoryID, Direction: direction, Title: "Lançamento", Amount: money.MustParse(amount),
+		})
+		require.NoError(t, err)
+	}
+	create("2024-01-15", "IN", salary.ID, "1000.00")
+	create("2024-02-10", "OUT", market.ID, "300.00")
+
+	// A purchase paid for someone else, in two installments (Feb and Mar).
+	plan, err := instService.CreatePurchase(ctx, installment.PurchaseRequest{
+		Description: "Presente", TotalAmount: money.MustParse("200.00"), Count: 2,
+		CategoryID: market.ID, PaymentMethodID: pix.ID, PurchaseDate: time.Date(2024, 2, 20, 0, 0, 0, 0, time.UTC),
+	})
+	require.NoError(t, err)
+	person, err := picService.CreatePerson(ctx, "Ana", "")
+	require.NoError(t, err)
+	_, err = picService.CreateCase(ctx, picuinha.CreateCaseRequest{
+		PersonID: person.ID, Title: "Presente", CaseType: picuinha.CaseTypeCardInstall,
+		TotalAmount: money.MustParse("200.00"), InstallmentCount: 2, StartDate: time.Date(2024, 2, 20, 0, 0, 0, 0, time.UTC),
+		PaymentMethodID: &pix.ID, InstallmentPlanID: &plan.ID,
+	})
+	require.NoError(t, err)
+
+	t.Run("Running balance splits own money and picuinhas", func(t *testing.T) {
+		rec := client.Request(t, std_http.MethodGet, "/reports/cumulative?from=2024-02-01&to=2024-03-31", nil)
+		require.Equal(t, std_http.StatusOK, rec.Code, rec.Body.String())
+		var res dto.CumulativeResponse
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &res))
+
+		assert.Equal(t, "2024-02-01", res.From)
+		assert.Equal(t, "2024-03-01", res.To)
+		assert.Equal(t, "1000.00", res.OpeningBalance.String())
+		require.Len(t, res.Months, 2)
+
+		feb, mar := res.Months[0], res.Months[1]
+		assert.Equal(t, "2024-02-01", feb.Month)
+		assert.Equal(t, "400.00", feb.Expense.String())
+		assert.Equal(t, "600.00", feb.Balance.String())
+		assert.Equal(t, "300.00", feb.Own.Expense.String())
+		assert.Equal(t, "100.00", feb.Picuinha.Expense.String())
+
+		assert.Equal(t, "500.00", mar.Balance.String())
+		assert.Equal(t, "700.00", mar.Own.Balance.String())
+		assert.Equal(t, "-200.00", mar.Picuinha.Balance.String())
+	})
+
+	t.Run("Planned installments only when asked", func(t *testing.T) {
+		now := time.Now().UTC()
+		_, err := instService.CreatePurchase(ctx, installment.PurchaseRequest{
+			Description: "Geladeira", TotalAmount: money.MustParse("900.00"), Count: 3,
+			CategoryID: market.ID, PaymentMethodID: pix.ID, PurchaseDate: now,
+		})
+		require.NoError(t, err)
+
+		next := time.Date(now.Year(), now.Month()+1, 1, 0, 0, 0, 0, time.UTC).Format("2006-01-02")
+		path := "/reports/cumulative?from=" + next + "&to=" + next
+
+		var res dto.CumulativeResponse
+		rec := client.Request(t, std_http.MethodGet, path, nil)
+		require.Equal(t, std_http.StatusOK, rec.Code)
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &res))
+		require.Len(t, res.Months, 1)
+		assert.True(t, res.Months[0].Expense.IsZero())
+
+		rec = client.Request(t, std_http.MethodGet, path+"&include_installments=true", nil)
+		require.Equal(t, std_http.StatusOK, rec.Code)
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &res))
+		require.Len(t, res.Months, 1)
+		assert.Equal(t, "300.00", res.Months[0].Expense.String())
+		assert.Equal(t, "300.00", res.Months[0].PlannedInstallments.String())
+	})
+
+	t.Run("Inverted range is rejected", func(t *testing.T) {
+		rec := client.Request(t, std_http.MethodGet, "/reports/cumulative?from=2024-03-01&to=2024-02-01", nil)
+		assert.Equal(t, std_http.StatusBadRequest, rec.Code)
+	})
+}
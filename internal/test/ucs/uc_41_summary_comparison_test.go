@@ -0,0 +1,150 @@
+package ucs
+
+import (
+	"context"
+	"encoding/json"
+	std_http "net/http"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/adapters/http"
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/adapters/http/dto"
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/adapters/postgres"
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/domain/cashflow"
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/domain/category"
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/money"
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/test/harness"
+)
+
+func TestUC41_SummaryComparison(t *testing.T) {
+	db := harness.SetupTestDB(t)
+	defer db.Pool.Close()
+
+	catRepo := postgres.NewCategoryRepository(db.Pool)
+	cfRepo := postgres.NewCashFlowRepository(db.Pool)
+	cfService := cashflow.NewService(cfRepo, catRepo)
+
+	e := echo.New()
+	http.RegisterCashFlowRoutes(e, http.NewCashFlowHandler(cfService))
+	client := harness.NewHTTPClient(e)
+
+	ctx := context.Background()
+	salary, _ := catRepo.Create(ctx, &category.Category{Name: "Salário", Direction: "IN", IsActive: true})
+	market, _ := catRepo.Create(ctx, &category.Category{Name: "Mercado", Direction: "OUT", IsActive: true})
+	leisure, _ := catRepo.Create(ctx, &category.Category{Name: "Lazer", Direction: "OUT", IsActive: true})
+	travel, _ := catRepo.Create(ctx, &category.Category{Name: "Viagem", Direction: "OUT", IsActive: true})
+
+	create := func(date string, cat *category.Category, amount string) {
+		d, _ := time.Parse("2006-01-02", date)
+		_, err := cfService.Create(ctx, cashflow.CreateCashFlowRequest{
+			Date: d, CategoryID: cat.ID, Direction: cat.Direction, Title: cat.Name, Amount: money.MustParse(amount),
+		})
+		require.NoError(t, err)
+	}
+	create("2023-04-05", salary, "4000.00")
+	create("2023-04-10", market, "250.00")
+	create("2024-01-10", market, "300.00")
+	create("2024-02-10", market, "400.00")
+	create("2024-02-15", leisure, "100.00")
+	create("2024-03-05", salary, "5000.00")
+	create("2024-03-10", market, "500.00")
+	create("2024-03-15", leisure, "50.00")
+	create("2024-03-20", travel, "1000.00")
+	create("2024-04-05", salary, "5000.00")
+	create("2024-04-10", market, "600.00")
+	create("2024-04-15", leisure, "50.00")
+
+	categories := func(t *testing.T, mode string) map[string]dto.CategorySummaryResponse {
+		rec := client.Request(t, std_http.MethodGet, "/cashflows/category-summary?month=2024-04-01&compare="+mode, nil)
+		require.Equal(t, std_http.StatusOK, rec.Code, rec.Body.String())
+		var list []dto.CategorySummaryResponse
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &list))
+		byName := make(map[string]dto.CategorySummaryResponse)
+		for _, c := range list {
+			byName[c.CategoryName] = c
+		}
+		return byName
+	}
+
+	t.Run("Previous month", func(t *testing.T) {
+		rec := client.Request(t, std_http.MethodGet, "/cashflows/summary?month=2024-04-01&compare=previous_month", nil)
+		require.Equal(t, std_http.StatusOK, rec.Code, rec.Body.String())
+		var res dto.MonthlySummaryResponse
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &res))
+
+		assert.Equal(t, "650.00", res.TotalExpense.String())
+		require.NotNil(t, res.Comparison)
+		cmp := res.Comparison
+		assert.Equal(t, "2024-03-01", cmp.BaselineFrom)
+		assert.Equal(t, "2024-03-01", cmp.BaselineTo)
+		assert.Equal(t, "1550.00", cmp.TotalExpense.Baseline.String())
+		assert.Equal(t, "-900.00", cmp.TotalExpense.Delta.String())
+		require.NotNil(t, cmp.TotalExpense.ChangePct)
+		assert.Equal(t, -58.06, *cmp.TotalExpense.ChangePct)
+		assert.Equal(t, 0.0, *cmp.TotalIncome.ChangePct)
+
+		// Unchanged categories are never flagged.
+		require.Len(t, cmp.BiggestSwings, 2)
+		assert.Equal(t, "Viagem", cmp.BiggestSwings[0].CategoryName)
+		assert.Equal(t, "Mercado", cmp.BiggestSwings[1].CategoryName)
+
+		byName := categories(t, "previous_month")
+		require.Contains(t, byName, "Viagem")
+		assert.True(t, byName["Viagem"].TotalAmount.IsZero())
+		assert.Equal(t, -100.0, *byName["Viagem"].ChangePct)
+		assert.True(t, byName["Viagem"].BiggestSwing)
+		assert.Equal(t, 20.0, *byName["Mercado"].ChangePct)
+		assert.False(t, byName["Lazer"].BiggestSwing)
+	})
+
+	t.Run("Same month last year", func(t *testing.T) {
+		byName := categories(t, "previous_year")
+		assert.Equal(t, "250.00", byName["Mercado"].Baseline.String())
+		assert.Equal(t, 140.0, *byName["Mercado"].ChangePct)
+		assert.Equal(t, 25.0, *byName["Salário"].ChangePct)
+		// No baseline, no percentage.
+		assert.Nil(t, byName["Lazer"].ChangePct)
+		assert.Equal(t, "50.00", byName["Lazer"].Delta.String())
+	})
+
+	t.Run("Trailing average", func(t *testing.T) {
+		rec := client.Request(t, std_http.MethodGet, "/cashflows/summary?month=2024-04-01&compare=avg_3m", nil)
+		require.Equal(t, std_http.StatusOK, rec.Code)
+		var res dto.MonthlySummaryResponse
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &res))
+		assert.Equal(t, "2024-01-01", res.Comparison.BaselineFrom)
+		assert.Equal(t, "2024-03-01", res.Comparison.BaselineTo)
+		assert.Equal(t, 3, res.Comparison.Months)
+		assert.Equal(t, "1666.67", res.Comparison.TotalIncome.Baseline.String())
+
+		byName := categories(t, "avg_3m")
+		assert.Equal(t, "400.00", byName["Mercado"].Baseline.String())
+		assert.Equal(t, 50.0, *byName["Mercado"].ChangePct)
+		assert.Equal(t, "333.33", byName["Viagem"].Baseline.String())
+
+		rec = client.Request(t, std_http.MethodGet, "/cashflows/summary?month=2024-04-01&compare=avg_12m", nil)
+		require.Equal(t, std_http.StatusOK, rec.Code)
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &res))
+		assert.Equal(t, "2023-04-01", res.Comparison.BaselineFrom)
+		assert.Equal(t, 12, res.Comparison.Months)
+	})
+
+	t.Run("Without compare the response is unchanged", func(t *testing.T) {
+		rec := client.Request(t, std_http.MethodGet, "/cashflows/summary?month=2024-04-01", nil)
+		require.Equal(t, std_http.StatusOK, rec.Code)
+		assert.NotContains(t, rec.Body.String(), "comparison")
+
+		rec = client.Request(t, std_http.MethodGet, "/cashflows/category-summary?month=2024-04-01", nil)
+		require.Equal(t, std_http.StatusOK, rec.Code)
+		assert.NotContains(t, rec.Body.String(), "delta")
+	})
+
+	t.Run("Unknown mode is rejected", func(t *testing.T) {
+		rec := client.Request(t, std_http.MethodGet, "/cashflows/summary?month=2024-04-01&compare=last_week", nil)
+		assert.Equal(t, std_http.StatusBadRequest, rec.Code)
+	})
+}
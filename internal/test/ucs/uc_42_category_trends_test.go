@@ -0,0 +1,131 @@
+package ucs
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	std_http "net/http"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/adapters/http"
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/adapters/http/dto"
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/adapters/postgres"
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/domain/budget"
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/domain/cashflow"
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/domain/category"
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/domain/report"
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/money"
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/test/harness"
+)
+
+func TestUC42_CategoryTrends(t *testing.T) {
+	db := harness.SetupTestDB(t)
+	defer db.Pool.Close()
+
+	catRepo := postgres.NewCategoryRepository(db.Pool)
+	cfRepo := postgres.NewCashFlowRepository(db.Pool)
+	cfService := cashflow.NewService(cfRepo, catRepo)
+	budService := budget.NewService(postgres.NewBudgetRepository(db.Pool), catRepo, cfRepo)
+	reportService := report.NewService(postgres.NewReportRepository(db.Pool), catRepo, postgres.NewRecurrenceRepository(db.Pool))
+
+	e := echo.New()
+	http.RegisterReportRoutes(e, http.NewReportHandler(reportService))
+	client := harness.NewHTTPClient(e)
+
+	ctx := context.Background()
+	market, _ := catRepo.Create(ctx, &category.Category{Name: "Mercado", Direction: "OUT", IsActive: true})
+	leisure, _ := catRepo.Create(ctx, &category.Category{Name: "Lazer", Direction: "OUT", IsActive: true})
+	streaming, _ := catRepo.Create(ctx, &category.Category{Name: "Streaming", Direction: "OUT", IsActive: true})
+
+	series := map[*category.Category][]string{
+		market:    {"100.00", "200.00", "300.00", "400.00"},
+		leisure:   {"300.00", "200.00", "100.00", ""},
+		streaming: {"10.00", "20.00", "30.00", "40.00"},
+	}
+	for cat, amounts := range series {
+		for i, amount := range amounts {
+			if amount == "" {
+				continue
+			}
+			_, err := cfService.Create(ctx, cashflow.CreateCashFlowRequest{
+				Date: time.Date(2024, time.Month(i+1), 10, 0, 0, 0, 0, time.UTC), CategoryID: cat.ID,
+				Direction: "OUT", Title: cat.Name, Amount: money.MustParse(amount),
+			})
+			require.NoError(t, err)
+		}
+	}
+
+	// Set in February, the budget carries over to the months after it.
+	_, err := budService.SetBudgetItem(ctx, time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC), market.ID, budget.ModeAbsolute, money.MustParse("250.00"), 0)
+	require.NoError(t, err)
+
+	t.Run("History with budget, moving averages and trend", func(t *testing.T) {
+		rec := client.Request(t, std_http.MethodGet, fmt.Sprintf("/reports/categories/%d/history?from=2024-01-01&to=2024-04-30", market.ID), nil)
+		require.Equal(t, std_http.StatusOK, rec.Code, rec.Body.String())
+		var res dto.CategoryHistoryResponse
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &res))
+
+		assert.Equal(t, "Mercado", res.CategoryName)
+		assert.Equal(t, 100.0, res.TrendSlope)
+		require.Len(t, res.Months, 4)
+
+		jan, feb, apr := res.Months[0], res.Months[1], res.Months[3]
+		assert.Equal(t, "2024-01-01", jan.Month)
+		assert.Nil(t, jan.Planned)
+		assert.Nil(t, jan.ChangePct)
+		assert.Equal(t, "33.33", jan.MovingAverage3.String())
+		assert.Equal(t, "16.67", jan.MovingAverage6.String())
+
+		require.NotNil(t, feb.Planned)
+		assert.Equal(t, "250.00", feb.Planned.String())
+		assert.Equal(t, 100.0, *feb.ChangePct)
+
+		require.NotNil(t, apr.Planned)
+		assert.Equal(t, "250.00", apr.Planned.String())
+		assert.Equal(t, "300.00", apr.MovingAverage3.String())
+		assert.Equal(t, 33.33, *apr.ChangePct)
+	})
+
+	t.Run("Fastest growing expenses", func(t *testing.T) {
+		rec := client.Request(t, std_http.MethodGet, "/reports/categories/growth?from=2024-01-01&to=2024-04-30", nil)
+		require.Equal(t, std_http.StatusOK, rec.Code, rec.Body.String())
+		var res dto.GrowthResponse
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &res))
+
+		// Lazer is shrinking and is left out.
+		require.Len(t, res.Categories, 2)
+		first, second := res.Categories[0], res.Categories[1]
+		assert.Equal(t, "Mercado", first.CategoryName)
+		assert.Equal(t, 100.0, first.TrendSlope)
+		assert.Equal(t, "1000.00", first.Total.String())
+		assert.Equal(t, "250.00", first.MonthlyAverage.String())
+		assert.Equal(t, 40.0, first.GrowthPct)
+		require.NotNil(t, first.Planned)
+		assert.Equal(t, "750.00", first.Planned.String())
+
+		assert.Equal(t, "Streaming", second.CategoryName)
+		assert.Equal(t, 10.0, second.TrendSlope)
+		assert.Nil(t, second.Planned)
+
+		rec = client.Request(t, std_http.MethodGet, "/reports/categories/growth?from=2024-01-01&to=2024-04-30&limit=1", nil)
+		require.Equal(t, std_http.StatusOK, rec.Code)
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &res))
+		assert.Len(t, res.Categories, 1)
+	})
+
+	t.Run("Errors", func(t *testing.T) {
+		rec := client.Request(t, std_http.MethodGet, "/reports/categories/9999/history", nil)
+		assert.Equal(t, std_http.StatusNotFound, rec.Code)
+
+		rec = client.Request(t, std_http.MethodGet, "/reports/categories/growth?limit=0", nil)
+		assert.Equal(t, std_http.StatusBadRequest, rec.Code)
+
+		rec = client.Request(t, std_http.MethodGet, "/reports/categories/growth?limit=51", nil)
+		assert.Equal(t, std_http.StatusBadRequest, rec.Code)
+	})
+}
@@ -0,0 +1,173 @@
+package ucs
+
+import (
+	"context"
+	"encoding/json"
+	std_http "net/http"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/adapters/http"
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/adapters/http/dto"
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/adapters/postgres"
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/domain/cashflow"
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/domain/category"
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/domain/installment"
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/domain/payment"
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/domain/picuinha"
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/domain/recurrence"
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/domain/report"
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/money"
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/test/harness"
+)
+
+func TestUC43_Forecast(t *testing.T) {
+	db := harness.SetupTestDB(t)
+	defer db.Pool.Close()
+
+	catRepo := postgres.NewCategoryRepository(db.Pool)
+	cfRepo := postgres.NewCashFlowRepository(db.Pool)
+	payRepo := postgres.NewPaymentRepository(db.Pool)
+	recRepo := postgres.NewRecurrenceRepository(db.Pool)
+	cfService := cashflow.NewService(cfRepo, catRepo)
+	instService := installment.NewService(postgres.NewInstallmentRepository(db.Pool), cfService, payRepo)
+	picService := picuinha.NewService(postgres.NewPicuinhaRepository(db.Pool))
+	reportService := report.NewService(postgres.NewReportRepository(db.Pool), catRepo, recRepo)
+
+	e := echo.New()
+	http.RegisterReportRoutes(e, http.NewReportHandler(reportService))
+	client := harness.NewHTTPClient(e)
+
+	ctx := context.Background()
+	salary, _ := catRepo.Create(ctx, &category.Category{Name: "Salário", Direction: "IN", IsActive: true})
+	rent, _ := catRepo.Create(ctx, &category.Category{Name: "Aluguel", Direction: "OUT", IsActive: true})
+	leisure, _ := catRepo.Create(ctx, &category.Category{Name: "Lazer", Direction: "OUT", IsActive: true})
+	home, _ := catRepo.Create(ctx, &category.Category{Name: "Casa", Direction: "OUT", IsActive: true})
+	pix, err := payRepo.Create(ctx, &payment.PaymentMethod{Name: "Pix", Kind: payment.KindPix, IsActive: true})
+	require.NoError(t, err)
+
+	now := time.Now().UTC()
+	current := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+	day := func(months, d int) time.Time { return current.AddDate(0, months, d-1) }
+	create := func(req cashflow.CreateCashFlowRequest) {
+		_, err := cfService.Create(ctx, req)
+		require.NoError(t, err)
+	}
+
+	// Variable spending alternating 100 and 300: 200 a month on average.
+	for k := 1; k <= report.ForecastHistoryMonths; k++ {
+		amount := "100.00"
+		if k%2 == 0 {
+			amount = "300.00"
+		}
+		create(cashflow.CreateCashFlowRequest{Date: day(-k, 10), CategoryID: leisure.ID, Direction: "OUT", Title: "Bar", Amount: money.MustParse(amount)})
+	}
+	create(cashflow.CreateCashFlowRequest{Date: day(-1, 5), CategoryID: salary.ID, Direction: "IN", Title: "Salário", Amount: money.MustParse("10000.00")})
+	create(cashflow.CreateCashFlowRequest{Date: day(0, 1), CategoryID: rent.ID, Direction: "OUT", Title: "Aluguel", Amount: money.MustParse("1500.00"), IsFixed: true})
+	create(cashflow.CreateCashFlowRequest{Date: day(0, 28), CategoryID: home.ID, Direction: "OUT", Title: "Conserto", Amount: money.MustParse("300.00"), Status: cashflow.StatusPlanned})
+	// The rent was already copied into the second month, with a new amount.
+	create(cashflow.CreateCashFlowRequest{Date: day(2, 1), CategoryID: rent.ID, Direction: "OUT", Title: "Aluguel", Amount: money.MustParse("1600.00"), IsFixed: true, Status: cashflow.StatusPlanned})
+
+	// 200 now and 200 in each of the next two months.
+	_, err = instService.CreatePurchase(ctx, installment.PurchaseRequest{
+		Description: "Cadeira", TotalAmount: money.MustParse("600.00"), Count: 3,
+		CategoryID: home.ID, PaymentMethodID: pix.ID, PurchaseDate: now,
+	})
+	require.NoError(t, err)
+
+	_, err = recRepo.Create(ctx, &recurrence.Rule{
+		Title: "Salário", CategoryID: salary.ID, Direction: "IN", Amount: money.MustParse("5000.00"),
+		Frequency: recurrence.FrequencyMonthly, Interval: 1, BusinessDayAdjust: recurrence.AdjustNone,
+		StartDate: day(1, 5), IsActive: true,
+	})
+	require.NoError(t, err)
+
+	person, err := picService.CreatePerson(ctx, "Bruno", "")
+	require.NoError(t, err)
+	_, err = picService.CreateCase(ctx, picuinha.CreateCaseRequest{
+		PersonID: person.ID, Title: "Ingresso", CaseType: picuinha.CaseTypeOneOff,
+		TotalAmount: money.MustParse("250.00"), StartDate: day(1, 10),
+	})
+	require.NoError(t, err)
+
+	t.Run("Month by month projection", func(t *testing.T) {
+		rec := client.Request(t, std_http.MethodGet, "/reports/forecast?months=3", nil)
+		require.Equal(t, std_http.StatusOK, rec.Code, rec.Body.String())
+		var res dto.ForecastResponse
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &res))
+
+		assert.Equal(t, day(1, 1).Format("2006-01-02"), res.From)
+		assert.Equal(t, day(3, 1).Format("2006-01-02"), res.To)
+		assert.Equal(t, day(-6, 1).Format("2006-01-02"), res.HistoryFrom)
+		// 10000 - 1200 of leisure - 1500 of rent - 300 planned - 200 of the first installment.
+		assert.Equal(t, "6800.00", res.OpeningBalance.String())
+		require.Len(t, res.Variable, 1)
+		assert.Equal(t, "200.00", res.Variable[0].Average.String())
+		require.Len(t, res.Months, 3)
+
+		m1, m2, m3 := res.Months[0], res.Months[1], res.Months[2]
+		assert.Equal(t, "5000.00", m1.Sources.RecurringIncome.String())
+		assert.Equal(t, "250.00", m1.Sources.Receivables.String())
+		assert.Equal(t, "200.00", m1.Sources.Installments.String())
+		assert.Equal(t, "1500.00", m1.Sources.FixedExpense.String())
+		assert.Equal(t, "5250.00", m1.Income.String())
+		assert.Equal(t, "1900.00", m1.Expense.String())
+		assert.Equal(t, "10150.00", m1.Balance.String())
+
+		// The planned copy replaces the fixed rent.
+		assert.True(t, m2.Sources.FixedExpense.IsZero())
+		assert.Equal(t, "1600.00", m2.Sources.PlannedExpense.String())
+		assert.Equal(t, "2000.00", m2.Expense.String())
+		assert.Equal(t, "13150.00", m2.Balance.String())
+
+		assert.True(t, m3.Sources.Installments.IsZero())
+		assert.Equal(t, "1700.00", m3.Expense.String())
+		assert.Equal(t, "16450.00", m3.Balance.String())
+
+		// The pessimistic end leaves out the money owed by Bruno.
+		assert.Equal(t, "5000.00", m1.IncomeBand.Low.String())
+		assert.True(t, m1.ExpenseBand.High.Cmp(m1.Expense) > 0)
+		assert.True(t, m1.BalanceBand.Low.Cmp(m1.Balance.Sub(money.MustParse("250.00"))) < 0)
+		assert.True(t, m3.BalanceBand.High.Sub(m3.Balance).Cmp(m1.BalanceBand.High.Sub(m1.Balance)) > 0)
+		assert.Equal(t, res.From, res.LowestMonth)
+		assert.Equal(t, m1.BalanceBand.Low, res.LowestBalance)
+	})
+
+	t.Run("Materialized occurrences stay in their month", func(t *testing.T) {
+		recService := recurrence.NewService(recRepo, cfService, catRepo, payRepo)
+		result, err := recService.Materialize(ctx, day(1, 1), day(1, 1))
+		require.NoError(t, err)
+		require.Len(t, result.Created, 1)
+
+		flow, err := cfRepo.GetByID(ctx, *result.Created[0].CashFlowID)
+		require.NoError(t, err)
+		assert.Equal(t, cashflow.StatusPlanned, flow.Status)
+
+		rec := client.Request(t, std_http.MethodGet, "/reports/forecast?months=3", nil)
+		require.Equal(t, std_http.StatusOK, rec.Code, rec.Body.String())
+		var res dto.ForecastResponse
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &res))
+
+		// The salary moves from the recurring to the planned income of its
+		// month, and stays out of the opening balance.
+		assert.Equal(t, "6800.00", res.OpeningBalance.String())
+		m1 := res.Months[0]
+		assert.True(t, m1.Sources.RecurringIncome.IsZero())
+		assert.Equal(t, "5000.00", m1.Sources.PlannedIncome.String())
+		assert.Equal(t, "5250.00", m1.Income.String())
+		assert.Equal(t, "10150.00", m1.Balance.String())
+		assert.Equal(t, "5000.00", res.Months[1].Sources.RecurringIncome.String())
+	})
+
+	t.Run("Months out of range", func(t *testing.T) {
+		rec := client.Request(t, std_http.MethodGet, "/reports/forecast?months=0", nil)
+		assert.Equal(t, std_http.StatusBadRequest, rec.Code)
+
+		rec = client.Request(t, std_http.MethodGet, "/reports/forecast?months=37", nil)
+		assert.Equal(t, std_http.StatusBadRequest, rec.Code)
+	})
+}
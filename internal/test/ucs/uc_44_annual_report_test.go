@@ -0,0 +1,173 @@
+package ucs
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	std_http "net/http"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/adapters/http"
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/adapters/http/dto"
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/adapters/postgres"
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/domain/budget"
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/domain/cashflow"
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/domain/category"
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/domain/report"
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/money"
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/test/harness"
+)
+
+func TestUC44_AnnualReport(t *testing.T) {
+	db := harness.SetupTestDB(t)
+	defer db.Pool.Close()
+
+	catRepo := postgres.NewCategoryRepository(db.Pool)
+	cfRepo := postgres.NewCashFlowRepository(db.Pool)
+	cfService := cashflow.NewService(cfRepo, catRepo)
+	budService := budget.NewService(postgres.NewBudgetRepository(db.Pool), catRepo, cfRepo)
+	reportService := report.NewService(postgres.NewReportRepository(db.Pool), catRepo, postgres.NewRecurrenceRepository(db.Pool))
+
+	e := echo.New()
+	http.RegisterReportRoutes(e, http.NewReportHandler(reportService))
+	client := harness.NewHTTPClient(e)
+
+	ctx := context.Background()
+	salary, _ := catRepo.Create(ctx, &category.Category{Name: "Salário", Direction: "IN", IsActive: true})
+	market, _ := catRepo.Create(ctx, &category.Category{Name: "Mercado", Direction: "OUT", IsActive: true})
+	travel, _ := catRepo.Create(ctx, &category.Category{Name: "Viagem", Direction: "OUT", IsActive: true})
+
+	create := func(date string, cat *category.Category, amount string) {
+		d, _ := time.Parse("2006-01-02", date)
+		_, err := cfService.Create(ctx, cashflow.CreateCashFlowRequest{
+			Date: d, CategoryID: cat.ID, Direction: cat.Direction, Title: cat.Name, Amount: money.MustParse(amount),
+		})
+		require.NoError(t, err)
+	}
+	create("2023-01-05", salary, "5000.00")
+	create("2023-01-10", market, "300.00")
+	create("2023-02-05", salary, "5000.00")
+	create("2023-03-10", market, "500.00")
+	create("2024-01-10", market, "999.00")
+
+	// Set in February, the budgets carry over to the rest of the year.
+	feb := time.Date(2023, 2, 1, 0, 0, 0, 0, time.UTC)
+	_, err := budService.SetBudgetItem(ctx, feb, market.ID, budget.ModeAbsolute, money.MustParse("400.00"), 0)
+	require.NoError(t, err)
+	_, err = budService.SetBudgetItem(ctx, feb, travel.ID, budget.ModeAbsolute, money.MustParse("200.00"), 0)
+	require.NoError(t, err)
+
+	t.Run("Realized grid", func(t *testing.T) {
+		rec := client.Request(t, std_http.MethodGet, "/reports/annual/2023", nil)
+		require.Equal(t, std_http.StatusOK, rec.Code, rec.Body.String())
+		var res dto.AnnualReportResponse
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &res))
+
+		assert.Equal(t, 2023, res.Year)
+		assert.Equal(t, 12, res.AverageMonths)
+		require.Len(t, res.Income.Categories, 1)
+		assert.Equal(t, "10000.00", res.Income.Total.Total.Actual.String())
+		assert.Equal(t, "833.33", res.Income.Total.Average.Actual.String())
+
+		// Viagem only has a budget.
+		require.Len(t, res.Expense.Categories, 1)
+		m := res.Expense.Categories[0]
+		assert.Equal(t, "Mercado", m.CategoryName)
+		require.Len(t, m.Months, 12)
+		assert.Equal(t, "300.00", m.Months[0].Actual.String())
+		assert.True(t, m.Months[1].Actual.IsZero())
+		assert.Nil(t, m.Months[1].Planned)
+		assert.Equal(t, "800.00", m.Total.Actual.String())
+		assert.Equal(t, "66.67", m.Average.Actual.String())
+
+		assert.Equal(t, "4700.00", res.Net.Months[0].Actual.String())
+		assert.Equal(t, "-500.00", res.Net.Months[2].Actual.String())
+		assert.Equal(t, "9200.00", res.Net.Total.Actual.String())
+	})
+
+	t.Run("Budget alongside", func(t *testing.T) {
+		rec := client.Request(t, std_http.MethodGet, "/reports/annual/2023?include_budget=true", nil)
+		require.Equal(t, std_http.StatusOK, rec.Code, rec.Body.String())
+		var res dto.AnnualReportResponse
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &res))
+
+		require.Len(t, res.Expense.Categories, 2)
+		m, v := res.Expense.Categories[0], res.Expense.Categories[1]
+		assert.Nil(t, m.Months[0].Planned)
+		require.NotNil(t, m.Months[2].Planned)
+		assert.Equal(t, "400.00", m.Months[2].Planned.String())
+		assert.Equal(t, "4400.00", m.Total.Planned.String())
+		assert.Equal(t, "366.67", m.Average.Planned.String())
+
+		assert.Equal(t, "Viagem", v.CategoryName)
+		assert.True(t, v.Total.Actual.IsZero())
+		assert.Equal(t, "2200.00", v.Total.Planned.String())
+		assert.Equal(t, "600.00", res.Expense.Total.Months[1].Planned.String())
+	})
+
+	t.Run("CSV download", func(t *testing.T) {
+		rec := client.Request(t, std_http.MethodGet, "/reports/annual/2023.csv", nil)
+		require.Equal(t, std_http.StatusOK, rec.Code, rec.Body.String())
+		assert.Contains(t, rec.Header().Get("Content-Type"), "text/csv")
+		assert.Contains(t, rec.Header().Get("Content-Disposition"), "relatorio-anual-2023.csv")
+
+		records, err := csv.NewReader(rec.Body).ReadAll()
+		require.NoError(t, err)
+		// Header, Salário, income total, Mercado, expense total and net.
+		require.Len(t, records, 6)
+		assert.Equal(t, []string{"Direção", "Categoria", "Jan"}, records[0][:3])
+		assert.Equal(t, []string{"Total", "Média"}, records[0][14:])
+		assert.Equal(t, []string{"OUT", "Mercado", "300.00", "0.00", "500.00"}, records[3][:5])
+		assert.Equal(t, "800.00", records[3][14])
+		assert.Equal(t, []string{"", "Saldo"}, records[5][:2])
+
+		rec = client.Request(t, std_http.MethodGet, "/reports/annual/2023.csv?include_budget=true", nil)
+		require.Equal(t, std_http.StatusOK, rec.Code)
+		records, err = csv.NewReader(rec.Body).ReadAll()
+		require.NoError(t, err)
+		assert.Equal(t, []string{"Jan realizado", "Jan orçado"}, records[0][2:4])
+		assert.Equal(t, []string{"300.00", "", "0.00", "400.00"}, records[3][2:6])
+	})
+
+	t.Run("XLSX download", func(t *testing.T) {
+		rec := client.Request(t, std_http.MethodGet, "/reports/annual/2023.xlsx", nil)
+		require.Equal(t, std_http.StatusOK, rec.Code, rec.Body.String())
+		assert.Contains(t, rec.Header().Get("Content-Disposition"), "relatorio-anual-2023.xlsx")
+
+		body := rec.Body.Bytes()
+		z, err := zip.NewReader(bytes.NewReader(body), int64(len(body)))
+		require.NoError(t, err)
+		var sheet []byte
+		for _, f := range z.File {
+			if f.Name == "xl/worksheets/sheet1.xml" {
+				r, err := f.Open()
+				require.NoError(t, err)
+				sheet, err = io.ReadAll(r)
+				require.NoError(t, err)
+				r.Close()
+			}
+		}
+		require.NotNil(t, sheet)
+		assert.Contains(t, string(sheet), "Mercado")
+		assert.Contains(t, string(sheet), "<v>800</v>")
+	})
+
+	t.Run("Invalid year or format", func(t *testing.T) {
+		rec := client.Request(t, std_http.MethodGet, "/reports/annual/abc", nil)
+		assert.Equal(t, std_http.StatusBadRequest, rec.Code)
+
+		rec = client.Request(t, std_http.MethodGet, "/reports/annual/1800", nil)
+		assert.Equal(t, std_http.StatusBadRequest, rec.Code)
+
+		rec = client.Request(t, std_http.MethodGet, "/reports/annual/2023.pdf", nil)
+		assert.Equal(t, std_http.StatusBadRequest, rec.Code)
+	})
+}
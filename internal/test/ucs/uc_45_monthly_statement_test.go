@@ -0,0 +1,123 @@
+package ucs
+
+import (
+	"bytes"
+	"context"
+	std_http "net/http"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/adapters/http"
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/adapters/postgres"
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/domain/budget"
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/domain/cashflow"
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/domain/category"
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/domain/payment"
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/domain/picuinha"
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/domain/report"
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/money"
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/test/harness"
+)
+
+func TestUC45_MonthlyStatement(t *testing.T) {
+	db := harness.SetupTestDB(t)
+	defer db.Pool.Close()
+
+	catRepo := postgres.NewCategoryRepository(db.Pool)
+	cfRepo := postgres.NewCashFlowRepository(db.Pool)
+	payRepo := postgres.NewPaymentRepository(db.Pool)
+	cfService := cashflow.NewService(cfRepo, catRepo)
+	budService := budget.NewService(postgres.NewBudgetRepository(db.Pool), catRepo, cfRepo)
+	payService := payment.NewService(payRepo)
+	picService := picuinha.NewService(postgres.NewPicuinhaRepository(db.Pool))
+	statementService := report.NewStatementService(cfService, budService, payService, picService)
+
+	e := echo.New()
+	http.RegisterStatementRoutes(e, http.NewStatementHandler(statementService))
+	client := harness.NewHTTPClient(e)
+
+	ctx := context.Background()
+	salary, _ := catRepo.Create(ctx, &category.Category{Name: "Salário", Direction: "IN", IsActive: true})
+	market, _ := catRepo.Create(ctx, &category.Category{Name: "Mercado", Direction: "OUT", IsActive: true})
+	leisure, _ := catRepo.Create(ctx, &category.Category{Name: "Lazer", Direction: "OUT", IsActive: true})
+
+	closingDay, dueDay := int32(1), int32(10)
+	card, err := payService.CreatePaymentMethod(ctx, "Nubank", payment.KindCreditCard, "Nubank", nil, &closingDay, &dueDay)
+	require.NoError(t, err)
+	_, err = payService.CreatePaymentMethod(ctx, "Inter", payment.KindCreditCard, "Inter", nil, &closingDay, &dueDay)
+	require.NoError(t, err)
+
+	march := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	create := func(req cashflow.CreateCashFlowRequest) {
+		_, err := cfService.Create(ctx, req)
+		require.NoError(t, err)
+	}
+	create(cashflow.CreateCashFlowRequest{Date: march.AddDate(0, 0, 4), CategoryID: salary.ID, Direction: "IN", Title: "Salário", Amount: money.MustParse("5000.00")})
+	create(cashflow.CreateCashFlowRequest{
+		Date: march.AddDate(0, 0, 9), CategoryID: market.ID, Direction: "OUT", Title: "Supermercado", Amount: money.MustParse("700.00"),
+		PaymentMethodID: &card.ID, AffectsCardInvoice: true,
+	})
+	create(cashflow.CreateCashFlowRequest{Date: march.AddDate(0, 0, 19), CategoryID: leisure.ID, Direction: "OUT", Title: "Cinema", Amount: money.MustParse("300.00"), Status: cashflow.StatusPlanned})
+
+	_, err = budService.SetBudgetItem(ctx, march, market.ID, budget.ModeAbsolute, money.MustParse("600.00"), 0)
+	require.NoError(t, err)
+
+	ana, err := picService.CreatePerson(ctx, "Ana", "")
+	require.NoError(t, err)
+	_, err = picService.CreatePerson(ctx, "Bruno", "")
+	require.NoError(t, err)
+	_, err = picService.CreateCase(ctx, picuinha.CreateCaseRequest{
+		PersonID: ana.ID, Title: "Ingresso", CaseType: picuinha.CaseTypeOneOff,
+		TotalAmount: money.MustParse("250.00"), StartDate: time.Date(2024, 1, 10, 0, 0, 0, 0, time.UTC),
+	})
+	require.NoError(t, err)
+
+	t.Run("Statement gathers every section", func(t *testing.T) {
+		st, err := statementService.GetMonthlyStatement(ctx, march.AddDate(0, 0, 14))
+		require.NoError(t, err)
+
+		assert.Equal(t, march, st.Month)
+		// The planned flow is listed but left out of the totals.
+		assert.Equal(t, "5000.00", st.Summary.TotalIncome.String())
+		assert.Equal(t, "700.00", st.Summary.TotalExpense.String())
+		assert.Len(t, st.Categories, 2)
+		require.Len(t, st.CashFlows, 3)
+
+		require.Len(t, st.Budget.Items, 1)
+		assert.Equal(t, "600.00", st.Budget.Items[0].PlannedAmount.String())
+		assert.Equal(t, "700.00", st.Budget.Items[0].ActualAmount.String())
+
+		// Inter has nothing in March.
+		require.Len(t, st.Invoices, 1)
+		assert.Equal(t, "Nubank", st.Invoices[0].CardName)
+		assert.Equal(t, "700.00", st.Invoices[0].Invoice.Total.String())
+
+		// Bruno owes nothing.
+		require.Len(t, st.Picuinhas, 1)
+		assert.Equal(t, "Ana", st.Picuinhas[0].Name)
+		assert.Equal(t, "250.00", st.Picuinhas[0].Balance.String())
+	})
+
+	t.Run("PDF download", func(t *testing.T) {
+		rec := client.Request(t, std_http.MethodGet, "/reports/monthly/2024-03-01.pdf", nil)
+		require.Equal(t, std_http.StatusOK, rec.Code, rec.Body.String())
+		assert.Equal(t, "application/pdf", rec.Header().Get("Content-Type"))
+		assert.Contains(t, rec.Header().Get("Content-Disposition"), "extrato-2024-03.pdf")
+
+		body := rec.Body.Bytes()
+		assert.True(t, bytes.HasPrefix(body, []byte("%PDF-1.4")))
+		assert.True(t, bytes.HasSuffix(body, []byte("%%EOF\n")))
+	})
+
+	t.Run("Invalid month or format", func(t *testing.T) {
+		rec := client.Request(t, std_http.MethodGet, "/reports/monthly/2024-03-01.csv", nil)
+		assert.Equal(t, std_http.StatusBadRequest, rec.Code)
+
+		rec = client.Request(t, std_http.MethodGet, "/reports/monthly/2024-13-01.pdf", nil)
+		assert.Equal(t, std_http.StatusBadRequest, rec.Code)
+	})
+}
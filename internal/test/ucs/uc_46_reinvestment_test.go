@@ -0,0 +1,146 @@
+package ucs
+
+import (
+	"context"
+	"encoding/json"
+	std_http "net/http"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/adapters/http"
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/adapters/http/dto"
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/adapters/postgres"
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/domain/cashflow"
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/domain/category"
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/domain/report"
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/money"
+	"github.com/LucasSiedschlag/HausHaltsMeister/internal/test/harness"
+)
+
+func TestUC46_Reinvestment(t *testing.T) {
+	db := harness.SetupTestDB(t)
+	defer db.Pool.Close()
+
+	catRepo := postgres.NewCategoryRepository(db.Pool)
+	cfService := cashflow.NewService(postgres.NewCashFlowRepository(db.Pool), catRepo)
+	reportService := report.NewService(postgres.NewReportRepository(db.Pool), catRepo, postgres.NewRecurrenceRepository(db.Pool))
+
+	e := echo.New()
+	http.RegisterCategoryRoutes(e, http.NewCategoryHandler(category.NewService(catRepo)))
+	http.RegisterReportRoutes(e, http.NewReportHandler(reportService))
+	client := harness.NewHTTPClient(e)
+
+	ctx := context.Background()
+	seeded, err := catRepo.List(ctx, false)
+	require.NoError(t, err)
+	byName := map[string]*category.Category{}
+	for _, c := range seeded {
+		byName[c.Name] = c
+	}
+	yields, contributions, transfer := byName["Investimento"], byName["Investimentos"], byName["Transferência enviada"]
+	require.NotNil(t, yields)
+	require.NotNil(t, contributions)
+	require.NotNil(t, transfer)
+
+	t.Run("Seeded investment categories", func(t *testing.T) {
+		assert.Equal(t, category.RoleInvestment, yields.Role)
+		assert.Equal(t, category.RoleInvestment, contributions.Role)
+	})
+
+	t.Run("Role on create and update", func(t *testing.T) {
+		rec := client.Request(t, std_http.MethodPost, "/categories", map[string]interface{}{
+			"name": "Dividendos", "direction": "IN", "is_budget_relevant": false, "role": "INVESTMENT",
+		})
+		require.Equal(t, std_http.StatusCreated, rec.Code, rec.Body.String())
+		var created dto.CategoryResponse
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &created))
+		assert.Equal(t, "INVESTMENT", created.Role)
+
+		rec = client.Request(t, std_http.MethodPost, "/categories", map[string]interface{}{
+			"name": "Bônus", "direction": "IN", "is_budget_relevant": true,
+		})
+		require.Equal(t, std_http.StatusCreated, rec.Code)
+		assert.Contains(t, rec.Body.String(), `"role":"REGULAR"`)
+
+		rec = client.Request(t, std_http.MethodPost, "/categories", map[string]interface{}{
+			"name": "Outra", "direction": "OUT", "role": "TRANSFER",
+		})
+		assert.Equal(t, std_http.StatusBadRequest, rec.Code)
+		assert.Contains(t, rec.Body.String(), "invalid role")
+
+		// Without a role the update keeps the current one.
+		path := "/categories/" + strconv.Itoa(int(created.ID))
+		rec = client.Request(t, std_http.MethodPut, path, map[string]interface{}{
+			"name": "Dividendos e JCP", "direction": "IN", "is_budget_relevant": false, "is_active": true,
+		})
+		require.Equal(t, std_http.StatusOK, rec.Code, rec.Body.String())
+		assert.Contains(t, rec.Body.String(), `"role":"INVESTMENT"`)
+
+		rec = client.Request(t, std_http.MethodPut, path, map[string]interface{}{
+			"name": "Dividendos e JCP", "direction": "IN", "is_budget_relevant": false, "is_active": true, "role": "REGULAR",
+		})
+		require.Equal(t, std_http.StatusOK, rec.Code, rec.Body.String())
+		assert.Contains(t, rec.Body.String(), `"role":"REGULAR"`)
+
+		// Transfer categories keep their role.
+		rec = client.Request(t, std_http.MethodPut, "/categories/"+strconv.Itoa(int(transfer.ID)), map[string]interface{}{
+			"name": transfer.Name, "direction": transfer.Direction, "is_budget_relevant": false, "is_active": true, "role": "INVESTMENT",
+		})
+		assert.Equal(t, std_http.StatusBadRequest, rec.Code)
+	})
+
+	salary, _ := catRepo.Create(ctx, &category.Category{Name: "Salário", Direction: "IN", IsActive: true})
+	create := func(date string, cat *category.Category, amount string, status string) {
+		d, _ := time.Parse("2006-01-02", date)
+		_, err := cfService.Create(ctx, cashflow.CreateCashFlowRequest{
+			Date: d, CategoryID: cat.ID, Direction: cat.Direction, Title: cat.Name, Amount: money.MustParse(amount), Status: status,
+		})
+		require.NoError(t, err)
+	}
+	create("2024-01-10", yields, "1000.00", "")
+	create("2024-01-05", salary, "5000.00", "")
+	create("2024-02-10", contributions, "600.00", "")
+	create("2024-03-05", yields, "20.00", "")
+	create("2024-03-10", contributions, "300.00", "")
+	create("2024-03-20", yields, "200.00", cashflow.StatusPlanned)
+
+	t.Run("Monthly and cumulative gap", func(t *testing.T) {
+		rec := client.Request(t, std_http.MethodGet, "/reports/reinvestment?from=2024-02-01&to=2024-03-01", nil)
+		require.Equal(t, std_http.StatusOK, rec.Code, rec.Body.String())
+		var res dto.ReinvestmentResponse
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &res))
+
+		// The January redemption comes in as the opening gap.
+		assert.Equal(t, "1000.00", res.OpeningGap.String())
+		require.Len(t, res.Months, 2)
+
+		feb := res.Months[0]
+		assert.Equal(t, "2024-02-01", feb.Month)
+		assert.True(t, feb.Inflow.IsZero())
+		assert.Equal(t, "600.00", feb.Contributions.String())
+		assert.Equal(t, "-600.00", feb.Gap.String())
+		assert.Equal(t, "400.00", feb.CumulativeGap.String())
+
+		// The planned yield and the salary are left out.
+		mar := res.Months[1]
+		assert.Equal(t, "20.00", mar.Inflow.String())
+		assert.Equal(t, "300.00", mar.Contributions.String())
+		assert.Equal(t, "-280.00", mar.Gap.String())
+		assert.Equal(t, "120.00", mar.CumulativeGap.String())
+
+		assert.Equal(t, "20.00", res.Inflow.String())
+		assert.Equal(t, "900.00", res.Contributions.String())
+		assert.Equal(t, "-880.00", res.Gap.String())
+		assert.Equal(t, "120.00", res.ClosingGap.String())
+	})
+
+	t.Run("Invalid range", func(t *testing.T) {
+		rec := client.Request(t, std_http.MethodGet, "/reports/reinvestment?from=2024-03-01&to=2024-02-01", nil)
+		assert.Equal(t, std_http.StatusBadRequest, rec.Code)
+	})
+}
@@ -0,0 +1,16 @@
+CREATE TABLE cash_flow_revisions (
+  cash_flow_revision_id INT GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+  cash_flow_id int NOT NULL,
+  action varchar(10) NOT NULL CHECK (action IN ('UPDATE', 'DELETE')),
+  date date NOT NULL,
+  category_id int NOT NULL,
+  direction varchar(10) NOT NULL,
+  title varchar(255) NOT NULL,
+  amount decimal(14,2) NOT NULL,
+  is_fixed boolean NOT NULL,
+  revised_at timestamp NOT NULL DEFAULT now()
+);
+
+CREATE INDEX idx_cash_flow_revisions_cash_flow_id ON cash_flow_revisions (cash_flow_id);
+
+COMMENT ON TABLE cash_flow_revisions IS 'Valores originais de um lançamento antes de cada alteração ou exclusão. Sem FK para preservar o histórico de lançamentos excluídos.';
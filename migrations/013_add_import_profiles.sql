@@ -0,0 +1,15 @@
+CREATE TABLE import_profiles (
+  import_profile_id INT GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+  name varchar(100) NOT NULL UNIQUE,
+  delimiter varchar(1) NOT NULL DEFAULT ';',
+  has_header boolean NOT NULL DEFAULT true,
+  date_column int NOT NULL CHECK (date_column >= 0),
+  date_format varchar(20) NOT NULL DEFAULT 'DD/MM/YYYY',
+  title_column int NOT NULL CHECK (title_column >= 0),
+  amount_column int NOT NULL CHECK (amount_column >= 0),
+  decimal_comma boolean NOT NULL DEFAULT true,
+  sign_convention varchar(20) NOT NULL DEFAULT 'NEGATIVE_IS_OUT' CHECK (sign_convention IN ('NEGATIVE_IS_OUT', 'POSITIVE_IS_OUT')),
+  created_at timestamp NOT NULL DEFAULT now()
+);
+
+COMMENT ON TABLE import_profiles IS 'Mapeamento de colunas de extratos CSV por banco. Colunas são índices a partir de 0.';
@@ -0,0 +1,8 @@
+ALTER TABLE cash_flows
+  ADD COLUMN fitid varchar(255),
+  ADD COLUMN external_account varchar(100);
+
+CREATE UNIQUE INDEX idx_cash_flows_fitid ON cash_flows (COALESCE(external_account, ''), fitid) WHERE fitid IS NOT NULL;
+
+COMMENT ON COLUMN cash_flows.fitid IS 'Identificador da transação no arquivo OFX (FITID). Evita duplicar lançamentos ao reimportar extratos.';
+COMMENT ON COLUMN cash_flows.external_account IS 'Conta de origem no OFX (ACCTID). O FITID só é único dentro da conta.';
@@ -0,0 +1,32 @@
+CREATE TABLE recurrence_rules (
+  recurrence_rule_id INT GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+  title varchar(255) NOT NULL,
+  category_id int NOT NULL REFERENCES flow_categories (category_id),
+  direction varchar(10) NOT NULL CHECK (direction IN ('IN', 'OUT')),
+  amount decimal(14,2) NOT NULL CHECK (amount > 0),
+  is_fixed boolean NOT NULL DEFAULT true,
+  payment_method_id int REFERENCES payment_methods (payment_method_id),
+  frequency varchar(10) NOT NULL CHECK (frequency IN ('WEEKLY', 'MONTHLY', 'YEARLY')),
+  interval_count int NOT NULL DEFAULT 1 CHECK (interval_count >= 1),
+  by_month_day int CHECK (by_month_day = -1 OR by_month_day BETWEEN 1 AND 31),
+  business_day_adjust varchar(10) NOT NULL DEFAULT 'NONE' CHECK (business_day_adjust IN ('NONE', 'PREVIOUS', 'NEXT')),
+  start_date date NOT NULL,
+  end_date date,
+  is_active boolean NOT NULL DEFAULT true,
+  created_at timestamp NOT NULL DEFAULT now(),
+  CHECK (end_date IS NULL OR end_date >= start_date)
+);
+
+CREATE TABLE recurrence_occurrences (
+  recurrence_rule_id int NOT NULL REFERENCES recurrence_rules (recurrence_rule_id) ON DELETE CASCADE,
+  occurrence_date date NOT NULL,
+  cash_flow_id int REFERENCES cash_flows (cash_flow_id) ON DELETE SET NULL,
+  created_at timestamp NOT NULL DEFAULT now(),
+  PRIMARY KEY (recurrence_rule_id, occurrence_date)
+);
+
+CREATE INDEX idx_recurrence_occurrences_cash_flow_id ON recurrence_occurrences (cash_flow_id);
+
+COMMENT ON TABLE recurrence_rules IS 'Lançamento modelo + regra de repetição (semanal, mensal, anual). by_month_day = -1 significa último dia do mês.';
+
+COMMENT ON TABLE recurrence_occurrences IS 'Ocorrências já geradas por regra. occurrence_date é a data nominal (antes do ajuste de dia útil); garante que a geração seja idempotente. cash_flow_id fica nulo se o lançamento for excluído, e a ocorrência não é gerada de novo.';
@@ -0,0 +1,6 @@
+ALTER TABLE cash_flows
+  ADD COLUMN source_cash_flow_id int REFERENCES cash_flows (cash_flow_id) ON DELETE SET NULL;
+
+CREATE UNIQUE INDEX idx_cash_flows_source_date ON cash_flows (source_cash_flow_id, date) WHERE source_cash_flow_id IS NOT NULL;
+
+COMMENT ON COLUMN cash_flows.source_cash_flow_id IS 'Lançamento fixo de onde este foi copiado (copy-fixed). Evita copiar o mesmo gasto duas vezes para o mês.';
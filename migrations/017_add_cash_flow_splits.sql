@@ -0,0 +1,23 @@
+CREATE TABLE cash_flow_splits (
+  cash_flow_split_id INT GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+  cash_flow_id int NOT NULL REFERENCES cash_flows (cash_flow_id) ON DELETE CASCADE,
+  category_id int NOT NULL REFERENCES flow_categories (category_id),
+  amount decimal(14,2) NOT NULL CHECK (amount > 0)
+);
+
+CREATE INDEX idx_cash_flow_splits_cash_flow_id ON cash_flow_splits (cash_flow_id);
+
+COMMENT ON TABLE cash_flow_splits IS 'Divisão de um lançamento entre várias categorias (ex.: compra de mercado com itens de Custos Fixos e Prazeres). A soma das linhas é igual ao amount do lançamento.';
+
+-- Uma linha por divisão; lançamentos sem divisão aparecem como uma linha só, com a própria categoria.
+-- Relatórios e orçamento agregam por esta view.
+CREATE VIEW cash_flow_lines AS
+SELECT cf.cash_flow_id, NULL::int AS cash_flow_split_id, cf.date, cf.direction, cf.category_id, cf.amount
+FROM cash_flows cf
+WHERE NOT EXISTS (
+  SELECT 1 FROM cash_flow_splits s WHERE s.cash_flow_id = cf.cash_flow_id
+)
+UNION ALL
+SELECT cf.cash_flow_id, s.cash_flow_split_id, cf.date, cf.direction, s.category_id, s.amount
+FROM cash_flows cf
+JOIN cash_flow_splits s ON s.cash_flow_id = cf.cash_flow_id;
@@ -0,0 +1,15 @@
+CREATE TABLE tags (
+  tag_id INT GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+  name varchar(50) NOT NULL UNIQUE,
+  created_at timestamp NOT NULL DEFAULT now()
+);
+
+CREATE TABLE cash_flow_tags (
+  cash_flow_id int NOT NULL REFERENCES cash_flows (cash_flow_id) ON DELETE CASCADE,
+  tag_id int NOT NULL REFERENCES tags (tag_id) ON DELETE CASCADE,
+  PRIMARY KEY (cash_flow_id, tag_id)
+);
+
+CREATE INDEX idx_cash_flow_tags_tag_id ON cash_flow_tags (tag_id);
+
+COMMENT ON TABLE tags IS 'Marcadores livres que atravessam categorias (ex.: viagem-floripa-2026, reforma-cozinha). Nome sempre em minúsculas, sem espaços.';
@@ -0,0 +1,17 @@
+CREATE TABLE attachments (
+  attachment_id INT GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+  cash_flow_id int REFERENCES cash_flows (cash_flow_id) ON DELETE CASCADE,
+  installment_plan_id int REFERENCES installment_plans (installment_plan_id) ON DELETE CASCADE,
+  file_name varchar(255) NOT NULL,
+  content_type varchar(100) NOT NULL,
+  size_bytes bigint NOT NULL CHECK (size_bytes > 0),
+  sha256 char(64) NOT NULL,
+  created_at timestamp NOT NULL DEFAULT now(),
+  CHECK (num_nonnulls(cash_flow_id, installment_plan_id) = 1)
+);
+
+CREATE UNIQUE INDEX idx_attachments_cash_flow_sha256 ON attachments (cash_flow_id, sha256) WHERE cash_flow_id IS NOT NULL;
+CREATE UNIQUE INDEX idx_attachments_installment_plan_sha256 ON attachments (installment_plan_id, sha256) WHERE installment_plan_id IS NOT NULL;
+CREATE INDEX idx_attachments_sha256 ON attachments (sha256);
+
+COMMENT ON TABLE attachments IS 'Comprovantes e documentos de um lançamento ou de um parcelamento/picuinha. O conteúdo fica no blob store, endereçado pelo sha256.';
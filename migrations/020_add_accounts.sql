@@ -0,0 +1,20 @@
+CREATE TABLE accounts (
+  account_id INT GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+  name varchar(100) NOT NULL UNIQUE,
+  kind varchar(30) NOT NULL CHECK (kind IN ('CHECKING', 'SAVINGS', 'WALLET', 'BROKERAGE')),
+  institution varchar(100),
+  opening_balance decimal(14,2) NOT NULL DEFAULT 0,
+  opening_date date NOT NULL,
+  is_active boolean NOT NULL DEFAULT true,
+  created_at timestamp NOT NULL DEFAULT now()
+);
+
+ALTER TABLE cash_flows
+  ADD COLUMN account_id int REFERENCES accounts (account_id);
+
+CREATE INDEX idx_cash_flows_account_id_date ON cash_flows (account_id, date) WHERE account_id IS NOT NULL;
+
+ALTER TABLE payment_methods
+  ADD COLUMN account_id int REFERENCES accounts (account_id);
+
+COMMENT ON TABLE accounts IS 'Onde o dinheiro está: conta corrente, poupança, carteira ou saldo em corretora. O saldo inicial vale a partir de opening_date; lançamentos anteriores já estão nele.';
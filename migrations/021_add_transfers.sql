@@ -0,0 +1,36 @@
+ALTER TABLE flow_categories
+  ADD COLUMN role varchar(20) NOT NULL DEFAULT 'REGULAR',
+  ADD CONSTRAINT flow_categories_role_check CHECK (role IN ('REGULAR', 'TRANSFER'));
+
+INSERT INTO flow_categories (name, direction, is_budget_relevant, is_active, role) VALUES
+('Transferência enviada', 'OUT', false, true, 'TRANSFER'),
+('Transferência recebida', 'IN', false, true, 'TRANSFER');
+
+CREATE TABLE transfers (
+  transfer_id INT GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+  from_account_id int NOT NULL REFERENCES accounts (account_id),
+  to_account_id int NOT NULL REFERENCES accounts (account_id),
+  out_cash_flow_id int NOT NULL UNIQUE REFERENCES cash_flows (cash_flow_id),
+  in_cash_flow_id int NOT NULL UNIQUE REFERENCES cash_flows (cash_flow_id),
+  created_at timestamp NOT NULL DEFAULT now(),
+  CHECK (from_account_id <> to_account_id)
+);
+
+COMMENT ON TABLE transfers IS 'Movimentação entre contas próprias: uma saída na conta de origem e uma entrada na de destino, criadas juntas. Não conta como receita nem despesa, só nos saldos das contas.';
+COMMENT ON COLUMN flow_categories.role IS 'Papel da categoria nos relatórios. TRANSFER fica fora de receitas, despesas e orçamento.';
+
+-- Categorias de transferência ficam fora dos relatórios e do orçamento.
+CREATE OR REPLACE VIEW cash_flow_lines AS
+SELECT cf.cash_flow_id, NULL::int AS cash_flow_split_id, cf.date, cf.direction, cf.category_id, cf.amount
+FROM cash_flows cf
+JOIN flow_categories fc ON fc.category_id = cf.category_id
+WHERE fc.role <> 'TRANSFER'
+  AND NOT EXISTS (
+    SELECT 1 FROM cash_flow_splits s WHERE s.cash_flow_id = cf.cash_flow_id
+  )
+UNION ALL
+SELECT cf.cash_flow_id, s.cash_flow_split_id, cf.date, cf.direction, s.category_id, s.amount
+FROM cash_flows cf
+JOIN cash_flow_splits s ON s.cash_flow_id = cf.cash_flow_id
+JOIN flow_categories fc ON fc.category_id = s.category_id
+WHERE fc.role <> 'TRANSFER';
@@ -0,0 +1,24 @@
+CREATE TABLE reconciliations (
+  reconciliation_id INT GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+  account_id int REFERENCES accounts (account_id),
+  payment_method_id int REFERENCES payment_methods (payment_method_id),
+  statement_date date NOT NULL,
+  statement_balance decimal(14,2) NOT NULL,
+  status varchar(20) NOT NULL DEFAULT 'OPEN' CHECK (status IN ('OPEN', 'COMPLETED')),
+  cleared_balance decimal(14,2),
+  created_at timestamp NOT NULL DEFAULT now(),
+  completed_at timestamp,
+  CHECK (num_nonnulls(account_id, payment_method_id) = 1)
+);
+
+-- Uma conciliação aberta por conta ou cartão.
+CREATE UNIQUE INDEX uq_reconciliations_open_account ON reconciliations (account_id) WHERE status = 'OPEN' AND account_id IS NOT NULL;
+CREATE UNIQUE INDEX uq_reconciliations_open_payment_method ON reconciliations (payment_method_id) WHERE status = 'OPEN' AND payment_method_id IS NOT NULL;
+
+ALTER TABLE cash_flows
+  ADD COLUMN cleared_at timestamp,
+  ADD COLUMN reconciliation_id int REFERENCES reconciliations (reconciliation_id);
+
+CREATE INDEX idx_cash_flows_reconciliation_id ON cash_flows (reconciliation_id) WHERE reconciliation_id IS NOT NULL;
+
+COMMENT ON TABLE reconciliations IS 'Conferência de uma conta ou cartão contra o extrato do banco. Ao concluir, os lançamentos conferidos (cleared_at) recebem o reconciliation_id e ficam travados.';
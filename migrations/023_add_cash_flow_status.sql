@@ -0,0 +1,39 @@
+ALTER TABLE cash_flows
+  ADD COLUMN status varchar(20) NOT NULL DEFAULT 'REALIZED',
+  ADD CONSTRAINT cash_flows_status_check CHECK (status IN ('PLANNED', 'REALIZED', 'CANCELLED'));
+
+-- is_future só era gravado pelas parcelas, sempre como true. Ficam previstas as de meses futuros.
+UPDATE cash_flows cf
+SET status = 'PLANNED'
+FROM expense_details ed
+WHERE ed.cash_flow_id = cf.cash_flow_id
+  AND ed.is_future
+  AND date_trunc('month', cf.date) > date_trunc('month', current_date);
+
+-- O estado passa a ficar no lançamento, que vale também para entradas (sem expense_details).
+-- is_future continua espelhando status = 'PLANNED' para quem ainda lê a coluna.
+UPDATE expense_details ed
+SET is_future = (cf.status = 'PLANNED')
+FROM cash_flows cf
+WHERE cf.cash_flow_id = ed.cash_flow_id;
+
+COMMENT ON COLUMN cash_flows.status IS 'PLANNED: previsto, fora dos totais realizados e dos saldos até ser confirmado. CANCELLED: previsto que não aconteceu, fora de tudo.';
+COMMENT ON COLUMN expense_details.is_future IS 'Espelho de cash_flows.status = ''PLANNED'', mantido pelas consultas que criam, confirmam e cancelam lançamentos.';
+
+-- Lançamentos cancelados saem dos relatórios; previstos ficam, filtrados pelo status.
+CREATE OR REPLACE VIEW cash_flow_lines AS
+SELECT cf.cash_flow_id, NULL::int AS cash_flow_split_id, cf.date, cf.direction, cf.category_id, cf.amount, cf.status
+FROM cash_flows cf
+JOIN flow_categories fc ON fc.category_id = cf.category_id
+WHERE fc.role <> 'TRANSFER'
+  AND cf.status <> 'CANCELLED'
+  AND NOT EXISTS (
+    SELECT 1 FROM cash_flow_splits s WHERE s.cash_flow_id = cf.cash_flow_id
+  )
+UNION ALL
+SELECT cf.cash_flow_id, s.cash_flow_split_id, cf.date, cf.direction, s.category_id, s.amount, cf.status
+FROM cash_flows cf
+JOIN cash_flow_splits s ON s.cash_flow_id = cf.cash_flow_id
+JOIN flow_categories fc ON fc.category_id = s.category_id
+WHERE fc.role <> 'TRANSFER'
+  AND cf.status <> 'CANCELLED';
@@ -0,0 +1,37 @@
+CREATE TABLE exchange_rates (
+  exchange_rate_id INT GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+  currency varchar(3) NOT NULL CHECK (currency ~ '^[A-Z]{3}$'),
+  rate_date date NOT NULL,
+  rate decimal(18,8) NOT NULL CHECK (rate > 0),
+  created_at timestamp NOT NULL DEFAULT now(),
+  UNIQUE (currency, rate_date)
+);
+
+COMMENT ON TABLE exchange_rates IS 'Cotação de uma moeda na moeda base (quanto vale 1 unidade). Vale a partir de rate_date até a próxima cotação.';
+
+-- amount continua na moeda base, já convertido (com o IOF), e é o que entra em resumos, orçamentos e faturas.
+-- Os campos novos guardam a origem dos valores em outra moeda; ficam nulos na moeda base.
+ALTER TABLE cash_flows
+  ADD COLUMN currency varchar(3),
+  ADD COLUMN original_amount decimal(14,2),
+  ADD COLUMN exchange_rate decimal(18,8),
+  ADD COLUMN iof_amount decimal(14,2),
+  ADD CONSTRAINT cash_flows_conversion_check CHECK (num_nonnulls(currency, original_amount, exchange_rate, iof_amount) IN (0, 4));
+
+ALTER TABLE installment_plans
+  ADD COLUMN currency varchar(3),
+  ADD COLUMN original_amount decimal(14,2),
+  ADD COLUMN exchange_rate decimal(18,8),
+  ADD COLUMN iof_amount decimal(14,2),
+  ADD CONSTRAINT installment_plans_conversion_check CHECK (num_nonnulls(currency, original_amount, exchange_rate, iof_amount) IN (0, 4));
+
+-- As revisões guardam a conversão junto com os valores anteriores.
+ALTER TABLE cash_flow_revisions
+  ADD COLUMN currency varchar(3),
+  ADD COLUMN original_amount decimal(14,2),
+  ADD COLUMN exchange_rate decimal(18,8),
+  ADD COLUMN iof_amount decimal(14,2),
+  ADD CONSTRAINT cash_flow_revisions_conversion_check CHECK (num_nonnulls(currency, original_amount, exchange_rate, iof_amount) IN (0, 4));
+
+COMMENT ON COLUMN cash_flows.currency IS 'Moeda em que o lançamento foi feito, quando não é a moeda base. amount já está convertido.';
+COMMENT ON COLUMN cash_flows.iof_amount IS 'IOF incluído em amount, cobrado em compras no cartão em moeda estrangeira.';
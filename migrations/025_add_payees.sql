@@ -0,0 +1,25 @@
+CREATE TABLE payees (
+  payee_id INT GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+  name varchar(100) NOT NULL,
+  normalized_name varchar(100) NOT NULL UNIQUE,
+  default_category_id int REFERENCES flow_categories (category_id) ON DELETE SET NULL,
+  default_payment_method_id int REFERENCES payment_methods (payment_method_id) ON DELETE SET NULL,
+  created_at timestamp NOT NULL DEFAULT now()
+);
+
+CREATE TABLE payee_aliases (
+  payee_alias_id INT GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+  payee_id int NOT NULL REFERENCES payees (payee_id) ON DELETE CASCADE,
+  alias varchar(100) NOT NULL,
+  normalized_alias varchar(100) NOT NULL UNIQUE
+);
+
+CREATE INDEX idx_payee_aliases_payee_id ON payee_aliases (payee_id);
+
+ALTER TABLE cash_flows
+  ADD COLUMN payee_id int REFERENCES payees (payee_id) ON DELETE SET NULL;
+
+CREATE INDEX idx_cash_flows_payee_id_date ON cash_flows (payee_id, date) WHERE payee_id IS NOT NULL;
+
+COMMENT ON TABLE payees IS 'Favorecido/estabelecimento: quem recebeu ou pagou, com categoria e meio de pagamento padrão.';
+COMMENT ON TABLE payee_aliases IS 'Outras grafias do favorecido no título dos lançamentos (ex.: UBER *TRIP). normalized_alias: minúsculas, só letras, dígitos e espaços simples.';
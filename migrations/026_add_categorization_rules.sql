@@ -0,0 +1,28 @@
+CREATE TABLE categorization_rules (
+  categorization_rule_id INT GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+  name varchar(100) NOT NULL,
+  priority int NOT NULL DEFAULT 0,
+  is_active boolean NOT NULL DEFAULT true,
+  title_pattern varchar(255),
+  direction varchar(10) CHECK (direction IN ('IN', 'OUT')),
+  min_amount decimal(14,2) CHECK (min_amount > 0),
+  max_amount decimal(14,2) CHECK (max_amount > 0),
+  payment_method_id int REFERENCES payment_methods (payment_method_id) ON DELETE CASCADE,
+  payee_id int REFERENCES payees (payee_id) ON DELETE CASCADE,
+  set_category_id int REFERENCES flow_categories (category_id) ON DELETE SET NULL,
+  set_is_fixed boolean,
+  set_payee_id int REFERENCES payees (payee_id) ON DELETE SET NULL,
+  created_at timestamp NOT NULL DEFAULT now(),
+  CHECK (min_amount IS NULL OR max_amount IS NULL OR min_amount <= max_amount)
+);
+
+CREATE TABLE categorization_rule_tags (
+  categorization_rule_id int NOT NULL REFERENCES categorization_rules (categorization_rule_id) ON DELETE CASCADE,
+  tag_id int NOT NULL REFERENCES tags (tag_id) ON DELETE CASCADE,
+  PRIMARY KEY (categorization_rule_id, tag_id)
+);
+
+CREATE INDEX idx_categorization_rule_tags_tag_id ON categorization_rule_tags (tag_id);
+
+COMMENT ON TABLE categorization_rules IS 'Regras de categorização automática. Condições (title_pattern é regex sem diferenciar maiúsculas, direction, faixa de valor, meio de pagamento, favorecido) precisam valer todas; ações set_* e tags são aplicadas ao criar lançamentos. Regras rodam por priority crescente; em cada campo vale a primeira regra que o define.';
+COMMENT ON TABLE categorization_rule_tags IS 'Tags adicionadas pela regra aos lançamentos.';
@@ -0,0 +1,11 @@
+ALTER TABLE flow_categories
+  DROP CONSTRAINT flow_categories_role_check,
+  ADD CONSTRAINT flow_categories_role_check CHECK (role IN ('REGULAR', 'TRANSFER', 'INVESTMENT'));
+
+-- As categorias de investimento do seed passam a alimentar o reinvestimento.
+UPDATE flow_categories
+SET role = 'INVESTMENT'
+WHERE role = 'REGULAR'
+  AND (name, direction) IN (('Investimento', 'IN'), ('Investimentos', 'OUT'));
+
+COMMENT ON COLUMN flow_categories.role IS 'Papel da categoria nos relatórios. TRANSFER fica fora de receitas, despesas e orçamento. INVESTMENT conta normalmente e alimenta o cálculo de reinvestimento: resgates e rendimentos (IN) contra aportes (OUT).';
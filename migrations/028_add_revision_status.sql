@@ -0,0 +1,5 @@
+ALTER TABLE cash_flow_revisions
+  ADD COLUMN status varchar(20) NOT NULL DEFAULT 'REALIZED',
+  ADD CONSTRAINT cash_flow_revisions_status_check CHECK (status IN ('PLANNED', 'REALIZED', 'CANCELLED'));
+
+COMMENT ON COLUMN cash_flow_revisions.status IS 'Status do lançamento antes da alteração. Revisões anteriores a esta coluna ficam como REALIZED.';
@@ -0,0 +1,15 @@
+-- Ocorrências de recorrência geradas para meses futuros ficam previstas, como as parcelas.
+UPDATE cash_flows cf
+SET status = 'PLANNED'
+FROM recurrence_occurrences ro
+WHERE ro.cash_flow_id = cf.cash_flow_id
+  AND cf.status = 'REALIZED'
+  AND cf.reconciliation_id IS NULL
+  AND date_trunc('month', cf.date) > date_trunc('month', current_date);
+
+UPDATE expense_details ed
+SET is_future = true
+FROM recurrence_occurrences ro
+JOIN cash_flows cf ON cf.cash_flow_id = ro.cash_flow_id
+WHERE ed.cash_flow_id = cf.cash_flow_id
+  AND cf.status = 'PLANNED';